@@ -57,7 +57,7 @@ func NewAries(opts *config.Options) (*Aries, error) {
 		return nil, fmt.Errorf("failed to get Framework context: %w", err)
 	}
 
-	commandHandlers, err := controller.GetCommandHandlers(context)
+	commandHandlers, err := controller.GetCommandHandlers(context, controller.WithAutoAccept(opts.AutoAccept))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get command handlers: %w", err)
 	}
@@ -269,7 +269,8 @@ func addAriesHandlers(pkgMap map[string]map[string]func(*command) *result) {
 		}
 
 		commands, err := controller.GetCommandHandlers(ctx, controller.WithMessageHandler(msgHandler),
-			controller.WithDefaultLabel(cOpts.Label), controller.WithNotifier(&jsNotifier{}))
+			controller.WithDefaultLabel(cOpts.Label), controller.WithAutoAccept(cOpts.AutoAccept),
+			controller.WithNotifier(&jsNotifier{}))
 		if err != nil {
 			return newErrResult(c.ID, err.Error())
 		}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
@@ -71,6 +72,9 @@ type protocolService interface {
 
 	// CreateConnection saves the connection record.
 	CreateConnection(*connection.Record, *did.Doc) error
+
+	// Actions returns the received invitations and exchange requests awaiting acceptance.
+	Actions() ([]didexchange.Action, error)
 }
 
 // New return new instance of didexchange client.
@@ -212,6 +216,23 @@ func (c *Client) AcceptExchangeRequest(connectionID, publicDID, label string) er
 	return nil
 }
 
+// Actions returns the received invitations and exchange requests awaiting acceptance, accepted with
+// AcceptInvitation and AcceptExchangeRequest respectively. These are only pending if auto execute isn't setup
+// for this client.
+func (c *Client) Actions() ([]Action, error) {
+	actions, err := c.didexchangeSvc.Actions()
+	if err != nil {
+		return nil, fmt.Errorf("did exchange client - actions: %w", err)
+	}
+
+	result := make([]Action, len(actions))
+	for i, action := range actions {
+		result[i] = Action(action)
+	}
+
+	return result, nil
+}
+
 // CreateImplicitInvitation enables invitee to create and send an exchange request using inviter public DID.
 func (c *Client) CreateImplicitInvitation(inviterLabel, inviterDID string) (string, error) {
 	return c.didexchangeSvc.CreateImplicitInvitation(inviterLabel, inviterDID, "", "")
@@ -226,10 +247,14 @@ func (c *Client) CreateImplicitInvitationWithDID(inviter, invitee *DIDInfo) (str
 	return c.didexchangeSvc.CreateImplicitInvitation(inviter.Label, inviter.DID, invitee.Label, invitee.DID)
 }
 
-// QueryConnections queries connections matching given criteria(parameters).
+// QueryConnections queries connections matching given criteria(parameters), ordered by connection ID.
+// The results are paged by the offset and limit parameters.
 func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) {
-	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 - query all connections from all criteria and
-	//  also results needs to be paged.
+	if request.Offset < 0 || request.Limit < 0 {
+		return nil, errors.New("offset and limit must not be negative")
+	}
+
+	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 - query all connections from all criteria.
 	records, err := c.connectionStore.QueryConnectionRecords()
 	if err != nil {
 		return nil, fmt.Errorf("failed query connections: %w", err)
@@ -253,6 +278,20 @@ func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connectio
 		result = append(result, &Connection{Record: record})
 	}
 
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ConnectionID < result[j].ConnectionID
+	})
+
+	if request.Offset >= len(result) {
+		return nil, nil
+	}
+
+	result = result[request.Offset:]
+
+	if request.Limit > 0 && request.Limit < len(result) {
+		result = result[:request.Limit]
+	}
+
 	return result, nil
 }
 
@@ -719,6 +719,48 @@ func TestClient_RemoveConnection(t *testing.T) {
 	})
 }
 
+func TestClient_Actions(t *testing.T) {
+	t.Run("test success", func(t *testing.T) {
+		expected := []didexchange.Action{{PIID: "thid1", ConnectionID: "id1"}}
+
+		c, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ServiceMap: map[string]interface{}{
+				didexchange.DIDExchange: &mocksvc.MockDIDExchangeSvc{
+					ActionsFunc: func() ([]didexchange.Action, error) {
+						return expected, nil
+					},
+				},
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		actions, err := c.Actions()
+		require.NoError(t, err)
+		require.Equal(t, []Action{Action(expected[0])}, actions)
+	})
+	t.Run("test error", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ServiceMap: map[string]interface{}{
+				didexchange.DIDExchange: &mocksvc.MockDIDExchangeSvc{
+					ActionsFunc: func() ([]didexchange.Action, error) {
+						return nil, errors.New("actions error")
+					},
+				},
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = c.Actions()
+		require.EqualError(t, err, "did exchange client - actions: actions error")
+	})
+}
+
 func TestClient_HandleInvitation(t *testing.T) {
 	ed25519KH, err := mockkms.CreateMockED25519KeyHandle()
 	require.NoError(t, err)
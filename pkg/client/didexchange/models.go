@@ -37,6 +37,12 @@ type QueryConnectionsParams struct {
 
 	// TheirRole is other party's role
 	TheirRole string `json:"their_role,omitempty"`
+
+	// Offset is the number of matching connections skipped, for paging the results
+	Offset int `json:"offset,omitempty"`
+
+	// Limit is the maximum number of connections returned, all matching connections are returned if not set
+	Limit int `json:"limit,omitempty"`
 }
 
 // Connection model
@@ -52,6 +58,9 @@ type Invitation struct {
 	*didexchange.Invitation
 }
 
+// Action model for a received invitation or exchange request awaiting acceptance.
+type Action didexchange.Action
+
 // DIDInfo model for specifying public DID and associated label.
 type DIDInfo struct {
 
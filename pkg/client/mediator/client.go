@@ -43,6 +43,9 @@ type protocolService interface {
 
 	// Config returns the router's configuration.
 	Config() (*mediator.Config, error)
+
+	// KeylistQuery queries the router for a page of the agent's recipient keys.
+	KeylistQuery(limit, offset int) (*mediator.Keylist, error)
 }
 
 // WithTimeout option is for definition timeout value waiting for responses received from the router.
@@ -110,3 +113,13 @@ func (c *Client) GetConfig() (*mediator.Config, error) {
 
 	return conf, nil
 }
+
+// KeylistQuery queries the router for a page of limit recipient keys of the agent, starting at offset.
+func (c *Client) KeylistQuery(limit, offset int) (*mediator.Keylist, error) {
+	keylist, err := c.routeSvc.KeylistQuery(limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("router keylist query : %w", err)
+	}
+
+	return keylist, nil
+}
@@ -166,3 +166,40 @@ func TestClient_GetConfig(t *testing.T) {
 		require.True(t, errors.Is(err, expected))
 	})
 }
+
+func TestClient_KeylistQuery(t *testing.T) {
+	t.Run("returns key list", func(t *testing.T) {
+		expected := &mediator.Keylist{
+			Keys:       []mediator.Key{{RecipientKey: "key1"}},
+			Pagination: &mediator.Pagination{Count: 1, Offset: 10, Remaining: 5},
+		}
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				KeylistQueryFunc: func(limit, offset int) (*mediator.Keylist, error) {
+					require.Equal(t, 1, limit)
+					require.Equal(t, 10, offset)
+
+					return expected, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+		result, err := c.KeylistQuery(1, 10)
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	})
+	t.Run("wraps keylist query error", func(t *testing.T) {
+		expected := errors.New("test")
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				KeylistQueryFunc: func(limit, offset int) (*mediator.Keylist, error) {
+					return nil, expected
+				},
+			},
+		})
+		require.NoError(t, err)
+		_, err = c.KeylistQuery(1, 0)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+}
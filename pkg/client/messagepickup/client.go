@@ -32,6 +32,8 @@ type protocolService interface {
 
 	BatchPickup(connectionID string, size int) (int, error)
 
+	DeliveryRequest(connectionID string, size int) (*messagepickup.Batch, error)
+
 	Noop(connectionID string) error
 }
 
@@ -74,6 +76,17 @@ func (r *Client) BatchPickup(connectionID string, size int) (int, error) {
 	return count, nil
 }
 
+// DeliveryRequest request to have multiple waiting messages sent inside a batch message, the batch is returned
+// instead of dispatching its messages.
+func (r *Client) DeliveryRequest(connectionID string, size int) (*messagepickup.Batch, error) {
+	batch, err := r.messagepickupSvc.DeliveryRequest(connectionID, size)
+	if err != nil {
+		return nil, fmt.Errorf("message pickup client - delivery request: %w", err)
+	}
+
+	return batch, nil
+}
+
 // Noop a message to reestablish a connection when there is no other reason to message the mediator.
 func (r *Client) Noop(connectionID string) error {
 	return r.messagepickupSvc.Noop(connectionID)
@@ -89,6 +89,32 @@ func TestBatchPickup(t *testing.T) {
 	})
 }
 
+func TestDeliveryRequest(t *testing.T) {
+	t.Run("delivery request - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{},
+		})
+		require.NoError(t, err)
+
+		batch, err := client.DeliveryRequest("connID", 1)
+		require.NoError(t, err)
+		require.NotNil(t, batch)
+	})
+
+	t.Run("delivery request - service error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				DeliveryRequestErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.DeliveryRequest("connID", 1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+}
+
 func TestNoop(t *testing.T) {
 	t.Run("noop - success", func(t *testing.T) {
 		client, err := New(&mockprovider.Provider{
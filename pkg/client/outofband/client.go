@@ -16,8 +16,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
@@ -38,6 +40,19 @@ const (
 	InvitationMsgType = outofband.InvitationMsgType
 )
 
+// attachmentMimeType is the media type of the requests attached to invitations.
+const attachmentMimeType = "application/json"
+
+// attachmentMsgTypes are the message types of the requests which can be attached to invitations.
+// nolint:gochecknoglobals
+var attachmentMsgTypes = map[string]bool{
+	issuecredential.ProposeCredentialMsgType: true,
+	issuecredential.OfferCredentialMsgType:   true,
+	issuecredential.RequestCredentialMsgType: true,
+	presentproof.ProposePresentationMsgType:  true,
+	presentproof.RequestPresentationMsgType:  true,
+}
+
 // EventOptions are is a container of options that you can pass to an event's
 // Continue function to customize the reaction to incoming out-of-band messages.
 type EventOptions struct {
@@ -63,10 +78,11 @@ type Event interface {
 type MessageOption func(*message) error
 
 type message struct {
-	Label    string
-	Goal     string
-	GoalCode string
-	Service  []interface{}
+	Label       string
+	Goal        string
+	GoalCode    string
+	Service     []interface{}
+	Attachments []*decorator.Attachment
 }
 
 // OobService defines the outofband service.
@@ -183,6 +199,7 @@ func (c *Client) CreateInvitation(protocols []string, opts ...MessageOption) (*I
 		GoalCode:  msg.GoalCode,
 		Service:   msg.Service,
 		Protocols: protocols,
+		Requests:  msg.Attachments,
 	}
 
 	if len(inv.Service) == 0 {
@@ -194,6 +211,14 @@ func (c *Client) CreateInvitation(protocols []string, opts ...MessageOption) (*I
 		inv.Service = []interface{}{svc}
 	}
 
+	if err := validateServices(inv.Service); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	if err := validateAttachments(inv.Requests); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
 	if len(inv.Protocols) == 0 {
 		// TODO should be injected into client
 		//  https://github.com/hyperledger/aries-framework-go/issues/1691
@@ -307,6 +332,66 @@ func WithServices(svcs ...interface{}) MessageOption {
 	}
 }
 
+// WithAttachments allows you to attach requests to the invitation message, the recipient processes them once the
+// connection is established. The attachments must be JSON issue-credential or present-proof requests.
+func WithAttachments(attachments ...*decorator.Attachment) MessageOption {
+	return func(m *message) error {
+		m.Attachments = attachments
+
+		return nil
+	}
+}
+
+// validateServices checks that every service entry is either a public DID or a service block with recipient keys,
+// so that the recipient can reach the agent.
+func validateServices(svcs []interface{}) error {
+	for i := range svcs {
+		switch svc := svcs[i].(type) {
+		case string:
+			if svc == "" {
+				return errors.New("service entry must be a public DID or a service block")
+			}
+		case *did.Service:
+			if len(svc.RecipientKeys) == 0 {
+				return fmt.Errorf("service block %s must include recipient keys", svc.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateAttachments checks that every attachment is a JSON issue-credential or present-proof request, the
+// requests the recipient's protocol services can act upon once the connection is established.
+func validateAttachments(attachments []*decorator.Attachment) error {
+	for _, a := range attachments {
+		if a == nil {
+			return errors.New("attachment must not be empty")
+		}
+
+		if a.MimeType != attachmentMimeType {
+			return fmt.Errorf("unsupported media type '%s' of attachment %s: '%s' is required",
+				a.MimeType, a.ID, attachmentMimeType)
+		}
+
+		bytes, err := a.Data.Fetch()
+		if err != nil {
+			return fmt.Errorf("invalid attachment %s: %w", a.ID, err)
+		}
+
+		msg, err := service.ParseDIDCommMsgMap(bytes)
+		if err != nil {
+			return fmt.Errorf("invalid attachment %s: %w", a.ID, err)
+		}
+
+		if !attachmentMsgTypes[msg.Type()] {
+			return fmt.Errorf("unsupported message type '%s' of attachment %s", msg.Type(), a.ID)
+		}
+	}
+
+	return nil
+}
+
 // DidDocServiceFunc returns a function that returns a DID doc `service` entry.
 // Used when no service entries are specified when creating messages.
 func didServiceBlockFunc(p Provider) func() (*did.Service, error) {
@@ -19,8 +19,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
@@ -331,6 +333,78 @@ func TestCreateInvitation(t *testing.T) {
 		require.Contains(t, inv.Service, didRef)
 		require.Contains(t, inv.Service, svc)
 	})
+	t.Run("WithServices rejects service blocks without recipient keys", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		_, err = c.CreateInvitation(nil, WithServices(&did.Service{
+			ID:              "svc-1",
+			ServiceEndpoint: "https://example.com",
+		}))
+		require.EqualError(t, err, "failed to create invitation: service block svc-1 must include recipient keys")
+	})
+	t.Run("WithServices rejects empty dids", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		_, err = c.CreateInvitation(nil, WithServices(""))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service entry must be a public DID or a service block")
+	})
+	t.Run("WithAttachments", func(t *testing.T) {
+		c, err := New(withTestProvider())
+		require.NoError(t, err)
+		offer := requestAttachment(issuecredential.OfferCredentialMsgType)
+		proofRequest := requestAttachment(presentproof.RequestPresentationMsgType)
+		inv, err := c.CreateInvitation(nil, WithAttachments(offer, proofRequest))
+		require.NoError(t, err)
+		require.Equal(t, []*decorator.Attachment{offer, proofRequest}, inv.Requests)
+
+		// the attachments are encoded as requests~attach and survive the invitation's encode/decode
+		bytes, err := json.Marshal(inv)
+		require.NoError(t, err)
+
+		raw := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(bytes, &raw))
+		require.Len(t, raw["requests~attach"], 2)
+
+		decoded := &Invitation{}
+		require.NoError(t, json.Unmarshal(bytes, decoded))
+		require.Len(t, decoded.Requests, 2)
+		require.Equal(t, offer.ID, decoded.Requests[0].ID)
+		require.Equal(t, offer.MimeType, decoded.Requests[0].MimeType)
+
+		msgBytes, err := decoded.Requests[0].Data.Fetch()
+		require.NoError(t, err)
+
+		msg, err := service.ParseDIDCommMsgMap(msgBytes)
+		require.NoError(t, err)
+		require.Equal(t, issuecredential.OfferCredentialMsgType, msg.Type())
+	})
+	t.Run("WithAttachments rejects unsupported attachments", func(t *testing.T) {
+		wrongMimeType := requestAttachment(issuecredential.OfferCredentialMsgType)
+		wrongMimeType.MimeType = "text/plain"
+
+		noData := requestAttachment(issuecredential.OfferCredentialMsgType)
+		noData.Data = decorator.AttachmentData{}
+
+		tests := []struct {
+			attachment *decorator.Attachment
+			errorMsg   string
+		}{
+			{attachment: nil, errorMsg: "attachment must not be empty"},
+			{attachment: wrongMimeType, errorMsg: "unsupported media type 'text/plain'"},
+			{attachment: dummyAttachment(t), errorMsg: "unsupported media type"},
+			{attachment: noData, errorMsg: "no contents in this attachment"},
+			{attachment: requestAttachment(issuecredential.IssueCredentialMsgType), errorMsg: "unsupported message type"},
+		}
+
+		for _, test := range tests {
+			c, err := New(withTestProvider())
+			require.NoError(t, err)
+			_, err = c.CreateInvitation(nil, WithAttachments(test.attachment))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.errorMsg)
+		}
+	})
 }
 
 func TestClient_ActionContinue(t *testing.T) {
@@ -505,6 +579,19 @@ func base64Attachment(t *testing.T, data interface{}) *decorator.Attachment {
 	}
 }
 
+func requestAttachment(msgType string) *decorator.Attachment {
+	return &decorator.Attachment{
+		ID:       uuid.New().String(),
+		MimeType: "application/json",
+		Data: decorator.AttachmentData{
+			JSON: map[string]interface{}{
+				"@id":   uuid.New().String(),
+				"@type": msgType,
+			},
+		},
+	}
+}
+
 type didcommMsg struct {
 	ID   string
 	Type string
@@ -7,10 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package presentproof
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
 
 type (
@@ -29,6 +33,7 @@ type (
 var (
 	errEmptyRequestPresentation = errors.New("request presentation message is empty")
 	errEmptyProposePresentation = errors.New("propose presentation message is empty")
+	errNoPresentationDefinition = errors.New("request presentation has no presentation definition")
 )
 
 // Provider contains dependencies for the protocol and is typically created by using aries.Context()
@@ -148,6 +153,41 @@ func (c *Client) AcceptProblemReport(piID string) error {
 	return c.service.ActionContinue(piID, nil)
 }
 
+// MatchRequestPresentation is used by the Prover to select the held credentials satisfying the DIF presentation
+// definition attached to a presentation request (https://identity.foundation/presentation-exchange/), that is
+// an attachment of the form {"presentation_definition": {...}}. It returns the unsigned presentation submission
+// of the selected credentials, to be signed and sent with AcceptRequestPresentation.
+func MatchRequestPresentation(msg *RequestPresentation,
+	credentials []*verifiable.Credential) (*verifiable.Presentation, error) {
+	if msg == nil {
+		return nil, errEmptyRequestPresentation
+	}
+
+	for i := range msg.RequestPresentationsAttach {
+		raw, err := msg.RequestPresentationsAttach[i].Data.Fetch()
+		if err != nil {
+			return nil, fmt.Errorf("fetch request presentation attachment: %w", err)
+		}
+
+		var request struct {
+			PresentationDefinition *presexch.PresentationDefinitions `json:"presentation_definition"`
+		}
+
+		if err = json.Unmarshal(raw, &request); err != nil || request.PresentationDefinition == nil {
+			continue
+		}
+
+		vp, err := presexch.NewPresentation(request.PresentationDefinition, credentials)
+		if err != nil {
+			return nil, fmt.Errorf("match presentation definition: %w", err)
+		}
+
+		return vp, nil
+	}
+
+	return nil, errNoPresentationDefinition
+}
+
 // WithPresentation allows providing Presentation message
 // Use this option to respond to RequestPresentation.
 func WithPresentation(msg *Presentation) presentproof.Opt {
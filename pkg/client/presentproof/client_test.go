@@ -9,13 +9,18 @@ package presentproof
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
 )
 
@@ -246,3 +251,74 @@ func TestClient_NegotiateProposePresentation(t *testing.T) {
 
 	require.NoError(t, client.NegotiateRequestPresentation("PIID", &ProposePresentation{}))
 }
+
+func TestMatchRequestPresentation(t *testing.T) {
+	newVC := func(id string, age float64) *verifiable.Credential {
+		return &verifiable.Credential{
+			ID:      id,
+			Context: []string{"https://www.w3.org/2018/credentials/v1"},
+			Types:   []string{"VerifiableCredential"},
+			Issuer:  verifiable.Issuer{ID: "did:example:issuer"},
+			Issued:  util.NewTime(time.Date(2020, 1, 1, 10, 54, 1, 0, time.UTC)),
+			Subject: map[string]interface{}{"id": "did:example:holder", "age": age},
+		}
+	}
+
+	credentials := []*verifiable.Credential{
+		newVC("http://example.edu/credentials/1", 12),
+		newVC("http://example.edu/credentials/2", 32),
+	}
+
+	definition := map[string]interface{}{
+		"presentation_definition": &presexch.PresentationDefinitions{
+			InputDescriptors: []*presexch.InputDescriptor{{
+				ID: "adult",
+				Constraints: &presexch.Constraints{Fields: []*presexch.Field{{
+					Path:   []string{"$.credentialSubject.age"},
+					Filter: map[string]interface{}{"type": "number", "minimum": 18},
+				}}},
+			}},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		vp, err := MatchRequestPresentation(&RequestPresentation{
+			RequestPresentationsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{JSON: map[string]interface{}{"other": "request"}}},
+				{Data: decorator.AttachmentData{JSON: definition}},
+			},
+		}, credentials)
+		require.NoError(t, err)
+		require.Contains(t, vp.Type, presexch.PresentationSubmissionJSONLDType)
+
+		creds := vp.Credentials()
+		require.Len(t, creds, 1)
+		require.Equal(t, credentials[1], creds[0])
+	})
+
+	t.Run("Empty request", func(t *testing.T) {
+		_, err := MatchRequestPresentation(nil, credentials)
+		require.EqualError(t, err, errEmptyRequestPresentation.Error())
+	})
+
+	t.Run("No presentation definition", func(t *testing.T) {
+		_, err := MatchRequestPresentation(&RequestPresentation{}, credentials)
+		require.EqualError(t, err, errNoPresentationDefinition.Error())
+	})
+
+	t.Run("Fetch attachment error", func(t *testing.T) {
+		_, err := MatchRequestPresentation(&RequestPresentation{
+			RequestPresentationsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{Base64: "!"}}},
+		}, credentials)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "fetch request presentation attachment")
+	})
+
+	t.Run("No credential satisfies the definition", func(t *testing.T) {
+		_, err := MatchRequestPresentation(&RequestPresentation{
+			RequestPresentationsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{JSON: definition}}},
+		}, credentials[:1])
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "match presentation definition: no credential satisfies input descriptor adult")
+	})
+}
@@ -37,6 +37,8 @@ SPDX-License-Identifier: Apache-2.0
 //        }
 //
 //        if event.Message.Type() == presentproof.RequestPresentationMsgType {
+//          // The Prover may select the held credentials satisfying the attached presentation definition.
+//          vp, err := presentproof.MatchRequestPresentation(request, credentials)
 //          // If Prover is willing to accept a request.
 //          client.AcceptRequestPresentation(piid, &Presentation{})
 //          // If Prover wants to counter a request they received with a proposal.
@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Fields is a set of key/value pairs logged along with a message for structured logging.
+type Fields map[string]interface{}
+
+// String formats fields as space separated key=[value] pairs sorted by key.
+func (f Fields) String() string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf("%s=[%v]", k, f[k])
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// FieldLogger logs messages through the underlying logger along with a set of fields.
+type FieldLogger struct {
+	logger Logger
+	fields Fields
+}
+
+// WithFields returns a FieldLogger logging messages through given logger along with given fields.
+func WithFields(logger Logger, fields Fields) *FieldLogger {
+	return &FieldLogger{logger: logger, fields: fields}
+}
+
+// WithFields returns a FieldLogger logging messages of this logger along with given fields.
+func (l *Log) WithFields(fields Fields) *FieldLogger {
+	return WithFields(l, fields)
+}
+
+// WithFields returns a new FieldLogger logging messages along with the fields of this logger and given fields,
+// given fields take precedence.
+func (l *FieldLogger) WithFields(fields Fields) *FieldLogger {
+	merged := make(Fields, len(l.fields)+len(fields))
+
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	return WithFields(l.logger, merged)
+}
+
+// Debugf calls Debugf function of underlying logger with fields appended to the message.
+func (l *FieldLogger) Debugf(msg string, args ...interface{}) {
+	l.logger.Debugf(l.format(msg), args...)
+}
+
+// Infof calls Infof function of underlying logger with fields appended to the message.
+func (l *FieldLogger) Infof(msg string, args ...interface{}) {
+	l.logger.Infof(l.format(msg), args...)
+}
+
+// Warnf calls Warnf function of underlying logger with fields appended to the message.
+func (l *FieldLogger) Warnf(msg string, args ...interface{}) {
+	l.logger.Warnf(l.format(msg), args...)
+}
+
+// Errorf calls Errorf function of underlying logger with fields appended to the message.
+func (l *FieldLogger) Errorf(msg string, args ...interface{}) {
+	l.logger.Errorf(l.format(msg), args...)
+}
+
+// format appends the fields to msg, escaping them as they aren't formatting directives.
+func (l *FieldLogger) format(msg string) string {
+	if len(l.fields) == 0 {
+		return msg
+	}
+
+	return msg + " " + strings.ReplaceAll(l.fields.String(), "%", "%%")
+}
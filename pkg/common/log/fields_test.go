@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package log
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	require.Equal(t, "a=[1] b=[two] c=[true]", Fields{"c": true, "a": 1, "b": "two"}.String())
+	require.Empty(t, Fields{}.String())
+}
+
+func TestWithFields(t *testing.T) {
+	logger := &capturingLogger{}
+
+	fieldLogger := WithFields(logger, Fields{"requestID": "123", "method": "sidetree"})
+
+	fieldLogger.Debugf("debug %s", "msg")
+	fieldLogger.Infof("info %s", "msg")
+	fieldLogger.Warnf("warn %s", "msg")
+	fieldLogger.Errorf("error %s", "msg")
+
+	require.Equal(t, []string{
+		"debug msg method=[sidetree] requestID=[123]",
+		"info msg method=[sidetree] requestID=[123]",
+		"warn msg method=[sidetree] requestID=[123]",
+		"error msg method=[sidetree] requestID=[123]",
+	}, logger.lines)
+
+	t.Run("merge fields", func(t *testing.T) {
+		logger.lines = nil
+
+		fieldLogger.WithFields(Fields{"requestID": "456", "outcome": "100%"}).Infof("done")
+		fieldLogger.Infof("done")
+
+		require.Equal(t, []string{
+			"done method=[sidetree] outcome=[100%] requestID=[456]",
+			"done method=[sidetree] requestID=[123]",
+		}, logger.lines)
+	})
+
+	t.Run("no fields", func(t *testing.T) {
+		logger.lines = nil
+
+		WithFields(logger, nil).Infof("done %d", 1)
+
+		require.Equal(t, []string{"done 1"}, logger.lines)
+	})
+
+	t.Run("module logger", func(t *testing.T) {
+		require.NotNil(t, New("sample-module-fields").WithFields(Fields{"requestID": "123"}))
+	})
+}
+
+type capturingLogger struct {
+	lines []string
+}
+
+func (c *capturingLogger) Fatalf(msg string, args ...interface{}) {
+	c.lines = append(c.lines, fmt.Sprintf(msg, args...))
+}
+
+func (c *capturingLogger) Panicf(msg string, args ...interface{}) {
+	c.lines = append(c.lines, fmt.Sprintf(msg, args...))
+}
+
+func (c *capturingLogger) Debugf(msg string, args ...interface{}) {
+	c.lines = append(c.lines, fmt.Sprintf(msg, args...))
+}
+
+func (c *capturingLogger) Infof(msg string, args ...interface{}) {
+	c.lines = append(c.lines, fmt.Sprintf(msg, args...))
+}
+
+func (c *capturingLogger) Warnf(msg string, args ...interface{}) {
+	c.lines = append(c.lines, fmt.Sprintf(msg, args...))
+}
+
+func (c *capturingLogger) Errorf(msg string, args ...interface{}) {
+	c.lines = append(c.lines, fmt.Sprintf(msg, args...))
+}
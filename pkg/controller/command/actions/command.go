@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/client/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	issuecredentialsvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	presentproofsvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+var logger = log.New("aries-framework/controller/actions")
+
+const (
+	// ActionsErrorCode is for failures in actions command.
+	ActionsErrorCode = command.Code(iota + command.Actions)
+)
+
+// constants for the actions controller command
+const (
+	// command name
+	CommandName = "actions"
+	Actions     = "Actions"
+
+	// log constants
+	successString = "success"
+)
+
+// Provider contains dependencies for the actions controller command and is typically created by using
+// aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+	KMS() kms.KeyManager
+	ServiceEndpoint() string
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Command is controller command listing the protocol actions awaiting user input.
+type Command struct {
+	issueCredential *issuecredential.Client
+	presentProof    *presentproof.Client
+	didExchange     *didexchange.Client
+}
+
+// New returns new actions controller command instance.
+func New(ctx Provider) (*Command, error) {
+	issueCredential, err := issuecredential.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create issue credential client: %w", err)
+	}
+
+	presentProof, err := presentproof.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create present proof client: %w", err)
+	}
+
+	didExchange, err := didexchange.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create did exchange client: %w", err)
+	}
+
+	return &Command{
+		issueCredential: issueCredential,
+		presentProof:    presentProof,
+		didExchange:     didExchange,
+	}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, Actions, c.Actions),
+	}
+}
+
+// Actions returns the pending actions of the issue-credential, present-proof and did-exchange protocols,
+// awaiting to be accepted or declined by the user.
+func (c *Command) Actions(rw io.Writer, _ io.Reader) command.Error {
+	result, err := c.actions()
+	if err != nil {
+		logutil.LogError(logger, CommandName, Actions, err.Error())
+		return command.NewExecuteError(ActionsErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ActionsResponse{
+		Actions: result,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, Actions, successString)
+
+	return nil
+}
+
+func (c *Command) actions() ([]Action, error) {
+	result := []Action{}
+
+	credentialActions, err := c.issueCredential.Actions()
+	if err != nil {
+		return nil, fmt.Errorf("issue credential actions: %w", err)
+	}
+
+	for _, a := range credentialActions {
+		result = append(result, newAction(issuecredentialsvc.Name, a.PIID, a.Msg, a.MyDID, a.TheirDID))
+	}
+
+	proofActions, err := c.presentProof.Actions()
+	if err != nil {
+		return nil, fmt.Errorf("present proof actions: %w", err)
+	}
+
+	for _, a := range proofActions {
+		result = append(result, newAction(presentproofsvc.Name, a.PIID, a.Msg, a.MyDID, a.TheirDID))
+	}
+
+	exchangeActions, err := c.didExchange.Actions()
+	if err != nil {
+		return nil, fmt.Errorf("did exchange actions: %w", err)
+	}
+
+	for _, a := range exchangeActions {
+		action := newAction(didexchange.ProtocolName, a.PIID, a.Msg, a.MyDID, a.TheirDID)
+		action.ConnectionID = a.ConnectionID
+
+		result = append(result, action)
+	}
+
+	return result, nil
+}
+
+func newAction(protocol, piid string, msg service.DIDCommMsgMap, myDID, theirDID string) Action {
+	return Action{
+		Protocol: protocol,
+		PIID:     piid,
+		Type:     msg.Type(),
+		Msg:      msg,
+		MyDID:    myDID,
+		TheirDID: theirDID,
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	issuecredentialmocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/issuecredential"
+	presentproofmocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
+	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
+	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func newProvider(issueCredentialSvc, presentProofSvc, didExchangeSvc interface{}) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ServiceMap: map[string]interface{}{
+			issuecredential.Name:    issueCredentialSvc,
+			presentproof.Name:       presentProofSvc,
+			didexchange.DIDExchange: didExchangeSvc,
+			mediator.Coordination:   &mockroute.MockMediatorSvc{},
+		},
+	}
+}
+
+func msg(msgType string) service.DIDCommMsgMap {
+	return service.DIDCommMsgMap{"@id": "id", "@type": msgType}
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issueCredentialSvc := issuecredentialmocks.NewMockProtocolService(ctrl)
+	presentProofSvc := presentproofmocks.NewMockProtocolService(ctrl)
+
+	t.Run("Success", func(t *testing.T) {
+		cmd, err := New(newProvider(issueCredentialSvc, presentProofSvc, &mockdidexchange.MockDIDExchangeSvc{}))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		require.Len(t, cmd.GetHandlers(), 1)
+	})
+
+	t.Run("Create issue credential client (error)", func(t *testing.T) {
+		cmd, err := New(newProvider(nil, presentProofSvc, &mockdidexchange.MockDIDExchangeSvc{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create issue credential client")
+		require.Nil(t, cmd)
+	})
+
+	t.Run("Create present proof client (error)", func(t *testing.T) {
+		cmd, err := New(newProvider(issueCredentialSvc, nil, &mockdidexchange.MockDIDExchangeSvc{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create present proof client")
+		require.Nil(t, cmd)
+	})
+
+	t.Run("Create did exchange client (error)", func(t *testing.T) {
+		cmd, err := New(newProvider(issueCredentialSvc, presentProofSvc, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create did exchange client")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestCommand_Actions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		issueCredentialSvc := issuecredentialmocks.NewMockProtocolService(ctrl)
+		issueCredentialSvc.EXPECT().Actions().Return([]issuecredential.Action{{
+			PIID:     "ic-1",
+			Msg:      msg(issuecredential.OfferCredentialMsgType),
+			MyDID:    "did:example:mine",
+			TheirDID: "did:example:theirs",
+		}}, nil)
+
+		presentProofSvc := presentproofmocks.NewMockProtocolService(ctrl)
+		presentProofSvc.EXPECT().Actions().Return([]presentproof.Action{
+			{PIID: "pp-1", Msg: msg(presentproof.RequestPresentationMsgType)},
+			{PIID: "pp-2", Msg: msg(presentproof.ProposePresentationMsgType)},
+		}, nil)
+
+		didExchangeSvc := &mockdidexchange.MockDIDExchangeSvc{
+			ActionsFunc: func() ([]didexchange.Action, error) {
+				return []didexchange.Action{{
+					PIID:         "de-1",
+					ConnectionID: "conn-1",
+					Msg:          msg(didexchange.RequestMsgType),
+				}}, nil
+			},
+		}
+
+		cmd, err := New(newProvider(issueCredentialSvc, presentProofSvc, didExchangeSvc))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Actions(&b, nil))
+
+		var res ActionsResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+
+		for i := range res.Actions {
+			require.Equal(t, res.Actions[i].Type, res.Actions[i].Msg.Type())
+			require.Equal(t, "id", res.Actions[i].Msg.ID())
+
+			res.Actions[i].Msg = nil
+		}
+
+		require.Equal(t, []Action{
+			{
+				Protocol: issuecredential.Name,
+				PIID:     "ic-1",
+				Type:     issuecredential.OfferCredentialMsgType,
+				MyDID:    "did:example:mine",
+				TheirDID: "did:example:theirs",
+			},
+			{
+				Protocol: presentproof.Name,
+				PIID:     "pp-1",
+				Type:     presentproof.RequestPresentationMsgType,
+			},
+			{
+				Protocol: presentproof.Name,
+				PIID:     "pp-2",
+				Type:     presentproof.ProposePresentationMsgType,
+			},
+			{
+				Protocol:     didexchange.DIDExchange,
+				PIID:         "de-1",
+				ConnectionID: "conn-1",
+				Type:         didexchange.RequestMsgType,
+			},
+		}, res.Actions)
+	})
+
+	t.Run("No pending actions", func(t *testing.T) {
+		issueCredentialSvc := issuecredentialmocks.NewMockProtocolService(ctrl)
+		issueCredentialSvc.EXPECT().Actions().Return(nil, nil)
+
+		presentProofSvc := presentproofmocks.NewMockProtocolService(ctrl)
+		presentProofSvc.EXPECT().Actions().Return(nil, nil)
+
+		cmd, err := New(newProvider(issueCredentialSvc, presentProofSvc, &mockdidexchange.MockDIDExchangeSvc{}))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Actions(&b, nil))
+		require.JSONEq(t, `{"actions":[]}`, b.String())
+	})
+
+	t.Run("Issue credential actions (error)", func(t *testing.T) {
+		issueCredentialSvc := issuecredentialmocks.NewMockProtocolService(ctrl)
+		issueCredentialSvc.EXPECT().Actions().Return(nil, errors.New("some error message"))
+
+		presentProofSvc := presentproofmocks.NewMockProtocolService(ctrl)
+
+		cmd, err := New(newProvider(issueCredentialSvc, presentProofSvc, &mockdidexchange.MockDIDExchangeSvc{}))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Actions(&b, nil)
+		require.EqualError(t, cmdErr, "issue credential actions: some error message")
+		require.Equal(t, ActionsErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Present proof actions (error)", func(t *testing.T) {
+		issueCredentialSvc := issuecredentialmocks.NewMockProtocolService(ctrl)
+		issueCredentialSvc.EXPECT().Actions().Return(nil, nil)
+
+		presentProofSvc := presentproofmocks.NewMockProtocolService(ctrl)
+		presentProofSvc.EXPECT().Actions().Return(nil, errors.New("some error message"))
+
+		cmd, err := New(newProvider(issueCredentialSvc, presentProofSvc, &mockdidexchange.MockDIDExchangeSvc{}))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Actions(&b, nil)
+		require.EqualError(t, cmdErr, "present proof actions: some error message")
+		require.Equal(t, ActionsErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("DID exchange actions (error)", func(t *testing.T) {
+		issueCredentialSvc := issuecredentialmocks.NewMockProtocolService(ctrl)
+		issueCredentialSvc.EXPECT().Actions().Return(nil, nil)
+
+		presentProofSvc := presentproofmocks.NewMockProtocolService(ctrl)
+		presentProofSvc.EXPECT().Actions().Return(nil, nil)
+
+		didExchangeSvc := &mockdidexchange.MockDIDExchangeSvc{
+			ActionsFunc: func() ([]didexchange.Action, error) {
+				return nil, errors.New("some error message")
+			},
+		}
+
+		cmd, err := New(newProvider(issueCredentialSvc, presentProofSvc, didExchangeSvc))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Actions(&b, nil)
+		require.EqualError(t, cmdErr, "did exchange actions: did exchange client - actions: some error message")
+		require.Equal(t, ActionsErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
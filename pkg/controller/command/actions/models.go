@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+
+// Action model
+//
+// Represents a protocol action awaiting user input
+//
+type Action struct {
+	// Protocol of the action (eg: issue-credential, present-proof or didexchange)
+	Protocol string `json:"protocol"`
+
+	// PIID is the protocol instance ID
+	PIID string `json:"piid"`
+
+	// ConnectionID of the did-exchange actions, the ID to accept the invitation or the exchange request with
+	ConnectionID string `json:"connection_id,omitempty"`
+
+	// Type of the message awaiting user input
+	Type string `json:"type"`
+
+	// Msg awaiting user input
+	Msg service.DIDCommMsgMap `json:"msg"`
+
+	MyDID    string `json:"my_did,omitempty"`
+	TheirDID string `json:"their_did,omitempty"`
+}
+
+// ActionsResponse model
+//
+// Represents Actions response message
+//
+type ActionsResponse struct {
+	Actions []Action `json:"actions"`
+}
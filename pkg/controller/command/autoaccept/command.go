@@ -0,0 +1,162 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package autoaccept
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+var logger = log.New("aries-framework/controller/autoaccept")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.AutoAccept)
+
+	// SetAutoAcceptErrorCode is for failures in set auto-accept command.
+	SetAutoAcceptErrorCode
+
+	// GetAutoAcceptErrorCode is for failures in get auto-accept command.
+	GetAutoAcceptErrorCode
+)
+
+// constants for the auto-accept controller command.
+const (
+	// command name.
+	CommandName = "autoaccept"
+
+	// command methods.
+	SetAutoAcceptCommandMethod = "SetAutoAccept"
+	GetAutoAcceptCommandMethod = "GetAutoAccept"
+
+	// log constants.
+	successString  = "success"
+	protocolString = "protocol"
+
+	errEmptyProtocol = "protocol is mandatory"
+)
+
+// Provider contains dependencies for the auto-accept controller command and is typically created by using
+// aries.Context().
+type Provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Command is controller command configuring the protocols whose actions are accepted without user input.
+type Command struct {
+	policies *Policies
+}
+
+// New returns new auto-accept controller command instance, autoAccept is the policy of did-exchange until
+// configured (the auto-accept option of the agent).
+func New(ctx Provider, autoAccept bool) (*Command, error) {
+	policies, err := NewPolicies(ctx.StorageProvider(), map[string]bool{didexchange.DIDExchange: autoAccept})
+	if err != nil {
+		return nil, fmt.Errorf("create auto-accept policies: %w", err)
+	}
+
+	return &Command{policies: policies}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, SetAutoAcceptCommandMethod, c.SetAutoAccept),
+		cmdutil.NewCommandHandler(CommandName, GetAutoAcceptCommandMethod, c.GetAutoAccept),
+	}
+}
+
+// SetAutoAccept sets the auto-accept policy of a protocol, the actions of the protocol received from then on are
+// accepted, or left pending, accordingly.
+func (c *Command) SetAutoAccept(rw io.Writer, req io.Reader) command.Error {
+	var request Policy
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SetAutoAcceptCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.Protocol == "" {
+		logutil.LogDebug(logger, CommandName, SetAutoAcceptCommandMethod, errEmptyProtocol)
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyProtocol))
+	}
+
+	err = c.policies.Set(request.Protocol, request.AutoAccept)
+	if errors.Is(err, ErrUnknownProtocol) {
+		logutil.LogInfo(logger, CommandName, SetAutoAcceptCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if err != nil {
+		logutil.LogError(logger, CommandName, SetAutoAcceptCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(protocolString, request.Protocol))
+
+		return command.NewExecuteError(SetAutoAcceptErrorCode, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, SetAutoAcceptCommandMethod, successString,
+		logutil.CreateKeyValueString(protocolString, request.Protocol))
+
+	return nil
+}
+
+// GetAutoAccept returns the auto-accept policy of a protocol, or of all the protocols if none is given.
+func (c *Command) GetAutoAccept(rw io.Writer, req io.Reader) command.Error {
+	var request GetAutoAcceptArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetAutoAcceptCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	protocols := Protocols()
+	if request.Protocol != "" {
+		protocols = []string{request.Protocol}
+	}
+
+	response := &GetAutoAcceptResponse{}
+
+	for _, protocol := range protocols {
+		policy, err := c.policies.Get(protocol)
+		if errors.Is(err, ErrUnknownProtocol) {
+			logutil.LogInfo(logger, CommandName, GetAutoAcceptCommandMethod, err.Error())
+
+			return command.NewValidationError(InvalidRequestErrorCode, err)
+		}
+
+		if err != nil {
+			logutil.LogError(logger, CommandName, GetAutoAcceptCommandMethod, err.Error(),
+				logutil.CreateKeyValueString(protocolString, protocol))
+
+			return command.NewExecuteError(GetAutoAcceptErrorCode, err)
+		}
+
+		response.Policies = append(response.Policies, policy)
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, GetAutoAcceptCommandMethod, successString)
+
+	return nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package autoaccept
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func getAutoAccept(t *testing.T, cmd *Command, request string) *GetAutoAcceptResponse {
+	t.Helper()
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.GetAutoAccept(&b, bytes.NewBufferString(request)))
+
+	response := &GetAutoAcceptResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), response))
+
+	return response
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()}, false)
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 2)
+	})
+
+	t.Run("open store (error)", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open failed")},
+		}, false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create auto-accept policies")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestCommand_SetAutoAccept(t *testing.T) {
+	t.Run("set the policies", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()}, true)
+		require.NoError(t, err)
+
+		require.Equal(t, []*Policy{
+			{Protocol: didexchange.DIDExchange, AutoAccept: true},
+			{Protocol: issuecredential.Name},
+			{Protocol: presentproof.Name},
+		}, getAutoAccept(t, cmd, `{}`).Policies)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.SetAutoAccept(&b, bytes.NewBufferString(`{"protocol": "didexchange"}`)))
+		require.NoError(t, cmd.SetAutoAccept(&b, bytes.NewBufferString(
+			`{"protocol": "present-proof", "auto_accept": true}`)))
+		require.Empty(t, b.String())
+
+		require.Equal(t, []*Policy{{Protocol: presentproof.Name, AutoAccept: true}},
+			getAutoAccept(t, cmd, `{"protocol": "present-proof"}`).Policies)
+		require.Equal(t, []*Policy{{Protocol: didexchange.DIDExchange}},
+			getAutoAccept(t, cmd, `{"protocol": "didexchange"}`).Policies)
+	})
+
+	t.Run("validation and failures", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			request   string
+			store     *mockstore.MockStore
+			errorCode command.Code
+			errorMsg  string
+			errType   command.Type
+		}{
+			{
+				name:      "invalid request",
+				request:   `----`,
+				errorCode: InvalidRequestErrorCode,
+				errorMsg:  "invalid character",
+				errType:   command.ValidationError,
+			},
+			{
+				name:      "missing protocol",
+				request:   `{"auto_accept": true}`,
+				errorCode: InvalidRequestErrorCode,
+				errorMsg:  errEmptyProtocol,
+				errType:   command.ValidationError,
+			},
+			{
+				name:      "unknown protocol",
+				request:   `{"protocol": "unknown", "auto_accept": true}`,
+				errorCode: InvalidRequestErrorCode,
+				errorMsg:  ErrUnknownProtocol.Error(),
+				errType:   command.ValidationError,
+			},
+			{
+				name:      "store error",
+				request:   `{"protocol": "present-proof", "auto_accept": true}`,
+				store:     &mockstore.MockStore{Store: map[string][]byte{}, ErrPut: errors.New("put failed")},
+				errorCode: SetAutoAcceptErrorCode,
+				errorMsg:  "put failed",
+				errType:   command.ExecuteError,
+			},
+		}
+
+		for _, test := range tests {
+			tc := test
+			t.Run(tc.name, func(t *testing.T) {
+				prov := mockstore.NewMockStoreProvider()
+				if tc.store != nil {
+					prov = mockstore.NewCustomMockStoreProvider(tc.store)
+				}
+
+				cmd, err := New(&mockprovider.Provider{StorageProviderValue: prov}, false)
+				require.NoError(t, err)
+
+				cmdErr := cmd.SetAutoAccept(&bytes.Buffer{}, bytes.NewBufferString(tc.request))
+				require.Error(t, cmdErr)
+				require.Equal(t, tc.errType, cmdErr.Type())
+				require.Equal(t, tc.errorCode, cmdErr.Code())
+				require.Contains(t, cmdErr.Error(), tc.errorMsg)
+			})
+		}
+	})
+}
+
+func TestCommand_GetAutoAccept(t *testing.T) {
+	t.Run("invalid request", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()}, false)
+		require.NoError(t, err)
+
+		cmdErr := cmd.GetAutoAccept(&bytes.Buffer{}, bytes.NewBufferString(`----`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("unknown protocol", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()}, false)
+		require.NoError(t, err)
+
+		cmdErr := cmd.GetAutoAccept(&bytes.Buffer{}, bytes.NewBufferString(`{"protocol": "unknown"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), ErrUnknownProtocol.Error())
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		store := &mockstore.MockStore{Store: map[string][]byte{}, ErrGet: errors.New("get failed")}
+
+		cmd, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewCustomMockStoreProvider(store)}, false)
+		require.NoError(t, err)
+
+		cmdErr := cmd.GetAutoAccept(&bytes.Buffer{}, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetAutoAcceptErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "get failed")
+	})
+}
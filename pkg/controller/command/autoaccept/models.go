@@ -0,0 +1,24 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package autoaccept
+
+// GetAutoAcceptArgs model
+//
+// This is used for getting the auto-accept policies.
+//
+type GetAutoAcceptArgs struct {
+	// Protocol name, the policies of all the protocols are returned if empty
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// GetAutoAcceptResponse model
+//
+// Represents the auto-accept policies of the protocols.
+//
+type GetAutoAcceptResponse struct {
+	Policies []*Policy `json:"policies"`
+}
@@ -0,0 +1,135 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package autoaccept
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// StoreName is the name of the store holding the auto-accept policies.
+const StoreName = "autoaccept"
+
+// ErrUnknownProtocol is returned for the protocols not having an auto-accept policy.
+var ErrUnknownProtocol = errors.New("unknown protocol")
+
+// Protocols returns the names of the protocols having an auto-accept policy.
+func Protocols() []string {
+	return []string{didexchange.DIDExchange, issuecredential.Name, presentproof.Name}
+}
+
+// Policy is the auto-accept policy of a protocol.
+type Policy struct {
+	// Protocol name, eg. didexchange, issue-credential, present-proof
+	Protocol string `json:"protocol"`
+
+	// AutoAccept is true if the actions of the protocol are accepted without user input
+	AutoAccept bool `json:"auto_accept"`
+}
+
+// Policies are the auto-accept policies of the protocols. They are persisted, hence shared by the controller
+// commands handling the protocol actions and kept across restarts.
+type Policies struct {
+	store    storage.Store
+	defaults map[string]bool
+}
+
+// NewPolicies returns the policies persisted in the store of the provider. The defaults are the policies of the
+// protocols not configured yet, the actions of a protocol are not auto-accepted by default.
+func NewPolicies(p storage.Provider, defaults map[string]bool) (*Policies, error) {
+	store, err := p.OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open auto-accept policies store: %w", err)
+	}
+
+	return &Policies{store: store, defaults: defaults}, nil
+}
+
+// Set sets the auto-accept policy of the protocol.
+func (p *Policies) Set(protocol string, autoAccept bool) error {
+	if !isProtocol(protocol) {
+		return fmt.Errorf("%w: %s", ErrUnknownProtocol, protocol)
+	}
+
+	src, err := json.Marshal(&Policy{Protocol: protocol, AutoAccept: autoAccept})
+	if err != nil {
+		return fmt.Errorf("marshal auto-accept policy: %w", err)
+	}
+
+	return p.store.Put(protocol, src)
+}
+
+// Get returns the auto-accept policy of the protocol.
+func (p *Policies) Get(protocol string) (*Policy, error) {
+	if !isProtocol(protocol) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProtocol, protocol)
+	}
+
+	src, err := p.store.Get(protocol)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return &Policy{Protocol: protocol, AutoAccept: p.defaults[protocol]}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get auto-accept policy: %w", err)
+	}
+
+	policy := &Policy{}
+
+	err = json.Unmarshal(src, policy)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal auto-accept policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// Handle handles the action events of the protocol, the policy being read for each action: the actions are
+// continued while the protocol is auto-accepted, else they are left pending. All the actions are forwarded to the
+// returned channel to be notified.
+func (p *Policies) Handle(protocol string, actions <-chan service.DIDCommAction) <-chan service.DIDCommAction {
+	notifications := make(chan service.DIDCommAction)
+
+	go func() {
+		defer close(notifications)
+
+		for action := range actions {
+			policy, err := p.Get(protocol)
+			if err != nil {
+				logger.Errorf("auto-accept %s action: %s", protocol, err)
+			}
+
+			notification := action
+			notification.Message = action.Message.Clone()
+
+			if err == nil && policy.AutoAccept {
+				action.Continue(&service.Empty{})
+			}
+
+			notifications <- notification
+		}
+	}()
+
+	return notifications
+}
+
+func isProtocol(name string) bool {
+	for _, protocol := range Protocols() {
+		if protocol == name {
+			return true
+		}
+	}
+
+	return false
+}
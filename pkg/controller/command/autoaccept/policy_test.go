@@ -0,0 +1,136 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package autoaccept
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestPolicies(t *testing.T) {
+	t.Run("set and get", func(t *testing.T) {
+		prov := mockstore.NewMockStoreProvider()
+
+		policies, err := NewPolicies(prov, map[string]bool{didexchange.DIDExchange: true})
+		require.NoError(t, err)
+
+		policy, err := policies.Get(didexchange.DIDExchange)
+		require.NoError(t, err)
+		require.Equal(t, &Policy{Protocol: didexchange.DIDExchange, AutoAccept: true}, policy)
+
+		policy, err = policies.Get(presentproof.Name)
+		require.NoError(t, err)
+		require.False(t, policy.AutoAccept)
+
+		require.NoError(t, policies.Set(didexchange.DIDExchange, false))
+		require.NoError(t, policies.Set(presentproof.Name, true))
+
+		// the policies are persisted
+		policies, err = NewPolicies(prov, nil)
+		require.NoError(t, err)
+
+		policy, err = policies.Get(didexchange.DIDExchange)
+		require.NoError(t, err)
+		require.False(t, policy.AutoAccept)
+
+		policy, err = policies.Get(presentproof.Name)
+		require.NoError(t, err)
+		require.True(t, policy.AutoAccept)
+	})
+
+	t.Run("unknown protocol", func(t *testing.T) {
+		policies, err := NewPolicies(mockstore.NewMockStoreProvider(), nil)
+		require.NoError(t, err)
+
+		require.True(t, errors.Is(policies.Set("unknown", true), ErrUnknownProtocol))
+
+		_, err = policies.Get("unknown")
+		require.True(t, errors.Is(err, ErrUnknownProtocol))
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		_, err := NewPolicies(&mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open failed")}, nil)
+		require.EqualError(t, err, "open auto-accept policies store: open failed")
+
+		store := &mockstore.MockStore{Store: map[string][]byte{presentproof.Name: []byte("{")}}
+
+		policies, err := NewPolicies(mockstore.NewCustomMockStoreProvider(store), nil)
+		require.NoError(t, err)
+
+		_, err = policies.Get(presentproof.Name)
+		require.Contains(t, err.Error(), "unmarshal auto-accept policy")
+
+		store.ErrGet = errors.New("get failed")
+
+		_, err = policies.Get(presentproof.Name)
+		require.EqualError(t, err, "get auto-accept policy: get failed")
+	})
+}
+
+func TestPolicies_Handle(t *testing.T) {
+	policies, err := NewPolicies(mockstore.NewMockStoreProvider(), map[string]bool{didexchange.DIDExchange: true})
+	require.NoError(t, err)
+
+	didExchangeActions := make(chan service.DIDCommAction)
+	didExchangeNotifications := policies.Handle(didexchange.DIDExchange, didExchangeActions)
+
+	presentProofActions := make(chan service.DIDCommAction)
+	presentProofNotifications := policies.Handle(presentproof.Name, presentProofActions)
+
+	// handle sends an action of the protocol and returns whether it was continued, once notified
+	handle := func(t *testing.T, actions chan<- service.DIDCommAction,
+		notifications <-chan service.DIDCommAction, protocol string) bool {
+		t.Helper()
+
+		continued := false
+
+		actions <- service.DIDCommAction{
+			ProtocolName: protocol,
+			Message:      service.NewDIDCommMsgMap(struct{}{}),
+			Continue: func(args interface{}) {
+				require.Equal(t, &service.Empty{}, args)
+
+				continued = true
+			},
+		}
+
+		// the actions are notified, auto-accepted or not
+		select {
+		case notification := <-notifications:
+			require.Equal(t, protocol, notification.ProtocolName)
+		case <-time.After(time.Second):
+			require.Fail(t, "action not notified")
+		}
+
+		return continued
+	}
+
+	// did-exchange auto-accepted, present-proof left pending
+	require.True(t, handle(t, didExchangeActions, didExchangeNotifications, didexchange.DIDExchange))
+	require.False(t, handle(t, presentProofActions, presentProofNotifications, presentproof.Name))
+
+	// the policies are read for each action
+	require.NoError(t, policies.Set(didexchange.DIDExchange, false))
+	require.NoError(t, policies.Set(presentproof.Name, true))
+
+	require.False(t, handle(t, didExchangeActions, didExchangeNotifications, didexchange.DIDExchange))
+	require.True(t, handle(t, presentProofActions, presentProofNotifications, presentproof.Name))
+
+	close(didExchangeActions)
+	close(presentProofActions)
+
+	_, ok := <-didExchangeNotifications
+	require.False(t, ok)
+}
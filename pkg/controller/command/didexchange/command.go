@@ -11,17 +11,24 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/google/uuid"
+
 	"github.com/hyperledger/aries-framework-go/pkg/client/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/autoaccept"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
 )
 
 var logger = log.New("aries-framework/controller/did-exchange")
@@ -33,21 +40,35 @@ const (
 	// error messages
 	errEmptyInviterDID = "empty inviter DID"
 	errEmptyConnID     = "empty connection ID"
+	errEmptyThreadID   = "empty thread ID"
+	errInvalidPaging   = "offset and limit must not be negative"
+	errEmptyNewDID     = "empty new DID"
+	errNotCompleted    = "connection `%s` is not completed"
+	errEmptyEndpoint   = "empty service endpoint"
+	errEmptyToDID      = "empty to_did"
+
+	// RotateDIDMsgType is the type of the message notifying the other party of a connection of the new DID of the
+	// agent (https://github.com/hyperledger/aries-rfcs/tree/main/features/0794-did-rotate).
+	RotateDIDMsgType = "https://didcomm.org/did-rotate/1.0/rotate"
 
 	AcceptExchangeRequestCommandMethod    = "AcceptExchangeRequest"
 	AcceptInvitationCommandMethod         = "AcceptInvitation"
 	CreateImplicitInvitationCommandMethod = "CreateImplicitInvitation"
 	CreateInvitationCommandMethod         = "CreateInvitation"
 	QueryConnectionByIDCommandMethod      = "QueryConnectionByID"
+	QueryConnectionByThreadCommandMethod  = "QueryConnectionByThread"
 	QueryConnectionsCommandMethod         = "QueryConnections"
 	ReceiveInvitationCommandMethod        = "ReceiveInvitation"
 	CreateConnectionCommandMethod         = "CreateConnection"
 	RemoveConnectionCommandMethod         = "RemoveConnection"
+	RotateDIDCommandMethod                = "RotateDID"
+	UpdateServiceEndpointCommandMethod    = "UpdateServiceEndpoint"
 
 	// log constants
 	connectionIDString = "connectionID"
 	successString      = "success"
 	invitationIDString = "invitationID"
+	threadIDString     = "threadID"
 )
 
 const (
@@ -79,6 +100,15 @@ const (
 	// CreateConnectionErrorCode is for failures in create connection command.
 	CreateConnectionErrorCode
 
+	// RotateDIDErrorCode is for failures in rotate DID command.
+	RotateDIDErrorCode
+
+	// QueryConnectionByThreadErrorCode is for failures in query connection by thread command.
+	QueryConnectionByThreadErrorCode
+
+	// UpdateServiceEndpointErrorCode is for failures in update service endpoint command.
+	UpdateServiceEndpointErrorCode
+
 	_actions = "_actions"
 	_states  = "_states"
 )
@@ -90,10 +120,24 @@ type provider interface {
 	ServiceEndpoint() string
 	StorageProvider() storage.Provider
 	ProtocolStateStorageProvider() storage.Provider
+	Messenger() service.Messenger
+	VDRIRegistry() vdriapi.Registry
+}
+
+// Option configures the DID Exchange controller command.
+type Option func(opts *Command)
+
+// WithMessageHandler registers the handler of the did-rotate messages of the other parties of the connections
+// with the given message handler, a DID rotated by the other party isn't applied to the connection without it.
+func WithMessageHandler(handler command.MessageHandler) Option {
+	return func(opts *Command) {
+		opts.msgHandler = handler
+	}
 }
 
 // New returns new DID Exchange controller command instance.
-func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccept bool) (*Command, error) {
+func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccept bool,
+	opts ...Option) (*Command, error) {
 	didExchange, err := didexchange.New(ctx)
 	if err != nil {
 		return nil, err
@@ -113,34 +157,42 @@ func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccep
 		return nil, fmt.Errorf("register msg event: %w", err)
 	}
 
-	subscribers := []chan service.DIDCommAction{
-		make(chan service.DIDCommAction),
+	// the actions are auto-accepted according to the policy of the protocol, autoAccept until configured
+	policies, err := autoaccept.NewPolicies(ctx.StorageProvider(), map[string]bool{protocol.DIDExchange: autoAccept})
+	if err != nil {
+		return nil, fmt.Errorf("create auto-accept policies: %w", err)
 	}
 
-	if autoAccept {
-		subscribers = append(subscribers, make(chan service.DIDCommAction))
-
-		go service.AutoExecuteActionEvent(subscribers[1])
+	connectionStore, err := connection.NewRecorder(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create connection recorder: %w", err)
 	}
 
-	go func() {
-		for action := range actions {
-			for i := range subscribers {
-				action.Message = action.Message.Clone()
-				subscribers[i] <- action
-			}
-		}
-	}()
+	didConnections, err := didstore.NewConnectionStore(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create did connection store: %w", err)
+	}
 
 	obs := webnotifier.NewObserver(notifier)
-	obs.RegisterAction(protocol.DIDExchange+_actions, subscribers[0])
+	obs.RegisterAction(protocol.DIDExchange+_actions, policies.Handle(protocol.DIDExchange, actions))
 	obs.RegisterStateMsg(protocol.DIDExchange+_states, states)
 
 	cmd := &Command{
-		ctx:          ctx,
-		client:       didExchange,
-		msgCh:        make(chan service.StateMsg),
-		defaultLabel: defaultLabel,
+		ctx:             ctx,
+		client:          didExchange,
+		connectionStore: connectionStore,
+		didConnections:  didConnections,
+		msgCh:           make(chan service.StateMsg),
+		defaultLabel:    defaultLabel,
+	}
+
+	for _, opt := range opts {
+		opt(cmd)
+	}
+
+	err = cmd.registerRotateService()
+	if err != nil {
+		return nil, err
 	}
 
 	return cmd, nil
@@ -148,10 +200,13 @@ func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccep
 
 // Command is controller command for DID Exchange.
 type Command struct {
-	ctx          provider
-	client       *didexchange.Client
-	msgCh        chan service.StateMsg
-	defaultLabel string
+	ctx             provider
+	client          *didexchange.Client
+	connectionStore *connection.Recorder
+	didConnections  *didstore.ConnectionStore
+	msgHandler      command.MessageHandler
+	msgCh           chan service.StateMsg
+	defaultLabel    string
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -163,9 +218,12 @@ func (c *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, CreateConnectionCommandMethod, c.CreateConnection),
 		cmdutil.NewCommandHandler(CommandName, RemoveConnectionCommandMethod, c.RemoveConnection),
 		cmdutil.NewCommandHandler(CommandName, QueryConnectionByIDCommandMethod, c.QueryConnectionByID),
+		cmdutil.NewCommandHandler(CommandName, QueryConnectionByThreadCommandMethod, c.QueryConnectionByThread),
 		cmdutil.NewCommandHandler(CommandName, QueryConnectionsCommandMethod, c.QueryConnections),
 		cmdutil.NewCommandHandler(CommandName, AcceptExchangeRequestCommandMethod, c.AcceptExchangeRequest),
 		cmdutil.NewCommandHandler(CommandName, CreateImplicitInvitationCommandMethod, c.CreateImplicitInvitation),
+		cmdutil.NewCommandHandler(CommandName, RotateDIDCommandMethod, c.RotateDID),
+		cmdutil.NewCommandHandler(CommandName, UpdateServiceEndpointCommandMethod, c.UpdateServiceEndpoint),
 	}
 }
 
@@ -179,6 +237,12 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
+	urlBase, urlEncoding, err := c.invitationURLOptions(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateInvitationCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
 	var invitation *didexchange.Invitation
 	// call didexchange client
 	if request.Public != "" {
@@ -192,9 +256,16 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 		return command.NewExecuteError(CreateInvitationErrorCode, err)
 	}
 
+	invitationURL, err := cmdutil.InvitationURL(urlBase, urlEncoding, invitation)
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreateInvitationCommandMethod, err.Error())
+		return command.NewExecuteError(CreateInvitationErrorCode, err)
+	}
+
 	command.WriteNillableResponse(rw, &CreateInvitationResponse{
-		Invitation: invitation,
-		Alias:      request.Alias},
+		Invitation:    invitation,
+		Alias:         request.Alias,
+		InvitationURL: invitationURL},
 		logger)
 
 	logutil.LogDebug(logger, CommandName, CreateInvitationCommandMethod, successString,
@@ -203,6 +274,30 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// invitationURLOptions returns the base and the encoding of the invitation URL, the agent's endpoint and the legacy
+// 'c_i' encoding are used unless provided.
+func (c *Command) invitationURLOptions(args *CreateInvitationArgs) (string, string, error) {
+	base, encoding := c.ctx.ServiceEndpoint(), cmdutil.LegacyInvitationEncoding
+
+	if args.InvitationURLBase != "" {
+		if err := cmdutil.ValidateInvitationURLBase(args.InvitationURLBase); err != nil {
+			return "", "", err
+		}
+
+		base = args.InvitationURLBase
+	}
+
+	if args.InvitationURLEncoding != "" {
+		if err := cmdutil.ValidateInvitationURLEncoding(args.InvitationURLEncoding); err != nil {
+			return "", "", err
+		}
+
+		encoding = args.InvitationURLEncoding
+	}
+
+	return base, encoding, nil
+}
+
 // ReceiveInvitation receives a new connection invitation.
 func (c *Command) ReceiveInvitation(rw io.Writer, req io.Reader) command.Error {
 	var request didexchange.Invitation
@@ -341,7 +436,8 @@ func (c *Command) AcceptExchangeRequest(rw io.Writer, req io.Reader) command.Err
 	return nil
 }
 
-// QueryConnections queries agent to agent connections.
+// QueryConnections queries agent to agent connections matching the state, my DID and their DID filters,
+// paged by offset and limit.
 func (c *Command) QueryConnections(rw io.Writer, req io.Reader) command.Error {
 	var request QueryConnectionsArgs
 
@@ -351,6 +447,11 @@ func (c *Command) QueryConnections(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
+	if request.Offset < 0 || request.Limit < 0 {
+		logutil.LogDebug(logger, CommandName, QueryConnectionsCommandMethod, errInvalidPaging)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errInvalidPaging))
+	}
+
 	results, err := c.client.QueryConnections(&request.QueryConnectionsParams)
 	if err != nil {
 		logutil.LogError(logger, CommandName, QueryConnectionsCommandMethod, err.Error())
@@ -398,6 +499,47 @@ func (c *Command) QueryConnectionByID(rw io.Writer, req io.Reader) command.Error
 	return nil
 }
 
+// QueryConnectionByThread fetches the connection the messages of the given thread were received on and the
+// protocol state of the thread, for correlating the DIDComm threads to their connection.
+func (c *Command) QueryConnectionByThread(rw io.Writer, req io.Reader) command.Error {
+	var request ThreadIDArg
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, QueryConnectionByThreadCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.ThreadID == "" {
+		logutil.LogDebug(logger, CommandName, QueryConnectionByThreadCommandMethod, errEmptyThreadID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyThreadID))
+	}
+
+	thread, err := c.connectionStore.GetThreadRecord(request.ThreadID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, QueryConnectionByThreadCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(threadIDString, request.ThreadID))
+		return command.NewExecuteError(QueryConnectionByThreadErrorCode, err)
+	}
+
+	record, err := c.connectionStore.GetConnectionRecord(thread.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, QueryConnectionByThreadCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(threadIDString, request.ThreadID))
+		return command.NewExecuteError(QueryConnectionByThreadErrorCode, fmt.Errorf("get connection: %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &QueryConnectionByThreadResponse{
+		Result: &didexchange.Connection{Record: record},
+		Thread: thread,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, QueryConnectionByThreadCommandMethod, successString,
+		logutil.CreateKeyValueString(threadIDString, request.ThreadID))
+
+	return nil
+}
+
 // CreateConnection creates a new connection record in completed state and returns the generated connectionID.
 func (c *Command) CreateConnection(rw io.Writer, req io.Reader) command.Error {
 	request := &CreateConnectionRequest{}
@@ -436,7 +578,8 @@ func (c *Command) CreateConnection(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
-// RemoveConnection removes given connection record.
+// RemoveConnection removes given connection record along with its protocol state, pending requests of the connection
+// can no longer be accepted.
 func (c *Command) RemoveConnection(rw io.Writer, req io.Reader) command.Error {
 	var request ConnectionIDArg
 
@@ -465,3 +608,169 @@ func (c *Command) RemoveConnection(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// RotateDID replaces the DID used by the agent in a completed connection with a new resolvable DID. The other party
+// is notified of the new DID by a rotate message sent from the DID being replaced.
+func (c *Command) RotateDID(rw io.Writer, req io.Reader) command.Error {
+	var request RotateDIDArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RotateDIDCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.ID == "" {
+		logutil.LogDebug(logger, CommandName, RotateDIDCommandMethod, errEmptyConnID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyConnID))
+	}
+
+	if request.NewDID == "" {
+		logutil.LogDebug(logger, CommandName, RotateDIDCommandMethod, errEmptyNewDID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyNewDID))
+	}
+
+	record, err := c.connectionStore.GetConnectionRecord(request.ID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RotateDIDCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, request.ID))
+		return command.NewExecuteError(RotateDIDErrorCode, fmt.Errorf("get connection: %w", err))
+	}
+
+	if record.State != connection.StateNameCompleted {
+		logutil.LogDebug(logger, CommandName, RotateDIDCommandMethod, fmt.Sprintf(errNotCompleted, request.ID))
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errNotCompleted, request.ID))
+	}
+
+	newDoc, err := c.ctx.VDRIRegistry().Resolve(request.NewDID)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RotateDIDCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, request.ID))
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("resolve new DID: %w", err))
+	}
+
+	err = c.rotateDID(record, newDoc)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RotateDIDCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, request.ID))
+		return command.NewExecuteError(RotateDIDErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &QueryConnectionResponse{
+		Result: &didexchange.Connection{Record: record},
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, RotateDIDCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionIDString, request.ID))
+
+	return nil
+}
+
+// UpdateServiceEndpoint rotates the DIDs used by the agent in the completed connections to new did:peer DIDs with
+// the given DIDComm service endpoint, the other party of each connection is notified of the new DID by a did-rotate
+// message [RFC-0794]. The connections which can't be rotated are returned with their failure, the other connections
+// are rotated still. The endpoint of the DID documents created for new connections is not changed.
+func (c *Command) UpdateServiceEndpoint(rw io.Writer, req io.Reader) command.Error {
+	var request UpdateServiceEndpointArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UpdateServiceEndpointCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.ServiceEndpoint == "" {
+		logutil.LogDebug(logger, CommandName, UpdateServiceEndpointCommandMethod, errEmptyEndpoint)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyEndpoint))
+	}
+
+	records, err := c.connectionStore.QueryConnectionRecords()
+	if err != nil {
+		logutil.LogError(logger, CommandName, UpdateServiceEndpointCommandMethod, err.Error())
+		return command.NewExecuteError(UpdateServiceEndpointErrorCode, fmt.Errorf("query connections: %w", err))
+	}
+
+	// the new DID documents by replaced DID, a DID may be used in several connections
+	newDocs := make(map[string]*did.Doc)
+	response := &UpdateServiceEndpointResponse{ConnectionIDs: []string{}, Failures: []ConnectionFailure{}}
+
+	for _, record := range records {
+		if record.State != connection.StateNameCompleted {
+			continue
+		}
+
+		err = c.rotateServiceEndpoint(record, request.ServiceEndpoint, newDocs)
+		if err != nil {
+			logutil.LogError(logger, CommandName, UpdateServiceEndpointCommandMethod, err.Error(),
+				logutil.CreateKeyValueString(connectionIDString, record.ConnectionID))
+
+			response.Failures = append(response.Failures, ConnectionFailure{
+				ConnectionID: record.ConnectionID,
+				Error:        err.Error(),
+			})
+
+			continue
+		}
+
+		response.ConnectionIDs = append(response.ConnectionIDs, record.ConnectionID)
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, UpdateServiceEndpointCommandMethod, successString)
+
+	return nil
+}
+
+// rotateServiceEndpoint rotates the DID of the agent in the connection to a did:peer DID with the endpoint, the
+// connections of the same DID are rotated to the same new DID.
+func (c *Command) rotateServiceEndpoint(record *connection.Record, endpoint string, newDocs map[string]*did.Doc) error {
+	newDoc, ok := newDocs[record.MyDID]
+	if !ok {
+		var err error
+
+		newDoc, err = c.ctx.VDRIRegistry().Create(peer.DIDMethod,
+			vdriapi.WithNumAlgo(2),
+			vdriapi.WithServiceType(vdriapi.DIDCommServiceType),
+			vdriapi.WithServiceEndpoint(endpoint),
+		)
+		if err != nil {
+			return fmt.Errorf("create DID: %w", err)
+		}
+
+		newDocs[record.MyDID] = newDoc
+	}
+
+	return c.rotateDID(record, newDoc)
+}
+
+// rotateDID notifies the other party of the connection of the new DID of the agent with a rotate message, then uses
+// the new DID in the connection.
+func (c *Command) rotateDID(record *connection.Record, newDoc *did.Doc) error {
+	msg := service.NewDIDCommMsgMap(&rotateDIDMsg{
+		ID:    uuid.New().String(),
+		Type:  RotateDIDMsgType,
+		ToDID: newDoc.ID,
+	})
+
+	err := c.ctx.Messenger().Send(msg, record.MyDID, record.TheirDID)
+	if err != nil {
+		return fmt.Errorf("send rotate message: %w", err)
+	}
+
+	// the messages of the other party to the keys of the new DID are mapped to it
+	err = c.didConnections.SaveDIDFromDoc(newDoc)
+	if err != nil {
+		return fmt.Errorf("save DID connection: %w", err)
+	}
+
+	// the mapping of the replaced DID is kept, the other party may still use it until the rotation is processed
+	record.MyDID = newDoc.ID
+
+	err = c.connectionStore.SaveConnectionRecord(record)
+	if err != nil {
+		return fmt.Errorf("save connection: %w", err)
+	}
+
+	return nil
+}
@@ -17,19 +17,24 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	mockwebhook "github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	didexsvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
 	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
@@ -37,6 +42,7 @@ import (
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
@@ -63,6 +69,24 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, cmd)
 	})
 
+	t.Run("Successfully create new DID exchange command with message handler", func(t *testing.T) {
+		registrar := msghandler.NewRegistrar()
+
+		cmd, err := New(mockProvider(), webnotifier.NewHTTPNotifier(nil), "", false, WithMessageHandler(registrar))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		require.Len(t, registrar.Services(), 1)
+		require.Equal(t, rotateServiceName, registrar.Services()[0].Name())
+		require.True(t, registrar.Services()[0].Accept(RotateDIDMsgType, nil))
+
+		// the did-rotate message service can't be registered twice
+		cmd, err = New(mockProvider(), webnotifier.NewHTTPNotifier(nil), "", false, WithMessageHandler(registrar))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "register did-rotate message service")
+		require.Nil(t, cmd)
+	})
+
 	t.Run("Test create new DID exchange command failure", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{ServiceErr: errors.New("test-error")},
 			webnotifier.NewHTTPNotifier(nil), "", false)
@@ -170,6 +194,51 @@ func TestCommand_CreateInvitation(t *testing.T) {
 		require.Equal(t, "https://didcomm.org/didexchange/1.0/invitation", response.Invitation.Type)
 	})
 
+	t.Run("Successful CreateInvitation with invitation URL", func(t *testing.T) {
+		cmd, err := New(mockProvider(), mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		tests := []struct {
+			args     string
+			expected string
+		}{
+			{args: `{}`, expected: `^endpoint\?c_i=`},
+			{args: `{"invitation_url_base":"https://example.com/invite"}`, expected: `^https://example\.com/invite\?c_i=`},
+			{
+				args:     `{"invitation_url_base":"https://example.com/invite","invitation_url_encoding":"oob"}`,
+				expected: `^https://example\.com/invite\?oob=`,
+			},
+		}
+
+		for _, test := range tests {
+			var b bytes.Buffer
+			require.NoError(t, cmd.CreateInvitation(&b, bytes.NewBufferString(test.args)))
+
+			response := CreateInvitationResponse{}
+			require.NoError(t, json.NewDecoder(&b).Decode(&response))
+			require.Regexp(t, test.expected, response.InvitationURL)
+
+			invitation := &didexsvc.Invitation{}
+			require.NoError(t, cmdutil.DecodeInvitationURL(response.InvitationURL, invitation))
+			require.Equal(t, response.Invitation.Invitation, invitation)
+		}
+	})
+
+	t.Run("CreateInvitation with invalid invitation URL options", func(t *testing.T) {
+		cmd, err := New(mockProvider(), mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		for _, args := range []string{`{"invitation_url_base":"/invite"}`, `{"invitation_url_encoding":"d_m"}`} {
+			var b bytes.Buffer
+			cmdErr := cmd.CreateInvitation(&b, bytes.NewBufferString(args))
+			require.Error(t, cmdErr, args)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), args)
+			require.Equal(t, command.ValidationError, cmdErr.Type(), args)
+		}
+	})
+
 	t.Run("CreateInvitation failure", func(t *testing.T) {
 		const errMsg = "sample-err-01"
 		provider := mockProvider()
@@ -429,6 +498,58 @@ func TestCommand_QueryConnections(t *testing.T) {
 		require.NotEmpty(t, connID, response.Results[0].ConnectionID)
 	})
 
+	t.Run("test query connections with filters and paging", func(t *testing.T) {
+		prov := mockProvider()
+		store := mockstore.MockStore{Store: make(map[string][]byte)}
+
+		for i, state := range []string{"invited", "requested", "requested", "completed", "requested"} {
+			connID := fmt.Sprintf("conn%d", i)
+			connRec := &connection.Record{
+				State:        state,
+				ConnectionID: connID,
+				MyDID:        fmt.Sprintf("did:example:my%d", i%2),
+				TheirDID:     fmt.Sprintf("did:example:their%d", i),
+			}
+
+			connBytes, err := json.Marshal(connRec)
+			require.NoError(t, err)
+			require.NoError(t, store.Put("conn_"+connID, connBytes))
+		}
+
+		prov.StorageProviderValue = &mockstore.MockStoreProvider{Store: &store}
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		query := func(request string) []string {
+			var b bytes.Buffer
+			require.NoError(t, cmd.QueryConnections(&b, bytes.NewBufferString(request)))
+
+			response := QueryConnectionsResponse{}
+			require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+			var ids []string
+			for _, result := range response.Results {
+				ids = append(ids, result.ConnectionID)
+			}
+
+			return ids
+		}
+
+		require.Equal(t, []string{"conn1", "conn2", "conn4"}, query(`{"state":"requested"}`))
+		require.Equal(t, []string{"conn2", "conn4"}, query(`{"state":"requested","my_did":"did:example:my0"}`))
+		require.Equal(t, []string{"conn3"}, query(`{"their_did":"did:example:their3"}`))
+		require.Equal(t, []string{"conn1", "conn2"}, query(`{"state":"requested","limit":2}`))
+		require.Equal(t, []string{"conn4"}, query(`{"state":"requested","offset":2,"limit":2}`))
+		require.Empty(t, query(`{"state":"requested","offset":3}`))
+
+		var b bytes.Buffer
+		cmdErr := cmd.QueryConnections(&b, bytes.NewBufferString(`{"offset":-1}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
 	t.Run("test query connections validation error", func(t *testing.T) {
 		cmd, err := New(mockProvider(), mockwebhook.NewMockWebhookNotifier(), "", false)
 		require.NoError(t, err)
@@ -831,6 +952,150 @@ func TestCommand_AcceptExchangeRequest(t *testing.T) {
 			require.Fail(t, "tests are not validated")
 		}
 	})
+
+	t.Run("test connection request stays pending until accepted with auto accept off", func(t *testing.T) {
+		protocolStateStore := mockstore.NewMockStoreProvider()
+		store := mockstore.NewMockStoreProvider()
+		didExSvc, err := didexsvc.New(
+			&protocol.MockProvider{
+				ProtocolStateStoreProvider: protocolStateStore, StoreProvider: store,
+				ServiceMap: map[string]interface{}{
+					mediator.Coordination: &mockroute.MockMediatorSvc{},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		ed25519KH, err := mockkms.CreateMockED25519KeyHandle()
+		require.NoError(t, err)
+
+		states := make(chan string, 10)
+		connID := make(chan string, 1)
+
+		cmd, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: protocolStateStore,
+			StorageProviderValue:              store,
+			ServiceMap: map[string]interface{}{
+				didexsvc.DIDExchange:  didExSvc,
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+			KMSValue: &mockkms.KeyManager{CreateKeyValue: ed25519KH}},
+			&mockwebhook.Notifier{
+				NotifyFunc: func(topic string, message []byte) error {
+					if topic != "didexchange_states" {
+						return nil
+					}
+
+					conn := struct {
+						StateID    string
+						Properties map[string]string
+						Type       string
+					}{}
+					require.NoError(t, json.Unmarshal(message, &conn))
+
+					if conn.Type != postState {
+						return nil
+					}
+
+					if conn.StateID == "requested" {
+						connID <- conn.Properties[connectionIDString]
+					}
+
+					states <- conn.StateID
+
+					return nil
+				},
+			},
+			"", false,
+		)
+		require.NoError(t, err)
+
+		waitFor := func(state string) {
+			select {
+			case s := <-states:
+				require.Equal(t, state, s)
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "timeout waiting for state "+state)
+			}
+		}
+
+		connectionState := func(id string) string {
+			var b bytes.Buffer
+			require.NoError(t, cmd.QueryConnectionByID(&b, bytes.NewBufferString(fmt.Sprintf(`{"id":"%s"}`, id))))
+
+			response := QueryConnectionResponse{}
+			require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+			return response.Result.State
+		}
+
+		newDidDoc, err := (&mockvdri.MockVDRIRegistry{}).Create("peer")
+		require.NoError(t, err)
+
+		invitation, err := cmd.client.CreateInvitation("test")
+		require.NoError(t, err)
+
+		thid := uuid.New().String()
+
+		request, err := json.Marshal(
+			&didexsvc.Request{
+				Type:  didexsvc.RequestMsgType,
+				ID:    thid,
+				Label: "test",
+				Thread: &decorator.Thread{
+					PID: invitation.ID,
+				},
+				Connection: &didexsvc.Connection{
+					DID:    newDidDoc.ID,
+					DIDDoc: newDidDoc,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		msg, err := service.ParseDIDCommMsgMap(request)
+		require.NoError(t, err)
+
+		_, err = didExSvc.HandleInbound(msg, "", "")
+		require.NoError(t, err)
+
+		waitFor("requested")
+
+		cid := <-connID
+
+		// the request isn't answered until accepted
+		select {
+		case s := <-states:
+			require.Fail(t, "unexpected state "+s)
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		require.Equal(t, "requested", connectionState(cid))
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.AcceptExchangeRequest(&b, bytes.NewBufferString(fmt.Sprintf(`{"id":"%s"}`, cid))))
+
+		waitFor("responded")
+		require.Equal(t, "responded", connectionState(cid))
+
+		// the invitee acknowledges the response
+		ack, err := json.Marshal(&model.Ack{
+			Type:   didexsvc.AckMsgType,
+			ID:     uuid.New().String(),
+			Status: "OK",
+			Thread: &decorator.Thread{ID: thid},
+		})
+		require.NoError(t, err)
+
+		msg, err = service.ParseDIDCommMsgMap(ack)
+		require.NoError(t, err)
+
+		_, err = didExSvc.HandleInbound(msg, "", "")
+		require.NoError(t, err)
+
+		waitFor("completed")
+		require.Equal(t, "completed", connectionState(cid))
+	})
 }
 
 func TestCommand_SaveConnection(t *testing.T) {
@@ -868,6 +1133,20 @@ func TestCommand_SaveConnection(t *testing.T) {
 		require.NotEmpty(t, response.ID)
 	})
 
+	t.Run("test remove unknown connection", func(t *testing.T) {
+		cmd, err := New(mockProvider(), mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RemoveConnection(&b, bytes.NewBufferString(`{"id":"unknown"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RemoveConnectionErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "data not found")
+		require.Empty(t, b.String())
+	})
+
 	t.Run("test remove connection validation error", func(t *testing.T) {
 		cmd, err := New(mockProvider(), mockwebhook.NewMockWebhookNotifier(), "", false)
 		require.NoError(t, err)
@@ -934,6 +1213,321 @@ func TestCommand_RemoveConnection(t *testing.T) {
 	})
 }
 
+func TestCommand_RotateDID(t *testing.T) {
+	const (
+		connID   = "1234"
+		myDID    = "did:example:my"
+		newDID   = "did:example:new"
+		theirDID = "did:example:their"
+	)
+
+	prepare := func(t *testing.T, state string) (*mockprovider.Provider, *mockstore.MockStore) {
+		t.Helper()
+
+		prov := mockProvider()
+		store := &mockstore.MockStore{Store: make(map[string][]byte)}
+		require.NoError(t, store.Put("conn_"+connID, toBytes(t, &connection.Record{
+			State:        state,
+			ConnectionID: connID,
+			MyDID:        myDID,
+			TheirDID:     theirDID,
+		})))
+
+		prov.StorageProviderValue = &mockstore.MockStoreProvider{Store: store}
+		prov.VDRIRegistryValue = &mockvdri.MockVDRIRegistry{ResolveValue: &did.Doc{ID: newDID}}
+
+		return prov, store
+	}
+
+	t.Run("test rotate DID", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prov, store := prepare(t, connection.StateNameCompleted)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), myDID, theirDID).
+			Do(func(msg service.DIDCommMsgMap, _, _ string) {
+				require.Equal(t, RotateDIDMsgType, msg.Type())
+				require.NotEmpty(t, msg.ID())
+				require.Equal(t, newDID, msg["to_did"])
+			}).Return(nil)
+
+		prov.MessengerValue = messenger
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RotateDID(&b, bytes.NewBufferString(`{"id":"1234","new_did":"did:example:new"}`))
+		require.NoError(t, cmdErr)
+
+		response := QueryConnectionResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, newDID, response.Result.MyDID)
+
+		record := &connection.Record{}
+		require.NoError(t, json.Unmarshal(store.Store["conn_"+connID], record))
+		require.Equal(t, newDID, record.MyDID)
+		require.Equal(t, theirDID, record.TheirDID)
+	})
+
+	t.Run("test rotate DID validation errors", func(t *testing.T) {
+		prov, _ := prepare(t, "requested")
+		prov.VDRIRegistryValue = &mockvdri.MockVDRIRegistry{}
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		for request, errMsg := range map[string]string{
+			`--`:                                "",
+			`{"new_did":"did:example:new"}`:     errEmptyConnID,
+			`{"id":"1234"}`:                     errEmptyNewDID,
+			`{"id":"1234","new_did":"did:x:1"}`: "connection `1234` is not completed",
+		} {
+			var b bytes.Buffer
+			cmdErr := cmd.RotateDID(&b, bytes.NewBufferString(request))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+			require.Contains(t, cmdErr.Error(), errMsg)
+		}
+
+		prov, _ = prepare(t, connection.StateNameCompleted)
+		prov.VDRIRegistryValue = &mockvdri.MockVDRIRegistry{}
+
+		cmd, err = New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RotateDID(&b, bytes.NewBufferString(`{"id":"1234","new_did":"did:example:new"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "resolve new DID")
+	})
+
+	t.Run("test rotate DID failures", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prov, store := prepare(t, connection.StateNameCompleted)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		prov.MessengerValue = messenger
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RotateDID(&b, bytes.NewBufferString(`{"id":"5678","new_did":"did:example:new"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RotateDIDErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "get connection")
+
+		messenger.EXPECT().Send(gomock.Any(), myDID, theirDID).Return(errors.New("send error"))
+
+		cmdErr = cmd.RotateDID(&b, bytes.NewBufferString(`{"id":"1234","new_did":"did:example:new"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RotateDIDErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "send rotate message: send error")
+
+		// the record isn't updated if the rotate message can't be sent
+		record := &connection.Record{}
+		require.NoError(t, json.Unmarshal(store.Store["conn_"+connID], record))
+		require.Equal(t, myDID, record.MyDID)
+
+		messenger.EXPECT().Send(gomock.Any(), myDID, theirDID).Return(nil)
+
+		store.ErrPut = errors.New("put error")
+
+		cmdErr = cmd.RotateDID(&b, bytes.NewBufferString(`{"id":"1234","new_did":"did:example:new"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RotateDIDErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "save connection")
+	})
+}
+
+func TestCommand_UpdateServiceEndpoint(t *testing.T) {
+	const (
+		myDID    = "did:example:my"
+		otherDID = "did:example:other"
+		endpoint = "https://new.agent.example.com"
+	)
+
+	prepare := func(t *testing.T) (*mockprovider.Provider, *mockstore.MockStore, map[string]*did.Doc) {
+		t.Helper()
+
+		prov := mockProvider()
+		store := &mockstore.MockStore{Store: make(map[string][]byte)}
+
+		for _, record := range []*connection.Record{
+			{ConnectionID: "1", State: connection.StateNameCompleted, MyDID: myDID, TheirDID: "did:example:alice"},
+			{ConnectionID: "2", State: connection.StateNameCompleted, MyDID: myDID, TheirDID: "did:example:bob"},
+			{ConnectionID: "3", State: connection.StateNameCompleted, MyDID: otherDID, TheirDID: "did:example:carol"},
+			{ConnectionID: "4", State: "requested", MyDID: "did:example:pending", TheirDID: "did:example:dave"},
+		} {
+			require.NoError(t, store.Put("conn_"+record.ConnectionID, toBytes(t, record)))
+		}
+
+		prov.StorageProviderValue = &mockstore.MockStoreProvider{Store: store}
+
+		created := make(map[string]*did.Doc)
+
+		prov.VDRIRegistryValue = &mockvdri.MockVDRIRegistry{
+			CreateFunc: func(method string, opts ...vdri.DocOpts) (*did.Doc, error) {
+				docOpts := &vdri.CreateDIDOpts{}
+				for _, opt := range opts {
+					opt(docOpts)
+				}
+
+				require.Equal(t, peer.DIDMethod, method)
+				require.Equal(t, 2, docOpts.NumAlgo)
+				require.Equal(t, vdri.DIDCommServiceType, docOpts.ServiceType)
+				require.Equal(t, endpoint, docOpts.ServiceEndpoint)
+
+				doc := &did.Doc{ID: fmt.Sprintf("did:peer:2.new%d", len(created))}
+				created[doc.ID] = doc
+
+				return doc, nil
+			},
+		}
+
+		return prov, store, created
+	}
+
+	getRecord := func(t *testing.T, store *mockstore.MockStore, connID string) *connection.Record {
+		t.Helper()
+
+		record := &connection.Record{}
+		require.NoError(t, json.Unmarshal(store.Store["conn_"+connID], record))
+
+		return record
+	}
+
+	t.Run("test update service endpoint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prov, store, created := prepare(t)
+
+		// the new DIDs sent by replaced DID
+		sent := make(map[string]string)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, myDID, theirDID string) {
+				require.Equal(t, RotateDIDMsgType, msg.Type())
+				require.NotEmpty(t, msg.ID())
+
+				toDID, ok := msg["to_did"].(string)
+				require.True(t, ok)
+				require.Contains(t, created, toDID)
+
+				if prev, ok := sent[myDID]; ok {
+					require.Equal(t, prev, toDID)
+				}
+
+				sent[myDID] = toDID
+			}).Return(nil).Times(3)
+
+		prov.MessengerValue = messenger
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.UpdateServiceEndpoint(&b, bytes.NewBufferString(`{"service_endpoint":"`+endpoint+`"}`))
+		require.NoError(t, cmdErr)
+
+		response := UpdateServiceEndpointResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.ElementsMatch(t, []string{"1", "2", "3"}, response.ConnectionIDs)
+		require.Empty(t, response.Failures)
+
+		// a new DID is created by replaced DID
+		require.Len(t, created, 2)
+		require.Len(t, sent, 2)
+		require.NotEqual(t, sent[myDID], sent[otherDID])
+
+		require.Equal(t, sent[myDID], getRecord(t, store, "1").MyDID)
+		require.Equal(t, sent[myDID], getRecord(t, store, "2").MyDID)
+		require.Equal(t, sent[otherDID], getRecord(t, store, "3").MyDID)
+		require.Equal(t, "did:example:pending", getRecord(t, store, "4").MyDID)
+	})
+
+	t.Run("test update service endpoint validation errors", func(t *testing.T) {
+		prov, _, _ := prepare(t)
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		for request, errMsg := range map[string]string{`--`: "", `{}`: errEmptyEndpoint} {
+			var b bytes.Buffer
+			cmdErr := cmd.UpdateServiceEndpoint(&b, bytes.NewBufferString(request))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+			require.Contains(t, cmdErr.Error(), errMsg)
+		}
+	})
+
+	t.Run("test update service endpoint send failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prov, store, _ := prepare(t)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), myDID, gomock.Any()).Return(nil).Times(2)
+		messenger.EXPECT().Send(gomock.Any(), otherDID, "did:example:carol").Return(errors.New("send error"))
+
+		prov.MessengerValue = messenger
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.UpdateServiceEndpoint(&b, bytes.NewBufferString(`{"service_endpoint":"`+endpoint+`"}`))
+		require.NoError(t, cmdErr)
+
+		// the connections are rotated past the failure
+		response := UpdateServiceEndpointResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.ElementsMatch(t, []string{"1", "2"}, response.ConnectionIDs)
+		require.Equal(t, []ConnectionFailure{
+			{ConnectionID: "3", Error: "send rotate message: send error"},
+		}, response.Failures)
+
+		// the connection isn't rotated if the rotate message can't be sent
+		require.Equal(t, otherDID, getRecord(t, store, "3").MyDID)
+		require.NotEqual(t, myDID, getRecord(t, store, "1").MyDID)
+	})
+
+	t.Run("test update service endpoint create DID failure", func(t *testing.T) {
+		prov, store, _ := prepare(t)
+		prov.VDRIRegistryValue = &mockvdri.MockVDRIRegistry{CreateErr: errors.New("create error")}
+
+		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.UpdateServiceEndpoint(&b, bytes.NewBufferString(`{"service_endpoint":"`+endpoint+`"}`))
+		require.NoError(t, cmdErr)
+
+		response := UpdateServiceEndpointResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Empty(t, response.ConnectionIDs)
+		require.Len(t, response.Failures, 3)
+
+		for _, failure := range response.Failures {
+			require.Equal(t, "create DID: create error", failure.Error)
+		}
+
+		require.Equal(t, myDID, getRecord(t, store, "1").MyDID)
+	})
+}
+
 func mockProvider() *mockprovider.Provider {
 	return &mockprovider.Provider{
 		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
@@ -986,3 +1580,74 @@ func toBytes(t *testing.T, v interface{}) []byte {
 
 	return bits
 }
+
+func TestCommand_QueryConnectionByThread(t *testing.T) {
+	const (
+		connID   = "1234"
+		threadID = "thread-1"
+	)
+
+	prov := mockProvider()
+	prov.StorageProviderValue = &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: make(map[string][]byte)}}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: connID,
+		State:        connection.StateNameCompleted,
+	}))
+	require.NoError(t, recorder.SaveThreadRecord(&connection.ThreadRecord{
+		ThreadID:     threadID,
+		ConnectionID: connID,
+		Protocol:     "https://didcomm.org/present-proof/2.0",
+		MessageType:  "https://didcomm.org/present-proof/2.0/request-presentation",
+	}))
+	require.NoError(t, recorder.SaveThreadRecord(&connection.ThreadRecord{
+		ThreadID:     "thread-removed",
+		ConnectionID: "5678",
+	}))
+
+	cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
+	require.NoError(t, err)
+
+	t.Run("test query connection by thread", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.QueryConnectionByThread(&b, bytes.NewBufferString(`{"thread_id":"thread-1"}`))
+		require.NoError(t, cmdErr)
+
+		response := QueryConnectionByThreadResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, connID, response.Result.ConnectionID)
+		require.Equal(t, connection.StateNameCompleted, response.Result.State)
+		require.Equal(t, threadID, response.Thread.ThreadID)
+		require.Equal(t, "https://didcomm.org/present-proof/2.0", response.Thread.Protocol)
+	})
+
+	t.Run("test query connection by thread validation errors", func(t *testing.T) {
+		for request, errMsg := range map[string]string{
+			`--`: "",
+			`{}`: errEmptyThreadID,
+		} {
+			var b bytes.Buffer
+			cmdErr := cmd.QueryConnectionByThread(&b, bytes.NewBufferString(request))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+			require.Contains(t, cmdErr.Error(), errMsg)
+		}
+	})
+
+	t.Run("test query connection by thread not found", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.QueryConnectionByThread(&b, bytes.NewBufferString(`{"thread_id":"thread-unknown"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, QueryConnectionByThreadErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), storage.ErrDataNotFound.Error())
+
+		cmdErr = cmd.QueryConnectionByThread(&b, bytes.NewBufferString(`{"thread_id":"thread-removed"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, QueryConnectionByThreadErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "get connection")
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/client/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 // CreateInvitationArgs model
@@ -24,6 +25,12 @@ type CreateInvitationArgs struct {
 
 	// Optional public DID to be used in invitation
 	Public string `json:"public,omitempty"`
+
+	// Optional absolute http(s) URL of the invitation URL, defaults to the agent's endpoint
+	InvitationURLBase string `json:"invitation_url_base,omitempty"`
+
+	// Optional query parameter carrying the invitation in the invitation URL, either 'c_i' (default) or 'oob'
+	InvitationURLEncoding string `json:"invitation_url_encoding,omitempty"`
 }
 
 // CreateInvitationResponse model
@@ -300,6 +307,24 @@ type ConnectionIDArg struct {
 	ID string `json:"id"`
 }
 
+// ThreadIDArg model
+//
+// This is used for querying connection by thread ID
+//
+type ThreadIDArg struct {
+	// Thread ID
+	ThreadID string `json:"thread_id"`
+}
+
+// QueryConnectionByThreadResponse model
+//
+// This is used for returning the connection a thread belongs to, along with the protocol state of the thread
+//
+type QueryConnectionByThreadResponse struct {
+	Result *didexchange.Connection  `json:"result,omitempty"`
+	Thread *connection.ThreadRecord `json:"thread,omitempty"`
+}
+
 // CreateConnectionRequest model
 //
 type CreateConnectionRequest struct {
@@ -319,3 +344,55 @@ type DIDDocument struct {
 	ID       string          `json:"id"`
 	Contents json.RawMessage `json:"contents"`
 }
+
+// RotateDIDArgs model
+//
+// This is used for rotating the DID used by the agent in a connection
+//
+type RotateDIDArgs struct {
+	// Connection ID
+	ID string `json:"id"`
+
+	// The DID replacing the DID of the agent in the connection, it has to be resolvable
+	NewDID string `json:"new_did"`
+}
+
+// UpdateServiceEndpointArgs model
+//
+// This is used for updating the service endpoint of the DIDs used by the agent in the connections
+//
+type UpdateServiceEndpointArgs struct {
+	// The new DIDComm service endpoint of the agent
+	ServiceEndpoint string `json:"service_endpoint"`
+}
+
+// UpdateServiceEndpointResponse model
+//
+// This is used for returning the connections rotated to the DIDs of the updated service endpoint
+//
+type UpdateServiceEndpointResponse struct {
+	// The IDs of the connections rotated to the new DIDs
+	ConnectionIDs []string `json:"connection_ids"`
+
+	// The connections which couldn't be rotated
+	Failures []ConnectionFailure `json:"failures"`
+}
+
+// ConnectionFailure model
+//
+// This is used for returning the failure of a command on a connection
+//
+type ConnectionFailure struct {
+	// The ID of the connection
+	ConnectionID string `json:"connection_id"`
+
+	// The failure
+	Error string `json:"error"`
+}
+
+// rotateDIDMsg is the did-rotate message notifying the other party of a connection of the new DID of the agent.
+type rotateDIDMsg struct {
+	ID    string `json:"@id"`
+	Type  string `json:"@type"`
+	ToDID string `json:"to_did"`
+}
@@ -0,0 +1,109 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didexchange
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+)
+
+const (
+	// rotateServiceName is the name of the message service of the did-rotate messages.
+	rotateServiceName = "did-rotate"
+
+	rotateHandleInbound = "rotateHandleInbound"
+)
+
+// rotateMessageService handles the did-rotate messages [RFC-0794] of the other parties of the connections: the DID
+// of the other party is replaced by the DID it rotated to in the connection.
+type rotateMessageService struct {
+	vdriRegistry    vdriapi.Registry
+	connectionStore *connection.Recorder
+	didConnections  *didstore.ConnectionStore
+}
+
+// registerRotateService registers the did-rotate message service of the connections of the command with the message
+// handler of the command, if any.
+func (c *Command) registerRotateService() error {
+	if c.msgHandler == nil {
+		return nil
+	}
+
+	err := c.msgHandler.Register(&rotateMessageService{
+		vdriRegistry:    c.ctx.VDRIRegistry(),
+		connectionStore: c.connectionStore,
+		didConnections:  c.didConnections,
+	})
+	if err != nil {
+		return fmt.Errorf("register did-rotate message service: %w", err)
+	}
+
+	return nil
+}
+
+// Name of the did-rotate message service.
+func (s *rotateMessageService) Name() string {
+	return rotateServiceName
+}
+
+// Accept is acceptance criteria for the did-rotate message service.
+func (s *rotateMessageService) Accept(msgType string, purpose []string) bool {
+	return msgType == RotateDIDMsgType
+}
+
+// HandleInbound applies the DID rotated to by the sender of the message to the connection of myDID and theirDID.
+func (s *rotateMessageService) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	rotate := rotateDIDMsg{}
+
+	err := msg.Decode(&rotate)
+	if err != nil {
+		return "", fmt.Errorf("decode rotate message: %w", err)
+	}
+
+	if rotate.ToDID == "" {
+		return "", errors.New(errEmptyToDID)
+	}
+
+	connectionID, err := s.connectionStore.GetConnectionIDByDIDs(myDID, theirDID)
+	if err != nil {
+		return "", fmt.Errorf("find connection: %w", err)
+	}
+
+	record, err := s.connectionStore.GetConnectionRecord(connectionID)
+	if err != nil {
+		return "", fmt.Errorf("get connection: %w", err)
+	}
+
+	newDoc, err := s.vdriRegistry.Resolve(rotate.ToDID)
+	if err != nil {
+		return "", fmt.Errorf("resolve rotated DID: %w", err)
+	}
+
+	// the messages of the other party from the keys of the new DID are mapped to it
+	err = s.didConnections.SaveDIDFromDoc(newDoc)
+	if err != nil {
+		return "", fmt.Errorf("save DID connection: %w", err)
+	}
+
+	record.TheirDID = rotate.ToDID
+
+	err = s.connectionStore.SaveConnectionRecord(record)
+	if err != nil {
+		return "", fmt.Errorf("save connection: %w", err)
+	}
+
+	logutil.LogDebug(logger, CommandName, rotateHandleInbound, successString,
+		logutil.CreateKeyValueString(connectionIDString, connectionID))
+
+	return "", nil
+}
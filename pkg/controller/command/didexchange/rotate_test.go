@@ -0,0 +1,136 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didexchange
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+)
+
+func TestRotateMessageService_HandleInbound(t *testing.T) {
+	const (
+		connID   = "1234"
+		myDID    = "did:example:my"
+		theirDID = "did:example:their"
+		newDID   = "did:example:new"
+	)
+
+	newKey := []byte("new-public-key")
+
+	prepare := func(t *testing.T) (*rotateMessageService, *mockstore.MockStore, *mockvdri.MockVDRIRegistry) {
+		t.Helper()
+
+		prov := mockProvider()
+		store := &mockstore.MockStore{Store: make(map[string][]byte)}
+		prov.StorageProviderValue = &mockstore.MockStoreProvider{Store: store}
+		prov.ProtocolStateStorageProviderValue = &mockstore.MockStoreProvider{Store: store}
+
+		registry := &mockvdri.MockVDRIRegistry{ResolveValue: &did.Doc{
+			ID:        newDID,
+			PublicKey: []did.PublicKey{{ID: newDID + "#key-1", Value: newKey}},
+		}}
+		prov.VDRIRegistryValue = registry
+
+		registrar := msghandler.NewRegistrar()
+
+		_, err := New(prov, nil, "", false, WithMessageHandler(registrar))
+		require.NoError(t, err)
+
+		svc, ok := registrar.Services()[0].(*rotateMessageService)
+		require.True(t, ok)
+
+		require.NoError(t, svc.connectionStore.SaveConnectionRecord(&connection.Record{
+			ConnectionID: connID,
+			State:        connection.StateNameCompleted,
+			MyDID:        myDID,
+			TheirDID:     theirDID,
+		}))
+
+		return svc, store, registry
+	}
+
+	rotateMsg := func(toDID string) service.DIDCommMsgMap {
+		return service.NewDIDCommMsgMap(&rotateDIDMsg{ID: "msg-1", Type: RotateDIDMsgType, ToDID: toDID})
+	}
+
+	t.Run("test rotate DID of the other party", func(t *testing.T) {
+		svc, store, _ := prepare(t)
+
+		_, err := svc.HandleInbound(rotateMsg(newDID), myDID, theirDID)
+		require.NoError(t, err)
+
+		record := &connection.Record{}
+		require.NoError(t, json.Unmarshal(store.Store["conn_"+connID], record))
+		require.Equal(t, myDID, record.MyDID)
+		require.Equal(t, newDID, record.TheirDID)
+
+		// the connection is found by the new DID
+		connectionID, err := svc.connectionStore.GetConnectionIDByDIDs(myDID, newDID)
+		require.NoError(t, err)
+		require.Equal(t, connID, connectionID)
+
+		// and the keys of the new DID are mapped to it
+		didConnections, err := didstore.NewConnectionStore(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{Store: store},
+			VDRIRegistryValue:    svc.vdriRegistry,
+		})
+		require.NoError(t, err)
+
+		mappedDID, err := didConnections.GetDID(base58.Encode(newKey))
+		require.NoError(t, err)
+		require.Equal(t, newDID, mappedDID)
+	})
+
+	t.Run("test rotate DID of the other party failures", func(t *testing.T) {
+		svc, store, registry := prepare(t)
+
+		_, err := svc.HandleInbound(service.DIDCommMsgMap{"@type": RotateDIDMsgType, "to_did": []int{1}}, myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode rotate message")
+
+		_, err = svc.HandleInbound(rotateMsg(""), myDID, theirDID)
+		require.EqualError(t, err, errEmptyToDID)
+
+		_, err = svc.HandleInbound(rotateMsg(newDID), myDID, "did:example:unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "find connection")
+
+		registry.ResolveValue = nil
+		registry.ResolveErr = errors.New("resolve error")
+
+		_, err = svc.HandleInbound(rotateMsg(newDID), myDID, theirDID)
+		require.EqualError(t, err, "resolve rotated DID: resolve error")
+
+		registry.ResolveErr = nil
+		registry.ResolveValue = &did.Doc{ID: newDID}
+		store.ErrPut = errors.New("put error")
+
+		_, err = svc.HandleInbound(rotateMsg(newDID), myDID, theirDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "save connection")
+
+		// the connection isn't rotated on failures
+		store.ErrPut = nil
+
+		record := &connection.Record{}
+		require.NoError(t, json.Unmarshal(store.Store["conn_"+connID], record))
+		require.Equal(t, theirDID, record.TheirDID)
+	})
+}
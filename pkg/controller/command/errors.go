@@ -64,6 +64,18 @@ const (
 
 	// Outofband error group for outofband command errors.
 	Outofband = 11000
+
+	// Actions error group for protocol actions command errors.
+	Actions = 12000
+
+	// Wallet error group for wallet export and import command errors.
+	Wallet = 13000
+
+	// Metrics error group for agent metrics command errors.
+	Metrics = 14000
+
+	// AutoAccept error group for auto-accept policies command errors.
+	AutoAccept = 15000
 )
 
 // Error is the  interface for representing an command error condition, with the nil value representing no error.
@@ -15,11 +15,14 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/client/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/autoaccept"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 var logger = log.New("aries-framework/controller/issuecredential")
@@ -88,25 +91,46 @@ const (
 	errEmptyIssueCredential   = "empty IssueCredential"
 	errEmptyProposeCredential = "empty ProposeCredential"
 	errEmptyRequestCredential = "empty RequestCredential"
+	errEmptyAttributeName     = "empty credential preview attribute name"
+	errDuplicateAttribute     = "duplicate credential preview attribute `%s`"
+	errConnectionNotFound     = "connection `%s` not found"
+	errConnectionNotCompleted = "connection `%s` is not completed"
+	errPIIDNotFound           = "protocol instance `%s` not found"
+	errNotOfferReceived       = "protocol instance `%s` is not in the offer-received state"
 	// log constants
-	successString = "success"
+	successString      = "success"
+	connectionIDString = "connectionID"
 
 	_actions = "_actions"
 	_states  = "_states"
 )
 
+// Provider contains dependencies for the issue credential controller command and is typically created by using
+// aries.Context().
+type Provider interface {
+	issuecredential.Provider
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
 // Command is controller command for issue credential.
 type Command struct {
-	client *issuecredential.Client
+	client      *issuecredential.Client
+	connections *connection.Lookup
 }
 
 // New returns new issue credential controller command instance.
-func New(ctx issuecredential.Provider, notifier command.Notifier) (*Command, error) {
+func New(ctx Provider, notifier command.Notifier) (*Command, error) {
 	client, err := issuecredential.New(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create a client: %w", err)
 	}
 
+	connections, err := connection.NewLookup(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create connection lookup: %w", err)
+	}
+
 	// creates action channel
 	actions := make(chan service.DIDCommAction)
 	// registers action channel to listen for events
@@ -121,11 +145,17 @@ func New(ctx issuecredential.Provider, notifier command.Notifier) (*Command, err
 		return nil, fmt.Errorf("register msg event: %w", err)
 	}
 
+	// the actions are auto-accepted according to the policy of the protocol
+	policies, err := autoaccept.NewPolicies(ctx.StorageProvider(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create auto-accept policies: %w", err)
+	}
+
 	obs := webnotifier.NewObserver(notifier)
-	obs.RegisterAction(protocol.Name+_actions, actions)
+	obs.RegisterAction(protocol.Name+_actions, policies.Handle(protocol.Name, actions))
 	obs.RegisterStateMsg(protocol.Name+_states, states)
 
-	return &Command{client: client}, nil
+	return &Command{client: client, connections: connections}, nil
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -165,8 +195,8 @@ func (c *Command) Actions(rw io.Writer, _ io.Reader) command.Error {
 	return nil
 }
 
-// SendOffer is used by the Issuer to send an offer.
-// nolint: dupl
+// SendOffer is used by the Issuer to send an offer, either between the given DIDs or over the given completed
+// connection.
 func (c *Command) SendOffer(rw io.Writer, req io.Reader) command.Error {
 	var args SendOfferArgs
 
@@ -175,6 +205,17 @@ func (c *Command) SendOffer(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
+	if args.ConnectionID != "" {
+		record, cmdErr := c.completedConnection(args.ConnectionID)
+		if cmdErr != nil {
+			logutil.LogInfo(logger, CommandName, SendOffer, cmdErr.Error(),
+				logutil.CreateKeyValueString(connectionIDString, args.ConnectionID))
+			return cmdErr
+		}
+
+		args.MyDID, args.TheirDID = record.MyDID, record.TheirDID
+	}
+
 	if args.MyDID == "" {
 		logutil.LogDebug(logger, CommandName, SendOffer, errEmptyMyDID)
 		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyDID))
@@ -190,6 +231,11 @@ func (c *Command) SendOffer(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyOfferCredential))
 	}
 
+	if err := validatePreview(&args.OfferCredential.CredentialPreview); err != nil {
+		logutil.LogDebug(logger, CommandName, SendOffer, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
 	piid, err := c.client.SendOffer(args.OfferCredential, args.MyDID, args.TheirDID)
 	if err != nil {
 		logutil.LogError(logger, CommandName, SendOffer, err.Error())
@@ -203,6 +249,66 @@ func (c *Command) SendOffer(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// completedConnection returns the record of the completed connection with the given ID.
+func (c *Command) completedConnection(connectionID string) (*connection.Record, command.Error) {
+	record, err := c.connections.GetConnectionRecord(connectionID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errConnectionNotFound, connectionID))
+	}
+
+	if err != nil {
+		return nil, command.NewExecuteError(SendOfferErrorCode, fmt.Errorf("get connection: %w", err))
+	}
+
+	if record.State != connection.StateNameCompleted {
+		return nil, command.NewValidationError(InvalidRequestErrorCode,
+			fmt.Errorf(errConnectionNotCompleted, connectionID))
+	}
+
+	return record, nil
+}
+
+// offerReceived checks that the protocol instance with the given PIID waits for an offer to be accepted.
+func (c *Command) offerReceived(piID string) command.Error {
+	actions, err := c.client.Actions()
+	if err != nil {
+		return command.NewExecuteError(AcceptOfferErrorCode, fmt.Errorf("get actions: %w", err))
+	}
+
+	for _, action := range actions {
+		if action.PIID != piID {
+			continue
+		}
+
+		if action.Msg.Type() != protocol.OfferCredentialMsgType {
+			return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errNotOfferReceived, piID))
+		}
+
+		return nil
+	}
+
+	return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errPIIDNotFound, piID))
+}
+
+// validatePreview checks that the credential preview attributes are named, and named once.
+func validatePreview(preview *protocol.PreviewCredential) error {
+	names := make(map[string]struct{}, len(preview.Attributes))
+
+	for _, attr := range preview.Attributes {
+		if attr.Name == "" {
+			return errors.New(errEmptyAttributeName)
+		}
+
+		if _, ok := names[attr.Name]; ok {
+			return fmt.Errorf(errDuplicateAttribute, attr.Name)
+		}
+
+		names[attr.Name] = struct{}{}
+	}
+
+	return nil
+}
+
 // SendProposal is used by the Holder to send a proposal.
 // nolint: dupl
 func (c *Command) SendProposal(rw io.Writer, req io.Reader) command.Error {
@@ -385,6 +491,11 @@ func (c *Command) AcceptOffer(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
 	}
 
+	if cmdErr := c.offerReceived(args.PIID); cmdErr != nil {
+		logutil.LogError(logger, CommandName, AcceptOffer, cmdErr.Error())
+		return cmdErr
+	}
+
 	if err := c.client.AcceptOffer(args.PIID); err != nil {
 		logutil.LogError(logger, CommandName, AcceptOffer, err.Error())
 		return command.NewExecuteError(AcceptOfferErrorCode, err)
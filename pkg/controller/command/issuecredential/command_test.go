@@ -17,13 +17,35 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/client/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/issuecredential"
 	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 const jsonPayload = `{"piid":"id"}`
 
+// connectionsProvider adds the stores of the connection records to an issue credential client provider.
+type connectionsProvider struct {
+	issuecredential.Provider
+	storageProvider storage.Provider
+}
+
+func withConnections(p issuecredential.Provider) *connectionsProvider {
+	return &connectionsProvider{Provider: p, storageProvider: mem.NewProvider()}
+}
+
+func (p *connectionsProvider) StorageProvider() storage.Provider {
+	return p.storageProvider
+}
+
+func (p *connectionsProvider) ProtocolStateStorageProvider() storage.Provider {
+	return p.storageProvider
+}
+
 func TestNew(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -36,7 +58,7 @@ func TestNew(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -48,7 +70,7 @@ func TestNew(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.EqualError(t, err, "cannot create a client: cast service to issuecredential service failed")
 		require.Nil(t, cmd)
 	})
@@ -60,7 +82,7 @@ func TestNew(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.EqualError(t, err, "register action event: error")
 		require.Nil(t, cmd)
 	})
@@ -73,7 +95,7 @@ func TestNew(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.EqualError(t, err, "register msg event: error")
 		require.Nil(t, cmd)
 	})
@@ -99,7 +121,7 @@ func TestCommand_Actions(t *testing.T) {
 
 		service.EXPECT().Actions().Return(toProtocolActions(expected.Actions), nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -114,7 +136,7 @@ func TestCommand_Actions(t *testing.T) {
 	t.Run("Error", func(t *testing.T) {
 		service.EXPECT().Actions().Return(nil, errors.New("some error message"))
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -138,7 +160,7 @@ func TestCommand_SendOffer(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -151,7 +173,7 @@ func TestCommand_SendOffer(t *testing.T) {
 	})
 
 	t.Run("Empty MyDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -165,7 +187,7 @@ func TestCommand_SendOffer(t *testing.T) {
 	})
 
 	t.Run("Empty TheirDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -179,7 +201,7 @@ func TestCommand_SendOffer(t *testing.T) {
 	})
 
 	t.Run("Empty OfferCredential", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -204,7 +226,7 @@ func TestCommand_SendOffer(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -227,7 +249,7 @@ func TestCommand_SendOffer(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -235,6 +257,114 @@ func TestCommand_SendOffer(t *testing.T) {
 		const jsonPayload = `{"my_did":"id","their_did":"id","offer_credential":{}}`
 		require.NoError(t, cmd.SendOffer(&b, bytes.NewBufferString(jsonPayload)))
 	})
+
+	t.Run("Invalid credential preview", func(t *testing.T) {
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendOffer(&b, bytes.NewBufferString(`{"my_did":"id","their_did":"id",
+			"offer_credential":{"credential_preview":{"attributes":[{"value":"Alice"}]}}}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyAttributeName)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.SendOffer(&b, bytes.NewBufferString(`{"my_did":"id","their_did":"id",
+			"offer_credential":{"credential_preview":{"attributes":[{"name":"first_name"},{"name":"first_name"}]}}}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "duplicate credential preview attribute `first_name`")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Success (connection)", func(t *testing.T) {
+		protocolService := mocks.NewMockProtocolService(ctrl)
+		protocolService.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		protocolService.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		protocolService.EXPECT().HandleOutbound(gomock.Any(), "my-did", "their-did").
+			DoAndReturn(expectOfferCredential(t, []protocol.Attribute{{Name: "first_name", Value: "Alice"}}))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(protocolService, nil)
+
+		prov := withConnections(provider)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn-id",
+			State:        connection.StateNameCompleted,
+			MyDID:        "my-did",
+			TheirDID:     "their-did",
+		})
+
+		cmd, err := New(prov, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"connection_id":"conn-id",
+			"offer_credential":{"credential_preview":{"attributes":[{"name":"first_name","value":"Alice"}]}}}`
+		require.NoError(t, cmd.SendOffer(&b, bytes.NewBufferString(jsonPayload)))
+		require.JSONEq(t, `{"piid":"piid"}`, b.String())
+	})
+
+	t.Run("Connection errors", func(t *testing.T) {
+		prov := withConnections(provider)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "invited-id",
+			State:        "invited",
+			MyDID:        "my-did",
+			TheirDID:     "their-did",
+		})
+
+		cmd, err := New(prov, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendOffer(&b, bytes.NewBufferString(`{"connection_id":"missing-id","offer_credential":{}}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "connection `missing-id` not found")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.SendOffer(&b, bytes.NewBufferString(`{"connection_id":"invited-id","offer_credential":{}}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "connection `invited-id` is not completed")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+}
+
+// expectOfferCredential checks the message sent by the client is an offer credential previewing the given attributes.
+func expectOfferCredential(t *testing.T,
+	attributes []protocol.Attribute) func(service.DIDCommMsg, string, string) (string, error) {
+	return func(msg service.DIDCommMsg, _, _ string) (string, error) {
+		require.Equal(t, protocol.OfferCredentialMsgType, msg.Type())
+
+		offer := &protocol.OfferCredential{}
+		require.NoError(t, msg.Decode(offer))
+		require.Equal(t, attributes, offer.CredentialPreview.Attributes)
+
+		return "piid", nil
+	}
+}
+
+// offerAction returns the pending action of the protocol instance piID which received an offer.
+func offerAction(piID string) protocol.Action {
+	return pendingAction(piID, protocol.OfferCredential{Type: protocol.OfferCredentialMsgType})
+}
+
+func pendingAction(piID string, msg interface{}) protocol.Action {
+	return protocol.Action{PIID: piID, Msg: service.NewDIDCommMsgMap(msg)}
+}
+
+func saveConnection(t *testing.T, prov *connectionsProvider, record *connection.Record) {
+	t.Helper()
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(record))
 }
 
 func TestCommand_SendProposal(t *testing.T) {
@@ -249,7 +379,7 @@ func TestCommand_SendProposal(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -262,7 +392,7 @@ func TestCommand_SendProposal(t *testing.T) {
 	})
 
 	t.Run("Empty MyDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -276,7 +406,7 @@ func TestCommand_SendProposal(t *testing.T) {
 	})
 
 	t.Run("Empty TheirDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -290,7 +420,7 @@ func TestCommand_SendProposal(t *testing.T) {
 	})
 
 	t.Run("Empty ProposeCredential", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -315,7 +445,7 @@ func TestCommand_SendProposal(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -338,7 +468,7 @@ func TestCommand_SendProposal(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -360,7 +490,7 @@ func TestCommand_SendRequest(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -373,7 +503,7 @@ func TestCommand_SendRequest(t *testing.T) {
 	})
 
 	t.Run("Empty MyDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -387,7 +517,7 @@ func TestCommand_SendRequest(t *testing.T) {
 	})
 
 	t.Run("Empty TheirDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -401,7 +531,7 @@ func TestCommand_SendRequest(t *testing.T) {
 	})
 
 	t.Run("Empty RequestCredential", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -426,7 +556,7 @@ func TestCommand_SendRequest(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -449,7 +579,7 @@ func TestCommand_SendRequest(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -471,7 +601,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -484,7 +614,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -498,7 +628,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 	})
 
 	t.Run("Empty OfferCredential", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -520,7 +650,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -543,7 +673,7 @@ func TestCommand_AcceptProposal(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -565,7 +695,7 @@ func TestCommand_NegotiateProposal(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -578,7 +708,7 @@ func TestCommand_NegotiateProposal(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -592,7 +722,7 @@ func TestCommand_NegotiateProposal(t *testing.T) {
 	})
 
 	t.Run("Empty OfferCredential", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -614,7 +744,7 @@ func TestCommand_NegotiateProposal(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -637,7 +767,7 @@ func TestCommand_NegotiateProposal(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -659,7 +789,7 @@ func TestCommand_DeclineProposal(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -672,7 +802,7 @@ func TestCommand_DeclineProposal(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -694,7 +824,7 @@ func TestCommand_DeclineProposal(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -716,7 +846,7 @@ func TestCommand_DeclineProposal(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -737,7 +867,7 @@ func TestCommand_AcceptOffer(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -750,7 +880,7 @@ func TestCommand_AcceptOffer(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -763,16 +893,85 @@ func TestCommand_AcceptOffer(t *testing.T) {
 		require.Equal(t, command.ValidationError, cmdErr.Type())
 	})
 
+	t.Run("Unknown PIID", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().Actions().Return([]protocol.Action{offerAction("other-id")}, nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptOffer(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "protocol instance `id` not found")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Not in the offer-received state", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().Actions().Return([]protocol.Action{
+			pendingAction("id", protocol.RequestCredential{Type: protocol.RequestCredentialMsgType}),
+		}, nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptOffer(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "protocol instance `id` is not in the offer-received state")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Actions (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().Actions().Return(nil, errors.New("some error message"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptOffer(&b, bytes.NewBufferString(jsonPayload))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, AcceptOfferErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
 	t.Run("AcceptOffer (error)", func(t *testing.T) {
 		service := mocks.NewMockProtocolService(ctrl)
 		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
 		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().Actions().Return([]protocol.Action{offerAction("id")}, nil)
 		service.EXPECT().ActionContinue(gomock.Any(), gomock.Any()).Return(errors.New("some error message"))
 
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -789,12 +988,13 @@ func TestCommand_AcceptOffer(t *testing.T) {
 		service := mocks.NewMockProtocolService(ctrl)
 		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
 		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
-		service.EXPECT().ActionContinue(gomock.Any(), gomock.Any())
+		service.EXPECT().Actions().Return([]protocol.Action{offerAction("other-id"), offerAction("id")}, nil)
+		service.EXPECT().ActionContinue("id", gomock.Any())
 
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -815,7 +1015,7 @@ func TestCommand_AcceptProblemReport(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -828,7 +1028,7 @@ func TestCommand_AcceptProblemReport(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -850,7 +1050,7 @@ func TestCommand_AcceptProblemReport(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -872,7 +1072,7 @@ func TestCommand_AcceptProblemReport(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -893,7 +1093,7 @@ func TestCommand_DeclineOffer(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -906,7 +1106,7 @@ func TestCommand_DeclineOffer(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -928,7 +1128,7 @@ func TestCommand_DeclineOffer(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -950,7 +1150,7 @@ func TestCommand_DeclineOffer(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -971,7 +1171,7 @@ func TestCommand_AcceptRequest(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -984,7 +1184,7 @@ func TestCommand_AcceptRequest(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -998,7 +1198,7 @@ func TestCommand_AcceptRequest(t *testing.T) {
 	})
 
 	t.Run("Empty IssueCredential", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1020,7 +1220,7 @@ func TestCommand_AcceptRequest(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1043,7 +1243,7 @@ func TestCommand_AcceptRequest(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1065,7 +1265,7 @@ func TestCommand_DeclineRequest(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1078,7 +1278,7 @@ func TestCommand_DeclineRequest(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1100,7 +1300,7 @@ func TestCommand_DeclineRequest(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1122,7 +1322,7 @@ func TestCommand_DeclineRequest(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1143,7 +1343,7 @@ func TestCommand_AcceptCredential(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1156,7 +1356,7 @@ func TestCommand_AcceptCredential(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1178,7 +1378,7 @@ func TestCommand_AcceptCredential(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1200,7 +1400,7 @@ func TestCommand_AcceptCredential(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1221,7 +1421,7 @@ func TestCommand_DeclineCredential(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1234,7 +1434,7 @@ func TestCommand_DeclineCredential(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1256,7 +1456,7 @@ func TestCommand_DeclineCredential(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1278,7 +1478,7 @@ func TestCommand_DeclineCredential(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
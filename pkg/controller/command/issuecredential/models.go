@@ -192,6 +192,9 @@ type SendOfferArgs struct {
 	MyDID string `json:"my_did"`
 	// TheirDID receiver's did
 	TheirDID string `json:"their_did"`
+	// ConnectionID of the completed connection to send the offer over, MyDID and TheirDID are taken from
+	// the connection if set
+	ConnectionID string `json:"connection_id,omitempty"`
 	// OfferCredential is a message describing the credential intend to offer and
 	// possibly the price they expect to be paid.
 	OfferCredential *issuecredential.OfferCredential `json:"offer_credential"`
@@ -8,14 +8,18 @@ package kms
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 
+	"github.com/btcsuite/btcd/btcec"
+
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -31,6 +35,12 @@ const (
 	CreateKeySetError
 	// ImportKeyError is for failures while importing key.
 	ImportKeyError
+	// SignJWSError is for failures while signing JWS.
+	SignJWSError
+	// ExportPublicKeyError is for failures while exporting public key.
+	ExportPublicKeyError
+	// ExportKeySetError is for failures while exporting key set.
+	ExportKeySetError
 )
 
 // constants for KMS commands
@@ -41,15 +51,31 @@ const (
 	// command methods
 	CreateKeySetCommandMethod = "CreateKeySet"
 	ImportKeyCommandMethod    = "ImportKey"
+	SignJWSCommandMethod      = "SignJWS"
+	ExportPublicKeyMethod     = "ExportPublicKey"
+	ExportKeySetCommandMethod = "ExportKeySet"
 
 	// error messages
 	errEmptyKeyType = "key type is mandatory"
 	errEmptyKeyID   = "key id is mandatory"
+
+	// JWS algorithms supported by SignJWS
+	edDSAAlg  = "EdDSA"
+	es256KAlg = "ES256K"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
 type provider interface {
 	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+}
+
+// signingKeyTypes are the key types of the key sets created by CreateKeySet.
+// nolint:gochecknoglobals
+var signingKeyTypes = map[kms.KeyType]bool{
+	kms.ED25519Type:            true,
+	kms.ECDSAP256TypeDER:       true,
+	kms.ECDSAP256TypeIEEEP1363: true,
 }
 
 // Command contains command operations provided by verifiable credential controller.
@@ -75,10 +101,14 @@ func (o *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(CommandName, CreateKeySetCommandMethod, o.CreateKeySet),
 		cmdutil.NewCommandHandler(CommandName, ImportKeyCommandMethod, o.ImportKey),
+		cmdutil.NewCommandHandler(CommandName, SignJWSCommandMethod, o.SignJWS),
+		cmdutil.NewCommandHandler(CommandName, ExportPublicKeyMethod, o.ExportPublicKey),
+		cmdutil.NewCommandHandler(CommandName, ExportKeySetCommandMethod, o.ExportKeySet),
 	}
 }
 
-// CreateKeySet create a new public/private encryption and signature key pairs set.
+// CreateKeySet create a new public/private signature key pairs set (Ed25519 or ECDSA P-256), only the public key is
+// returned.
 func (o *Command) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
 	var request CreateKeySetRequest
 
@@ -93,6 +123,13 @@ func (o *Command) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyType))
 	}
 
+	if !signingKeyTypes[kms.KeyType(request.KeyType)] {
+		logutil.LogDebug(logger, CommandName, CreateKeySetCommandMethod, "unsupported key type : "+request.KeyType)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("key type %s not supported, supported "+
+			"key types are %s, %s and %s", request.KeyType, kms.ED25519, kms.ECDSAP256DER, kms.ECDSAP256IEEEP1363))
+	}
+
 	keyID, pubKeyBytes, err := o.ctx.KMS().CreateAndExportPubKeyBytes(kms.KeyType(request.KeyType))
 	if err != nil {
 		logutil.LogError(logger, CommandName, CreateKeySetCommandMethod, err.Error())
@@ -109,7 +146,42 @@ func (o *Command) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
-// ImportKey import key.
+// ExportPublicKey exports the public key of the key set of the given key ID, the private key never leaves the KMS.
+func (o *Command) ExportPublicKey(rw io.Writer, req io.Reader) command.Error {
+	var request ExportPublicKeyRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportPublicKeyMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, ExportPublicKeyMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	pubKeyBytes, err := o.ctx.KMS().ExportPubKeyBytes(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ExportPublicKeyMethod, err.Error(),
+			logutil.CreateKeyValueString("keyID", request.KeyID))
+
+		return command.NewExecuteError(ExportPublicKeyError, fmt.Errorf("export public key of '%s' : %w",
+			request.KeyID, err))
+	}
+
+	command.WriteNillableResponse(rw, &ExportPublicKeyResponse{
+		KeyID:     request.KeyID,
+		PublicKey: base64.RawURLEncoding.EncodeToString(pubKeyBytes),
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportPublicKeyMethod, "success")
+
+	return nil
+}
+
+// ImportKey imports the private keys of an encrypted JWK set exported by ExportKeySet (see ImportKeyRequest), or a
+// private key given as a plain JWK.
 func (o *Command) ImportKey(rw io.Writer, req io.Reader) command.Error {
 	buf := new(bytes.Buffer)
 	_, err := buf.ReadFrom(req)
@@ -119,6 +191,11 @@ func (o *Command) ImportKey(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
 	}
 
+	var request ImportKeyRequest
+	if json.Unmarshal(buf.Bytes(), &request) == nil && request.Encrypted != nil {
+		return o.importKeySet(rw, &request)
+	}
+
 	var jwk jose.JWK
 	if errUnmarshal := jwk.UnmarshalJSON(buf.Bytes()); errUnmarshal != nil {
 		logutil.LogInfo(logger, CommandName, ImportKeyCommandMethod, errUnmarshal.Error())
@@ -130,27 +207,133 @@ func (o *Command) ImportKey(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
 	}
 
-	var kType kms.KeyType
+	kType, err := importKeyType(&jwk)
+	if err != nil {
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	_, _, err = o.importKey(jwk.Key, kType, kms.WithKeyID(jwk.KeyID))
+	if err != nil {
+		logutil.LogError(logger, CommandName, ImportKeyCommandMethod, err.Error())
+		return command.NewExecuteError(ImportKeyError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
 
+	logutil.LogDebug(logger, CommandName, ImportKeyCommandMethod, "success")
+
+	return nil
+}
+
+// importKeyType returns the KMS key type of the imported JWK.
+func importKeyType(jwk *jose.JWK) (kms.KeyType, error) {
 	switch jwk.Crv {
 	case "Ed25519":
-		kType = kms.ED25519Type
+		return kms.ED25519Type, nil
 	case "P-256":
-		kType = kms.ECDSAP256TypeIEEEP1363
+		return kms.ECDSAP256TypeIEEEP1363, nil
 	default:
+		return "", fmt.Errorf("import key type not supported %s", jwk.Crv)
+	}
+}
+
+// SignJWS signs the payload as a compact JWS with the key of the given key ID, the signature algorithm is set by
+// the 'alg' header (EdDSA or ES256K). The 'kid' header defaults to the key ID.
+func (o *Command) SignJWS(rw io.Writer, req io.Reader) command.Error {
+	var request SignJWSRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SignJWSCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, SignJWSCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	headers := jose.Headers(request.Headers)
+	if headers == nil {
+		headers = jose.Headers{}
+	}
+
+	alg, _ := headers.Algorithm() // nolint: errcheck
+	if alg != edDSAAlg && alg != es256KAlg {
+		logutil.LogDebug(logger, CommandName, SignJWSCommandMethod, "unsupported alg : "+alg)
+
 		return command.NewValidationError(InvalidRequestErrorCode,
-			fmt.Errorf("import key type not supported %s", jwk.Crv))
+			fmt.Errorf("JWS algorithm '%s' not supported, supported algorithms are %s and %s", alg, edDSAAlg, es256KAlg))
 	}
 
-	_, _, err = o.importKey(jwk.Key, kType, kms.WithKeyID(jwk.KeyID))
+	if _, ok := headers.KeyID(); !ok {
+		headers[jose.HeaderKeyID] = request.KeyID
+	}
+
+	signer, err := o.jwsSigner(request.KeyID, alg)
 	if err != nil {
-		logutil.LogError(logger, CommandName, ImportKeyCommandMethod, err.Error())
-		return command.NewExecuteError(ImportKeyError, err)
+		logutil.LogError(logger, CommandName, SignJWSCommandMethod, err.Error(),
+			logutil.CreateKeyValueString("keyID", request.KeyID))
+
+		return command.NewExecuteError(SignJWSError, err)
 	}
 
-	command.WriteNillableResponse(rw, nil, logger)
+	jws, err := jose.NewJWS(headers, nil, []byte(request.Payload), signer)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SignJWSCommandMethod, err.Error())
+		return command.NewExecuteError(SignJWSError, err)
+	}
 
-	logutil.LogDebug(logger, CommandName, ImportKeyCommandMethod, "success")
+	compactJWS, err := jws.SerializeCompact(false)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SignJWSCommandMethod, err.Error())
+		return command.NewExecuteError(SignJWSError, fmt.Errorf("serialize JWS : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &SignJWSResponse{JWS: compactJWS}, logger)
+
+	logutil.LogDebug(logger, CommandName, SignJWSCommandMethod, "success")
 
 	return nil
 }
+
+// jwsSigner returns the signer of the key of keyID, the key must match the alg JWS algorithm.
+func (o *Command) jwsSigner(keyID, alg string) (jose.Signer, error) {
+	pubKey, err := o.ctx.KMS().ExportPubKeyBytes(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("key '%s' not found : %w", keyID, err)
+	}
+
+	switch alg {
+	case edDSAAlg:
+		if len(pubKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("key '%s' is not an Ed25519 key required by %s", keyID, alg)
+		}
+	case es256KAlg:
+		if _, err = btcec.ParsePubKey(pubKey, btcec.S256()); err != nil {
+			return nil, fmt.Errorf("key '%s' is not a secp256k1 key required by %s", keyID, alg)
+		}
+	}
+
+	kh, err := o.ctx.KMS().Get(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("key '%s' not found : %w", keyID, err)
+	}
+
+	return &kmsSigner{crypto: o.ctx.Crypto(), kh: kh, alg: alg}, nil
+}
+
+// kmsSigner signs JWS with a key handle of the KMS.
+type kmsSigner struct {
+	crypto crypto.Crypto
+	kh     interface{}
+	alg    string
+}
+
+func (s *kmsSigner) Sign(data []byte) ([]byte, error) {
+	return s.crypto.Sign(data, s.kh)
+}
+
+func (s *kmsSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: s.alg}
+}
@@ -12,18 +12,29 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	ariesjose "github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
 )
 
 func TestNew(t *testing.T) {
@@ -34,7 +45,7 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 2, len(handlers))
+		require.Equal(t, 5, len(handlers))
 	})
 
 	t.Run("test new command - error from import key", func(t *testing.T) {
@@ -122,13 +133,104 @@ func TestCreateKeySet(t *testing.T) {
 			}})
 		require.NotNil(t, cmd)
 
-		reqBytes, err := json.Marshal(CreateKeySetRequest{KeyType: "invalid"})
+		reqBytes, err := json.Marshal(CreateKeySetRequest{KeyType: "ED25519"})
 		require.NoError(t, err)
 
 		var b bytes.Buffer
 		err = cmd.CreateKeySet(&b, bytes.NewBuffer(reqBytes))
 		require.EqualError(t, err, "error export public key")
 	})
+
+	t.Run("test create key set - error key type not supported", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+		require.NotNil(t, cmd)
+
+		for _, keyType := range []string{"invalid", string(kms.AES256GCMType), string(kms.ECDSAP384TypeDER)} {
+			reqBytes, err := json.Marshal(CreateKeySetRequest{KeyType: keyType})
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			cmdErr := cmd.CreateKeySet(&b, bytes.NewBuffer(reqBytes))
+			require.Error(t, cmdErr, keyType)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), keyType)
+			require.Equal(t, command.ValidationError, cmdErr.Type(), keyType)
+			require.Contains(t, cmdErr.Error(), "key type "+keyType+" not supported")
+		}
+	})
+}
+
+func TestExportPublicKey(t *testing.T) {
+	t.Run("test export public key of created key sets - success", func(t *testing.T) {
+		localKMS, err := localkms.New("local-lock://test/key-uri/",
+			mockkms.NewProviderForKMS(mem.NewProvider(), &noop.NoLock{}))
+		require.NoError(t, err)
+
+		cmd := New(&mockprovider.Provider{KMSValue: localKMS})
+
+		for _, keyType := range []kms.KeyType{kms.ED25519Type, kms.ECDSAP256TypeDER, kms.ECDSAP256TypeIEEEP1363} {
+			reqBytes, err := json.Marshal(CreateKeySetRequest{KeyType: string(keyType)})
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			require.NoError(t, cmd.CreateKeySet(&b, bytes.NewBuffer(reqBytes)))
+
+			created := CreateKeySetResponse{}
+			require.NoError(t, json.NewDecoder(&b).Decode(&created))
+
+			reqBytes, err = json.Marshal(ExportPublicKeyRequest{KeyID: created.KeyID})
+			require.NoError(t, err)
+
+			b.Reset()
+			require.NoError(t, cmd.ExportPublicKey(&b, bytes.NewBuffer(reqBytes)))
+
+			exported := ExportPublicKeyResponse{}
+			require.NoError(t, json.NewDecoder(&b).Decode(&exported))
+			require.Equal(t, created.KeyID, exported.KeyID)
+			require.Equal(t, created.PublicKey, exported.PublicKey)
+
+			pubKey, err := base64.RawURLEncoding.DecodeString(exported.PublicKey)
+			require.NoError(t, err)
+
+			switch keyType {
+			case kms.ED25519Type:
+				require.Len(t, pubKey, ed25519.PublicKeySize)
+			case kms.ECDSAP256TypeDER:
+				// DER key sets export PKIX encoded public keys
+				pkixKey, err := x509.ParsePKIXPublicKey(pubKey)
+				require.NoError(t, err)
+
+				ecKey, ok := pkixKey.(*ecdsa.PublicKey)
+				require.True(t, ok)
+				require.Equal(t, elliptic.P256(), ecKey.Curve)
+			default:
+				x, y := elliptic.Unmarshal(elliptic.P256(), pubKey)
+				require.NotNil(t, x, keyType)
+				require.True(t, elliptic.P256().IsOnCurve(x, y), keyType)
+			}
+		}
+	})
+
+	t.Run("test export public key - errors", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ExportPubKeyBytesErr: errors.New("key not found")},
+		})
+
+		var b bytes.Buffer
+		cmdErr := cmd.ExportPublicKey(&b, bytes.NewBufferString("}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.ExportPublicKey(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyKeyID)
+
+		cmdErr = cmd.ExportPublicKey(&b, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, ExportPublicKeyError, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.EqualError(t, cmdErr, "export public key of 'k1' : key not found")
+	})
 }
 
 func TestImportKey(t *testing.T) {
@@ -256,3 +358,183 @@ func TestImportKey(t *testing.T) {
 		require.Contains(t, err.Error(), "failed request decode")
 	})
 }
+
+func TestSignJWS(t *testing.T) {
+	t.Run("test sign JWS - EdDSA success", func(t *testing.T) {
+		localKMS, err := localkms.New("local-lock://test/key-uri/",
+			mockkms.NewProviderForKMS(mem.NewProvider(), &noop.NoLock{}))
+		require.NoError(t, err)
+
+		tinkCrypto, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		keyID, pubKey, err := localKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		cmd := New(&mockprovider.Provider{KMSValue: localKMS, CryptoValue: tinkCrypto})
+
+		jws := signJWS(t, cmd, &SignJWSRequest{
+			KeyID:   keyID,
+			Headers: map[string]interface{}{"alg": "EdDSA", "typ": "JWT"},
+			Payload: `{"iss":"did:example:123"}`,
+		})
+
+		parsed, err := ariesjose.ParseJWS(jws, ariesjose.SignatureVerifierFunc(
+			func(_ ariesjose.Headers, _, signingInput, signature []byte) error {
+				if !ed25519.Verify(pubKey, signingInput, signature) {
+					return errors.New("invalid signature")
+				}
+
+				return nil
+			}))
+		require.NoError(t, err)
+		require.Equal(t, `{"iss":"did:example:123"}`, string(parsed.Payload))
+
+		kid, ok := parsed.ProtectedHeaders.KeyID()
+		require.True(t, ok)
+		require.Equal(t, keyID, kid)
+
+		typ, ok := parsed.ProtectedHeaders.Type()
+		require.True(t, ok)
+		require.Equal(t, "JWT", typ)
+	})
+
+	t.Run("test sign JWS - ES256K success", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+		require.NoError(t, err)
+
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{
+				ExportPubKeyBytesValue: elliptic.Marshal(btcec.S256(), privKey.X, privKey.Y),
+			},
+			CryptoValue: &mockcrypto.Crypto{SignFn: func(data []byte, _ interface{}) ([]byte, error) {
+				digest := sha256.Sum256(data)
+
+				r, s, err := ecdsa.Sign(rand.Reader, privKey, digest[:])
+				if err != nil {
+					return nil, err
+				}
+
+				return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...), nil
+			}},
+		})
+
+		jws := signJWS(t, cmd, &SignJWSRequest{
+			KeyID:   "kid-1",
+			Headers: map[string]interface{}{"alg": "ES256K", "kid": "did:example:123#key-1"},
+			Payload: "payload",
+		})
+
+		parsed, err := ariesjose.ParseJWS(jws, ariesjose.SignatureVerifierFunc(
+			func(_ ariesjose.Headers, _, signingInput, signature []byte) error {
+				digest := sha256.Sum256(signingInput)
+				r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+
+				if !ecdsa.Verify(&privKey.PublicKey, digest[:], r, s) {
+					return errors.New("invalid signature")
+				}
+
+				return nil
+			}))
+		require.NoError(t, err)
+		require.Equal(t, "payload", string(parsed.Payload))
+
+		alg, ok := parsed.ProtectedHeaders.Algorithm()
+		require.True(t, ok)
+		require.Equal(t, "ES256K", alg)
+
+		kid, ok := parsed.ProtectedHeaders.KeyID()
+		require.True(t, ok)
+		require.Equal(t, "did:example:123#key-1", kid)
+	})
+
+	t.Run("test sign JWS - validation errors", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		tests := []struct {
+			request  string
+			errorMsg string
+		}{
+			{request: "}", errorMsg: "failed request decode"},
+			{request: `{"headers":{"alg":"EdDSA"}}`, errorMsg: errEmptyKeyID},
+			{request: `{"keyID":"k1"}`, errorMsg: "JWS algorithm '' not supported"},
+			{request: `{"keyID":"k1","headers":{"alg":"HS256"}}`, errorMsg: "JWS algorithm 'HS256' not supported"},
+		}
+
+		for _, test := range tests {
+			var b bytes.Buffer
+			cmdErr := cmd.SignJWS(&b, bytes.NewBufferString(test.request))
+			require.Error(t, cmdErr, test.request)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), test.request)
+			require.Contains(t, cmdErr.Error(), test.errorMsg, test.request)
+		}
+	})
+
+	t.Run("test sign JWS - key errors", func(t *testing.T) {
+		p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		edPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		tests := []struct {
+			name     string
+			km       *mockkms.KeyManager
+			crypto   *mockcrypto.Crypto
+			alg      string
+			errorMsg string
+		}{{
+			name:     "unknown key ID",
+			km:       &mockkms.KeyManager{ExportPubKeyBytesErr: errors.New("no key")},
+			alg:      "EdDSA",
+			errorMsg: "key 'k1' not found : no key",
+		}, {
+			name:     "P-256 key with EdDSA",
+			km:       &mockkms.KeyManager{ExportPubKeyBytesValue: elliptic.Marshal(p256Key.Curve, p256Key.X, p256Key.Y)},
+			alg:      "EdDSA",
+			errorMsg: "key 'k1' is not an Ed25519 key required by EdDSA",
+		}, {
+			name:     "Ed25519 key with ES256K",
+			km:       &mockkms.KeyManager{ExportPubKeyBytesValue: edPubKey},
+			alg:      "ES256K",
+			errorMsg: "key 'k1' is not a secp256k1 key required by ES256K",
+		}, {
+			name:     "key handle error",
+			km:       &mockkms.KeyManager{ExportPubKeyBytesValue: edPubKey, GetKeyErr: errors.New("no key handle")},
+			alg:      "EdDSA",
+			errorMsg: "key 'k1' not found : no key handle",
+		}, {
+			name:     "sign error",
+			km:       &mockkms.KeyManager{ExportPubKeyBytesValue: edPubKey},
+			crypto:   &mockcrypto.Crypto{SignErr: errors.New("sign failed")},
+			alg:      "EdDSA",
+			errorMsg: "sign failed",
+		}}
+
+		for _, test := range tests {
+			cmd := New(&mockprovider.Provider{KMSValue: test.km, CryptoValue: test.crypto})
+
+			var b bytes.Buffer
+			cmdErr := cmd.SignJWS(&b, bytes.NewBufferString(`{"keyID":"k1","headers":{"alg":"`+test.alg+`"}}`))
+			require.Error(t, cmdErr, test.name)
+			require.Equal(t, SignJWSError, cmdErr.Code(), test.name)
+			require.Contains(t, cmdErr.Error(), test.errorMsg, test.name)
+		}
+	})
+}
+
+func signJWS(t *testing.T, cmd *Command, request *SignJWSRequest) string {
+	t.Helper()
+
+	reqBytes, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.SignJWS(&b, bytes.NewBuffer(reqBytes)))
+
+	response := SignJWSResponse{}
+	require.NoError(t, json.NewDecoder(&b).Decode(&response))
+	require.True(t, ariesjose.IsCompactJWS(response.JWS))
+
+	return response.JWS
+}
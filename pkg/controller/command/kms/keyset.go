@@ -0,0 +1,248 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+
+	"github.com/square/go-jose/v3"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	ariesjose "github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	passphraseenc "github.com/hyperledger/aries-framework-go/pkg/internal/passphrase"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	errEmptyKeyIDs      = "key ids are mandatory"
+	errPassphraseNeeded = "passphrase is required to import an encrypted key set"
+
+	// minimum strength of the passphrases encrypting the exported key sets: a passphrase has at least
+	// minPassphraseLength characters of at least minPassphraseClasses classes among lowercase letters, uppercase
+	// letters, digits and symbols.
+	minPassphraseLength  = 12
+	minPassphraseClasses = 3
+)
+
+// exportableKeyTypes are the key types of the keys exported by ExportKeySet, the key types imported back by
+// ImportKey.
+// nolint:gochecknoglobals
+var exportableKeyTypes = map[kms.KeyType]bool{
+	kms.ED25519Type:            true,
+	kms.ECDSAP256TypeIEEEP1363: true,
+}
+
+// privateKeyExporter is implemented by the KMSs exporting their private keys, such as the local KMS.
+type privateKeyExporter interface {
+	ExportPrivateKey(keyID string) (interface{}, kms.KeyType, error)
+}
+
+// jwkSet is a JWK set (RFC 7517).
+type jwkSet struct {
+	Keys []*ariesjose.JWK `json:"keys"`
+}
+
+// ExportKeySet exports the private keys of the given key IDs (Ed25519 or ECDSA P-256) as a JWK set encrypted under
+// the passphrase, for backup or for provisioning another agent with ImportKey. The keys never leave the KMS
+// unencrypted, the passphrase must be strong.
+func (o *Command) ExportKeySet(rw io.Writer, req io.Reader) command.Error {
+	var request ExportKeySetRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportKeySetCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if len(request.KeyIDs) == 0 {
+		logutil.LogDebug(logger, CommandName, ExportKeySetCommandMethod, errEmptyKeyIDs)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyKeyIDs))
+	}
+
+	if err = validatePassphrase(request.Passphrase); err != nil {
+		logutil.LogDebug(logger, CommandName, ExportKeySetCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	set, err := o.exportKeySet(request.KeyIDs)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ExportKeySetCommandMethod, err.Error())
+		return command.NewExecuteError(ExportKeySetError, err)
+	}
+
+	encrypted, err := encryptKeySet(set, request.Passphrase)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ExportKeySetCommandMethod, err.Error())
+		return command.NewExecuteError(ExportKeySetError, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportKeySetResponse{Encrypted: encrypted}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportKeySetCommandMethod, "success")
+
+	return nil
+}
+
+func (o *Command) exportKeySet(keyIDs []string) (*jwkSet, error) {
+	exporter, ok := o.ctx.KMS().(privateKeyExporter)
+	if !ok {
+		return nil, errors.New("the KMS doesn't export private keys")
+	}
+
+	set := &jwkSet{}
+
+	for _, keyID := range keyIDs {
+		privKey, kt, err := exporter.ExportPrivateKey(keyID)
+		if err != nil {
+			return nil, fmt.Errorf("export key '%s' : %w", keyID, err)
+		}
+
+		if !exportableKeyTypes[kt] {
+			return nil, fmt.Errorf("key '%s' of type %s can't be exported, supported key types are %s and %s",
+				keyID, kt, kms.ED25519, kms.ECDSAP256IEEEP1363)
+		}
+
+		set.Keys = append(set.Keys, &ariesjose.JWK{JSONWebKey: jose.JSONWebKey{Key: privKey, KeyID: keyID}})
+	}
+
+	return set, nil
+}
+
+// importKeySet imports the private keys of the encrypted JWK set of the request, with their key IDs.
+func (o *Command) importKeySet(rw io.Writer, request *ImportKeyRequest) command.Error {
+	if request.Passphrase == "" {
+		logutil.LogDebug(logger, CommandName, ImportKeyCommandMethod, errPassphraseNeeded)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errPassphraseNeeded))
+	}
+
+	set, err := decryptKeySet(request.Encrypted, request.Passphrase)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	keyTypes := make([]kms.KeyType, len(set.Keys))
+
+	for i, jwk := range set.Keys {
+		if jwk.KeyID == "" {
+			logutil.LogDebug(logger, CommandName, ImportKeyCommandMethod, errEmptyKeyID)
+			return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyKeyID))
+		}
+
+		if jwk.IsPublic() {
+			return command.NewValidationError(InvalidRequestErrorCode,
+				fmt.Errorf("key '%s' is not a private key", jwk.KeyID))
+		}
+
+		if keyTypes[i], err = importKeyType(jwk); err != nil {
+			return command.NewValidationError(InvalidRequestErrorCode, err)
+		}
+	}
+
+	response := &ImportKeyResponse{}
+
+	for i, jwk := range set.Keys {
+		_, _, err = o.importKey(jwk.Key, keyTypes[i], kms.WithKeyID(jwk.KeyID))
+		if err != nil {
+			logutil.LogError(logger, CommandName, ImportKeyCommandMethod, err.Error(),
+				logutil.CreateKeyValueString("keyID", jwk.KeyID))
+
+			return command.NewExecuteError(ImportKeyError, fmt.Errorf("import key '%s' : %w", jwk.KeyID, err))
+		}
+
+		response.KeyIDs = append(response.KeyIDs, jwk.KeyID)
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, ImportKeyCommandMethod, "success")
+
+	return nil
+}
+
+// validatePassphrase rejects the weak passphrases, see minPassphraseLength and minPassphraseClasses.
+func validatePassphrase(passphrase string) error {
+	if len([]rune(passphrase)) < minPassphraseLength {
+		return fmt.Errorf("weak passphrase: at least %d characters are required", minPassphraseLength)
+	}
+
+	classes := map[string]bool{}
+
+	for _, r := range passphrase {
+		switch {
+		case unicode.IsLower(r):
+			classes["lower"] = true
+		case unicode.IsUpper(r):
+			classes["upper"] = true
+		case unicode.IsDigit(r):
+			classes["digit"] = true
+		case !unicode.IsSpace(r):
+			classes["symbol"] = true
+		}
+	}
+
+	if len(classes) < minPassphraseClasses {
+		return fmt.Errorf("weak passphrase: at least %d of lowercase letters, uppercase letters, digits and symbols "+
+			"are required", minPassphraseClasses)
+	}
+
+	return nil
+}
+
+func encryptKeySet(set *jwkSet, passphrase string) (*EncryptedKeySet, error) {
+	plaintext, err := json.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("marshal key set : %w", err)
+	}
+
+	defer wipe(plaintext)
+
+	encrypted, err := passphraseenc.Encrypt(plaintext, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt key set : %w", err)
+	}
+
+	return &EncryptedKeySet{
+		Salt:       encrypted.Salt,
+		Nonce:      encrypted.Nonce,
+		Ciphertext: encrypted.Ciphertext,
+	}, nil
+}
+
+func decryptKeySet(encrypted *EncryptedKeySet, passphrase string) (*jwkSet, error) {
+	plaintext, err := passphraseenc.Decrypt(&passphraseenc.Encrypted{
+		Salt:       encrypted.Salt,
+		Nonce:      encrypted.Nonce,
+		Ciphertext: encrypted.Ciphertext,
+	}, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt key set : %w", err)
+	}
+
+	defer wipe(plaintext)
+
+	set := &jwkSet{}
+
+	err = json.Unmarshal(plaintext, set)
+	if err != nil {
+		return nil, fmt.Errorf("parse decrypted key set : %w", err)
+	}
+
+	return set, nil
+}
+
+// wipe overwrites the private key material of b.
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+const passphrase = "correct-Horse-battery-9"
+
+func newLocalKMS(t *testing.T) *localkms.LocalKMS {
+	t.Helper()
+
+	localKMS, err := localkms.New("local-lock://test/key-uri/",
+		mockkms.NewProviderForKMS(mem.NewProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	return localKMS
+}
+
+func exportKeySet(t *testing.T, cmd *Command, request *ExportKeySetRequest) *EncryptedKeySet {
+	t.Helper()
+
+	reqBytes, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.ExportKeySet(&b, bytes.NewBuffer(reqBytes)))
+
+	response := ExportKeySetResponse{}
+	require.NoError(t, json.NewDecoder(&b).Decode(&response))
+	require.NotNil(t, response.Encrypted)
+
+	return response.Encrypted
+}
+
+func importKeySet(t *testing.T, cmd *Command, encrypted *EncryptedKeySet,
+	passphrase string) (*ImportKeyResponse, command.Error) {
+	t.Helper()
+
+	reqBytes, err := json.Marshal(&ImportKeyRequest{Encrypted: encrypted, Passphrase: passphrase})
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	if cmdErr := cmd.ImportKey(&b, bytes.NewBuffer(reqBytes)); cmdErr != nil {
+		return nil, cmdErr
+	}
+
+	response := &ImportKeyResponse{}
+	require.NoError(t, json.NewDecoder(&b).Decode(response))
+
+	return response, nil
+}
+
+func TestExportKeySet(t *testing.T) {
+	t.Run("test export and import key set - success", func(t *testing.T) {
+		agentKMS := newLocalKMS(t)
+
+		edKeyID, edPubKey, err := agentKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		ecKeyID, ecPubKey, err := agentKMS.CreateAndExportPubKeyBytes(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		encrypted := exportKeySet(t, New(&mockprovider.Provider{KMSValue: agentKMS}),
+			&ExportKeySetRequest{KeyIDs: []string{edKeyID, ecKeyID}, Passphrase: passphrase})
+
+		// the private key isn't exported in the clear
+		privKey, _, err := agentKMS.ExportPrivateKey(edKeyID)
+		require.NoError(t, err)
+
+		encryptedBytes, err := json.Marshal(encrypted)
+		require.NoError(t, err)
+		require.NotContains(t, string(encryptedBytes),
+			base64.RawURLEncoding.EncodeToString(privKey.(ed25519.PrivateKey).Seed()))
+		require.NotContains(t, string(encryptedBytes), edKeyID)
+
+		// the keys are imported in another agent with their key IDs
+		otherKMS := newLocalKMS(t)
+
+		response, cmdErr := importKeySet(t, New(&mockprovider.Provider{KMSValue: otherKMS}), encrypted, passphrase)
+		require.NoError(t, cmdErr)
+		require.Equal(t, []string{edKeyID, ecKeyID}, response.KeyIDs)
+
+		pubKey, err := otherKMS.ExportPubKeyBytes(edKeyID)
+		require.NoError(t, err)
+		require.Equal(t, edPubKey, pubKey)
+
+		pubKey, err = otherKMS.ExportPubKeyBytes(ecKeyID)
+		require.NoError(t, err)
+		require.Equal(t, ecPubKey, pubKey)
+	})
+
+	t.Run("test import key set - wrong passphrase", func(t *testing.T) {
+		agentKMS := newLocalKMS(t)
+
+		keyID, _, err := agentKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		encrypted := exportKeySet(t, New(&mockprovider.Provider{KMSValue: agentKMS}),
+			&ExportKeySetRequest{KeyIDs: []string{keyID}, Passphrase: passphrase})
+
+		otherKMS := newLocalKMS(t)
+		cmd := New(&mockprovider.Provider{KMSValue: otherKMS})
+
+		_, cmdErr := importKeySet(t, cmd, encrypted, "wrong-Horse-battery-9")
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "decrypt key set")
+
+		_, err = otherKMS.Get(keyID)
+		require.Error(t, err)
+
+		_, cmdErr = importKeySet(t, cmd, encrypted, "")
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errPassphraseNeeded)
+
+		encrypted.Nonce = encrypted.Nonce[1:]
+
+		_, cmdErr = importKeySet(t, cmd, encrypted, passphrase)
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "decrypt key set : invalid nonce")
+	})
+
+	t.Run("test export key set - weak passphrases", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: newLocalKMS(t)})
+
+		for _, weak := range []string{"", "Sh0rt!", "alllowercaseletters", "lowercase-and-symbols", "UPPER1234567"} {
+			reqBytes, err := json.Marshal(&ExportKeySetRequest{KeyIDs: []string{"kid"}, Passphrase: weak})
+			require.NoError(t, err)
+
+			cmdErr := cmd.ExportKeySet(&bytes.Buffer{}, bytes.NewBuffer(reqBytes))
+			require.Error(t, cmdErr, weak)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), weak)
+			require.Contains(t, cmdErr.Error(), "weak passphrase", weak)
+		}
+	})
+
+	t.Run("test export key set - validation errors", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: newLocalKMS(t)})
+
+		cmdErr := cmd.ExportKeySet(&bytes.Buffer{}, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "failed request decode")
+
+		cmdErr = cmd.ExportKeySet(&bytes.Buffer{}, bytes.NewBufferString(`{"passphrase":"`+passphrase+`"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyKeyIDs)
+	})
+
+	t.Run("test export key set - export errors", func(t *testing.T) {
+		agentKMS := newLocalKMS(t)
+
+		keyID, _, err := agentKMS.CreateAndExportPubKeyBytes(kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			km      kms.KeyManager
+			keyID   string
+			errMsg  string
+			comment string
+		}{
+			{&mockkms.KeyManager{}, "kid", "the KMS doesn't export private keys", "KMS without export"},
+			{agentKMS, "unknown", "export key 'unknown'", "unknown key"},
+			{agentKMS, keyID, "of type ECDSAP256DER can't be exported", "unsupported key type"},
+		} {
+			reqBytes, err := json.Marshal(&ExportKeySetRequest{KeyIDs: []string{tc.keyID}, Passphrase: passphrase})
+			require.NoError(t, err)
+
+			cmdErr := New(&mockprovider.Provider{KMSValue: tc.km}).ExportKeySet(&bytes.Buffer{},
+				bytes.NewBuffer(reqBytes))
+			require.Error(t, cmdErr, tc.comment)
+			require.Equal(t, ExportKeySetError, cmdErr.Code(), tc.comment)
+			require.Equal(t, command.ExecuteError, cmdErr.Type(), tc.comment)
+			require.Contains(t, cmdErr.Error(), tc.errMsg, tc.comment)
+		}
+	})
+}
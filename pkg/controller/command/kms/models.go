@@ -19,6 +19,75 @@ type CreateKeySetResponse struct {
 	PublicKey string `json:"publicKey,omitempty"`
 }
 
+// ExportPublicKeyRequest is model for exportPublicKey request.
+type ExportPublicKeyRequest struct {
+	KeyID string `json:"keyID,omitempty"`
+}
+
+// ExportPublicKeyResponse for returning the public key of a key set.
+type ExportPublicKeyResponse struct {
+	//  key id
+	KeyID string `json:"keyID,omitempty"`
+	//  public key base64 encoded
+	PublicKey string `json:"publicKey,omitempty"`
+}
+
+// SignJWSRequest is model for signJWS request.
+type SignJWSRequest struct {
+	// KeyID of the KMS key used to sign
+	KeyID string `json:"keyID,omitempty"`
+	// Headers of the JWS, the 'alg' header is mandatory (EdDSA or ES256K)
+	Headers map[string]interface{} `json:"headers,omitempty"`
+	// Payload to sign
+	Payload string `json:"payload,omitempty"`
+}
+
+// SignJWSResponse for returning the signed JWS.
+type SignJWSResponse struct {
+	// JWS in compact serialization
+	JWS string `json:"jws,omitempty"`
+}
+
+// ImportKeyRequest is model for importing the private keys of an encrypted JWK set exported by ExportKeySet.
+type ImportKeyRequest struct {
+	// Encrypted JWK set
+	Encrypted *EncryptedKeySet `json:"encrypted,omitempty"`
+	// Passphrase the JWK set is encrypted with
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// ImportKeyResponse for returning the key IDs of the keys imported from an encrypted JWK set.
+type ImportKeyResponse struct {
+	// KeyIDs of the imported keys
+	KeyIDs []string `json:"keyIDs,omitempty"`
+}
+
+// ExportKeySetRequest is model for exportKeySet request.
+type ExportKeySetRequest struct {
+	// KeyIDs of the KMS keys to export (Ed25519 or ECDSA P-256 keys)
+	KeyIDs []string `json:"keyIDs,omitempty"`
+	// Passphrase encrypting the exported JWK set, at least 12 characters mixing at least 3 of lowercase letters,
+	// uppercase letters, digits and symbols
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// ExportKeySetResponse for returning the encrypted JWK set of the exported keys.
+type ExportKeySetResponse struct {
+	// Encrypted JWK set
+	Encrypted *EncryptedKeySet `json:"encrypted,omitempty"`
+}
+
+// EncryptedKeySet is a JWK set of private keys encrypted with XChaCha20-Poly1305 under a key derived from a
+// passphrase with scrypt.
+type EncryptedKeySet struct {
+	// Salt of the scrypt key derivation
+	Salt []byte `json:"salt"`
+	// Nonce of the encryption
+	Nonce []byte `json:"nonce"`
+	// Ciphertext of the JWK set
+	Ciphertext []byte `json:"ciphertext"`
+}
+
 // JSONWebKey contains subset of json web key json properties.
 type JSONWebKey struct {
 	Use string `json:"use,omitempty"`
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	messagepickupSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 )
 
@@ -57,6 +58,21 @@ const (
 
 	// BatchPickupRequestErrorCode for batch pick up error.
 	BatchPickupRequestErrorCode
+
+	// GetConfigErrorCode for get router config error.
+	GetConfigErrorCode
+
+	// KeylistQueryErrorCode for keylist query error.
+	KeylistQueryErrorCode
+
+	// InvalidMediatorConnectionErrorCode for mediator connection validation error.
+	InvalidMediatorConnectionErrorCode
+
+	// DeliveryRequestMissingConnIDCode for connection ID validation error.
+	DeliveryRequestMissingConnIDCode
+
+	// DeliveryRequestErrorCode for delivery request error.
+	DeliveryRequestErrorCode
 )
 
 // constant for the mediator controller
@@ -71,6 +87,10 @@ const (
 	ReconnectCommandMethod       = "Reconnect"
 	StatusCommandMethod          = "Status"
 	BatchPickupCommandMethod     = "BatchPickup"
+	GetConfigCommandMethod       = "GetConfig"
+	KeylistQueryCommandMethod    = "KeylistQuery"
+	StatusRequestCommandMethod   = "StatusRequest"
+	DeliveryRequestCommandMethod = "DeliveryRequest"
 
 	// log constants
 	connectionID  = "connectionID"
@@ -131,11 +151,14 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, ReconnectCommandMethod, o.Reconnect),
 		cmdutil.NewCommandHandler(CommandName, StatusCommandMethod, o.Status),
 		cmdutil.NewCommandHandler(CommandName, BatchPickupCommandMethod, o.BatchPickup),
+		cmdutil.NewCommandHandler(CommandName, GetConfigCommandMethod, o.GetConfig),
+		cmdutil.NewCommandHandler(CommandName, KeylistQueryCommandMethod, o.KeylistQuery),
+		cmdutil.NewCommandHandler(CommandName, StatusRequestCommandMethod, o.StatusRequest),
+		cmdutil.NewCommandHandler(CommandName, DeliveryRequestCommandMethod, o.DeliveryRequest),
 	}
 }
 
-// Register registers the agent with the router.
-// nolint:dupl
+// Register registers the agent with the router and returns the routing configuration granted by the router.
 func (o *Command) Register(rw io.Writer, req io.Reader) command.Error {
 	var request RegisterRoute
 
@@ -158,7 +181,18 @@ func (o *Command) Register(rw io.Writer, req io.Reader) command.Error {
 		return command.NewExecuteError(RegisterRouterErrorCode, err)
 	}
 
-	command.WriteNillableResponse(rw, nil, logger)
+	conf, err := o.routeClient.GetConfig()
+	if err != nil {
+		logutil.LogError(logger, CommandName, RegisterCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(RegisterRouterErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RegisterRouteResponse{
+		ConnectionID:   request.ConnectionID,
+		RouterEndpoint: conf.Endpoint(),
+		RoutingKeys:    conf.Keys(),
+	}, logger)
 
 	logutil.LogDebug(logger, CommandName, RegisterCommandMethod, successString,
 		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
@@ -198,6 +232,73 @@ func (o *Command) Connection(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// GetConfig returns the connectionID and the routing configuration of the registered router.
+func (o *Command) GetConfig(rw io.Writer, req io.Reader) command.Error {
+	routerConnID, err := o.routeClient.GetConnection()
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetConfigCommandMethod, err.Error())
+		return command.NewExecuteError(GetConfigErrorCode, err)
+	}
+
+	conf, err := o.routeClient.GetConfig()
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetConfigCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, routerConnID))
+		return command.NewExecuteError(GetConfigErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ConfigResponse{
+		ConnectionID:   routerConnID,
+		RouterEndpoint: conf.Endpoint(),
+		RoutingKeys:    conf.Keys(),
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetConfigCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, routerConnID))
+
+	return nil
+}
+
+// KeylistQuery returns a page of the recipient keys the agent registered with the router. The next page is
+// returned by querying again from the returned next offset, until no keys are remaining.
+func (o *Command) KeylistQuery(rw io.Writer, req io.Reader) command.Error {
+	var request KeylistQueryRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, KeylistQueryCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Limit < 0 || request.Offset < 0 {
+		logutil.LogDebug(logger, CommandName, KeylistQueryCommandMethod, "negative limit or offset")
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New("limit and offset can't be negative"))
+	}
+
+	keylist, err := o.routeClient.KeylistQuery(request.Limit, request.Offset)
+	if err != nil {
+		logutil.LogError(logger, CommandName, KeylistQueryCommandMethod, err.Error())
+		return command.NewExecuteError(KeylistQueryErrorCode, err)
+	}
+
+	response := &KeylistQueryResponse{RecipientKeys: []string{}}
+
+	for _, key := range keylist.Keys {
+		response.RecipientKeys = append(response.RecipientKeys, key.RecipientKey)
+	}
+
+	if keylist.Pagination != nil && keylist.Pagination.Remaining > 0 {
+		response.Remaining = keylist.Pagination.Remaining
+		response.NextOffset = keylist.Pagination.Offset + keylist.Pagination.Count
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, KeylistQueryCommandMethod, successString)
+
+	return nil
+}
+
 // Reconnect sends noop message to reestablish a connection when there is no other reason to message the mediator
 // nolint:dupl
 func (o *Command) Reconnect(rw io.Writer, req io.Reader) command.Error {
@@ -291,3 +392,98 @@ func (o *Command) BatchPickup(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// StatusRequest queries the registered mediator for the status of the messages queued for the agent.
+func (o *Command) StatusRequest(rw io.Writer, req io.Reader) command.Error {
+	var request StatusRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, StatusRequestCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, StatusRequestCommandMethod, "missing connectionID",
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewValidationError(StatusRequestMissingConnIDCode, errors.New("connectionID is mandatory"))
+	}
+
+	if cmdErr := o.validateMediatorConnection(StatusRequestCommandMethod, request.ConnectionID); cmdErr != nil {
+		return cmdErr
+	}
+
+	status, err := o.messageClient.StatusRequest(request.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, StatusRequestCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(StatusRequestErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &StatusResponse{status}, logger)
+
+	logutil.LogDebug(logger, CommandName, StatusRequestCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
+
+// DeliveryRequest requests a batch of the messages queued for the agent by the registered mediator, the batched
+// messages are returned instead of being dispatched.
+func (o *Command) DeliveryRequest(rw io.Writer, req io.Reader) command.Error {
+	var request DeliveryRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DeliveryRequestCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, DeliveryRequestCommandMethod, "missing connectionID",
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewValidationError(DeliveryRequestMissingConnIDCode, errors.New("connectionID is mandatory"))
+	}
+
+	if cmdErr := o.validateMediatorConnection(DeliveryRequestCommandMethod, request.ConnectionID); cmdErr != nil {
+		return cmdErr
+	}
+
+	batch, err := o.messageClient.DeliveryRequest(request.ConnectionID, request.Size)
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeliveryRequestCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(DeliveryRequestErrorCode, err)
+	}
+
+	response := &DeliveryResponse{Messages: []*messagepickupSvc.Message{}}
+	if batch.Messages != nil {
+		response.Messages = batch.Messages
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, DeliveryRequestCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
+
+// validateMediatorConnection checks that the connection is the connection with the registered mediator.
+func (o *Command) validateMediatorConnection(method, connID string) command.Error {
+	routerConnID, err := o.routeClient.GetConnection()
+	if err != nil {
+		logutil.LogError(logger, CommandName, method, err.Error(),
+			logutil.CreateKeyValueString(connectionID, connID))
+		return command.NewValidationError(InvalidMediatorConnectionErrorCode, err)
+	}
+
+	if routerConnID != connID {
+		logutil.LogDebug(logger, CommandName, method, "not the mediator connection",
+			logutil.CreateKeyValueString(connectionID, connID))
+		return command.NewValidationError(InvalidMediatorConnectionErrorCode,
+			fmt.Errorf("connection %s is not the mediator connection", connID))
+	}
+
+	return nil
+}
@@ -10,10 +10,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	messagepickupSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
@@ -40,7 +43,7 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 6, len(handlers))
+		require.Equal(t, 10, len(handlers))
 	})
 
 	t.Run("test new command - client creation fail", func(t *testing.T) {
@@ -56,11 +59,20 @@ func TestNew(t *testing.T) {
 
 func TestCommand_Register(t *testing.T) {
 	t.Run("test register - success", func(t *testing.T) {
+		var registered string
+
 		cmd, err := New(
 			&mockprovider.Provider{
 				ServiceMap: map[string]interface{}{
 					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
-					mediator.Coordination:          &mockroute.MockMediatorSvc{},
+					mediator.Coordination: &mockroute.MockMediatorSvc{
+						RegisterFunc: func(connectionID string, options ...mediator.ClientOption) error {
+							registered = connectionID
+							return nil
+						},
+						RouterEndpoint: "http://router.example.com",
+						RoutingKeys:    []string{"key-1", "key-2"},
+					},
 				},
 			},
 			false,
@@ -71,6 +83,37 @@ func TestCommand_Register(t *testing.T) {
 		var b bytes.Buffer
 		err = cmd.Register(&b, bytes.NewBufferString(sampleConnRequest))
 		require.NoError(t, err)
+		require.Equal(t, "123-abc", registered)
+
+		response := RegisterRouteResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, RegisterRouteResponse{
+			ConnectionID:   "123-abc",
+			RouterEndpoint: "http://router.example.com",
+			RoutingKeys:    []string{"key-1", "key-2"},
+		}, response)
+	})
+
+	t.Run("test register - get config error", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediator.Coordination: &mockroute.MockMediatorSvc{
+						ConfigErr: errors.New("config error"),
+					},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Register(&b, bytes.NewBufferString(sampleConnRequest))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "config error")
+		require.Equal(t, RegisterRouterErrorCode, cmdErr.Code())
 	})
 
 	t.Run("test register - empty connectionID", func(t *testing.T) {
@@ -226,6 +269,208 @@ func TestCommand_Connection(t *testing.T) {
 	})
 }
 
+func TestCommand_GetConfig(t *testing.T) {
+	t.Run("test get config - success", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediator.Coordination: &mockroute.MockMediatorSvc{
+						ConnectionID:   "conn-abc",
+						RouterEndpoint: "http://router.example.com",
+						RoutingKeys:    []string{"key-1", "key-2"},
+					},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err = cmd.GetConfig(&b, nil)
+		require.NoError(t, err)
+
+		response := ConfigResponse{}
+		err = json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+		require.Equal(t, ConfigResponse{
+			ConnectionID:   "conn-abc",
+			RouterEndpoint: "http://router.example.com",
+			RoutingKeys:    []string{"key-1", "key-2"},
+		}, response)
+	})
+
+	t.Run("test get config - router not registered", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediator.Coordination: &mockroute.MockMediatorSvc{
+						GetConnectionIDErr: mediator.ErrRouterNotRegistered,
+					},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetConfig(&b, nil)
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "router not registered")
+		require.Equal(t, GetConfigErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("test get config - config error", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediator.Coordination: &mockroute.MockMediatorSvc{
+						ConnectionID: "conn-abc",
+						ConfigErr:    errors.New("config error"),
+					},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetConfig(&b, nil)
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "config error")
+		require.Equal(t, GetConfigErrorCode, cmdErr.Code())
+	})
+}
+
+func TestCommand_KeylistQuery(t *testing.T) {
+	keys := make([]string, 25)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%02d", i)
+	}
+
+	// route service paging through keys the way the router does
+	routeSvc := &mockroute.MockMediatorSvc{
+		KeylistQueryFunc: func(limit, offset int) (*mediator.Keylist, error) {
+			end := offset + limit
+			if end > len(keys) {
+				end = len(keys)
+			}
+
+			keylist := &mediator.Keylist{
+				Pagination: &mediator.Pagination{Count: end - offset, Offset: offset, Remaining: len(keys) - end},
+			}
+
+			for _, key := range keys[offset:end] {
+				keylist.Keys = append(keylist.Keys, mediator.Key{RecipientKey: key})
+			}
+
+			return keylist, nil
+		},
+	}
+
+	cmd, err := New(
+		&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+				mediator.Coordination:          routeSvc,
+			},
+		},
+		false,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	t.Run("test keylist query - resume from next offset", func(t *testing.T) {
+		var (
+			result []string
+			pages  []KeylistQueryResponse
+		)
+
+		request := KeylistQueryRequest{Limit: 10}
+
+		for {
+			reqBytes, err := json.Marshal(request)
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			require.NoError(t, cmd.KeylistQuery(&b, bytes.NewBuffer(reqBytes)))
+
+			response := KeylistQueryResponse{}
+			require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+			pages = append(pages, response)
+			result = append(result, response.RecipientKeys...)
+
+			if response.Remaining == 0 {
+				break
+			}
+
+			request.Offset = response.NextOffset
+		}
+
+		require.Equal(t, keys, result)
+		require.Len(t, pages, 3)
+		require.Equal(t, []string{"key-10"}, pages[1].RecipientKeys[:1])
+		require.Equal(t, 20, pages[1].NextOffset)
+		require.Equal(t, 5, pages[1].Remaining)
+		require.Len(t, pages[2].RecipientKeys, 5)
+		require.Zero(t, pages[2].NextOffset)
+	})
+
+	t.Run("test keylist query - empty page", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, cmd.KeylistQuery(&b, bytes.NewBufferString(`{"limit":10,"offset":25}`)))
+
+		response := KeylistQueryResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, KeylistQueryResponse{RecipientKeys: []string{}}, response)
+	})
+
+	t.Run("test keylist query - invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.KeylistQuery(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "request decode")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.KeylistQuery(&b, bytes.NewBufferString(`{"limit":10,"offset":-1}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "limit and offset can't be negative")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("test keylist query - error", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediator.Coordination: &mockroute.MockMediatorSvc{
+						KeylistQueryFunc: func(limit, offset int) (*mediator.Keylist, error) {
+							return nil, mediator.ErrRouterNotRegistered
+						},
+					},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.KeylistQuery(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "router not registered")
+		require.Equal(t, KeylistQueryErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
+
 func TestCommand_Reconnect(t *testing.T) {
 	t.Run("test reconnect - success", func(t *testing.T) {
 		cmd, err := New(
@@ -484,3 +729,180 @@ func TestCommand_BatchPickup(t *testing.T) {
 		require.Contains(t, err.Error(), "batch pickup error")
 	})
 }
+
+func TestCommand_StatusRequest(t *testing.T) {
+	newCommand := func(t *testing.T, pickup *messagepickup.MockMessagePickupSvc,
+		router *mockroute.MockMediatorSvc) *Command {
+		t.Helper()
+
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: pickup,
+					mediator.Coordination:          router,
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	t.Run("test status request - success", func(t *testing.T) {
+		const count = 12
+
+		cmd := newCommand(t, &messagepickup.MockMessagePickupSvc{
+			StatusRequestFunc: func(connectionID string) (*messagepickupSvc.Status, error) {
+				require.Equal(t, "123-abc", connectionID)
+
+				return &messagepickupSvc.Status{MessageCount: count}, nil
+			},
+		}, &mockroute.MockMediatorSvc{ConnectionID: "123-abc"})
+
+		var b bytes.Buffer
+		err := cmd.StatusRequest(&b, bytes.NewBufferString(sampleConnRequest))
+		require.NoError(t, err)
+
+		response := StatusResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, count, response.MessageCount)
+	})
+
+	t.Run("test status request - invalid request", func(t *testing.T) {
+		cmd := newCommand(t, &messagepickup.MockMessagePickupSvc{}, &mockroute.MockMediatorSvc{})
+
+		var b bytes.Buffer
+		err := cmd.StatusRequest(&b, bytes.NewBufferString("--"))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+		require.Contains(t, err.Error(), "request decode")
+
+		err = cmd.StatusRequest(&b, bytes.NewBufferString(sampleEmptyConnectionRequest))
+		require.Error(t, err)
+		require.Equal(t, StatusRequestMissingConnIDCode, err.Code())
+		require.Contains(t, err.Error(), "connectionID is mandatory")
+	})
+
+	t.Run("test status request - not the mediator connection", func(t *testing.T) {
+		cmd := newCommand(t, &messagepickup.MockMessagePickupSvc{}, &mockroute.MockMediatorSvc{ConnectionID: "xyz"})
+
+		var b bytes.Buffer
+		err := cmd.StatusRequest(&b, bytes.NewBufferString(sampleConnRequest))
+		require.Error(t, err)
+		require.Equal(t, InvalidMediatorConnectionErrorCode, err.Code())
+		require.Equal(t, command.ValidationError, err.Type())
+		require.Contains(t, err.Error(), "connection 123-abc is not the mediator connection")
+
+		cmd = newCommand(t, &messagepickup.MockMessagePickupSvc{}, &mockroute.MockMediatorSvc{
+			GetConnectionIDErr: errors.New("router not registered"),
+		})
+
+		err = cmd.StatusRequest(&b, bytes.NewBufferString(sampleConnRequest))
+		require.Error(t, err)
+		require.Equal(t, InvalidMediatorConnectionErrorCode, err.Code())
+		require.Contains(t, err.Error(), "router not registered")
+	})
+
+	t.Run("test status request - failure", func(t *testing.T) {
+		cmd := newCommand(t, &messagepickup.MockMessagePickupSvc{
+			StatusRequestErr: errors.New("status error"),
+		}, &mockroute.MockMediatorSvc{ConnectionID: "123-abc"})
+
+		var b bytes.Buffer
+		err := cmd.StatusRequest(&b, bytes.NewBufferString(sampleConnRequest))
+		require.Error(t, err)
+		require.Equal(t, StatusRequestErrorCode, err.Code())
+		require.Contains(t, err.Error(), "status error")
+	})
+}
+
+func TestCommand_DeliveryRequest(t *testing.T) {
+	newCommand := func(t *testing.T, pickup *messagepickup.MockMessagePickupSvc,
+		router *mockroute.MockMediatorSvc) *Command {
+		t.Helper()
+
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: pickup,
+					mediator.Coordination:          router,
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	t.Run("test delivery request - success", func(t *testing.T) {
+		cmd := newCommand(t, &messagepickup.MockMessagePickupSvc{
+			DeliveryRequestFunc: func(connectionID string, size int) (*messagepickupSvc.Batch, error) {
+				require.Equal(t, "123-abc", connectionID)
+				require.Equal(t, 100, size)
+
+				return &messagepickupSvc.Batch{Messages: []*messagepickupSvc.Message{
+					{ID: "msg-1", Message: &model.Envelope{CipherText: "cipher-1"}},
+					{ID: "msg-2", Message: &model.Envelope{CipherText: "cipher-2"}},
+				}}, nil
+			},
+		}, &mockroute.MockMediatorSvc{ConnectionID: "123-abc"})
+
+		var b bytes.Buffer
+		err := cmd.DeliveryRequest(&b, bytes.NewBufferString(sampleBatchPickupRequest))
+		require.NoError(t, err)
+
+		response := DeliveryResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Len(t, response.Messages, 2)
+		require.Equal(t, "msg-1", response.Messages[0].ID)
+		require.Equal(t, "cipher-2", response.Messages[1].Message.CipherText)
+	})
+
+	t.Run("test delivery request - empty batch", func(t *testing.T) {
+		cmd := newCommand(t, &messagepickup.MockMessagePickupSvc{}, &mockroute.MockMediatorSvc{ConnectionID: "123-abc"})
+
+		var b bytes.Buffer
+		err := cmd.DeliveryRequest(&b, bytes.NewBufferString(sampleBatchPickupRequest))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"messages": []}`, b.String())
+	})
+
+	t.Run("test delivery request - invalid request", func(t *testing.T) {
+		cmd := newCommand(t, &messagepickup.MockMessagePickupSvc{}, &mockroute.MockMediatorSvc{})
+
+		var b bytes.Buffer
+		err := cmd.DeliveryRequest(&b, bytes.NewBufferString("--"))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+		require.Contains(t, err.Error(), "request decode")
+
+		err = cmd.DeliveryRequest(&b, bytes.NewBufferString(sampleEmptyConnectionRequest))
+		require.Error(t, err)
+		require.Equal(t, DeliveryRequestMissingConnIDCode, err.Code())
+		require.Contains(t, err.Error(), "connectionID is mandatory")
+	})
+
+	t.Run("test delivery request - not the mediator connection", func(t *testing.T) {
+		cmd := newCommand(t, &messagepickup.MockMessagePickupSvc{}, &mockroute.MockMediatorSvc{ConnectionID: "xyz"})
+
+		var b bytes.Buffer
+		err := cmd.DeliveryRequest(&b, bytes.NewBufferString(sampleBatchPickupRequest))
+		require.Error(t, err)
+		require.Equal(t, InvalidMediatorConnectionErrorCode, err.Code())
+		require.Contains(t, err.Error(), "connection 123-abc is not the mediator connection")
+	})
+
+	t.Run("test delivery request - failure", func(t *testing.T) {
+		cmd := newCommand(t, &messagepickup.MockMessagePickupSvc{
+			DeliveryRequestErr: errors.New("delivery error"),
+		}, &mockroute.MockMediatorSvc{ConnectionID: "123-abc"})
+
+		var b bytes.Buffer
+		err := cmd.DeliveryRequest(&b, bytes.NewBufferString(sampleBatchPickupRequest))
+		require.Error(t, err)
+		require.Equal(t, DeliveryRequestErrorCode, err.Code())
+		require.Contains(t, err.Error(), "delivery error")
+	})
+}
@@ -13,6 +13,44 @@ type RegisterRoute struct {
 	ConnectionID string `json:"connectionID"`
 }
 
+// RegisterRouteResponse is the routing configuration granted by the router the agent registered with.
+type RegisterRouteResponse struct {
+	// ConnectionID of the connection with the router.
+	ConnectionID string `json:"connectionID"`
+	// RouterEndpoint is the endpoint of the router.
+	RouterEndpoint string `json:"router_endpoint"`
+	// RoutingKeys are the routing keys of the router.
+	RoutingKeys []string `json:"routing_keys"`
+}
+
+// ConfigResponse is the routing configuration of the registered router.
+type ConfigResponse struct {
+	// ConnectionID of the connection with the router.
+	ConnectionID string `json:"connectionID"`
+	// RouterEndpoint is the endpoint of the router.
+	RouterEndpoint string `json:"router_endpoint"`
+	// RoutingKeys are the routing keys of the router.
+	RoutingKeys []string `json:"routing_keys"`
+}
+
+// KeylistQueryRequest is request for a page of the recipient keys registered with the router.
+type KeylistQueryRequest struct {
+	// Limit is the maximum number of keys of the page, the router default is used if zero.
+	Limit int `json:"limit"`
+	// Offset of the page, the next offset of the previous page to resume a query.
+	Offset int `json:"offset"`
+}
+
+// KeylistQueryResponse is a page of the recipient keys registered with the router.
+type KeylistQueryResponse struct {
+	// RecipientKeys of the page.
+	RecipientKeys []string `json:"recipient_keys"`
+	// Remaining is the number of keys after the page.
+	Remaining int `json:"remaining,omitempty"`
+	// NextOffset is the offset of the next page, unset if no keys are remaining.
+	NextOffset int `json:"next_offset,omitempty"`
+}
+
 // StatusRequest is request for getting details about pending messages.
 type StatusRequest struct {
 	ConnectionID string `json:"connectionID"`
@@ -36,3 +74,17 @@ type BatchPickupResponse struct {
 	// Count of messages dispatched.
 	MessageCount int `json:"message_count"`
 }
+
+// DeliveryRequest is request for a batch of the messages queued by the mediator.
+type DeliveryRequest struct {
+	// ConnectionID of the connection with the mediator.
+	ConnectionID string `json:"connectionID"`
+	// Size of the batch of queued messages to be delivered.
+	Size int `json:"batch_size"`
+}
+
+// DeliveryResponse is response containing the batch of messages delivered by the mediator.
+type DeliveryResponse struct {
+	// Messages of the batch.
+	Messages []*messagepickup.Message `json:"messages"`
+}
@@ -7,17 +7,29 @@ SPDX-License-Identifier: Apache-2.0
 package messaging
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/basic"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -44,6 +56,17 @@ const (
 	errMsgDestSvcEndpointKeysMissing    = "missing service endpoint recipient/routing keys in message destination"
 	errMsgConnectionMatchingDIDNotFound = "unable to find connection matching DID"
 	errMsgIDEmpty                       = "empty message ID"
+	errMsgContentEmpty                  = "empty message content"
+	errMsgConnectionIDEmpty             = "empty connection ID"
+	errMsgConnectionNotFound            = "connection `%s` not found"
+	errMsgConnectionNotCompleted        = "connection `%s` is not completed"
+	errMsgRecipientKeysEmpty            = "empty recipient keys"
+	errMsgEnvelopeEmpty                 = "empty envelope"
+	errMsgUnpackedNotJSON               = "unpacked message is not JSON"
+	errMsgThreadIDEmpty                 = "empty thread ID"
+	errMsgResendNotSupported            = "messenger does not support resending messages"
+	errMsgTraceNotSupported             = "outbound dispatcher does not support tracing messages"
+	errMsgThreadNotTraced               = "no message traced on thread `%s`"
 
 	// command methods
 	RegisteredServicesCommandMethod         = "Services"
@@ -52,6 +75,11 @@ const (
 	RegisterHTTPMessageServiceCommandMethod = "RegisterHTTPService"
 	SendNewMessageCommandMethod             = "Send"
 	SendReplyMessageCommandMethod           = "Reply"
+	SendBasicMessageCommandMethod           = "SendMessage"
+	PackMessageCommandMethod                = "PackMessage"
+	UnpackMessageCommandMethod              = "UnpackMessage"
+	ResendMessageCommandMethod              = "Resend"
+	GetMessageTraceCommandMethod            = "GetMessageTrace"
 
 	// log constants
 	connectionIDString = "connectionID"
@@ -59,6 +87,8 @@ const (
 	destinationDID     = "destinationDID"
 	replyTo            = "replyTo"
 	successString      = "success"
+	senderKeyIDString  = "senderKeyID"
+	threadIDString     = "threadID"
 )
 
 // Error codes
@@ -77,6 +107,21 @@ const (
 
 	// SendMsgReplyError is for failures while sending message replies.
 	SendMsgReplyError
+
+	// SendBasicMsgError is for failures while sending basic messages.
+	SendBasicMsgError
+
+	// PackMsgError is for failures while packing messages.
+	PackMsgError
+
+	// UnpackMsgError is for failures while unpacking messages.
+	UnpackMsgError
+
+	// ResendMsgError is for failures while resending messages.
+	ResendMsgError
+
+	// GetMessageTraceError is for failures while getting the trace of a thread.
+	GetMessageTraceError
 )
 
 // errConnForDIDNotFound when matching connection ID not found.
@@ -87,9 +132,21 @@ var errConnForDIDNotFound = fmt.Errorf(errMsgConnectionMatchingDIDNotFound)
 type provider interface {
 	VDRIRegistry() vdri.Registry
 	Messenger() service.Messenger
+	OutboundDispatcher() dispatcher.Outbound
 	ProtocolStateStorageProvider() storage.Provider
 	StorageProvider() storage.Provider
 	KMS() kms.KeyManager
+	Packers() []packer.Packer
+}
+
+// resender is implemented by the messengers keeping the last message sent on each thread.
+type resender interface {
+	Resend(thID string) error
+}
+
+// messageTracer is implemented by the outbound dispatchers tracing the messages sent and received on each thread.
+type messageTracer interface {
+	Tracer() *dispatcher.Tracer
 }
 
 // Command contains basic command operations provided by messaging controller command.
@@ -126,6 +183,11 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, RegisterHTTPMessageServiceCommandMethod, o.RegisterHTTPService),
 		cmdutil.NewCommandHandler(CommandName, SendNewMessageCommandMethod, o.Send),
 		cmdutil.NewCommandHandler(CommandName, SendReplyMessageCommandMethod, o.Reply),
+		cmdutil.NewCommandHandler(CommandName, SendBasicMessageCommandMethod, o.SendMessage),
+		cmdutil.NewCommandHandler(CommandName, PackMessageCommandMethod, o.PackMessage),
+		cmdutil.NewCommandHandler(CommandName, UnpackMessageCommandMethod, o.UnpackMessage),
+		cmdutil.NewCommandHandler(CommandName, ResendMessageCommandMethod, o.Resend),
+		cmdutil.NewCommandHandler(CommandName, GetMessageTraceCommandMethod, o.GetMessageTrace),
 	}
 }
 
@@ -270,6 +332,269 @@ func (o *Command) Reply(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// Resend sends again the last message sent on the thread, for retrying a protocol stalled by a flaky transport.
+// The thread ID of a protocol instance is its ID (piID). The message is sent with its original ID, a recipient
+// having received it already detects the duplicate.
+func (o *Command) Resend(rw io.Writer, req io.Reader) command.Error {
+	var request ResendMessageArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ResendMessageCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.ThreadID == "" {
+		logutil.LogDebug(logger, CommandName, ResendMessageCommandMethod, errMsgThreadIDEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgThreadIDEmpty))
+	}
+
+	messenger, ok := o.ctx.Messenger().(resender)
+	if !ok {
+		logutil.LogError(logger, CommandName, ResendMessageCommandMethod, errMsgResendNotSupported)
+		return command.NewExecuteError(ResendMsgError, fmt.Errorf(errMsgResendNotSupported))
+	}
+
+	err = messenger.Resend(request.ThreadID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ResendMessageCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(threadIDString, request.ThreadID))
+		return command.NewExecuteError(ResendMsgError, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, ResendMessageCommandMethod, successString,
+		logutil.CreateKeyValueString(threadIDString, request.ThreadID))
+
+	return nil
+}
+
+// GetMessageTrace returns the trace of a thread: the connection of the thread, if any, and the protocol steps of
+// the thread in the order they happened, each step being a message received or sent along with the transport used.
+func (o *Command) GetMessageTrace(rw io.Writer, req io.Reader) command.Error {
+	var request MessageTraceArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetMessageTraceCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.ThreadID == "" {
+		logutil.LogDebug(logger, CommandName, GetMessageTraceCommandMethod, errMsgThreadIDEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgThreadIDEmpty))
+	}
+
+	tracer, ok := o.ctx.OutboundDispatcher().(messageTracer)
+	if !ok {
+		logutil.LogError(logger, CommandName, GetMessageTraceCommandMethod, errMsgTraceNotSupported)
+		return command.NewExecuteError(GetMessageTraceError, fmt.Errorf(errMsgTraceNotSupported))
+	}
+
+	steps := tracer.Tracer().Trace(request.ThreadID)
+	if len(steps) == 0 {
+		logutil.LogInfo(logger, CommandName, GetMessageTraceCommandMethod, "thread not traced",
+			logutil.CreateKeyValueString(threadIDString, request.ThreadID))
+
+		return command.NewExecuteError(GetMessageTraceError, fmt.Errorf(errMsgThreadNotTraced, request.ThreadID))
+	}
+
+	response := &MessageTraceResponse{ThreadID: request.ThreadID, Steps: steps}
+
+	thread, err := o.connectionLookup.GetThreadRecord(request.ThreadID)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		logutil.LogError(logger, CommandName, GetMessageTraceCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(threadIDString, request.ThreadID))
+
+		return command.NewExecuteError(GetMessageTraceError, err)
+	}
+
+	if thread != nil {
+		response.ConnectionID = thread.ConnectionID
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, GetMessageTraceCommandMethod, successString,
+		logutil.CreateKeyValueString(threadIDString, request.ThreadID))
+
+	return nil
+}
+
+// SendMessage sends a basic message to the other party of the completed connection provided.
+func (o *Command) SendMessage(rw io.Writer, req io.Reader) command.Error {
+	var request SendBasicMessageArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SendBasicMessageCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, SendBasicMessageCommandMethod, errMsgConnectionIDEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgConnectionIDEmpty))
+	}
+
+	if request.Content == "" {
+		logutil.LogDebug(logger, CommandName, SendBasicMessageCommandMethod, errMsgContentEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgContentEmpty))
+	}
+
+	conn, err := o.connectionLookup.GetConnectionRecord(request.ConnectionID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		logutil.LogDebug(logger, CommandName, SendBasicMessageCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, request.ConnectionID))
+
+		return command.NewValidationError(InvalidRequestErrorCode,
+			fmt.Errorf(errMsgConnectionNotFound, request.ConnectionID))
+	}
+
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendBasicMessageCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, request.ConnectionID))
+
+		return command.NewExecuteError(SendBasicMsgError, err)
+	}
+
+	if conn.State != stateNameCompleted {
+		logutil.LogDebug(logger, CommandName, SendBasicMessageCommandMethod, "connection not completed",
+			logutil.CreateKeyValueString(connectionIDString, request.ConnectionID))
+
+		return command.NewValidationError(InvalidRequestErrorCode,
+			fmt.Errorf(errMsgConnectionNotCompleted, request.ConnectionID))
+	}
+
+	msg := &basic.Message{
+		ID:       uuid.New().String(),
+		Type:     basic.MessageRequestType,
+		SentTime: time.Now().UTC(),
+		Content:  request.Content,
+	}
+	msg.I10n.Locale = request.Locale
+
+	err = o.ctx.Messenger().Send(service.NewDIDCommMsgMap(msg), conn.MyDID, conn.TheirDID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendBasicMessageCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, request.ConnectionID))
+
+		return command.NewExecuteError(SendBasicMsgError, err)
+	}
+
+	command.WriteNillableResponse(rw, &SendBasicMessageResponse{ID: msg.ID}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendBasicMessageCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionIDString, request.ConnectionID))
+
+	return nil
+}
+
+// PackMessage packs the message in an encryption envelope (JWE) for the recipient keys provided. The message is
+// packed with authcrypt if the sender key ID is provided and with anoncrypt otherwise.
+func (o *Command) PackMessage(rw io.Writer, req io.Reader) command.Error {
+	var request PackMessageArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, PackMessageCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if len(request.Message) == 0 {
+		logutil.LogDebug(logger, CommandName, PackMessageCommandMethod, errMsgBodyEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgBodyEmpty))
+	}
+
+	if len(request.RecipientKeys) == 0 {
+		logutil.LogDebug(logger, CommandName, PackMessageCommandMethod, errMsgRecipientKeysEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgRecipientKeysEmpty))
+	}
+
+	recipients, err := recipientKeys(request.RecipientKeys)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, PackMessageCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	envelopePacker, err := o.envelopePacker(request.SenderKeyID != "")
+	if err != nil {
+		logutil.LogError(logger, CommandName, PackMessageCommandMethod, err.Error())
+		return command.NewExecuteError(PackMsgError, err)
+	}
+
+	envelope, err := envelopePacker.Pack(request.Message, []byte(request.SenderKeyID), recipients)
+	if err != nil {
+		logutil.LogError(logger, CommandName, PackMessageCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(senderKeyIDString, request.SenderKeyID))
+
+		return command.NewExecuteError(PackMsgError, err)
+	}
+
+	command.WriteNillableResponse(rw, &PackMessageResponse{Envelope: string(envelope)}, logger)
+
+	logutil.LogDebug(logger, CommandName, PackMessageCommandMethod, successString,
+		logutil.CreateKeyValueString(senderKeyIDString, request.SenderKeyID))
+
+	return nil
+}
+
+// UnpackMessage unpacks the encryption envelope (JWE) with the matching recipient key of the KMS. Envelopes packed
+// with authcrypt are decrypted with the sender key known to the agent, which authenticates the sender.
+func (o *Command) UnpackMessage(rw io.Writer, req io.Reader) command.Error {
+	var request UnpackMessageArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UnpackMessageCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.Envelope == "" {
+		logutil.LogDebug(logger, CommandName, UnpackMessageCommandMethod, errMsgEnvelopeEmpty)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgEnvelopeEmpty))
+	}
+
+	jwe, err := jose.Deserialize(request.Envelope)
+	if err != nil {
+		logutil.LogDebug(logger, CommandName, UnpackMessageCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("invalid envelope: %w", err))
+	}
+
+	// authcrypt envelopes carry the ID of the sender key
+	_, authenticated := jwe.ProtectedHeaders.SenderKeyID()
+
+	envelopePacker, err := o.envelopePacker(authenticated)
+	if err != nil {
+		logutil.LogError(logger, CommandName, UnpackMessageCommandMethod, err.Error())
+		return command.NewExecuteError(UnpackMsgError, err)
+	}
+
+	envelope, err := envelopePacker.Unpack([]byte(request.Envelope))
+	if err != nil {
+		logutil.LogError(logger, CommandName, UnpackMessageCommandMethod, err.Error())
+		return command.NewExecuteError(UnpackMsgError, err)
+	}
+
+	if !json.Valid(envelope.Message) {
+		logutil.LogError(logger, CommandName, UnpackMessageCommandMethod, errMsgUnpackedNotJSON)
+		return command.NewExecuteError(UnpackMsgError, fmt.Errorf(errMsgUnpackedNotJSON))
+	}
+
+	response := &UnpackMessageResponse{
+		Message:      envelope.Message,
+		RecipientKey: base64.RawURLEncoding.EncodeToString(envelope.ToKey),
+	}
+
+	if len(envelope.FromKey) > 0 {
+		response.SenderKey = base64.RawURLEncoding.EncodeToString(envelope.FromKey)
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, UnpackMessageCommandMethod, successString)
+
+	return nil
+}
+
 // RegisterHTTPService registers new http over didcomm service to message handler registrar.
 func (o *Command) RegisterHTTPService(rw io.Writer, req io.Reader) command.Error {
 	var request RegisterHTTPMsgSvcArgs
@@ -293,7 +618,7 @@ func (o *Command) registerMessageService(params *RegisterMsgSvcArgs) command.Err
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgSvcNameRequired))
 	}
 
-	if params.Type == "" {
+	if params.Type == "" && len(params.Purpose) == 0 {
 		logutil.LogDebug(logger, CommandName, RegisterMessageServiceCommandMethod, errMsgInvalidAcceptanceCrit)
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errMsgInvalidAcceptanceCrit))
 	}
@@ -310,6 +635,57 @@ func (o *Command) registerMessageService(params *RegisterMsgSvcArgs) command.Err
 	return nil
 }
 
+// envelopePacker returns the authcrypt packer if authenticated and the anoncrypt packer otherwise.
+func (o *Command) envelopePacker(authenticated bool) (packer.Packer, error) {
+	for _, p := range o.ctx.Packers() {
+		switch p.(type) {
+		case *authcrypt.Packer:
+			if authenticated {
+				return p, nil
+			}
+		case *anoncrypt.Packer:
+			if !authenticated {
+				return p, nil
+			}
+		}
+	}
+
+	if authenticated {
+		return nil, errors.New("authcrypt packer not available")
+	}
+
+	return nil, errors.New("anoncrypt packer not available")
+}
+
+// recipientKeys converts the recipient keys to the marshalled public keys expected by the packers.
+func recipientKeys(keys []RecipientKey) ([][]byte, error) {
+	recipients := make([][]byte, len(keys))
+
+	for i, key := range keys {
+		pubKeyBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key.PublicKey, "="))
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient key '%s': %w", key.KeyID, err)
+		}
+
+		pubKey := &composite.PublicKey{}
+
+		err = json.Unmarshal(pubKeyBytes, pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient key '%s': %w", key.KeyID, err)
+		}
+
+		// the key ID identifies the key of the recipient KMS unpacking the envelope
+		pubKey.KID = key.KeyID
+
+		recipients[i], err = json.Marshal(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("marshal recipient key '%s': %w", key.KeyID, err)
+		}
+	}
+
+	return recipients, nil
+}
+
 func (o *Command) validateMessageDestination(dest *SendNewMessageArgs) error {
 	var didMissing, connIDMissing, svcEPMissing = dest.TheirDID == "",
 		dest.ConnectionID == "",
@@ -8,26 +8,44 @@ package messaging
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	msghandlerpkg "github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/basic"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 	mocksvc "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
-	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/base58wrapper"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
@@ -44,7 +62,7 @@ func TestNew(t *testing.T) {
 	t.Run("Test create new command success failure", func(t *testing.T) {
 		const errMsg = "sample-error"
 		cmd, err := New(&protocol.MockProvider{
-			StoreProvider: &storage.MockStoreProvider{
+			StoreProvider: &mockstorage.MockStoreProvider{
 				ErrOpenStoreHandle: fmt.Errorf(errMsg),
 			},
 		}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
@@ -81,6 +99,22 @@ func TestCommand_RegisterService(t *testing.T) {
 		))
 	})
 
+	t.Run("Successful Register Message Service by purpose", func(t *testing.T) {
+		msgRegistrar := msghandler.NewMockMsgServiceProvider()
+		cmd, err := New(&protocol.MockProvider{}, msgRegistrar, webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RegisterService(&b, bytes.NewBufferString(`{"name":"prp-msg-01","purpose": ["prp-01"]}`))
+		require.NoError(t, cmdErr)
+
+		require.Len(t, msgRegistrar.Services(), 1)
+		require.True(t, msgRegistrar.Services()[0].Accept("https://didcomm.org/json/1.0/msg", []string{"prp-01"}))
+		require.True(t, msgRegistrar.Services()[0].Accept("https://didcomm.org/other/1.0/msg", []string{"prp-01"}))
+		require.False(t, msgRegistrar.Services()[0].Accept("https://didcomm.org/json/1.0/msg", []string{"prp-02"}))
+	})
+
 	t.Run("Register Message Service Input validation", func(t *testing.T) {
 		tests := []struct {
 			name      string
@@ -95,7 +129,7 @@ func TestCommand_RegisterService(t *testing.T) {
 				errorMsg:  errMsgSvcNameRequired,
 			},
 			{
-				name:      "missing type test",
+				name:      "missing type and purpose test",
 				json:      `{"name": "svx-001"}`,
 				errorCode: InvalidRequestErrorCode,
 				errorMsg:  errMsgInvalidAcceptanceCrit,
@@ -314,6 +348,74 @@ func TestCommand_UnregisterService(t *testing.T) {
 	})
 }
 
+func TestCommand_RegisteredServiceInbound(t *testing.T) {
+	const msgType = "https://example.com/app/1.0/custom"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messenger := serviceMocks.NewMockMessengerHandler(ctrl)
+	messenger.EXPECT().HandleInbound(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	registrar := msghandlerpkg.NewRegistrar()
+
+	ctx, err := context.New(context.WithMessageServiceProvider(registrar), context.WithMessengerHandler(messenger))
+	require.NoError(t, err)
+
+	type notification struct {
+		topic   string
+		message []byte
+	}
+
+	var notifications []notification
+
+	cmd, err := New(&protocol.MockProvider{}, registrar, &webhook.Notifier{
+		NotifyFunc: func(topic string, message []byte) error {
+			notifications = append(notifications, notification{topic: topic, message: message})
+
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.RegisterService(&b, bytes.NewBufferString(
+		fmt.Sprintf(`{"name":"custom-svc","type":"%s"}`, msgType))))
+
+	handleInbound := ctx.InboundMessageHandler()
+
+	// matching message is delivered to the notifier topic of the registered service
+	require.NoError(t, handleInbound([]byte(fmt.Sprintf(`{"@id":"msg-1","@type":"%s","text":"hello"}`, msgType)),
+		"mydid", "theirdid"))
+
+	require.Len(t, notifications, 1)
+	require.Equal(t, "custom-svc", notifications[0].topic)
+
+	topic := struct {
+		Message  map[string]interface{} `json:"message"`
+		MyDID    string                 `json:"mydid"`
+		TheirDID string                 `json:"theirdid"`
+	}{}
+	require.NoError(t, json.Unmarshal(notifications[0].message, &topic))
+	require.Equal(t, "msg-1", topic.Message["@id"])
+	require.Equal(t, "hello", topic.Message["text"])
+	require.Equal(t, "mydid", topic.MyDID)
+	require.Equal(t, "theirdid", topic.TheirDID)
+
+	// non matching message isn't delivered
+	err = handleInbound([]byte(`{"@id":"msg-2","@type":"https://example.com/app/1.0/other"}`), "mydid", "theirdid")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no message handlers found")
+	require.Len(t, notifications, 1)
+
+	// unregistered service doesn't receive messages anymore
+	require.NoError(t, cmd.UnregisterService(&b, bytes.NewBufferString(`{"name":"custom-svc"}`)))
+
+	err = handleInbound([]byte(fmt.Sprintf(`{"@id":"msg-3","@type":"%s"}`, msgType)), "mydid", "theirdid")
+	require.Error(t, err)
+	require.Len(t, notifications, 1)
+}
+
 func TestCommand_Services(t *testing.T) {
 	msgRegistrar := msghandler.NewMockMsgServiceProvider()
 	cmd, err := New(&protocol.MockProvider{}, msgRegistrar, webhook.NewMockWebhookNotifier())
@@ -446,14 +548,14 @@ func TestCommand_Send(t *testing.T) {
 		for _, test := range tests {
 			tc := test
 			t.Run(tc.name, func(t *testing.T) {
-				mockStore := &storage.MockStore{Store: make(map[string][]byte)}
+				mockStore := &mockstorage.MockStore{Store: make(map[string][]byte)}
 				if tc.testConnection != nil {
 					connBytes, err := json.Marshal(tc.testConnection)
 					require.NoError(t, err)
 					require.NoError(t, mockStore.Put(fmt.Sprintf("conn_%s", tc.testConnection.ConnectionID), connBytes))
 				}
 
-				cmd, err := New(&protocol.MockProvider{StoreProvider: storage.NewCustomMockStoreProvider(mockStore)},
+				cmd, err := New(&protocol.MockProvider{StoreProvider: mockstorage.NewCustomMockStoreProvider(mockStore)},
 					msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
 				require.NoError(t, err)
 				require.NotNil(t, cmd)
@@ -553,11 +655,11 @@ func TestCommand_Send(t *testing.T) {
 				provider := &protocol.MockProvider{}
 
 				if tc.testConnection != nil {
-					mockStore := &storage.MockStore{Store: make(map[string][]byte)}
+					mockStore := &mockstorage.MockStore{Store: make(map[string][]byte)}
 					connBytes, err := json.Marshal(tc.testConnection)
 					require.NoError(t, err)
 					require.NoError(t, mockStore.Put(fmt.Sprintf("conn_%s", tc.testConnection.ConnectionID), connBytes))
-					provider.StoreProvider = storage.NewCustomMockStoreProvider(mockStore)
+					provider.StoreProvider = mockstorage.NewCustomMockStoreProvider(mockStore)
 				}
 
 				if tc.messenger != nil {
@@ -681,6 +783,434 @@ func TestCommand_Reply(t *testing.T) {
 	})
 }
 
+func TestCommand_Resend(t *testing.T) {
+	t.Run("Test resend the last message of the thread", func(t *testing.T) {
+		var resent []string
+
+		cmd, err := New(&protocol.MockProvider{
+			CustomMessenger: &mocksvc.MockMessenger{ResendFunc: func(thID string) error {
+				resent = append(resent, thID)
+
+				return nil
+			}},
+		}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			var b bytes.Buffer
+			require.NoError(t, cmd.Resend(&b, bytes.NewBufferString(`{"thread_ID": "piid-1"}`)))
+			require.Empty(t, b.String())
+		}
+
+		require.Equal(t, []string{"piid-1", "piid-1"}, resent)
+	})
+
+	t.Run("Test resend validation and failures", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			requestJSON string
+			messenger   *mocksvc.MockMessenger
+			errorCode   command.Code
+			errorMsg    string
+			errType     command.Type
+		}{
+			{
+				name:        "missing thread ID",
+				requestJSON: `{}`,
+				errorCode:   InvalidRequestErrorCode,
+				errorMsg:    errMsgThreadIDEmpty,
+				errType:     command.ValidationError,
+			},
+			{
+				name:        "invalid request",
+				requestJSON: `----`,
+				errorCode:   InvalidRequestErrorCode,
+				errorMsg:    "invalid character",
+				errType:     command.ValidationError,
+			},
+			{
+				name:        "unknown thread",
+				requestJSON: `{"thread_ID": "unknown"}`,
+				messenger: &mocksvc.MockMessenger{
+					ErrResend: fmt.Errorf("get outbound message: %w", storage.ErrDataNotFound),
+				},
+				errorCode: ResendMsgError,
+				errorMsg:  storage.ErrDataNotFound.Error(),
+				errType:   command.ExecuteError,
+			},
+		}
+
+		for _, test := range tests {
+			tc := test
+			t.Run(tc.name, func(t *testing.T) {
+				provider := &protocol.MockProvider{}
+
+				if tc.messenger != nil {
+					provider.CustomMessenger = tc.messenger
+				}
+
+				cmd, err := New(provider, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+				require.NoError(t, err)
+
+				var b bytes.Buffer
+				cmdErr := cmd.Resend(&b, bytes.NewBufferString(tc.requestJSON))
+				require.Error(t, cmdErr)
+				require.Equal(t, tc.errType, cmdErr.Type())
+				require.Equal(t, tc.errorCode, cmdErr.Code())
+				require.Contains(t, cmdErr.Error(), tc.errorMsg)
+			})
+		}
+	})
+
+	t.Run("Test resend not supported by the messenger", func(t *testing.T) {
+		cmd := &Command{ctx: &messengerProvider{provider: &protocol.MockProvider{}}}
+
+		cmdErr := cmd.Resend(&bytes.Buffer{}, bytes.NewBufferString(`{"thread_ID": "piid-1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, ResendMsgError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errMsgResendNotSupported)
+	})
+}
+
+func TestCommand_GetMessageTrace(t *testing.T) {
+	const issueCredential = "https://didcomm.org/issue-credential/2.0"
+
+	tracer := dispatcher.NewTracer()
+
+	for _, step := range []struct {
+		msgType, direction string
+	}{
+		{"propose-credential", dispatcher.DirectionInbound},
+		{"offer-credential", dispatcher.DirectionOutbound},
+		{"request-credential", dispatcher.DirectionInbound},
+		{"issue-credential", dispatcher.DirectionOutbound},
+	} {
+		tracer.Record(service.DIDCommMsgMap{
+			"@id":     "msg-" + step.msgType,
+			"@type":   issueCredential + "/" + step.msgType,
+			"~thread": map[string]interface{}{"thid": "thread-1"},
+		}, dispatcher.Span{Direction: step.direction})
+	}
+
+	newProvider := func() *tracingProvider {
+		return &tracingProvider{
+			provider: &protocol.MockProvider{
+				StoreProvider:              mockstorage.NewMockStoreProvider(),
+				ProtocolStateStoreProvider: mockstorage.NewMockStoreProvider(),
+			},
+			outbound: &tracingOutbound{tracer: tracer},
+		}
+	}
+
+	getTrace := func(t *testing.T, prov provider, thID string) *MessageTraceResponse {
+		t.Helper()
+
+		cmd, err := New(prov, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.GetMessageTrace(&b, bytes.NewBufferString(`{"thread_ID": "`+thID+`"}`)))
+
+		response := &MessageTraceResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), response))
+
+		return response
+	}
+
+	t.Run("Test trace of the protocol steps of a thread", func(t *testing.T) {
+		prov := newProvider()
+
+		recorder, err := connection.NewRecorder(prov)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveThreadRecord(&connection.ThreadRecord{
+			ThreadID: "thread-1", ConnectionID: "conn-1", Protocol: issueCredential,
+		}))
+
+		response := getTrace(t, prov, "thread-1")
+		require.Equal(t, "thread-1", response.ThreadID)
+		require.Equal(t, "conn-1", response.ConnectionID)
+
+		var steps []string
+		for _, span := range response.Steps {
+			steps = append(steps, span.Direction+" "+span.MessageType)
+		}
+
+		require.Equal(t, []string{
+			"inbound " + issueCredential + "/propose-credential",
+			"outbound " + issueCredential + "/offer-credential",
+			"inbound " + issueCredential + "/request-credential",
+			"outbound " + issueCredential + "/issue-credential",
+		}, steps)
+	})
+
+	t.Run("Test trace of a thread without connection", func(t *testing.T) {
+		response := getTrace(t, newProvider(), "thread-1")
+		require.Empty(t, response.ConnectionID)
+		require.Len(t, response.Steps, 4)
+	})
+
+	t.Run("Test get message trace validation and failures", func(t *testing.T) {
+		failingStore := mockstorage.NewMockStoreProvider()
+		failingStore.Store.ErrGet = fmt.Errorf("get error")
+
+		tests := []struct {
+			name        string
+			requestJSON string
+			provider    provider
+			errorCode   command.Code
+			errorMsg    string
+			errType     command.Type
+		}{
+			{
+				name:        "missing thread ID",
+				requestJSON: `{}`,
+				errorCode:   InvalidRequestErrorCode,
+				errorMsg:    errMsgThreadIDEmpty,
+				errType:     command.ValidationError,
+			},
+			{
+				name:        "invalid request",
+				requestJSON: `----`,
+				errorCode:   InvalidRequestErrorCode,
+				errorMsg:    "invalid character",
+				errType:     command.ValidationError,
+			},
+			{
+				name:        "thread not traced",
+				requestJSON: `{"thread_ID": "unknown"}`,
+				errorCode:   GetMessageTraceError,
+				errorMsg:    "no message traced on thread `unknown`",
+				errType:     command.ExecuteError,
+			},
+			{
+				name:        "tracing not supported",
+				requestJSON: `{"thread_ID": "thread-1"}`,
+				provider:    &protocol.MockProvider{},
+				errorCode:   GetMessageTraceError,
+				errorMsg:    errMsgTraceNotSupported,
+				errType:     command.ExecuteError,
+			},
+			{
+				name:        "thread lookup failure",
+				requestJSON: `{"thread_ID": "thread-1"}`,
+				provider: &tracingProvider{
+					provider: &protocol.MockProvider{
+						StoreProvider:              failingStore,
+						ProtocolStateStoreProvider: mockstorage.NewMockStoreProvider(),
+					},
+					outbound: &tracingOutbound{tracer: tracer},
+				},
+				errorCode: GetMessageTraceError,
+				errorMsg:  "get error",
+				errType:   command.ExecuteError,
+			},
+		}
+
+		for _, test := range tests {
+			tc := test
+			t.Run(tc.name, func(t *testing.T) {
+				prov := tc.provider
+				if prov == nil {
+					prov = newProvider()
+				}
+
+				cmd, err := New(prov, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+				require.NoError(t, err)
+
+				var b bytes.Buffer
+				cmdErr := cmd.GetMessageTrace(&b, bytes.NewBufferString(tc.requestJSON))
+				require.Error(t, cmdErr)
+				require.Equal(t, tc.errType, cmdErr.Type())
+				require.Equal(t, tc.errorCode, cmdErr.Code())
+				require.Contains(t, cmdErr.Error(), tc.errorMsg)
+			})
+		}
+	})
+}
+
+// tracingProvider provides an outbound dispatcher tracing messages.
+type tracingProvider struct {
+	provider
+	outbound dispatcher.Outbound
+}
+
+func (p *tracingProvider) OutboundDispatcher() dispatcher.Outbound {
+	return p.outbound
+}
+
+type tracingOutbound struct {
+	mockdispatcher.MockOutbound
+	tracer *dispatcher.Tracer
+}
+
+func (o *tracingOutbound) Tracer() *dispatcher.Tracer {
+	return o.tracer
+}
+
+// messengerProvider provides a messenger not supporting resending messages.
+type messengerProvider struct {
+	provider
+}
+
+func (p *messengerProvider) Messenger() service.Messenger {
+	return struct{ service.Messenger }{}
+}
+
+func TestCommand_SendMessage(t *testing.T) {
+	newCommand := func(t *testing.T, messenger *mocksvc.MockMessenger, records ...*connection.Record) *Command {
+		mockStore := &mockstorage.MockStore{Store: make(map[string][]byte)}
+
+		for _, record := range records {
+			connBytes, err := json.Marshal(record)
+			require.NoError(t, err)
+			require.NoError(t, mockStore.Put(fmt.Sprintf("conn_%s", record.ConnectionID), connBytes))
+		}
+
+		cmd, err := New(&protocol.MockProvider{
+			StoreProvider:   mockstorage.NewCustomMockStoreProvider(mockStore),
+			CustomMessenger: messenger,
+		}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		return cmd
+	}
+
+	completed := &connection.Record{ConnectionID: "conn-001", State: "completed", MyDID: "mydid", TheirDID: "theirdid"}
+
+	t.Run("Test send basic message success", func(t *testing.T) {
+		var sent []service.DIDCommMsgMap
+
+		cmd := newCommand(t, &mocksvc.MockMessenger{
+			SendFunc: func(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+				require.Equal(t, "mydid", myDID)
+				require.Equal(t, "theirdid", theirDID)
+
+				sent = append(sent, msg)
+
+				return nil
+			},
+		}, completed)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendMessage(&b, bytes.NewBufferString(
+			`{"connection_ID": "conn-001", "content": "hello", "locale": "en"}`))
+		require.NoError(t, cmdErr)
+
+		response := SendBasicMessageResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+
+		require.Len(t, sent, 1)
+		require.Equal(t, basic.MessageRequestType, sent[0].Type())
+		require.Equal(t, response.ID, sent[0].ID())
+
+		msg := basic.Message{}
+		require.NoError(t, sent[0].Decode(&msg))
+		require.Equal(t, "hello", msg.Content)
+		require.Equal(t, "en", msg.I10n.Locale)
+		require.False(t, msg.SentTime.IsZero())
+	})
+
+	t.Run("Test send basic message failures", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			requestJSON string
+			messenger   *mocksvc.MockMessenger
+			errorCode   command.Code
+			errorType   command.Type
+			errorMsg    string
+		}{
+			{
+				name:        "invalid input",
+				requestJSON: `----`,
+				errorCode:   InvalidRequestErrorCode,
+				errorType:   command.ValidationError,
+				errorMsg:    "invalid character",
+			},
+			{
+				name:        "missing connection ID",
+				requestJSON: `{"content": "hello"}`,
+				errorCode:   InvalidRequestErrorCode,
+				errorType:   command.ValidationError,
+				errorMsg:    errMsgConnectionIDEmpty,
+			},
+			{
+				name:        "missing content",
+				requestJSON: `{"connection_ID": "conn-001"}`,
+				errorCode:   InvalidRequestErrorCode,
+				errorType:   command.ValidationError,
+				errorMsg:    errMsgContentEmpty,
+			},
+			{
+				name:        "connection not found",
+				requestJSON: `{"connection_ID": "conn-unknown", "content": "hello"}`,
+				errorCode:   InvalidRequestErrorCode,
+				errorType:   command.ValidationError,
+				errorMsg:    "connection `conn-unknown` not found",
+			},
+			{
+				name:        "connection not completed",
+				requestJSON: `{"connection_ID": "conn-002", "content": "hello"}`,
+				errorCode:   InvalidRequestErrorCode,
+				errorType:   command.ValidationError,
+				errorMsg:    "connection `conn-002` is not completed",
+			},
+			{
+				name:        "send failure",
+				requestJSON: `{"connection_ID": "conn-001", "content": "hello"}`,
+				messenger:   &mocksvc.MockMessenger{ErrSend: fmt.Errorf("sample-err-01")},
+				errorCode:   SendBasicMsgError,
+				errorType:   command.ExecuteError,
+				errorMsg:    "sample-err-01",
+			},
+		}
+
+		for _, test := range tests {
+			tc := test
+			t.Run(tc.name, func(t *testing.T) {
+				messenger := tc.messenger
+				if messenger == nil {
+					messenger = &mocksvc.MockMessenger{
+						SendFunc: func(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+							require.FailNow(t, "message sent")
+
+							return nil
+						},
+					}
+				}
+
+				cmd := newCommand(t, messenger, completed,
+					&connection.Record{ConnectionID: "conn-002", State: "requested", MyDID: "mydid", TheirDID: "theirdid"})
+
+				var b bytes.Buffer
+				cmdErr := cmd.SendMessage(&b, bytes.NewBufferString(tc.requestJSON))
+				require.Error(t, cmdErr)
+				require.Contains(t, cmdErr.Error(), tc.errorMsg)
+				require.Equal(t, tc.errorCode, cmdErr.Code())
+				require.Equal(t, tc.errorType, cmdErr.Type())
+				require.Empty(t, b.String())
+			})
+		}
+	})
+
+	t.Run("Test send basic message connection lookup failure", func(t *testing.T) {
+		cmd, err := New(&protocol.MockProvider{
+			StoreProvider: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+				Store:  make(map[string][]byte),
+				ErrGet: fmt.Errorf("sample-err-02"),
+			}),
+		}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendMessage(&b, bytes.NewBufferString(`{"connection_ID": "conn-001", "content": "hello"}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "sample-err-02")
+		require.Equal(t, SendBasicMsgError, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
+
 func TestCommand_SendToDestinationFailures(t *testing.T) {
 	prov := &protocol.MockProvider{}
 	prov.CustomVDRI = &mockvdri.MockVDRIRegistry{
@@ -697,3 +1227,308 @@ func TestCommand_SendToDestinationFailures(t *testing.T) {
 	require.Error(t, cErr)
 	require.Equal(t, cErr.Error(), errMsgDestinationMissing)
 }
+
+// packAgent is an agent with its own KMS and stores, packing and unpacking messages through the command.
+type packAgent struct {
+	cmd            *Command
+	kms            *localkms.LocalKMS
+	thirdPartyKeys storage.Store
+}
+
+func newPackAgent(t *testing.T) *packAgent {
+	t.Helper()
+
+	storeProvider := mockstorage.NewMockStoreProvider()
+
+	k, err := localkms.New("local-lock://test/key/uri",
+		mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	packerProvider := &mockprovider.Provider{KMSValue: k, StorageProviderValue: storeProvider}
+
+	authPacker, err := authcrypt.New(packerProvider, jose.A256GCM)
+	require.NoError(t, err)
+
+	anonPacker, err := anoncrypt.New(packerProvider, jose.A256GCM)
+	require.NoError(t, err)
+
+	cmd, err := New(&protocol.MockProvider{
+		StoreProvider: storeProvider,
+		CustomKMS:     k,
+		CustomPackers: []packer.Packer{authPacker, anonPacker},
+	}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	store, err := storeProvider.OpenStore(authcrypt.ThirdPartyKeysDB)
+	require.NoError(t, err)
+
+	return &packAgent{cmd: cmd, kms: k, thirdPartyKeys: base58wrapper.NewBase58StoreWrapper(store)}
+}
+
+func (a *packAgent) createKey(t *testing.T, kt kms.KeyType) RecipientKey {
+	t.Helper()
+
+	kid, _, err := a.kms.Create(kt)
+	require.NoError(t, err)
+
+	pubKey, err := a.kms.ExportPubKeyBytes(kid)
+	require.NoError(t, err)
+
+	return RecipientKey{KeyID: kid, PublicKey: base64.RawURLEncoding.EncodeToString(pubKey)}
+}
+
+func (a *packAgent) pack(t *testing.T, args *PackMessageArgs) (string, command.Error) {
+	t.Helper()
+
+	request, err := json.Marshal(args)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+
+	cmdErr := a.cmd.PackMessage(&b, bytes.NewBuffer(request))
+	if cmdErr != nil {
+		return "", cmdErr
+	}
+
+	response := PackMessageResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+
+	return response.Envelope, nil
+}
+
+func (a *packAgent) unpack(t *testing.T, envelope string) (*UnpackMessageResponse, command.Error) {
+	t.Helper()
+
+	request, err := json.Marshal(&UnpackMessageArgs{Envelope: envelope})
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+
+	cmdErr := a.cmd.UnpackMessage(&b, bytes.NewBuffer(request))
+	if cmdErr != nil {
+		return nil, cmdErr
+	}
+
+	response := &UnpackMessageResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), response))
+
+	return response, nil
+}
+
+func TestCommand_PackUnpackMessage(t *testing.T) {
+	const msg = `{"@id":"msg-1","@type":"https://didcomm.org/basicmessage/1.0/message","content":"hello"}`
+
+	t.Run("Test authcrypt round trip", func(t *testing.T) {
+		alice, bob := newPackAgent(t), newPackAgent(t)
+
+		aliceKey := alice.createKey(t, kms.ECDH1PU256AES256GCMType)
+		bobKey := bob.createKey(t, kms.ECDH1PU256AES256GCMType)
+
+		// bob knows the sender key of alice
+		alicePubKey, err := base64.RawURLEncoding.DecodeString(aliceKey.PublicKey)
+		require.NoError(t, err)
+		require.NoError(t, bob.thirdPartyKeys.Put(aliceKey.KeyID, alicePubKey))
+
+		envelope, cmdErr := alice.pack(t, &PackMessageArgs{
+			Message:       json.RawMessage(msg),
+			SenderKeyID:   aliceKey.KeyID,
+			RecipientKeys: []RecipientKey{bobKey},
+		})
+		require.NoError(t, cmdErr)
+		require.NotContains(t, envelope, "hello")
+
+		response, cmdErr := bob.unpack(t, envelope)
+		require.NoError(t, cmdErr)
+		require.JSONEq(t, msg, string(response.Message))
+		require.Equal(t, aliceKey.PublicKey, response.SenderKey)
+		require.NotEmpty(t, response.RecipientKey)
+
+		// the envelope of a sender impersonating alice doesn't decrypt with the sender key of alice
+		mallory := newPackAgent(t)
+		malloryKey := mallory.createKey(t, kms.ECDH1PU256AES256GCMType)
+
+		envelope, cmdErr = mallory.pack(t, &PackMessageArgs{
+			Message:       json.RawMessage(msg),
+			SenderKeyID:   malloryKey.KeyID,
+			RecipientKeys: []RecipientKey{bobKey},
+		})
+		require.NoError(t, cmdErr)
+
+		jwe, err := jose.Deserialize(envelope)
+		require.NoError(t, err)
+
+		jwe.ProtectedHeaders[jose.HeaderSenderKeyID] = aliceKey.KeyID
+
+		envelope, err = jwe.CompactSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		_, cmdErr = bob.unpack(t, envelope)
+		require.Error(t, cmdErr)
+		require.Equal(t, UnpackMsgError, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Test anoncrypt round trip with multiple recipients", func(t *testing.T) {
+		alice, bob, carol := newPackAgent(t), newPackAgent(t), newPackAgent(t)
+
+		bobKey := bob.createKey(t, kms.ECDHES256AES256GCMType)
+		carolKey := carol.createKey(t, kms.ECDHES256AES256GCMType)
+
+		envelope, cmdErr := alice.pack(t, &PackMessageArgs{
+			Message:       json.RawMessage(msg),
+			RecipientKeys: []RecipientKey{bobKey, carolKey},
+		})
+		require.NoError(t, cmdErr)
+
+		for _, recipient := range []*packAgent{bob, carol} {
+			response, cmdErr := recipient.unpack(t, envelope)
+			require.NoError(t, cmdErr)
+			require.JSONEq(t, msg, string(response.Message))
+			require.Empty(t, response.SenderKey)
+			require.NotEmpty(t, response.RecipientKey)
+		}
+
+		// alice isn't a recipient
+		_, cmdErr = alice.unpack(t, envelope)
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "no matching recipient in envelope")
+		require.Equal(t, UnpackMsgError, cmdErr.Code())
+	})
+
+	t.Run("Test pack message failures", func(t *testing.T) {
+		alice := newPackAgent(t)
+		bobKey := newPackAgent(t).createKey(t, kms.ECDHES256AES256GCMType)
+
+		noPackers, err := New(&protocol.MockProvider{}, msghandler.NewMockMsgServiceProvider(),
+			webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		tests := []struct {
+			name      string
+			cmd       *Command
+			request   string
+			errorCode command.Code
+			errorType command.Type
+			errorMsg  string
+		}{
+			{
+				name:      "invalid input",
+				request:   `----`,
+				errorCode: InvalidRequestErrorCode,
+				errorType: command.ValidationError,
+				errorMsg:  "invalid character",
+			},
+			{
+				name:      "missing message",
+				request:   `{"recipient_keys": [{"keyID": "k1", "publicKey": "e30"}]}`,
+				errorCode: InvalidRequestErrorCode,
+				errorType: command.ValidationError,
+				errorMsg:  errMsgBodyEmpty,
+			},
+			{
+				name:      "missing recipient keys",
+				request:   `{"message": {"msg": "hello"}}`,
+				errorCode: InvalidRequestErrorCode,
+				errorType: command.ValidationError,
+				errorMsg:  errMsgRecipientKeysEmpty,
+			},
+			{
+				name:      "recipient key not base64url",
+				request:   `{"message": {"msg": "hello"}, "recipient_keys": [{"keyID": "k1", "publicKey": "!!!"}]}`,
+				errorCode: InvalidRequestErrorCode,
+				errorType: command.ValidationError,
+				errorMsg:  "invalid recipient key 'k1'",
+			},
+			{
+				name: "recipient key not a public key",
+				request: `{"message": {"msg": "hello"}, "recipient_keys": [{"keyID": "k1", "publicKey": "` +
+					base64.RawURLEncoding.EncodeToString([]byte("key")) + `"}]}`,
+				errorCode: InvalidRequestErrorCode,
+				errorType: command.ValidationError,
+				errorMsg:  "invalid recipient key 'k1'",
+			},
+			{
+				name: "packer not available",
+				cmd:  noPackers,
+				request: `{"message": {"msg": "hello"}, "recipient_keys": [{"keyID": "` + bobKey.KeyID +
+					`", "publicKey": "` + bobKey.PublicKey + `"}]}`,
+				errorCode: PackMsgError,
+				errorType: command.ExecuteError,
+				errorMsg:  "anoncrypt packer not available",
+			},
+			{
+				name: "sender key not found",
+				request: `{"message": {"msg": "hello"}, "sender_key_id": "unknown", "recipient_keys": [{"keyID": "` +
+					bobKey.KeyID + `", "publicKey": "` + bobKey.PublicKey + `"}]}`,
+				errorCode: PackMsgError,
+				errorType: command.ExecuteError,
+				errorMsg:  "failed to get sender key from KMS",
+			},
+		}
+
+		for _, test := range tests {
+			tc := test
+			t.Run(tc.name, func(t *testing.T) {
+				cmd := tc.cmd
+				if cmd == nil {
+					cmd = alice.cmd
+				}
+
+				var b bytes.Buffer
+				cmdErr := cmd.PackMessage(&b, bytes.NewBufferString(tc.request))
+				require.Error(t, cmdErr)
+				require.Contains(t, cmdErr.Error(), tc.errorMsg)
+				require.Equal(t, tc.errorCode, cmdErr.Code())
+				require.Equal(t, tc.errorType, cmdErr.Type())
+				require.Empty(t, b.String())
+			})
+		}
+	})
+
+	t.Run("Test unpack message failures", func(t *testing.T) {
+		alice := newPackAgent(t)
+
+		tests := []struct {
+			name      string
+			request   string
+			errorCode command.Code
+			errorType command.Type
+			errorMsg  string
+		}{
+			{
+				name:      "invalid input",
+				request:   `----`,
+				errorCode: InvalidRequestErrorCode,
+				errorType: command.ValidationError,
+				errorMsg:  "invalid character",
+			},
+			{
+				name:      "missing envelope",
+				request:   `{}`,
+				errorCode: InvalidRequestErrorCode,
+				errorType: command.ValidationError,
+				errorMsg:  errMsgEnvelopeEmpty,
+			},
+			{
+				name:      "invalid envelope",
+				request:   `{"envelope": "not.a.jwe"}`,
+				errorCode: InvalidRequestErrorCode,
+				errorType: command.ValidationError,
+				errorMsg:  "invalid envelope",
+			},
+		}
+
+		for _, test := range tests {
+			tc := test
+			t.Run(tc.name, func(t *testing.T) {
+				var b bytes.Buffer
+				cmdErr := alice.cmd.UnpackMessage(&b, bytes.NewBufferString(tc.request))
+				require.Error(t, cmdErr)
+				require.Contains(t, cmdErr.Error(), tc.errorMsg)
+				require.Equal(t, tc.errorCode, cmdErr.Code())
+				require.Equal(t, tc.errorType, cmdErr.Type())
+				require.Empty(t, b.String())
+			})
+		}
+	})
+}
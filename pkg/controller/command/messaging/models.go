@@ -8,9 +8,12 @@ package messaging
 
 import (
 	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 )
 
-// RegisterMsgSvcArgs contains parameters for registering a message service to message handler.
+// RegisterMsgSvcArgs contains parameters for registering a message service to message handler,
+// at least one of the message type and purpose acceptance criteria is required.
 type RegisterMsgSvcArgs struct {
 	// Name of the message service
 	Name string `json:"name"`
@@ -84,6 +87,48 @@ type SendReplyMessageArgs struct {
 	MessageBody json.RawMessage `json:"message_body"`
 }
 
+// ResendMessageArgs contains parameters for resending the last message of a thread.
+type ResendMessageArgs struct {
+	// ID of the thread, the protocol instance ID (piID) for the protocols
+	ThreadID string `json:"thread_ID"`
+}
+
+// MessageTraceArgs contains parameters for getting the trace of a thread.
+type MessageTraceArgs struct {
+	// ID of the thread, the protocol instance ID (piID) for the protocols
+	ThreadID string `json:"thread_ID"`
+}
+
+// MessageTraceResponse is response of getting the trace of a thread.
+type MessageTraceResponse struct {
+	// ID of the thread
+	ThreadID string `json:"thread_ID"`
+
+	// ID of the connection the messages of the thread were received on, empty if unknown
+	ConnectionID string `json:"connection_id,omitempty"`
+
+	// Protocol steps of the thread, the messages received and sent on the thread in the order they happened
+	Steps []dispatcher.Span `json:"steps"`
+}
+
+// SendBasicMessageArgs contains parameters for sending a basic message over a connection.
+type SendBasicMessageArgs struct {
+	// ID of the completed connection the message is sent over
+	ConnectionID string `json:"connection_ID"`
+
+	// Content of the basic message
+	Content string `json:"content"`
+
+	// Locale of the content, optional
+	Locale string `json:"locale,omitempty"`
+}
+
+// SendBasicMessageResponse is response of sending a basic message.
+type SendBasicMessageResponse struct {
+	// ID of the basic message sent
+	ID string `json:"id"`
+}
+
 // RegisterHTTPMsgSvcArgs contains parameters for registering an HTTP over DIDComm message service to message handler.
 type RegisterHTTPMsgSvcArgs struct {
 	// Name of the HTTP over DIDComm message service
@@ -94,3 +139,49 @@ type RegisterHTTPMsgSvcArgs struct {
 	// If not provided then all incoming messages of HTTP over DIDComm type will be handled by operation.
 	Purpose []string `json:"purpose"`
 }
+
+// PackMessageArgs contains parameters for packing a message in an encryption envelope.
+type PackMessageArgs struct {
+	// Message to pack
+	Message json.RawMessage `json:"message"`
+
+	// KMS key ID of the sender key, optional.
+	// The message is packed with authcrypt if provided and with anoncrypt otherwise.
+	SenderKeyID string `json:"sender_key_id,omitempty"`
+
+	// Public keys of the recipients of the message
+	RecipientKeys []RecipientKey `json:"recipient_keys"`
+}
+
+// RecipientKey is the public key of a message recipient.
+type RecipientKey struct {
+	// KMS key ID of the recipient key
+	KeyID string `json:"keyID"`
+
+	// Public key bytes as exported by the recipient KMS, base64url encoded
+	PublicKey string `json:"publicKey"`
+}
+
+// PackMessageResponse is response of packing a message.
+type PackMessageResponse struct {
+	// Encryption envelope, a JWE in compact serialization for a single recipient and in JSON serialization otherwise
+	Envelope string `json:"envelope"`
+}
+
+// UnpackMessageArgs contains parameters for unpacking an encryption envelope.
+type UnpackMessageArgs struct {
+	// Encryption envelope to unpack
+	Envelope string `json:"envelope"`
+}
+
+// UnpackMessageResponse is response of unpacking an encryption envelope.
+type UnpackMessageResponse struct {
+	// Unpacked message
+	Message json.RawMessage `json:"message"`
+
+	// Public key of the authenticated sender, base64url encoded. Empty for anoncrypt envelopes.
+	SenderKey string `json:"sender_key,omitempty"`
+
+	// Public key of the recipient which unpacked the envelope, base64url encoded
+	RecipientKey string `json:"recipient_key"`
+}
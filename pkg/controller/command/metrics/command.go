@@ -0,0 +1,159 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+var logger = log.New("aries-framework/controller/metrics")
+
+const (
+	// GetMetricsErrorCode is for failures in get metrics command.
+	GetMetricsErrorCode = command.Code(iota + command.Metrics)
+)
+
+// constants for the metrics controller command
+const (
+	// command name
+	CommandName = "metrics"
+
+	// command methods
+	GetMetricsCommandMethod = "GetMetrics"
+
+	// log constants
+	successString = "success"
+)
+
+// Provider contains dependencies for the metrics controller command and is typically created by using
+// aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+	Messenger() service.Messenger
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// the counters maintained by the protocol services, the dispatcher and the messenger.
+type (
+	credentialsIssuedCounter interface {
+		CredentialsIssued() uint64
+	}
+
+	presentationsVerifiedCounter interface {
+		PresentationsVerified() uint64
+	}
+
+	messagesSentCounter interface {
+		MessagesSent() uint64
+	}
+
+	messagesReceivedCounter interface {
+		MessagesReceived() uint64
+	}
+)
+
+// Command is controller command exposing the runtime counters of the agent.
+type Command struct {
+	ctx    Provider
+	lookup *connection.Lookup
+}
+
+// New returns new metrics controller command instance.
+func New(ctx Provider) (*Command, error) {
+	lookup, err := connection.NewLookup(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create connection lookup: %w", err)
+	}
+
+	return &Command{ctx: ctx, lookup: lookup}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, GetMetricsCommandMethod, c.GetMetrics),
+	}
+}
+
+// GetMetrics returns the counters of the agent since it started: the active (completed) connections, the
+// credentials issued, the proofs verified and the messages sent and received. The counters of the services not
+// registered to the agent are zero.
+func (c *Command) GetMetrics(rw io.Writer, _ io.Reader) command.Error {
+	activeConnections, err := c.activeConnections()
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetMetricsCommandMethod, err.Error())
+
+		return command.NewExecuteError(GetMetricsErrorCode, err)
+	}
+
+	response := &MetricsResponse{ActiveConnections: activeConnections}
+
+	if counter, ok := c.service(issuecredential.Name).(credentialsIssuedCounter); ok {
+		response.CredentialsIssued = counter.CredentialsIssued()
+	}
+
+	if counter, ok := c.service(presentproof.Name).(presentationsVerifiedCounter); ok {
+		response.ProofsVerified = counter.PresentationsVerified()
+	}
+
+	if counter, ok := c.ctx.OutboundDispatcher().(messagesSentCounter); ok {
+		response.MessagesSent = counter.MessagesSent()
+	}
+
+	if counter, ok := c.ctx.Messenger().(messagesReceivedCounter); ok {
+		response.MessagesReceived = counter.MessagesReceived()
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, GetMetricsCommandMethod, successString)
+
+	return nil
+}
+
+// activeConnections returns the number of connections in completed state.
+func (c *Command) activeConnections() (int, error) {
+	records, err := c.lookup.QueryConnectionRecords()
+	if err != nil {
+		return 0, fmt.Errorf("query connection records: %w", err)
+	}
+
+	var active int
+
+	for _, record := range records {
+		if record.State == didexchange.StateIDCompleted {
+			active++
+		}
+	}
+
+	return active, nil
+}
+
+// service returns the protocol service with given name, nil if not registered.
+func (c *Command) service(name string) interface{} {
+	svc, err := c.ctx.Service(name)
+	if err != nil {
+		return nil
+	}
+
+	return svc
+}
@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockservice "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+// services stubs the counters of the protocol services.
+type services struct {
+	issued, verified uint64
+}
+
+func (s *services) CredentialsIssued() uint64     { return s.issued }
+func (s *services) PresentationsVerified() uint64 { return s.verified }
+
+type outbound struct {
+	mockdispatcher.MockOutbound
+	sent uint64
+}
+
+func (o *outbound) MessagesSent() uint64 { return o.sent }
+
+type messenger struct {
+	mockservice.MockMessenger
+	received uint64
+}
+
+func (m *messenger) MessagesReceived() uint64 { return m.received }
+
+func newProvider(services map[string]interface{}) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		ServiceMap:                        services,
+		OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+	}
+}
+
+func getMetrics(t *testing.T, prov Provider) *MetricsResponse {
+	t.Helper()
+
+	cmd, err := New(prov)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.GetMetrics(&b, nil))
+
+	response := &MetricsResponse{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), response))
+
+	return response
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(newProvider(nil))
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 1)
+	})
+
+	t.Run("create connection lookup (error)", func(t *testing.T) {
+		prov := newProvider(nil)
+		prov.StorageProviderValue = &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open failed")}
+
+		cmd, err := New(prov)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create connection lookup")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestCommand_GetMetrics(t *testing.T) {
+	t.Run("counters of the agent", func(t *testing.T) {
+		svc := &services{issued: 3, verified: 2}
+
+		prov := newProvider(map[string]interface{}{issuecredential.Name: svc, presentproof.Name: svc})
+		prov.OutboundDispatcherValue = &outbound{sent: 7}
+		prov.MessengerValue = &messenger{received: 5}
+
+		recorder, err := connection.NewRecorder(prov)
+		require.NoError(t, err)
+
+		for id, state := range map[string]string{
+			"conn-1": didexchange.StateIDCompleted,
+			"conn-2": didexchange.StateIDCompleted,
+			"conn-3": didexchange.StateIDRequested,
+		} {
+			require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+				ConnectionID: id, State: state, MyDID: id + "-my", TheirDID: id + "-their",
+			}))
+		}
+
+		require.Equal(t, &MetricsResponse{
+			ActiveConnections: 2,
+			CredentialsIssued: 3,
+			ProofsVerified:    2,
+			MessagesSent:      7,
+			MessagesReceived:  5,
+		}, getMetrics(t, prov))
+	})
+
+	t.Run("services not registered", func(t *testing.T) {
+		require.Equal(t, &MetricsResponse{}, getMetrics(t, newProvider(nil)))
+	})
+
+	t.Run("query connection records (error)", func(t *testing.T) {
+		store := mockstore.NewMockStoreProvider()
+		store.Store.Store["conn_invalid"] = []byte("{")
+
+		prov := newProvider(nil)
+		prov.StorageProviderValue = store
+
+		cmd, err := New(prov)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetMetrics(&b, nil)
+		require.Error(t, cmdErr)
+		require.Equal(t, GetMetricsErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "query connection records")
+	})
+}
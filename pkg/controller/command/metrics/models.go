@@ -0,0 +1,28 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+// MetricsResponse model
+//
+// Represents the runtime counters of the agent since it started
+//
+type MetricsResponse struct {
+	// ActiveConnections is the number of connections in completed state
+	ActiveConnections int `json:"active_connections"`
+
+	// CredentialsIssued is the number of credentials issued with the issue-credential protocol
+	CredentialsIssued uint64 `json:"credentials_issued"`
+
+	// ProofsVerified is the number of presentations received and accepted with the present-proof protocol
+	ProofsVerified uint64 `json:"proofs_verified"`
+
+	// MessagesSent is the number of messages sent, or forwarded, by the agent
+	MessagesSent uint64 `json:"messages_sent"`
+
+	// MessagesReceived is the number of inbound messages handled by the agent
+	MessagesReceived uint64 `json:"messages_received"`
+}
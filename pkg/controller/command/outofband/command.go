@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 )
 
@@ -59,6 +60,8 @@ const (
 	errEmptyRequest                = "request was not provided"
 	errEmptyMyLabel                = "my_label was not provided"
 	errEmptyPIID                   = "piid was not provided"
+	errPublicDIDWithService        = "public_did and service can't be provided together"
+	errInvitationWithURL           = "invitation and invitation_url can't be provided together"
 	// log constants
 	successString = "success"
 
@@ -70,6 +73,7 @@ var logger = log.New("aries-framework/controller/outofband")
 
 // Command is controller command for outofband.
 type Command struct {
+	ctx    outofband.Provider
 	client *outofband.Client
 }
 
@@ -98,7 +102,7 @@ func New(ctx outofband.Provider, notifier command.Notifier) (*Command, error) {
 	obs.RegisterAction(protocol.Name+_actions, actions)
 	obs.RegisterStateMsg(protocol.Name+_states, states)
 
-	return &Command{client: client}, nil
+	return &Command{ctx: ctx, client: client}, nil
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -153,6 +157,9 @@ func (c *Command) CreateRequest(rw io.Writer, req io.Reader) command.Error {
 // CreateInvitation creates and saves an out-of-band invitation.
 // Protocols is an optional list of protocol identifier URIs that can be used to form connections. A default
 // will be set if none are provided.
+// The invitation is reachable through the public DID if provided, otherwise through the given service entries or
+// a new inlined service block. The response includes an invitation URL for sharing the invitation, based on the
+// agent's endpoint and using the 'oob' encoding unless specified otherwise.
 func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 	var args CreateInvitationArgs
 	if err := json.NewDecoder(req).Decode(&args); err != nil {
@@ -160,10 +167,28 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
+	urlBase, urlEncoding, err := c.invitationURLOptions(&args)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateInvitation, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	services := args.Service
+
+	if args.PublicDID != "" {
+		if len(services) > 0 {
+			logutil.LogDebug(logger, CommandName, CreateInvitation, errPublicDIDWithService)
+			return command.NewValidationError(InvalidRequestErrorCode, errors.New(errPublicDIDWithService))
+		}
+
+		services = []interface{}{args.PublicDID}
+	}
+
 	invitation, err := c.client.CreateInvitation(args.Protocols, []outofband.MessageOption{
 		outofband.WithGoal(args.Goal, args.GoalCode),
 		outofband.WithLabel(args.Label),
-		outofband.WithServices(args.Service...),
+		outofband.WithServices(services...),
+		outofband.WithAttachments(args.Attachments...),
 	}...)
 
 	if err != nil {
@@ -171,8 +196,15 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 		return command.NewExecuteError(CreateInvitationErrorCode, err)
 	}
 
+	invitationURL, err := cmdutil.InvitationURL(urlBase, urlEncoding, invitation)
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreateInvitation, err.Error())
+		return command.NewExecuteError(CreateInvitationErrorCode, err)
+	}
+
 	command.WriteNillableResponse(rw, &CreateInvitationResponse{
-		Invitation: invitation,
+		Invitation:    invitation,
+		InvitationURL: invitationURL,
 	}, logger)
 
 	logutil.LogDebug(logger, CommandName, CreateInvitation, successString)
@@ -180,6 +212,30 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// invitationURLOptions returns the base and the encoding of the invitation URL, the agent's endpoint and the 'oob'
+// encoding are used unless provided.
+func (c *Command) invitationURLOptions(args *CreateInvitationArgs) (string, string, error) {
+	base, encoding := c.ctx.ServiceEndpoint(), cmdutil.OOBInvitationEncoding
+
+	if args.InvitationURLBase != "" {
+		if err := cmdutil.ValidateInvitationURLBase(args.InvitationURLBase); err != nil {
+			return "", "", err
+		}
+
+		base = args.InvitationURLBase
+	}
+
+	if args.InvitationURLEncoding != "" {
+		if err := cmdutil.ValidateInvitationURLEncoding(args.InvitationURLEncoding); err != nil {
+			return "", "", err
+		}
+
+		encoding = args.InvitationURLEncoding
+	}
+
+	return base, encoding, nil
+}
+
 // AcceptRequest from another agent and return the ID of a new connection record.
 func (c *Command) AcceptRequest(rw io.Writer, req io.Reader) command.Error { // nolint: dupl
 	var args AcceptRequestArgs
@@ -214,14 +270,32 @@ func (c *Command) AcceptRequest(rw io.Writer, req io.Reader) command.Error { //
 }
 
 // AcceptInvitation from another agent and return the ID of the new connection records.
-func (c *Command) AcceptInvitation(rw io.Writer, req io.Reader) command.Error { // nolint: dupl
+// The invitation is either provided as JSON or as an invitation URL, it can be reached through a public DID or an
+// inlined service block.
+func (c *Command) AcceptInvitation(rw io.Writer, req io.Reader) command.Error {
 	var args AcceptInvitationArgs
 	if err := json.NewDecoder(req).Decode(&args); err != nil {
 		logutil.LogInfo(logger, CommandName, AcceptInvitation, err.Error())
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
-	if args.Invitation == nil {
+	if args.Invitation != nil && args.InvitationURL != "" {
+		logutil.LogDebug(logger, CommandName, AcceptInvitation, errInvitationWithURL)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errInvitationWithURL))
+	}
+
+	invitation := args.Invitation
+
+	if args.InvitationURL != "" {
+		invitation = &outofband.Invitation{}
+
+		if err := cmdutil.DecodeInvitationURL(args.InvitationURL, invitation); err != nil {
+			logutil.LogInfo(logger, CommandName, AcceptInvitation, err.Error())
+			return command.NewValidationError(InvalidRequestErrorCode, err)
+		}
+	}
+
+	if invitation == nil {
 		logutil.LogDebug(logger, CommandName, AcceptInvitation, errEmptyRequest)
 		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyRequest))
 	}
@@ -231,7 +305,12 @@ func (c *Command) AcceptInvitation(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyLabel))
 	}
 
-	connID, err := c.client.AcceptInvitation(args.Invitation, args.MyLabel)
+	if err := validateInvitation(invitation); err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptInvitation, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	connID, err := c.client.AcceptInvitation(invitation, args.MyLabel)
 	if err != nil {
 		logutil.LogError(logger, CommandName, AcceptInvitation, err.Error())
 		return command.NewExecuteError(AcceptInvitationErrorCode, err)
@@ -246,6 +325,36 @@ func (c *Command) AcceptInvitation(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// validateInvitation checks that the invitation is an out-of-band invitation reachable through at least one
+// public DID or service block.
+func validateInvitation(invitation *outofband.Invitation) error {
+	if invitation.ID == "" {
+		return errors.New("invalid invitation: @id was not provided")
+	}
+
+	if invitation.Type != outofband.InvitationMsgType {
+		return fmt.Errorf("invalid invitation: unsupported @type '%s'", invitation.Type)
+	}
+
+	if len(invitation.Service) == 0 {
+		return errors.New("invalid invitation: service was not provided")
+	}
+
+	for _, svc := range invitation.Service {
+		switch s := svc.(type) {
+		case string:
+			if s == "" {
+				return errors.New("invalid invitation: empty DID service entry")
+			}
+		case map[string]interface{}, *did.Service:
+		default:
+			return fmt.Errorf("invalid invitation: unsupported service entry %v", s)
+		}
+	}
+
+	return nil
+}
+
 // Actions returns pending actions that have not yet to be executed or canceled.
 func (c *Command) Actions(rw io.Writer, _ io.Reader) command.Error {
 	result, err := c.client.Actions()
@@ -8,19 +8,26 @@ package outofband
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"testing"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/client/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/outofband"
 	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 )
 
 const (
@@ -28,6 +35,8 @@ const (
 	label       = "label"
 	reason      = "reason"
 	jsonPayload = `{"piid":"` + PIID + `","label":"` + label + `","reason":"` + reason + `"}`
+
+	publicDIDInvitation = `{"@id":"inv-1","@type":"` + outofband.InvitationMsgType + `","service":["did:example:123"]}`
 )
 
 func TestNew(t *testing.T) {
@@ -187,6 +196,7 @@ func TestCommand_CreateInvitation(t *testing.T) {
 
 	provider := mocks.NewMockProvider(ctrl)
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+	provider.EXPECT().ServiceEndpoint().Return("http://example.com/agent").AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
 		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
@@ -209,6 +219,7 @@ func TestCommand_CreateInvitation(t *testing.T) {
 
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+		provider.EXPECT().ServiceEndpoint().Return("http://example.com/agent")
 		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
@@ -243,6 +254,7 @@ func TestCommand_CreateInvitation(t *testing.T) {
 			Service:   []interface{}{"s1"},
 			Protocols: []string{"s1"},
 		}
+		provider.EXPECT().ServiceEndpoint().Return("http://example.com/agent")
 		args, err := json.Marshal(expected)
 		require.NoError(t, err)
 		require.NoError(t, cmd.CreateInvitation(&b, bytes.NewBuffer(args)))
@@ -254,9 +266,161 @@ func TestCommand_CreateInvitation(t *testing.T) {
 		require.Equal(t, expected.GoalCode, res.Invitation.GoalCode)
 		require.Equal(t, expected.Service, res.Invitation.Service)
 		require.Equal(t, expected.Protocols, res.Invitation.Protocols)
+		require.Equal(t, res.Invitation, decodeInvitationURL(t, res.InvitationURL))
+	})
+
+	t.Run("Public DID", func(t *testing.T) {
+		service := mocks.NewMockOobService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().SaveInvitation(gomock.Any()).Return(nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+		provider.EXPECT().ServiceEndpoint().Return("http://example.com/agent")
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.CreateInvitation(&b, bytes.NewBufferString(
+			`{"public_did":"did:example:123","attachments":[{"@id":"a1","mime-type":"application/json",`+
+				`"data":{"json":{"@id":"o1","@type":"https://didcomm.org/issue-credential/2.0/offer-credential"}}}]}`)))
+
+		res := CreateInvitationResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Equal(t, []interface{}{"did:example:123"}, res.Invitation.Service)
+		require.Len(t, res.Invitation.Requests, 1)
+		require.Equal(t, "a1", res.Invitation.Requests[0].ID)
+		require.Regexp(t, `^http://example\.com/agent\?oob=`, res.InvitationURL)
+		require.Equal(t, res.Invitation, decodeInvitationURL(t, res.InvitationURL))
+	})
+
+	t.Run("Invitation URL base and encoding", func(t *testing.T) {
+		for _, encoding := range []string{cmdutil.OOBInvitationEncoding, cmdutil.LegacyInvitationEncoding} {
+			service := mocks.NewMockOobService(ctrl)
+			service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+			service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+			service.EXPECT().SaveInvitation(gomock.Any()).Return(nil)
+
+			provider := mocks.NewMockProvider(ctrl)
+			provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+			provider.EXPECT().ServiceEndpoint().Return("http://example.com/agent")
+			cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+			require.NoError(t, err)
+			require.NotNil(t, cmd)
+
+			args, err := json.Marshal(&CreateInvitationArgs{
+				Label:                 "label",
+				PublicDID:             "did:example:123",
+				InvitationURLBase:     "https://example.com/invite",
+				InvitationURLEncoding: encoding,
+			})
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			require.NoError(t, cmd.CreateInvitation(&b, bytes.NewBuffer(args)))
+
+			res := CreateInvitationResponse{}
+			require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+			require.Regexp(t, `^https://example\.com/invite\?`+encoding+`=`, res.InvitationURL)
+			require.Equal(t, res.Invitation, decodeInvitationURL(t, res.InvitationURL))
+		}
+	})
+
+	t.Run("Invalid invitation URL options (error)", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		for _, args := range []string{
+			`{"invitation_url_base":"example.com/invite"}`,
+			`{"invitation_url_base":"ws://example.com/invite"}`,
+			`{"invitation_url_encoding":"d_m"}`,
+		} {
+			var b bytes.Buffer
+			cmdErr := cmd.CreateInvitation(&b, bytes.NewBufferString(args))
+
+			require.Error(t, cmdErr, args)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), args)
+			require.Equal(t, command.ValidationError, cmdErr.Type(), args)
+		}
+	})
+
+	t.Run("Public DID and service (error)", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreateInvitation(&b, bytes.NewBufferString(`{"public_did":"did:example:123","service":["s1"]}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errPublicDIDWithService)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Unsupported attachment (error)", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreateInvitation(&b, bytes.NewBufferString(
+			`{"public_did":"did:example:123","attachments":[{"@id":"a1","mime-type":"text/plain","data":{"json":{}}}]}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "unsupported media type 'text/plain' of attachment a1")
+		require.Equal(t, CreateInvitationErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Inlined service block", func(t *testing.T) {
+		service := mocks.NewMockOobService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().SaveInvitation(gomock.Any()).Return(nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(protocol.Name).Return(service, nil)
+		provider.EXPECT().Service(mediator.Coordination).Return(&mockroute.MockMediatorSvc{}, nil)
+		provider.EXPECT().KMS().Return(&mockkms.KeyManager{CrAndExportPubKeyValue: []byte("verkey")})
+		provider.EXPECT().ServiceEndpoint().Return("http://example.com/agent").Times(2)
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.CreateInvitation(&b, bytes.NewBufferString(`{"label":"label"}`)))
+
+		res := CreateInvitationResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+
+		inv := decodeInvitationURL(t, res.InvitationURL)
+		require.Equal(t, res.Invitation, inv)
+		require.Len(t, inv.Service, 1)
+
+		svcBytes, err := json.Marshal(inv.Service[0])
+		require.NoError(t, err)
+
+		svc := &did.Service{}
+		require.NoError(t, json.Unmarshal(svcBytes, svc))
+		require.Equal(t, "did-communication", svc.Type)
+		require.Equal(t, "http://example.com/agent", svc.ServiceEndpoint)
+		require.Equal(t, []string{base58.Encode([]byte("verkey"))}, svc.RecipientKeys)
 	})
 }
 
+func decodeInvitationURL(t *testing.T, invitationURL string) *outofband.Invitation {
+	t.Helper()
+
+	inv := &outofband.Invitation{}
+	require.NoError(t, cmdutil.DecodeInvitationURL(invitationURL, inv))
+
+	return inv
+}
+
 func TestCommand_AcceptRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -416,7 +580,7 @@ func TestCommand_AcceptInvitation(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		cmdErr := cmd.AcceptInvitation(&b, bytes.NewBufferString(`{"invitation":{},"my_label":"label"}`))
+		cmdErr := cmd.AcceptInvitation(&b, bytes.NewBufferString(`{"invitation":`+publicDIDInvitation+`,"my_label":"label"}`))
 
 		require.Error(t, cmdErr)
 		require.Contains(t, cmdErr.Error(), "error message")
@@ -438,11 +602,132 @@ func TestCommand_AcceptInvitation(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		var b bytes.Buffer
-		require.NoError(t, cmd.AcceptInvitation(&b, bytes.NewBufferString(`{"invitation":{},"my_label":"label"}`)))
+		require.NoError(t, cmd.AcceptInvitation(&b, bytes.NewBufferString(
+			`{"invitation":`+publicDIDInvitation+`,"my_label":"label"}`)))
 		res := AcceptInvitationResponse{}
 		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
 		require.Equal(t, connID, res.ConnectionID)
 	})
+
+	t.Run("Success (invitation URL with inlined service block)", func(t *testing.T) {
+		const connID = "conn-id"
+
+		inv := &outofband.Invitation{
+			ID:   "inv-1",
+			Type: outofband.InvitationMsgType,
+			Service: []interface{}{map[string]interface{}{
+				"ID":              "svc-1",
+				"Type":            "did-communication",
+				"RecipientKeys":   []interface{}{"key-1"},
+				"ServiceEndpoint": "http://example.com/agent",
+			}},
+			Protocols: []string{"https://didcomm.org/didexchange/1.0"},
+		}
+		invBytes, err := json.Marshal(inv)
+		require.NoError(t, err)
+
+		service := mocks.NewMockOobService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		service.EXPECT().AcceptInvitation(gomock.Any(), "label").DoAndReturn(
+			func(i *protocol.Invitation, _ string) (string, error) {
+				require.Equal(t, inv.ID, i.ID)
+				require.Equal(t, inv.Service, i.Service)
+				require.Equal(t, inv.Protocols, i.Protocols)
+
+				return connID, nil
+			}).Times(2)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		for _, invURL := range []string{
+			"http://example.com/agent?oob=" + base64.URLEncoding.EncodeToString(invBytes),
+			"http://example.com/agent?oob=" + base64.RawURLEncoding.EncodeToString(invBytes),
+		} {
+			args, err := json.Marshal(&AcceptInvitationArgs{InvitationURL: invURL, MyLabel: "label"})
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			require.NoError(t, cmd.AcceptInvitation(&b, bytes.NewBuffer(args)))
+			res := AcceptInvitationResponse{}
+			require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+			require.Equal(t, connID, res.ConnectionID)
+		}
+	})
+
+	t.Run("Malformed invitation", func(t *testing.T) {
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		tests := []struct {
+			name     string
+			args     string
+			errorMsg string
+		}{{
+			name:     "invitation and invitation URL",
+			args:     `{"invitation":` + publicDIDInvitation + `,"invitation_url":"http://example.com?oob=e30"}`,
+			errorMsg: errInvitationWithURL,
+		}, {
+			name:     "missing @id",
+			args:     `{"invitation":{"@type":"` + outofband.InvitationMsgType + `","service":["did:example:1"]}}`,
+			errorMsg: "@id was not provided",
+		}, {
+			name:     "unsupported @type",
+			args:     `{"invitation":{"@id":"1","@type":"https://didcomm.org/other/1.0/invitation","service":["s1"]}}`,
+			errorMsg: "unsupported @type",
+		}, {
+			name:     "missing service",
+			args:     `{"invitation":{"@id":"1","@type":"` + outofband.InvitationMsgType + `","service":[]}}`,
+			errorMsg: "service was not provided",
+		}, {
+			name:     "empty DID",
+			args:     `{"invitation":{"@id":"1","@type":"` + outofband.InvitationMsgType + `","service":[""]}}`,
+			errorMsg: "empty DID service entry",
+		}, {
+			name:     "unsupported service entry",
+			args:     `{"invitation":{"@id":"1","@type":"` + outofband.InvitationMsgType + `","service":[1]}}`,
+			errorMsg: "unsupported service entry",
+		}, {
+			name:     "invitation URL without invitation parameter",
+			args:     `{"invitation_url":"http://example.com?x=e30"}`,
+			errorMsg: "has no 'oob' or 'c_i' query parameter",
+		}, {
+			name:     "invitation URL not base64url encoded",
+			args:     `{"invitation_url":"http://example.com?oob=!!!"}`,
+			errorMsg: "decode invitation URL",
+		}, {
+			name:     "invitation URL not carrying JSON",
+			args:     `{"invitation_url":"http://example.com?oob=` + base64.URLEncoding.EncodeToString([]byte("{")) + `"}`,
+			errorMsg: "unmarshal invitation",
+		}, {
+			name:     "invalid invitation URL",
+			args:     `{"invitation_url":"://example.com"}`,
+			errorMsg: "parse invitation URL",
+		}}
+
+		for _, test := range tests {
+			var args map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(test.args), &args), test.name)
+
+			args["my_label"] = "label"
+
+			payload, err := json.Marshal(args)
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			cmdErr := cmd.AcceptInvitation(&b, bytes.NewBuffer(payload))
+
+			require.Error(t, cmdErr, test.name)
+			require.Contains(t, cmdErr.Error(), test.errorMsg, test.name)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), test.name)
+			require.Equal(t, command.ValidationError, cmdErr.Type(), test.name)
+		}
+	})
 }
 
 func TestCommand_Actions(t *testing.T) {
@@ -43,6 +43,21 @@ type CreateInvitationArgs struct {
 	GoalCode  string        `json:"goal_code"`
 	Service   []interface{} `json:"service"`
 	Protocols []string      `json:"protocols"`
+
+	// PublicDID of the agent to be used as the invitation service, can't be provided along with Service.
+	PublicDID string `json:"public_did,omitempty"`
+
+	// Attachments are the issue-credential or present-proof requests to be processed by the recipient once the
+	// connection is established, encoded as the invitation's requests~attach. Their media type must be
+	// 'application/json'.
+	Attachments []*decorator.Attachment `json:"attachments,omitempty"`
+
+	// InvitationURLBase is the absolute http(s) URL of the invitation URL, defaults to the agent's endpoint.
+	InvitationURLBase string `json:"invitation_url_base,omitempty"`
+
+	// InvitationURLEncoding is the query parameter carrying the invitation, either 'oob' (default) or the
+	// legacy 'c_i'.
+	InvitationURLEncoding string `json:"invitation_url_encoding,omitempty"`
 }
 
 // CreateInvitationResponse model
@@ -51,6 +66,9 @@ type CreateInvitationArgs struct {
 //
 type CreateInvitationResponse struct {
 	Invitation *outofband.Invitation `json:"invitation"`
+
+	// InvitationURL carrying the encoded invitation
+	InvitationURL string `json:"invitation_url"`
 }
 
 // AcceptRequestArgs model
@@ -77,6 +95,10 @@ type AcceptRequestResponse struct {
 type AcceptInvitationArgs struct {
 	Invitation *outofband.Invitation `json:"invitation"`
 	MyLabel    string                `json:"my_label"`
+
+	// InvitationURL carrying the encoded invitation in the 'oob' or 'c_i' query parameter, can't be provided
+	// along with Invitation.
+	InvitationURL string `json:"invitation_url,omitempty"`
 }
 
 // AcceptInvitationResponse model
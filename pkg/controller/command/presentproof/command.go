@@ -15,11 +15,14 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/autoaccept"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 const (
@@ -76,9 +79,12 @@ const (
 	errEmptyPresentation        = "empty Presentation"
 	errEmptyProposePresentation = "empty ProposePresentation"
 	errEmptyRequestPresentation = "empty RequestPresentation"
+	errConnectionNotFound       = "connection `%s` not found"
+	errConnectionNotCompleted   = "connection `%s` is not completed"
 
 	// log constants
-	successString = "success"
+	successString      = "success"
+	connectionIDString = "connectionID"
 
 	_actions = "_actions"
 	_states  = "_states"
@@ -86,18 +92,32 @@ const (
 
 var logger = log.New("aries-framework/controller/presentproof")
 
+// Provider contains dependencies for the present proof controller command and is typically created by using
+// aries.Context().
+type Provider interface {
+	presentproof.Provider
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
 // Command is controller command for present proof.
 type Command struct {
-	client *presentproof.Client
+	client      *presentproof.Client
+	connections *connection.Lookup
 }
 
 // New returns new present proof controller command instance.
-func New(ctx presentproof.Provider, notifier command.Notifier) (*Command, error) {
+func New(ctx Provider, notifier command.Notifier) (*Command, error) {
 	client, err := presentproof.New(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create a client: %w", err)
 	}
 
+	connections, err := connection.NewLookup(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create connection lookup: %w", err)
+	}
+
 	// creates action channel
 	actions := make(chan service.DIDCommAction)
 	// registers action channel to listen for events
@@ -112,11 +132,17 @@ func New(ctx presentproof.Provider, notifier command.Notifier) (*Command, error)
 		return nil, fmt.Errorf("register msg event: %w", err)
 	}
 
+	// the actions are auto-accepted according to the policy of the protocol
+	policies, err := autoaccept.NewPolicies(ctx.StorageProvider(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create auto-accept policies: %w", err)
+	}
+
 	obs := webnotifier.NewObserver(notifier)
-	obs.RegisterAction(protocol.Name+_actions, actions)
+	obs.RegisterAction(protocol.Name+_actions, policies.Handle(protocol.Name, actions))
 	obs.RegisterStateMsg(protocol.Name+_states, states)
 
-	return &Command{client: client}, nil
+	return &Command{client: client, connections: connections}, nil
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -153,8 +179,8 @@ func (c *Command) Actions(rw io.Writer, _ io.Reader) command.Error {
 	return nil
 }
 
-// SendRequestPresentation is used by the Verifier to send a request presentation.
-// nolint: dupl
+// SendRequestPresentation is used by the Verifier to send a request presentation, either between the given DIDs or
+// over the given completed connection.
 func (c *Command) SendRequestPresentation(rw io.Writer, req io.Reader) command.Error {
 	var args SendRequestPresentationArgs
 
@@ -163,6 +189,17 @@ func (c *Command) SendRequestPresentation(rw io.Writer, req io.Reader) command.E
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
+	if args.ConnectionID != "" {
+		record, cmdErr := c.completedConnection(args.ConnectionID)
+		if cmdErr != nil {
+			logutil.LogInfo(logger, CommandName, SendRequestPresentation, cmdErr.Error(),
+				logutil.CreateKeyValueString(connectionIDString, args.ConnectionID))
+			return cmdErr
+		}
+
+		args.MyDID, args.TheirDID = record.MyDID, record.TheirDID
+	}
+
 	if args.MyDID == "" {
 		logutil.LogDebug(logger, CommandName, SendRequestPresentation, errEmptyMyDID)
 		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyDID))
@@ -193,6 +230,25 @@ func (c *Command) SendRequestPresentation(rw io.Writer, req io.Reader) command.E
 	return nil
 }
 
+// completedConnection returns the record of the completed connection with the given ID.
+func (c *Command) completedConnection(connectionID string) (*connection.Record, command.Error) {
+	record, err := c.connections.GetConnectionRecord(connectionID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errConnectionNotFound, connectionID))
+	}
+
+	if err != nil {
+		return nil, command.NewExecuteError(SendRequestPresentationErrorCode, fmt.Errorf("get connection: %w", err))
+	}
+
+	if record.State != connection.StateNameCompleted {
+		return nil, command.NewValidationError(InvalidRequestErrorCode,
+			fmt.Errorf(errConnectionNotCompleted, connectionID))
+	}
+
+	return record, nil
+}
+
 // SendProposePresentation is used by the Prover to send a propose presentation.
 // nolint: dupl
 func (c *Command) SendProposePresentation(rw io.Writer, req io.Reader) command.Error {
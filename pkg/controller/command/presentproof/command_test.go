@@ -17,13 +17,36 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/autoaccept"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
 	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 const jsonPayload = `{"piid":"id"}`
 
+// connectionsProvider adds the stores of the connection records to a present proof client provider.
+type connectionsProvider struct {
+	presentproof.Provider
+	storageProvider storage.Provider
+}
+
+func withConnections(p presentproof.Provider) *connectionsProvider {
+	return &connectionsProvider{Provider: p, storageProvider: mem.NewProvider()}
+}
+
+func (p *connectionsProvider) StorageProvider() storage.Provider {
+	return p.storageProvider
+}
+
+func (p *connectionsProvider) ProtocolStateStorageProvider() storage.Provider {
+	return p.storageProvider
+}
+
 func TestNew(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -36,7 +59,7 @@ func TestNew(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -48,7 +71,7 @@ func TestNew(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.EqualError(t, err, "cannot create a client: cast service to presentproof service failed")
 		require.Nil(t, cmd)
 	})
@@ -60,7 +83,7 @@ func TestNew(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.EqualError(t, err, "register action event: error")
 		require.Nil(t, cmd)
 	})
@@ -73,7 +96,7 @@ func TestNew(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.EqualError(t, err, "register msg event: error")
 		require.Nil(t, cmd)
 	})
@@ -91,7 +114,7 @@ func TestCommand_SendRequestPresentation(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -104,7 +127,7 @@ func TestCommand_SendRequestPresentation(t *testing.T) {
 	})
 
 	t.Run("Empty MyDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -118,7 +141,7 @@ func TestCommand_SendRequestPresentation(t *testing.T) {
 	})
 
 	t.Run("Empty TheirDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -132,7 +155,7 @@ func TestCommand_SendRequestPresentation(t *testing.T) {
 	})
 
 	t.Run("Empty RequestPresentation", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -157,7 +180,7 @@ func TestCommand_SendRequestPresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -180,7 +203,7 @@ func TestCommand_SendRequestPresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -188,6 +211,147 @@ func TestCommand_SendRequestPresentation(t *testing.T) {
 		const jsonPayload = `{"my_did":"id","their_did":"id","request_presentation":{}}`
 		require.NoError(t, cmd.SendRequestPresentation(&b, bytes.NewBufferString(jsonPayload)))
 	})
+
+	t.Run("Success (connection)", func(t *testing.T) {
+		protocolService := mocks.NewMockProtocolService(ctrl)
+		protocolService.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		protocolService.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		protocolService.EXPECT().HandleInbound(gomock.Any(), "my-did", "their-did").
+			DoAndReturn(expectRequestPresentation(t, "proof request"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(protocolService, nil)
+
+		prov := withConnections(provider)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn-id",
+			State:        connection.StateNameCompleted,
+			MyDID:        "my-did",
+			TheirDID:     "their-did",
+		})
+
+		cmd, err := New(prov, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		const jsonPayload = `{"connection_id":"conn-id","request_presentation":{"comment":"proof request"}}`
+		require.NoError(t, cmd.SendRequestPresentation(&b, bytes.NewBufferString(jsonPayload)))
+		require.JSONEq(t, `{"piid":"piid"}`, b.String())
+	})
+
+	t.Run("Connection errors", func(t *testing.T) {
+		prov := withConnections(provider)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "invited-id",
+			State:        "invited",
+			MyDID:        "my-did",
+			TheirDID:     "their-did",
+		})
+
+		cmd, err := New(prov, mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SendRequestPresentation(&b,
+			bytes.NewBufferString(`{"connection_id":"missing-id","request_presentation":{}}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "connection `missing-id` not found")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.SendRequestPresentation(&b,
+			bytes.NewBufferString(`{"connection_id":"invited-id","request_presentation":{}}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "connection `invited-id` is not completed")
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.SendRequestPresentation(&b,
+			bytes.NewBufferString(`{"connection_id":"invited-id","request_presentation":"invalid"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+}
+
+// expectRequestPresentation checks the message sent by the client is a request presentation with the given comment.
+func expectRequestPresentation(t *testing.T, comment string) func(service.DIDCommMsg, string, string) (string, error) {
+	return func(msg service.DIDCommMsg, _, _ string) (string, error) {
+		require.Equal(t, protocol.RequestPresentationMsgType, msg.Type())
+
+		request := &protocol.RequestPresentation{}
+		require.NoError(t, msg.Decode(request))
+		require.Equal(t, comment, request.Comment)
+
+		return "piid", nil
+	}
+}
+
+func saveConnection(t *testing.T, prov *connectionsProvider, record *connection.Record) {
+	t.Helper()
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(record))
+}
+
+func TestCommand_AutoAccept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var actions chan<- service.DIDCommAction
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().RegisterActionEvent(gomock.Any()).DoAndReturn(func(ch chan<- service.DIDCommAction) error {
+		actions = ch
+
+		return nil
+	})
+	svc.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+
+	notified := make(chan struct{})
+
+	notifier := mocknotifier.NewMockNotifier(ctrl)
+	notifier.EXPECT().Notify(protocol.Name+_actions, gomock.Any()).Do(func(string, []byte) {
+		notified <- struct{}{}
+	}).Return(nil).Times(2)
+
+	prov := withConnections(provider)
+
+	_, err := New(prov, notifier)
+	require.NoError(t, err)
+
+	// request sends a request presentation action and returns whether it was continued, once notified
+	request := func() bool {
+		continued := false
+
+		actions <- service.DIDCommAction{
+			ProtocolName: protocol.Name,
+			Message: service.NewDIDCommMsgMap(protocol.RequestPresentation{
+				Type: protocol.RequestPresentationMsgType,
+			}),
+			Continue: func(interface{}) { continued = true },
+			Stop:     func(error) {},
+		}
+
+		<-notified
+
+		return continued
+	}
+
+	// present-proof is not auto-accepted by default, the action is left pending
+	require.False(t, request())
+
+	policies, err := autoaccept.NewPolicies(prov.StorageProvider(), nil)
+	require.NoError(t, err)
+	require.NoError(t, policies.Set(protocol.Name, true))
+
+	require.True(t, request())
 }
 
 func TestCommand_SendProposePresentation(t *testing.T) {
@@ -202,7 +366,7 @@ func TestCommand_SendProposePresentation(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -215,7 +379,7 @@ func TestCommand_SendProposePresentation(t *testing.T) {
 	})
 
 	t.Run("Empty MyDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -229,7 +393,7 @@ func TestCommand_SendProposePresentation(t *testing.T) {
 	})
 
 	t.Run("Empty TheirDID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -243,7 +407,7 @@ func TestCommand_SendProposePresentation(t *testing.T) {
 	})
 
 	t.Run("Empty ProposePresentation", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -268,7 +432,7 @@ func TestCommand_SendProposePresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -291,7 +455,7 @@ func TestCommand_SendProposePresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -313,7 +477,7 @@ func TestCommand_AcceptRequestPresentation(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -326,7 +490,7 @@ func TestCommand_AcceptRequestPresentation(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -340,7 +504,7 @@ func TestCommand_AcceptRequestPresentation(t *testing.T) {
 	})
 
 	t.Run("Empty Presentation", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -362,7 +526,7 @@ func TestCommand_AcceptRequestPresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -385,7 +549,7 @@ func TestCommand_AcceptRequestPresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -407,7 +571,7 @@ func TestCommand_NegotiateRequestPresentation(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -420,7 +584,7 @@ func TestCommand_NegotiateRequestPresentation(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -434,7 +598,7 @@ func TestCommand_NegotiateRequestPresentation(t *testing.T) {
 	})
 
 	t.Run("Empty ProposePresentation", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -456,7 +620,7 @@ func TestCommand_NegotiateRequestPresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -479,7 +643,7 @@ func TestCommand_NegotiateRequestPresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -501,7 +665,7 @@ func TestCommand_AcceptProposePresentation(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -514,7 +678,7 @@ func TestCommand_AcceptProposePresentation(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -528,7 +692,7 @@ func TestCommand_AcceptProposePresentation(t *testing.T) {
 	})
 
 	t.Run("Empty RequestPresentation", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -550,7 +714,7 @@ func TestCommand_AcceptProposePresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -573,7 +737,7 @@ func TestCommand_AcceptProposePresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -603,7 +767,7 @@ func TestCommand_Actions(t *testing.T) {
 
 		service.EXPECT().Actions().Return(toProtocolActions(expected.Actions), nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -618,7 +782,7 @@ func TestCommand_Actions(t *testing.T) {
 	t.Run("Error", func(t *testing.T) {
 		service.EXPECT().Actions().Return(nil, errors.New("some error message"))
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -642,7 +806,7 @@ func TestCommand_AcceptPresentation(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -655,7 +819,7 @@ func TestCommand_AcceptPresentation(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -677,7 +841,7 @@ func TestCommand_AcceptPresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -699,7 +863,7 @@ func TestCommand_AcceptPresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -720,7 +884,7 @@ func TestCommand_AcceptProblemReport(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -733,7 +897,7 @@ func TestCommand_AcceptProblemReport(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -755,7 +919,7 @@ func TestCommand_AcceptProblemReport(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -777,7 +941,7 @@ func TestCommand_AcceptProblemReport(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -798,7 +962,7 @@ func TestCommand_DeclineRequestPresentation(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -811,7 +975,7 @@ func TestCommand_DeclineRequestPresentation(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -833,7 +997,7 @@ func TestCommand_DeclineRequestPresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -855,7 +1019,7 @@ func TestCommand_DeclineRequestPresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -876,7 +1040,7 @@ func TestCommand_DeclineProposePresentation(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -889,7 +1053,7 @@ func TestCommand_DeclineProposePresentation(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -911,7 +1075,7 @@ func TestCommand_DeclineProposePresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -933,7 +1097,7 @@ func TestCommand_DeclineProposePresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -954,7 +1118,7 @@ func TestCommand_DeclinePresentation(t *testing.T) {
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
 
 	t.Run("Decode error", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -967,7 +1131,7 @@ func TestCommand_DeclinePresentation(t *testing.T) {
 	})
 
 	t.Run("Empty PIID", func(t *testing.T) {
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -989,7 +1153,7 @@ func TestCommand_DeclinePresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -1011,7 +1175,7 @@ func TestCommand_DeclinePresentation(t *testing.T) {
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
 
-		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		cmd, err := New(withConnections(provider), mocknotifier.NewMockNotifier(nil))
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
 
@@ -168,6 +168,9 @@ type SendRequestPresentationArgs struct {
 	MyDID string `json:"my_did"`
 	// TheirDID receiver's did
 	TheirDID string `json:"their_did"`
+	// ConnectionID of the completed connection to send the request over, MyDID and TheirDID are taken from
+	// the connection if set
+	ConnectionID string `json:"connection_id,omitempty"`
 	// RequestPresentation describes values that need to be revealed and predicates that need to be fulfilled.
 	RequestPresentation *presentproof.RequestPresentation `json:"request_presentation"`
 }
@@ -0,0 +1,148 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+const (
+	// UnknownCommandErrorCode is for dispatching a command which isn't registered.
+	UnknownCommandErrorCode = Code(iota + Common)
+)
+
+const (
+	errEmptyCommandName  = "command name is mandatory"
+	errEmptyCommandExec  = "command execute function is mandatory"
+	errAlreadyRegistered = "registration failed, command with name `%s` already registered"
+	errNeverRegistered   = "failed to deregister, unable to find registered command with name `%s`"
+	errUnknownCommand    = "unable to find registered command with name `%s`"
+)
+
+// QualifiedName returns the name under which the given method of a controller command is registered
+// (eg: "vdri.CreatePublicDID").
+func QualifiedName(name, method string) string {
+	return name + "." + method
+}
+
+// Interceptor wraps the execution of the command registered with given name, so that cross-cutting logic
+// (eg: auth, logging) runs around every dispatched command. An interceptor may short-circuit the command
+// by returning an Exec which doesn't call next.
+type Interceptor func(ctx context.Context, name string, next Exec) Exec
+
+// NewRegistry returns new command registry instance holding given command handlers,
+// returns error in case of duplicate command handlers.
+func NewRegistry(handlers ...Handler) (*Registry, error) {
+	r := &Registry{commands: make(map[string]Exec)}
+
+	for _, handler := range handlers {
+		if err := r.RegisterCommand(QualifiedName(handler.Name(), handler.Method()), handler.Handle()); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Registry maintains the controller commands available for dispatch and allows dynamic register/deregister
+// of commands, so that integrators can add custom commands to an already running agent.
+type Registry struct {
+	commands     map[string]Exec
+	interceptors []Interceptor
+	lock         sync.RWMutex
+}
+
+// Use appends given interceptors to the interceptor chain run around every dispatched command,
+// interceptors run in the order they were added, the first one being the outermost.
+func (r *Registry) Use(interceptors ...Interceptor) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.interceptors = append(r.interceptors, interceptors...)
+}
+
+// RegisterCommand registers given command execute function under given name,
+// returns error in case of duplicate registration.
+func (r *Registry) RegisterCommand(name string, h Exec) error {
+	if name == "" {
+		return errors.New(errEmptyCommandName)
+	}
+
+	if h == nil {
+		return errors.New(errEmptyCommandExec)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.commands[name]; ok {
+		return fmt.Errorf(errAlreadyRegistered, name)
+	}
+
+	r.commands[name] = h
+
+	return nil
+}
+
+// Deregister deregisters command with given name from this registry,
+// returns error if given command doesn't exists.
+func (r *Registry) Deregister(name string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.commands[name]; !ok {
+		return fmt.Errorf(errNeverRegistered, name)
+	}
+
+	delete(r.commands, name)
+
+	return nil
+}
+
+// Commands returns the sorted names of the commands registered to this registry.
+func (r *Registry) Commands() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	names := make([]string, 0, len(r.commands))
+	for name := range r.commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Dispatch executes the command registered with given name.
+func (r *Registry) Dispatch(name string, rw io.Writer, req io.Reader) Error {
+	return r.DispatchContext(context.Background(), name, rw, req)
+}
+
+// DispatchContext executes the command registered with given name through the interceptor chain,
+// ctx is passed to the interceptors.
+func (r *Registry) DispatchContext(ctx context.Context, name string, rw io.Writer, req io.Reader) Error {
+	r.lock.RLock()
+	exec, ok := r.commands[name]
+	interceptors := r.interceptors
+	r.lock.RUnlock()
+
+	if !ok {
+		return NewValidationError(UnknownCommandErrorCode, fmt.Errorf(errUnknownCommand, name))
+	}
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		exec = interceptors[i](ctx, name, exec)
+	}
+
+	return exec(rw, req)
+}
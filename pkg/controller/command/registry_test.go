@@ -0,0 +1,273 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistry(t *testing.T) {
+	t.Run("test new registry with handlers", func(t *testing.T) {
+		registry, err := NewRegistry(
+			&mockHandler{name: "vdri", method: "ResolveDID", exec: echoExec},
+			&mockHandler{name: "vdri", method: "CreatePublicDID", exec: echoExec},
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"vdri.CreatePublicDID", "vdri.ResolveDID"}, registry.Commands())
+	})
+
+	t.Run("test new registry with duplicate handlers", func(t *testing.T) {
+		registry, err := NewRegistry(
+			&mockHandler{name: "vdri", method: "ResolveDID", exec: echoExec},
+			&mockHandler{name: "vdri", method: "ResolveDID", exec: echoExec},
+		)
+		require.EqualError(t, err, fmt.Sprintf(errAlreadyRegistered, "vdri.ResolveDID"))
+		require.Nil(t, registry)
+	})
+}
+
+func TestRegistry_RegisterCommand(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	t.Run("test register and dispatch custom command", func(t *testing.T) {
+		err = registry.RegisterCommand("custom.Echo", echoExec)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := registry.Dispatch("custom.Echo", &b, bytes.NewBufferString(`{"hello":"world"}`))
+		require.NoError(t, cmdErr)
+		require.Equal(t, `{"hello":"world"}`, b.String())
+	})
+
+	t.Run("test duplicate registration", func(t *testing.T) {
+		err = registry.RegisterCommand("custom.Echo", echoExec)
+		require.EqualError(t, err, fmt.Sprintf(errAlreadyRegistered, "custom.Echo"))
+	})
+
+	t.Run("test invalid registration", func(t *testing.T) {
+		err = registry.RegisterCommand("", echoExec)
+		require.EqualError(t, err, errEmptyCommandName)
+
+		err = registry.RegisterCommand("custom.Nil", nil)
+		require.EqualError(t, err, errEmptyCommandExec)
+	})
+
+	t.Run("test dispatch command error", func(t *testing.T) {
+		err = registry.RegisterCommand("custom.Fail", func(rw io.Writer, req io.Reader) Error {
+			return NewExecuteError(UnknownStatus, errors.New("failed"))
+		})
+		require.NoError(t, err)
+
+		cmdErr := registry.Dispatch("custom.Fail", &bytes.Buffer{}, &bytes.Buffer{})
+		require.Error(t, cmdErr)
+		require.Equal(t, ExecuteError, cmdErr.Type())
+		require.EqualError(t, cmdErr, "failed")
+	})
+}
+
+func TestRegistry_Deregister(t *testing.T) {
+	registry, err := NewRegistry(&mockHandler{name: "custom", method: "Echo", exec: echoExec})
+	require.NoError(t, err)
+
+	err = registry.Deregister("custom.Echo")
+	require.NoError(t, err)
+	require.Empty(t, registry.Commands())
+
+	cmdErr := registry.Dispatch("custom.Echo", &bytes.Buffer{}, &bytes.Buffer{})
+	require.Error(t, cmdErr)
+	require.Equal(t, ValidationError, cmdErr.Type())
+	require.Equal(t, UnknownCommandErrorCode, cmdErr.Code())
+	require.EqualError(t, cmdErr, fmt.Sprintf(errUnknownCommand, "custom.Echo"))
+
+	err = registry.Deregister("custom.Echo")
+	require.EqualError(t, err, fmt.Sprintf(errNeverRegistered, "custom.Echo"))
+
+	// command can be registered again once deregistered
+	err = registry.RegisterCommand("custom.Echo", echoExec)
+	require.NoError(t, err)
+}
+
+func TestRegistry_Concurrency(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			name := fmt.Sprintf("custom.Echo%d", i)
+			require.NoError(t, registry.RegisterCommand(name, echoExec))
+
+			var b bytes.Buffer
+			require.NoError(t, registry.Dispatch(name, &b, bytes.NewBufferString(name)))
+			require.Equal(t, name, b.String())
+
+			require.NoError(t, registry.Deregister(name))
+		}(i)
+	}
+
+	wg.Wait()
+
+	require.Empty(t, registry.Commands())
+}
+
+func TestRegistry_Use(t *testing.T) {
+	t.Run("test interceptor short-circuits command", func(t *testing.T) {
+		registry, err := NewRegistry()
+		require.NoError(t, err)
+
+		called := false
+
+		err = registry.RegisterCommand("custom.Echo", func(rw io.Writer, req io.Reader) Error {
+			called = true
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		registry.Use(func(ctx context.Context, name string, next Exec) Exec {
+			return func(rw io.Writer, req io.Reader) Error {
+				if ctx.Value(ctxKey("token")) == nil {
+					return NewValidationError(UnknownStatus, fmt.Errorf("unauthorized call to %s", name))
+				}
+
+				return next(rw, req)
+			}
+		})
+
+		cmdErr := registry.Dispatch("custom.Echo", &bytes.Buffer{}, &bytes.Buffer{})
+		require.EqualError(t, cmdErr, "unauthorized call to custom.Echo")
+		require.False(t, called)
+
+		ctx := context.WithValue(context.Background(), ctxKey("token"), "secret")
+		cmdErr = registry.DispatchContext(ctx, "custom.Echo", &bytes.Buffer{}, &bytes.Buffer{})
+		require.NoError(t, cmdErr)
+		require.True(t, called)
+	})
+
+	t.Run("test interceptor mutates response", func(t *testing.T) {
+		registry, err := NewRegistry(&mockHandler{name: "custom", method: "Echo", exec: echoExec})
+		require.NoError(t, err)
+
+		registry.Use(func(_ context.Context, _ string, next Exec) Exec {
+			return func(rw io.Writer, req io.Reader) Error {
+				var b bytes.Buffer
+
+				if cmdErr := next(&b, req); cmdErr != nil {
+					return cmdErr
+				}
+
+				_, err := rw.Write(bytes.ToUpper(b.Bytes()))
+				if err != nil {
+					return NewExecuteError(UnknownStatus, err)
+				}
+
+				return nil
+			}
+		})
+
+		var b bytes.Buffer
+		cmdErr := registry.Dispatch("custom.Echo", &b, bytes.NewBufferString("hello"))
+		require.NoError(t, cmdErr)
+		require.Equal(t, "HELLO", b.String())
+	})
+
+	t.Run("test interceptors ordering", func(t *testing.T) {
+		registry, err := NewRegistry(&mockHandler{name: "custom", method: "Echo", exec: echoExec})
+		require.NoError(t, err)
+
+		var calls []string
+
+		tracer := func(id string) Interceptor {
+			return func(_ context.Context, name string, next Exec) Exec {
+				return func(rw io.Writer, req io.Reader) Error {
+					calls = append(calls, id+" before "+name)
+					defer func() { calls = append(calls, id+" after "+name) }()
+
+					return next(rw, req)
+				}
+			}
+		}
+
+		registry.Use(tracer("first"), tracer("second"))
+		registry.Use(tracer("third"))
+
+		cmdErr := registry.Dispatch("custom.Echo", &bytes.Buffer{}, bytes.NewBufferString("hello"))
+		require.NoError(t, cmdErr)
+		require.Equal(t, []string{
+			"first before custom.Echo",
+			"second before custom.Echo",
+			"third before custom.Echo",
+			"third after custom.Echo",
+			"second after custom.Echo",
+			"first after custom.Echo",
+		}, calls)
+	})
+
+	t.Run("test interceptors don't run for unknown command", func(t *testing.T) {
+		registry, err := NewRegistry()
+		require.NoError(t, err)
+
+		registry.Use(func(_ context.Context, _ string, next Exec) Exec {
+			require.FailNow(t, "interceptor isn't expected to be called")
+
+			return next
+		})
+
+		cmdErr := registry.Dispatch("custom.Echo", &bytes.Buffer{}, &bytes.Buffer{})
+		require.Error(t, cmdErr)
+		require.Equal(t, UnknownCommandErrorCode, cmdErr.Code())
+	})
+}
+
+type ctxKey string
+
+func echoExec(rw io.Writer, req io.Reader) Error {
+	b, err := ioutil.ReadAll(req)
+	if err != nil {
+		return NewValidationError(UnknownStatus, err)
+	}
+
+	_, err = rw.Write(b)
+	if err != nil {
+		return NewExecuteError(UnknownStatus, err)
+	}
+
+	return nil
+}
+
+type mockHandler struct {
+	name   string
+	method string
+	exec   Exec
+}
+
+func (m *mockHandler) Name() string {
+	return m.name
+}
+
+func (m *mockHandler) Method() string {
+	return m.method
+}
+
+func (m *mockHandler) Handle() Exec {
+	return m.exec
+}
@@ -7,18 +7,35 @@ SPDX-License-Identifier: Apache-2.0
 package vdri
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/httpbinding"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
 )
 
 var logger = log.New("aries-framework/command/vdri")
@@ -36,6 +53,33 @@ const (
 
 	// ResolveDIDErrorCode for get did error.
 	ResolveDIDErrorCode
+
+	// CreatePublicDIDErrorCode for create public did error.
+	CreatePublicDIDErrorCode
+
+	// RegisterVDRIErrorCode for register vdri error.
+	RegisterVDRIErrorCode
+
+	// DiffDIDErrorCode for diff did error.
+	DiffDIDErrorCode
+
+	// GetDIDCommServiceErrorCode for get DIDComm service error.
+	GetDIDCommServiceErrorCode
+
+	// ImportDIDErrorCode for import did error.
+	ImportDIDErrorCode
+
+	// GetDIDMethodsErrorCode for get did methods error.
+	GetDIDMethodsErrorCode
+
+	// CreatePeerDIDErrorCode for create peer did error.
+	CreatePeerDIDErrorCode
+
+	// ValidateDIDDocErrorCode for validate did doc error.
+	ValidateDIDDocErrorCode
+
+	// GetDIDCreateStatusErrorCode for get did create status error.
+	GetDIDCreateStatusErrorCode
 )
 
 // constants for the VDRI controller's methods
@@ -44,43 +88,152 @@ const (
 	CommandName = "vdri"
 
 	// command methods
-	SaveDIDCommandMethod    = "SaveDID"
-	GetDIDsCommandMethod    = "GetDIDRecords"
-	GetDIDCommandMethod     = "GetDID"
-	ResolveDIDCommandMethod = "ResolveDID"
+	SaveDIDCommandMethod       = "SaveDID"
+	GetDIDsCommandMethod       = "GetDIDRecords"
+	GetDIDCommandMethod        = "GetDID"
+	ResolveDIDCommandMethod    = "ResolveDID"
+	CreatePublicDIDMethod      = "CreatePublicDID"
+	RegisterVDRIMethod         = "RegisterVDRI"
+	DiffDIDCommandMethod       = "DiffDID"
+	GetDIDCommServiceMethod    = "GetDIDCommService"
+	ImportDIDCommandMethod     = "ImportDID"
+	GetDIDMethodsMethod        = "GetDIDMethods"
+	CreatePeerDIDMethod        = "CreatePeerDID"
+	ValidateDIDDocMethod       = "ValidateDIDDoc"
+	CreatePublicDIDAsyncMethod = "CreatePublicDIDAsync"
+	GetDIDCreateStatusMethod   = "GetDIDCreateStatus"
 
 	// error messages
-	errEmptyDIDName = "name is mandatory"
-	errEmptyDIDID   = "did is mandatory"
+	errEmptyDIDName       = "name is mandatory"
+	errEmptyDIDID         = "did is mandatory"
+	errEmptyDIDMethod     = "did method is mandatory"
+	errEmptyVDRIURL       = "vdri url is mandatory"
+	errEmptyNewDIDDoc     = "new did doc is mandatory"
+	errInvalidService     = "invalid service at index %d: %s"
+	errUnallowedDIDMethod = "did method `%s` is not supported, supported methods: %s"
+	errUnsupportedContext = "did context version `%s` is not supported, supported versions: v0.11, v1"
+	errNoDIDCommService   = "did `%s` has no " + vdriapi.DIDCommServiceType + " service"
+	errInvalidDIDComm     = "invalid " + vdriapi.DIDCommServiceType + " service `%s` of did `%s`: %s"
+	errEmptyDIDDoc        = "did doc is mandatory"
+	errMismatchedMethod   = "did `%s` is not a did of method `%s`"
+	errInvalidPrivateKey  = "invalid private key: %s"
+	errNoRouting          = "routing configuration is missing: no router registered nor agent service endpoint"
+	errNoKeyAgreement     = "did method `%s` doesn't support key agreement keys"
+
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+
+	// DIDCreateTopic is the topic of the DID creation progress events published to the notifier.
+	DIDCreateTopic = "did-create"
+
+	// DID creation progress event types, did-create-submitted is published once the VDRI built the request submitting
+	// the DID hence not by the VDRIs creating DIDs without requests (eg: did:key)
+	DIDCreateRequestedEvent = "did-create-requested"
+	DIDCreateSubmittedEvent = "did-create-submitted"
+	DIDCreateCompletedEvent = "did-create-completed"
+	DIDCreateFailedEvent    = "did-create-failed"
 
 	// log constants
-	didID = "did"
+	didID     = "did"
+	didMethod = "method"
+	vdriURL   = "url"
+	requestID = "requestID"
+)
+
+// DID core contexts, the did documents are serialized in the shape of their first context.
+const (
+	didContextV011    = "https://w3id.org/did/v0.11"
+	didContextV12019  = "https://www.w3.org/2019/did/v1"
+	didContextV1W3Org = "https://www.w3.org/ns/did/v1"
 )
 
+// didContextVersions maps the context versions the resolved did documents can be converted to, to their context.
+var didContextVersions = map[string]string{ // nolint:gochecknoglobals
+	"v0.11": didContextV011,
+	"v1":    did.Context,
+}
+
 // provider contains dependencies for the vdri controller command operations
 // and is typically created by using aries.Context()
 type provider interface {
 	VDRIRegistry() vdriapi.Registry
 	StorageProvider() storage.Provider
+	KMS() kms.KeyManager
+	Service(id string) (interface{}, error)
+	ServiceEndpoint() string
 }
 
 // Command contains command operations provided by vdri controller.
 type Command struct {
-	ctx      provider
-	didStore *didstore.Store
+	ctx            provider
+	didStore       *didstore.Store
+	createJobStore storage.Store
+	logger         log.Logger
+	newRequestID   func() string
+	allowedMethods []string
+	notifier       command.Notifier
+}
+
+// Option configures the vdri controller command.
+type Option func(opts *Command)
+
+// WithLogger option is for setting the logger of the structured request logs (defaults to the package logger).
+func WithLogger(l log.Logger) Option {
+	return func(opts *Command) {
+		opts.logger = l
+	}
+}
+
+// WithRequestIDGenerator option is for setting the generator of the request IDs missing from requests
+// (defaults to random UUIDs).
+func WithRequestIDGenerator(generate func() string) Option {
+	return func(opts *Command) {
+		opts.newRequestID = generate
+	}
+}
+
+// WithAllowedMethods option is for restricting the DID methods public DIDs can be created with, requests for other
+// methods are rejected before reaching the VDRI registry. All methods are allowed if not set.
+func WithAllowedMethods(methods []string) Option {
+	return func(opts *Command) {
+		opts.allowedMethods = append([]string(nil), methods...)
+		sort.Strings(opts.allowedMethods)
+	}
+}
+
+// WithNotifier option is for publishing the progress of public DID creations as DIDCreateEvent under DIDCreateTopic,
+// tied to the request ID of the creation (eg: streamed to clients through the websocket notifier).
+// No events are published by default, nor for dry runs.
+func WithNotifier(notifier command.Notifier) Option {
+	return func(opts *Command) {
+		opts.notifier = notifier
+	}
 }
 
 // New returns new vdri controller command instance.
-func New(ctx provider) (*Command, error) {
+func New(ctx provider, opts ...Option) (*Command, error) {
 	didStore, err := didstore.New(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("new did store : %w", err)
 	}
 
-	return &Command{
-		ctx:      ctx,
-		didStore: didStore,
-	}, nil
+	createJobStore, err := ctx.StorageProvider().OpenStore(createJobStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open did create job store : %w", err)
+	}
+
+	cmd := &Command{
+		ctx:            ctx,
+		didStore:       didStore,
+		createJobStore: createJobStore,
+		logger:         logger,
+		newRequestID:   uuid.New().String,
+	}
+
+	for _, opt := range opts {
+		opt(cmd)
+	}
+
+	return cmd, nil
 }
 
 // GetHandlers returns list of all commands supported by this controller command.
@@ -90,12 +243,404 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, GetDIDCommandMethod, o.GetDID),
 		cmdutil.NewCommandHandler(CommandName, GetDIDsCommandMethod, o.GetDIDRecords),
 		cmdutil.NewCommandHandler(CommandName, ResolveDIDCommandMethod, o.ResolveDID),
+		cmdutil.NewCommandHandler(CommandName, CreatePublicDIDMethod, o.CreatePublicDID),
+		cmdutil.NewCommandHandler(CommandName, RegisterVDRIMethod, o.RegisterVDRI),
+		cmdutil.NewCommandHandler(CommandName, DiffDIDCommandMethod, o.DiffDID),
+		cmdutil.NewCommandHandler(CommandName, GetDIDCommServiceMethod, o.GetDIDCommService),
+		cmdutil.NewCommandHandler(CommandName, ImportDIDCommandMethod, o.ImportDID),
+		cmdutil.NewCommandHandler(CommandName, GetDIDMethodsMethod, o.GetDIDMethods),
+		cmdutil.NewCommandHandler(CommandName, CreatePeerDIDMethod, o.CreatePeerDID),
+		cmdutil.NewCommandHandler(CommandName, ValidateDIDDocMethod, o.ValidateDIDDoc),
+		cmdutil.NewCommandHandler(CommandName, CreatePublicDIDAsyncMethod, o.CreatePublicDIDAsync),
+		cmdutil.NewCommandHandler(CommandName, GetDIDCreateStatusMethod, o.GetDIDCreateStatus),
+	}
+}
+
+// CreatePublicDID creates a new public DID using the VDRI registry method given in the request.
+// In dry run mode the request which would be submitted by the registry is returned instead, without creating the DID.
+// The request ID given in the request (generated if missing) is logged along with the outcome of the request.
+func (o *Command) CreatePublicDID(rw io.Writer, req io.Reader) command.Error {
+	start := time.Now()
+
+	var request CreatePublicDIDArgs
+
+	decodeErr := json.NewDecoder(req).Decode(&request)
+
+	if request.RequestID == "" {
+		request.RequestID = o.newRequestID()
+	}
+
+	reqLogger := log.WithFields(o.logger, log.Fields{
+		"command": CommandName,
+		"action":  CreatePublicDIDMethod,
+		requestID: request.RequestID,
+		didMethod: request.Method,
+		"dryRun":  request.DryRun,
+	})
+
+	cmdErr := o.createPublicDID(rw, &request, decodeErr, reqLogger)
+
+	logOutcome(reqLogger, time.Since(start), cmdErr)
+
+	return cmdErr
+}
+
+func (o *Command) createPublicDID(rw io.Writer, request *CreatePublicDIDArgs, decodeErr error,
+	reqLogger *log.FieldLogger) command.Error {
+	if cmdErr := o.validateCreatePublicDID(request, decodeErr); cmdErr != nil {
+		return cmdErr
+	}
+
+	requestBuilder := prepareBasicRequestBuilder(request.RequestHeader)
+
+	if request.DryRun {
+		return createPublicDIDDryRun(rw, request, requestBuilder)
 	}
+
+	response, err := o.submitPublicDID(request, requestBuilder, reqLogger)
+	if err != nil {
+		return command.NewExecuteError(CreatePublicDIDErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	reqLogger.Debugf("created public DID %s", response.ID)
+
+	return nil
+}
+
+// validateCreatePublicDID validates the create public DID request.
+func (o *Command) validateCreatePublicDID(request *CreatePublicDIDArgs, decodeErr error) command.Error {
+	if decodeErr != nil {
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", decodeErr))
+	}
+
+	if request.Method == "" {
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDMethod))
+	}
+
+	if !o.isAllowedMethod(request.Method) {
+		return command.NewValidationError(InvalidRequestErrorCode,
+			fmt.Errorf(errUnallowedDIDMethod, request.Method, strings.Join(o.allowedMethods, ", ")))
+	}
+
+	if err := validateServices(request.Services); err != nil {
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if request.KeyAgreement && !o.supportsKeyAgreement(request.Method) {
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errNoKeyAgreement, request.Method))
+	}
+
+	return nil
+}
+
+// supportsKeyAgreement tells whether the DIDs created with the method have the keyAgreement verification method
+// requested, as described by the VDRI registry. The methods of registries not describing their methods are assumed
+// not to support keyAgreement verification methods.
+func (o *Command) supportsKeyAgreement(method string) bool {
+	describer, ok := o.ctx.VDRIRegistry().(vdriapi.MethodDescriber)
+	if !ok {
+		return false
+	}
+
+	for _, capabilities := range describer.Methods() {
+		if capabilities.Method == method {
+			return capabilities.KeyAgreement
+		}
+	}
+
+	return false
 }
 
-// ResolveDID resolve did.
+// submitPublicDID creates the public DID of the validated request through the VDRI registry, publishing the progress
+// of the creation. The submission is published when the VDRI builds its request with the request builder.
+func (o *Command) submitPublicDID(request *CreatePublicDIDArgs, requestBuilder func([]byte) (io.Reader, error),
+	reqLogger *log.FieldLogger) (*CreatePublicDIDResponse, error) {
+	o.notifyCreate(reqLogger, &DIDCreateEvent{Type: DIDCreateRequestedEvent}, request)
+
+	reqLogger.Debugf("creating public DID")
+
+	notifyingBuilder := func(payload []byte) (io.Reader, error) {
+		reader, err := requestBuilder(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		o.notifyCreate(reqLogger, &DIDCreateEvent{Type: DIDCreateSubmittedEvent}, request)
+
+		return reader, nil
+	}
+
+	doc, err := o.createPublicDIDDoc(request, notifyingBuilder)
+	if err != nil {
+		o.notifyCreate(reqLogger, &DIDCreateEvent{Type: DIDCreateFailedEvent, Error: err.Error()}, request)
+
+		return nil, fmt.Errorf("create public did: %w", err)
+	}
+
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		o.notifyCreate(reqLogger, &DIDCreateEvent{Type: DIDCreateFailedEvent, ID: doc.ID, Error: err.Error()}, request)
+
+		return nil, fmt.Errorf("marshal did doc: %w", err)
+	}
+
+	o.notifyCreate(reqLogger, &DIDCreateEvent{Type: DIDCreateCompletedEvent, ID: doc.ID}, request)
+
+	return &CreatePublicDIDResponse{
+		ID:        doc.ID,
+		DID:       json.RawMessage(docBytes),
+		RequestID: request.RequestID,
+	}, nil
+}
+
+// notifyCreate publishes the DID creation progress event of request, if a notifier is set. Failing to publish the
+// event doesn't fail the request.
+func (o *Command) notifyCreate(reqLogger *log.FieldLogger, event *DIDCreateEvent, request *CreatePublicDIDArgs) {
+	if o.notifier == nil {
+		return
+	}
+
+	event.RequestID = request.RequestID
+	event.Method = request.Method
+
+	msg, err := json.Marshal(event)
+	if err != nil {
+		reqLogger.Warnf("failed to marshal %s event: %s", event.Type, err)
+
+		return
+	}
+
+	if err = o.notifier.Notify(DIDCreateTopic, msg); err != nil {
+		reqLogger.Warnf("failed to publish %s event: %s", event.Type, err)
+	}
+}
+
+// validateServices checks the services of the DID to be created have a type and an endpoint.
+func validateServices(services []did.Service) error {
+	for i, s := range services {
+		switch {
+		case s.Type == "":
+			return fmt.Errorf(errInvalidService, i, "service type is mandatory")
+		case s.ServiceEndpoint == "":
+			return fmt.Errorf(errInvalidService, i, "service endpoint is mandatory")
+		}
+	}
+
+	return nil
+}
+
+// isAllowedMethod tells whether public DIDs can be created with given DID method.
+func (o *Command) isAllowedMethod(method string) bool {
+	if len(o.allowedMethods) == 0 {
+		return true
+	}
+
+	for _, allowed := range o.allowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createPublicDIDDryRun writes the request the registry would submit for the given create public DID request, the
+// payload of the request is the preview of the DID document to be created (see previewPublicDIDDoc).
+func createPublicDIDDryRun(rw io.Writer, request *CreatePublicDIDArgs,
+	requestBuilder func([]byte) (io.Reader, error)) command.Error {
+	previewDoc, err := previewPublicDIDDoc(request)
+	if err != nil {
+		return command.NewExecuteError(CreatePublicDIDErrorCode, fmt.Errorf("build did doc: %w", err))
+	}
+
+	payload, err := previewDoc.JSONBytes()
+	if err != nil {
+		return command.NewExecuteError(CreatePublicDIDErrorCode, fmt.Errorf("marshal did doc: %w", err))
+	}
+
+	previewReader, err := requestBuilder(payload)
+	if err != nil {
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("build request: %w", err))
+	}
+
+	var preview bytes.Buffer
+
+	_, err = preview.ReadFrom(previewReader)
+	if err != nil {
+		return command.NewExecuteError(CreatePublicDIDErrorCode, fmt.Errorf("read request: %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &CreatePublicDIDResponse{
+		DryRun: &DryRunResult{
+			Method:  request.Method,
+			Request: json.RawMessage(bytes.TrimSpace(preview.Bytes())),
+		},
+		RequestID: request.RequestID,
+	}, logger)
+
+	return nil
+}
+
+// previewPublicDIDDoc builds the DID document of the create public DID request without creating it: the public key,
+// the services and the keyAgreement verification method if requested. The DID and the keys of the document are only
+// known once created, the preview has no ID and ephemeral keys which aren't created in the KMS.
+func previewPublicDIDDoc(request *CreatePublicDIDArgs) (*did.Doc, error) {
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate public key: %w", err)
+	}
+
+	publicKey := did.NewPublicKeyFromBytes("#key-1", ed25519VerificationKey2018, "", pubKey)
+
+	previewDoc := did.BuildDoc(
+		did.WithPublicKey([]did.PublicKey{*publicKey}),
+		did.WithService(request.Services),
+		did.WithAuthentication([]did.VerificationMethod{*did.NewReferencedVerificationMethod(publicKey,
+			did.Authentication, true)}),
+	)
+
+	if !request.KeyAgreement {
+		return previewDoc, nil
+	}
+
+	keyAgreementKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key agreement key: %w", err)
+	}
+
+	x25519PubKey, err := cryptoutil.PublicEd25519toCurve25519(keyAgreementKey)
+	if err != nil {
+		return nil, fmt.Errorf("convert key agreement key to X25519: %w", err)
+	}
+
+	previewDoc.KeyAgreement = []did.VerificationMethod{*did.NewEmbeddedVerificationMethod(
+		did.NewPublicKeyFromBytes("#key-2", vdriapi.X25519KeyAgreementKey2019, "", x25519PubKey), did.KeyAgreement)}
+
+	return previewDoc, nil
+}
+
+// logOutcome logs the outcome and duration of a request, validation failures are client errors hence
+// aren't logged as errors.
+func logOutcome(reqLogger *log.FieldLogger, duration time.Duration, cmdErr command.Error) {
+	fields := log.Fields{"duration": duration}
+
+	if cmdErr == nil {
+		fields["outcome"] = "success"
+		reqLogger.WithFields(fields).Infof("request completed")
+
+		return
+	}
+
+	fields["outcome"] = "failure"
+	fields["error"] = cmdErr.Error()
+	fields["errorCode"] = cmdErr.Code()
+
+	if cmdErr.Type() == command.ValidationError {
+		reqLogger.WithFields(fields).Infof("request failed")
+
+		return
+	}
+
+	reqLogger.WithFields(fields).Errorf("request failed")
+}
+
+// prepareBasicRequestBuilder is basic request builder for public DID creation,
+// request body will be the base64 URL encoded public DID payload along with the optional header.
+func prepareBasicRequestBuilder(header string) func(payload []byte) (io.Reader, error) {
+	return func(payload []byte) (io.Reader, error) {
+		request := struct {
+			Header  json.RawMessage `json:"header,omitempty"`
+			Payload string          `json:"payload"`
+		}{
+			Payload: base64.URLEncoding.EncodeToString(payload),
+		}
+
+		if header != "" {
+			request.Header = json.RawMessage(header)
+		}
+
+		b, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+
+		return bytes.NewReader(b), nil
+	}
+}
+
+// RegisterVDRI registers a HTTP binding (universal resolver style) vdri resolving the DIDs of the given method
+// into the VDRI registry at runtime.
+func (o *Command) RegisterVDRI(rw io.Writer, req io.Reader) command.Error {
+	var request RegisterVDRIArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RegisterVDRIMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Method == "" {
+		logutil.LogDebug(logger, CommandName, RegisterVDRIMethod, errEmptyDIDMethod)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDMethod))
+	}
+
+	if request.URL == "" {
+		logutil.LogDebug(logger, CommandName, RegisterVDRIMethod, errEmptyVDRIURL)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyVDRIURL))
+	}
+
+	endpoint, err := url.ParseRequestURI(request.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		logutil.LogDebug(logger, CommandName, RegisterVDRIMethod, "invalid vdri url",
+			logutil.CreateKeyValueString(vdriURL, request.URL))
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("invalid vdri url: %s", request.URL))
+	}
+
+	registrar, ok := o.ctx.VDRIRegistry().(vdriapi.MethodRegistrar)
+	if !ok {
+		logutil.LogError(logger, CommandName, RegisterVDRIMethod, "vdri registry doesn't support registration")
+
+		return command.NewExecuteError(RegisterVDRIErrorCode,
+			fmt.Errorf("vdri registry doesn't support registration"))
+	}
+
+	method := request.Method
+	opts := []httpbinding.Option{httpbinding.WithAccept(func(m string) bool { return m == method })}
+
+	if request.AuthToken != "" {
+		opts = append(opts, httpbinding.WithResolveAuthToken(request.AuthToken))
+	}
+
+	httpVDRI, err := httpbinding.New(request.URL, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RegisterVDRIMethod, "new http binding vdri: "+err.Error(),
+			logutil.CreateKeyValueString(didMethod, request.Method))
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("new http binding vdri: %w", err))
+	}
+
+	err = registrar.RegisterVDRI(request.Method, httpVDRI)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RegisterVDRIMethod, "register vdri: "+err.Error(),
+			logutil.CreateKeyValueString(didMethod, request.Method))
+
+		return command.NewExecuteError(RegisterVDRIErrorCode, fmt.Errorf("register vdri: %w", err))
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, RegisterVDRIMethod, "success",
+		logutil.CreateKeyValueString(didMethod, request.Method))
+
+	return nil
+}
+
+// ResolveDID resolve did, along with its resolution metadata if requested.
 func (o *Command) ResolveDID(rw io.Writer, req io.Reader) command.Error {
-	var request IDArg
+	var request ResolveDIDArgs
 
 	err := json.NewDecoder(req).Decode(&request)
 	if err != nil {
@@ -108,7 +653,16 @@ func (o *Command) ResolveDID(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDID))
 	}
 
-	didDoc, err := o.ctx.VDRIRegistry().Resolve(request.ID)
+	didContext, ok := didContextVersions[request.ContextVersion]
+	if request.ContextVersion != "" && !ok {
+		logutil.LogDebug(logger, CommandName, ResolveDIDCommandMethod, "unsupported context version",
+			logutil.CreateKeyValueString(didID, request.ID))
+
+		return command.NewValidationError(InvalidRequestErrorCode,
+			fmt.Errorf(errUnsupportedContext, request.ContextVersion))
+	}
+
+	result, err := o.resolve(request.ID, request.IncludeMetadata)
 	if err != nil {
 		logutil.LogError(logger, CommandName, ResolveDIDCommandMethod, "resolve did doc: "+err.Error(),
 			logutil.CreateKeyValueString(didID, request.ID))
@@ -116,6 +670,11 @@ func (o *Command) ResolveDID(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(ResolveDIDErrorCode, fmt.Errorf("resolve did doc: %w", err))
 	}
 
+	didDoc := result.Doc
+	if didContext != "" {
+		didDoc = withContext(didDoc, didContext)
+	}
+
 	docBytes, err := didDoc.JSONBytes()
 	if err != nil {
 		logutil.LogError(logger, CommandName, ResolveDIDCommandMethod, "unmarshal did doc: "+err.Error(),
@@ -124,9 +683,13 @@ func (o *Command) ResolveDID(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(ResolveDIDErrorCode, fmt.Errorf("unmarshal did doc: %w", err))
 	}
 
-	command.WriteNillableResponse(rw, &Document{
-		DID: json.RawMessage(docBytes),
-	}, logger)
+	response := &ResolveDIDResponse{Document: Document{DID: json.RawMessage(docBytes)}}
+
+	if request.IncludeMetadata {
+		response.Metadata = &result.Metadata
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
 
 	logutil.LogDebug(logger, CommandName, ResolveDIDCommandMethod, "success",
 		logutil.CreateKeyValueString(didID, request.ID))
@@ -134,6 +697,351 @@ func (o *Command) ResolveDID(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// resolve resolves the did document, along with its resolution metadata if withMetadata is set.
+func (o *Command) resolve(id string, withMetadata bool) (*vdriapi.ResolveResult, error) {
+	if withMetadata {
+		return o.ctx.VDRIRegistry().ResolveWithMetadata(id)
+	}
+
+	didDoc, err := o.ctx.VDRIRegistry().Resolve(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vdriapi.ResolveResult{Doc: didDoc}, nil
+}
+
+// DiffDID reports the public keys, services and authentication references changed between the old and new did docs,
+// the old did doc is resolved from the id of the new did doc if missing. Nothing is updated.
+func (o *Command) DiffDID(rw io.Writer, req io.Reader) command.Error {
+	var request DiffDIDArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DiffDIDCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if len(request.New) == 0 {
+		logutil.LogDebug(logger, CommandName, DiffDIDCommandMethod, errEmptyNewDIDDoc)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyNewDIDDoc))
+	}
+
+	newDoc, err := did.ParseDocument(request.New)
+	if err != nil {
+		logutil.LogError(logger, CommandName, DiffDIDCommandMethod, "parse new did doc: "+err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("parse new did doc: %w", err))
+	}
+
+	var oldDoc *did.Doc
+
+	if len(request.Old) == 0 {
+		oldDoc, err = o.ctx.VDRIRegistry().Resolve(newDoc.ID)
+		if err != nil {
+			logutil.LogError(logger, CommandName, DiffDIDCommandMethod, "resolve did doc: "+err.Error(),
+				logutil.CreateKeyValueString(didID, newDoc.ID))
+
+			return command.NewExecuteError(DiffDIDErrorCode, fmt.Errorf("resolve did doc: %w", err))
+		}
+	} else {
+		oldDoc, err = did.ParseDocument(request.Old)
+		if err != nil {
+			logutil.LogError(logger, CommandName, DiffDIDCommandMethod, "parse old did doc: "+err.Error())
+			return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("parse old did doc: %w", err))
+		}
+	}
+
+	command.WriteNillableResponse(rw, &DiffDIDResponse{Diff: did.Diff(oldDoc, newDoc)}, logger)
+
+	logutil.LogDebug(logger, CommandName, DiffDIDCommandMethod, "success",
+		logutil.CreateKeyValueString(didID, newDoc.ID))
+
+	return nil
+}
+
+// ValidateDIDDoc validates the did doc of the request against the DID Core rules, before storing or publishing it,
+// and returns the violations of the rules. The services of the did doc must be of the types known to the framework
+// (did-communication) or of the service types given in the request.
+func (o *Command) ValidateDIDDoc(rw io.Writer, req io.Reader) command.Error {
+	var request ValidateDIDDocArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ValidateDIDDocMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if len(request.DID) == 0 {
+		logutil.LogDebug(logger, CommandName, ValidateDIDDocMethod, errEmptyDIDDoc)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDDoc))
+	}
+
+	violations, err := did.ValidateRules(request.DID,
+		did.WithServiceTypes(append([]string{vdriapi.DIDCommServiceType}, request.ServiceTypes...)...))
+	if err != nil {
+		logutil.LogError(logger, CommandName, ValidateDIDDocMethod, "validate did doc: "+err.Error())
+		return command.NewExecuteError(ValidateDIDDocErrorCode, fmt.Errorf("validate did doc: %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &ValidateDIDDocResponse{
+		Valid:      len(violations) == 0,
+		Violations: violations,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, ValidateDIDDocMethod, "success")
+
+	return nil
+}
+
+// GetDIDCommService resolves the did and returns the endpoint, recipient keys and routing keys of its
+// did-communication service, the service with the highest priority is returned if the did doc has several.
+// The service must have an endpoint and at least one recipient key for messages to be sent to the did.
+func (o *Command) GetDIDCommService(rw io.Writer, req io.Reader) command.Error {
+	var request IDArg
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetDIDCommServiceMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ID == "" {
+		logutil.LogDebug(logger, CommandName, GetDIDCommServiceMethod, errEmptyDIDID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDID))
+	}
+
+	didDoc, err := o.ctx.VDRIRegistry().Resolve(request.ID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetDIDCommServiceMethod, "resolve did doc: "+err.Error(),
+			logutil.CreateKeyValueString(didID, request.ID))
+
+		return command.NewExecuteError(GetDIDCommServiceErrorCode, fmt.Errorf("resolve did doc: %w", err))
+	}
+
+	service, ok := did.LookupService(didDoc, vdriapi.DIDCommServiceType)
+	if !ok {
+		logutil.LogDebug(logger, CommandName, GetDIDCommServiceMethod, "no DIDComm service",
+			logutil.CreateKeyValueString(didID, request.ID))
+
+		return command.NewExecuteError(GetDIDCommServiceErrorCode, fmt.Errorf(errNoDIDCommService, request.ID))
+	}
+
+	var invalid string
+
+	switch {
+	case service.ServiceEndpoint == "":
+		invalid = "missing service endpoint"
+	case len(service.RecipientKeys) == 0:
+		invalid = "missing recipient keys"
+	}
+
+	if invalid != "" {
+		logutil.LogDebug(logger, CommandName, GetDIDCommServiceMethod, invalid,
+			logutil.CreateKeyValueString(didID, request.ID))
+
+		return command.NewExecuteError(GetDIDCommServiceErrorCode,
+			fmt.Errorf(errInvalidDIDComm, service.ID, request.ID, invalid))
+	}
+
+	command.WriteNillableResponse(rw, &DIDCommServiceResponse{
+		ID:              service.ID,
+		ServiceEndpoint: service.ServiceEndpoint,
+		RecipientKeys:   service.RecipientKeys,
+		RoutingKeys:     service.RoutingKeys,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetDIDCommServiceMethod, "success",
+		logutil.CreateKeyValueString(didID, request.ID))
+
+	return nil
+}
+
+// ImportDID imports an externally created did doc into the VDRI registry so that the did is recognized without
+// being resolved, the did must be a did of the method given in the request. The private key of a public key of
+// the did doc may be given along with it for the did to sign with, the key is then imported into the KMS.
+func (o *Command) ImportDID(rw io.Writer, req io.Reader) command.Error {
+	var request ImportDIDArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportDIDCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if len(request.DID) == 0 {
+		logutil.LogDebug(logger, CommandName, ImportDIDCommandMethod, errEmptyDIDDoc)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDDoc))
+	}
+
+	if request.Method == "" {
+		logutil.LogDebug(logger, CommandName, ImportDIDCommandMethod, errEmptyDIDMethod)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDMethod))
+	}
+
+	didDoc, err := did.ParseDocument(request.DID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ImportDIDCommandMethod, "parse did doc: "+err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("parse did doc: %w", err))
+	}
+
+	if didDoc.ID == "" {
+		logutil.LogDebug(logger, CommandName, ImportDIDCommandMethod, errEmptyDIDID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDIDID))
+	}
+
+	parsedDID, err := did.Parse(didDoc.ID)
+	if err != nil || parsedDID.Method != request.Method {
+		logutil.LogDebug(logger, CommandName, ImportDIDCommandMethod, "mismatched did method",
+			logutil.CreateKeyValueString(didID, didDoc.ID))
+
+		return command.NewValidationError(InvalidRequestErrorCode,
+			fmt.Errorf(errMismatchedMethod, didDoc.ID, request.Method))
+	}
+
+	var keyID string
+
+	if request.PrivateKey != "" {
+		var cmdErr command.Error
+
+		keyID, cmdErr = o.importPrivateKey(didDoc, request.PrivateKey)
+		if cmdErr != nil {
+			logutil.LogError(logger, CommandName, ImportDIDCommandMethod, cmdErr.Error(),
+				logutil.CreateKeyValueString(didID, didDoc.ID))
+
+			return cmdErr
+		}
+	}
+
+	err = o.ctx.VDRIRegistry().Store(didDoc)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ImportDIDCommandMethod, "store did doc: "+err.Error(),
+			logutil.CreateKeyValueString(didID, didDoc.ID))
+
+		return command.NewExecuteError(ImportDIDErrorCode, fmt.Errorf("store did doc: %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &ImportDIDResponse{ID: didDoc.ID, KeyID: keyID}, logger)
+
+	logutil.LogDebug(logger, CommandName, ImportDIDCommandMethod, "success",
+		logutil.CreateKeyValueString(didID, didDoc.ID))
+
+	return nil
+}
+
+// createPublicDIDDoc creates the public DID of the request, with a keyAgreement verification method if requested.
+// The support of keyAgreement verification methods by the DID method is validated beforehand, the created DID is
+// nonetheless checked to have the requested keyAgreement verification method.
+func (o *Command) createPublicDIDDoc(request *CreatePublicDIDArgs,
+	requestBuilder func([]byte) (io.Reader, error)) (*did.Doc, error) {
+	opts := []vdriapi.DocOpts{vdriapi.WithRequestBuilder(requestBuilder)}
+
+	if len(request.Services) > 0 {
+		opts = append(opts, vdriapi.WithServices(request.Services))
+	}
+
+	var keyAgreement *vdriapi.PubKey
+
+	if request.KeyAgreement {
+		var err error
+
+		keyAgreement, err = o.createKeyAgreementKey()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, vdriapi.WithEncryptionKey(keyAgreement))
+	}
+
+	doc, err := o.ctx.VDRIRegistry().Create(request.Method, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyAgreement != nil && !hasKeyAgreement(doc, keyAgreement.Value) {
+		return nil, fmt.Errorf(errNoKeyAgreement, request.Method)
+	}
+
+	return doc, nil
+}
+
+// createKeyAgreementKey creates a key in the KMS for the keyAgreement verification method of a DID. The KMS doesn't
+// create X25519 keys, the X25519 key is derived from a new ed25519 key of the KMS, as the crypto box of the KMS does,
+// and identified by the ID of the ed25519 key in the KMS.
+func (o *Command) createKeyAgreementKey() (*vdriapi.PubKey, error) {
+	keyID, pubKey, err := o.ctx.KMS().CreateAndExportPubKeyBytes(kms.ED25519Type)
+	if err != nil {
+		return nil, fmt.Errorf("create key agreement key: %w", err)
+	}
+
+	x25519PubKey, err := cryptoutil.PublicEd25519toCurve25519(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("convert key agreement key to X25519: %w", err)
+	}
+
+	return &vdriapi.PubKey{ID: keyID, Value: x25519PubKey, Type: vdriapi.X25519KeyAgreementKey2019}, nil
+}
+
+// hasKeyAgreement tells whether pubKey is a keyAgreement verification method of the did doc.
+func hasKeyAgreement(didDoc *did.Doc, pubKey []byte) bool {
+	for _, vm := range didDoc.KeyAgreement {
+		if bytes.Equal(vm.PublicKey.Value, pubKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// importPrivateKey imports the base58 encoded ed25519 private key into the KMS, the key must be the private key of
+// a public key of the did doc. The ID of the imported key is returned.
+func (o *Command) importPrivateKey(didDoc *did.Doc, privateKey string) (string, command.Error) {
+	privKey := ed25519.PrivateKey(base58.Decode(privateKey))
+	if len(privKey) != ed25519.PrivateKeySize {
+		return "", command.NewValidationError(InvalidRequestErrorCode,
+			fmt.Errorf(errInvalidPrivateKey, "not a base58 encoded ed25519 private key"))
+	}
+
+	if !hasPublicKey(didDoc, privKey.Public().(ed25519.PublicKey)) {
+		return "", command.NewValidationError(InvalidRequestErrorCode,
+			fmt.Errorf(errInvalidPrivateKey, "no matching public key in did doc"))
+	}
+
+	keyID, _, err := o.ctx.KMS().ImportPrivateKey(privKey, kms.ED25519Type)
+	if err != nil {
+		return "", command.NewExecuteError(ImportDIDErrorCode, fmt.Errorf("import private key: %w", err))
+	}
+
+	return keyID, nil
+}
+
+// hasPublicKey tells whether pubKey is a public key of the did doc.
+func hasPublicKey(didDoc *did.Doc, pubKey ed25519.PublicKey) bool {
+	for _, pk := range didDoc.PublicKey {
+		if bytes.Equal(pk.Value, pubKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withContext returns a copy of the did doc with its DID core context replaced by the given context, other contexts
+// (eg: security suites) are kept after it.
+func withContext(didDoc *did.Doc, context string) *did.Doc {
+	converted := *didDoc
+	converted.Context = []string{context}
+
+	for _, c := range didDoc.Context {
+		switch c {
+		case didContextV011, did.Context, didContextV12019, didContextV1W3Org:
+		default:
+			converted.Context = append(converted.Context, c)
+		}
+	}
+
+	return &converted
+}
+
 // SaveDID saves the did doc to the store.
 func (o *Command) SaveDID(rw io.Writer, req io.Reader) command.Error {
 	request := &DIDArgs{}
@@ -212,6 +1120,138 @@ func (o *Command) GetDID(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// GetDIDMethods returns the DID methods supported by the VDRI registry, along with whether each method supports
+// creating, updating and deactivating DIDs.
+func (o *Command) GetDIDMethods(rw io.Writer, req io.Reader) command.Error {
+	describer, ok := o.ctx.VDRIRegistry().(vdriapi.MethodDescriber)
+	if !ok {
+		logutil.LogError(logger, CommandName, GetDIDMethodsMethod, "vdri registry doesn't describe its methods")
+
+		return command.NewExecuteError(GetDIDMethodsErrorCode,
+			fmt.Errorf("vdri registry doesn't describe its methods"))
+	}
+
+	command.WriteNillableResponse(rw, &DIDMethodsResponse{Methods: describer.Methods()}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetDIDMethodsMethod, "success")
+
+	return nil
+}
+
+// CreatePeerDID creates a new peer DID for a connection, reachable through the router (mediator) of the agent if one
+// is registered, through the agent service endpoint otherwise. The recipient keys of the DID are added to the router.
+// The DID is stored by the VDRI registry, and saved with its friendly name if one is given in the request.
+func (o *Command) CreatePeerDID(rw io.Writer, req io.Reader) command.Error {
+	var request CreatePeerDIDArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil && !errors.Is(err, io.EOF) {
+		logutil.LogInfo(logger, CommandName, CreatePeerDIDMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Name != "" {
+		if _, err = o.didStore.GetDIDByName(request.Name); err == nil {
+			logutil.LogDebug(logger, CommandName, CreatePeerDIDMethod, "did name already exists")
+
+			return command.NewValidationError(InvalidRequestErrorCode,
+				fmt.Errorf("did name %s already exists", request.Name))
+		}
+	}
+
+	routeSvc, err := o.routeService()
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreatePeerDIDMethod, err.Error())
+
+		return command.NewExecuteError(CreatePeerDIDErrorCode, err)
+	}
+
+	serviceEndpoint, routingKeys, err := mediator.GetRouterConfig(routeSvc, o.ctx.ServiceEndpoint())
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreatePeerDIDMethod, err.Error())
+
+		return command.NewExecuteError(CreatePeerDIDErrorCode, err)
+	}
+
+	if serviceEndpoint == "" {
+		logutil.LogDebug(logger, CommandName, CreatePeerDIDMethod, errNoRouting)
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errNoRouting))
+	}
+
+	didDoc, err := o.ctx.VDRIRegistry().Create(peer.DIDMethod,
+		vdriapi.WithServiceType(vdriapi.DIDCommServiceType),
+		vdriapi.WithServiceEndpoint(serviceEndpoint),
+		vdriapi.WithRoutingKeys(routingKeys))
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreatePeerDIDMethod, "create peer did: "+err.Error())
+
+		return command.NewExecuteError(CreatePeerDIDErrorCode, fmt.Errorf("create peer did: %w", err))
+	}
+
+	if cmdErr := o.registerPeerDID(routeSvc, didDoc, request.Name); cmdErr != nil {
+		return cmdErr
+	}
+
+	docBytes, err := didDoc.JSONBytes()
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreatePeerDIDMethod, "marshal did doc: "+err.Error())
+
+		return command.NewExecuteError(CreatePeerDIDErrorCode, fmt.Errorf("marshal did doc: %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &CreatePeerDIDResponse{ID: didDoc.ID, DID: json.RawMessage(docBytes)}, logger)
+
+	logutil.LogDebug(logger, CommandName, CreatePeerDIDMethod, "success",
+		logutil.CreateKeyValueString(didID, didDoc.ID))
+
+	return nil
+}
+
+// routeService returns the route coordination service of the agent.
+func (o *Command) routeService() (mediator.ProtocolService, error) {
+	svc, err := o.ctx.Service(mediator.Coordination)
+	if err != nil {
+		return nil, fmt.Errorf("lookup route service: %w", err)
+	}
+
+	routeSvc, ok := svc.(mediator.ProtocolService)
+	if !ok {
+		return nil, errors.New("cast to route service failed")
+	}
+
+	return routeSvc, nil
+}
+
+// registerPeerDID adds the recipient keys of the created peer DID to the router and saves the DID with its friendly
+// name, if given.
+func (o *Command) registerPeerDID(routeSvc mediator.ProtocolService, didDoc *did.Doc, name string) command.Error {
+	if svc, ok := did.LookupService(didDoc, vdriapi.DIDCommServiceType); ok {
+		for _, recKey := range svc.RecipientKeys {
+			if err := mediator.AddKeyToRouter(routeSvc, recKey); err != nil {
+				logutil.LogError(logger, CommandName, CreatePeerDIDMethod, "add key to the router: "+err.Error(),
+					logutil.CreateKeyValueString(didID, didDoc.ID))
+
+				return command.NewExecuteError(CreatePeerDIDErrorCode, fmt.Errorf("add key to the router: %w", err))
+			}
+		}
+	}
+
+	if name == "" {
+		return nil
+	}
+
+	if err := o.didStore.SaveDID(name, didDoc); err != nil {
+		logutil.LogError(logger, CommandName, CreatePeerDIDMethod, "save did doc: "+err.Error(),
+			logutil.CreateKeyValueString(didID, didDoc.ID))
+
+		return command.NewExecuteError(CreatePeerDIDErrorCode, fmt.Errorf("save did doc: %w", err))
+	}
+
+	return nil
+}
+
 // GetDIDRecords retrieves the did doc containing name and didID. //TODO Add pagination feature #1566.
 func (o *Command) GetDIDRecords(rw io.Writer, req io.Reader) command.Error {
 	didRecords := o.didStore.GetDIDRecords()
@@ -8,16 +8,39 @@ package vdri
 
 import (
 	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockmediator "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	vdriregistry "github.com/hyperledger/aries-framework-go/pkg/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/key"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
 )
 
 const sampleDIDName = "sampleDIDName"
@@ -51,7 +74,7 @@ func TestNew(t *testing.T) {
 		require.NoError(t, err)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 4, len(handlers))
+		require.Equal(t, 14, len(handlers))
 	})
 
 	t.Run("test new command - did store error", func(t *testing.T) {
@@ -194,6 +217,58 @@ func TestResolveDID(t *testing.T) {
 		require.NotEmpty(t, response.DID)
 	})
 
+	t.Run("test resolve did with metadata - deactivated did", func(t *testing.T) {
+		didDoc, err := did.ParseDocument([]byte(doc))
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+				ResolveValue: didDoc,
+				ResolveMetadata: vdriapi.ResolutionMetadata{
+					ContentType: vdriapi.DIDLDJSONContentType,
+					Deactivated: true,
+				},
+			},
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.ResolveDID(&getRW,
+			bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp","includeMetadata":true}`))
+		require.NoError(t, cmdErr)
+
+		response := ResolveDIDResponse{}
+		err = json.NewDecoder(&getRW).Decode(&response)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, response.DID)
+		require.NotNil(t, response.Metadata)
+		require.True(t, response.Metadata.Deactivated)
+		require.Equal(t, vdriapi.DIDLDJSONContentType, response.Metadata.ContentType)
+
+		// metadata is omitted unless requested
+		getRW.Reset()
+		cmdErr = cmd.ResolveDID(&getRW, bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp"}`))
+		require.NoError(t, cmdErr)
+		require.NotContains(t, getRW.String(), "metadata")
+	})
+
+	t.Run("test resolve did with metadata - resolve error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{ResolveErr: fmt.Errorf("failed to resolve")},
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.ResolveDID(&b,
+			bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp","includeMetadata":true}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to resolve")
+	})
+
 	t.Run("test get did - invalid request", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{
 			StorageProviderValue: mockstore.NewMockStoreProvider(),
@@ -238,6 +313,75 @@ func TestResolveDID(t *testing.T) {
 	})
 }
 
+func TestResolveDIDContextVersion(t *testing.T) {
+	resolveDID := func(t *testing.T, didDoc *did.Doc, request string) map[string]interface{} {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{ResolveValue: didDoc},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.ResolveDID(&b, bytes.NewBufferString(request))
+		require.NoError(t, cmdErr)
+
+		var response ResolveDIDResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		var rawDoc map[string]interface{}
+		require.NoError(t, json.Unmarshal(response.DID, &rawDoc))
+
+		return rawDoc
+	}
+
+	didDoc, err := did.ParseDocument([]byte(doc))
+	require.NoError(t, err)
+
+	t.Run("test resolve did - v0.11 context", func(t *testing.T) {
+		rawDoc := resolveDID(t, didDoc, `{"id":"did:peer:21tDAKCERh95uGgKbJNHYp","contextVersion":"v0.11"}`)
+		require.Equal(t, []interface{}{"https://w3id.org/did/v0.11", "https://w3id.org/did/v2"}, rawDoc["@context"])
+
+		publicKey := rawDoc["publicKey"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, "did:peer:123456789abcdefghi", publicKey["owner"])
+		require.NotContains(t, publicKey, "controller")
+
+		// resolved did doc is left as is
+		require.Equal(t, []string{"https://w3id.org/did/v1", "https://w3id.org/did/v2"}, didDoc.Context)
+	})
+
+	t.Run("test resolve did - v1 context", func(t *testing.T) {
+		v011Doc := *didDoc
+		v011Doc.Context = []string{"https://w3id.org/did/v0.11"}
+
+		rawDoc := resolveDID(t, &v011Doc, `{"id":"did:peer:21tDAKCERh95uGgKbJNHYp","contextVersion":"v1"}`)
+		require.Equal(t, []interface{}{"https://w3id.org/did/v1"}, rawDoc["@context"])
+
+		publicKey := rawDoc["publicKey"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, "did:peer:123456789abcdefghi", publicKey["controller"])
+	})
+
+	t.Run("test resolve did - context kept as resolved", func(t *testing.T) {
+		rawDoc := resolveDID(t, didDoc, `{"id":"did:peer:21tDAKCERh95uGgKbJNHYp"}`)
+		require.Equal(t, []interface{}{"https://w3id.org/did/v1", "https://w3id.org/did/v2"}, rawDoc["@context"])
+	})
+
+	t.Run("test resolve did - unsupported context version", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{ResolveValue: didDoc},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.ResolveDID(&b,
+			bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp","contextVersion":"v2"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "did context version `v2` is not supported")
+	})
+}
+
 func TestGetDID(t *testing.T) {
 	t.Run("test get did - success", func(t *testing.T) {
 		s := make(map[string][]byte)
@@ -345,3 +489,1562 @@ func TestGetDIDRecords(t *testing.T) {
 		require.Equal(t, 1, len(response.Result))
 	})
 }
+
+func TestCreatePublicDID(t *testing.T) {
+	t.Run("test create public did - success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+				CreateFunc: func(method string, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+					require.Equal(t, "sidetree", method)
+
+					docOpts := &vdriapi.CreateDIDOpts{}
+					for _, opt := range opts {
+						opt(docOpts)
+					}
+
+					require.NotNil(t, docOpts.RequestBuilder)
+
+					return did.ParseDocument([]byte(doc))
+				},
+			},
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree"}`))
+		require.NoError(t, cmdErr)
+
+		var response CreatePublicDIDResponse
+		err = json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, response.DID)
+		require.Nil(t, response.DryRun)
+
+		didDoc, err := did.ParseDocument(response.DID)
+		require.NoError(t, err)
+		require.Equal(t, "did:peer:21tDAKCERh95uGgKbJNHYp", didDoc.ID)
+		require.Equal(t, didDoc.ID, response.ID)
+	})
+
+	t.Run("test create public did - with services", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+
+		peerVDRI, err := peer.New(mockstore.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: vdriregistry.New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{
+				CrAndExportPubKeyID:    "123",
+				CrAndExportPubKeyValue: pubKey,
+			}}, vdriregistry.WithVDRI(peerVDRI)),
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"peer","services":[`+
+			`{"id":"#didcomm","type":"did-communication","serviceEndpoint":"https://example.com/didcomm",`+
+			`"routingKeys":["did:example:mediator#key-1"]},`+
+			`{"id":"#hub","type":"hub","serviceEndpoint":"https://example.com/hub"}]}`))
+		require.NoError(t, cmdErr)
+
+		var response CreatePublicDIDResponse
+		err = json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+
+		didDoc, err := did.ParseDocument(response.DID)
+		require.NoError(t, err)
+		require.Len(t, didDoc.Service, 2)
+
+		service, ok := did.LookupService(didDoc, vdriapi.DIDCommServiceType)
+		require.True(t, ok)
+		require.Equal(t, "https://example.com/didcomm", service.ServiceEndpoint)
+		require.Equal(t, []string{"did:example:mediator#key-1"}, service.RoutingKeys)
+		require.Equal(t, []string{base58.Encode(pubKey)}, service.RecipientKeys)
+
+		service, ok = did.LookupService(didDoc, "hub")
+		require.True(t, ok)
+		require.Equal(t, "https://example.com/hub", service.ServiceEndpoint)
+	})
+
+	t.Run("test create public did - invalid services", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+				CreateFunc: func(string, ...vdriapi.DocOpts) (*did.Doc, error) {
+					require.FailNow(t, "registry create must not be invoked for invalid services")
+
+					return nil, nil
+				},
+			},
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		for services, expected := range map[string]string{
+			`[{"serviceEndpoint":"https://example.com"}]`: "invalid service at index 0: service type is mandatory",
+			`[{"type":"hub","serviceEndpoint":"https://example.com"},{"type":"hub"}]`: "invalid service at index 1: " +
+				"service endpoint is mandatory",
+		} {
+			var b bytes.Buffer
+			cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"peer","services":`+services+`}`))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+			require.EqualError(t, cmdErr, expected)
+		}
+	})
+
+	t.Run("test create public did - dry run", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+				CreateFunc: func(string, ...vdriapi.DocOpts) (*did.Doc, error) {
+					require.FailNow(t, "registry create must not be invoked in dry run mode")
+
+					return nil, nil
+				},
+			},
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree", "header":"{\"alg\":\"none\"}", `+
+			`"services":[{"id":"#didcomm","type":"did-communication","serviceEndpoint":"http://example.com"}], `+
+			`"dryRun":true}`))
+		require.NoError(t, cmdErr)
+
+		var response CreatePublicDIDResponse
+		err = json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+
+		require.Empty(t, response.DID)
+		require.NotNil(t, response.DryRun)
+		require.Equal(t, "sidetree", response.DryRun.Method)
+
+		preview := dryRunDoc(t, response.DryRun, `{"alg":"none"}`)
+		require.Len(t, preview.PublicKey, 1)
+		require.Equal(t, "#key-1", preview.PublicKey[0].ID)
+		require.Equal(t, "Ed25519VerificationKey2018", preview.PublicKey[0].Type)
+		require.Len(t, base58.Decode(preview.PublicKey[0].PublicKeyBase58), ed25519.PublicKeySize)
+		require.Equal(t, []string{"#key-1"}, preview.Authentication)
+		require.Len(t, preview.Service, 1)
+		require.Equal(t, "#didcomm", preview.Service[0].ID)
+		require.Equal(t, "http://example.com", preview.Service[0].ServiceEndpoint)
+		require.Empty(t, preview.KeyAgreement)
+	})
+
+	t.Run("test create public did - dry run with key agreement", func(t *testing.T) {
+		km := &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("no key is created in dry run mode")}
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:             km,
+			VDRIRegistryValue: vdriregistry.New(&mockprovider.Provider{KMSValue: km},
+				vdriregistry.WithVDRI(&mockvdri.MockVDRI{
+					AcceptValue: true,
+					MethodsValue: []vdriapi.MethodCapabilities{
+						{Method: "sidetree", Resolve: true, Create: true, KeyAgreement: true},
+					},
+					BuildFunc: func(*vdriapi.PubKey, ...vdriapi.DocOpts) (*did.Doc, error) {
+						require.FailNow(t, "the DID is not created in dry run mode")
+
+						return nil, nil
+					},
+				})),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree","keyAgreement":true,"dryRun":true}`))
+		require.NoError(t, cmdErr)
+
+		var response CreatePublicDIDResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		preview := dryRunDoc(t, response.DryRun, "")
+		require.Len(t, preview.PublicKey, 1)
+		require.Len(t, preview.KeyAgreement, 1)
+		require.Equal(t, "#key-2", preview.KeyAgreement[0].ID)
+		require.Equal(t, vdriapi.X25519KeyAgreementKey2019, preview.KeyAgreement[0].Type)
+		require.Len(t, base58.Decode(preview.KeyAgreement[0].PublicKeyBase58), 32)
+	})
+
+	t.Run("test create public did - dry run invalid header", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree", "header":"{", "dryRun":true}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "build request")
+	})
+
+	t.Run("test create public did - invalid request", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "request decode")
+
+		cmdErr = cmd.CreatePublicDID(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyDIDMethod)
+	})
+
+	t.Run("test create public did - registry error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{CreateErr: fmt.Errorf("create error")},
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreatePublicDIDErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "create error")
+	})
+}
+
+// previewKey is a key of the DID doc previewed by dry runs.
+type previewKey struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	PublicKeyBase58 string `json:"publicKeyBase58"`
+}
+
+// previewDoc is the DID doc previewed by dry runs, the DID is unknown hence the doc has no ID.
+type previewDoc struct {
+	PublicKey      []previewKey  `json:"publicKey"`
+	Authentication []string      `json:"authentication"`
+	Service        []did.Service `json:"service"`
+	KeyAgreement   []previewKey  `json:"keyAgreement"`
+}
+
+// dryRunDoc returns the DID doc previewed as the payload of the dry run request, the header of the request is checked.
+func dryRunDoc(t *testing.T, dryRun *DryRunResult, header string) *previewDoc {
+	t.Helper()
+
+	require.NotNil(t, dryRun)
+
+	var request struct {
+		Header  json.RawMessage `json:"header"`
+		Payload string          `json:"payload"`
+	}
+
+	require.NoError(t, json.Unmarshal(dryRun.Request, &request))
+
+	if header == "" {
+		require.Empty(t, request.Header)
+	} else {
+		require.JSONEq(t, header, string(request.Header))
+	}
+
+	payload, err := base64.URLEncoding.DecodeString(request.Payload)
+	require.NoError(t, err)
+
+	doc := &previewDoc{}
+	require.NoError(t, json.Unmarshal(payload, doc))
+
+	return doc
+}
+
+func TestCreatePublicDIDKeyAgreement(t *testing.T) {
+	t.Run("test create public did with key agreement - success", func(t *testing.T) {
+		localKMS, err := localkms.New("local-lock://test/key-uri/",
+			mockkms.NewProviderForKMS(mem.NewProvider(), &noop.NoLock{}))
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:             localKMS,
+			VDRIRegistryValue: vdriregistry.New(&mockprovider.Provider{KMSValue: localKMS},
+				vdriregistry.WithVDRI(key.New())),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"key","keyAgreement":true}`))
+		require.NoError(t, cmdErr)
+
+		var response CreatePublicDIDResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		violations, err := did.ValidateRules(response.DID)
+		require.NoError(t, err)
+		require.Empty(t, violations)
+
+		doc, err := did.ParseDocument(response.DID)
+		require.NoError(t, err)
+		require.Len(t, doc.KeyAgreement, 1)
+
+		keyAgreement := doc.KeyAgreement[0].PublicKey
+		require.Equal(t, vdriapi.X25519KeyAgreementKey2019, keyAgreement.Type)
+		require.Equal(t, doc.ID, keyAgreement.Controller)
+		require.True(t, strings.HasPrefix(keyAgreement.ID, doc.ID+"#"))
+
+		// the X25519 key is derived from the ed25519 key of the KMS identified by the fragment of its id
+		keyID := strings.TrimPrefix(keyAgreement.ID, doc.ID+"#")
+
+		_, err = localKMS.Get(keyID)
+		require.NoError(t, err)
+
+		pubKey, err := localKMS.ExportPubKeyBytes(keyID)
+		require.NoError(t, err)
+
+		x25519PubKey, err := cryptoutil.PublicEd25519toCurve25519(pubKey)
+		require.NoError(t, err)
+		require.Equal(t, x25519PubKey, keyAgreement.Value)
+	})
+
+	t.Run("test create public did with key agreement - kms error", func(t *testing.T) {
+		km := &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("kms error")}
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:             km,
+			VDRIRegistryValue:    vdriregistry.New(&mockprovider.Provider{KMSValue: km}, vdriregistry.WithVDRI(key.New())),
+		})
+		require.NoError(t, err)
+
+		cmdErr := cmd.CreatePublicDID(&bytes.Buffer{}, bytes.NewBufferString(`{"method":"key","keyAgreement":true}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreatePublicDIDErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "create key agreement key: kms error")
+	})
+
+	t.Run("test create public did with key agreement - key agreement not supported", func(t *testing.T) {
+		km := &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("no key is created")}
+
+		for _, tc := range []struct {
+			registry vdriapi.Registry
+			comment  string
+		}{
+			{
+				registry: vdriregistry.New(&mockprovider.Provider{KMSValue: km},
+					vdriregistry.WithVDRI(&mockvdri.MockVDRI{
+						AcceptValue: true,
+						MethodsValue: []vdriapi.MethodCapabilities{
+							{Method: "sidetree", Resolve: true, Create: true},
+						},
+						BuildFunc: func(*vdriapi.PubKey, ...vdriapi.DocOpts) (*did.Doc, error) {
+							require.Fail(t, "the DID is not created")
+
+							return nil, nil
+						},
+					})),
+				comment: "method without key agreement",
+			},
+			{
+				registry: &mockvdri.MockVDRIRegistry{
+					CreateFunc: func(string, ...vdriapi.DocOpts) (*did.Doc, error) {
+						require.Fail(t, "the DID is not created")
+
+						return nil, nil
+					},
+				},
+				comment: "registry without method descriptions",
+			},
+		} {
+			cmd, err := New(&mockprovider.Provider{
+				StorageProviderValue: mockstore.NewMockStoreProvider(),
+				KMSValue:             km,
+				VDRIRegistryValue:    tc.registry,
+			})
+			require.NoError(t, err, tc.comment)
+
+			for _, dryRun := range []bool{false, true} {
+				cmdErr := cmd.CreatePublicDID(&bytes.Buffer{}, bytes.NewBufferString(
+					fmt.Sprintf(`{"method":"sidetree","keyAgreement":true,"dryRun":%t}`, dryRun)))
+				require.Error(t, cmdErr, tc.comment)
+				require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), tc.comment)
+				require.Equal(t, command.ValidationError, cmdErr.Type(), tc.comment)
+				require.Contains(t, cmdErr.Error(), fmt.Sprintf(errNoKeyAgreement, "sidetree"), tc.comment)
+			}
+		}
+	})
+
+	t.Run("test create public did with key agreement - created without key agreement", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+
+		km := &mockkms.KeyManager{CrAndExportPubKeyID: "kid", CrAndExportPubKeyValue: pubKey}
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:             km,
+			VDRIRegistryValue: vdriregistry.New(&mockprovider.Provider{KMSValue: km},
+				vdriregistry.WithVDRI(&mockvdri.MockVDRI{
+					AcceptValue: true,
+					MethodsValue: []vdriapi.MethodCapabilities{
+						{Method: "sidetree", Resolve: true, Create: true, KeyAgreement: true},
+					},
+					BuildFunc: func(*vdriapi.PubKey, ...vdriapi.DocOpts) (*did.Doc, error) {
+						return did.ParseDocument([]byte(doc))
+					},
+				})),
+		})
+		require.NoError(t, err)
+
+		cmdErr := cmd.CreatePublicDID(&bytes.Buffer{}, bytes.NewBufferString(`{"method":"sidetree","keyAgreement":true}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreatePublicDIDErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), fmt.Sprintf(errNoKeyAgreement, "sidetree"))
+	})
+}
+
+func TestDiffDID(t *testing.T) {
+	oldDoc, err := did.ParseDocument([]byte(doc))
+	require.NoError(t, err)
+
+	newDoc, err := did.ParseDocument([]byte(doc))
+	require.NoError(t, err)
+
+	newDoc.PublicKey[0].Value = []byte("new-value")
+	newDoc.Service = []did.Service{{
+		ID: "did:peer:21tDAKCERh95uGgKbJNHYp#agent", Type: "did-communication", ServiceEndpoint: "https://example.com",
+	}}
+
+	newDocBytes, err := newDoc.JSONBytes()
+	require.NoError(t, err)
+
+	expected := &did.DocDiff{
+		ChangedPublicKeys: []string{"did:peer:123456789abcdefghi#keys-1"},
+		AddedServices:     []string{"did:peer:21tDAKCERh95uGgKbJNHYp#agent"},
+	}
+
+	newCommand := func(t *testing.T, registry vdriapi.Registry) *Command {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    registry,
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	t.Run("test diff did - success", func(t *testing.T) {
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{ResolveErr: errors.New("not expected")})
+
+		req, err := json.Marshal(&DiffDIDArgs{Old: json.RawMessage(doc), New: newDocBytes})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.DiffDID(&b, bytes.NewBuffer(req))
+		require.NoError(t, cmdErr)
+
+		var response DiffDIDResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, expected, response.Diff)
+	})
+
+	t.Run("test diff did - old did doc resolved", func(t *testing.T) {
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{ResolveValue: oldDoc})
+
+		req, err := json.Marshal(&DiffDIDArgs{New: newDocBytes})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.DiffDID(&b, bytes.NewBuffer(req))
+		require.NoError(t, cmdErr)
+
+		var response DiffDIDResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, expected, response.Diff)
+	})
+
+	t.Run("test diff did - same did docs", func(t *testing.T) {
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{ResolveValue: oldDoc})
+
+		var b bytes.Buffer
+		cmdErr := cmd.DiffDID(&b, bytes.NewBufferString(fmt.Sprintf(`{"new":%s}`, doc)))
+		require.NoError(t, cmdErr)
+		require.JSONEq(t, `{"diff":{}}`, b.String())
+	})
+
+	t.Run("test diff did - validation errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.DiffDID(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.DiffDID(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyNewDIDDoc)
+
+		cmdErr = cmd.DiffDID(&b, bytes.NewBufferString(`{"new":{}}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "parse new did doc")
+
+		cmdErr = cmd.DiffDID(&b, bytes.NewBufferString(fmt.Sprintf(`{"old":{},"new":%s}`, doc)))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "parse old did doc")
+	})
+
+	t.Run("test diff did - resolve error", func(t *testing.T) {
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{ResolveErr: errors.New("resolve error")})
+
+		var b bytes.Buffer
+		cmdErr := cmd.DiffDID(&b, bytes.NewBufferString(fmt.Sprintf(`{"new":%s}`, doc)))
+		require.Error(t, cmdErr)
+		require.Equal(t, DiffDIDErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "resolve error")
+	})
+}
+
+func TestValidateDIDDoc(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	validate := func(t *testing.T, args *ValidateDIDDocArgs) *ValidateDIDDocResponse {
+		t.Helper()
+
+		req, err := json.Marshal(args)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.ValidateDIDDoc(&b, bytes.NewBuffer(req)))
+
+		var response ValidateDIDDocResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		return &response
+	}
+
+	// docWith returns the did doc with the authentication and service of the parameters
+	docWith := func(t *testing.T, authentication, service string) json.RawMessage {
+		t.Helper()
+
+		d := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(doc), &d))
+
+		d["authentication"] = json.RawMessage(authentication)
+		d["service"] = json.RawMessage(service)
+
+		docBytes, err := json.Marshal(d)
+		require.NoError(t, err)
+
+		return docBytes
+	}
+
+	t.Run("test validate did doc - valid did doc", func(t *testing.T) {
+		response := validate(t, &ValidateDIDDocArgs{Document: Document{DID: json.RawMessage(doc)}})
+		require.Equal(t, &ValidateDIDDocResponse{Valid: true}, response)
+
+		response = validate(t, &ValidateDIDDocArgs{Document: Document{DID: docWith(t,
+			`["did:peer:123456789abcdefghi#keys-1"]`,
+			`[{"id": "#agent", "type": "did-communication", "serviceEndpoint": "https://agent.example.com"},
+			{"id": "#hub", "type": "IdentityHub", "serviceEndpoint": "https://hub.example.com"}]`,
+		)}, ServiceTypes: []string{"IdentityHub"}})
+		require.Equal(t, &ValidateDIDDocResponse{Valid: true}, response)
+	})
+
+	t.Run("test validate did doc - duplicate ids", func(t *testing.T) {
+		response := validate(t, &ValidateDIDDocArgs{Document: Document{DID: docWith(t,
+			`[{"id": "did:peer:123456789abcdefghi#keys-1", "type": "Ed25519VerificationKey2018",
+			"controller": "did:peer:21tDAKCERh95uGgKbJNHYp", "publicKeyBase58": "B12NYF8RrR3h"}]`,
+			`[{"id": "#agent", "type": "did-communication", "serviceEndpoint": "https://agent.example.com"},
+			{"id": "#agent", "type": "did-communication", "serviceEndpoint": "https://agent2.example.com"}]`,
+		)}})
+		require.Equal(t, &ValidateDIDDocResponse{Violations: []did.Violation{
+			{Path: "authentication[0]", Message: "duplicate id did:peer:123456789abcdefghi#keys-1, " +
+				"already used by publicKey[0]"},
+			{Path: "service[1]", Message: "duplicate id #agent, already used by service[0]"},
+		}}, response)
+	})
+
+	t.Run("test validate did doc - missing verification method", func(t *testing.T) {
+		response := validate(t, &ValidateDIDDocArgs{Document: Document{DID: docWith(t,
+			`["did:peer:123456789abcdefghi#keys-1", "did:peer:21tDAKCERh95uGgKbJNHYp#keys-2"]`,
+			`[{"id": "#hub", "type": "IdentityHub", "serviceEndpoint": "https://hub.example.com"}]`,
+		)}})
+		require.Equal(t, &ValidateDIDDocResponse{Violations: []did.Violation{
+			{Path: "authentication[1]", Message: "references missing verification method " +
+				"did:peer:21tDAKCERh95uGgKbJNHYp#keys-2"},
+			{Path: "service[0]", Message: "unknown service type IdentityHub, known types: did-communication"},
+		}}, response)
+	})
+
+	t.Run("test validate did doc - validation errors", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.ValidateDIDDoc(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "request decode")
+
+		cmdErr = cmd.ValidateDIDDoc(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyDIDDoc)
+	})
+}
+
+func TestImportDID(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	importedDoc := did.BuildDoc(did.WithPublicKey([]did.PublicKey{
+		*did.NewPublicKeyFromBytes("#key-1", "Ed25519VerificationKey2018", "did:example:123", pubKey),
+	}))
+	importedDoc.ID = "did:example:123"
+
+	importedDocBytes, err := importedDoc.JSONBytes()
+	require.NoError(t, err)
+
+	newCommand := func(t *testing.T, registry vdriapi.Registry, km *mockkms.KeyManager) *Command {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    registry,
+			KMSValue:             km,
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	t.Run("test import did - success", func(t *testing.T) {
+		registry := &mockvdri.MockVDRIRegistry{}
+		cmd := newCommand(t, registry, &mockkms.KeyManager{ImportPrivateKeyErr: errors.New("not expected")})
+
+		req, err := json.Marshal(&ImportDIDArgs{Document: Document{DID: importedDocBytes}, Method: "example"})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.ImportDID(&b, bytes.NewBuffer(req))
+		require.NoError(t, cmdErr)
+		require.JSONEq(t, `{"id":"did:example:123"}`, b.String())
+
+		require.Contains(t, registry.MemStore, importedDoc.ID)
+		require.Equal(t, importedDoc.PublicKey[0].Value, registry.MemStore[importedDoc.ID].PublicKey[0].Value)
+	})
+
+	t.Run("test import did - with private key", func(t *testing.T) {
+		registry := &mockvdri.MockVDRIRegistry{}
+		cmd := newCommand(t, registry, &mockkms.KeyManager{ImportPrivateKeyID: "kid"})
+
+		req, err := json.Marshal(&ImportDIDArgs{
+			Document:   Document{DID: importedDocBytes},
+			Method:     "example",
+			PrivateKey: base58.Encode(privKey),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.ImportDID(&b, bytes.NewBuffer(req))
+		require.NoError(t, cmdErr)
+
+		var response ImportDIDResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, ImportDIDResponse{ID: importedDoc.ID, KeyID: "kid"}, response)
+		require.Contains(t, registry.MemStore, importedDoc.ID)
+	})
+
+	t.Run("test import did - validation errors", func(t *testing.T) {
+		registry := &mockvdri.MockVDRIRegistry{}
+		cmd := newCommand(t, registry, &mockkms.KeyManager{})
+
+		_, otherPrivKey, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+
+		noIDDoc := strings.Replace(string(importedDocBytes), `"id":"did:example:123",`, `"id":"",`, 1)
+		require.NotEqual(t, string(importedDocBytes), noIDDoc)
+
+		for _, tc := range []struct {
+			req string
+			err string
+		}{
+			{req: "--", err: "request decode"},
+			{req: `{"method":"example"}`, err: errEmptyDIDDoc},
+			{req: fmt.Sprintf(`{"did":%s}`, importedDocBytes), err: errEmptyDIDMethod},
+			{req: `{"did":{},"method":"example"}`, err: "parse did doc"},
+			{req: fmt.Sprintf(`{"did":%s,"method":"example"}`, noIDDoc), err: errEmptyDIDID},
+			{
+				req: fmt.Sprintf(`{"did":%s,"method":"peer"}`, importedDocBytes),
+				err: "did `did:example:123` is not a did of method `peer`",
+			},
+			{
+				req: fmt.Sprintf(`{"did":%s,"method":"example","privateKey":"invalid"}`, importedDocBytes),
+				err: "not a base58 encoded ed25519 private key",
+			},
+			{
+				req: fmt.Sprintf(`{"did":%s,"method":"example","privateKey":"%s"}`, importedDocBytes,
+					base58.Encode(otherPrivKey)),
+				err: "no matching public key in did doc",
+			},
+		} {
+			var b bytes.Buffer
+			cmdErr := cmd.ImportDID(&b, bytes.NewBufferString(tc.req))
+			require.Error(t, cmdErr, tc.req)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+			require.Contains(t, cmdErr.Error(), tc.err)
+		}
+
+		require.Empty(t, registry.MemStore)
+	})
+
+	t.Run("test import did - store and import errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{PutErr: errors.New("store error")}, &mockkms.KeyManager{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.ImportDID(&b, bytes.NewBufferString(fmt.Sprintf(`{"did":%s,"method":"example"}`,
+			importedDocBytes)))
+		require.Error(t, cmdErr)
+		require.Equal(t, ImportDIDErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "store error")
+
+		registry := &mockvdri.MockVDRIRegistry{}
+		cmd = newCommand(t, registry, &mockkms.KeyManager{ImportPrivateKeyErr: errors.New("import error")})
+
+		cmdErr = cmd.ImportDID(&b, bytes.NewBufferString(fmt.Sprintf(`{"did":%s,"method":"example","privateKey":"%s"}`,
+			importedDocBytes, base58.Encode(privKey))))
+		require.Error(t, cmdErr)
+		require.Equal(t, ImportDIDErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "import error")
+		require.Empty(t, registry.MemStore)
+	})
+}
+
+func TestGetDIDCommService(t *testing.T) {
+	newCommand := func(t *testing.T, registry vdriapi.Registry) *Command {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    registry,
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	newDoc := func(t *testing.T, services ...did.Service) *did.Doc {
+		didDoc, err := did.ParseDocument([]byte(doc))
+		require.NoError(t, err)
+
+		didDoc.Service = services
+
+		return didDoc
+	}
+
+	didCommService := did.Service{
+		ID:              "did:peer:21tDAKCERh95uGgKbJNHYp#didcomm",
+		Type:            vdriapi.DIDCommServiceType,
+		Priority:        1,
+		ServiceEndpoint: "https://example.com/didcomm",
+		RecipientKeys:   []string{"H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"},
+		RoutingKeys:     []string{"did:example:mediator#key-1"},
+	}
+
+	t.Run("test get DIDComm service - success", func(t *testing.T) {
+		lowerPriority := didCommService
+		lowerPriority.ID = "did:peer:21tDAKCERh95uGgKbJNHYp#didcomm-2"
+		lowerPriority.Priority = 2
+
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{ResolveValue: newDoc(t,
+			did.Service{ID: "did:peer:21tDAKCERh95uGgKbJNHYp#hub", Type: "hub", ServiceEndpoint: "https://hub.com"},
+			lowerPriority, didCommService,
+		)})
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetDIDCommService(&b, bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp"}`))
+		require.NoError(t, cmdErr)
+
+		var response DIDCommServiceResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, DIDCommServiceResponse{
+			ID:              didCommService.ID,
+			ServiceEndpoint: didCommService.ServiceEndpoint,
+			RecipientKeys:   didCommService.RecipientKeys,
+			RoutingKeys:     didCommService.RoutingKeys,
+		}, response)
+	})
+
+	t.Run("test get DIDComm service - no DIDComm service", func(t *testing.T) {
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{ResolveValue: newDoc(t,
+			did.Service{ID: "did:peer:21tDAKCERh95uGgKbJNHYp#hub", Type: "hub", ServiceEndpoint: "https://hub.com"},
+		)})
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetDIDCommService(&b, bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetDIDCommServiceErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.EqualError(t, cmdErr, "did `did:peer:21tDAKCERh95uGgKbJNHYp` has no did-communication service")
+		require.Empty(t, b.String())
+	})
+
+	t.Run("test get DIDComm service - invalid DIDComm service", func(t *testing.T) {
+		noEndpoint := didCommService
+		noEndpoint.ServiceEndpoint = ""
+
+		noKeys := didCommService
+		noKeys.RecipientKeys = nil
+
+		for invalid, service := range map[string]did.Service{
+			"missing service endpoint": noEndpoint,
+			"missing recipient keys":   noKeys,
+		} {
+			cmd := newCommand(t, &mockvdri.MockVDRIRegistry{ResolveValue: newDoc(t, service)})
+
+			var b bytes.Buffer
+			cmdErr := cmd.GetDIDCommService(&b, bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp"}`))
+			require.Error(t, cmdErr)
+			require.Equal(t, GetDIDCommServiceErrorCode, cmdErr.Code())
+			require.Contains(t, cmdErr.Error(), invalid)
+		}
+	})
+
+	t.Run("test get DIDComm service - validation errors", func(t *testing.T) {
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetDIDCommService(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "request decode")
+
+		cmdErr = cmd.GetDIDCommService(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), errEmptyDIDID)
+	})
+
+	t.Run("test get DIDComm service - resolve error", func(t *testing.T) {
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{ResolveErr: errors.New("resolve error")})
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetDIDCommService(&b, bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetDIDCommServiceErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "resolve error")
+	})
+}
+
+func TestGetDIDMethods(t *testing.T) {
+	t.Run("test get did methods", func(t *testing.T) {
+		peerVDRI, err := peer.New(mockstore.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: vdriregistry.New(&mockprovider.Provider{},
+				vdriregistry.WithVDRI(peerVDRI),
+				vdriregistry.WithVDRI(key.New()),
+				vdriregistry.WithVDRI(&mockvdri.MockVDRI{MethodsValue: []vdriapi.MethodCapabilities{
+					{Method: "example", Resolve: true, Create: true, Update: true, Deactivate: true},
+				}})),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RegisterVDRI(&b, bytes.NewBufferString(`{"method":"web","url":"http://localhost:8080"}`))
+		require.NoError(t, cmdErr)
+
+		var getRW bytes.Buffer
+		cmdErr = cmd.GetDIDMethods(&getRW, nil)
+		require.NoError(t, cmdErr)
+
+		response := DIDMethodsResponse{}
+		err = json.NewDecoder(&getRW).Decode(&response)
+		require.NoError(t, err)
+
+		require.Equal(t, []vdriapi.MethodCapabilities{
+			{Method: "example", Resolve: true, Create: true, Update: true, Deactivate: true},
+			{Method: "key", Resolve: true, Create: true, KeyAgreement: true},
+			{Method: "peer", Resolve: true, Create: true},
+			{Method: "web", Resolve: true},
+		}, response.Methods)
+	})
+
+	t.Run("test registry without method descriptions", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetDIDMethods(&b, nil)
+		require.Error(t, cmdErr)
+		require.Equal(t, GetDIDMethodsErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "vdri registry doesn't describe its methods")
+	})
+}
+
+func TestCreatePeerDID(t *testing.T) {
+	// newCmd returns a command creating peer DIDs with the route service
+	newCmd := func(t *testing.T, routeSvc *mockmediator.MockMediatorSvc, endpoint string) (*Command, vdriapi.Registry) {
+		t.Helper()
+
+		pubKey, _, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+
+		peerVDRI, err := peer.New(mockstore.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		registry := vdriregistry.New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{
+			CrAndExportPubKeyID:    "123",
+			CrAndExportPubKeyValue: pubKey,
+		}}, vdriregistry.WithVDRI(peerVDRI))
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    registry,
+			ServiceMap:           map[string]interface{}{mediator.Coordination: routeSvc},
+			ServiceEndpointValue: endpoint,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		return cmd, registry
+	}
+
+	t.Run("test create peer did - routed through the router", func(t *testing.T) {
+		var addedKeys []string
+
+		cmd, registry := newCmd(t, &mockmediator.MockMediatorSvc{
+			RouterEndpoint: "https://router.example.com",
+			RoutingKeys:    []string{"router-key-1"},
+			AddKeyFunc: func(recKey string) error {
+				addedKeys = append(addedKeys, recKey)
+
+				return nil
+			},
+		}, "https://agent.example.com")
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePeerDID(&b, bytes.NewBufferString(`{"name":"`+sampleDIDName+`"}`))
+		require.NoError(t, cmdErr)
+
+		var response CreatePeerDIDResponse
+		err := json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+
+		require.True(t, strings.HasPrefix(response.ID, "did:peer:"))
+
+		// the created DID resolves locally
+		didDoc, err := registry.Resolve(response.ID)
+		require.NoError(t, err)
+		require.Equal(t, response.ID, didDoc.ID)
+
+		svc, ok := did.LookupService(didDoc, vdriapi.DIDCommServiceType)
+		require.True(t, ok)
+		require.Equal(t, "https://router.example.com", svc.ServiceEndpoint)
+		require.Equal(t, []string{"router-key-1"}, svc.RoutingKeys)
+		require.NotEmpty(t, svc.RecipientKeys)
+		require.Equal(t, svc.RecipientKeys, addedKeys)
+
+		// the DID is saved with its name
+		b.Reset()
+		cmdErr = cmd.GetDID(&b, bytes.NewBufferString(`{"id":"`+response.ID+`"}`))
+		require.NoError(t, cmdErr)
+
+		// the name is taken
+		cmdErr = cmd.CreatePeerDID(&b, bytes.NewBufferString(`{"name":"`+sampleDIDName+`"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "already exists")
+	})
+
+	t.Run("test create peer did - agent service endpoint without router", func(t *testing.T) {
+		cmd, registry := newCmd(t, &mockmediator.MockMediatorSvc{}, "https://agent.example.com")
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePeerDID(&b, bytes.NewBufferString(""))
+		require.NoError(t, cmdErr)
+
+		var response CreatePeerDIDResponse
+		err := json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+
+		didDoc, err := registry.Resolve(response.ID)
+		require.NoError(t, err)
+
+		svc, ok := did.LookupService(didDoc, vdriapi.DIDCommServiceType)
+		require.True(t, ok)
+		require.Equal(t, "https://agent.example.com", svc.ServiceEndpoint)
+		require.Empty(t, svc.RoutingKeys)
+	})
+
+	t.Run("test create peer did - routing configuration missing", func(t *testing.T) {
+		cmd, _ := newCmd(t, &mockmediator.MockMediatorSvc{}, "")
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePeerDID(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), errNoRouting)
+	})
+
+	t.Run("test create peer did - invalid request", func(t *testing.T) {
+		cmd, _ := newCmd(t, &mockmediator.MockMediatorSvc{}, "https://agent.example.com")
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePeerDID(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "request decode")
+	})
+
+	t.Run("test create peer did - router errors", func(t *testing.T) {
+		cmd, _ := newCmd(t, &mockmediator.MockMediatorSvc{ConfigErr: errors.New("config error")}, "")
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePeerDID(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreatePeerDIDErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "config error")
+
+		cmd, _ = newCmd(t, &mockmediator.MockMediatorSvc{
+			RouterEndpoint: "https://router.example.com",
+			RoutingKeys:    []string{"router-key-1"},
+			AddKeyErr:      errors.New("add key error"),
+		}, "")
+
+		cmdErr = cmd.CreatePeerDID(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreatePeerDIDErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "add key to the router: addKey: add key error")
+	})
+
+	t.Run("test create peer did - route service errors", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			ServiceErr:           errors.New("service error"),
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePeerDID(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreatePeerDIDErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "lookup route service: service error")
+
+		cmd, err = New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			ServiceValue:         &mockvdri.MockVDRIRegistry{},
+		})
+		require.NoError(t, err)
+
+		cmdErr = cmd.CreatePeerDID(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "cast to route service failed")
+	})
+
+	t.Run("test create peer did - create error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{CreateErr: errors.New("create error")},
+			ServiceValue:         &mockmediator.MockMediatorSvc{},
+			ServiceEndpointValue: "https://agent.example.com",
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePeerDID(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreatePeerDIDErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "create peer did: create error")
+	})
+}
+
+func TestRegisterVDRI(t *testing.T) {
+	newCommand := func(t *testing.T) *Command {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    vdriregistry.New(&mockprovider.Provider{}),
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	t.Run("test register vdri and resolve did through it", func(t *testing.T) {
+		resolver := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			require.Equal(t, "/did:peer:21tDAKCERh95uGgKbJNHYp", req.URL.String())
+			require.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+			res.Header().Add("Content-type", "application/did+ld+json")
+			res.WriteHeader(http.StatusOK)
+			_, err := res.Write([]byte(doc))
+			require.NoError(t, err)
+		}))
+		defer resolver.Close()
+
+		cmd := newCommand(t)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RegisterVDRI(&b, bytes.NewBufferString(
+			fmt.Sprintf(`{"method":"peer","url":"%s","authToken":"secret"}`, resolver.URL)))
+		require.NoError(t, cmdErr)
+
+		var getRW bytes.Buffer
+		cmdErr = cmd.ResolveDID(&getRW, bytes.NewBufferString(`{"id":"did:peer:21tDAKCERh95uGgKbJNHYp"}`))
+		require.NoError(t, cmdErr)
+
+		response := Document{}
+		err := json.NewDecoder(&getRW).Decode(&response)
+		require.NoError(t, err)
+
+		didDoc, err := did.ParseDocument(response.DID)
+		require.NoError(t, err)
+		require.Equal(t, "did:peer:21tDAKCERh95uGgKbJNHYp", didDoc.ID)
+
+		// registered vdri only resolves its method
+		cmdErr = cmd.ResolveDID(&getRW, bytes.NewBufferString(`{"id":"did:example:123"}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "did method example not supported for vdri")
+
+		// duplicate registration
+		cmdErr = cmd.RegisterVDRI(&b, bytes.NewBufferString(
+			fmt.Sprintf(`{"method":"peer","url":"%s"}`, resolver.URL)))
+		require.Error(t, cmdErr)
+		require.Equal(t, RegisterVDRIErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "did method peer already registered")
+	})
+
+	t.Run("test invalid request", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		tests := []struct {
+			request string
+			err     string
+		}{
+			{request: "--", err: "request decode"},
+			{request: `{"url":"http://localhost:8080"}`, err: errEmptyDIDMethod},
+			{request: `{"method":"peer"}`, err: errEmptyVDRIURL},
+			{request: `{"method":"peer","url":"localhost:8080"}`, err: "invalid vdri url"},
+			{request: `{"method":"peer","url":"ftp://localhost:8080"}`, err: "invalid vdri url"},
+			{request: `{"method":"peer","url":"http://"}`, err: "invalid vdri url"},
+		}
+
+		for _, test := range tests {
+			var b bytes.Buffer
+			cmdErr := cmd.RegisterVDRI(&b, bytes.NewBufferString(test.request))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Contains(t, cmdErr.Error(), test.err)
+		}
+	})
+
+	t.Run("test registry doesn't support registration", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.RegisterVDRI(&b, bytes.NewBufferString(`{"method":"peer","url":"http://localhost:8080"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RegisterVDRIErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
+
+func TestCreatePublicDIDEvents(t *testing.T) {
+	newCommand := func(t *testing.T, registry vdriapi.Registry, events *[]DIDCreateEvent) *Command {
+		notifier := &webhook.Notifier{NotifyFunc: func(topic string, message []byte) error {
+			require.Equal(t, DIDCreateTopic, topic)
+
+			var event DIDCreateEvent
+			require.NoError(t, json.Unmarshal(message, &event))
+
+			*events = append(*events, event)
+
+			return nil
+		}}
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    registry,
+		}, WithNotifier(notifier))
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	didDoc, err := did.ParseDocument([]byte(doc))
+	require.NoError(t, err)
+
+	requested := DIDCreateEvent{Type: DIDCreateRequestedEvent, RequestID: "req-1", Method: "sidetree"}
+	submitted := DIDCreateEvent{Type: DIDCreateSubmittedEvent, RequestID: "req-1", Method: "sidetree"}
+
+	// submittingRegistry is a registry submitting the request built by the request builder to the ledger, the events
+	// published before and after building the request are checked.
+	submittingRegistry := func(t *testing.T, events *[]DIDCreateEvent, ledgerErr error) vdriapi.Registry {
+		return &mockvdri.MockVDRIRegistry{
+			CreateFunc: func(method string, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+				docOpts := &vdriapi.CreateDIDOpts{}
+				for _, opt := range opts {
+					opt(docOpts)
+				}
+
+				require.Equal(t, []DIDCreateEvent{requested}, *events)
+
+				_, err := docOpts.RequestBuilder([]byte("payload"))
+				require.NoError(t, err)
+
+				require.Equal(t, []DIDCreateEvent{requested, submitted}, *events)
+
+				if ledgerErr != nil {
+					return nil, ledgerErr
+				}
+
+				return didDoc, nil
+			},
+		}
+	}
+
+	t.Run("test create public did events - success", func(t *testing.T) {
+		var events []DIDCreateEvent
+
+		cmd := newCommand(t, submittingRegistry(t, &events, nil), &events)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree","requestID":"req-1"}`))
+		require.NoError(t, cmdErr)
+
+		require.Equal(t, []DIDCreateEvent{
+			requested,
+			submitted,
+			{Type: DIDCreateCompletedEvent, RequestID: "req-1", Method: "sidetree", ID: didDoc.ID},
+		}, events)
+	})
+
+	t.Run("test create public did events - created without request", func(t *testing.T) {
+		var events []DIDCreateEvent
+
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{CreateValue: didDoc}, &events)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree","requestID":"req-1"}`))
+		require.NoError(t, cmdErr)
+
+		require.Equal(t, []DIDCreateEvent{
+			requested,
+			{Type: DIDCreateCompletedEvent, RequestID: "req-1", Method: "sidetree", ID: didDoc.ID},
+		}, events)
+	})
+
+	t.Run("test create public did events - failure", func(t *testing.T) {
+		var events []DIDCreateEvent
+
+		cmd := newCommand(t, submittingRegistry(t, &events, errors.New("ledger error")), &events)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree","requestID":"req-1"}`))
+		require.Error(t, cmdErr)
+
+		require.Equal(t, []DIDCreateEvent{
+			requested,
+			submitted,
+			{Type: DIDCreateFailedEvent, RequestID: "req-1", Method: "sidetree", Error: "ledger error"},
+		}, events)
+	})
+
+	t.Run("test create public did events - request build failure", func(t *testing.T) {
+		var events []DIDCreateEvent
+
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{
+			CreateFunc: func(method string, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+				docOpts := &vdriapi.CreateDIDOpts{}
+				for _, opt := range opts {
+					opt(docOpts)
+				}
+
+				_, err := docOpts.RequestBuilder([]byte("payload"))
+
+				return nil, err
+			},
+		}, &events)
+
+		// the invalid header fails the request build, nothing is submitted
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree","requestID":"req-1","header":"{"}`))
+		require.Error(t, cmdErr)
+
+		require.Len(t, events, 2)
+		require.Equal(t, requested, events[0])
+		require.Equal(t, DIDCreateFailedEvent, events[1].Type)
+	})
+
+	t.Run("test create public did events - none for invalid requests and dry runs", func(t *testing.T) {
+		var events []DIDCreateEvent
+
+		cmd := newCommand(t, &mockvdri.MockVDRIRegistry{CreateValue: didDoc}, &events)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+
+		cmdErr = cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree","dryRun":true}`))
+		require.NoError(t, cmdErr)
+
+		require.Empty(t, events)
+	})
+
+	t.Run("test create public did events - publishing failure doesn't fail the request", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{CreateValue: didDoc},
+		}, WithNotifier(&webhook.Notifier{NotifyFunc: func(string, []byte) error {
+			return errors.New("notify error")
+		}}))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree"}`))
+		require.NoError(t, cmdErr)
+	})
+}
+
+func TestCreatePublicDIDAllowedMethods(t *testing.T) {
+	newCommand := func(t *testing.T, opts ...Option) *Command {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+				CreateFunc: func(method string, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+					return did.ParseDocument([]byte(doc))
+				},
+			},
+		}, opts...)
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		return cmd
+	}
+
+	t.Run("test allowed method", func(t *testing.T) {
+		cmd := newCommand(t, WithAllowedMethods([]string{"sov", "sidetree"}))
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree"}`))
+		require.NoError(t, cmdErr)
+		require.NotEmpty(t, b.String())
+	})
+
+	t.Run("test disallowed method", func(t *testing.T) {
+		cmd := newCommand(t, WithAllowedMethods([]string{"sov", "sidetree"}))
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sovv"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.EqualError(t, cmdErr, "did method `sovv` is not supported, supported methods: sidetree, sov")
+		require.Empty(t, b.String())
+
+		// dry run requests are validated too
+		cmdErr = cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sovv","dryRun":true}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
+	t.Run("test no allowlist configured", func(t *testing.T) {
+		for _, opts := range [][]Option{nil, {WithAllowedMethods(nil)}} {
+			cmd := newCommand(t, opts...)
+
+			var b bytes.Buffer
+			cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sovv"}`))
+			require.NoError(t, cmdErr)
+		}
+	})
+}
+
+func TestCreatePublicDIDLogging(t *testing.T) {
+	t.Run("test create public did - success logs", func(t *testing.T) {
+		l := &capturingLogger{}
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+				CreateFunc: func(method string, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+					return did.ParseDocument([]byte(doc))
+				},
+			},
+		}, WithLogger(l), WithRequestIDGenerator(func() string { return "generated-id" }))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree"}`))
+		require.NoError(t, cmdErr)
+
+		var response CreatePublicDIDResponse
+		err = json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+		require.Equal(t, "generated-id", response.RequestID)
+
+		require.Len(t, l.infos, 1)
+		require.Contains(t, l.infos[0], "request completed")
+		require.Contains(t, l.infos[0], "requestID=[generated-id]")
+		require.Contains(t, l.infos[0], "method=[sidetree]")
+		require.Contains(t, l.infos[0], "action=[CreatePublicDID]")
+		require.Contains(t, l.infos[0], "outcome=[success]")
+		require.Contains(t, l.infos[0], "duration=[")
+		require.Empty(t, l.errors)
+	})
+
+	t.Run("test create public did - failure logs", func(t *testing.T) {
+		l := &capturingLogger{}
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{CreateErr: fmt.Errorf("create error")},
+		}, WithLogger(l))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`{"method":"sidetree","requestID":"client-id"}`))
+		require.Error(t, cmdErr)
+
+		require.Empty(t, l.infos)
+		require.Len(t, l.errors, 1)
+		require.Contains(t, l.errors[0], "request failed")
+		require.Contains(t, l.errors[0], "requestID=[client-id]")
+		require.Contains(t, l.errors[0], "method=[sidetree]")
+		require.Contains(t, l.errors[0], "outcome=[failure]")
+		require.Contains(t, l.errors[0], "error=[create public did: create error]")
+		require.Contains(t, l.errors[0], "duration=[")
+	})
+
+	t.Run("test create public did - invalid request logs", func(t *testing.T) {
+		l := &capturingLogger{}
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		}, WithLogger(l))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDID(&b, bytes.NewBufferString(`--`))
+		require.Error(t, cmdErr)
+
+		require.Empty(t, l.errors)
+		require.Len(t, l.infos, 1)
+		require.Contains(t, l.infos[0], "outcome=[failure]")
+		require.Regexp(t, `requestID=\[[0-9a-f-]{36}\]`, l.infos[0])
+	})
+}
+
+type capturingLogger struct {
+	infos  []string
+	errors []string
+}
+
+func (c *capturingLogger) Fatalf(msg string, args ...interface{}) {}
+
+func (c *capturingLogger) Panicf(msg string, args ...interface{}) {}
+
+func (c *capturingLogger) Debugf(msg string, args ...interface{}) {}
+
+func (c *capturingLogger) Infof(msg string, args ...interface{}) {
+	c.infos = append(c.infos, fmt.Sprintf(msg, args...))
+}
+
+func (c *capturingLogger) Warnf(msg string, args ...interface{}) {}
+
+func (c *capturingLogger) Errorf(msg string, args ...interface{}) {
+	c.errors = append(c.errors, fmt.Sprintf(msg, args...))
+}
+
+func TestCommandInterceptors(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+			CreateFunc: func(method string, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+				return did.ParseDocument([]byte(doc))
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	registry, err := command.NewRegistry(cmd.GetHandlers()...)
+	require.NoError(t, err)
+
+	var intercepted []string
+
+	registry.Use(func(_ context.Context, name string, next command.Exec) command.Exec {
+		return func(rw io.Writer, req io.Reader) command.Error {
+			intercepted = append(intercepted, name)
+
+			return next(rw, req)
+		}
+	})
+
+	var b bytes.Buffer
+	cmdErr := registry.Dispatch(command.QualifiedName(CommandName, CreatePublicDIDMethod), &b,
+		bytes.NewBufferString(`{"method":"sidetree"}`))
+	require.NoError(t, cmdErr)
+
+	var response CreatePublicDIDResponse
+	err = json.NewDecoder(&b).Decode(&response)
+	require.NoError(t, err)
+
+	didDoc, err := did.ParseDocument(response.DID)
+	require.NoError(t, err)
+	require.Equal(t, "did:peer:21tDAKCERh95uGgKbJNHYp", didDoc.ID)
+
+	// command errors are returned unchanged
+	cmdErr = registry.Dispatch(command.QualifiedName(CommandName, CreatePublicDIDMethod), &b,
+		bytes.NewBufferString(`{}`))
+	require.Error(t, cmdErr)
+	require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	require.Equal(t, command.ValidationError, cmdErr.Type())
+
+	require.Equal(t, []string{"vdri.CreatePublicDID", "vdri.CreatePublicDID"}, intercepted)
+}
+
+func TestPrepareBasicRequestBuilder(t *testing.T) {
+	payload := []byte(`{"id":"did:example:123"}`)
+
+	reader, err := prepareBasicRequestBuilder(`{"kid":"key-1"}`)(payload)
+	require.NoError(t, err)
+
+	requestBytes, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+
+	require.JSONEq(t, fmt.Sprintf(`{"header":{"kid":"key-1"},"payload":"%s"}`,
+		base64.URLEncoding.EncodeToString(payload)), string(requestBytes))
+
+	reader, err = prepareBasicRequestBuilder("")(payload)
+	require.NoError(t, err)
+
+	requestBytes, err = ioutil.ReadAll(reader)
+	require.NoError(t, err)
+
+	require.JSONEq(t, fmt.Sprintf(`{"payload":"%s"}`, base64.URLEncoding.EncodeToString(payload)),
+		string(requestBytes))
+}
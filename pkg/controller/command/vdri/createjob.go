@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdri
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// createJobStoreName is the store of the jobs of the asynchronous public DID creations.
+	createJobStoreName = "didcreatejob"
+
+	// DID creation job statuses
+	DIDCreateJobPending   = "pending"
+	DIDCreateJobCompleted = "completed"
+	DIDCreateJobFailed    = "failed"
+
+	errEmptyJobID  = "job id is mandatory"
+	errAsyncDryRun = "dry run is not supported by asynchronous DID creations"
+	errJobNotFound = "did create job `%s` not found"
+
+	// log constants
+	jobIDKey = "jobID"
+)
+
+// CreatePublicDIDAsync starts the creation of a new public DID using the VDRI registry method given in the request
+// and returns the pending job of the creation right away, without waiting for the ledger. The status of the job,
+// along with the created DID once completed, is polled with GetDIDCreateStatus. The request is validated as by
+// CreatePublicDID, dry runs aren't supported.
+// The jobs are persisted but not resumed: a job whose agent stopped before the DID creation ended stays pending.
+func (o *Command) CreatePublicDIDAsync(rw io.Writer, req io.Reader) command.Error {
+	start := time.Now()
+
+	var request CreatePublicDIDArgs
+
+	decodeErr := json.NewDecoder(req).Decode(&request)
+
+	if request.RequestID == "" {
+		request.RequestID = o.newRequestID()
+	}
+
+	reqLogger := log.WithFields(o.logger, log.Fields{
+		"command": CommandName,
+		"action":  CreatePublicDIDAsyncMethod,
+		requestID: request.RequestID,
+		didMethod: request.Method,
+	})
+
+	cmdErr := o.createPublicDIDAsync(rw, &request, decodeErr, reqLogger)
+
+	logOutcome(reqLogger, time.Since(start), cmdErr)
+
+	return cmdErr
+}
+
+func (o *Command) createPublicDIDAsync(rw io.Writer, request *CreatePublicDIDArgs, decodeErr error,
+	reqLogger *log.FieldLogger) command.Error {
+	if cmdErr := o.validateCreatePublicDID(request, decodeErr); cmdErr != nil {
+		return cmdErr
+	}
+
+	if request.DryRun {
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errAsyncDryRun))
+	}
+
+	now := time.Now()
+
+	job := &DIDCreateJob{
+		JobID:       uuid.New().String(),
+		Status:      DIDCreateJobPending,
+		RequestID:   request.RequestID,
+		Method:      request.Method,
+		CreatedTime: now,
+		UpdatedTime: now,
+	}
+
+	err := o.saveCreateJob(job)
+	if err != nil {
+		return command.NewExecuteError(CreatePublicDIDErrorCode, err)
+	}
+
+	jobLogger := reqLogger.WithFields(log.Fields{jobIDKey: job.JobID})
+
+	go o.runCreateJob(*job, request, jobLogger)
+
+	command.WriteNillableResponse(rw, job, logger)
+
+	jobLogger.Debugf("started public DID creation job")
+
+	return nil
+}
+
+// runCreateJob creates the public DID of the job and saves the outcome of the creation as the status of the job.
+func (o *Command) runCreateJob(job DIDCreateJob, request *CreatePublicDIDArgs, jobLogger *log.FieldLogger) {
+	start := time.Now()
+
+	response, err := o.submitPublicDID(request, prepareBasicRequestBuilder(request.RequestHeader), jobLogger)
+	if err != nil {
+		job.Status = DIDCreateJobFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = DIDCreateJobCompleted
+		job.ID = response.ID
+		job.DID = response.DID
+	}
+
+	job.UpdatedTime = time.Now()
+
+	if saveErr := o.saveCreateJob(&job); saveErr != nil {
+		jobLogger.Errorf("failed to save the status of the public DID creation job: %s", saveErr)
+	}
+
+	fields := log.Fields{"duration": time.Since(start), "status": job.Status}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		jobLogger.WithFields(fields).Errorf("public DID creation job failed")
+
+		return
+	}
+
+	jobLogger.WithFields(fields).Infof("public DID creation job completed")
+}
+
+func (o *Command) saveCreateJob(job *DIDCreateJob) error {
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal did create job : %w", err)
+	}
+
+	err = o.createJobStore.Put(job.JobID, jobBytes)
+	if err != nil {
+		return fmt.Errorf("save did create job : %w", err)
+	}
+
+	return nil
+}
+
+// GetDIDCreateStatus returns the job of an asynchronous public DID creation started by CreatePublicDIDAsync: its
+// status and, once completed, the created DID.
+func (o *Command) GetDIDCreateStatus(rw io.Writer, req io.Reader) command.Error {
+	var request DIDCreateJobArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetDIDCreateStatusMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.JobID == "" {
+		logutil.LogDebug(logger, CommandName, GetDIDCreateStatusMethod, errEmptyJobID)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyJobID))
+	}
+
+	jobBytes, err := o.createJobStore.Get(request.JobID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		logutil.LogDebug(logger, CommandName, GetDIDCreateStatusMethod, fmt.Sprintf(errJobNotFound, request.JobID))
+
+		return command.NewValidationError(GetDIDCreateStatusErrorCode, fmt.Errorf(errJobNotFound, request.JobID))
+	}
+
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetDIDCreateStatusMethod, "get did create job : "+err.Error(),
+			logutil.CreateKeyValueString(jobIDKey, request.JobID))
+
+		return command.NewExecuteError(GetDIDCreateStatusErrorCode, fmt.Errorf("get did create job : %w", err))
+	}
+
+	job := &DIDCreateJob{}
+
+	err = json.Unmarshal(jobBytes, job)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetDIDCreateStatusMethod, "unmarshal did create job : "+err.Error(),
+			logutil.CreateKeyValueString(jobIDKey, request.JobID))
+
+		return command.NewExecuteError(GetDIDCreateStatusErrorCode, fmt.Errorf("unmarshal did create job : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, job, logger)
+
+	logutil.LogDebug(logger, CommandName, GetDIDCreateStatusMethod, "success",
+		logutil.CreateKeyValueString(jobIDKey, request.JobID))
+
+	return nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdri
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+)
+
+func getDIDCreateStatus(t *testing.T, cmd *Command, jobID string) *DIDCreateJob {
+	t.Helper()
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.GetDIDCreateStatus(&b, bytes.NewBufferString(`{"jobID":"`+jobID+`"}`)))
+
+	job := &DIDCreateJob{}
+	require.NoError(t, json.NewDecoder(&b).Decode(job))
+
+	return job
+}
+
+func TestCreatePublicDIDAsync(t *testing.T) {
+	didDoc, err := did.ParseDocument([]byte(doc))
+	require.NoError(t, err)
+
+	t.Run("test create public did async - pending then completed", func(t *testing.T) {
+		submitted := make(chan struct{})
+		ledger := make(chan struct{})
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+				// slow registrar, the DID is created once the ledger is released
+				CreateFunc: func(method string, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+					close(submitted)
+					<-ledger
+
+					return didDoc, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDIDAsync(&b, bytes.NewBufferString(`{"method":"sidetree","requestID":"req-1"}`))
+		require.NoError(t, cmdErr)
+
+		started := &DIDCreateJob{}
+		require.NoError(t, json.NewDecoder(&b).Decode(started))
+		require.NotEmpty(t, started.JobID)
+		require.Equal(t, DIDCreateJobPending, started.Status)
+		require.Equal(t, "req-1", started.RequestID)
+		require.Equal(t, "sidetree", started.Method)
+		require.Empty(t, started.DID)
+
+		<-submitted
+
+		job := getDIDCreateStatus(t, cmd, started.JobID)
+		require.Equal(t, DIDCreateJobPending, job.Status)
+		require.Empty(t, job.ID)
+		require.Empty(t, job.DID)
+
+		close(ledger)
+
+		require.Eventually(t, func() bool {
+			job = getDIDCreateStatus(t, cmd, started.JobID)
+
+			return job.Status != DIDCreateJobPending
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Equal(t, DIDCreateJobCompleted, job.Status)
+		require.Equal(t, didDoc.ID, job.ID)
+		require.Empty(t, job.Error)
+		require.False(t, job.UpdatedTime.Before(job.CreatedTime))
+
+		createdDoc, err := did.ParseDocument(job.DID)
+		require.NoError(t, err)
+		require.Equal(t, didDoc.ID, createdDoc.ID)
+	})
+
+	t.Run("test create public did async - failed", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{CreateErr: errors.New("ledger error")},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDIDAsync(&b, bytes.NewBufferString(`{"method":"sidetree"}`))
+		require.NoError(t, cmdErr)
+
+		started := &DIDCreateJob{}
+		require.NoError(t, json.NewDecoder(&b).Decode(started))
+		require.NotEmpty(t, started.RequestID)
+
+		var job *DIDCreateJob
+
+		require.Eventually(t, func() bool {
+			job = getDIDCreateStatus(t, cmd, started.JobID)
+
+			return job.Status != DIDCreateJobPending
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Equal(t, DIDCreateJobFailed, job.Status)
+		require.Equal(t, "create public did: ledger error", job.Error)
+		require.Empty(t, job.DID)
+	})
+
+	t.Run("test create public did async - validation errors", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+				CreateFunc: func(method string, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+					require.Fail(t, "no DID is created for invalid requests")
+
+					return nil, nil
+				},
+			},
+		}, WithAllowedMethods([]string{"sidetree"}))
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			request string
+			errMsg  string
+		}{
+			{"--", "request decode"},
+			{`{}`, errEmptyDIDMethod},
+			{`{"method":"ethr"}`, "did method `ethr` is not supported"},
+			{`{"method":"sidetree","services":[{"id":"s1"}]}`, "service type is mandatory"},
+			{`{"method":"sidetree","dryRun":true}`, errAsyncDryRun},
+		} {
+			var b bytes.Buffer
+			cmdErr := cmd.CreatePublicDIDAsync(&b, bytes.NewBufferString(tc.request))
+			require.Error(t, cmdErr, tc.request)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code(), tc.request)
+			require.Equal(t, command.ValidationError, cmdErr.Type(), tc.request)
+			require.Contains(t, cmdErr.Error(), tc.errMsg, tc.request)
+			require.Empty(t, b.Bytes(), tc.request)
+		}
+	})
+
+	t.Run("test create public did async - save job error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				Store: &mockstore.MockStore{Store: map[string][]byte{}, ErrPut: errors.New("put error")},
+			},
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{CreateValue: didDoc},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreatePublicDIDAsync(&b, bytes.NewBufferString(`{"method":"sidetree"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreatePublicDIDErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "save did create job : put error")
+	})
+
+	t.Run("test new command - did create job store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				Store:         &mockstore.MockStore{Store: map[string][]byte{}},
+				FailNamespace: createJobStoreName,
+			},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open did create job store")
+	})
+}
+
+func TestGetDIDCreateStatus(t *testing.T) {
+	t.Run("test get did create status - validation errors", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()})
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			request string
+			code    command.Code
+			errMsg  string
+		}{
+			{"--", InvalidRequestErrorCode, "request decode"},
+			{`{}`, InvalidRequestErrorCode, errEmptyJobID},
+			{`{"jobID":"unknown"}`, GetDIDCreateStatusErrorCode, "did create job `unknown` not found"},
+		} {
+			var b bytes.Buffer
+			cmdErr := cmd.GetDIDCreateStatus(&b, bytes.NewBufferString(tc.request))
+			require.Error(t, cmdErr, tc.request)
+			require.Equal(t, tc.code, cmdErr.Code(), tc.request)
+			require.Equal(t, command.ValidationError, cmdErr.Type(), tc.request)
+			require.Contains(t, cmdErr.Error(), tc.errMsg, tc.request)
+		}
+	})
+
+	t.Run("test get did create status - store errors", func(t *testing.T) {
+		store := &mockstore.MockStore{Store: map[string][]byte{"job-1": []byte("{")}}
+
+		cmd, err := New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{Store: store}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetDIDCreateStatus(&b, bytes.NewBufferString(`{"jobID":"job-1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetDIDCreateStatusErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "unmarshal did create job")
+
+		store.ErrGet = errors.New("get error")
+
+		cmdErr = cmd.GetDIDCreateStatus(&b, bytes.NewBufferString(`{"jobID":"job-1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetDIDCreateStatusErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "get did create job : get error")
+	})
+}
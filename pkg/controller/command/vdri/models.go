@@ -8,7 +8,10 @@ package vdri
 
 import (
 	"encoding/json"
+	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	storeDID "github.com/hyperledger/aries-framework-go/pkg/store/did"
 )
 
@@ -32,6 +35,31 @@ type IDArg struct {
 	ID string `json:"id"`
 }
 
+// ResolveDIDArgs model
+//
+// This is used for resolving did by ID from input json.
+//
+type ResolveDIDArgs struct {
+	IDArg
+
+	// IncludeMetadata returns the did resolution metadata along with the did document if set
+	IncludeMetadata bool `json:"includeMetadata,omitempty"`
+
+	// ContextVersion of the did document returned (v0.11 or v1), the did document is returned as resolved if not set
+	ContextVersion string `json:"contextVersion,omitempty"`
+}
+
+// ResolveDIDResponse model
+//
+// This is used for returning the resolved did document, along with its resolution metadata if requested.
+//
+type ResolveDIDResponse struct {
+	Document
+
+	// Metadata of the did resolution
+	Metadata *vdriapi.ResolutionMetadata `json:"metadata,omitempty"`
+}
+
 // DIDRecordResult holds the did doc records.
 type DIDRecordResult struct {
 	// Result
@@ -46,3 +74,205 @@ type NameArg struct {
 	// Name
 	Name string `json:"name"`
 }
+
+// CreatePublicDIDArgs contains parameters for creating new public DID.
+type CreatePublicDIDArgs struct {
+	// Params for creating public DID
+	Method string `json:"method,omitempty"`
+
+	// RequestHeader to be included while submitting request to http binding URL
+	RequestHeader string `json:"header,omitempty"`
+
+	// Services to be advertised by the created DID (eg: DIDComm endpoints), type and endpoint are mandatory
+	Services []did.Service `json:"services,omitempty"`
+
+	// DryRun returns the request which would be submitted for the given method without creating the DID
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// KeyAgreement adds a X25519 keyAgreement verification method (eg: for DIDComm v2 and JWE), its key is created
+	// in the KMS. The DID method must support keyAgreement verification methods (see GetDIDMethods)
+	KeyAgreement bool `json:"keyAgreement,omitempty"`
+
+	// RequestID correlates the logs of the request, generated if missing
+	RequestID string `json:"requestID,omitempty"`
+}
+
+// RegisterVDRIArgs contains parameters for registering a HTTP binding vdri at runtime.
+type RegisterVDRIArgs struct {
+	// Method of the DIDs resolved by the vdri
+	Method string `json:"method"`
+
+	// URL of the HTTP binding resolver endpoint
+	URL string `json:"url"`
+
+	// AuthToken sent as bearer token along with the resolve requests
+	AuthToken string `json:"authToken,omitempty"`
+}
+
+// DiffDIDArgs contains the did docs to be compared.
+type DiffDIDArgs struct {
+	// Old did doc, the did doc resolved from the id of the new did doc is used if missing
+	Old json.RawMessage `json:"old,omitempty"`
+
+	// New did doc
+	New json.RawMessage `json:"new"`
+}
+
+// DiffDIDResponse for diff did operation.
+type DiffDIDResponse struct {
+	// Diff between the old and new did docs
+	Diff *did.DocDiff `json:"diff"`
+}
+
+// ValidateDIDDocArgs contains the did doc to be validated.
+type ValidateDIDDocArgs struct {
+	Document
+
+	// ServiceTypes the service types known in addition to the types known to the framework
+	ServiceTypes []string `json:"serviceTypes,omitempty"`
+}
+
+// ValidateDIDDocResponse for validate did doc operation.
+type ValidateDIDDocResponse struct {
+	// Valid is true when the did doc violates no rule
+	Valid bool `json:"valid"`
+
+	// Violations of the DID Core rules by the did doc
+	Violations []did.Violation `json:"violations,omitempty"`
+}
+
+// ImportDIDArgs contains the externally created did doc to be imported.
+type ImportDIDArgs struct {
+	Document
+
+	// Method of the did, the id of the did doc must be a did of this method
+	Method string `json:"method"`
+
+	// PrivateKey base58 encoded ed25519 private key of a public key of the did doc, for the did to sign with
+	PrivateKey string `json:"privateKey,omitempty"`
+}
+
+// ImportDIDResponse for import did operation.
+type ImportDIDResponse struct {
+	// ID of the imported did
+	ID string `json:"id"`
+
+	// KeyID of the private key imported into the KMS, only set if a private key was given
+	KeyID string `json:"keyID,omitempty"`
+}
+
+// DIDCommServiceResponse for get DIDComm service operation.
+type DIDCommServiceResponse struct {
+	// ID of the did-communication service
+	ID string `json:"id"`
+
+	// ServiceEndpoint messages are sent to
+	ServiceEndpoint string `json:"serviceEndpoint"`
+
+	// RecipientKeys messages are encrypted for
+	RecipientKeys []string `json:"recipientKeys"`
+
+	// RoutingKeys of the mediators messages are forwarded through
+	RoutingKeys []string `json:"routingKeys,omitempty"`
+}
+
+// CreatePublicDIDResponse for create public DID operation.
+type CreatePublicDIDResponse struct {
+	// ID of the created DID, same as the id of the DID document
+	ID string `json:"id,omitempty"`
+
+	// DID document
+	DID json.RawMessage `json:"did,omitempty"`
+
+	// DryRun result, only set when the DID creation was requested in dry run mode
+	DryRun *DryRunResult `json:"dryRun,omitempty"`
+
+	// RequestID of the request
+	RequestID string `json:"requestID,omitempty"`
+}
+
+// DIDCreateJobArgs contains the job ID of an asynchronous public DID creation.
+type DIDCreateJobArgs struct {
+	// JobID of the DID creation, returned by CreatePublicDIDAsync
+	JobID string `json:"jobID"`
+}
+
+// DIDCreateJob is the state of an asynchronous public DID creation.
+type DIDCreateJob struct {
+	// JobID of the DID creation
+	JobID string `json:"jobID"`
+
+	// Status of the DID creation: pending, completed or failed
+	Status string `json:"status"`
+
+	// RequestID of the DID creation request
+	RequestID string `json:"requestID,omitempty"`
+
+	// Method of the DID being created
+	Method string `json:"method"`
+
+	// ID of the created DID, only set once the DID is created
+	ID string `json:"id,omitempty"`
+
+	// DID document, only set once the DID is created
+	DID json.RawMessage `json:"did,omitempty"`
+
+	// Error which failed the DID creation, only set for failed creations
+	Error string `json:"error,omitempty"`
+
+	// CreatedTime of the job
+	CreatedTime time.Time `json:"createdTime"`
+
+	// UpdatedTime of the job, the time the DID creation completed or failed
+	UpdatedTime time.Time `json:"updatedTime"`
+}
+
+// DIDCreateEvent is the payload of the public DID creation progress events.
+type DIDCreateEvent struct {
+	// Type of the event (eg: did-create-requested)
+	Type string `json:"type"`
+
+	// RequestID of the DID creation request
+	RequestID string `json:"requestID"`
+
+	// Method of the DID being created
+	Method string `json:"method"`
+
+	// ID of the created DID, only set once the DID is created
+	ID string `json:"id,omitempty"`
+
+	// Error which failed the DID creation, only set for failed creations
+	Error string `json:"error,omitempty"`
+}
+
+// DryRunResult contains the request which would be submitted for creating a public DID.
+type DryRunResult struct {
+	// Method of the DID to be created
+	Method string `json:"method"`
+
+	// Request serialized request (header and encoded payload) built for the registry
+	Request json.RawMessage `json:"request"`
+}
+
+// DIDMethodsResponse for get DID methods operation.
+type DIDMethodsResponse struct {
+	// Methods supported by the VDRI registry along with their capabilities, sorted by method
+	Methods []vdriapi.MethodCapabilities `json:"methods"`
+}
+
+// CreatePeerDIDArgs model
+//
+// This is used for creating a peer DID.
+type CreatePeerDIDArgs struct {
+	// Name to save the DID with, optional
+	Name string `json:"name,omitempty"`
+}
+
+// CreatePeerDIDResponse for create peer DID operation.
+type CreatePeerDIDResponse struct {
+	// ID of the created peer DID
+	ID string `json:"id"`
+
+	// DID document
+	DID json.RawMessage `json:"did"`
+}
@@ -12,17 +12,24 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	ariescrypto "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/credentialstatus"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	verifiablesigner "github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
@@ -77,6 +84,27 @@ const (
 
 	// RemovePresentationByNameErrorCode for remove vp by name errors.
 	RemovePresentationByNameErrorCode
+
+	// VerifyCredentialErrorCode for verify vc error.
+	VerifyCredentialErrorCode
+
+	// GetCredentialsByTagErrorCode for get credential records by tag error.
+	GetCredentialsByTagErrorCode
+
+	// DeriveCredentialErrorCode for derive vc error.
+	DeriveCredentialErrorCode
+
+	// VerifyPresentationErrorCode for verify vp error.
+	VerifyPresentationErrorCode
+
+	// QueryCredentialsErrorCode for query credential records error.
+	QueryCredentialsErrorCode
+
+	// CreateDIDAuthRequestErrorCode for create DID authentication request error.
+	CreateDIDAuthRequestErrorCode
+
+	// VerifyDIDAuthResponseErrorCode for verify DID authentication response error.
+	VerifyDIDAuthResponseErrorCode
 )
 
 // constants for the Verifiable protocol
@@ -97,6 +125,13 @@ const (
 	GeneratePresentationByIDCommandMethod = "GeneratePresentationByID"
 	RemoveCredentialByNameCommandMethod   = "RemoveCredentialByName"
 	RemovePresentationByNameCommandMethod = "RemovePresentationByName"
+	VerifyCredentialCommandMethod         = "VerifyCredential"
+	GetCredentialsByTagCommandMethod      = "GetCredentialsByTag"
+	DeriveCredentialCommandMethod         = "DeriveCredential"
+	VerifyPresentationCommandMethod       = "VerifyPresentation"
+	QueryCredentialsCommandMethod         = "QueryCredentials"
+	CreateDIDAuthRequestCommandMethod     = "CreateDIDAuthRequest"
+	VerifyDIDAuthResponseCommandMethod    = "VerifyDIDAuthResponse"
 
 	// error messages
 	errEmptyCredentialName   = "credential name is mandatory"
@@ -104,6 +139,10 @@ const (
 	errEmptyCredentialID     = "credential id is mandatory"
 	errEmptyPresentationID   = "presentation id is mandatory"
 	errEmptyDID              = "did is mandatory"
+	errEmptyCredential       = "credential is mandatory"
+	errEmptyPresentation     = "presentation is mandatory"
+	errEmptyTag              = "tag is mandatory"
+	errEmptyFrame            = "frame is mandatory"
 
 	// log constants
 	vcID   = "vcID"
@@ -116,6 +155,8 @@ const (
 	Ed25519Signature2018 = "Ed25519Signature2018"
 	// JSONWebSignature2020 json web signature suite.
 	JSONWebSignature2020 = "JsonWebSignature2020"
+	// BbsBlsSignature2020 BBS+ signature suite.
+	BbsBlsSignature2020 = "BbsBlsSignature2020"
 
 	// Ed25519KeyType ed25519 key type.
 	Ed25519KeyType = "Ed25519"
@@ -138,6 +179,7 @@ type keyResolver interface {
 type kmsSigner struct {
 	keyHandle interface{}
 	crypto    ariescrypto.Crypto
+	bbs       bool
 }
 
 func newKMSSigner(keyManager kms.KeyManager, c ariescrypto.Crypto, creator string) (*kmsSigner, error) {
@@ -156,6 +198,11 @@ func newKMSSigner(keyManager kms.KeyManager, c ariescrypto.Crypto, creator strin
 }
 
 func (s *kmsSigner) Sign(data []byte) ([]byte, error) {
+	// a BBS+ signature signs each N-Quads statement of the document as a separate message
+	if s.bbs {
+		return s.crypto.SignMulti(sigverifier.SplitStatements(data), s.keyHandle)
+	}
+
 	v, err := s.crypto.Sign(data, s.keyHandle)
 	if err != nil {
 		return nil, err
@@ -177,6 +224,8 @@ type Command struct {
 	verifiableStore verifiablestore.Store
 	didStore        *didstore.Store
 	kResolver       keyResolver
+	statusChecker   *credentialstatus.Checker
+	didAuthStore    storage.Store
 	ctx             provider
 }
 
@@ -192,11 +241,21 @@ func New(p provider) (*Command, error) {
 		return nil, fmt.Errorf("new did store : %w", err)
 	}
 
+	didAuthStore, err := p.StorageProvider().OpenStore(didAuthStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open did auth store : %w", err)
+	}
+
+	kResolver := verifiable.NewDIDKeyResolver(p.VDRIRegistry())
+
 	return &Command{
 		verifiableStore: verifiableStore,
 		didStore:        didStore,
-		kResolver:       verifiable.NewDIDKeyResolver(p.VDRIRegistry()),
-		ctx:             p,
+		kResolver:       kResolver,
+		didAuthStore:    didAuthStore,
+		statusChecker: credentialstatus.New(
+			credentialstatus.WithCredentialOpts(verifiable.WithPublicKeyFetcher(kResolver.PublicKeyFetcher()))),
+		ctx: p,
 	}, nil
 }
 
@@ -216,6 +275,13 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, GetPresentationsCommandMethod, o.GetPresentations),
 		cmdutil.NewCommandHandler(CommandName, RemoveCredentialByNameCommandMethod, o.RemoveCredentialByName),
 		cmdutil.NewCommandHandler(CommandName, RemovePresentationByNameCommandMethod, o.RemovePresentationByName),
+		cmdutil.NewCommandHandler(CommandName, VerifyCredentialCommandMethod, o.VerifyCredential),
+		cmdutil.NewCommandHandler(CommandName, GetCredentialsByTagCommandMethod, o.GetCredentialsByTag),
+		cmdutil.NewCommandHandler(CommandName, DeriveCredentialCommandMethod, o.DeriveCredential),
+		cmdutil.NewCommandHandler(CommandName, VerifyPresentationCommandMethod, o.VerifyPresentation),
+		cmdutil.NewCommandHandler(CommandName, QueryCredentialsCommandMethod, o.QueryCredentials),
+		cmdutil.NewCommandHandler(CommandName, CreateDIDAuthRequestCommandMethod, o.CreateDIDAuthRequest),
+		cmdutil.NewCommandHandler(CommandName, VerifyDIDAuthResponseCommandMethod, o.VerifyDIDAuthResponse),
 	}
 }
 
@@ -247,6 +313,99 @@ func (o *Command) ValidateCredential(rw io.Writer, req io.Reader) command.Error
 	return nil
 }
 
+// VerifyCredential verifies the proof of the verifiable credential (JSON-LD or JWT) with the public key of its
+// issuer resolved through the VDRI registry. If requested, the credential must not be revoked in the status list
+// its `credentialStatus` refers to. Credentials failing the verification are reported in the response along with
+// the reason.
+func (o *Command) VerifyCredential(rw io.Writer, req io.Reader) command.Error {
+	request := &VerifyCredentialRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.VerifiableCredential == "" {
+		logutil.LogDebug(logger, CommandName, VerifyCredentialCommandMethod, errEmptyCredential)
+
+		return command.NewValidationError(VerifyCredentialErrorCode, fmt.Errorf(errEmptyCredential))
+	}
+
+	response := &VerifyCredentialResponse{Verified: true}
+
+	err = o.verifyCredential(request.VerifiableCredential, request.CheckStatus)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "verify vc : "+err.Error())
+
+		response = &VerifyCredentialResponse{Reason: err.Error()}
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyCredentialCommandMethod, "success")
+
+	return nil
+}
+
+// VerifyPresentation verifies the proof of the verifiable presentation (JSON-LD or JWT), that it is bound to its
+// holder and made with the expected challenge and domain, and verifies each of its credentials as VerifyCredential
+// does. The presentation is verified if its proof and all of its credentials are.
+func (o *Command) VerifyPresentation(rw io.Writer, req io.Reader) command.Error {
+	request := &VerifyPresentationRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyPresentationCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	vpStr := presentationString(request.VerifiablePresentation)
+	if vpStr == "" {
+		logutil.LogDebug(logger, CommandName, VerifyPresentationCommandMethod, errEmptyPresentation)
+
+		return command.NewValidationError(VerifyPresentationErrorCode, fmt.Errorf(errEmptyPresentation))
+	}
+
+	response := &VerifyPresentationResponse{Verified: true}
+
+	_, err = o.verifyPresentation(vpStr, request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyPresentationCommandMethod, "verify vp : "+err.Error())
+
+		response.Verified = false
+		response.Reason = err.Error()
+	}
+
+	credentials, err := presentationCredentials(vpStr)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyPresentationCommandMethod, "vp credentials : "+err.Error())
+
+		response.Verified = false
+		response.Reason = err.Error()
+	}
+
+	for _, vcStr := range credentials {
+		result := VerifyCredentialResponse{Verified: true}
+
+		if err := o.verifyCredential(vcStr, false); err != nil {
+			result = VerifyCredentialResponse{Reason: err.Error()}
+
+			response.Verified = false
+		}
+
+		response.Credentials = append(response.Credentials, result)
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyPresentationCommandMethod, "success")
+
+	return nil
+}
+
 // SaveCredential saves the verifiable credential to the store.
 func (o *Command) SaveCredential(rw io.Writer, req io.Reader) command.Error {
 	request := &CredentialExt{}
@@ -417,6 +576,58 @@ func (o *Command) SignCredential(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// DeriveCredential derives from the BBS+ signatures of the given verifiable credential a new credential revealing
+// only the attributes selected by the frame, with proofs bound to the nonce.
+func (o *Command) DeriveCredential(rw io.Writer, req io.Reader) command.Error {
+	request := &DeriveCredentialRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DeriveCredentialCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if len(request.Credential) == 0 {
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCredential))
+	}
+
+	if len(request.Frame) == 0 {
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyFrame))
+	}
+
+	vc, err := verifiable.ParseCredential(request.Credential,
+		verifiable.WithPublicKeyFetcher(o.kResolver.PublicKeyFetcher()))
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeriveCredentialCommandMethod, "parse vc : "+err.Error())
+
+		return command.NewValidationError(DeriveCredentialErrorCode, fmt.Errorf("parse vc : %w", err))
+	}
+
+	derivedVC, err := vc.DeriveProof(request.Frame, []byte(request.Nonce),
+		verifiable.WithPublicKeyFetcher(o.kResolver.PublicKeyFetcher()))
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeriveCredentialCommandMethod, "derive vc : "+err.Error())
+
+		return command.NewValidationError(DeriveCredentialErrorCode, fmt.Errorf("derive vc : %w", err))
+	}
+
+	vcBytes, err := derivedVC.MarshalJSON()
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeriveCredentialCommandMethod, "marshal vc : "+err.Error())
+
+		return command.NewValidationError(DeriveCredentialErrorCode, fmt.Errorf("marshal vc : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &Credential{
+		VerifiableCredential: string(vcBytes),
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, DeriveCredentialCommandMethod, "success")
+
+	return nil
+}
+
 // GetPresentation retrieves the verifiable presentation from the store.
 func (o *Command) GetPresentation(rw io.Writer, req io.Reader) command.Error {
 	var request IDArg
@@ -510,6 +721,109 @@ func (o *Command) GetCredentials(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// GetCredentialsByTag retrieves the verifiable credential records tagged with the given issuer, type or subject.
+func (o *Command) GetCredentialsByTag(rw io.Writer, req io.Reader) command.Error {
+	var request TagArg
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetCredentialsByTagCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Tag == "" {
+		logutil.LogDebug(logger, CommandName, GetCredentialsByTagCommandMethod, errEmptyTag)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyTag))
+	}
+
+	vcRecords, err := o.verifiableStore.GetCredentialsByTag(request.Tag, request.Value)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetCredentialsByTagCommandMethod,
+			"get credential records by tag : "+err.Error(), logutil.CreateKeyValueString(request.Tag, request.Value))
+
+		return command.NewValidationError(GetCredentialsByTagErrorCode,
+			fmt.Errorf("get credential records by tag : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &RecordResult{
+		Result: vcRecords,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetCredentialsByTagCommandMethod, "success",
+		logutil.CreateKeyValueString(request.Tag, request.Value))
+
+	return nil
+}
+
+// QueryCredentials retrieves the verifiable credential records matching all the filters of the request, the expiry
+// of the credentials is computed from their expirationDate at the time of the query.
+func (o *Command) QueryCredentials(rw io.Writer, req io.Reader) command.Error {
+	var request QueryCredentialsArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, QueryCredentialsCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	vcRecords, err := o.queryCredentialRecords(&request, time.Now())
+	if err != nil {
+		logutil.LogError(logger, CommandName, QueryCredentialsCommandMethod,
+			"query credential records : "+err.Error())
+
+		return command.NewValidationError(QueryCredentialsErrorCode,
+			fmt.Errorf("query credential records : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &RecordResult{
+		Result: vcRecords,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, QueryCredentialsCommandMethod, "success")
+
+	return nil
+}
+
+// queryCredentialRecords looks the records up by the issuer or type tag if filtered on, the other filters are
+// then applied to the tagged records.
+func (o *Command) queryCredentialRecords(request *QueryCredentialsArgs, now time.Time) ([]*verifiablestore.Record,
+	error) {
+	var (
+		records []*verifiablestore.Record
+		err     error
+	)
+
+	switch {
+	case request.Issuer != "":
+		records, err = o.verifiableStore.GetCredentialsByTag(verifiablestore.IssuerTag, request.Issuer)
+	case request.Type != "":
+		records, err = o.verifiableStore.GetCredentialsByTag(verifiablestore.TypeTag, request.Type)
+	default:
+		records, err = o.verifiableStore.GetCredentials()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []*verifiablestore.Record
+
+	for _, r := range records {
+		if request.Type != "" && !containsAll(r.Type, []string{request.Type}) {
+			continue
+		}
+
+		expired := r.Expires != nil && !r.Expires.After(now)
+		if request.Expired != nil && *request.Expired != expired {
+			continue
+		}
+
+		matching = append(matching, r)
+	}
+
+	return matching, nil
+}
+
 // GetPresentations retrieves the verifiable presentation records containing name and fields of interest.
 func (o *Command) GetPresentations(rw io.Writer, req io.Reader) command.Error {
 	vpRecords, err := o.verifiableStore.GetPresentations()
@@ -762,18 +1076,25 @@ func (o *Command) addLinkedDataProof(p provable, opts *ProofOptions) error {
 
 	var signatureSuite verifiablesigner.SignatureSuite
 
+	signatureRepresentation := verifiable.SignatureJWS
+
 	switch opts.SignatureType {
 	case Ed25519Signature2018:
 		signatureSuite = ed25519signature2018.New(suite.WithSigner(s))
 	case JSONWebSignature2020:
 		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(s))
+	case BbsBlsSignature2020:
+		// BBS+ signatures sign the N-Quads statements of the document, they are not JWS
+		s.bbs = true
+		signatureSuite = bbsblssignature2020.New(suite.WithSigner(s))
+		signatureRepresentation = verifiable.SignatureProofValue
 	default:
 		return fmt.Errorf("signature type unsupported %s", opts.SignatureType)
 	}
 
 	signingCtx := &verifiable.LinkedDataProofContext{
 		VerificationMethod:      opts.VerificationMethod,
-		SignatureRepresentation: verifiable.SignatureJWS,
+		SignatureRepresentation: signatureRepresentation,
 		SignatureType:           opts.SignatureType,
 		Suite:                   signatureSuite,
 		Created:                 opts.Created,
@@ -814,6 +1135,29 @@ func (o *Command) parseVerifiableCredentials(request *PresentationRequest,
 		vcs = append(vcs, vc)
 	}
 
+	// stored credentials were parsed when saved
+	for _, id := range request.CredentialIDs {
+		vc, e := o.verifiableStore.GetCredential(id)
+		if e != nil {
+			logutil.LogError(logger, CommandName, GeneratePresentationCommandMethod,
+				"failed to get stored credential: "+e.Error(), logutil.CreateKeyValueString(vcID, id))
+			return nil, nil, nil, fmt.Errorf("get stored credential failed: %w", e)
+		}
+
+		vcs = append(vcs, vc)
+	}
+
+	if request.Query != nil {
+		queried, e := o.queryCredentials(request.Query)
+		if e != nil {
+			logutil.LogError(logger, CommandName, GeneratePresentationCommandMethod,
+				"failed to query stored credentials: "+e.Error())
+			return nil, nil, nil, fmt.Errorf("query stored credentials failed: %w", e)
+		}
+
+		vcs = append(vcs, queried...)
+	}
+
 	opts, err := prepareOpts(request.ProofOptions, didDoc, did.Authentication)
 	if err != nil {
 		logutil.LogError(logger, CommandName, GeneratePresentationCommandMethod,
@@ -824,6 +1168,74 @@ func (o *Command) parseVerifiableCredentials(request *PresentationRequest,
 	return vcs, nil, opts, nil
 }
 
+// queryCredentials returns the stored credentials having all of the query types and one of its schemas if any.
+func (o *Command) queryCredentials(query *CredentialQuery) ([]interface{}, error) {
+	if len(query.Types) == 0 && len(query.Schemas) == 0 {
+		return nil, errors.New("credential query has no types nor schemas")
+	}
+
+	records, err := o.verifiableStore.GetCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("get credential records: %w", err)
+	}
+
+	var vcs []interface{}
+
+	for _, r := range records {
+		if !containsAll(r.Type, query.Types) {
+			continue
+		}
+
+		vc, err := o.verifiableStore.GetCredential(r.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get stored credential %s: %w", r.ID, err)
+		}
+
+		if len(query.Schemas) > 0 && !hasSchema(vc, query.Schemas) {
+			continue
+		}
+
+		vcs = append(vcs, vc)
+	}
+
+	if len(vcs) == 0 {
+		return nil, errors.New("no stored credential matches the query")
+	}
+
+	return vcs, nil
+}
+
+func containsAll(values, required []string) bool {
+	for _, r := range required {
+		found := false
+
+		for _, v := range values {
+			if v == r {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasSchema(vc *verifiable.Credential, schemas []string) bool {
+	for _, schema := range vc.Schemas {
+		for _, id := range schemas {
+			if schema.ID == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (o *Command) parsePresentation(request *PresentationRequest,
 	didDoc *did.Doc) ([]interface{}, *verifiable.Presentation, *ProofOptions, error) {
 	presentation, err := verifiable.ParseUnverifiedPresentation(request.Presentation)
@@ -849,7 +1261,7 @@ func (o *Command) parsePresentationRequest(request *PresentationRequest,
 		return nil, nil, nil, fmt.Errorf("invalid request, signature type empty")
 	}
 
-	if len(request.VerifiableCredentials) > 0 {
+	if len(request.VerifiableCredentials) > 0 || len(request.CredentialIDs) > 0 || request.Query != nil {
 		return o.parseVerifiableCredentials(request, didDoc)
 	}
 
@@ -954,6 +1366,219 @@ func (o *Command) addCredentialProof(vc *verifiable.Credential, didDoc *did.Doc,
 	return o.addLinkedDataProof(vc, opts)
 }
 
+// verifyCredential checks the proof of the credential vcStr and, if checkStatus is set, its revocation status.
+// JWT credentials are signed by their issuer by construction, the embedded proofs of JSON-LD credentials must be
+// made with a key of their issuer.
+func (o *Command) verifyCredential(vcStr string, checkStatus bool) error {
+	vc, err := verifiable.ParseCredential([]byte(vcStr), verifiable.WithPublicKeyFetcher(o.kResolver.PublicKeyFetcher()))
+	if err != nil {
+		return fmt.Errorf("parse vc : %w", err)
+	}
+
+	if jwt.IsJWS(vcStr) {
+		return o.checkStatus(vc, checkStatus)
+	}
+
+	if len(vc.Proofs) == 0 {
+		return errors.New("vc has no proof")
+	}
+
+	for _, p := range vc.Proofs {
+		ldProof, err := proof.NewProof(p)
+		if err != nil {
+			return fmt.Errorf("parse vc proof : %w", err)
+		}
+
+		keyID, err := ldProof.PublicKeyID()
+		if err != nil {
+			return fmt.Errorf("parse vc proof : %w", err)
+		}
+
+		if strings.Split(keyID, "#")[0] != vc.Issuer.ID {
+			return fmt.Errorf("vc proof key %s doesn't belong to issuer %s", keyID, vc.Issuer.ID)
+		}
+	}
+
+	return o.checkStatus(vc, checkStatus)
+}
+
+func (o *Command) checkStatus(vc *verifiable.Credential, checkStatus bool) error {
+	if !checkStatus {
+		return nil
+	}
+
+	revoked, err := o.statusChecker.Revoked(vc)
+	if err != nil {
+		return fmt.Errorf("check vc status : %w", err)
+	}
+
+	if revoked {
+		return errors.New("vc is revoked")
+	}
+
+	return nil
+}
+
+// verifyPresentation checks the proof of the presentation vpStr. JWT presentations are signed by their holder by
+// construction, the embedded proofs of JSON-LD presentations must be made with a key of their holder.
+func (o *Command) verifyPresentation(vpStr string, request *VerifyPresentationRequest) (*verifiable.Presentation,
+	error) {
+	vp, err := verifiable.ParsePresentation([]byte(vpStr),
+		verifiable.WithPresPublicKeyFetcher(o.kResolver.PublicKeyFetcher()))
+	if err != nil {
+		return nil, fmt.Errorf("parse vp : %w", err)
+	}
+
+	if vp.Holder == "" {
+		return nil, errors.New("vp has no holder")
+	}
+
+	if request.Holder != "" && vp.Holder != request.Holder {
+		return nil, fmt.Errorf("vp holder %s is not the expected holder %s", vp.Holder, request.Holder)
+	}
+
+	if jwt.IsJWS(vpStr) {
+		if err := verifyPresentationJWTClaims(vpStr, request); err != nil {
+			return nil, err
+		}
+
+		return vp, nil
+	}
+
+	if len(vp.Proofs) == 0 {
+		return nil, errors.New("vp has no proof")
+	}
+
+	for _, p := range vp.Proofs {
+		ldProof, err := proof.NewProof(p)
+		if err != nil {
+			return nil, fmt.Errorf("parse vp proof : %w", err)
+		}
+
+		keyID, err := ldProof.PublicKeyID()
+		if err != nil {
+			return nil, fmt.Errorf("parse vp proof : %w", err)
+		}
+
+		if strings.Split(keyID, "#")[0] != vp.Holder {
+			return nil, fmt.Errorf("vp proof key %s doesn't belong to holder %s", keyID, vp.Holder)
+		}
+
+		if request.Challenge != "" && ldProof.Challenge != request.Challenge {
+			return nil, fmt.Errorf("vp proof challenge '%s' doesn't match the expected challenge", ldProof.Challenge)
+		}
+
+		if request.Domain != "" && ldProof.Domain != request.Domain {
+			return nil, fmt.Errorf("vp proof domain '%s' doesn't match the expected domain", ldProof.Domain)
+		}
+	}
+
+	return vp, nil
+}
+
+// presentationString returns the presentation document, or JWT, of the presentation field of a request. JWT
+// presentations are passed as JSON strings.
+func presentationString(vpBytes json.RawMessage) string {
+	var vpJWT string
+	if json.Unmarshal(vpBytes, &vpJWT) == nil {
+		return vpJWT
+	}
+
+	return string(vpBytes)
+}
+
+// verifyPresentationJWTClaims checks the challenge and domain claims of the presentation JWT verified beforehand.
+func verifyPresentationJWTClaims(vpJWT string, request *VerifyPresentationRequest) error {
+	token, err := jwt.Parse(vpJWT, jwt.WithSignatureVerifier(&noVerifier{}))
+	if err != nil {
+		return fmt.Errorf("parse vp jwt : %w", err)
+	}
+
+	claims := struct {
+		*jwt.Claims
+		Nonce string `json:"nonce,omitempty"`
+	}{}
+
+	err = token.DecodeClaims(&claims)
+	if err != nil {
+		return fmt.Errorf("decode vp jwt claims : %w", err)
+	}
+
+	if request.Challenge != "" && claims.Nonce != request.Challenge {
+		return fmt.Errorf("vp jwt nonce '%s' doesn't match the expected challenge", claims.Nonce)
+	}
+
+	if request.Domain != "" && (claims.Claims == nil || !claims.Audience.Contains(request.Domain)) {
+		return errors.New("vp jwt audience doesn't contain the expected domain")
+	}
+
+	return nil
+}
+
+// presentationCredentials returns the credentials embedded in the presentation vpStr, as they were embedded.
+func presentationCredentials(vpStr string) ([]string, error) {
+	var raw struct {
+		Credentials json.RawMessage `json:"verifiableCredential,omitempty"`
+	}
+
+	if jwt.IsJWS(vpStr) {
+		token, err := jwt.Parse(vpStr, jwt.WithSignatureVerifier(&noVerifier{}))
+		if err != nil {
+			return nil, fmt.Errorf("parse vp jwt : %w", err)
+		}
+
+		claims := struct {
+			Presentation *struct {
+				Credentials json.RawMessage `json:"verifiableCredential,omitempty"`
+			} `json:"vp,omitempty"`
+		}{}
+
+		if err = token.DecodeClaims(&claims); err != nil {
+			return nil, fmt.Errorf("decode vp jwt claims : %w", err)
+		}
+
+		if claims.Presentation != nil {
+			raw.Credentials = claims.Presentation.Credentials
+		}
+	} else if err := json.Unmarshal([]byte(vpStr), &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal vp : %w", err)
+	}
+
+	if len(raw.Credentials) == 0 {
+		return nil, nil
+	}
+
+	var credentials []json.RawMessage
+
+	// a single credential may be embedded without an array
+	if err := json.Unmarshal(raw.Credentials, &credentials); err != nil {
+		credentials = []json.RawMessage{raw.Credentials}
+	}
+
+	result := make([]string, len(credentials))
+
+	for i, vc := range credentials {
+		// JWT credentials are embedded as JSON strings
+		var vcJWT string
+		if json.Unmarshal(vc, &vcJWT) == nil {
+			result[i] = vcJWT
+
+			continue
+		}
+
+		result[i] = string(vc)
+	}
+
+	return result, nil
+}
+
+// noVerifier skips the verification of JWTs, to read the claims of JWTs verified beforehand.
+type noVerifier struct{}
+
+func (v *noVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
+	return nil
+}
+
 func isDID(str string) bool {
 	return strings.HasPrefix(str, "did:")
 }
@@ -8,23 +8,42 @@ package verifiable
 
 import (
 	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/credentialstatus"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	cryptomock "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	kmsmock "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
 	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
@@ -317,7 +336,7 @@ func TestNew(t *testing.T) {
 		require.NoError(t, err)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 13, len(handlers))
+		require.Equal(t, 20, len(handlers))
 	})
 
 	t.Run("test new command - vc store error", func(t *testing.T) {
@@ -658,6 +677,220 @@ func TestGetCredentials(t *testing.T) {
 	})
 }
 
+func TestGetCredentialsByTag(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	// credentials only using the base context, loaded without network access
+	saveVC := func(t *testing.T, name, id, issuer, vcType string) command.Error {
+		vcReqBytes, err := json.Marshal(CredentialExt{
+			Credential: Credential{VerifiableCredential: fmt.Sprintf(`{
+				"@context": ["https://www.w3.org/2018/credentials/v1"],
+				"id": "%s",
+				"type": ["VerifiableCredential", "%s"],
+				"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+				"issuer": "%s",
+				"issuanceDate": "2020-01-01T10:54:01Z"
+			}`, id, vcType, issuer)},
+			Name: name,
+		})
+		require.NoError(t, err)
+
+		return cmd.SaveCredential(&bytes.Buffer{}, bytes.NewBuffer(vcReqBytes))
+	}
+
+	getByTag := func(t *testing.T, tag, value string) []string {
+		reqBytes, err := json.Marshal(TagArg{Tag: tag, Value: value})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.GetCredentialsByTag(&b, bytes.NewBuffer(reqBytes)))
+
+		var response RecordResult
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		var names []string
+		for _, r := range response.Result {
+			names = append(names, r.Name)
+		}
+
+		return names
+	}
+
+	require.NoError(t, saveVC(t, "vc1", "http://example.edu/credentials/1", "did:example:issuer1", "DegreeCredential"))
+	require.NoError(t, saveVC(t, "vc2", "http://example.edu/credentials/2", "did:example:issuer2", "DegreeCredential"))
+	require.NoError(t, saveVC(t, "vc3", "http://example.edu/credentials/3", "did:example:issuer2", "IDCredential"))
+
+	t.Run("test save and get vc", func(t *testing.T) {
+		reqBytes, err := json.Marshal(IDArg{ID: "http://example.edu/credentials/2"})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.GetCredential(&b, bytes.NewBuffer(reqBytes)))
+
+		var response Credential
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		vc, err := verifiable.ParseUnverifiedCredential([]byte(response.VerifiableCredential))
+		require.NoError(t, err)
+		require.Equal(t, "did:example:issuer2", vc.Issuer.ID)
+	})
+
+	t.Run("test get vc - not found", func(t *testing.T) {
+		reqBytes, err := json.Marshal(IDArg{ID: "http://example.edu/credentials/4"})
+		require.NoError(t, err)
+
+		cmdErr := cmd.GetCredential(&bytes.Buffer{}, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetCredentialErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), storage.ErrDataNotFound.Error())
+	})
+
+	t.Run("test save vc - duplicate id", func(t *testing.T) {
+		cmdErr := saveVC(t, "vc4", "http://example.edu/credentials/1", "did:example:issuer1", "DegreeCredential")
+		require.Error(t, cmdErr)
+		require.Equal(t, SaveCredentialErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "credential id already exists")
+	})
+
+	t.Run("test get credentials by tag", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"vc2", "vc3"}, getByTag(t, verifiablestore.IssuerTag, "did:example:issuer2"))
+		require.ElementsMatch(t, []string{"vc1", "vc2"}, getByTag(t, verifiablestore.TypeTag, "DegreeCredential"))
+		require.ElementsMatch(t, []string{"vc1", "vc2", "vc3"},
+			getByTag(t, verifiablestore.SubjectTag, "did:example:ebfeb1f712ebc6f1c276e12ec21"))
+		require.Empty(t, getByTag(t, verifiablestore.IssuerTag, "did:example:issuer3"))
+	})
+
+	t.Run("test get credentials by tag - invalid request", func(t *testing.T) {
+		cmdErr := cmd.GetCredentialsByTag(&bytes.Buffer{}, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.GetCredentialsByTag(&bytes.Buffer{}, bytes.NewBufferString(`{"value":"did:example:issuer1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyTag)
+	})
+
+	t.Run("test get credentials by tag - unsupported tag", func(t *testing.T) {
+		cmdErr := cmd.GetCredentialsByTag(&bytes.Buffer{}, bytes.NewBufferString(`{"tag":"name","value":"vc1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetCredentialsByTagErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "unsupported credential tag")
+	})
+}
+
+func TestQueryCredentials(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	saveVC := func(t *testing.T, name, issuer, vcType, expirationDate string) {
+		t.Helper()
+
+		if expirationDate != "" {
+			expirationDate = fmt.Sprintf(`, "expirationDate": "%s"`, expirationDate)
+		}
+
+		vcReqBytes, err := json.Marshal(CredentialExt{
+			Credential: Credential{VerifiableCredential: fmt.Sprintf(`{
+				"@context": ["https://www.w3.org/2018/credentials/v1"],
+				"id": "http://example.edu/credentials/%s",
+				"type": ["VerifiableCredential", "%s"],
+				"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+				"issuer": "%s",
+				"issuanceDate": "2010-01-01T10:54:01Z"%s
+			}`, name, vcType, issuer, expirationDate)},
+			Name: name,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, cmd.SaveCredential(&bytes.Buffer{}, bytes.NewBuffer(vcReqBytes)))
+	}
+
+	query := func(t *testing.T, request string) []string {
+		t.Helper()
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.QueryCredentials(&b, bytes.NewBufferString(request)))
+
+		var response RecordResult
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		var names []string
+		for _, r := range response.Result {
+			names = append(names, r.Name)
+		}
+
+		return names
+	}
+
+	saveVC(t, "vc1", "did:example:issuer1", "DegreeCredential", "2015-01-01T00:00:00Z")
+	saveVC(t, "vc2", "did:example:issuer1", "DegreeCredential", "2999-01-01T00:00:00Z")
+	saveVC(t, "vc3", "did:example:issuer1", "IDCredential", "")
+	saveVC(t, "vc4", "did:example:issuer2", "DegreeCredential", "2016-06-01T00:00:00Z")
+	saveVC(t, "vc5", "did:example:issuer2", "IDCredential", "2999-06-01T00:00:00Z")
+
+	for _, tc := range []struct {
+		request string
+		names   []string
+	}{
+		{`{}`, []string{"vc1", "vc2", "vc3", "vc4", "vc5"}},
+		{`{"issuer":"did:example:issuer1"}`, []string{"vc1", "vc2", "vc3"}},
+		{`{"issuer":"did:example:issuer3"}`, nil},
+		{`{"type":"DegreeCredential"}`, []string{"vc1", "vc2", "vc4"}},
+		{`{"expired":true}`, []string{"vc1", "vc4"}},
+		{`{"expired":false}`, []string{"vc2", "vc3", "vc5"}},
+		{`{"issuer":"did:example:issuer1","type":"DegreeCredential"}`, []string{"vc1", "vc2"}},
+		{`{"issuer":"did:example:issuer2","expired":false}`, []string{"vc5"}},
+		{`{"type":"IDCredential","expired":true}`, nil},
+		{`{"issuer":"did:example:issuer2","type":"DegreeCredential","expired":true}`, []string{"vc4"}},
+	} {
+		require.ElementsMatch(t, tc.names, query(t, tc.request), tc.request)
+	}
+
+	t.Run("records hold the expiration date", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, cmd.QueryCredentials(&b, bytes.NewBufferString(`{"type":"IDCredential"}`)))
+
+		var response RecordResult
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Len(t, response.Result, 2)
+
+		for _, r := range response.Result {
+			if r.Name == "vc3" {
+				require.Nil(t, r.Expires)
+			} else {
+				require.Equal(t, time.Date(2999, time.June, 1, 0, 0, 0, 0, time.UTC), r.Expires.UTC())
+			}
+		}
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		cmdErr := cmd.QueryCredentials(&bytes.Buffer{}, bytes.NewBufferString(`{"expired":"yes"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+				Store: map[string][]byte{"vcname_vc1": []byte("{")},
+			}),
+		})
+		require.NoError(t, err)
+
+		cmdErr := cmd.QueryCredentials(&bytes.Buffer{}, bytes.NewBufferString(`{"issuer":"did:example:issuer1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, QueryCredentialsErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "query credential records")
+	})
+}
+
 func TestGeneratePresentation(t *testing.T) {
 	s := make(map[string][]byte)
 	cmd, cmdErr := New(&mockprovider.Provider{
@@ -1068,6 +1301,201 @@ func TestGeneratePresentation(t *testing.T) {
 	})
 }
 
+func TestGeneratePresentation_StoredCredentials(t *testing.T) {
+	const holderDID = "did:example:holder"
+
+	keyManager, err := localkms.New("local-lock://custom/master/key/",
+		kmsmock.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	keyID, pubKey, err := keyManager.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	holderKey := did.NewPublicKeyFromBytes(holderDID+"#"+keyID, "Ed25519VerificationKey2018", holderDID, pubKey)
+	holderDoc := did.BuildDoc(
+		did.WithPublicKey([]did.PublicKey{*holderKey}),
+		did.WithAuthentication([]did.VerificationMethod{
+			*did.NewReferencedVerificationMethod(holderKey, did.Authentication, false),
+		}),
+	)
+	holderDoc.ID = holderDID
+
+	vdriRegistry := &mockvdri.MockVDRIRegistry{
+		ResolveFunc: func(didID string, opts ...vdri.ResolveOpts) (*did.Doc, error) {
+			if didID != holderDID {
+				return nil, vdri.ErrNotFound
+			}
+
+			return holderDoc, nil
+		},
+	}
+
+	provider := &mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRIRegistryValue:    vdriRegistry,
+		KMSValue:             keyManager,
+		CryptoValue:          tinkCrypto,
+	}
+
+	vcStore, err := verifiablestore.New(provider)
+	require.NoError(t, err)
+
+	credentialIDs := []string{"http://example.edu/credentials/1", "http://example.edu/credentials/2"}
+
+	for i, id := range credentialIDs {
+		require.NoError(t, vcStore.SaveCredential(fmt.Sprintf("vc%d", i), &verifiable.Credential{
+			Context: []string{"https://www.w3.org/2018/credentials/v1"},
+			ID:      id,
+			Types:   []string{"VerifiableCredential"},
+			Subject: holderDID,
+			Issuer:  verifiable.Issuer{ID: "did:example:issuer"},
+			Issued:  util.NewTime(time.Date(2020, 1, 1, 10, 54, 1, 0, time.UTC)),
+		}))
+	}
+
+	degreeIDs := []string{"http://example.edu/credentials/degree/1", "http://example.edu/credentials/degree/2"}
+
+	for i, id := range degreeIDs {
+		require.NoError(t, vcStore.SaveCredential(fmt.Sprintf("degree%d", i), &verifiable.Credential{
+			Context: []string{"https://www.w3.org/2018/credentials/v1", "https://www.w3.org/2018/credentials/examples/v1"},
+			ID:      id,
+			Types:   []string{"VerifiableCredential", "UniversityDegreeCredential"},
+			Schemas: []verifiable.TypedID{{
+				ID:   fmt.Sprintf("https://example.edu/schemas/degree/%d", i),
+				Type: "JsonSchemaValidator2018",
+			}},
+			Subject: holderDID,
+			Issuer:  verifiable.Issuer{ID: "did:example:issuer"},
+			Issued:  util.NewTime(time.Date(2020, 1, 1, 10, 54, 1, 0, time.UTC)),
+		}))
+	}
+
+	cmd, err := New(provider)
+	require.NoError(t, err)
+
+	queryPresentation := func(t *testing.T, query *CredentialQuery) []string {
+		reqBytes, err := json.Marshal(&PresentationRequest{
+			Query:        query,
+			DID:          holderDID,
+			ProofOptions: &ProofOptions{SignatureType: Ed25519Signature2018},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.GeneratePresentation(&b, bytes.NewBuffer(reqBytes)))
+
+		var response Presentation
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		vp, err := verifiable.ParsePresentation(response.VerifiablePresentation,
+			verifiable.WithPresPublicKeyFetcher(verifiable.NewDIDKeyResolver(vdriRegistry).PublicKeyFetcher()))
+		require.NoError(t, err)
+
+		var ids []string
+
+		for _, vcRaw := range vp.Credentials() {
+			ids = append(ids, vcRaw.(map[string]interface{})["id"].(string))
+		}
+
+		return ids
+	}
+
+	t.Run("test generate presentation from queried credentials - by type", func(t *testing.T) {
+		ids := queryPresentation(t, &CredentialQuery{Types: []string{"UniversityDegreeCredential"}})
+		require.ElementsMatch(t, degreeIDs, ids)
+	})
+
+	t.Run("test generate presentation from queried credentials - by type and schema", func(t *testing.T) {
+		ids := queryPresentation(t, &CredentialQuery{
+			Types:   []string{"VerifiableCredential"},
+			Schemas: []string{"https://example.edu/schemas/degree/1"},
+		})
+		require.Equal(t, degreeIDs[1:], ids)
+	})
+
+	t.Run("test generate presentation from queried credentials - no match", func(t *testing.T) {
+		for _, query := range []*CredentialQuery{
+			{Types: []string{"DriverLicenseCredential"}},
+			{Types: []string{"UniversityDegreeCredential"}, Schemas: []string{"https://example.edu/schemas/other"}},
+			{},
+		} {
+			reqBytes, err := json.Marshal(&PresentationRequest{
+				Query:        query,
+				DID:          holderDID,
+				ProofOptions: &ProofOptions{SignatureType: Ed25519Signature2018},
+			})
+			require.NoError(t, err)
+
+			cmdErr := cmd.GeneratePresentation(&bytes.Buffer{}, bytes.NewBuffer(reqBytes))
+			require.Error(t, cmdErr)
+			require.Equal(t, GeneratePresentationErrorCode, cmdErr.Code())
+			require.Contains(t, cmdErr.Error(), "query stored credentials failed")
+		}
+	})
+
+	t.Run("test generate presentation from stored credentials - success", func(t *testing.T) {
+		reqBytes, err := json.Marshal(&PresentationRequest{
+			CredentialIDs: credentialIDs,
+			DID:           holderDID,
+			ProofOptions: &ProofOptions{
+				SignatureType: Ed25519Signature2018,
+				Domain:        "issuer.example.com",
+				Challenge:     "sample-challenge",
+			},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.GeneratePresentation(&b, bytes.NewBuffer(reqBytes)))
+
+		var response Presentation
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		// the proof is verified with the holder key
+		vp, err := verifiable.ParsePresentation(response.VerifiablePresentation,
+			verifiable.WithPresPublicKeyFetcher(verifiable.NewDIDKeyResolver(vdriRegistry).PublicKeyFetcher()))
+		require.NoError(t, err)
+		require.Equal(t, holderDID, vp.Holder)
+
+		var ids []string
+
+		for _, vcRaw := range vp.Credentials() {
+			ids = append(ids, vcRaw.(map[string]interface{})["id"].(string))
+		}
+
+		require.Equal(t, credentialIDs, ids)
+
+		require.Len(t, vp.Proofs, 1)
+		require.Equal(t, "sample-challenge", vp.Proofs[0]["challenge"])
+		require.Equal(t, "issuer.example.com", vp.Proofs[0]["domain"])
+		require.Equal(t, holderKey.ID, vp.Proofs[0]["verificationMethod"])
+
+		// tampering with the challenge invalidates the proof
+		tampered := bytes.Replace(response.VerifiablePresentation, []byte("sample-challenge"), []byte("other-challenge"), 1)
+
+		_, err = verifiable.ParsePresentation(tampered,
+			verifiable.WithPresPublicKeyFetcher(verifiable.NewDIDKeyResolver(vdriRegistry).PublicKeyFetcher()))
+		require.Error(t, err)
+	})
+
+	t.Run("test generate presentation from stored credentials - credential not found", func(t *testing.T) {
+		reqBytes, err := json.Marshal(&PresentationRequest{
+			CredentialIDs: []string{"http://example.edu/credentials/3"},
+			DID:           holderDID,
+			ProofOptions:  &ProofOptions{SignatureType: Ed25519Signature2018},
+		})
+		require.NoError(t, err)
+
+		cmdErr := cmd.GeneratePresentation(&bytes.Buffer{}, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, GeneratePresentationErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "get stored credential failed")
+	})
+}
+
 func TestGeneratePresentationByID(t *testing.T) {
 	s := make(map[string][]byte)
 	cmd, cmdErr := New(&mockprovider.Provider{
@@ -2321,3 +2749,697 @@ func TestCommand_RemoveVPByName(t *testing.T) {
 		require.Contains(t, err.Error(), "remove vp by name")
 	})
 }
+
+func TestCommand_VerifyCredential(t *testing.T) {
+	const issuerDID = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+	pubKey, privKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	issuerKey := did.NewPublicKeyFromBytes(issuerDID+"#key-1", "Ed25519VerificationKey2018", issuerDID, pubKey)
+	issuerDoc := did.BuildDoc(
+		did.WithPublicKey([]did.PublicKey{*issuerKey}),
+		did.WithAssertion([]did.VerificationMethod{
+			*did.NewReferencedVerificationMethod(issuerKey, did.AssertionMethod, false),
+		}),
+	)
+	issuerDoc.ID = issuerDID
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+			ResolveFunc: func(didID string, opts ...vdri.ResolveOpts) (*did.Doc, error) {
+				if didID != issuerDID {
+					return nil, vdri.ErrNotFound
+				}
+
+				return issuerDoc, nil
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	signer := signature.GetEd25519Signer(privKey, pubKey)
+
+	newCredential := func(issuer string) *verifiable.Credential {
+		return &verifiable.Credential{
+			Context: []string{"https://www.w3.org/2018/credentials/v1"},
+			ID:      sampleVCID,
+			Types:   []string{"VerifiableCredential"},
+			Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			Issuer:  verifiable.Issuer{ID: issuer},
+			Issued:  util.NewTime(time.Date(2020, 1, 1, 10, 54, 1, 0, time.UTC)),
+		}
+	}
+
+	signLDP := func(t *testing.T, vc *verifiable.Credential, keyID string) string {
+		err := vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+			SignatureType:           Ed25519Signature2018,
+			SignatureRepresentation: verifiable.SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      keyID,
+		})
+		require.NoError(t, err)
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		return string(vcBytes)
+	}
+
+	signJWT := func(t *testing.T, vc *verifiable.Credential) string {
+		claims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWT, err := claims.MarshalJWS(verifiable.EdDSA, signer, issuerKey.ID)
+		require.NoError(t, err)
+
+		return vcJWT
+	}
+
+	verify := func(t *testing.T, vcStr string) *VerifyCredentialResponse {
+		reqBytes, err := json.Marshal(&Credential{VerifiableCredential: vcStr})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.VerifyCredential(&b, bytes.NewBuffer(reqBytes)))
+
+		response := &VerifyCredentialResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(response))
+
+		return response
+	}
+
+	t.Run("test verify credential - success (Ed25519Signature2018)", func(t *testing.T) {
+		result := verify(t, signLDP(t, newCredential(issuerDID), issuerKey.ID))
+		require.Equal(t, &VerifyCredentialResponse{Verified: true}, result)
+	})
+
+	t.Run("test verify credential - success (JWT)", func(t *testing.T) {
+		result := verify(t, signJWT(t, newCredential(issuerDID)))
+		require.Equal(t, &VerifyCredentialResponse{Verified: true}, result)
+	})
+
+	t.Run("test verify credential - tampered credential", func(t *testing.T) {
+		tampered := strings.Replace(signLDP(t, newCredential(issuerDID), issuerKey.ID),
+			"2020-01-01T10:54:01Z", "2021-01-01T10:54:01Z", 1)
+
+		result := verify(t, tampered)
+		require.False(t, result.Verified)
+		require.Contains(t, result.Reason, "check embedded proof")
+
+		parts := strings.Split(signJWT(t, newCredential(issuerDID)), ".")
+		tamperedClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+
+		parts[1] = base64.RawURLEncoding.EncodeToString(
+			bytes.Replace(tamperedClaims, []byte(sampleVCID), []byte("http://example.edu/credentials/1990"), 1))
+
+		result = verify(t, strings.Join(parts, "."))
+		require.False(t, result.Verified)
+		require.Contains(t, result.Reason, "JWS decoding")
+	})
+
+	t.Run("test verify credential - unresolvable issuer", func(t *testing.T) {
+		const unknownDID = "did:example:unknown"
+
+		result := verify(t, signLDP(t, newCredential(unknownDID), unknownDID+"#key-1"))
+		require.False(t, result.Verified)
+		require.Contains(t, result.Reason, "resolve DID "+unknownDID)
+
+		result = verify(t, signJWT(t, newCredential(unknownDID)))
+		require.False(t, result.Verified)
+		require.Contains(t, result.Reason, "resolve DID "+unknownDID)
+	})
+
+	t.Run("test verify credential - not signed by issuer", func(t *testing.T) {
+		result := verify(t, signLDP(t, newCredential("did:example:other"), issuerKey.ID))
+		require.False(t, result.Verified)
+		require.Contains(t, result.Reason, "doesn't belong to issuer did:example:other")
+	})
+
+	t.Run("test verify credential - no proof", func(t *testing.T) {
+		vcBytes, err := newCredential(issuerDID).MarshalJSON()
+		require.NoError(t, err)
+
+		result := verify(t, string(vcBytes))
+		require.Equal(t, &VerifyCredentialResponse{Reason: "vc has no proof"}, result)
+	})
+
+	t.Run("test verify credential - revocation status", func(t *testing.T) {
+		const revokedIndex = 42
+
+		bitstring := make([]byte, 16*1024)
+		bitstring[revokedIndex/8] |= 1 << (7 - revokedIndex%8)
+
+		var compressed bytes.Buffer
+
+		w := gzip.NewWriter(&compressed)
+		_, err := w.Write(bitstring)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		var statusList string
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			_, err := rw.Write([]byte(statusList))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		statusListVC := newCredential(issuerDID)
+		statusListVC.ID = server.URL
+		statusListVC.Types = []string{"VerifiableCredential", "StatusList2021Credential"}
+		statusListVC.Subject = map[string]interface{}{
+			"id":            server.URL + "#list",
+			"type":          "StatusList2021",
+			"statusPurpose": "revocation",
+			"encodedList":   base64.RawURLEncoding.EncodeToString(compressed.Bytes()),
+		}
+		statusList = signJWT(t, statusListVC)
+
+		newStatusCredential := func(index int) *verifiable.Credential {
+			vc := newCredential(issuerDID)
+			vc.Status = &verifiable.TypedID{
+				ID:   fmt.Sprintf("%s#%d", server.URL, index),
+				Type: credentialstatus.StatusList2021EntryType,
+				CustomFields: verifiable.CustomFields{
+					"statusPurpose":        "revocation",
+					"statusListIndex":      strconv.Itoa(index),
+					"statusListCredential": server.URL,
+				},
+			}
+
+			return vc
+		}
+
+		checkStatus := func(t *testing.T, vcStr string) *VerifyCredentialResponse {
+			reqBytes, err := json.Marshal(&VerifyCredentialRequest{
+				Credential:  Credential{VerifiableCredential: vcStr},
+				CheckStatus: true,
+			})
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			require.NoError(t, cmd.VerifyCredential(&b, bytes.NewBuffer(reqBytes)))
+
+			response := &VerifyCredentialResponse{}
+			require.NoError(t, json.NewDecoder(&b).Decode(response))
+
+			return response
+		}
+
+		// the JSON-LD status contexts aren't available offline, JWT credentials are used
+		revoked := signJWT(t, newStatusCredential(revokedIndex))
+
+		require.Equal(t, &VerifyCredentialResponse{Reason: "vc is revoked"}, checkStatus(t, revoked))
+
+		// the status isn't checked unless requested
+		require.Equal(t, &VerifyCredentialResponse{Verified: true}, verify(t, revoked))
+
+		require.Equal(t, &VerifyCredentialResponse{Verified: true},
+			checkStatus(t, signJWT(t, newStatusCredential(revokedIndex+1))))
+
+		result := checkStatus(t, signLDP(t, newCredential(issuerDID), issuerKey.ID))
+		require.False(t, result.Verified)
+		require.Equal(t, "check vc status : vc has no credentialStatus", result.Reason)
+
+		// the proof of the status list is checked
+		parts := strings.Split(statusList, ".")
+		parts[2] = parts[2][:len(parts[2])-4] + "AAAA"
+		statusList = strings.Join(parts, ".")
+
+		result = checkStatus(t, revoked)
+		require.False(t, result.Verified)
+		require.Contains(t, result.Reason, "parse status list")
+	})
+
+	t.Run("test verify credential - invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.VerifyCredential(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.VerifyCredential(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyCredentialErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyCredential)
+	})
+}
+
+func TestCommand_DeriveCredential(t *testing.T) {
+	const issuerDID = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+	keyManager, err := localkms.New("local-lock://custom/master/key/",
+		kmsmock.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	keyID, pubKey, err := keyManager.CreateAndExportPubKeyBytes(kms.BLS12381G2Type)
+	require.NoError(t, err)
+
+	issuerKey := did.NewPublicKeyFromBytes(issuerDID+"#"+keyID, "Bls12381G2Key2020", issuerDID, pubKey)
+	issuerDoc := did.BuildDoc(
+		did.WithPublicKey([]did.PublicKey{*issuerKey}),
+		did.WithAssertion([]did.VerificationMethod{
+			*did.NewReferencedVerificationMethod(issuerKey, did.AssertionMethod, false),
+		}),
+	)
+	issuerDoc.ID = issuerDID
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+			ResolveFunc: func(didID string, opts ...vdri.ResolveOpts) (*did.Doc, error) {
+				if didID != issuerDID {
+					return nil, vdri.ErrNotFound
+				}
+
+				return issuerDoc, nil
+			},
+		},
+		KMSValue:    keyManager,
+		CryptoValue: tinkCrypto,
+	})
+	require.NoError(t, err)
+
+	reqBytes, err := json.Marshal(&SignCredentialRequest{
+		Credential:   []byte(bbsCredential),
+		DID:          issuerDID,
+		ProofOptions: &ProofOptions{SignatureType: BbsBlsSignature2020},
+	})
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.SignCredential(&b, bytes.NewBuffer(reqBytes)))
+
+	var signResponse SignCredentialResponse
+	require.NoError(t, json.NewDecoder(&b).Decode(&signResponse))
+	require.Contains(t, string(signResponse.VerifiableCredential), BbsBlsSignature2020)
+
+	verify := func(t *testing.T, vcStr string) *VerifyCredentialResponse {
+		reqBytes, err := json.Marshal(&Credential{VerifiableCredential: vcStr})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.VerifyCredential(&b, bytes.NewBuffer(reqBytes)))
+
+		response := &VerifyCredentialResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(response))
+
+		return response
+	}
+
+	result := verify(t, string(signResponse.VerifiableCredential))
+	require.Equal(t, &VerifyCredentialResponse{Verified: true}, result)
+
+	var frame map[string]interface{}
+
+	require.NoError(t, json.Unmarshal([]byte(bbsRevealFrame), &frame))
+
+	t.Run("test derive credential - success", func(t *testing.T) {
+		reqBytes, err := json.Marshal(&DeriveCredentialRequest{
+			Credential: signResponse.VerifiableCredential,
+			Frame:      frame,
+			Nonce:      "nonce",
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.DeriveCredential(&b, bytes.NewBuffer(reqBytes)))
+
+		var response Credential
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+
+		require.Contains(t, response.VerifiableCredential, "BbsBlsSignatureProof2020")
+		require.Contains(t, response.VerifiableCredential, `"givenName":"Jayden"`)
+		require.NotContains(t, response.VerifiableCredential, `"familyName":"Doe"`)
+		require.NotContains(t, response.VerifiableCredential, "1958-07-17")
+
+		result := verify(t, response.VerifiableCredential)
+		require.Equal(t, &VerifyCredentialResponse{Verified: true}, result)
+	})
+
+	t.Run("test derive credential - credential without BBS+ signature", func(t *testing.T) {
+		reqBytes, err := json.Marshal(&DeriveCredentialRequest{
+			Credential: []byte(bbsCredential),
+			Frame:      frame,
+		})
+		require.NoError(t, err)
+
+		cmdErr := cmd.DeriveCredential(&bytes.Buffer{}, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, DeriveCredentialErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "derive vc : derive proof: VC has no proof")
+	})
+
+	t.Run("test derive credential - invalid credential", func(t *testing.T) {
+		tampered := strings.Replace(string(signResponse.VerifiableCredential), "Jayden", "Alice", 1)
+
+		reqBytes, err := json.Marshal(&DeriveCredentialRequest{
+			Credential: []byte(tampered),
+			Frame:      frame,
+		})
+		require.NoError(t, err)
+
+		cmdErr := cmd.DeriveCredential(&bytes.Buffer{}, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, DeriveCredentialErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "parse vc")
+	})
+
+	t.Run("test derive credential - invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.DeriveCredential(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.DeriveCredential(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyCredential)
+
+		cmdErr = cmd.DeriveCredential(&b, bytes.NewBufferString(`{"credential":{}}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyFrame)
+	})
+}
+
+const bbsContext = `
+    "https://www.w3.org/2018/credentials/v1",
+    "https://w3id.org/security/bbs/v1",
+    {
+      "givenName": "http://schema.org/givenName",
+      "familyName": "http://schema.org/familyName",
+      "birthDate": "http://schema.org/birthDate",
+      "PermanentResidentCard": "https://w3id.org/citizenship#PermanentResidentCard",
+      "PermanentResident": "https://w3id.org/citizenship#PermanentResident"
+    }
+`
+
+const bbsCredential = `{
+  "@context": [` + bbsContext + `],
+  "id": "https://issuer.oidp.uscis.gov/credentials/83627465",
+  "type": ["VerifiableCredential", "PermanentResidentCard"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2019-12-03T12:19:52Z",
+  "credentialSubject": {
+    "id": "did:example:b34ca6cd37bbf23",
+    "type": ["PermanentResident"],
+    "givenName": "Jayden",
+    "familyName": "Doe",
+    "birthDate": "1958-07-17"
+  }
+}`
+
+const bbsRevealFrame = `{
+  "@context": [` + bbsContext + `],
+  "type": ["VerifiableCredential", "PermanentResidentCard"],
+  "@explicit": true,
+  "issuer": {},
+  "issuanceDate": {},
+  "credentialSubject": {
+    "type": ["PermanentResident"],
+    "@explicit": true,
+    "givenName": {}
+  }
+}`
+
+func TestCommand_VerifyPresentation(t *testing.T) {
+	const (
+		issuerDID = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+		holderDID = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+	)
+
+	newDoc := func(id string, pubKey ed25519.PublicKey) (*did.Doc, *did.PublicKey) {
+		key := did.NewPublicKeyFromBytes(id+"#key-1", "Ed25519VerificationKey2018", id, pubKey)
+		doc := did.BuildDoc(
+			did.WithPublicKey([]did.PublicKey{*key}),
+			did.WithAssertion([]did.VerificationMethod{
+				*did.NewReferencedVerificationMethod(key, did.AssertionMethod, false),
+			}),
+			did.WithAuthentication([]did.VerificationMethod{
+				*did.NewReferencedVerificationMethod(key, did.Authentication, false),
+			}),
+		)
+		doc.ID = id
+
+		return doc, key
+	}
+
+	issuerPubKey, issuerPrivKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	holderPubKey, holderPrivKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	issuerDoc, issuerKey := newDoc(issuerDID, issuerPubKey)
+	holderDoc, holderKey := newDoc(holderDID, holderPubKey)
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+			ResolveFunc: func(didID string, opts ...vdri.ResolveOpts) (*did.Doc, error) {
+				switch didID {
+				case issuerDID:
+					return issuerDoc, nil
+				case holderDID:
+					return holderDoc, nil
+				}
+
+				return nil, vdri.ErrNotFound
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	issuerSigner := signature.GetEd25519Signer(issuerPrivKey, issuerPubKey)
+	holderSigner := signature.GetEd25519Signer(holderPrivKey, holderPubKey)
+
+	newCredential := func(t *testing.T) *verifiable.Credential {
+		vc := &verifiable.Credential{
+			Context: []string{"https://www.w3.org/2018/credentials/v1"},
+			ID:      sampleVCID,
+			Types:   []string{"VerifiableCredential"},
+			Subject: holderDID,
+			Issuer:  verifiable.Issuer{ID: issuerDID},
+			Issued:  util.NewTime(time.Date(2020, 1, 1, 10, 54, 1, 0, time.UTC)),
+		}
+
+		require.NoError(t, vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+			SignatureType:           Ed25519Signature2018,
+			SignatureRepresentation: verifiable.SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(issuerSigner)),
+			VerificationMethod:      issuerKey.ID,
+		}))
+
+		return vc
+	}
+
+	newPresentation := func(t *testing.T, holder string, vcs ...interface{}) *verifiable.Presentation {
+		vp := &verifiable.Presentation{
+			Context: []string{"https://www.w3.org/2018/credentials/v1"},
+			Type:    []string{"VerifiablePresentation"},
+			Holder:  holder,
+		}
+
+		require.NoError(t, vp.SetCredentials(vcs...))
+
+		return vp
+	}
+
+	signLDP := func(t *testing.T, vp *verifiable.Presentation, keyID, challenge string) json.RawMessage {
+		require.NoError(t, vp.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+			SignatureType:           Ed25519Signature2018,
+			SignatureRepresentation: verifiable.SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(holderSigner)),
+			VerificationMethod:      keyID,
+			Challenge:               challenge,
+			Domain:                  "verifier.example.com",
+			Purpose:                 "authentication",
+		}))
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		return vpBytes
+	}
+
+	signJWT := func(t *testing.T, vp *verifiable.Presentation, nonce string) json.RawMessage {
+		claims, err := vp.JWTClaims([]string{"verifier.example.com"}, false)
+		require.NoError(t, err)
+
+		token, err := jwt.NewSigned(struct {
+			*verifiable.JWTPresClaims
+			Nonce string `json:"nonce"`
+		}{claims, nonce}, jose.Headers{jose.HeaderKeyID: holderKey.ID}, &jwsSigner{signer: holderSigner})
+		require.NoError(t, err)
+
+		vpJWT, err := token.Serialize(false)
+		require.NoError(t, err)
+
+		vpBytes, err := json.Marshal(vpJWT)
+		require.NoError(t, err)
+
+		return vpBytes
+	}
+
+	verify := func(t *testing.T, request *VerifyPresentationRequest) *VerifyPresentationResponse {
+		reqBytes, err := json.Marshal(request)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.VerifyPresentation(&b, bytes.NewBuffer(reqBytes)))
+
+		response := &VerifyPresentationResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(response))
+
+		return response
+	}
+
+	t.Run("test verify presentation - success (Ed25519Signature2018)", func(t *testing.T) {
+		result := verify(t, &VerifyPresentationRequest{
+			VerifiablePresentation: signLDP(t, newPresentation(t, holderDID, newCredential(t)), holderKey.ID, "challenge"),
+			Holder:                 holderDID,
+			Challenge:              "challenge",
+			Domain:                 "verifier.example.com",
+		})
+		require.Equal(t, &VerifyPresentationResponse{
+			Verified:    true,
+			Credentials: []VerifyCredentialResponse{{Verified: true}},
+		}, result)
+	})
+
+	t.Run("test verify presentation - success (JWT)", func(t *testing.T) {
+		vcBytes, err := newCredential(t).MarshalJSON()
+		require.NoError(t, err)
+
+		result := verify(t, &VerifyPresentationRequest{
+			VerifiablePresentation: signJWT(t, newPresentation(t, holderDID, vcBytes), "challenge"),
+			Holder:                 holderDID,
+			Challenge:              "challenge",
+			Domain:                 "verifier.example.com",
+		})
+		require.Equal(t, &VerifyPresentationResponse{
+			Verified:    true,
+			Credentials: []VerifyCredentialResponse{{Verified: true}},
+		}, result)
+	})
+
+	t.Run("test verify presentation - wrong challenge", func(t *testing.T) {
+		result := verify(t, &VerifyPresentationRequest{
+			VerifiablePresentation: signLDP(t, newPresentation(t, holderDID, newCredential(t)), holderKey.ID, "other"),
+			Challenge:              "challenge",
+		})
+		require.False(t, result.Verified)
+		require.Equal(t, "vp proof challenge 'other' doesn't match the expected challenge", result.Reason)
+		require.Equal(t, []VerifyCredentialResponse{{Verified: true}}, result.Credentials)
+
+		result = verify(t, &VerifyPresentationRequest{
+			VerifiablePresentation: signJWT(t, newPresentation(t, holderDID, newCredential(t)), "other"),
+			Challenge:              "challenge",
+		})
+		require.False(t, result.Verified)
+		require.Equal(t, "vp jwt nonce 'other' doesn't match the expected challenge", result.Reason)
+	})
+
+	t.Run("test verify presentation - wrong domain", func(t *testing.T) {
+		result := verify(t, &VerifyPresentationRequest{
+			VerifiablePresentation: signLDP(t, newPresentation(t, holderDID, newCredential(t)), holderKey.ID, ""),
+			Domain:                 "other.example.com",
+		})
+		require.False(t, result.Verified)
+		require.Contains(t, result.Reason, "doesn't match the expected domain")
+
+		result = verify(t, &VerifyPresentationRequest{
+			VerifiablePresentation: signJWT(t, newPresentation(t, holderDID, newCredential(t)), ""),
+			Domain:                 "other.example.com",
+		})
+		require.False(t, result.Verified)
+		require.Equal(t, "vp jwt audience doesn't contain the expected domain", result.Reason)
+	})
+
+	t.Run("test verify presentation - tampered credential", func(t *testing.T) {
+		vcBytes, err := newCredential(t).MarshalJSON()
+		require.NoError(t, err)
+
+		tampered, err := verifiable.ParseUnverifiedCredential(
+			bytes.Replace(vcBytes, []byte("2020-01-01T10:54:01Z"), []byte("2021-01-01T10:54:01Z"), 1))
+		require.NoError(t, err)
+
+		for _, vp := range []json.RawMessage{
+			signLDP(t, newPresentation(t, holderDID, newCredential(t), tampered), holderKey.ID, ""),
+			signJWT(t, newPresentation(t, holderDID, newCredential(t), tampered), ""),
+		} {
+			result := verify(t, &VerifyPresentationRequest{VerifiablePresentation: vp})
+			require.False(t, result.Verified)
+			require.Empty(t, result.Reason)
+			require.Len(t, result.Credentials, 2)
+			require.True(t, result.Credentials[0].Verified)
+			require.False(t, result.Credentials[1].Verified)
+			require.Contains(t, result.Credentials[1].Reason, "check embedded proof")
+		}
+	})
+
+	t.Run("test verify presentation - not bound to the holder", func(t *testing.T) {
+		result := verify(t, &VerifyPresentationRequest{
+			VerifiablePresentation: signLDP(t, newPresentation(t, holderDID, newCredential(t)), holderKey.ID, ""),
+			Holder:                 "did:example:other",
+		})
+		require.False(t, result.Verified)
+		require.Contains(t, result.Reason, "is not the expected holder did:example:other")
+
+		result = verify(t, &VerifyPresentationRequest{
+			VerifiablePresentation: signLDP(t, newPresentation(t, issuerDID, newCredential(t)), holderKey.ID, ""),
+		})
+		require.False(t, result.Verified)
+		require.Contains(t, result.Reason, "doesn't belong to holder "+issuerDID)
+
+		result = verify(t, &VerifyPresentationRequest{
+			VerifiablePresentation: signLDP(t, newPresentation(t, "", newCredential(t)), holderKey.ID, ""),
+		})
+		require.Equal(t, "vp has no holder", result.Reason)
+	})
+
+	t.Run("test verify presentation - no proof", func(t *testing.T) {
+		vpBytes, err := newPresentation(t, holderDID).MarshalJSON()
+		require.NoError(t, err)
+
+		result := verify(t, &VerifyPresentationRequest{VerifiablePresentation: vpBytes})
+		require.Equal(t, &VerifyPresentationResponse{Reason: "vp has no proof"}, result)
+	})
+
+	t.Run("test verify presentation - invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.VerifyPresentation(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.VerifyPresentation(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyPresentationErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), errEmptyPresentation)
+	})
+}
+
+// jwsSigner signs JWTs with EdDSA.
+type jwsSigner struct {
+	signer verifiable.Signer
+}
+
+func (s *jwsSigner) Sign(data []byte) ([]byte, error) {
+	return s.signer.Sign(data)
+}
+
+func (s *jwsSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA"}
+}
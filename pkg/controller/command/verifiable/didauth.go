@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// didAuthStoreName is the store of the challenges of the pending DID authentication requests.
+	didAuthStoreName = "didauth"
+
+	errEmptyDomain    = "domain is mandatory"
+	errEmptyChallenge = "challenge is mandatory"
+
+	// log constants
+	challengeKey = "challenge"
+)
+
+// didAuthChallenge is the pending DID authentication request of a challenge.
+type didAuthChallenge struct {
+	Domain string `json:"domain"`
+}
+
+// CreateDIDAuthRequest creates a DID authentication request: the holder of a DID proves its control by answering
+// with a presentation signed with a key of its DID doc for the challenge and domain (audience) of the request.
+// The challenges are single-use, the challenge of a verified response is dropped.
+func (o *Command) CreateDIDAuthRequest(rw io.Writer, req io.Reader) command.Error {
+	var request DIDAuthRequestArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateDIDAuthRequestCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Domain == "" {
+		logutil.LogDebug(logger, CommandName, CreateDIDAuthRequestCommandMethod, errEmptyDomain)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyDomain))
+	}
+
+	challenge := uuid.New().String()
+
+	challengeBytes, err := json.Marshal(&didAuthChallenge{Domain: request.Domain})
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreateDIDAuthRequestCommandMethod, "marshal challenge : "+err.Error())
+
+		return command.NewExecuteError(CreateDIDAuthRequestErrorCode, fmt.Errorf("marshal challenge : %w", err))
+	}
+
+	err = o.didAuthStore.Put(challenge, challengeBytes)
+	if err != nil {
+		logutil.LogError(logger, CommandName, CreateDIDAuthRequestCommandMethod, "save challenge : "+err.Error())
+
+		return command.NewExecuteError(CreateDIDAuthRequestErrorCode, fmt.Errorf("save challenge : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &DIDAuthRequest{
+		Challenge: challenge,
+		Domain:    request.Domain,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, CreateDIDAuthRequestCommandMethod, "success",
+		logutil.CreateKeyValueString(challengeKey, challenge))
+
+	return nil
+}
+
+// VerifyDIDAuthResponse verifies the response to a DID authentication request created by CreateDIDAuthRequest, the
+// presentation must be signed for the challenge and domain of the request with a key of the DID doc of its holder
+// (resolved). The response is not verified if the presentation or its proof are invalid, the authenticated DID is
+// returned otherwise.
+func (o *Command) VerifyDIDAuthResponse(rw io.Writer, req io.Reader) command.Error {
+	var request DIDAuthResponseArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyDIDAuthResponseCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Challenge == "" {
+		logutil.LogDebug(logger, CommandName, VerifyDIDAuthResponseCommandMethod, errEmptyChallenge)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyChallenge))
+	}
+
+	vpStr := presentationString(request.VerifiablePresentation)
+	if vpStr == "" {
+		logutil.LogDebug(logger, CommandName, VerifyDIDAuthResponseCommandMethod, errEmptyPresentation)
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyPresentation))
+	}
+
+	pending, err := o.getDIDAuthChallenge(request.Challenge)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyDIDAuthResponseCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(challengeKey, request.Challenge))
+
+		return command.NewValidationError(VerifyDIDAuthResponseErrorCode, err)
+	}
+
+	vp, err := o.verifyPresentation(vpStr, &VerifyPresentationRequest{
+		Holder:    request.DID,
+		Challenge: request.Challenge,
+		Domain:    pending.Domain,
+	})
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyDIDAuthResponseCommandMethod, "verify vp : "+err.Error(),
+			logutil.CreateKeyValueString(challengeKey, request.Challenge))
+
+		command.WriteNillableResponse(rw, &DIDAuthResponse{Reason: err.Error()}, logger)
+
+		return nil
+	}
+
+	err = o.didAuthStore.Delete(request.Challenge)
+	if err != nil {
+		logutil.LogError(logger, CommandName, VerifyDIDAuthResponseCommandMethod, "drop challenge : "+err.Error(),
+			logutil.CreateKeyValueString(challengeKey, request.Challenge))
+
+		return command.NewExecuteError(VerifyDIDAuthResponseErrorCode, fmt.Errorf("drop challenge : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, &DIDAuthResponse{Verified: true, DID: vp.Holder}, logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyDIDAuthResponseCommandMethod, "success",
+		logutil.CreateKeyValueString(challengeKey, request.Challenge))
+
+	return nil
+}
+
+func (o *Command) getDIDAuthChallenge(challenge string) (*didAuthChallenge, error) {
+	challengeBytes, err := o.didAuthStore.Get(challenge)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, errors.New("unknown or already answered challenge")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get challenge : %w", err)
+	}
+
+	pending := &didAuthChallenge{}
+
+	err = json.Unmarshal(challengeBytes, pending)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal challenge : %w", err)
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,254 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+)
+
+func TestCommand_DIDAuth(t *testing.T) {
+	const (
+		holderDID = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+		domain    = "rp.example.com"
+	)
+
+	pubKey, privKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	// otherPubKey is not in the DID doc of the holder
+	otherPubKey, otherPrivKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	key := did.NewPublicKeyFromBytes(holderDID+"#key-1", "Ed25519VerificationKey2018", holderDID, pubKey)
+	holderDoc := did.BuildDoc(
+		did.WithPublicKey([]did.PublicKey{*key}),
+		did.WithAuthentication([]did.VerificationMethod{
+			*did.NewReferencedVerificationMethod(key, did.Authentication, false),
+		}),
+	)
+	holderDoc.ID = holderDID
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+			ResolveFunc: func(didID string, opts ...vdri.ResolveOpts) (*did.Doc, error) {
+				if didID == holderDID {
+					return holderDoc, nil
+				}
+
+				return nil, vdri.ErrNotFound
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	createRequest := func(t *testing.T) *DIDAuthRequest {
+		t.Helper()
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.CreateDIDAuthRequest(&b, bytes.NewBufferString(`{"domain":"`+domain+`"}`)))
+
+		request := &DIDAuthRequest{}
+		require.NoError(t, json.NewDecoder(&b).Decode(request))
+		require.NotEmpty(t, request.Challenge)
+		require.Equal(t, domain, request.Domain)
+
+		return request
+	}
+
+	// sign answers the challenge with a presentation signed under the verification method keyID
+	sign := func(t *testing.T, keyID string, signer signature.Signer, challenge string) json.RawMessage {
+		t.Helper()
+
+		vp := &verifiable.Presentation{
+			Context: []string{"https://www.w3.org/2018/credentials/v1"},
+			Type:    []string{"VerifiablePresentation"},
+			Holder:  holderDID,
+		}
+
+		require.NoError(t, vp.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+			SignatureType:           Ed25519Signature2018,
+			SignatureRepresentation: verifiable.SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      keyID,
+			Challenge:               challenge,
+			Domain:                  domain,
+			Purpose:                 "authentication",
+		}))
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		return vpBytes
+	}
+
+	verify := func(t *testing.T, args *DIDAuthResponseArgs) (*DIDAuthResponse, command.Error) {
+		t.Helper()
+
+		reqBytes, err := json.Marshal(args)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		if cmdErr := cmd.VerifyDIDAuthResponse(&b, bytes.NewBuffer(reqBytes)); cmdErr != nil {
+			return nil, cmdErr
+		}
+
+		response := &DIDAuthResponse{}
+		require.NoError(t, json.NewDecoder(&b).Decode(response))
+
+		return response, nil
+	}
+
+	holderSigner := signature.GetEd25519Signer(privKey, pubKey)
+
+	t.Run("valid signed response", func(t *testing.T) {
+		request := createRequest(t)
+
+		response, cmdErr := verify(t, &DIDAuthResponseArgs{
+			Challenge:              request.Challenge,
+			VerifiablePresentation: sign(t, key.ID, holderSigner, request.Challenge),
+			DID:                    holderDID,
+		})
+		require.NoError(t, cmdErr)
+		require.Equal(t, &DIDAuthResponse{Verified: true, DID: holderDID}, response)
+
+		// the challenge is single-use
+		_, cmdErr = verify(t, &DIDAuthResponseArgs{
+			Challenge:              request.Challenge,
+			VerifiablePresentation: sign(t, key.ID, holderSigner, request.Challenge),
+		})
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyDIDAuthResponseErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "unknown or already answered challenge")
+	})
+
+	t.Run("wrong-challenge response", func(t *testing.T) {
+		request := createRequest(t)
+		other := createRequest(t)
+
+		response, cmdErr := verify(t, &DIDAuthResponseArgs{
+			Challenge:              request.Challenge,
+			VerifiablePresentation: sign(t, key.ID, holderSigner, other.Challenge),
+		})
+		require.NoError(t, cmdErr)
+		require.False(t, response.Verified)
+		require.Empty(t, response.DID)
+		require.Contains(t, response.Reason, "doesn't match the expected challenge")
+
+		// the challenge can still be answered
+		response, cmdErr = verify(t, &DIDAuthResponseArgs{
+			Challenge:              request.Challenge,
+			VerifiablePresentation: sign(t, key.ID, holderSigner, request.Challenge),
+		})
+		require.NoError(t, cmdErr)
+		require.True(t, response.Verified)
+	})
+
+	t.Run("signature from a key not in the DID doc", func(t *testing.T) {
+		request := createRequest(t)
+
+		response, cmdErr := verify(t, &DIDAuthResponseArgs{
+			Challenge: request.Challenge,
+			VerifiablePresentation: sign(t, holderDID+"#key-2", signature.GetEd25519Signer(otherPrivKey, otherPubKey),
+				request.Challenge),
+		})
+		require.NoError(t, cmdErr)
+		require.False(t, response.Verified)
+		require.Contains(t, response.Reason, "parse vp")
+
+		// signed by another key under the verification method of the DID doc
+		response, cmdErr = verify(t, &DIDAuthResponseArgs{
+			Challenge:              request.Challenge,
+			VerifiablePresentation: sign(t, key.ID, signature.GetEd25519Signer(otherPrivKey, otherPubKey), request.Challenge),
+		})
+		require.NoError(t, cmdErr)
+		require.False(t, response.Verified)
+		require.Contains(t, response.Reason, "parse vp")
+	})
+
+	t.Run("response of another DID", func(t *testing.T) {
+		request := createRequest(t)
+
+		response, cmdErr := verify(t, &DIDAuthResponseArgs{
+			Challenge:              request.Challenge,
+			VerifiablePresentation: sign(t, key.ID, holderSigner, request.Challenge),
+			DID:                    "did:example:other",
+		})
+		require.NoError(t, cmdErr)
+		require.False(t, response.Verified)
+		require.Contains(t, response.Reason, "is not the expected holder did:example:other")
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, tc := range []struct {
+			exec    command.Exec
+			request string
+			errMsg  string
+		}{
+			{cmd.CreateDIDAuthRequest, "--", "request decode"},
+			{cmd.CreateDIDAuthRequest, `{}`, errEmptyDomain},
+			{cmd.VerifyDIDAuthResponse, "--", "request decode"},
+			{cmd.VerifyDIDAuthResponse, `{"verifiablePresentation":{}}`, errEmptyChallenge},
+			{cmd.VerifyDIDAuthResponse, `{"challenge":"c"}`, errEmptyPresentation},
+		} {
+			cmdErr := tc.exec(&bytes.Buffer{}, bytes.NewBufferString(tc.request))
+			require.Error(t, cmdErr)
+			require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+			require.Equal(t, command.ValidationError, cmdErr.Type())
+			require.Contains(t, cmdErr.Error(), tc.errMsg)
+		}
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+				Store:  map[string][]byte{"invalid": []byte("{")},
+				ErrPut: errors.New("put error"),
+			}),
+		})
+		require.NoError(t, err)
+
+		cmdErr := cmd.CreateDIDAuthRequest(&bytes.Buffer{}, bytes.NewBufferString(`{"domain":"`+domain+`"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreateDIDAuthRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "save challenge : put error")
+
+		cmdErr = cmd.VerifyDIDAuthResponse(&bytes.Buffer{},
+			bytes.NewBufferString(`{"challenge":"invalid","verifiablePresentation":{}}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyDIDAuthResponseErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "unmarshal challenge")
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				Store:         &mockstore.MockStore{Store: map[string][]byte{}},
+				FailNamespace: didAuthStoreName,
+			},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open did auth store")
+	})
+}
@@ -18,20 +18,69 @@ type Credential struct {
 	VerifiableCredential string `json:"verifiableCredential,omitempty"`
 }
 
+// VerifyCredentialRequest is model for verify credential request.
+type VerifyCredentialRequest struct {
+	Credential
+
+	// CheckStatus tells whether the revocation status of the credential is checked as well.
+	CheckStatus bool `json:"checkStatus,omitempty"`
+}
+
+// VerifyCredentialResponse is model for verify credential response.
+type VerifyCredentialResponse struct {
+	// Verified tells whether the credential proof was verified.
+	Verified bool `json:"verified"`
+	// Reason the credential couldn't be verified.
+	Reason string `json:"reason,omitempty"`
+}
+
+// VerifyPresentationRequest is model for verify presentation request.
+type VerifyPresentationRequest struct {
+	// VerifiablePresentation is the presentation document or JWT (as a JSON string) to verify.
+	VerifiablePresentation json.RawMessage `json:"verifiablePresentation,omitempty"`
+	// Holder is the DID the presentation must be bound to. Optional.
+	Holder string `json:"holder,omitempty"`
+	// Challenge the presentation proof must have been made with (the 'nonce' of JWT presentations). Optional.
+	Challenge string `json:"challenge,omitempty"`
+	// Domain the presentation proof must have been made for (the 'aud' of JWT presentations). Optional.
+	Domain string `json:"domain,omitempty"`
+}
+
+// VerifyPresentationResponse is model for verify presentation response.
+type VerifyPresentationResponse struct {
+	// Verified tells whether the presentation and all its credentials were verified.
+	Verified bool `json:"verified"`
+	// Reason the presentation proof or its holder binding couldn't be verified.
+	Reason string `json:"reason,omitempty"`
+	// Credentials are the verification results of the embedded credentials, in order.
+	Credentials []VerifyCredentialResponse `json:"credentials,omitempty"`
+}
+
 // PresentationRequest is model for verifiable presentation request.
 type PresentationRequest struct {
 	VerifiableCredentials []json.RawMessage `json:"verifiableCredential,omitempty"`
 	Presentation          json.RawMessage   `json:"presentation,omitempty"`
 	DID                   string            `json:"did,omitempty"`
 	*ProofOptions
+	// CredentialIDs are the IDs of stored credentials to add to the presentation along with VerifiableCredentials.
+	CredentialIDs []string `json:"credentialIDs,omitempty"`
+	// Query selects the stored credentials to add to the presentation along with VerifiableCredentials.
+	Query *CredentialQuery `json:"query,omitempty"`
 	// SkipVerify can be used to skip verification of `VerifiableCredentials` provided.
 	SkipVerify bool `json:"skipVerify,omitempty"`
 }
 
+// CredentialQuery selects the stored credentials matching all of its criteria.
+type CredentialQuery struct {
+	// Types the credentials must all have (eg: ["VerifiableCredential", "UniversityDegreeCredential"]).
+	Types []string `json:"types,omitempty"`
+	// Schemas are credential schema IDs, the credentials must have one of them.
+	Schemas []string `json:"schemas,omitempty"`
+}
+
 // IDArg model
 //
 // This is used for querying/removing by ID from input json.
-//
 type IDArg struct {
 	// ID
 	ID string `json:"id"`
@@ -66,6 +115,16 @@ type SignCredentialRequest struct {
 	*ProofOptions
 }
 
+// DeriveCredentialRequest is model for derive credential request.
+type DeriveCredentialRequest struct {
+	// Credential signed with BBS+ signatures the credential is derived from.
+	Credential json.RawMessage `json:"credential,omitempty"`
+	// Frame is the JSON-LD frame selecting the attributes of the credential to reveal.
+	Frame map[string]interface{} `json:"frame,omitempty"`
+	// Nonce the proofs of the derived credential are bound to.
+	Nonce string `json:"nonce,omitempty"`
+}
+
 // SignCredentialResponse is model for sign credential response.
 type SignCredentialResponse struct {
 	VerifiableCredential json.RawMessage `json:"verifiableCredential,omitempty"`
@@ -80,7 +139,6 @@ type PresentationExt struct {
 // PresentationRequestByID model
 //
 // This is used for querying/removing by ID from input json.
-//
 type PresentationRequestByID struct {
 	// ID
 	ID string `json:"id"`
@@ -95,12 +153,70 @@ type PresentationRequestByID struct {
 // NameArg model
 //
 // This is used for querying by name from input json.
-//
 type NameArg struct {
 	// Name
 	Name string `json:"name"`
 }
 
+// TagArg model
+//
+// This is used for querying the credential records by tag from input json.
+type TagArg struct {
+	// Tag is one of "issuer", "type" and "subject".
+	Tag string `json:"tag"`
+
+	// Value of the tag.
+	Value string `json:"value"`
+}
+
+// QueryCredentialsArgs model
+//
+// This is used for querying the credential records matching all the given filters from input json.
+type QueryCredentialsArgs struct {
+	// Issuer DID of the credentials.
+	Issuer string `json:"issuer,omitempty"`
+
+	// Type the credentials must have.
+	Type string `json:"type,omitempty"`
+
+	// Expired selects the expired credentials if true and the unexpired ones if false, both if not set.
+	Expired *bool `json:"expired,omitempty"`
+}
+
+// DIDAuthRequestArgs is model for creating a DID authentication request.
+type DIDAuthRequestArgs struct {
+	// Domain of the relying party, the audience the response must be signed for (eg. "rp.example.com").
+	Domain string `json:"domain"`
+}
+
+// DIDAuthRequest is model for the DID authentication request, answered with a presentation signed by the
+// holder of the DID for the challenge and domain.
+type DIDAuthRequest struct {
+	Challenge string `json:"challenge"`
+	Domain    string `json:"domain"`
+}
+
+// DIDAuthResponseArgs is model for verifying the response to a DID authentication request.
+type DIDAuthResponseArgs struct {
+	// Challenge of the DID authentication request.
+	Challenge string `json:"challenge"`
+	// VerifiablePresentation is the response, the presentation document or JWT (as a JSON string) signed by the
+	// holder of the DID.
+	VerifiablePresentation json.RawMessage `json:"verifiablePresentation"`
+	// DID the response must be signed by. Optional.
+	DID string `json:"did,omitempty"`
+}
+
+// DIDAuthResponse is model for the verification result of the response to a DID authentication request.
+type DIDAuthResponse struct {
+	// Verified tells whether the control of the DID was proven.
+	Verified bool `json:"verified"`
+	// DID authenticated.
+	DID string `json:"did,omitempty"`
+	// Reason the response couldn't be verified.
+	Reason string `json:"reason,omitempty"`
+}
+
 // RecordResult holds the credential records.
 type RecordResult struct {
 	// Result
@@ -0,0 +1,259 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	passphraseenc "github.com/hyperledger/aries-framework-go/pkg/internal/passphrase"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+var logger = log.New("aries-framework/controller/wallet")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.Wallet)
+
+	// ExportWalletErrorCode is for failures in export wallet command.
+	ExportWalletErrorCode
+
+	// ImportWalletErrorCode is for failures in import wallet command.
+	ImportWalletErrorCode
+)
+
+// constants for the wallet controller command.
+const (
+	// command name.
+	CommandName = "wallet"
+
+	// command methods.
+	ExportWalletCommandMethod = "ExportWallet"
+	ImportWalletCommandMethod = "ImportWallet"
+
+	// BundleVersion is the version of the Bundle format.
+	BundleVersion = 1
+
+	// log constants.
+	successString = "success"
+
+	errEmptyWallet      = "wallet is mandatory"
+	errPassphraseNeeded = "passphrase is required to import an encrypted wallet"
+)
+
+// Stores returns the names of the stores exported in the wallets: the connection, DID and DID connection, and
+// verifiable credential stores. The keys of the KMS aren't exported.
+func Stores() []string {
+	return []string{connection.Namespace, didstore.NameSpace, didstore.StoreName, verifiablestore.NameSpace}
+}
+
+// Provider contains dependencies for the wallet controller command and is typically created by using
+// aries.Context().
+type Provider interface {
+	StorageProvider() storage.Provider
+}
+
+// Command is controller command exporting and importing the wallet of the agent, for backup and migration.
+type Command struct {
+	stores map[string]storage.Store
+}
+
+// New returns new wallet controller command instance.
+func New(ctx Provider) (*Command, error) {
+	stores := make(map[string]storage.Store)
+
+	for _, name := range Stores() {
+		store, err := ctx.StorageProvider().OpenStore(name)
+		if err != nil {
+			return nil, fmt.Errorf("open store %s: %w", name, err)
+		}
+
+		stores[name] = store
+	}
+
+	return &Command{stores: stores}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, ExportWalletCommandMethod, c.ExportWallet),
+		cmdutil.NewCommandHandler(CommandName, ImportWalletCommandMethod, c.ImportWallet),
+	}
+}
+
+// ExportWallet exports the connections, DIDs and credentials of the agent as a Bundle, encrypted if a passphrase
+// is given.
+func (c *Command) ExportWallet(rw io.Writer, req io.Reader) command.Error {
+	var request ExportWalletRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportWalletCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	bundle, err := c.export(request.Passphrase)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ExportWalletCommandMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportWalletResponse{Wallet: bundle}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportWalletCommandMethod, successString)
+
+	return nil
+}
+
+// ImportWallet imports the records of a Bundle exported by ExportWallet, replacing the records with the same keys.
+func (c *Command) ImportWallet(rw io.Writer, req io.Reader) command.Error {
+	var request ImportWalletRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportWalletCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if len(request.Wallet) == 0 {
+		logutil.LogDebug(logger, CommandName, ImportWalletCommandMethod, errEmptyWallet)
+
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyWallet))
+	}
+
+	stores, err := openBundle(request.Wallet, request.Passphrase)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportWalletCommandMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	for name := range stores {
+		if _, ok := c.stores[name]; !ok {
+			logutil.LogInfo(logger, CommandName, ImportWalletCommandMethod, "unsupported store "+name)
+
+			return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("unsupported store '%s'", name))
+		}
+	}
+
+	for name, records := range stores {
+		if err := storage.Import(c.stores[name], records); err != nil {
+			logutil.LogError(logger, CommandName, ImportWalletCommandMethod, err.Error())
+
+			return command.NewExecuteError(ImportWalletErrorCode, fmt.Errorf("import store %s: %w", name, err))
+		}
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, ImportWalletCommandMethod, successString)
+
+	return nil
+}
+
+func (c *Command) export(passphrase string) ([]byte, error) {
+	stores := make(map[string][]storage.Record)
+
+	for name, store := range c.stores {
+		records, err := storage.Export(store)
+		if err != nil {
+			return nil, fmt.Errorf("export store %s: %w", name, err)
+		}
+
+		stores[name] = records
+	}
+
+	bundle := &Bundle{Version: BundleVersion, Stores: stores}
+
+	if passphrase != "" {
+		encrypted, err := encryptStores(stores, passphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		bundle = &Bundle{Version: BundleVersion, Encrypted: encrypted}
+	}
+
+	return json.Marshal(bundle)
+}
+
+// openBundle returns the stores of the bundle, decrypted with the passphrase if encrypted.
+func openBundle(bundleBytes []byte, passphrase string) (map[string][]storage.Record, error) {
+	var bundle Bundle
+
+	err := json.Unmarshal(bundleBytes, &bundle)
+	if err != nil {
+		return nil, fmt.Errorf("parse wallet : %w", err)
+	}
+
+	if bundle.Version != BundleVersion {
+		return nil, fmt.Errorf("unsupported wallet version %d", bundle.Version)
+	}
+
+	if bundle.Encrypted == nil {
+		return bundle.Stores, nil
+	}
+
+	if passphrase == "" {
+		return nil, errors.New(errPassphraseNeeded)
+	}
+
+	return decryptStores(bundle.Encrypted, passphrase)
+}
+
+func encryptStores(stores map[string][]storage.Record, passphrase string) (*EncryptedStores, error) {
+	plaintext, err := json.Marshal(stores)
+	if err != nil {
+		return nil, fmt.Errorf("marshal stores : %w", err)
+	}
+
+	encrypted, err := passphraseenc.Encrypt(plaintext, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt wallet : %w", err)
+	}
+
+	return &EncryptedStores{
+		Salt:       encrypted.Salt,
+		Nonce:      encrypted.Nonce,
+		Ciphertext: encrypted.Ciphertext,
+	}, nil
+}
+
+func decryptStores(encrypted *EncryptedStores, passphrase string) (map[string][]storage.Record, error) {
+	plaintext, err := passphraseenc.Decrypt(&passphraseenc.Encrypted{
+		Salt:       encrypted.Salt,
+		Nonce:      encrypted.Nonce,
+		Ciphertext: encrypted.Ciphertext,
+	}, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt wallet : %w", err)
+	}
+
+	var stores map[string][]storage.Record
+
+	err = json.Unmarshal(plaintext, &stores)
+	if err != nil {
+		return nil, fmt.Errorf("parse decrypted wallet : %w", err)
+	}
+
+	return stores, nil
+}
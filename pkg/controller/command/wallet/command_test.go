@@ -0,0 +1,273 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+const (
+	sampleConnectionID = "b1f4d7f8-1c5b-4a7e-9a0e-6e2a4b3f1c11"
+	sampleDID          = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+	sampleVCID         = "http://example.edu/credentials/1872"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 2)
+	})
+
+	t.Run("open store failure", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("db is down"),
+		}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "db is down")
+	})
+}
+
+func TestCommand_ExportImportWallet(t *testing.T) {
+	source := newProvider()
+	populate(t, source)
+
+	cmd, err := New(source)
+	require.NoError(t, err)
+
+	for _, passphrase := range []string{"", "correct horse battery staple"} {
+		t.Run(fmt.Sprintf("round trip (encrypted: %t)", passphrase != ""), func(t *testing.T) {
+			wallet := exportWallet(t, cmd, passphrase)
+
+			var bundle Bundle
+			require.NoError(t, json.Unmarshal(wallet, &bundle))
+			require.Equal(t, BundleVersion, bundle.Version)
+
+			if passphrase == "" {
+				require.Len(t, bundle.Stores, len(Stores()))
+				require.Nil(t, bundle.Encrypted)
+			} else {
+				require.Empty(t, bundle.Stores)
+				require.NotNil(t, bundle.Encrypted)
+				require.NotContains(t, string(wallet), sampleDID)
+			}
+
+			target := newProvider()
+
+			targetCmd, err := New(target)
+			require.NoError(t, err)
+
+			require.NoError(t, importWallet(t, targetCmd, wallet, passphrase))
+
+			assertRestored(t, target)
+		})
+	}
+
+	t.Run("encrypted wallet import errors", func(t *testing.T) {
+		wallet := exportWallet(t, cmd, "passphrase")
+
+		targetCmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		cmdErr := importWallet(t, targetCmd, wallet, "")
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, errPassphraseNeeded, cmdErr.Error())
+
+		cmdErr = importWallet(t, targetCmd, wallet, "other passphrase")
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "decrypt wallet")
+	})
+}
+
+func TestCommand_ImportWallet(t *testing.T) {
+	cmd, err := New(newProvider())
+	require.NoError(t, err)
+
+	t.Run("invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.ImportWallet(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.ImportWallet(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, errEmptyWallet, cmdErr.Error())
+	})
+
+	t.Run("invalid wallet", func(t *testing.T) {
+		cmdErr := importWallet(t, cmd, []byte(`"wallet"`), "")
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "parse wallet")
+
+		cmdErr = importWallet(t, cmd, []byte(`{"version": 2}`), "")
+		require.Error(t, cmdErr)
+		require.Equal(t, "unsupported wallet version 2", cmdErr.Error())
+
+		cmdErr = importWallet(t, cmd, []byte(`{"version": 1, "stores": {"kms": []}}`), "")
+		require.Error(t, cmdErr)
+		require.Equal(t, "unsupported store 'kms'", cmdErr.Error())
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		failing, err := New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+			Store: &mockstore.MockStore{Store: map[string][]byte{}, ErrPut: errors.New("db is down")},
+		}})
+		require.NoError(t, err)
+
+		cmdErr := importWallet(t, failing,
+			[]byte(`{"version": 1, "stores": {"verifiable": [{"key": "k", "value": "dg=="}]}}`), "")
+		require.Error(t, cmdErr)
+		require.Equal(t, ImportWalletErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "db is down")
+	})
+}
+
+func TestCommand_ExportWallet(t *testing.T) {
+	t.Run("invalid request", func(t *testing.T) {
+		cmd, err := New(newProvider())
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.ExportWallet(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+			Store: &mockstore.MockStore{Store: map[string][]byte{}, ErrItr: errors.New("db is down")},
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.ExportWallet(&b, bytes.NewBufferString("{}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, ExportWalletErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "db is down")
+	})
+}
+
+func newProvider() *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mem.NewProvider(),
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+	}
+}
+
+func populate(t *testing.T, p *mockprovider.Provider) {
+	recorder, err := connection.NewRecorder(p)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		ConnectionID: sampleConnectionID,
+		State:        "completed",
+		ThreadID:     "thread-1",
+		MyDID:        sampleDID,
+		TheirDID:     "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		Namespace:    "my",
+	}))
+
+	dids, err := didstore.New(p)
+	require.NoError(t, err)
+
+	require.NoError(t, dids.SaveDID("my-did", &did.Doc{Context: []string{did.Context}, ID: sampleDID}))
+
+	vcs, err := verifiablestore.New(p)
+	require.NoError(t, err)
+
+	require.NoError(t, vcs.SaveCredential("my-vc", &verifiable.Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      sampleVCID,
+		Types:   []string{"VerifiableCredential"},
+		Subject: sampleDID,
+		Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Issued:  util.NewTime(time.Date(2020, 1, 1, 10, 54, 1, 0, time.UTC)),
+	}))
+}
+
+func assertRestored(t *testing.T, p *mockprovider.Provider) {
+	lookup, err := connection.NewLookup(p)
+	require.NoError(t, err)
+
+	record, err := lookup.GetConnectionRecord(sampleConnectionID)
+	require.NoError(t, err)
+	require.Equal(t, sampleDID, record.MyDID)
+
+	dids, err := didstore.New(p)
+	require.NoError(t, err)
+
+	id, err := dids.GetDIDByName("my-did")
+	require.NoError(t, err)
+	require.Equal(t, sampleDID, id)
+
+	doc, err := dids.GetDID(sampleDID)
+	require.NoError(t, err)
+	require.Equal(t, sampleDID, doc.ID)
+
+	vcs, err := verifiablestore.New(p)
+	require.NoError(t, err)
+
+	id, err = vcs.GetCredentialIDByName("my-vc")
+	require.NoError(t, err)
+	require.Equal(t, sampleVCID, id)
+
+	vc, err := vcs.GetCredential(sampleVCID)
+	require.NoError(t, err)
+	require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", vc.Issuer.ID)
+}
+
+func exportWallet(t *testing.T, cmd *Command, passphrase string) []byte {
+	reqBytes, err := json.Marshal(&ExportWalletRequest{Passphrase: passphrase})
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, cmd.ExportWallet(&b, bytes.NewBuffer(reqBytes)))
+
+	var response ExportWalletResponse
+	require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+
+	return response.Wallet
+}
+
+func importWallet(t *testing.T, cmd *Command, wallet []byte, passphrase string) command.Error {
+	t.Helper()
+
+	reqBytes, err := json.Marshal(&ImportWalletRequest{Wallet: wallet, Passphrase: passphrase})
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+
+	return cmd.ImportWallet(&b, bytes.NewBuffer(reqBytes))
+}
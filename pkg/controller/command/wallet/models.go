@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// ExportWalletRequest model
+//
+// This is used for exporting the wallet of the agent.
+//
+type ExportWalletRequest struct {
+	// Passphrase the wallet is encrypted with, the wallet isn't encrypted if empty
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// ExportWalletResponse model
+//
+// Represents a response of export wallet command.
+//
+type ExportWalletResponse struct {
+	// Wallet is the exported Bundle
+	Wallet json.RawMessage `json:"wallet"`
+}
+
+// ImportWalletRequest model
+//
+// This is used for importing a wallet exported by an agent.
+//
+type ImportWalletRequest struct {
+	// Wallet is the Bundle to import
+	Wallet json.RawMessage `json:"wallet"`
+
+	// Passphrase the wallet was encrypted with, required by encrypted wallets
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// Bundle model
+//
+// The portable artifact of an exported wallet: the records of the connection, DID and credential stores,
+// encrypted with a passphrase or not.
+//
+type Bundle struct {
+	// Version of the bundle format
+	Version int `json:"version"`
+
+	// Stores are the records of the stores by store name, if not encrypted
+	Stores map[string][]storage.Record `json:"stores,omitempty"`
+
+	// Encrypted are the encrypted stores, if encrypted
+	Encrypted *EncryptedStores `json:"encrypted,omitempty"`
+}
+
+// EncryptedStores model
+//
+// The records of the stores by store name, encrypted with XChaCha20-Poly1305 under a key derived from the
+// passphrase with scrypt.
+//
+type EncryptedStores struct {
+	// Salt of the scrypt key derivation
+	Salt []byte `json:"salt"`
+
+	// Nonce of the encryption
+	Nonce []byte `json:"nonce"`
+
+	// Ciphertext of the JSON stores
+	Ciphertext []byte `json:"ciphertext"`
+}
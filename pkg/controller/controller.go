@@ -10,27 +10,35 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	actionscmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/actions"
+	autoacceptcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/autoaccept"
 	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
 	introducecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	issuecredentialcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
 	routercmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/mediator"
 	messagingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
+	metricscmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/metrics"
 	outofbandcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
 	presentproofcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
 	vdricmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	walletcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/wallet"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	actionsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/actions"
+	autoacceptrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/autoaccept"
 	didexchangerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
 	introducerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	issuecredentialrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
 	kmsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/mediator"
 	messagingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/messaging"
+	metricsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/metrics"
 	outofbandrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofband"
 	presentproofrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
 	vdrirest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdri"
 	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
+	walletrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/wallet"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 )
@@ -98,13 +106,13 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 
 	// DID Exchange REST operation
 	exchangeOp, err := didexchangerest.New(ctx, notifier, restAPIOpts.defaultLabel,
-		restAPIOpts.autoAccept)
+		restAPIOpts.autoAccept, didexchangeOpts(restAPIOpts.msgHandler)...)
 	if err != nil {
 		return nil, err
 	}
 
 	// VDRI REST operation
-	vdriOp, err := vdrirest.New(ctx)
+	vdriOp, err := vdrirest.New(ctx, vdricmd.WithNotifier(notifier))
 	if err != nil {
 		return nil, err
 	}
@@ -151,9 +159,33 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		return nil, fmt.Errorf("create outofband rest command : %w", err)
 	}
 
+	// actions REST operation
+	actionsOp, err := actionsrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create actions rest command : %w", err)
+	}
+
 	// kms command operation
 	kmscmd := kmsrest.New(ctx)
 
+	// wallet REST operation
+	walletOp, err := walletrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create wallet rest command : %w", err)
+	}
+
+	// metrics REST operation
+	metricsOp, err := metricsrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create metrics rest command : %w", err)
+	}
+
+	// auto-accept REST operation
+	autoacceptOp, err := autoacceptrest.New(ctx, restAPIOpts.autoAccept)
+	if err != nil {
+		return nil, fmt.Errorf("create auto-accept rest command : %w", err)
+	}
+
 	// creat handlers from all operations
 	var allHandlers []rest.Handler
 	allHandlers = append(allHandlers, exchangeOp.GetRESTHandlers()...)
@@ -166,6 +198,10 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	allHandlers = append(allHandlers, introduceOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, outofbandOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, kmscmd.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, actionsOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, walletOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, metricsOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, autoacceptOp.GetRESTHandlers()...)
 
 	nhp, ok := notifier.(handlerProvider)
 	if ok {
@@ -175,6 +211,16 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	return allHandlers, nil
 }
 
+// didexchangeOpts returns the options of the DID Exchange command, the did-rotate messages are handled when a message
+// handler is set.
+func didexchangeOpts(msgHandler command.MessageHandler) []didexchangecmd.Option {
+	if msgHandler == nil {
+		return nil
+	}
+
+	return []didexchangecmd.Option{didexchangecmd.WithMessageHandler(msgHandler)}
+}
+
 type handlerProvider interface {
 	GetRESTHandlers() []rest.Handler
 }
@@ -194,13 +240,13 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 
 	// did exchange command operation
 	didexcmd, err := didexchangecmd.New(ctx, notifier, cmdOpts.defaultLabel,
-		cmdOpts.autoAccept)
+		cmdOpts.autoAccept, didexchangeOpts(cmdOpts.msgHandler)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed initialized didexchange command: %w", err)
 	}
 
 	// VDRI command operation
-	vcmd, err := vdricmd.New(ctx)
+	vcmd, err := vdricmd.New(ctx, vdricmd.WithNotifier(notifier))
 	if err != nil {
 		return nil, err
 	}
@@ -247,9 +293,33 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		return nil, fmt.Errorf("create outofband command : %w", err)
 	}
 
+	// actions command operation
+	actions, err := actionscmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create actions command : %w", err)
+	}
+
 	// kms command operation
 	kmscmd := kms.New(ctx)
 
+	// wallet command operation
+	walletOp, err := walletcmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create wallet command : %w", err)
+	}
+
+	// metrics command operation
+	metrics, err := metricscmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create metrics command : %w", err)
+	}
+
+	// auto-accept command operation
+	autoaccept, err := autoacceptcmd.New(ctx, cmdOpts.autoAccept)
+	if err != nil {
+		return nil, fmt.Errorf("create auto-accept command : %w", err)
+	}
+
 	var allHandlers []command.Handler
 	allHandlers = append(allHandlers, didexcmd.GetHandlers()...)
 	allHandlers = append(allHandlers, vcmd.GetHandlers()...)
@@ -261,6 +331,21 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	allHandlers = append(allHandlers, presentproof.GetHandlers()...)
 	allHandlers = append(allHandlers, introduce.GetHandlers()...)
 	allHandlers = append(allHandlers, outofband.GetHandlers()...)
+	allHandlers = append(allHandlers, actions.GetHandlers()...)
+	allHandlers = append(allHandlers, walletOp.GetHandlers()...)
+	allHandlers = append(allHandlers, metrics.GetHandlers()...)
+	allHandlers = append(allHandlers, autoaccept.GetHandlers()...)
 
 	return allHandlers, nil
 }
+
+// GetCommandRegistry returns a command registry holding all command handlers provided by controller,
+// custom commands can be registered to the returned registry at runtime.
+func GetCommandRegistry(ctx *context.Provider, opts ...Opt) (*command.Registry, error) {
+	handlers, err := GetCommandHandlers(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return command.NewRegistry(handlers...)
+}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	vdricmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
@@ -35,6 +37,36 @@ func TestGetCommandHandlers(t *testing.T) {
 	require.Nil(t, controller)
 }
 
+func TestGetCommandRegistry(t *testing.T) {
+	t.Run("Failure", func(t *testing.T) {
+		registry, err := GetCommandRegistry(&context.Provider{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), api.ErrSvcNotFound.Error())
+		require.Nil(t, registry)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		path, cleanup := generateTempDir(t)
+		defer cleanup()
+
+		framework, err := aries.New(defaults.WithStorePath(path),
+			defaults.WithInboundHTTPAddr(":26510", "", "", ""))
+		require.NoError(t, err)
+		require.NotNil(t, framework)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+		require.NotNil(t, ctx)
+
+		registry, err := GetCommandRegistry(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, registry.Commands())
+		require.Contains(t, registry.Commands(), command.QualifiedName(vdricmd.CommandName, vdricmd.CreatePublicDIDMethod))
+	})
+}
+
 func TestGetCommandHandlers_Success(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		path, cleanup := generateTempDir(t)
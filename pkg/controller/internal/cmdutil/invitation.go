@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cmdutil
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// OOBInvitationEncoding encodes invitations in the 'oob' query parameter of the invitation URL.
+	// Reference: https://github.com/hyperledger/aries-rfcs/tree/master/features/0434-outofband
+	OOBInvitationEncoding = "oob"
+	// LegacyInvitationEncoding encodes invitations in the 'c_i' query parameter of the invitation URL.
+	// Reference: https://github.com/hyperledger/aries-rfcs/tree/master/features/0160-connection-protocol
+	LegacyInvitationEncoding = "c_i"
+)
+
+// ValidateInvitationURLEncoding checks that encoding is one of the supported invitation encodings.
+func ValidateInvitationURLEncoding(encoding string) error {
+	if encoding != OOBInvitationEncoding && encoding != LegacyInvitationEncoding {
+		return fmt.Errorf("unsupported invitation URL encoding '%s'", encoding)
+	}
+
+	return nil
+}
+
+// ValidateInvitationURLBase checks that base is an absolute http(s) URL.
+func ValidateInvitationURLBase(base string) error {
+	u, err := url.Parse(base)
+	if err != nil {
+		return fmt.Errorf("invalid invitation URL base: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid invitation URL base '%s': an absolute http(s) URL is required", base)
+	}
+
+	return nil
+}
+
+// InvitationURL returns the base URL carrying the base64url encoded invitation in the query parameter of the
+// given encoding, for example https://example.com/invite?oob=eyJAaWQiOi... .
+func InvitationURL(base, encoding string, invitation interface{}) (string, error) {
+	if err := ValidateInvitationURLEncoding(encoding); err != nil {
+		return "", err
+	}
+
+	invBytes, err := json.Marshal(invitation)
+	if err != nil {
+		return "", fmt.Errorf("marshal invitation: %w", err)
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid invitation URL base: %w", err)
+	}
+
+	query := u.Query()
+	query.Set(encoding, base64.URLEncoding.EncodeToString(invBytes))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// DecodeInvitationURL decodes the invitation carried by the invitation URL into invitation, both the 'oob' and the
+// legacy 'c_i' encodings are supported.
+func DecodeInvitationURL(invitationURL string, invitation interface{}) error {
+	u, err := url.Parse(invitationURL)
+	if err != nil {
+		return fmt.Errorf("parse invitation URL: %w", err)
+	}
+
+	query := u.Query()
+
+	encoded := query.Get(OOBInvitationEncoding)
+	if encoded == "" {
+		encoded = query.Get(LegacyInvitationEncoding)
+	}
+
+	if encoded == "" {
+		return errors.New("invitation URL has no 'oob' or 'c_i' query parameter")
+	}
+
+	// the encoding is base64url, padding is optional
+	invBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return fmt.Errorf("decode invitation URL: %w", err)
+	}
+
+	if err := json.Unmarshal(invBytes, invitation); err != nil {
+		return fmt.Errorf("unmarshal invitation: %w", err)
+	}
+
+	return nil
+}
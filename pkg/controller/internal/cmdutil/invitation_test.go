@@ -0,0 +1,109 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cmdutil
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testInvitation struct {
+	ID      string        `json:"@id"`
+	Type    string        `json:"@type"`
+	Label   string        `json:"label,omitempty"`
+	Service []interface{} `json:"service"`
+}
+
+func TestInvitationURL(t *testing.T) {
+	invitation := &testInvitation{
+		ID:      "inv-1",
+		Type:    "https://didcomm.org/oob-invitation/1.0/invitation",
+		Label:   "label with spaces & symbols ?=",
+		Service: []interface{}{"did:example:123"},
+	}
+
+	t.Run("both encodings decode back to the invitation", func(t *testing.T) {
+		for _, encoding := range []string{OOBInvitationEncoding, LegacyInvitationEncoding} {
+			invitationURL, err := InvitationURL("https://example.com/invite", encoding, invitation)
+			require.NoError(t, err)
+			require.Regexp(t, `^https://example\.com/invite\?`+encoding+`=`, invitationURL)
+
+			decoded := &testInvitation{}
+			require.NoError(t, DecodeInvitationURL(invitationURL, decoded))
+			require.Equal(t, invitation, decoded)
+		}
+	})
+
+	t.Run("base URL query is kept", func(t *testing.T) {
+		invitationURL, err := InvitationURL("https://example.com/invite?lang=en", OOBInvitationEncoding, invitation)
+		require.NoError(t, err)
+
+		u, err := url.Parse(invitationURL)
+		require.NoError(t, err)
+		require.Equal(t, "en", u.Query().Get("lang"))
+
+		decoded := &testInvitation{}
+		require.NoError(t, DecodeInvitationURL(invitationURL, decoded))
+		require.Equal(t, invitation, decoded)
+	})
+
+	t.Run("unpadded invitation decodes", func(t *testing.T) {
+		invitationURL := "https://example.com?c_i=" + base64.RawURLEncoding.EncodeToString([]byte(`{"@id":"inv-2"}`))
+
+		decoded := &testInvitation{}
+		require.NoError(t, DecodeInvitationURL(invitationURL, decoded))
+		require.Equal(t, "inv-2", decoded.ID)
+	})
+
+	t.Run("unsupported encoding", func(t *testing.T) {
+		_, err := InvitationURL("https://example.com/invite", "d_m", invitation)
+		require.EqualError(t, err, "unsupported invitation URL encoding 'd_m'")
+	})
+
+	t.Run("invalid base", func(t *testing.T) {
+		_, err := InvitationURL("://example.com", OOBInvitationEncoding, invitation)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid invitation URL base")
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		_, err := InvitationURL("https://example.com/invite", OOBInvitationEncoding, make(chan int))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "marshal invitation")
+	})
+}
+
+func TestValidateInvitationURLBase(t *testing.T) {
+	for _, base := range []string{"http://example.com", "https://example.com/invite?lang=en"} {
+		require.NoError(t, ValidateInvitationURLBase(base), base)
+	}
+
+	for _, base := range []string{"", "example.com/invite", "/invite", "ws://example.com", "https://", "://example.com"} {
+		require.Error(t, ValidateInvitationURLBase(base), base)
+	}
+}
+
+func TestDecodeInvitationURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		errorMsg string
+	}{
+		{url: "://example.com", errorMsg: "parse invitation URL"},
+		{url: "https://example.com?x=e30", errorMsg: "invitation URL has no 'oob' or 'c_i' query parameter"},
+		{url: "https://example.com?oob=!!!", errorMsg: "decode invitation URL"},
+		{url: "https://example.com?oob=" + base64.URLEncoding.EncodeToString([]byte("{")), errorMsg: "unmarshal"},
+	}
+
+	for _, test := range tests {
+		err := DecodeInvitationURL(test.url, &testInvitation{})
+		require.Error(t, err, test.url)
+		require.Contains(t, err.Error(), test.errorMsg, test.url)
+	}
+}
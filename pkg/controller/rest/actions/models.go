@@ -0,0 +1,26 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import "github.com/hyperledger/aries-framework-go/pkg/controller/command/actions"
+
+// pendingActionsRequest model
+//
+// Returns the pending actions of the issue-credential, present-proof and did-exchange protocols.
+//
+// swagger:parameters pendingActions
+type pendingActionsRequest struct{} // nolint: unused,deadcode
+
+// pendingActionsResponse model
+//
+// Represents Actions response message.
+//
+// swagger:response pendingActionsResponse
+type pendingActionsResponse struct { // nolint: unused,deadcode
+	// in: body
+	actions.ActionsResponse
+}
@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/actions"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the actions operation
+const (
+	OperationID = "/actions"
+	Actions     = OperationID
+)
+
+// Operation is controller REST service controller listing the protocol actions awaiting user input.
+type Operation struct {
+	command  *actions.Command
+	handlers []rest.Handler
+}
+
+// New returns new actions rest controller instance.
+func New(ctx actions.Provider) (*Operation, error) {
+	cmd, err := actions.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("actions command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(Actions, http.MethodGet, c.Actions),
+	}
+}
+
+// Actions swagger:route GET /actions actions pendingActions
+//
+// Returns the pending actions of the issue-credential, present-proof and did-exchange protocols awaiting
+// user input.
+//
+// Responses:
+//    default: genericError
+//        200: pendingActionsResponse
+func (c *Operation) Actions(rw http.ResponseWriter, _ *http.Request) {
+	rest.Execute(c.command.Actions, rw, nil)
+}
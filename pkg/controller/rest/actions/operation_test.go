@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/actions"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	issuecredentialmocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/issuecredential"
+	presentproofmocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/presentproof"
+	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
+	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestOperation_Actions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issueCredentialSvc := issuecredentialmocks.NewMockProtocolService(ctrl)
+	issueCredentialSvc.EXPECT().Actions().Return([]issuecredential.Action{{
+		PIID: "ic-1",
+		Msg:  service.DIDCommMsgMap{"@id": "id", "@type": issuecredential.OfferCredentialMsgType},
+	}}, nil)
+
+	presentProofSvc := presentproofmocks.NewMockProtocolService(ctrl)
+	presentProofSvc.EXPECT().Actions().Return(nil, nil)
+
+	operation, err := New(&mockprovider.Provider{
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ServiceMap: map[string]interface{}{
+			issuecredential.Name:    issueCredentialSvc,
+			presentproof.Name:       presentProofSvc,
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{},
+			mediator.Coordination:   &mockroute.MockMediatorSvc{},
+		},
+	})
+	require.NoError(t, err)
+
+	handlers := operation.GetRESTHandlers()
+	require.Len(t, handlers, 1)
+	require.Equal(t, Actions, handlers[0].Path())
+	require.Equal(t, http.MethodGet, handlers[0].Method())
+
+	req, err := http.NewRequest(http.MethodGet, Actions, nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handlers[0].Handle()(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var res actions.ActionsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	require.Len(t, res.Actions, 1)
+	require.Equal(t, issuecredential.Name, res.Actions[0].Protocol)
+	require.Equal(t, "ic-1", res.Actions[0].PIID)
+	require.Equal(t, issuecredential.OfferCredentialMsgType, res.Actions[0].Type)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&mockprovider.Provider{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "actions command")
+}
@@ -0,0 +1,43 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package autoaccept
+
+import "github.com/hyperledger/aries-framework-go/pkg/controller/command/autoaccept"
+
+// setAutoAcceptRequest model
+//
+// This is used for setting the auto-accept policy of a protocol.
+//
+// swagger:parameters setAutoAccept
+type setAutoAcceptRequest struct { // nolint: unused,deadcode
+	// Params for setting the policy
+	//
+	// in: body
+	Params autoaccept.Policy
+}
+
+// getAutoAcceptRequest model
+//
+// This is used for getting the auto-accept policies.
+//
+// swagger:parameters getAutoAccept
+type getAutoAcceptRequest struct { // nolint: unused,deadcode
+	// Protocol name, the policies of all the protocols are returned if empty
+	//
+	// in: query
+	Protocol string `json:"protocol"`
+}
+
+// getAutoAcceptResponse model
+//
+// Represents GetAutoAccept response message.
+//
+// swagger:response getAutoAcceptResponse
+type getAutoAcceptResponse struct { // nolint: unused,deadcode
+	// in: body
+	autoaccept.GetAutoAcceptResponse
+}
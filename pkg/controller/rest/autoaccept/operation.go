@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package autoaccept
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/autoaccept"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the auto-accept operation.
+const (
+	OperationID       = "/autoaccept"
+	SetAutoAcceptPath = OperationID
+	GetAutoAcceptPath = OperationID
+)
+
+// Operation is controller REST service controller configuring the protocols whose actions are accepted without
+// user input.
+type Operation struct {
+	command  *autoaccept.Command
+	handlers []rest.Handler
+}
+
+// New returns new auto-accept rest controller instance, autoAccept is the policy of did-exchange until configured.
+func New(ctx autoaccept.Provider, autoAccept bool) (*Operation, error) {
+	cmd, err := autoaccept.New(ctx, autoAccept)
+	if err != nil {
+		return nil, fmt.Errorf("auto-accept command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(SetAutoAcceptPath, http.MethodPost, c.SetAutoAccept),
+		cmdutil.NewHTTPHandler(GetAutoAcceptPath, http.MethodGet, c.GetAutoAccept),
+	}
+}
+
+// SetAutoAccept swagger:route POST /autoaccept autoaccept setAutoAccept
+//
+// Sets the auto-accept policy of a protocol.
+//
+// Responses:
+//    default: genericError
+func (c *Operation) SetAutoAccept(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.SetAutoAccept, rw, req.Body)
+}
+
+// GetAutoAccept swagger:route GET /autoaccept autoaccept getAutoAccept
+//
+// Returns the auto-accept policy of a protocol, or of all the protocols.
+//
+// Responses:
+//    default: genericError
+//        200: getAutoAcceptResponse
+func (c *Operation) GetAutoAccept(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.GetAutoAccept, rw, bytes.NewBufferString(fmt.Sprintf(`{"protocol":%q}`,
+		req.URL.Query().Get("protocol"))))
+}
@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package autoaccept
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/autoaccept"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func lookupHandler(t *testing.T, op *Operation, path, method string) rest.Handler {
+	t.Helper()
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path && h.Method() == method {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+func TestOperation_AutoAccept(t *testing.T) {
+	operation, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()}, false)
+	require.NoError(t, err)
+	require.Len(t, operation.GetRESTHandlers(), 2)
+
+	req, err := http.NewRequest(http.MethodPost, SetAutoAcceptPath,
+		bytes.NewBufferString(`{"protocol": "present-proof", "auto_accept": true}`))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	lookupHandler(t, operation, SetAutoAcceptPath, http.MethodPost).Handle()(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	req, err = http.NewRequest(http.MethodGet, GetAutoAcceptPath+"?protocol=present-proof", nil)
+	require.NoError(t, err)
+
+	rr = httptest.NewRecorder()
+	lookupHandler(t, operation, GetAutoAcceptPath, http.MethodGet).Handle()(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var res autoaccept.GetAutoAcceptResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	require.Equal(t, []*autoaccept.Policy{{Protocol: presentproof.Name, AutoAccept: true}}, res.Policies)
+
+	req, err = http.NewRequest(http.MethodGet, GetAutoAcceptPath+"?protocol=unknown", nil)
+	require.NoError(t, err)
+
+	rr = httptest.NewRecorder()
+	lookupHandler(t, operation, GetAutoAcceptPath, http.MethodGet).Handle()(rr, req)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&mockprovider.Provider{
+		StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open failed")},
+	}, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "auto-accept command")
+}
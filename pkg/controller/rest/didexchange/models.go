@@ -9,6 +9,7 @@ package didexchange
 import (
 	didexchangeSvc "github.com/hyperledger/aries-framework-go/pkg/client/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 // createInvitationRequest model
@@ -126,6 +127,32 @@ type getConnectionRequest struct { // nolint: unused,deadcode
 	ID string `json:"id"`
 }
 
+// getConnectionByThreadRequest model
+//
+// This is used for getting the connection a thread belongs to
+//
+// swagger:parameters getConnectionByThread
+type getConnectionByThreadRequest struct { // nolint: unused,deadcode
+	// The ID of the thread
+	//
+	// in: path
+	// required: true
+	ThreadID string `json:"thread_id"`
+}
+
+// queryConnectionByThreadResponse model
+//
+// This is used for returning the connection a thread belongs to, along with the protocol state of the thread
+//
+// swagger:response queryConnectionByThreadResponse
+type queryConnectionByThreadResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Result *didexchangeSvc.Connection `json:"result,omitempty"`
+		Thread *connection.ThreadRecord   `json:"thread,omitempty"`
+	}
+}
+
 // queryConnections model
 //
 // This is used for querying connections
@@ -241,3 +268,62 @@ type createConnectionRequest struct { // nolint: unused,deadcode
 	// required: true
 	Request didexchange.CreateConnectionRequest
 }
+
+// rotateDIDRequest model
+//
+// This is used for rotating the DID used by the agent in a connection
+//
+// swagger:parameters rotateDID
+type rotateDIDRequest struct { // nolint: unused,deadcode
+	// The ID of the connection record
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+
+	// Params for rotating the DID
+	//
+	// in: body
+	// required: true
+	Params struct {
+		// The DID replacing the DID of the agent in the connection, it has to be resolvable
+		//
+		// required: true
+		NewDID string `json:"new_did"`
+	}
+}
+
+// rotateDIDResponse model
+//
+// response of rotate DID action, the updated connection record
+//
+// swagger:response rotateDIDResponse
+type rotateDIDResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Result *didexchangeSvc.Connection `json:"result,omitempty"`
+	}
+}
+
+// updateServiceEndpointRequest model
+//
+// This is used for updating the service endpoint of the DIDs used by the agent in the connections
+//
+// swagger:parameters updateServiceEndpoint
+type updateServiceEndpointRequest struct { // nolint: unused,deadcode
+	// Params for updating the service endpoint
+	//
+	// in: body
+	// required: true
+	Params didexchange.UpdateServiceEndpointArgs
+}
+
+// updateServiceEndpointResponse model
+//
+// response of update service endpoint action, the connections rotated to the new DIDs and the failed connections
+//
+// swagger:response updateServiceEndpointResponse
+type updateServiceEndpointResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body didexchange.UpdateServiceEndpointResponse
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -19,6 +20,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
@@ -32,9 +35,12 @@ const (
 	AcceptInvitationPath         = OperationID + "/{id}/accept-invitation"
 	Connections                  = OperationID
 	ConnectionsByID              = OperationID + "/{id}"
+	ConnectionByThread           = OperationID + "/threads/{thread_id}"
 	AcceptExchangeRequest        = OperationID + "/{id}/accept-request"
 	CreateConnection             = OperationID + "/create"
 	RemoveConnection             = OperationID + "/{id}/remove"
+	RotateDID                    = OperationID + "/{id}/rotate-did"
+	UpdateServiceEndpoint        = OperationID + "/update-service-endpoint"
 )
 
 // provider contains dependencies for the Exchange protocol and is typically created by using aries.Context()
@@ -44,11 +50,14 @@ type provider interface {
 	ServiceEndpoint() string
 	StorageProvider() storage.Provider
 	ProtocolStateStorageProvider() storage.Provider
+	Messenger() service.Messenger
+	VDRIRegistry() vdriapi.Registry
 }
 
 // New returns new DID Exchange rest client protocol instance.
-func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccept bool) (*Operation, error) {
-	dxcmd, err := didexchange.New(ctx, notifier, defaultLabel, autoAccept)
+func New(ctx provider, notifier command.Notifier, defaultLabel string, autoAccept bool,
+	opts ...didexchange.Option) (*Operation, error) {
+	dxcmd, err := didexchange.New(ctx, notifier, defaultLabel, autoAccept, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize did-exchange command : %w", err)
 	}
@@ -76,6 +85,7 @@ func (c *Operation) registerHandler() {
 	c.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(Connections, http.MethodGet, c.QueryConnections),
 		cmdutil.NewHTTPHandler(ConnectionsByID, http.MethodGet, c.QueryConnectionByID),
+		cmdutil.NewHTTPHandler(ConnectionByThread, http.MethodGet, c.QueryConnectionByThread),
 		cmdutil.NewHTTPHandler(CreateInvitationPath, http.MethodPost, c.CreateInvitation),
 		cmdutil.NewHTTPHandler(CreateImplicitInvitationPath, http.MethodPost, c.CreateImplicitInvitation),
 		cmdutil.NewHTTPHandler(ReceiveInvitationPath, http.MethodPost, c.ReceiveInvitation),
@@ -83,6 +93,8 @@ func (c *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(AcceptExchangeRequest, http.MethodPost, c.AcceptExchangeRequest),
 		cmdutil.NewHTTPHandler(CreateConnection, http.MethodPost, c.CreateConnection),
 		cmdutil.NewHTTPHandler(RemoveConnection, http.MethodPost, c.RemoveConnection),
+		cmdutil.NewHTTPHandler(RotateDID, http.MethodPost, c.RotateDID),
+		cmdutil.NewHTTPHandler(UpdateServiceEndpoint, http.MethodPost, c.UpdateServiceEndpoint),
 	}
 }
 
@@ -202,6 +214,23 @@ func (c *Operation) QueryConnectionByID(rw http.ResponseWriter, req *http.Reques
 	rest.Execute(c.command.QueryConnectionByID, rw, bytes.NewBufferString(request))
 }
 
+// QueryConnectionByThread swagger:route GET /connections/threads/{thread_id} did-exchange getConnectionByThread
+//
+// Fetch the connection a thread belongs to and the protocol state of the thread.
+//
+// Responses:
+//    default: genericError
+//        200: queryConnectionByThreadResponse
+func (c *Operation) QueryConnectionByThread(rw http.ResponseWriter, req *http.Request) {
+	request, err := json.Marshal(&didexchange.ThreadIDArg{ThreadID: mux.Vars(req)["thread_id"]})
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
+		return
+	}
+
+	rest.Execute(c.command.QueryConnectionByThread, rw, bytes.NewReader(request))
+}
+
 // CreateConnection swagger:route POST /connections/create did-exchange createConnection
 //
 // Saves the connection record.
@@ -231,15 +260,71 @@ func (c *Operation) RemoveConnection(rw http.ResponseWriter, req *http.Request)
 	rest.Execute(c.command.RemoveConnection, rw, bytes.NewBufferString(request))
 }
 
+// RotateDID swagger:route POST /connections/{id}/rotate-did did-exchange rotateDID
+//
+// Replaces the DID used by the agent in a completed connection and notifies the other party.
+//
+// Responses:
+//    default: genericError
+//    200: rotateDIDResponse
+func (c *Operation) RotateDID(rw http.ResponseWriter, req *http.Request) {
+	id, found := getIDFromRequest(rw, req)
+	if !found {
+		return
+	}
+
+	var request didexchange.RotateDIDArgs
+
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
+		return
+	}
+
+	request.ID = id
+
+	reqBytes, err := json.Marshal(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, didexchange.InvalidRequestErrorCode, err)
+		return
+	}
+
+	rest.Execute(c.command.RotateDID, rw, bytes.NewReader(reqBytes))
+}
+
+// UpdateServiceEndpoint swagger:route POST /connections/update-service-endpoint did-exchange updateServiceEndpoint
+//
+// Rotates the DIDs used by the agent in the completed connections to new DIDs with the service endpoint and notifies
+// the other parties.
+//
+// Responses:
+//    default: genericError
+//    200: updateServiceEndpointResponse
+func (c *Operation) UpdateServiceEndpoint(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.UpdateServiceEndpoint, rw, req.Body)
+}
+
 // queryValuesAsJSON converts query strings to `map[string]string`
 // and marshals them to JSON bytes.
 func queryValuesAsJSON(vals url.Values) ([]byte, error) {
 	// normalize all query string key/values
-	args := make(map[string]string)
+	args := make(map[string]interface{})
 
 	for k, v := range vals {
-		if len(v) > 0 {
-			args[k] = v[0]
+		if len(v) == 0 {
+			continue
+		}
+
+		args[k] = v[0]
+
+		// paging params are numbers
+		if k == "offset" || k == "limit" {
+			n, err := strconv.Atoi(v[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", k, err)
+			}
+
+			args[k] = n
 		}
 	}
 
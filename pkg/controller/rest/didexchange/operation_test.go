@@ -33,9 +33,11 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
 	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
+	mockservice "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
@@ -208,6 +210,23 @@ func TestOperation_QueryConnectionByParams(t *testing.T) {
 		}
 	})
 
+	t.Run("test query connections with paging", func(t *testing.T) {
+		handler = getHandler(t, Connections)
+		buf, err := getSuccessResponseFromHandler(handler, nil,
+			OperationID+"?offset=0&limit=1")
+		require.NoError(t, err)
+
+		response := didexchange.QueryConnectionsResponse{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+		require.Len(t, response.Results, 1)
+
+		buf, code, err := sendRequestToHandler(handler, nil, OperationID+"?limit=one")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyRESTError(t, didexchange.InvalidRequestErrorCode, buf.Bytes())
+	})
+
 	t.Run("test query connections without state filter", func(t *testing.T) {
 		// perform test
 		handler = getHandler(t, Connections)
@@ -399,6 +418,140 @@ func TestOperation_RemoveConnection(t *testing.T) {
 	})
 }
 
+func TestOperation_RotateDID(t *testing.T) {
+	store := mockstore.MockStore{Store: make(map[string][]byte)}
+	connRec := &connection.Record{
+		State: connection.StateNameCompleted, ConnectionID: "1234", MyDID: "did:example:my", TheirDID: "did:example:their",
+	}
+
+	connBytes, err := json.Marshal(connRec)
+	require.NoError(t, err)
+	require.NoError(t, store.Put("conn_1234", connBytes))
+
+	op, err := New(&mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			didexsvc.DIDExchange:  &mockdidexchange.MockDIDExchangeSvc{},
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		StorageProviderValue:              &mockstore.MockStoreProvider{Store: &store},
+		VDRIRegistryValue:                 &mockvdri.MockVDRIRegistry{ResolveValue: &did.Doc{ID: "did:example:new"}},
+		MessengerValue:                    &mockservice.MockMessenger{},
+	}, webnotifier.NewHTTPNotifier(nil), "", false)
+	require.NoError(t, err)
+
+	handler := handlerLookup(t, op, RotateDID)
+
+	t.Run("test rotate DID success", func(t *testing.T) {
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"new_did":"did:example:new"}`),
+			OperationID+"/1234/rotate-did")
+		require.NoError(t, err)
+
+		response := &didexchange.QueryConnectionResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), response))
+		require.Equal(t, "1234", response.Result.ConnectionID)
+		require.Equal(t, "did:example:new", response.Result.MyDID)
+	})
+
+	t.Run("test rotate DID invalid request", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("--"), OperationID+"/1234/rotate-did")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyRESTError(t, didexchange.InvalidRequestErrorCode, buf.Bytes())
+	})
+}
+
+func TestOperation_UpdateServiceEndpoint(t *testing.T) {
+	store := mockstore.MockStore{Store: make(map[string][]byte)}
+	connRec := &connection.Record{
+		State: connection.StateNameCompleted, ConnectionID: "1234", MyDID: "did:example:my", TheirDID: "did:example:their",
+	}
+
+	connBytes, err := json.Marshal(connRec)
+	require.NoError(t, err)
+	require.NoError(t, store.Put("conn_1234", connBytes))
+
+	op, err := New(&mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			didexsvc.DIDExchange:  &mockdidexchange.MockDIDExchangeSvc{},
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		StorageProviderValue:              &mockstore.MockStoreProvider{Store: &store},
+		VDRIRegistryValue:                 &mockvdri.MockVDRIRegistry{CreateValue: &did.Doc{ID: "did:peer:2.new"}},
+		MessengerValue:                    &mockservice.MockMessenger{},
+	}, webnotifier.NewHTTPNotifier(nil), "", false)
+	require.NoError(t, err)
+
+	handler := handlerLookup(t, op, UpdateServiceEndpoint)
+
+	t.Run("test update service endpoint success", func(t *testing.T) {
+		buf, err := getSuccessResponseFromHandler(handler,
+			bytes.NewBufferString(`{"service_endpoint":"https://new.example.com"}`), UpdateServiceEndpoint)
+		require.NoError(t, err)
+
+		response := &didexchange.UpdateServiceEndpointResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), response))
+		require.Equal(t, []string{"1234"}, response.ConnectionIDs)
+		require.Empty(t, response.Failures)
+
+		record := &connection.Record{}
+		require.NoError(t, json.Unmarshal(store.Store["conn_1234"], record))
+		require.Equal(t, "did:peer:2.new", record.MyDID)
+	})
+
+	t.Run("test update service endpoint invalid request", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), UpdateServiceEndpoint)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyRESTError(t, didexchange.InvalidRequestErrorCode, buf.Bytes())
+	})
+}
+
+func TestOperation_QueryConnectionByThread(t *testing.T) {
+	store := mockstore.MockStore{Store: make(map[string][]byte)}
+
+	prov := &mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			didexsvc.DIDExchange:  &mockdidexchange.MockDIDExchangeSvc{},
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		StorageProviderValue:              &mockstore.MockStoreProvider{Store: &store},
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+		State: connection.StateNameCompleted, ConnectionID: "1234",
+	}))
+	require.NoError(t, recorder.SaveThreadRecord(&connection.ThreadRecord{
+		ThreadID: "th1234", ConnectionID: "1234", Protocol: "https://didcomm.org/basicmessage/1.0",
+	}))
+
+	op, err := New(prov, webnotifier.NewHTTPNotifier(nil), "", false)
+	require.NoError(t, err)
+
+	handler := handlerLookup(t, op, ConnectionByThread)
+
+	t.Run("test query connection by thread success", func(t *testing.T) {
+		buf, err := getSuccessResponseFromHandler(handler, nil, OperationID+"/threads/th1234")
+		require.NoError(t, err)
+
+		response := &didexchange.QueryConnectionByThreadResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), response))
+		require.Equal(t, "1234", response.Result.ConnectionID)
+		require.Equal(t, "https://didcomm.org/basicmessage/1.0", response.Thread.Protocol)
+	})
+
+	t.Run("test query connection by thread not found", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, nil, OperationID+"/threads/unknown")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyRESTError(t, didexchange.QueryConnectionByThreadErrorCode, buf.Bytes())
+	})
+}
+
 func TestGetIDFromRequest(t *testing.T) {
 	id, found := getIDFromRequest(httptest.NewRecorder(), &http.Request{})
 	require.False(t, found)
@@ -424,7 +577,7 @@ func TestEmptyID(t *testing.T) {
 	require.NotNil(t, op)
 
 	restHandlers := []http.HandlerFunc{
-		op.AcceptInvitation, op.AcceptExchangeRequest, op.QueryConnectionByID, op.RemoveConnection,
+		op.AcceptInvitation, op.AcceptExchangeRequest, op.QueryConnectionByID, op.RemoveConnection, op.RotateDID,
 	}
 	for _, handler := range restHandlers {
 		rw := httptest.NewRecorder()
@@ -17,7 +17,6 @@ import (
 
 	"github.com/gorilla/mux"
 
-	client "github.com/hyperledger/aries-framework-go/pkg/client/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
@@ -50,7 +49,7 @@ type Operation struct {
 }
 
 // New returns new issue credential rest client protocol instance.
-func New(ctx client.Provider, notifier command.Notifier) (*Operation, error) {
+func New(ctx issuecredential.Provider, notifier command.Notifier) (*Operation, error) {
 	cmd, err := issuecredential.New(ctx, notifier)
 	if err != nil {
 		return nil, fmt.Errorf("issue credential command : %w", err)
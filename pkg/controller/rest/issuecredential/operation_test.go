@@ -18,23 +18,34 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
 
-	client "github.com/hyperledger/aries-framework-go/pkg/client/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/issuecredential"
 	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 )
 
-func provider(ctrl *gomock.Controller) client.Provider {
-	service := mocks.NewMockProtocolService(ctrl)
-	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
-	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
-	service.EXPECT().ActionContinue(gomock.Any(), gomock.Any()).AnyTimes()
-	service.EXPECT().ActionStop(gomock.Any(), gomock.Any()).AnyTimes()
-
-	provider := mocks.NewMockProvider(ctrl)
-	provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+func provider(ctrl *gomock.Controller) issuecredential.Provider {
+	offer := protocol.Action{
+		PIID: "1234",
+		Msg:  service.NewDIDCommMsgMap(protocol.OfferCredential{Type: protocol.OfferCredentialMsgType}),
+	}
 
-	return provider
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+	svc.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+	svc.EXPECT().Actions().Return([]protocol.Action{offer}, nil).AnyTimes()
+	svc.EXPECT().ActionContinue(gomock.Any(), gomock.Any()).AnyTimes()
+	svc.EXPECT().ActionStop(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return &mockprovider.Provider{
+		ServiceValue:                      svc,
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+	}
 }
 
 func TestOperation_AcceptProposal(t *testing.T) {
@@ -43,3 +43,95 @@ type importKeyReq struct { // nolint: unused,deadcode
 	// in: body
 	kms.JSONWebKey
 }
+
+// importKeySetReq model
+//
+// This is used for importing the keys of an encrypted key set
+//
+// swagger:parameters importKeySetReq
+type importKeySetReq struct { // nolint: unused,deadcode
+	// Params for importKey of an encrypted key set
+	//
+	// in: body
+	kms.ImportKeyRequest
+}
+
+// importKeyRes model
+//
+// This is used for returning the IDs of the imported keys
+//
+// swagger:response importKeyRes
+type importKeyRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.ImportKeyResponse
+}
+
+// signJWSReq model
+//
+// This is used for signJWS request.
+//
+// swagger:parameters signJWSReq
+type signJWSReq struct { // nolint: unused,deadcode
+	// Params for signJWS
+	//
+	// in: body
+	kms.SignJWSRequest
+}
+
+// signJWSRes model
+//
+// This is used for returning the signed JWS
+//
+// swagger:response signJWSRes
+type signJWSRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.SignJWSResponse
+}
+
+// exportPublicKeyReq model
+//
+// This is used for exportPublicKey request.
+//
+// swagger:parameters exportPublicKeyReq
+type exportPublicKeyReq struct { // nolint: unused,deadcode
+	// Params for exportPublicKey
+	//
+	// in: body
+	kms.ExportPublicKeyRequest
+}
+
+// exportPublicKeyRes model
+//
+// This is used for returning the exported public key
+//
+// swagger:response exportPublicKeyRes
+type exportPublicKeyRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.ExportPublicKeyResponse
+}
+
+// exportKeySetReq model
+//
+// This is used for exportKeySet request.
+//
+// swagger:parameters exportKeySetReq
+type exportKeySetReq struct { // nolint: unused,deadcode
+	// Params for exportKeySet
+	//
+	// in: body
+	kms.ExportKeySetRequest
+}
+
+// exportKeySetRes model
+//
+// This is used for returning the encrypted key set
+//
+// swagger:response exportKeySetRes
+type exportKeySetRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.ExportKeySetResponse
+}
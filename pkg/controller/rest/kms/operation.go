@@ -14,6 +14,7 @@ import (
 	cmdkms "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -22,16 +23,23 @@ const (
 	KmsOperationID   = "/kms"
 	CreateKeySetPath = KmsOperationID + "/keyset"
 	ImportKeyPath    = KmsOperationID + "/import"
+	SignJWSPath      = KmsOperationID + "/jws"
+	ExportPubKeyPath = KmsOperationID + "/export"
+	ExportKeySetPath = KmsOperationID + "/keyset/export"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
 type provider interface {
 	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
 }
 
 type kmsCommand interface {
 	CreateKeySet(rw io.Writer, req io.Reader) command.Error
 	ImportKey(rw io.Writer, req io.Reader) command.Error
+	SignJWS(rw io.Writer, req io.Reader) command.Error
+	ExportPublicKey(rw io.Writer, req io.Reader) command.Error
+	ExportKeySet(rw io.Writer, req io.Reader) command.Error
 }
 
 // Operation contains basic common operations provided by controller REST API.
@@ -60,6 +68,9 @@ func (o *Operation) registerHandler() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(CreateKeySetPath, http.MethodPost, o.CreateKeySet),
 		cmdutil.NewHTTPHandler(ImportKeyPath, http.MethodPost, o.ImportKey),
+		cmdutil.NewHTTPHandler(SignJWSPath, http.MethodPost, o.SignJWS),
+		cmdutil.NewHTTPHandler(ExportPubKeyPath, http.MethodPost, o.ExportPublicKey),
+		cmdutil.NewHTTPHandler(ExportKeySetPath, http.MethodPost, o.ExportKeySet),
 	}
 }
 
@@ -76,10 +87,44 @@ func (o *Operation) CreateKeySet(rw http.ResponseWriter, req *http.Request) {
 
 // ImportKey swagger:route POST /kms/import kms importKey
 //
-// Import key.
+// Import a key, or the keys of a key set encrypted by exportKeySet.
 //
 // Responses:
 //    default: genericError
+//        200: importKeyRes
 func (o *Operation) ImportKey(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.ImportKey, rw, req.Body)
 }
+
+// SignJWS swagger:route POST /kms/jws kms signJWS
+//
+// Sign the payload as a compact JWS with a KMS key.
+//
+// Responses:
+//    default: genericError
+//        200: signJWSRes
+func (o *Operation) SignJWS(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.SignJWS, rw, req.Body)
+}
+
+// ExportPublicKey swagger:route POST /kms/export kms exportPublicKey
+//
+// Export the public key of a key set.
+//
+// Responses:
+//    default: genericError
+//        200: exportPublicKeyRes
+func (o *Operation) ExportPublicKey(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ExportPublicKey, rw, req.Body)
+}
+
+// ExportKeySet swagger:route POST /kms/keyset/export kms exportKeySet
+//
+// Export the private keys of key sets as a JWK set encrypted under a passphrase.
+//
+// Responses:
+//    default: genericError
+//        200: exportKeySetRes
+func (o *Operation) ExportKeySet(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ExportKeySet, rw, req.Body)
+}
@@ -8,6 +8,7 @@ package kms
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -31,7 +32,7 @@ func TestNew(t *testing.T) {
 			KMSValue: &mockkms.KeyManager{},
 		})
 		require.NotNil(t, cmd)
-		require.Equal(t, 2, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 5, len(cmd.GetRESTHandlers()))
 	})
 }
 
@@ -102,6 +103,107 @@ func TestImportKey(t *testing.T) {
 	})
 }
 
+func TestSignJWS(t *testing.T) {
+	t.Run("test sign JWS - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		cmd.command = &mockKMSCommand{}
+
+		handler := lookupHandler(t, cmd, SignJWSPath)
+		err := getSuccessResponseFromHandler(handler, SignJWSPath)
+		require.NoError(t, err)
+	})
+
+	t.Run("test sign JWS - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ExportPubKeyBytesErr: fmt.Errorf("key not found")},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, SignJWSPath)
+
+		req := signJWSReq{SignJWSRequest: kms.SignJWSRequest{
+			KeyID:   "k1",
+			Headers: map[string]interface{}{"alg": "EdDSA"},
+			Payload: "payload",
+		}}
+		reqBytes, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(reqBytes), SignJWSPath)
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.SignJWSError, "key 'k1' not found", buf.Bytes())
+	})
+}
+
+func TestExportPublicKey(t *testing.T) {
+	t.Run("test export public key - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ExportPubKeyBytesValue: []byte("publicKey")},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, ExportPubKeyPath)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"keyID":"k1"}`), ExportPubKeyPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		res := exportPublicKeyRes{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res.ExportPublicKeyResponse))
+		require.Equal(t, "k1", res.KeyID)
+		require.Equal(t, base64.RawURLEncoding.EncodeToString([]byte("publicKey")), res.PublicKey)
+	})
+
+	t.Run("test export public key - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ExportPubKeyBytesErr: fmt.Errorf("key not found")},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, ExportPubKeyPath)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"keyID":"k1"}`), ExportPubKeyPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.ExportPublicKeyError, "key not found", buf.Bytes())
+	})
+}
+
+func TestExportKeySet(t *testing.T) {
+	t.Run("test export key set - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		cmd.command = &mockKMSCommand{}
+
+		handler := lookupHandler(t, cmd, ExportKeySetPath)
+		err := getSuccessResponseFromHandler(handler, ExportKeySetPath)
+		require.NoError(t, err)
+	})
+
+	t.Run("test export key set - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, ExportKeySetPath)
+
+		req := exportKeySetReq{ExportKeySetRequest: kms.ExportKeySetRequest{
+			KeyIDs:     []string{"k1"},
+			Passphrase: "correct-Horse-battery-9",
+		}}
+		reqBytes, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(reqBytes), ExportKeySetPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.ExportKeySetError, "the KMS doesn't export private keys", buf.Bytes())
+	})
+}
+
 func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
@@ -172,6 +274,9 @@ func verifyError(t *testing.T, expectedCode command.Code, expectedMsg string, da
 
 type mockKMSCommand struct {
 	importKeyError command.Error
+	signJWSError   command.Error
+	exportError    command.Error
+	exportSetError command.Error
 }
 
 func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
@@ -181,3 +286,15 @@ func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error
 func (m *mockKMSCommand) ImportKey(rw io.Writer, req io.Reader) command.Error {
 	return m.importKeyError
 }
+
+func (m *mockKMSCommand) SignJWS(rw io.Writer, req io.Reader) command.Error {
+	return m.signJWSError
+}
+
+func (m *mockKMSCommand) ExportPublicKey(rw io.Writer, req io.Reader) command.Error {
+	return m.exportError
+}
+
+func (m *mockKMSCommand) ExportKeySet(rw io.Writer, req io.Reader) command.Error {
+	return m.exportSetError
+}
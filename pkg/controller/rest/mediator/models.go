@@ -22,8 +22,12 @@ type registerRouteReq struct { // nolint: unused,deadcode
 
 // registerRouteRes model
 //
+// Routing configuration granted by the router.
+//
 // swagger:response registerRouteRes
 type registerRouteRes struct { // nolint: unused,deadcode
+	// in: body
+	Params mediator.RegisterRouteResponse
 }
 
 // ConnectionRes model
@@ -36,6 +40,38 @@ type ConnectionRes struct { // nolint: unused,deadcode
 	Params mediator.RegisterRoute
 }
 
+// getConfigRes model
+//
+// response of get router config action
+//
+// swagger:response getConfigResponse
+type getConfigRes struct { // nolint: unused,deadcode
+	// in: body
+	Params mediator.ConfigResponse
+}
+
+// keylistQueryRequest model
+//
+// This is used for querying a page of the recipient keys registered with the router.
+//
+// swagger:parameters keylistQueryRequest
+type keylistQueryRequest struct { // nolint: unused,deadcode
+	// Params for querying the page of recipient keys.
+	//
+	// in: body
+	Params mediator.KeylistQueryRequest
+}
+
+// keylistQueryResponse model
+//
+// Page of the recipient keys registered with the router.
+//
+// swagger:response keylistQueryResponse
+type keylistQueryResponse struct { // nolint: unused,deadcode
+	// in: body
+	Params mediator.KeylistQueryResponse
+}
+
 // reconnectRouteReq model
 //
 // This is used register router for the agent.
@@ -95,3 +131,39 @@ type batchPickupResponse struct { // nolint: unused,deadcode
 	// in: body
 	Params mediator.BatchPickupResponse
 }
+
+// pickupStatusRequest model
+//
+// This is used for querying the registered mediator for the status of the queued messages.
+//
+// swagger:parameters pickupStatusRequest
+type pickupStatusRequest struct { // nolint: unused,deadcode
+	// Params for querying the status of the queued messages.
+	//
+	// in: body
+	Params mediator.StatusRequest
+}
+
+// deliveryRequest model
+//
+// This is used for requesting a batch of the messages queued by the registered mediator.
+//
+// swagger:parameters deliveryRequest
+type deliveryRequest struct { // nolint: unused,deadcode
+	// Params for requesting a batch of the queued messages.
+	//
+	// in: body
+	Params mediator.DeliveryRequest
+}
+
+// deliveryResponse model
+//
+// Response containing the batch of messages delivered by the registered mediator.
+//
+// swagger:response deliveryResponse
+type deliveryResponse struct { // nolint: unused,deadcode
+	// Batch of delivered messages.
+	//
+	// in: body
+	Params mediator.DeliveryResponse
+}
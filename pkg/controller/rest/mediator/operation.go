@@ -17,13 +17,17 @@ import (
 
 // constants for the mediator operations
 const (
-	RouteOperationID  = "/mediator"
-	RegisterPath      = RouteOperationID + "/register"
-	UnregisterPath    = RouteOperationID + "/unregister"
-	GetConnectionPath = RouteOperationID + "/connection"
-	ReconnectPath     = RouteOperationID + "/reconnect"
-	StatusPath        = RouteOperationID + "/status"
-	BatchPickupPath   = RouteOperationID + "/batchpickup"
+	RouteOperationID   = "/mediator"
+	RegisterPath       = RouteOperationID + "/register"
+	UnregisterPath     = RouteOperationID + "/unregister"
+	GetConnectionPath  = RouteOperationID + "/connection"
+	ReconnectPath      = RouteOperationID + "/reconnect"
+	StatusPath         = RouteOperationID + "/status"
+	BatchPickupPath    = RouteOperationID + "/batchpickup"
+	GetConfigPath      = RouteOperationID + "/config"
+	KeylistQueryPath   = RouteOperationID + "/keylist"
+	PickupStatusPath   = RouteOperationID + "/pickup/status"
+	PickupDeliveryPath = RouteOperationID + "/pickup/delivery"
 )
 
 // provider contains dependencies for the route protocol and is typically created by using aries.Context().
@@ -66,6 +70,10 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(ReconnectPath, http.MethodPost, o.Reconnect),
 		cmdutil.NewHTTPHandler(StatusPath, http.MethodPost, o.Status),
 		cmdutil.NewHTTPHandler(BatchPickupPath, http.MethodPost, o.BatchPickup),
+		cmdutil.NewHTTPHandler(GetConfigPath, http.MethodGet, o.GetConfig),
+		cmdutil.NewHTTPHandler(KeylistQueryPath, http.MethodPost, o.KeylistQuery),
+		cmdutil.NewHTTPHandler(PickupStatusPath, http.MethodPost, o.StatusRequest),
+		cmdutil.NewHTTPHandler(PickupDeliveryPath, http.MethodPost, o.DeliveryRequest),
 	}
 }
 
@@ -74,8 +82,9 @@ func (o *Operation) registerHandler() {
 // Registers the agent with the router.
 //
 // Responses:
-//    default: genericError
-//    200: registerRouteRes
+//
+//	default: genericError
+//	200: registerRouteRes
 func (o *Operation) Register(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Register, rw, req.Body)
 }
@@ -85,7 +94,8 @@ func (o *Operation) Register(rw http.ResponseWriter, req *http.Request) {
 // Unregisters the agent with the router.
 //
 // Responses:
-//    default: genericError
+//
+//	default: genericError
 func (o *Operation) Unregister(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Unregister, rw, req.Body)
 }
@@ -95,18 +105,44 @@ func (o *Operation) Unregister(rw http.ResponseWriter, req *http.Request) {
 // Retrieves the router connection id.
 //
 // Responses:
-//    default: genericError
-//    200: getConnectionResponse
+//
+//	default: genericError
+//	200: getConnectionResponse
 func (o *Operation) Connection(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Connection, rw, req.Body)
 }
 
+// GetConfig swagger:route GET /mediator/config mediator routerConfig
+//
+// Retrieves the router connection id and routing configuration.
+//
+// Responses:
+//
+//	default: genericError
+//	200: getConfigResponse
+func (o *Operation) GetConfig(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.GetConfig, rw, req.Body)
+}
+
+// KeylistQuery swagger:route POST /mediator/keylist mediator keylistQueryRequest
+//
+// Retrieves a page of the recipient keys registered with the router.
+//
+// Responses:
+//
+//	default: genericError
+//	200: keylistQueryResponse
+func (o *Operation) KeylistQuery(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.KeylistQuery, rw, req.Body)
+}
+
 // Reconnect swagger:route POST /mediator/reconnect mediator reconnectRouteRequest
 //
 // Reconnect the agent with the router to re-establish lost connection.
 //
 // Responses:
-//    default: genericError
+//
+//	default: genericError
 func (o *Operation) Reconnect(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Reconnect, rw, req.Body)
 }
@@ -116,8 +152,9 @@ func (o *Operation) Reconnect(rw http.ResponseWriter, req *http.Request) {
 // Status returns details about pending messages for given connection.
 //
 // Responses:
-//    default: genericError
-//    200: statusResponse
+//
+//	default: genericError
+//	200: statusResponse
 func (o *Operation) Status(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Status, rw, req.Body)
 }
@@ -127,8 +164,33 @@ func (o *Operation) Status(rw http.ResponseWriter, req *http.Request) {
 // BatchPickup dispatches pending messages for given connection.
 //
 // Responses:
-//    default: genericError
-//    200: batchPickupResponse
+//
+//	default: genericError
+//	200: batchPickupResponse
 func (o *Operation) BatchPickup(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.BatchPickup, rw, req.Body)
 }
+
+// StatusRequest swagger:route POST /mediator/pickup/status mediator pickupStatusRequest
+//
+// StatusRequest queries the registered mediator for the status of the messages queued for the agent.
+//
+// Responses:
+//
+//	default: genericError
+//	200: statusResponse
+func (o *Operation) StatusRequest(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.StatusRequest, rw, req.Body)
+}
+
+// DeliveryRequest swagger:route POST /mediator/pickup/delivery mediator deliveryRequest
+//
+// DeliveryRequest requests a batch of the messages queued for the agent by the registered mediator.
+//
+// Responses:
+//
+//	default: genericError
+//	200: deliveryResponse
+func (o *Operation) DeliveryRequest(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.DeliveryRequest, rw, req.Body)
+}
@@ -71,7 +71,7 @@ func TestOperation_GetRESTHandlers(t *testing.T) {
 	require.NotNil(t, svc)
 
 	handlers := svc.GetRESTHandlers()
-	require.Equal(t, len(handlers), 6)
+	require.Equal(t, len(handlers), 10)
 }
 
 func TestOperation_Register(t *testing.T) {
@@ -80,7 +80,10 @@ func TestOperation_Register(t *testing.T) {
 			&mockprovider.Provider{
 				ServiceMap: map[string]interface{}{
 					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
-					mediatorSvc.Coordination:       &mockroute.MockMediatorSvc{},
+					mediatorSvc.Coordination: &mockroute.MockMediatorSvc{
+						RouterEndpoint: "http://router.example.com",
+						RoutingKeys:    []string{"key-1"},
+					},
 				},
 			},
 			false,
@@ -94,12 +97,16 @@ func TestOperation_Register(t *testing.T) {
 		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
 		require.NoError(t, err)
 
-		response := registerRouteRes{}
+		response := mediator.RegisterRouteResponse{}
 		err = json.Unmarshal(buf.Bytes(), &response)
 		require.NoError(t, err)
 
 		// verify response
-		require.Empty(t, response)
+		require.Equal(t, mediator.RegisterRouteResponse{
+			ConnectionID:   "abc-123",
+			RouterEndpoint: "http://router.example.com",
+			RoutingKeys:    []string{"key-1"},
+		}, response)
 	})
 
 	t.Run("test register route - missing connectionID", func(t *testing.T) {
@@ -232,6 +239,131 @@ func TestOperation_Connection(t *testing.T) {
 	})
 }
 
+func TestOperation_GetConfig(t *testing.T) {
+	t.Run("test get config - success", func(t *testing.T) {
+		svc, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediatorSvc.Coordination: &mockroute.MockMediatorSvc{
+						ConnectionID:   "conn-abc",
+						RouterEndpoint: "http://router.example.com",
+						RoutingKeys:    []string{"key-1"},
+					},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, GetConfigPath)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer([]byte("")), handler.Path())
+		require.NoError(t, err)
+
+		response := getConfigRes{}
+		err = json.Unmarshal(buf.Bytes(), &response.Params)
+		require.NoError(t, err)
+
+		// verify response
+		require.Equal(t, "conn-abc", response.Params.ConnectionID)
+		require.Equal(t, "http://router.example.com", response.Params.RouterEndpoint)
+		require.Equal(t, []string{"key-1"}, response.Params.RoutingKeys)
+	})
+
+	t.Run("test get config - router not registered", func(t *testing.T) {
+		svc, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediatorSvc.Coordination: &mockroute.MockMediatorSvc{
+						GetConnectionIDErr: mediatorSvc.ErrRouterNotRegistered,
+					},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, GetConfigPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer([]byte("")), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, mediator.GetConfigErrorCode, "router not registered", buf.Bytes())
+	})
+}
+
+func TestOperation_KeylistQuery(t *testing.T) {
+	t.Run("test keylist query - success", func(t *testing.T) {
+		svc, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediatorSvc.Coordination: &mockroute.MockMediatorSvc{
+						KeylistQueryFunc: func(limit, offset int) (*mediatorSvc.Keylist, error) {
+							require.Equal(t, 2, limit)
+							require.Equal(t, 4, offset)
+
+							return &mediatorSvc.Keylist{
+								Keys:       []mediatorSvc.Key{{RecipientKey: "key-4"}, {RecipientKey: "key-5"}},
+								Pagination: &mediatorSvc.Pagination{Count: 2, Offset: 4, Remaining: 3},
+							}, nil
+						},
+					},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, KeylistQueryPath)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"limit":2,"offset":4}`),
+			handler.Path())
+		require.NoError(t, err)
+
+		response := keylistQueryResponse{}
+		err = json.Unmarshal(buf.Bytes(), &response.Params)
+		require.NoError(t, err)
+
+		// verify response
+		require.Equal(t, mediator.KeylistQueryResponse{
+			RecipientKeys: []string{"key-4", "key-5"},
+			Remaining:     3,
+			NextOffset:    6,
+		}, response.Params)
+	})
+
+	t.Run("test keylist query - error", func(t *testing.T) {
+		svc, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediatorSvc.Coordination: &mockroute.MockMediatorSvc{
+						KeylistQueryFunc: func(limit, offset int) (*mediatorSvc.Keylist, error) {
+							return nil, errors.New("keylist query error")
+						},
+					},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, KeylistQueryPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, mediator.KeylistQueryErrorCode, "keylist query error", buf.Bytes())
+	})
+}
+
 func TestOperation_Reconnect(t *testing.T) {
 	t.Run("test register route - success", func(t *testing.T) {
 		svc, err := New(
@@ -388,6 +520,101 @@ func TestOperation_BatchPickup(t *testing.T) {
 	})
 }
 
+func TestOperation_StatusRequest(t *testing.T) {
+	t.Run("test status request - success", func(t *testing.T) {
+		svc, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{
+						StatusRequestFunc: func(connectionID string) (*messagepickupSvc.Status, error) {
+							return &messagepickupSvc.Status{MessageCount: 3}, nil
+						},
+					},
+					mediatorSvc.Coordination: &mockroute.MockMediatorSvc{ConnectionID: "abc-123"},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, svc, PickupStatusPath)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer([]byte(connIDRequest)), handler.Path())
+		require.NoError(t, err)
+
+		response := statusResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response.Params))
+		require.Equal(t, 3, response.Params.MessageCount)
+	})
+
+	t.Run("test status request - not the mediator connection", func(t *testing.T) {
+		svc, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediatorSvc.Coordination:       &mockroute.MockMediatorSvc{ConnectionID: "xyz"},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, svc, PickupStatusPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer([]byte(connIDRequest)), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, mediator.InvalidMediatorConnectionErrorCode, "not the mediator connection", buf.Bytes())
+	})
+}
+
+func TestOperation_DeliveryRequest(t *testing.T) {
+	t.Run("test delivery request - success", func(t *testing.T) {
+		svc, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{
+						DeliveryRequestFunc: func(connectionID string, size int) (*messagepickupSvc.Batch, error) {
+							return &messagepickupSvc.Batch{Messages: []*messagepickupSvc.Message{{ID: "msg-1"}}}, nil
+						},
+					},
+					mediatorSvc.Coordination: &mockroute.MockMediatorSvc{ConnectionID: "abc-123"},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, svc, PickupDeliveryPath)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer([]byte(connIDRequest)), handler.Path())
+		require.NoError(t, err)
+
+		response := deliveryResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response.Params))
+		require.Len(t, response.Params.Messages, 1)
+		require.Equal(t, "msg-1", response.Params.Messages[0].ID)
+	})
+
+	t.Run("test delivery request - missing connectionID", func(t *testing.T) {
+		svc, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediatorSvc.Coordination:       &mockroute.MockMediatorSvc{},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, svc, PickupDeliveryPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer([]byte(`{}`)), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, mediator.DeliveryRequestMissingConnIDCode, "connectionID is mandatory", buf.Bytes())
+	})
+}
+
 func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
@@ -58,6 +58,107 @@ type SendReplyMessageRequest struct { // nolint: unused,deadcode
 	Params messaging.SendReplyMessageArgs
 }
 
+// ResendMessageRequest model
+//
+// This is used for operation to resend the last message of a thread
+//
+// swagger:parameters resendMessage
+type ResendMessageRequest struct { // nolint: unused,deadcode
+	// Params for resending the message
+	//
+	// in: body
+	Params messaging.ResendMessageArgs
+}
+
+// messageTraceRequest model
+//
+// This is used for operation to get the trace of a thread
+//
+// swagger:parameters getMessageTrace
+type messageTraceRequest struct { // nolint: unused,deadcode
+	// The ID of the thread
+	//
+	// in: path
+	// required: true
+	ThreadID string `json:"thread_id"`
+}
+
+// messageTraceResponse model
+//
+// This is used for returning the protocol steps of a thread
+//
+// swagger:response messageTraceResponse
+type messageTraceResponse struct { // nolint: unused,deadcode
+	// in: body
+	messaging.MessageTraceResponse
+}
+
+// SendBasicMessageRequest model
+//
+// This is used for operation to send basic message over a connection
+//
+// swagger:parameters sendBasicMessage
+type SendBasicMessageRequest struct { // nolint: unused,deadcode
+	// Params for sending basic message
+	//
+	// in: body
+	Params messaging.SendBasicMessageArgs
+}
+
+// sendBasicMessageResponse model
+//
+// This is used for returning the ID of the basic message sent
+//
+// swagger:response sendBasicMessageResponse
+type sendBasicMessageResponse struct { // nolint: unused,deadcode
+	// in: body
+	messaging.SendBasicMessageResponse
+}
+
+// packMessageRequest model
+//
+// This is used for operation to pack a message in an encryption envelope
+//
+// swagger:parameters packMessage
+type packMessageRequest struct { // nolint: unused,deadcode
+	// Params for packing a message
+	//
+	// in: body
+	Params messaging.PackMessageArgs
+}
+
+// packMessageResponse model
+//
+// This is used for returning the encryption envelope of the packed message
+//
+// swagger:response packMessageResponse
+type packMessageResponse struct { // nolint: unused,deadcode
+	// in: body
+	messaging.PackMessageResponse
+}
+
+// unpackMessageRequest model
+//
+// This is used for operation to unpack an encryption envelope
+//
+// swagger:parameters unpackMessage
+type unpackMessageRequest struct { // nolint: unused,deadcode
+	// Params for unpacking an encryption envelope
+	//
+	// in: body
+	Params messaging.UnpackMessageArgs
+}
+
+// unpackMessageResponse model
+//
+// This is used for returning the unpacked message along with the sender and recipient keys
+//
+// swagger:response unpackMessageResponse
+type unpackMessageResponse struct { // nolint: unused,deadcode
+	// in: body
+	messaging.UnpackMessageResponse
+}
+
 // RegisterHTTPMessageServiceRequest model
 //
 // This is used for operation to register an HTTP over DIDComm message service to message handler
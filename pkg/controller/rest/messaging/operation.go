@@ -7,14 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package messaging
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
@@ -33,6 +39,11 @@ const (
 	MsgServiceList        = MsgServiceOperationID + "/services"
 	SendNewMsg            = MsgServiceOperationID + "/send"
 	SendReplyMsg          = MsgServiceOperationID + "/reply"
+	SendBasicMsg          = MsgServiceOperationID + "/send-basic"
+	PackMsg               = MsgServiceOperationID + "/pack"
+	UnpackMsg             = MsgServiceOperationID + "/unpack"
+	ResendMsg             = MsgServiceOperationID + "/resend"
+	MsgTrace              = MsgServiceOperationID + "/trace/{thread_id}"
 )
 
 // provider contains dependencies for the common controller operations
@@ -40,9 +51,11 @@ const (
 type provider interface {
 	VDRIRegistry() vdri.Registry
 	Messenger() service.Messenger
+	OutboundDispatcher() dispatcher.Outbound
 	ProtocolStateStorageProvider() storage.Provider
 	StorageProvider() storage.Provider
 	KMS() kms.KeyManager
+	Packers() []packer.Packer
 }
 
 // Operation contains basic common operations provided by controller REST API.
@@ -78,6 +91,11 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(MsgServiceList, http.MethodGet, o.Services),
 		cmdutil.NewHTTPHandler(SendNewMsg, http.MethodPost, o.Send),
 		cmdutil.NewHTTPHandler(SendReplyMsg, http.MethodPost, o.Reply),
+		cmdutil.NewHTTPHandler(SendBasicMsg, http.MethodPost, o.SendMessage),
+		cmdutil.NewHTTPHandler(PackMsg, http.MethodPost, o.PackMessage),
+		cmdutil.NewHTTPHandler(UnpackMsg, http.MethodPost, o.UnpackMessage),
+		cmdutil.NewHTTPHandler(ResendMsg, http.MethodPost, o.Resend),
+		cmdutil.NewHTTPHandler(MsgTrace, http.MethodGet, o.GetMessageTrace),
 		cmdutil.NewHTTPHandler(RegisterHTTPOverDIDCommService, http.MethodPost, o.RegisterHTTPService),
 	}
 }
@@ -133,6 +151,66 @@ func (o *Operation) Reply(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.Reply, rw, req.Body)
 }
 
+// Resend swagger:route POST /message/resend message resendMessage
+//
+// resends the last message sent on a thread, the same message ID is kept
+//
+// Responses:
+//    default: genericError
+func (o *Operation) Resend(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Resend, rw, req.Body)
+}
+
+// GetMessageTrace swagger:route GET /message/trace/{thread_id} message getMessageTrace
+//
+// returns the protocol steps of a thread, the messages received and sent on the thread in the order they happened
+//
+// Responses:
+//    default: genericError
+//    200: messageTraceResponse
+func (o *Operation) GetMessageTrace(rw http.ResponseWriter, req *http.Request) {
+	request, err := json.Marshal(&messaging.MessageTraceArgs{ThreadID: mux.Vars(req)["thread_id"]})
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, messaging.InvalidRequestErrorCode, err)
+		return
+	}
+
+	rest.Execute(o.command.GetMessageTrace, rw, bytes.NewReader(request))
+}
+
+// SendMessage swagger:route POST /message/send-basic message sendBasicMessage
+//
+// sends basic message over a completed connection
+//
+// Responses:
+//    default: genericError
+//    200: sendBasicMessageResponse
+func (o *Operation) SendMessage(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.SendMessage, rw, req.Body)
+}
+
+// PackMessage swagger:route POST /message/pack message packMessage
+//
+// packs message in an encryption envelope, with authcrypt if the sender key ID is provided and with anoncrypt otherwise
+//
+// Responses:
+//    default: genericError
+//    200: packMessageResponse
+func (o *Operation) PackMessage(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.PackMessage, rw, req.Body)
+}
+
+// UnpackMessage swagger:route POST /message/unpack message unpackMessage
+//
+// unpacks encryption envelope with the matching recipient key
+//
+// Responses:
+//    default: genericError
+//    200: unpackMessageResponse
+func (o *Operation) UnpackMessage(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.UnpackMessage, rw, req.Body)
+}
+
 // RegisterHTTPService swagger:route POST /http-over-didcomm/register http-over-didcomm registerHttpMsgSvc
 //
 // registers new http over didcomm service to message handler registrar
@@ -8,6 +8,7 @@ package messaging
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,18 +23,27 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	svchttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/http"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 	mocksvc "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
@@ -113,7 +123,7 @@ func TestOperation_RegisterService(t *testing.T) {
 				errorMsg:  errMsgSvcNameRequired,
 			},
 			{
-				name:      "missing type test",
+				name:      "missing type and purpose test",
 				json:      `{"name": "svx-001"}`,
 				errorCode: messaging.InvalidRequestErrorCode,
 				errorMsg:  errMsgInvalidAcceptanceCrit,
@@ -706,6 +716,220 @@ func TestOperation_Reply(t *testing.T) {
 	})
 }
 
+func TestOperation_Resend(t *testing.T) {
+	t.Run("Test resend message", func(t *testing.T) {
+		var resent string
+
+		svc, err := New(&protocol.MockProvider{CustomMessenger: &mocksvc.MockMessenger{
+			ResendFunc: func(thID string) error {
+				resent = thID
+
+				return nil
+			},
+		}}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		handler := lookupCreatePublicDIDHandler(t, svc, ResendMsg)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"thread_ID": "piid-1"}`), handler.Path())
+		require.NoError(t, err)
+		require.Empty(t, buf)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "piid-1", resent)
+	})
+
+	t.Run("Test resend message to unknown thread", func(t *testing.T) {
+		svc, err := New(&protocol.MockProvider{CustomMessenger: &mocksvc.MockMessenger{
+			ErrResend: fmt.Errorf("sample-err-01"),
+		}}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+
+		handler := lookupCreatePublicDIDHandler(t, svc, ResendMsg)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"thread_ID": "piid-1"}`), handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, messaging.ResendMsgError, "sample-err-01", buf.Bytes())
+	})
+}
+
+func TestOperation_GetMessageTrace(t *testing.T) {
+	tracer := dispatcher.NewTracer()
+	tracer.Record(service.DIDCommMsgMap{
+		"@id":   "thread-1",
+		"@type": "https://didcomm.org/issue-credential/2.0/propose-credential",
+	}, dispatcher.Span{Direction: dispatcher.DirectionInbound})
+
+	svc, err := New(&tracingProvider{
+		provider: &protocol.MockProvider{},
+		outbound: &tracingOutbound{tracer: tracer},
+	}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	handler := lookupCreatePublicDIDHandler(t, svc, MsgTrace)
+
+	t.Run("Test get the trace of a thread", func(t *testing.T) {
+		buf, err := getSuccessResponseFromHandler(handler, nil, MsgServiceOperationID+"/trace/thread-1")
+		require.NoError(t, err)
+
+		response := &messaging.MessageTraceResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), response))
+		require.Equal(t, "thread-1", response.ThreadID)
+		require.Len(t, response.Steps, 1)
+		require.Equal(t, dispatcher.DirectionInbound, response.Steps[0].Direction)
+	})
+
+	t.Run("Test get the trace of an unknown thread", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, nil, MsgServiceOperationID+"/trace/unknown")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, messaging.GetMessageTraceError, "no message traced on thread `unknown`", buf.Bytes())
+	})
+}
+
+// tracingProvider provides an outbound dispatcher tracing messages.
+type tracingProvider struct {
+	provider
+	outbound dispatcher.Outbound
+}
+
+func (p *tracingProvider) OutboundDispatcher() dispatcher.Outbound {
+	return p.outbound
+}
+
+type tracingOutbound struct {
+	mockdispatcher.MockOutbound
+	tracer *dispatcher.Tracer
+}
+
+func (o *tracingOutbound) Tracer() *dispatcher.Tracer {
+	return o.tracer
+}
+
+func TestOperation_SendMessage(t *testing.T) {
+	newOperation := func(t *testing.T) *Operation {
+		mockStore := &storage.MockStore{Store: make(map[string][]byte)}
+
+		connBytes, err := json.Marshal(&connection.Record{ConnectionID: "conn-001",
+			State: "completed", MyDID: "mydid", TheirDID: "theirdid"})
+		require.NoError(t, err)
+		require.NoError(t, mockStore.Put("conn_conn-001", connBytes))
+
+		svc, err := New(&protocol.MockProvider{StoreProvider: storage.NewCustomMockStoreProvider(mockStore)},
+			msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		return svc
+	}
+
+	t.Run("Test send basic message", func(t *testing.T) {
+		handler := lookupCreatePublicDIDHandler(t, newOperation(t), SendBasicMsg)
+		buf, err := getSuccessResponseFromHandler(handler,
+			bytes.NewBufferString(`{"connection_ID": "conn-001", "content": "hello"}`), handler.Path())
+		require.NoError(t, err)
+
+		response := sendBasicMessageResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.NotEmpty(t, response.ID)
+	})
+
+	t.Run("Test send basic message to unknown connection", func(t *testing.T) {
+		handler := lookupCreatePublicDIDHandler(t, newOperation(t), SendBasicMsg)
+		buf, code, err := sendRequestToHandler(handler,
+			bytes.NewBufferString(`{"connection_ID": "conn-002", "content": "hello"}`), handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, messaging.InvalidRequestErrorCode, "connection `conn-002` not found", buf.Bytes())
+	})
+}
+
+func TestOperation_PackUnpackMessage(t *testing.T) {
+	storeProvider := storage.NewMockStoreProvider()
+
+	k, err := localkms.New("local-lock://test/key/uri",
+		mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	anonPacker, err := anoncrypt.New(&mockprovider.Provider{KMSValue: k, StorageProviderValue: storeProvider},
+		jose.A256GCM)
+	require.NoError(t, err)
+
+	svc, err := New(&protocol.MockProvider{
+		StoreProvider: storeProvider,
+		CustomKMS:     k,
+		CustomPackers: []packer.Packer{anonPacker},
+	}, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+	require.NoError(t, err)
+
+	kid, _, err := k.Create(kms.ECDHES256AES256GCMType)
+	require.NoError(t, err)
+
+	pubKey, err := k.ExportPubKeyBytes(kid)
+	require.NoError(t, err)
+
+	t.Run("Test pack and unpack message", func(t *testing.T) {
+		request, err := json.Marshal(&messaging.PackMessageArgs{
+			Message: json.RawMessage(`{"msg":"hello"}`),
+			RecipientKeys: []messaging.RecipientKey{
+				{KeyID: kid, PublicKey: base64.RawURLEncoding.EncodeToString(pubKey)},
+			},
+		})
+		require.NoError(t, err)
+
+		handler := lookupCreatePublicDIDHandler(t, svc, PackMsg)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(request), handler.Path())
+		require.NoError(t, err)
+
+		packed := packMessageResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &packed))
+		require.NotEmpty(t, packed.Envelope)
+
+		request, err = json.Marshal(&messaging.UnpackMessageArgs{Envelope: packed.Envelope})
+		require.NoError(t, err)
+
+		handler = lookupCreatePublicDIDHandler(t, svc, UnpackMsg)
+		buf, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(request), handler.Path())
+		require.NoError(t, err)
+
+		unpacked := unpackMessageResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &unpacked))
+		require.JSONEq(t, `{"msg":"hello"}`, string(unpacked.Message))
+		require.Empty(t, unpacked.SenderKey)
+	})
+
+	t.Run("Test pack message without recipient keys", func(t *testing.T) {
+		handler := lookupCreatePublicDIDHandler(t, svc, PackMsg)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"message": {"msg":"hello"}}`),
+			handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, messaging.InvalidRequestErrorCode, "empty recipient keys", buf.Bytes())
+	})
+
+	t.Run("Test unpack message without matching recipient", func(t *testing.T) {
+		other, err := localkms.New("local-lock://test/key/uri",
+			mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{}))
+		require.NoError(t, err)
+
+		otherKID, _, err := other.Create(kms.ECDHES256AES256GCMType)
+		require.NoError(t, err)
+
+		otherPubKey, err := other.ExportPubKeyBytes(otherKID)
+		require.NoError(t, err)
+
+		envelope, err := anonPacker.Pack([]byte(`{"msg":"hello"}`), nil, [][]byte{otherPubKey})
+		require.NoError(t, err)
+
+		request, err := json.Marshal(&messaging.UnpackMessageArgs{Envelope: string(envelope)})
+		require.NoError(t, err)
+
+		handler := lookupCreatePublicDIDHandler(t, svc, UnpackMsg)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(request), handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, messaging.UnpackMsgError, "no matching recipient in envelope", buf.Bytes())
+	})
+}
+
 func lookupCreatePublicDIDHandler(t *testing.T, op *Operation, path string) rest.Handler {
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
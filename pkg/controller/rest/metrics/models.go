@@ -0,0 +1,26 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import "github.com/hyperledger/aries-framework-go/pkg/controller/command/metrics"
+
+// getMetricsRequest model
+//
+// Returns the counters of the agent.
+//
+// swagger:parameters getMetrics
+type getMetricsRequest struct{} // nolint: unused,deadcode
+
+// getMetricsResponse model
+//
+// Represents GetMetrics response message.
+//
+// swagger:response getMetricsResponse
+type getMetricsResponse struct { // nolint: unused,deadcode
+	// in: body
+	metrics.MetricsResponse
+}
@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the metrics operation
+const (
+	OperationID = "/metrics"
+	GetMetrics  = OperationID
+)
+
+// Operation is controller REST service controller exposing the runtime counters of the agent.
+type Operation struct {
+	command  *metrics.Command
+	handlers []rest.Handler
+}
+
+// New returns new metrics rest controller instance.
+func New(ctx metrics.Provider) (*Operation, error) {
+	cmd, err := metrics.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("metrics command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(GetMetrics, http.MethodGet, c.GetMetrics),
+	}
+}
+
+// GetMetrics swagger:route GET /metrics metrics getMetrics
+//
+// Returns the counters of the agent: active connections, credentials issued, proofs verified and messages sent
+// and received.
+//
+// Responses:
+//    default: genericError
+//        200: getMetricsResponse
+func (c *Operation) GetMetrics(rw http.ResponseWriter, _ *http.Request) {
+	rest.Execute(c.command.GetMetrics, rw, nil)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/metrics"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestOperation_GetMetrics(t *testing.T) {
+	operation, err := New(&mockprovider.Provider{
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+	})
+	require.NoError(t, err)
+
+	handlers := operation.GetRESTHandlers()
+	require.Len(t, handlers, 1)
+	require.Equal(t, GetMetrics, handlers[0].Path())
+	require.Equal(t, http.MethodGet, handlers[0].Method())
+
+	req, err := http.NewRequest(http.MethodGet, GetMetrics, nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handlers[0].Handle()(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var res metrics.MetricsResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	require.Equal(t, metrics.MetricsResponse{}, res)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&mockprovider.Provider{
+		StorageProviderValue: &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("open failed")},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "metrics command")
+}
@@ -54,6 +54,16 @@ type outofbandCreateInvitationRequest struct { // nolint: unused,deadcode
 		GoalCode  string        `json:"goal_code"`
 		Service   []interface{} `json:"service"`
 		Protocols []string      `json:"protocols"`
+		PublicDID string        `json:"public_did,omitempty"`
+
+		// InvitationURLBase is the absolute http(s) URL of the invitation URL, defaults to the agent's endpoint.
+		InvitationURLBase string `json:"invitation_url_base,omitempty"`
+
+		// InvitationURLEncoding is the query parameter carrying the invitation, either 'oob' (default) or 'c_i'.
+		InvitationURLEncoding string `json:"invitation_url_encoding,omitempty"`
+
+		// Attachments are intended to provide the possibility to include files, links or even JSON payload.
+		Attachments []*decorator.Attachment `json:"attachments,omitempty"`
 	}
 }
 
@@ -65,7 +75,8 @@ type outofbandCreateInvitationRequest struct { // nolint: unused,deadcode
 type outofbandCreateInvitationResponse struct { // nolint: unused,deadcode
 	// in: body
 	Body struct {
-		Invitation struct{ *protocol.Invitation } `json:"invitation"`
+		Invitation    struct{ *protocol.Invitation } `json:"invitation"`
+		InvitationURL string                         `json:"invitation_url"`
 	}
 }
 
@@ -102,8 +113,9 @@ type outofbandAcceptRequestResponse struct { // nolint: unused,deadcode
 type outofbandAcceptInvitationRequest struct { // nolint: unused,deadcode
 	// in: body
 	Body struct {
-		Invitation struct{ *protocol.Invitation } `json:"invitation"`
-		MyLabel    string                         `json:"my_label"`
+		Invitation    struct{ *protocol.Invitation } `json:"invitation"`
+		MyLabel       string                         `json:"my_label"`
+		InvitationURL string                         `json:"invitation_url,omitempty"`
 	}
 }
 
@@ -46,6 +46,7 @@ func provider(ctrl *gomock.Controller) client.Provider {
 
 	provider := mocks.NewMockProvider(ctrl)
 	provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+	provider.EXPECT().ServiceEndpoint().Return("http://example.com/agent").AnyTimes()
 
 	return provider
 }
@@ -108,6 +109,7 @@ func TestOperation_CreateInvitation(t *testing.T) {
 	res := make(map[string]interface{})
 	require.NoError(t, json.Unmarshal(b.Bytes(), &res))
 	require.NotEmpty(t, res["invitation"])
+	require.Contains(t, res["invitation_url"], "http://example.com/agent?oob=")
 }
 
 func TestOperation_AcceptInvitation(t *testing.T) {
@@ -120,7 +122,7 @@ func TestOperation_AcceptInvitation(t *testing.T) {
 	b, code, err := sendRequestToHandler(
 		handlerLookup(t, operation, AcceptInvitation),
 		bytes.NewBufferString(`{
-			"invitation":{},
+			"invitation":{"@id":"1","@type":"https://didcomm.org/oob-invitation/1.0/invitation","service":["s1"]},
 			"my_label":"label"
 		}`),
 		AcceptInvitation,
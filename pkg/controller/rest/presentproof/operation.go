@@ -17,7 +17,6 @@ import (
 
 	"github.com/gorilla/mux"
 
-	client "github.com/hyperledger/aries-framework-go/pkg/client/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
@@ -47,7 +46,7 @@ type Operation struct {
 }
 
 // New returns new present proof rest client protocol instance.
-func New(ctx client.Provider, notifier command.Notifier) (*Operation, error) {
+func New(ctx presentproof.Provider, notifier command.Notifier) (*Operation, error) {
 	cmd, err := presentproof.New(ctx, notifier)
 	if err != nil {
 		return nil, fmt.Errorf("present proof command : %w", err)
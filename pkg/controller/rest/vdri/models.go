@@ -49,6 +49,27 @@ type resolveDIDReq struct { // nolint: unused,deadcode
 	// in: path
 	// required: true
 	ID string `json:"id"`
+
+	// IncludeMetadata - returns the did resolution metadata along with the did document if set
+	//
+	// in: query
+	IncludeMetadata bool `json:"includeMetadata"`
+
+	// ContextVersion - converts the did document to the given context version (v0.11 or v1) if set
+	//
+	// in: query
+	ContextVersion string `json:"contextVersion"`
+}
+
+// resolveDIDRes model
+//
+// This is used for returning the resolved did document, along with its resolution metadata if requested
+//
+// swagger:response resolveDIDRes
+type resolveDIDRes struct { // nolint: unused,deadcode
+
+	// in: body
+	vdricommand.ResolveDIDResponse
 }
 
 // documentRes model
@@ -71,3 +92,195 @@ type didRecordResult struct {
 	// in: body
 	Result []*didstore.Record `json:"result,omitempty"`
 }
+
+// createPublicDIDReq model
+//
+// This is used to create a new public DID.
+//
+// swagger:parameters createPublicDIDReq
+type createPublicDIDReq struct { // nolint: unused,deadcode
+	// Params for creating public DID
+	//
+	// in: body
+	Params vdricommand.CreatePublicDIDArgs
+}
+
+// createPublicDIDRes model
+//
+// This is used for returning the created public DID or the dry run result.
+//
+// swagger:response createPublicDIDRes
+type createPublicDIDRes struct { // nolint: unused,deadcode
+
+	// in: body
+	vdricommand.CreatePublicDIDResponse
+}
+
+// createPublicDIDAsyncReq model
+//
+// This is used to start the asynchronous creation of a new public DID.
+//
+// swagger:parameters createPublicDIDAsyncReq
+type createPublicDIDAsyncReq struct { // nolint: unused,deadcode
+	// Params for creating public DID
+	//
+	// in: body
+	Params vdricommand.CreatePublicDIDArgs
+}
+
+// didCreateStatusReq model
+//
+// This is used to get the status of an asynchronous public DID creation.
+//
+// swagger:parameters didCreateStatusReq
+type didCreateStatusReq struct { // nolint: unused,deadcode
+	// Job ID of the DID creation
+	//
+	// in: path
+	// required: true
+	JobID string `json:"jobID"`
+}
+
+// didCreateJobRes model
+//
+// This is used for returning the job of an asynchronous public DID creation.
+//
+// swagger:response didCreateJobRes
+type didCreateJobRes struct { // nolint: unused,deadcode
+
+	// in: body
+	vdricommand.DIDCreateJob
+}
+
+// registerVDRIReq model
+//
+// This is used to register a HTTP binding vdri at runtime.
+//
+// swagger:parameters registerVDRIReq
+type registerVDRIReq struct { // nolint: unused,deadcode
+	// Params for registering the vdri
+	//
+	// in: body
+	Params vdricommand.RegisterVDRIArgs
+}
+
+// diffDIDReq model
+//
+// This is used to compare two did documents, the old did document is resolved from the id of the new one if missing.
+//
+// swagger:parameters diffDIDReq
+type diffDIDReq struct { // nolint: unused,deadcode
+	// Params for comparing the did documents
+	//
+	// in: body
+	Params vdricommand.DiffDIDArgs
+}
+
+// diffDIDRes model
+//
+// This is used for returning the diff between two did documents.
+//
+// swagger:response diffDIDRes
+type diffDIDRes struct { // nolint: unused,deadcode
+	// in: body
+	vdricommand.DiffDIDResponse
+}
+
+// importDIDReq model
+//
+// This is used to import an externally created did document.
+//
+// swagger:parameters importDIDReq
+type importDIDReq struct { // nolint: unused,deadcode
+	// Params for importing the did document
+	//
+	// in: body
+	Params vdricommand.ImportDIDArgs
+}
+
+// importDIDRes model
+//
+// This is used for returning the id of the imported did, along with the id of the imported private key.
+//
+// swagger:response importDIDRes
+type importDIDRes struct { // nolint: unused,deadcode
+	// in: body
+	vdricommand.ImportDIDResponse
+}
+
+// didCommServiceReq model
+//
+// This is used to retrieve the did-communication service of a did.
+//
+// swagger:parameters didCommServiceReq
+type didCommServiceReq struct { // nolint: unused,deadcode
+	// DID ID - pass the did
+	//
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// didCommServiceRes model
+//
+// This is used for returning the did-communication service of a did.
+//
+// swagger:response didCommServiceRes
+type didCommServiceRes struct { // nolint: unused,deadcode
+	// in: body
+	vdricommand.DIDCommServiceResponse
+}
+
+// didMethodsRes model
+//
+// This is used for returning the DID methods supported by the vdri registry along with their capabilities.
+//
+// swagger:response didMethodsRes
+type didMethodsRes struct { // nolint: unused,deadcode
+	// in: body
+	vdricommand.DIDMethodsResponse
+}
+
+// validateDIDDocReq model
+//
+// This is used to validate a did document before storing or publishing it.
+//
+// swagger:parameters validateDIDDocReq
+type validateDIDDocReq struct { // nolint: unused,deadcode
+	// Params for validating the did document
+	//
+	// in: body
+	Params vdricommand.ValidateDIDDocArgs
+}
+
+// validateDIDDocRes model
+//
+// This is used for returning the rules violated by a did document.
+//
+// swagger:response validateDIDDocRes
+type validateDIDDocRes struct { // nolint: unused,deadcode
+	// in: body
+	vdricommand.ValidateDIDDocResponse
+}
+
+// createPeerDIDReq model
+//
+// This is used to create a new peer DID.
+//
+// swagger:parameters createPeerDIDReq
+type createPeerDIDReq struct { // nolint: unused,deadcode
+	// Params for creating the peer DID
+	//
+	// in: body
+	Params vdricommand.CreatePeerDIDArgs
+}
+
+// createPeerDIDRes model
+//
+// This is used for returning the created peer DID.
+//
+// swagger:response createPeerDIDRes
+type createPeerDIDRes struct { // nolint: unused,deadcode
+	// in: body
+	vdricommand.CreatePeerDIDResponse
+}
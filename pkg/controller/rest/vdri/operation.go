@@ -9,8 +9,10 @@ package vdri
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -18,17 +20,29 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
 // constants for the VDRI operations
 const (
-	VdriOperationID   = "/vdri"
-	vdriDIDPath       = VdriOperationID + "/did"
-	SaveDIDPath       = vdriDIDPath
-	GetDIDPath        = vdriDIDPath + "/{id}"
-	ResolveDIDPath    = vdriDIDPath + "/resolve/{id}"
-	GetDIDRecordsPath = vdriDIDPath + "/records"
+	VdriOperationID     = "/vdri"
+	vdriDIDPath         = VdriOperationID + "/did"
+	SaveDIDPath         = vdriDIDPath
+	GetDIDPath          = vdriDIDPath + "/{id}"
+	ResolveDIDPath      = vdriDIDPath + "/resolve/{id}"
+	GetDIDRecordsPath   = vdriDIDPath + "/records"
+	CreatePublicDIDPath = VdriOperationID + "/create-public-did"
+	RegisterVDRIPath    = VdriOperationID + "/register"
+	DiffDIDPath         = vdriDIDPath + "/diff"
+	DIDCommServicePath  = vdriDIDPath + "/didcomm-service/{id}"
+	ImportDIDPath       = vdriDIDPath + "/import"
+	DIDMethodsPath      = VdriOperationID + "/methods"
+	CreatePeerDIDPath   = VdriOperationID + "/create-peer-did"
+	ValidateDIDDocPath  = vdriDIDPath + "/validate"
+
+	CreatePublicDIDAsyncPath = VdriOperationID + "/create-public-did-async"
+	DIDCreateStatusPath      = CreatePublicDIDAsyncPath + "/{jobID}"
 )
 
 // provider contains dependencies for the common controller operations
@@ -36,6 +50,9 @@ const (
 type provider interface {
 	VDRIRegistry() vdriapi.Registry
 	StorageProvider() storage.Provider
+	KMS() kms.KeyManager
+	Service(id string) (interface{}, error)
+	ServiceEndpoint() string
 }
 
 // Operation contains basic common operations provided by controller REST API.
@@ -45,8 +62,8 @@ type Operation struct {
 }
 
 // New returns new common operations rest client instance.
-func New(ctx provider) (*Operation, error) {
-	cmd, err := vdri.New(ctx)
+func New(ctx provider, opts ...vdri.Option) (*Operation, error) {
+	cmd, err := vdri.New(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("new vdri : %w", err)
 	}
@@ -70,6 +87,16 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(GetDIDPath, http.MethodGet, o.GetDID),
 		cmdutil.NewHTTPHandler(ResolveDIDPath, http.MethodGet, o.ResolveDID),
 		cmdutil.NewHTTPHandler(GetDIDRecordsPath, http.MethodGet, o.GetDIDRecords),
+		cmdutil.NewHTTPHandler(CreatePublicDIDPath, http.MethodPost, o.CreatePublicDID),
+		cmdutil.NewHTTPHandler(RegisterVDRIPath, http.MethodPost, o.RegisterVDRI),
+		cmdutil.NewHTTPHandler(DiffDIDPath, http.MethodPost, o.DiffDID),
+		cmdutil.NewHTTPHandler(DIDCommServicePath, http.MethodGet, o.GetDIDCommService),
+		cmdutil.NewHTTPHandler(ImportDIDPath, http.MethodPost, o.ImportDID),
+		cmdutil.NewHTTPHandler(DIDMethodsPath, http.MethodGet, o.GetDIDMethods),
+		cmdutil.NewHTTPHandler(CreatePeerDIDPath, http.MethodPost, o.CreatePeerDID),
+		cmdutil.NewHTTPHandler(ValidateDIDDocPath, http.MethodPost, o.ValidateDIDDoc),
+		cmdutil.NewHTTPHandler(CreatePublicDIDAsyncPath, http.MethodPost, o.CreatePublicDIDAsync),
+		cmdutil.NewHTTPHandler(DIDCreateStatusPath, http.MethodGet, o.GetDIDCreateStatus),
 	}
 }
 
@@ -106,11 +133,11 @@ func (o *Operation) GetDID(rw http.ResponseWriter, req *http.Request) {
 
 // ResolveDID swagger:route GET /vdri/did/resolve/{id} vdri resolveDIDReq
 //
-// Resolve did
+// Resolve did, along with its resolution metadata if requested
 //
 // Responses:
 //    default: genericError
-//        200: documentRes
+//        200: resolveDIDRes
 func (o *Operation) ResolveDID(rw http.ResponseWriter, req *http.Request) {
 	id := mux.Vars(req)["id"]
 
@@ -120,9 +147,28 @@ func (o *Operation) ResolveDID(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	request := fmt.Sprintf(`{"id":"%s"}`, string(decodedID))
+	var includeMetadata bool
 
-	rest.Execute(o.command.ResolveDID, rw, bytes.NewBufferString(request))
+	if v := req.URL.Query().Get("includeMetadata"); v != "" {
+		includeMetadata, err = strconv.ParseBool(v)
+		if err != nil {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, vdri.InvalidRequestErrorCode,
+				fmt.Errorf("invalid includeMetadata"))
+			return
+		}
+	}
+
+	request, err := json.Marshal(&vdri.ResolveDIDArgs{
+		IDArg:           vdri.IDArg{ID: string(decodedID)},
+		IncludeMetadata: includeMetadata,
+		ContextVersion:  req.URL.Query().Get("contextVersion"),
+	})
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, vdri.ResolveDIDErrorCode, err)
+		return
+	}
+
+	rest.Execute(o.command.ResolveDID, rw, bytes.NewBuffer(request))
 }
 
 // GetDIDRecords swagger:route GET /vdri/did/records vdri getDIDRecords
@@ -135,3 +181,132 @@ func (o *Operation) ResolveDID(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) GetDIDRecords(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.GetDIDRecords, rw, req.Body)
 }
+
+// CreatePublicDID swagger:route POST /vdri/create-public-did vdri createPublicDIDReq
+//
+// Creates a new public DID, or previews the registry request in dry run mode.
+//
+// Responses:
+//    default: genericError
+//        200: createPublicDIDRes
+func (o *Operation) CreatePublicDID(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreatePublicDID, rw, req.Body)
+}
+
+// CreatePublicDIDAsync swagger:route POST /vdri/create-public-did-async vdri createPublicDIDAsyncReq
+//
+// Starts the creation of a new public DID, the pending job of the creation is returned right away.
+//
+// Responses:
+//    default: genericError
+//        200: didCreateJobRes
+func (o *Operation) CreatePublicDIDAsync(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreatePublicDIDAsync, rw, req.Body)
+}
+
+// GetDIDCreateStatus swagger:route GET /vdri/create-public-did-async/{jobID} vdri didCreateStatusReq
+//
+// Gets the status of an asynchronous public DID creation, along with the created DID once completed.
+//
+// Responses:
+//    default: genericError
+//        200: didCreateJobRes
+func (o *Operation) GetDIDCreateStatus(rw http.ResponseWriter, req *http.Request) {
+	request, err := json.Marshal(&vdri.DIDCreateJobArgs{JobID: mux.Vars(req)["jobID"]})
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, vdri.GetDIDCreateStatusErrorCode, err)
+		return
+	}
+
+	rest.Execute(o.command.GetDIDCreateStatus, rw, bytes.NewBuffer(request))
+}
+
+// CreatePeerDID swagger:route POST /vdri/create-peer-did vdri createPeerDIDReq
+//
+// Creates a new peer DID for a connection, reachable through the router of the agent.
+//
+// Responses:
+//    default: genericError
+//        200: createPeerDIDRes
+func (o *Operation) CreatePeerDID(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreatePeerDID, rw, req.Body)
+}
+
+// RegisterVDRI swagger:route POST /vdri/register vdri registerVDRIReq
+//
+// Registers a HTTP binding vdri resolving the DIDs of the given method at runtime.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) RegisterVDRI(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RegisterVDRI, rw, req.Body)
+}
+
+// DiffDID swagger:route POST /vdri/did/diff vdri diffDIDReq
+//
+// Reports the public keys, services and authentication references changed between two did documents.
+//
+// Responses:
+//    default: genericError
+//        200: diffDIDRes
+func (o *Operation) DiffDID(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.DiffDID, rw, req.Body)
+}
+
+// ValidateDIDDoc swagger:route POST /vdri/did/validate vdri validateDIDDocReq
+//
+// Validates a did document against the DID Core rules and reports the rules it violates.
+//
+// Responses:
+//    default: genericError
+//        200: validateDIDDocRes
+func (o *Operation) ValidateDIDDoc(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ValidateDIDDoc, rw, req.Body)
+}
+
+// ImportDID swagger:route POST /vdri/did/import vdri importDIDReq
+//
+// Imports an externally created did document, along with the private key the did signs with if given.
+//
+// Responses:
+//    default: genericError
+//        200: importDIDRes
+func (o *Operation) ImportDID(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ImportDID, rw, req.Body)
+}
+
+// GetDIDMethods swagger:route GET /vdri/methods vdri getDIDMethods
+//
+// Lists the DID methods supported by the vdri registry, along with the operations supported for each method.
+//
+// Responses:
+//    default: genericError
+//        200: didMethodsRes
+func (o *Operation) GetDIDMethods(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.GetDIDMethods, rw, req.Body)
+}
+
+// GetDIDCommService swagger:route GET /vdri/did/didcomm-service/{id} vdri didCommServiceReq
+//
+// Resolves did and returns the endpoint, recipient keys and routing keys of its did-communication service.
+//
+// Responses:
+//    default: genericError
+//        200: didCommServiceRes
+func (o *Operation) GetDIDCommService(rw http.ResponseWriter, req *http.Request) {
+	id := mux.Vars(req)["id"]
+
+	decodedID, err := base64.StdEncoding.DecodeString(id)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, vdri.InvalidRequestErrorCode, fmt.Errorf("invalid id"))
+		return
+	}
+
+	request, err := json.Marshal(&vdri.IDArg{ID: string(decodedID)})
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, vdri.GetDIDCommServiceErrorCode, err)
+		return
+	}
+
+	rest.Execute(o.command.GetDIDCommService, rw, bytes.NewBuffer(request))
+}
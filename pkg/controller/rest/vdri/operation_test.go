@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
@@ -23,10 +24,12 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
-	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	mockmediator "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	vdriregistry "github.com/hyperledger/aries-framework-go/pkg/vdri"
 )
 
 const sampleDIDName = "sampleDIDName"
@@ -58,7 +61,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 4, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 14, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -74,7 +77,7 @@ func TestNew(t *testing.T) {
 }
 
 func TestOperation_GetAPIHandlers(t *testing.T) {
-	svc, err := New(&protocol.MockProvider{})
+	svc, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()})
 	require.NoError(t, err)
 	require.NotNil(t, svc)
 
@@ -195,6 +198,91 @@ func TestResolveDID(t *testing.T) {
 		require.NotEmpty(t, response)
 	})
 
+	t.Run("test resolve did with metadata - success", func(t *testing.T) {
+		didDoc, err := did.ParseDocument([]byte(doc))
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+				ResolveValue:    didDoc,
+				ResolveMetadata: vdriapi.ResolutionMetadata{Deactivated: true},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, ResolveDIDPath, http.MethodGet)
+		buf, err := getSuccessResponseFromHandler(handler, nil, fmt.Sprintf(`%s/resolve/%s?includeMetadata=true`,
+			vdriDIDPath, base64.StdEncoding.EncodeToString([]byte("did:peer:21tDAKCERh95uGgKbJNHYp"))))
+		require.NoError(t, err)
+
+		response := resolveDIDRes{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, response.DID)
+		require.NotNil(t, response.Metadata)
+		require.True(t, response.Metadata.Deactivated)
+	})
+
+	t.Run("test resolve did with context version - success", func(t *testing.T) {
+		didDoc, err := did.ParseDocument([]byte(doc))
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{ResolveValue: didDoc},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, ResolveDIDPath, http.MethodGet)
+		buf, err := getSuccessResponseFromHandler(handler, nil, fmt.Sprintf(`%s/resolve/%s?contextVersion=v0.11`,
+			vdriDIDPath, base64.StdEncoding.EncodeToString([]byte("did:peer:21tDAKCERh95uGgKbJNHYp"))))
+		require.NoError(t, err)
+
+		response := resolveDIDRes{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		resolvedDoc, err := did.ParseDocument(response.DID)
+		require.NoError(t, err)
+		require.Equal(t, "https://w3id.org/did/v0.11", resolvedDoc.Context[0])
+	})
+
+	t.Run("test resolve did with context version - unsupported version", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, ResolveDIDPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, fmt.Sprintf(`%s/resolve/%s?contextVersion=v2`,
+			vdriDIDPath, base64.StdEncoding.EncodeToString([]byte("did:peer:21tDAKCERh95uGgKbJNHYp"))))
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "did context version `v2` is not supported", buf.Bytes())
+	})
+
+	t.Run("test resolve did with metadata - invalid flag", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, ResolveDIDPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, fmt.Sprintf(`%s/resolve/%s?includeMetadata=maybe`,
+			vdriDIDPath, base64.StdEncoding.EncodeToString([]byte("did:peer:21tDAKCERh95uGgKbJNHYp"))))
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "invalid includeMetadata", buf.Bytes())
+	})
+
 	t.Run("test resolve did - error", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{
 			StorageProviderValue: mockstore.NewMockStoreProvider(),
@@ -246,6 +334,392 @@ func TestGetDIDRecords(t *testing.T) {
 	})
 }
 
+func TestRegisterVDRI(t *testing.T) {
+	t.Run("test register vdri - success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    vdriregistry.New(&mockprovider.Provider{}),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, RegisterVDRIPath, http.MethodPost)
+		_, err = getSuccessResponseFromHandler(handler,
+			bytes.NewBufferString(`{"method":"example","url":"http://localhost:8080/resolve"}`), handler.Path())
+		require.NoError(t, err)
+	})
+
+	t.Run("test register vdri - error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, RegisterVDRIPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"method":"example"}`), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "vdri url is mandatory", buf.Bytes())
+	})
+}
+
+func TestDiffDID(t *testing.T) {
+	t.Run("test diff did - success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, DiffDIDPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler,
+			bytes.NewBufferString(fmt.Sprintf(`{"old":%s,"new":%s}`, doc, doc)), handler.Path())
+		require.NoError(t, err)
+		require.JSONEq(t, `{"diff":{}}`, buf.String())
+	})
+
+	t.Run("test diff did - error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, DiffDIDPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "new did doc is mandatory", buf.Bytes())
+	})
+}
+
+func TestValidateDIDDoc(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	handler := lookupHandler(t, cmd, ValidateDIDDocPath, http.MethodPost)
+
+	t.Run("test validate did doc - success", func(t *testing.T) {
+		buf, err := getSuccessResponseFromHandler(handler,
+			bytes.NewBufferString(fmt.Sprintf(`{"did":%s}`, doc)), handler.Path())
+		require.NoError(t, err)
+		require.JSONEq(t, `{"valid":true}`, buf.String())
+
+		buf, err = getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"did":{
+			"@context": ["https://w3id.org/did/v1"],
+			"id": "did:peer:123",
+			"authentication": ["did:peer:123#key-1"]
+		}}`), handler.Path())
+		require.NoError(t, err)
+		require.JSONEq(t, `{"valid":false,"violations":[{"path":"authentication[0]",
+			"message":"references missing verification method did:peer:123#key-1"}]}`, buf.String())
+	})
+
+	t.Run("test validate did doc - error", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "did doc is mandatory", buf.Bytes())
+	})
+}
+
+func TestImportDID(t *testing.T) {
+	t.Run("test import did - success", func(t *testing.T) {
+		registry := &mockvdri.MockVDRIRegistry{}
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    registry,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, ImportDIDPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler,
+			bytes.NewBufferString(fmt.Sprintf(`{"did":%s,"method":"peer"}`, doc)), handler.Path())
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"did:peer:21tDAKCERh95uGgKbJNHYp"}`, buf.String())
+		require.Contains(t, registry.MemStore, "did:peer:21tDAKCERh95uGgKbJNHYp")
+	})
+
+	t.Run("test import did - error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, ImportDIDPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler,
+			bytes.NewBufferString(fmt.Sprintf(`{"did":%s,"method":"example"}`, doc)), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "is not a did of method `example`", buf.Bytes())
+	})
+}
+
+func TestGetDIDMethods(t *testing.T) {
+	t.Run("test get did methods - success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: vdriregistry.New(&mockprovider.Provider{},
+				vdriregistry.WithVDRI(&mockvdri.MockVDRI{MethodsValue: []vdriapi.MethodCapabilities{
+					{Method: "example", Resolve: true, Create: true, Update: true},
+					{Method: "other", Resolve: true},
+				}})),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, DIDMethodsPath, http.MethodGet)
+		buf, err := getSuccessResponseFromHandler(handler, nil, handler.Path())
+		require.NoError(t, err)
+		require.JSONEq(t, `{"methods":[
+			{"method":"example","resolve":true,"create":true,"update":true,"deactivate":false,
+				"keyAgreement":false},
+			{"method":"other","resolve":true,"create":false,"update":false,"deactivate":false,
+				"keyAgreement":false}
+		]}`, buf.String())
+	})
+
+	t.Run("test get did methods - error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, DIDMethodsPath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, vdri.GetDIDMethodsErrorCode, "vdri registry doesn't describe its methods", buf.Bytes())
+	})
+}
+
+func TestCreatePeerDID(t *testing.T) {
+	t.Run("test create peer did - success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue: &mockvdri.MockVDRIRegistry{
+				CreateFunc: func(method string, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+					require.Equal(t, "peer", method)
+
+					return did.ParseDocument([]byte(doc))
+				},
+			},
+			ServiceValue:         &mockmediator.MockMediatorSvc{},
+			ServiceEndpointValue: "https://agent.example.com",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, CreatePeerDIDPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"name":"`+sampleDIDName+`"}`),
+			handler.Path())
+		require.NoError(t, err)
+
+		var response vdri.CreatePeerDIDResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, "did:peer:21tDAKCERh95uGgKbJNHYp", response.ID)
+		require.NotEmpty(t, response.DID)
+	})
+
+	t.Run("test create peer did - routing configuration missing", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			ServiceValue:         &mockmediator.MockMediatorSvc{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, CreatePeerDIDPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("{}"), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "routing configuration is missing", buf.Bytes())
+	})
+}
+
+func TestGetDIDCommService(t *testing.T) {
+	t.Run("test get DIDComm service - success", func(t *testing.T) {
+		didDoc, err := did.ParseDocument([]byte(doc))
+		require.NoError(t, err)
+
+		didDoc.Service = []did.Service{{
+			ID:              "did:peer:21tDAKCERh95uGgKbJNHYp#didcomm",
+			Type:            vdriapi.DIDCommServiceType,
+			ServiceEndpoint: "https://example.com/didcomm",
+			RecipientKeys:   []string{"H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"},
+		}}
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{ResolveValue: didDoc},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, DIDCommServicePath, http.MethodGet)
+		buf, err := getSuccessResponseFromHandler(handler, nil, fmt.Sprintf(`%s/didcomm-service/%s`,
+			vdriDIDPath, base64.StdEncoding.EncodeToString([]byte("did:peer:21tDAKCERh95uGgKbJNHYp"))))
+		require.NoError(t, err)
+
+		response := didCommServiceRes{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/didcomm", response.ServiceEndpoint)
+		require.Equal(t, []string{"H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"}, response.RecipientKeys)
+	})
+
+	t.Run("test get DIDComm service - no DIDComm service", func(t *testing.T) {
+		didDoc, err := did.ParseDocument([]byte(doc))
+		require.NoError(t, err)
+
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{ResolveValue: didDoc},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, DIDCommServicePath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, fmt.Sprintf(`%s/didcomm-service/%s`,
+			vdriDIDPath, base64.StdEncoding.EncodeToString([]byte("did:peer:21tDAKCERh95uGgKbJNHYp"))))
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, vdri.GetDIDCommServiceErrorCode, "has no did-communication service", buf.Bytes())
+	})
+
+	t.Run("test get DIDComm service - invalid id", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, DIDCommServicePath, http.MethodGet)
+		buf, code, err := sendRequestToHandler(handler, nil, vdriDIDPath+"/didcomm-service/--")
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "invalid id", buf.Bytes())
+	})
+}
+
+func TestCreatePublicDID(t *testing.T) {
+	t.Run("test create public did - success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, CreatePublicDIDPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"method":"sidetree"}`),
+			handler.Path())
+		require.NoError(t, err)
+
+		var response vdri.CreatePublicDIDResponse
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+		require.NotEmpty(t, response.DID)
+
+		didDoc, err := did.ParseDocument(response.DID)
+		require.NoError(t, err)
+		require.Equal(t, didDoc.ID, response.ID)
+	})
+
+	t.Run("test create public did - error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, CreatePublicDIDPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "did method is mandatory", buf.Bytes())
+	})
+}
+
+func TestCreatePublicDIDAsync(t *testing.T) {
+	t.Run("test create public did async - success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRIRegistryValue:    &mockvdri.MockVDRIRegistry{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, CreatePublicDIDAsyncPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"method":"sidetree"}`),
+			handler.Path())
+		require.NoError(t, err)
+
+		var started vdri.DIDCreateJob
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &started))
+		require.NotEmpty(t, started.JobID)
+
+		statusHandler := lookupHandler(t, cmd, DIDCreateStatusPath, http.MethodGet)
+
+		var job vdri.DIDCreateJob
+
+		require.Eventually(t, func() bool {
+			buf, err = getSuccessResponseFromHandler(statusHandler, nil,
+				CreatePublicDIDAsyncPath+"/"+started.JobID)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &job))
+
+			return job.Status != vdri.DIDCreateJobPending
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Equal(t, vdri.DIDCreateJobCompleted, job.Status)
+
+		didDoc, err := did.ParseDocument(job.DID)
+		require.NoError(t, err)
+		require.Equal(t, didDoc.ID, job.ID)
+	})
+
+	t.Run("test create public did async - error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, CreatePublicDIDAsyncPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), handler.Path())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.InvalidRequestErrorCode, "did method is mandatory", buf.Bytes())
+
+		handler = lookupHandler(t, cmd, DIDCreateStatusPath, http.MethodGet)
+		buf, code, err = sendRequestToHandler(handler, nil, CreatePublicDIDAsyncPath+"/unknown")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, vdri.GetDIDCreateStatusErrorCode, "did create job `unknown` not found", buf.Bytes())
+	})
+}
+
 func lookupHandler(t *testing.T, op *Operation, path, method string) rest.Handler {
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
@@ -25,6 +25,98 @@ type validateCredentialReq struct { // nolint: unused,deadcode
 	Params verifiable.Credential
 }
 
+// verifyCredentialReq model
+//
+// This is used to verify the verifiable credential.
+//
+// swagger:parameters verifyCredentialReq
+type verifyCredentialReq struct { // nolint: unused,deadcode
+	// Params for verifying the verifiable credential (pass the vc document or JWT as a string)
+	//
+	// in: body
+	Params verifiable.VerifyCredentialRequest
+}
+
+// verifyCredentialRes model
+//
+// This is used for returning the verify credential response
+//
+// swagger:response verifyCredentialRes
+type verifyCredentialRes struct { // nolint: unused,deadcode
+
+	// in: body
+	verifiable.VerifyCredentialResponse
+}
+
+// verifyPresentationReq model
+//
+// This is used to verify the verifiable presentation.
+//
+// swagger:parameters verifyPresentationReq
+type verifyPresentationReq struct { // nolint: unused,deadcode
+	// Params for verifying the verifiable presentation (pass the vp document or JWT as a string)
+	//
+	// in: body
+	Params verifiable.VerifyPresentationRequest
+}
+
+// createDIDAuthRequestReq model
+//
+// This is used to create a DID authentication request.
+//
+// swagger:parameters createDIDAuthRequestReq
+type createDIDAuthRequestReq struct { // nolint: unused,deadcode
+	// Params for creating the DID authentication request
+	//
+	// in: body
+	Params verifiable.DIDAuthRequestArgs
+}
+
+// createDIDAuthRequestRes model
+//
+// This is used for returning the DID authentication request
+//
+// swagger:response createDIDAuthRequestRes
+type createDIDAuthRequestRes struct { // nolint: unused,deadcode
+
+	// in: body
+	verifiable.DIDAuthRequest
+}
+
+// verifyDIDAuthResponseReq model
+//
+// This is used to verify the response to a DID authentication request.
+//
+// swagger:parameters verifyDIDAuthResponseReq
+type verifyDIDAuthResponseReq struct { // nolint: unused,deadcode
+	// Params for verifying the response (pass the vp document or JWT as a string)
+	//
+	// in: body
+	Params verifiable.DIDAuthResponseArgs
+}
+
+// verifyDIDAuthResponseRes model
+//
+// This is used for returning the verification result of the DID authentication response
+//
+// swagger:response verifyDIDAuthResponseRes
+type verifyDIDAuthResponseRes struct { // nolint: unused,deadcode
+
+	// in: body
+	verifiable.DIDAuthResponse
+}
+
+// verifyPresentationRes model
+//
+// This is used for returning the verify presentation response
+//
+// swagger:response verifyPresentationRes
+type verifyPresentationRes struct { // nolint: unused,deadcode
+
+	// in: body
+	verifiable.VerifyPresentationResponse
+}
+
 // emptyRes model
 //
 // swagger:response emptyRes
@@ -111,6 +203,46 @@ type getCredentialByNameReq struct { // nolint: unused,deadcode
 	Name string `json:"name"`
 }
 
+// getCredentialsByTagReq model
+//
+// This is used to retrieve the verifiable credentials by tag.
+//
+// swagger:parameters getCredentialsByTagReq
+type getCredentialsByTagReq struct { // nolint: unused,deadcode
+	// Tag (issuer, type or subject)
+	//
+	// in: path
+	// required: true
+	Tag string `json:"tag"`
+
+	// Tag value
+	//
+	// in: query
+	Value string `json:"value"`
+}
+
+// queryCredentialsReq model
+//
+// This is used to retrieve the verifiable credentials matching all the given filters.
+//
+// swagger:parameters queryCredentialsReq
+type queryCredentialsReq struct { // nolint: unused,deadcode
+	// Issuer DID of the credentials
+	//
+	// in: query
+	Issuer string `json:"issuer"`
+
+	// Type the credentials must have
+	//
+	// in: query
+	Type string `json:"type"`
+
+	// Expired selects the expired credentials if true and the unexpired ones if false, both if not set
+	//
+	// in: query
+	Expired bool `json:"expired"`
+}
+
 // removeCredentialByNameReq model
 //
 // This is used to remove the verifiable credential by name.
@@ -212,3 +344,26 @@ type signCredentialRes struct {
 	// in: body
 	VerifiableCredential json.RawMessage `json:"verifiableCredential,omitempty"`
 }
+
+// deriveCredentialReq model
+//
+// This is used to derive a credential revealing selected attributes from a credential signed with BBS+ signatures.
+//
+// swagger:parameters deriveCredentialReq
+type deriveCredentialReq struct { // nolint: unused,deadcode
+	// Params for deriving a credential
+	//
+	// in: body
+	Params verifiable.DeriveCredentialRequest
+}
+
+// deriveCredentialRes model
+//
+// This is used for returning the derived credential
+//
+// swagger:response deriveCredentialRes
+type deriveCredentialRes struct { // nolint: unused,deadcode
+
+	// in: body
+	verifiable.Credential
+}
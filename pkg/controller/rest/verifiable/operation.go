@@ -9,8 +9,10 @@ package verifiable
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -32,20 +34,30 @@ const (
 
 	// credential paths
 	ValidateCredentialPath     = verifiableCredentialPath + "/validate"
+	VerifyCredentialPath       = verifiableCredentialPath + "/verify"
 	SaveCredentialPath         = verifiableCredentialPath
 	GetCredentialPath          = verifiableCredentialPath + "/{id}"
 	GetCredentialByNamePath    = verifiableCredentialPath + "/name" + "/{name}"
 	GetCredentialsPath         = VerifiableOperationID + "/credentials"
+	GetCredentialsByTagPath    = GetCredentialsPath + "/tag" + "/{tag}"
+	QueryCredentialsPath       = GetCredentialsPath + "/query"
 	SignCredentialsPath        = VerifiableOperationID + "/signcredential"
+	DeriveCredentialPath       = VerifiableOperationID + "/derivecredential"
 	RemoveCredentialByNamePath = verifiableCredentialPath + "/remove/name" + "/{name}"
 
 	// presentation paths
 	GeneratePresentationPath     = verifiablePresentationPath + "/generate"
 	GeneratePresentationByIDPath = verifiablePresentationPath + "/generatebyid"
+	VerifyPresentationPath       = verifiablePresentationPath + "/verify"
 	SavePresentationPath         = verifiablePresentationPath
 	GetPresentationPath          = verifiablePresentationPath + "/{id}"
 	GetPresentationsPath         = VerifiableOperationID + "/presentations"
 	RemovePresentationByNamePath = verifiablePresentationPath + "/remove/name" + "/{name}"
+
+	// DID authentication paths
+	didAuthPath               = VerifiableOperationID + "/didauth"
+	CreateDIDAuthRequestPath  = didAuthPath + "/request"
+	VerifyDIDAuthResponsePath = didAuthPath + "/response"
 )
 
 // provider contains dependencies for the verifiable command and is typically created by using aries.Context().
@@ -84,18 +96,25 @@ func (o *Operation) GetRESTHandlers() []rest.Handler {
 func (o *Operation) registerHandler() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(ValidateCredentialPath, http.MethodPost, o.ValidateCredential),
+		cmdutil.NewHTTPHandler(VerifyCredentialPath, http.MethodPost, o.VerifyCredential),
 		cmdutil.NewHTTPHandler(SaveCredentialPath, http.MethodPost, o.SaveCredential),
 		cmdutil.NewHTTPHandler(GetCredentialPath, http.MethodGet, o.GetCredential),
 		cmdutil.NewHTTPHandler(GetCredentialByNamePath, http.MethodGet, o.GetCredentialByName),
 		cmdutil.NewHTTPHandler(GetCredentialsPath, http.MethodGet, o.GetCredentials),
+		cmdutil.NewHTTPHandler(GetCredentialsByTagPath, http.MethodGet, o.GetCredentialsByTag),
+		cmdutil.NewHTTPHandler(QueryCredentialsPath, http.MethodGet, o.QueryCredentials),
 		cmdutil.NewHTTPHandler(SignCredentialsPath, http.MethodPost, o.SignCredential),
+		cmdutil.NewHTTPHandler(DeriveCredentialPath, http.MethodPost, o.DeriveCredential),
 		cmdutil.NewHTTPHandler(GeneratePresentationPath, http.MethodPost, o.GeneratePresentation),
 		cmdutil.NewHTTPHandler(GeneratePresentationByIDPath, http.MethodPost, o.GeneratePresentationByID),
+		cmdutil.NewHTTPHandler(VerifyPresentationPath, http.MethodPost, o.VerifyPresentation),
 		cmdutil.NewHTTPHandler(SavePresentationPath, http.MethodPost, o.SavePresentation),
 		cmdutil.NewHTTPHandler(GetPresentationPath, http.MethodGet, o.GetPresentation),
 		cmdutil.NewHTTPHandler(GetPresentationsPath, http.MethodGet, o.GetPresentations),
 		cmdutil.NewHTTPHandler(RemoveCredentialByNamePath, http.MethodPost, o.RemoveCredentialByName),
 		cmdutil.NewHTTPHandler(RemovePresentationByNamePath, http.MethodPost, o.RemovePresentationByName),
+		cmdutil.NewHTTPHandler(CreateDIDAuthRequestPath, http.MethodPost, o.CreateDIDAuthRequest),
+		cmdutil.NewHTTPHandler(VerifyDIDAuthResponsePath, http.MethodPost, o.VerifyDIDAuthResponse),
 	}
 }
 
@@ -110,6 +129,50 @@ func (o *Operation) ValidateCredential(rw http.ResponseWriter, req *http.Request
 	rest.Execute(o.command.ValidateCredential, rw, req.Body)
 }
 
+// VerifyCredential swagger:route POST /verifiable/credential/verify verifiable verifyCredentialReq
+//
+// Verifies the proof of the verifiable credential with the public key of its issuer.
+//
+// Responses:
+//    default: genericError
+//        200: verifyCredentialRes
+func (o *Operation) VerifyCredential(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.VerifyCredential, rw, req.Body)
+}
+
+// VerifyPresentation swagger:route POST /verifiable/presentation/verify verifiable verifyPresentationReq
+//
+// Verifies the proof and the holder binding of the verifiable presentation, and each of its credentials.
+//
+// Responses:
+//    default: genericError
+//        200: verifyPresentationRes
+func (o *Operation) VerifyPresentation(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.VerifyPresentation, rw, req.Body)
+}
+
+// CreateDIDAuthRequest swagger:route POST /verifiable/didauth/request verifiable createDIDAuthRequestReq
+//
+// Creates a DID authentication request, answered with a presentation signed for its challenge and domain.
+//
+// Responses:
+//    default: genericError
+//        200: createDIDAuthRequestRes
+func (o *Operation) CreateDIDAuthRequest(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreateDIDAuthRequest, rw, req.Body)
+}
+
+// VerifyDIDAuthResponse swagger:route POST /verifiable/didauth/response verifiable verifyDIDAuthResponseReq
+//
+// Verifies the response to a DID authentication request against the resolved DID of its holder.
+//
+// Responses:
+//    default: genericError
+//        200: verifyDIDAuthResponseRes
+func (o *Operation) VerifyDIDAuthResponse(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.VerifyDIDAuthResponse, rw, req.Body)
+}
+
 // SaveCredential swagger:route POST /verifiable/credential verifiable saveCredentialReq
 //
 // Saves the verifiable credential.
@@ -200,6 +263,56 @@ func (o *Operation) GetCredentials(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.GetCredentials, rw, req.Body)
 }
 
+// GetCredentialsByTag swagger:route GET /verifiable/credentials/tag/{tag} verifiable getCredentialsByTagReq
+//
+// Retrieves the verifiable credentials tagged with the given issuer, type or subject.
+//
+// Responses:
+//    default: genericError
+//        200: credentialRecordResult
+func (o *Operation) GetCredentialsByTag(rw http.ResponseWriter, req *http.Request) {
+	request := fmt.Sprintf(`{"tag":"%s","value":"%s"}`, mux.Vars(req)["tag"], req.URL.Query().Get("value"))
+
+	rest.Execute(o.command.GetCredentialsByTag, rw, bytes.NewBufferString(request))
+}
+
+// QueryCredentials swagger:route GET /verifiable/credentials/query verifiable queryCredentialsReq
+//
+// Retrieves the verifiable credentials matching all the given issuer, type and expiry filters.
+//
+// Responses:
+//    default: genericError
+//        200: credentialRecordResult
+func (o *Operation) QueryCredentials(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	request := verifiable.QueryCredentialsArgs{
+		Issuer: query.Get("issuer"),
+		Type:   query.Get("type"),
+	}
+
+	if query.Get("expired") != "" {
+		expired, err := strconv.ParseBool(query.Get("expired"))
+		if err != nil {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, verifiable.InvalidRequestErrorCode,
+				fmt.Errorf("invalid expired filter : %w", err))
+
+			return
+		}
+
+		request.Expired = &expired
+	}
+
+	reqBytes, err := json.Marshal(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, verifiable.QueryCredentialsErrorCode, err)
+
+		return
+	}
+
+	rest.Execute(o.command.QueryCredentials, rw, bytes.NewBuffer(reqBytes))
+}
+
 // SignCredential swagger:route POST /verifiable/signcredential verifiable signCredentialReq
 //
 // Signs given credential.
@@ -211,6 +324,17 @@ func (o *Operation) SignCredential(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.SignCredential, rw, req.Body)
 }
 
+// DeriveCredential swagger:route POST /verifiable/derivecredential verifiable deriveCredentialReq
+//
+// Derives a credential revealing the attributes selected by a frame from a credential signed with BBS+ signatures.
+//
+// Responses:
+//    default: genericError
+//        200: deriveCredentialRes
+func (o *Operation) DeriveCredential(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.DeriveCredential, rw, req.Body)
+}
+
 // GetPresentations swagger:route GET /verifiable/presentations verifiable
 //
 // Retrieves the verifiable credentials.
@@ -15,6 +15,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"testing"
 	"time"
@@ -164,6 +165,12 @@ const udVerifiablePresentation = `{
     }
 `
 
+const vpWithoutProof = `{
+	"@context": ["https://www.w3.org/2018/credentials/v1"],
+	"type": ["VerifiablePresentation"],
+	"holder": "did:example:ebfeb1f712ebc6f1c276e12ec21"
+}`
+
 //nolint:lll
 const udPresentation = `{
         "@context": ["https://www.w3.org/2018/credentials/v1", "https://www.w3.org/2018/credentials/examples/v1"],
@@ -207,7 +214,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 13, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 20, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -266,6 +273,109 @@ func TestValidateVC(t *testing.T) {
 	})
 }
 
+func TestVerifyVC(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	handler := lookupHandler(t, cmd, VerifyCredentialPath, http.MethodPost)
+
+	t.Run("test verify vc - not verified", func(t *testing.T) {
+		jsonStr, err := json.Marshal(verifiable.Credential{VerifiableCredential: vc})
+		require.NoError(t, err)
+
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		response := verifyCredentialRes{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		// verify response
+		require.False(t, response.Verified)
+		require.Equal(t, "vc has no proof", response.Reason)
+	})
+
+	t.Run("test verify vc - error", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("{}"), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.VerifyCredentialErrorCode, "credential is mandatory", buf.Bytes())
+	})
+}
+
+func TestDeriveVC(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	handler := lookupHandler(t, cmd, DeriveCredentialPath, http.MethodPost)
+
+	t.Run("test derive vc - error", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("{}"), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.InvalidRequestErrorCode, "credential is mandatory", buf.Bytes())
+	})
+
+	t.Run("test derive vc - missing frame", func(t *testing.T) {
+		jsonStr, err := json.Marshal(verifiable.DeriveCredentialRequest{Credential: []byte(vc)})
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.InvalidRequestErrorCode, "frame is mandatory", buf.Bytes())
+	})
+}
+
+func TestVerifyVP(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	handler := lookupHandler(t, cmd, VerifyPresentationPath, http.MethodPost)
+
+	t.Run("test verify vp - not verified", func(t *testing.T) {
+		jsonStr, err := json.Marshal(verifiable.VerifyPresentationRequest{
+			VerifiablePresentation: json.RawMessage(vpWithoutProof),
+		})
+		require.NoError(t, err)
+
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		response := verifyPresentationRes{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		// verify response
+		require.False(t, response.Verified)
+		require.Equal(t, "vp has no proof", response.Reason)
+	})
+
+	t.Run("test verify vp - error", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("{}"), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.VerifyPresentationErrorCode, "presentation is mandatory", buf.Bytes())
+	})
+}
+
 func TestSaveVC(t *testing.T) {
 	t.Run("test save vc - success", func(t *testing.T) {
 		cmd, err := New(&mockprovider.Provider{
@@ -448,6 +558,155 @@ func TestGetCredentials(t *testing.T) {
 	})
 }
 
+func TestGetCredentialsByTag(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	jsonStr, err := json.Marshal(verifiable.CredentialExt{
+		Credential: verifiable.Credential{VerifiableCredential: `{
+			"@context": ["https://www.w3.org/2018/credentials/v1"],
+			"id": "http://example.edu/credentials/1",
+			"type": ["VerifiableCredential"],
+			"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+			"issuer": "did:example:issuer",
+			"issuanceDate": "2020-01-01T10:54:01Z"
+		}`},
+		Name: sampleCredentialName,
+	})
+	require.NoError(t, err)
+
+	handler := lookupHandler(t, cmd, SaveCredentialPath, http.MethodPost)
+	_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+	require.NoError(t, err)
+
+	handler = lookupHandler(t, cmd, GetCredentialsByTagPath, http.MethodGet)
+
+	t.Run("test get credentials by tag", func(t *testing.T) {
+		buf, err := getSuccessResponseFromHandler(handler, nil,
+			GetCredentialsPath+"/tag/issuer?value="+url.QueryEscape("did:example:issuer"))
+		require.NoError(t, err)
+
+		var response credentialRecordResult
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		// verify response
+		require.Len(t, response.Result, 1)
+		require.Equal(t, sampleCredentialName, response.Result[0].Name)
+	})
+
+	t.Run("test get credentials by tag - unsupported tag", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, nil, GetCredentialsPath+"/tag/name?value=vc")
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.GetCredentialsByTagErrorCode, "unsupported credential tag: name", buf.Bytes())
+	})
+}
+
+func TestQueryCredentials(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	handler := lookupHandler(t, cmd, SaveCredentialPath, http.MethodPost)
+
+	for name, expirationDate := range map[string]string{"vc1": "2015-01-01T00:00:00Z", "vc2": "2999-01-01T00:00:00Z"} {
+		jsonStr, err := json.Marshal(verifiable.CredentialExt{
+			Credential: verifiable.Credential{VerifiableCredential: `{
+				"@context": ["https://www.w3.org/2018/credentials/v1"],
+				"id": "http://example.edu/credentials/` + name + `",
+				"type": ["VerifiableCredential"],
+				"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+				"issuer": "did:example:issuer",
+				"issuanceDate": "2010-01-01T10:54:01Z",
+				"expirationDate": "` + expirationDate + `"
+			}`},
+			Name: name,
+		})
+		require.NoError(t, err)
+
+		_, err = getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+	}
+
+	handler = lookupHandler(t, cmd, QueryCredentialsPath, http.MethodGet)
+
+	t.Run("test query credentials", func(t *testing.T) {
+		buf, err := getSuccessResponseFromHandler(handler, nil,
+			QueryCredentialsPath+"?expired=true&issuer="+url.QueryEscape("did:example:issuer"))
+		require.NoError(t, err)
+
+		var response credentialRecordResult
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		require.Len(t, response.Result, 1)
+		require.Equal(t, "vc1", response.Result[0].Name)
+
+		buf, err = getSuccessResponseFromHandler(handler, nil, QueryCredentialsPath+"?type=VerifiableCredential")
+		require.NoError(t, err)
+
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+		require.Len(t, response.Result, 2)
+	})
+
+	t.Run("test query credentials - invalid expired filter", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler, nil, QueryCredentialsPath+"?expired=yes")
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.InvalidRequestErrorCode, "invalid expired filter", buf.Bytes())
+	})
+}
+
+func TestDIDAuth(t *testing.T) {
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	handler := lookupHandler(t, cmd, CreateDIDAuthRequestPath, http.MethodPost)
+
+	buf, err := getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"domain":"rp.example.com"}`),
+		handler.Path())
+	require.NoError(t, err)
+
+	request := verifiable.DIDAuthRequest{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &request))
+	require.NotEmpty(t, request.Challenge)
+	require.Equal(t, "rp.example.com", request.Domain)
+
+	handler = lookupHandler(t, cmd, VerifyDIDAuthResponsePath, http.MethodPost)
+
+	t.Run("test verify DID auth response - not verified", func(t *testing.T) {
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBufferString(`{"challenge":"`+
+			request.Challenge+`","verifiablePresentation":{}}`), handler.Path())
+		require.NoError(t, err)
+
+		response := verifiable.DIDAuthResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.False(t, response.Verified)
+		require.Contains(t, response.Reason, "parse vp")
+	})
+
+	t.Run("test verify DID auth response - unknown challenge", func(t *testing.T) {
+		buf, code, err := sendRequestToHandler(handler,
+			bytes.NewBufferString(`{"challenge":"unknown","verifiablePresentation":{}}`), handler.Path())
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.VerifyDIDAuthResponseErrorCode, "unknown or already answered challenge", buf.Bytes())
+	})
+}
+
 func TestGeneratePresentation(t *testing.T) {
 	s := make(map[string][]byte)
 	cmd, cmdErr := New(&mockprovider.Provider{
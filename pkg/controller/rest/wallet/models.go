@@ -0,0 +1,43 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import "github.com/hyperledger/aries-framework-go/pkg/controller/command/wallet"
+
+// exportWalletRequest model
+//
+// This is used for exporting the wallet of the agent.
+//
+// swagger:parameters exportWallet
+type exportWalletRequest struct { // nolint: unused,deadcode
+	// Params for exporting the wallet
+	//
+	// in: body
+	Params wallet.ExportWalletRequest
+}
+
+// exportWalletResponse model
+//
+// Represents an export wallet response message.
+//
+// swagger:response exportWalletResponse
+type exportWalletResponse struct { // nolint: unused,deadcode
+	// in: body
+	wallet.ExportWalletResponse
+}
+
+// importWalletRequest model
+//
+// This is used for importing a wallet exported by an agent.
+//
+// swagger:parameters importWallet
+type importWalletRequest struct { // nolint: unused,deadcode
+	// Params for importing the wallet
+	//
+	// in: body
+	Params wallet.ImportWalletRequest
+}
@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/wallet"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the wallet operation
+const (
+	OperationID      = "/wallet"
+	ExportWalletPath = OperationID + "/export"
+	ImportWalletPath = OperationID + "/import"
+)
+
+// Operation is controller REST service controller exporting and importing the wallet of the agent.
+type Operation struct {
+	command  *wallet.Command
+	handlers []rest.Handler
+}
+
+// New returns new wallet rest controller instance.
+func New(ctx wallet.Provider) (*Operation, error) {
+	cmd, err := wallet.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("wallet command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(ExportWalletPath, http.MethodPost, c.ExportWallet),
+		cmdutil.NewHTTPHandler(ImportWalletPath, http.MethodPost, c.ImportWallet),
+	}
+}
+
+// ExportWallet swagger:route POST /wallet/export wallet exportWallet
+//
+// Exports the connections, DIDs and credentials of the agent, encrypted with the passphrase if given.
+//
+// Responses:
+//    default: genericError
+//        200: exportWalletResponse
+func (c *Operation) ExportWallet(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.ExportWallet, rw, req.Body)
+}
+
+// ImportWallet swagger:route POST /wallet/import wallet importWallet
+//
+// Imports a wallet exported by an agent.
+//
+// Responses:
+//    default: genericError
+func (c *Operation) ImportWallet(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.ImportWallet, rw, req.Body)
+}
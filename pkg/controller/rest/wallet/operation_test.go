@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/wallet"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+)
+
+const sampleDID = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+
+func TestNew(t *testing.T) {
+	operation, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+	require.NoError(t, err)
+
+	handlers := operation.GetRESTHandlers()
+	require.Len(t, handlers, 2)
+	require.Equal(t, ExportWalletPath, handlers[0].Path())
+	require.Equal(t, http.MethodPost, handlers[0].Method())
+	require.Equal(t, ImportWalletPath, handlers[1].Path())
+	require.Equal(t, http.MethodPost, handlers[1].Method())
+
+	_, err = New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{
+		ErrOpenStoreHandle: errors.New("db is down"),
+	}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "wallet command")
+}
+
+func TestOperation_ExportImportWallet(t *testing.T) {
+	source := &mockprovider.Provider{StorageProviderValue: mem.NewProvider()}
+
+	dids, err := didstore.New(source)
+	require.NoError(t, err)
+	require.NoError(t, dids.SaveDID("my-did", &did.Doc{Context: []string{did.Context}, ID: sampleDID}))
+
+	sourceOp, err := New(source)
+	require.NoError(t, err)
+
+	rr := serve(t, sourceOp.ExportWallet, ExportWalletPath, &wallet.ExportWalletRequest{Passphrase: "passphrase"})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var exported wallet.ExportWalletResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &exported))
+
+	target := &mockprovider.Provider{StorageProviderValue: mem.NewProvider()}
+
+	targetOp, err := New(target)
+	require.NoError(t, err)
+
+	rr = serve(t, targetOp.ImportWallet, ImportWalletPath, &wallet.ImportWalletRequest{Wallet: exported.Wallet})
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	errResponse := struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResponse))
+	require.EqualValues(t, wallet.InvalidRequestErrorCode, errResponse.Code)
+	require.Equal(t, "passphrase is required to import an encrypted wallet", errResponse.Message)
+
+	rr = serve(t, targetOp.ImportWallet, ImportWalletPath, &wallet.ImportWalletRequest{
+		Wallet:     exported.Wallet,
+		Passphrase: "passphrase",
+	})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	dids, err = didstore.New(target)
+	require.NoError(t, err)
+
+	doc, err := dids.GetDID(sampleDID)
+	require.NoError(t, err)
+	require.Equal(t, sampleDID, doc.ID)
+}
+
+func serve(t *testing.T, handler http.HandlerFunc, path string, request interface{}) *httptest.ResponseRecorder {
+	reqBytes, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(reqBytes))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+
+	return rr
+}
@@ -9,22 +9,137 @@ package webnotifier
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sync"
+	"time"
 )
 
+const (
+	// SignatureHeader is the header of the notifications carrying the hex encoded HMAC-SHA256 of the notification
+	// body, computed with the shared secret and prefixed with 'sha256=', eg: X-Aries-Signature: sha256=5d5d1...
+	SignatureHeader = "X-Aries-Signature"
+	signaturePrefix = "sha256="
+
+	defaultRetryInterval = time.Second
+)
+
+// HTTPNotifierOpt configures the HTTPNotifier.
+type HTTPNotifierOpt func(n *HTTPNotifier)
+
+// WithSecret option is for signing the notifications with the secret shared with the subscribers, the signature
+// is sent in the SignatureHeader header. Notifications aren't signed by default.
+func WithSecret(secret []byte) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.secret = secret
+	}
+}
+
+// WithRetries option is for retrying the notifications which failed to be delivered or were answered with
+// a 5xx status, up to retries times waiting interval between attempts. Notifications aren't retried by default.
+func WithRetries(retries int, interval time.Duration) HTTPNotifierOpt {
+	return func(n *HTTPNotifier) {
+		n.retries = retries
+		n.retryInterval = interval
+	}
+}
+
+// webhook is a subscriber URL along with the topics it is notified of, it is notified of all topics if none.
+type webhook struct {
+	url    string
+	topics map[string]struct{}
+}
+
+func (w *webhook) subscribed(topic string) bool {
+	if len(w.topics) == 0 {
+		return true
+	}
+
+	_, ok := w.topics[topic]
+
+	return ok
+}
+
 // HTTPNotifier is a webhook dispatcher capable of notifying multiple subscribers via HTTP.
 type HTTPNotifier struct {
-	urls []string
+	webhooks      []*webhook
+	secret        []byte
+	retries       int
+	retryInterval time.Duration
+	lock          sync.RWMutex
 }
 
-// NewHTTPNotifier returns a new instance of an HTTPNotifier.
-func NewHTTPNotifier(webhookURLs []string) *HTTPNotifier {
-	return &HTTPNotifier{urls: webhookURLs}
+// NewHTTPNotifier returns a new instance of an HTTPNotifier notifying the webhookURLs of all topics.
+func NewHTTPNotifier(webhookURLs []string, opts ...HTTPNotifierOpt) *HTTPNotifier {
+	n := &HTTPNotifier{retryInterval: defaultRetryInterval}
+
+	for _, webhookURL := range webhookURLs {
+		n.webhooks = append(n.webhooks, &webhook{url: webhookURL})
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n
 }
 
-// Notify sends the given message to all of the urls.
+// RegisterWebhook registers the webhook URL to be notified of the given topics, or of all topics if none is given.
+// The topics of an already registered URL are replaced.
+func (n *HTTPNotifier) RegisterWebhook(webhookURL string, topics ...string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL '%s': an absolute http(s) URL is required", webhookURL)
+	}
+
+	w := &webhook{url: webhookURL, topics: make(map[string]struct{}, len(topics))}
+
+	for _, topic := range topics {
+		w.topics[topic] = struct{}{}
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for i := range n.webhooks {
+		if n.webhooks[i].url == webhookURL {
+			n.webhooks[i] = w
+
+			return nil
+		}
+	}
+
+	n.webhooks = append(n.webhooks, w)
+
+	return nil
+}
+
+// UnregisterWebhook unregisters the webhook URL, it isn't notified anymore.
+func (n *HTTPNotifier) UnregisterWebhook(webhookURL string) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for i := range n.webhooks {
+		if n.webhooks[i].url == webhookURL {
+			n.webhooks = append(n.webhooks[:i], n.webhooks[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("webhook '%s' is not registered", webhookURL)
+}
+
+// Notify sends the given message to all of the urls subscribed to the topic.
 // Topic is appended to the end of the webhook (subscriber) URL. E.g. localhost:8080/topic
 // If multiple errors are encountered, then the first one is returned.
 func (n *HTTPNotifier) Notify(topic string, message []byte) error {
@@ -43,15 +158,68 @@ func (n *HTTPNotifier) Notify(topic string, message []byte) error {
 
 	var allErrs error
 
-	for _, webhookURL := range n.urls {
-		err := notifyWH(webhookURL, topicMsg)
+	for _, webhookURL := range n.subscribers(topic) {
+		err := n.notifyWithRetries(webhookURL, topicMsg)
 		allErrs = appendError(allErrs, err)
 	}
 
 	return allErrs
 }
 
+func (n *HTTPNotifier) subscribers(topic string) []string {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	var urls []string
+
+	for _, w := range n.webhooks {
+		if w.subscribed(topic) {
+			urls = append(urls, w.url)
+		}
+	}
+
+	return urls
+}
+
+func (n *HTTPNotifier) notifyWithRetries(destination string, message []byte) error {
+	signature := ""
+
+	if len(n.secret) > 0 {
+		mac := hmac.New(sha256.New, n.secret)
+		_, _ = mac.Write(message) // nolint: errcheck
+
+		signature = signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	var err error
+
+	for attempt := 0; attempt <= n.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(n.retryInterval)
+
+			logger.Debugf("retrying notification to %s (attempt %d): %s", destination, attempt+1, err)
+		}
+
+		var retry bool
+
+		retry, err = post(destination, message, signature)
+		if !retry {
+			return err
+		}
+	}
+
+	return err
+}
+
 func notifyWH(destination string, message []byte) error {
+	_, err := post(destination, message, "")
+
+	return err
+}
+
+// post sends the message to destination, along with its signature if not empty. It tells whether the failed
+// notification may be retried, that is if it couldn't be delivered or a 5xx status was received.
+func post(destination string, message []byte, signature string) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), notificationSendTimeout)
 	defer cancel()
 
@@ -59,23 +227,28 @@ func notifyWH(destination string, message []byte) error {
 		bytes.NewBuffer(message))
 
 	if err != nil {
-		return fmt.Errorf("failed to create new http post request for %s: %s", destination, err)
+		return false, fmt.Errorf("failed to create new http post request for %s: %s", destination, err)
+	}
+
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
 	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to post notification to %s: %s", destination, err)
+		return true, fmt.Errorf("failed to post notification to %s: %s", destination, err)
 	}
 
 	defer closeResponse(resp.Body)
 
 	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated {
 		logger.Infof("Notification sent to %s successfully. \n", destination)
-		return nil
+		return false, nil
 	}
 
-	return fmt.Errorf("notification was sent to %s, but %s was received",
-		destination, resp.Status)
+	retry := resp.StatusCode >= http.StatusInternalServerError
+
+	return retry, fmt.Errorf("notification was sent to %s, but %s was received", destination, resp.Status)
 }
 
 func closeResponse(c io.Closer) {
@@ -8,9 +8,14 @@ package webnotifier
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -221,6 +226,139 @@ func TestWebhookNotificationClient500Response(t *testing.T) {
 	require.Contains(t, err.Error(), "500 Internal Server Error", err.Error())
 }
 
+func TestRegisterWebhook(t *testing.T) {
+	t.Run("Signed notifications of the subscribed topics", func(t *testing.T) {
+		secret := []byte("secret")
+		received := make(chan []byte, 2)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			body, err := ioutil.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			mac := hmac.New(sha256.New, secret)
+			_, err = mac.Write(body)
+			require.NoError(t, err)
+
+			require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), req.Header.Get(SignatureHeader))
+
+			received <- body
+		}))
+		defer srv.Close()
+
+		testNotifier := NewHTTPNotifier(nil, WithSecret(secret))
+		require.NoError(t, testNotifier.RegisterWebhook(srv.URL, "didexchange_states", "issue-credential_states"))
+
+		require.NoError(t, testNotifier.Notify("didexchange_states", getTestBasicMessageJSON()))
+		require.NoError(t, testNotifier.Notify("present-proof_states", getTestBasicMessageJSON()))
+		require.NoError(t, testNotifier.Notify("issue-credential_states", getTestBasicMessageJSON()))
+
+		for _, expected := range []string{"didexchange_states", "issue-credential_states"} {
+			var topicMsg struct {
+				Topic   string `json:"topic"`
+				Message msg    `json:"message"`
+			}
+
+			require.NoError(t, json.Unmarshal(<-received, &topicMsg))
+			require.Equal(t, expected, topicMsg.Topic)
+			require.Equal(t, "SomeConnectionID", topicMsg.Message.ConnectionID)
+		}
+
+		require.Empty(t, received)
+	})
+
+	t.Run("Registering again replaces the topics", func(t *testing.T) {
+		var calls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			require.Empty(t, req.Header.Get(SignatureHeader))
+			atomic.AddInt32(&calls, 1)
+		}))
+		defer srv.Close()
+
+		testNotifier := NewHTTPNotifier(nil)
+		require.NoError(t, testNotifier.RegisterWebhook(srv.URL, "didexchange_states"))
+		require.NoError(t, testNotifier.RegisterWebhook(srv.URL))
+
+		require.NoError(t, testNotifier.Notify("present-proof_states", getTestBasicMessageJSON()))
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Invalid URL", func(t *testing.T) {
+		testNotifier := NewHTTPNotifier(nil)
+
+		for _, webhookURL := range []string{"%", "badURL", "ws://localhost:8080", "http://"} {
+			require.Error(t, testNotifier.RegisterWebhook(webhookURL), webhookURL)
+		}
+
+		require.Empty(t, testNotifier.webhooks)
+	})
+}
+
+func TestUnregisterWebhook(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+
+	testNotifier := NewHTTPNotifier([]string{srv.URL})
+	require.NoError(t, testNotifier.UnregisterWebhook(srv.URL))
+	require.EqualError(t, testNotifier.UnregisterWebhook(srv.URL), fmt.Sprintf("webhook '%s' is not registered", srv.URL))
+
+	require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+	require.Zero(t, atomic.LoadInt32(&calls))
+}
+
+func TestNotifyWithRetries(t *testing.T) {
+	t.Run("Retried on 5xx", func(t *testing.T) {
+		var calls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				resp.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer srv.Close()
+
+		testNotifier := NewHTTPNotifier([]string{srv.URL}, WithRetries(3, time.Millisecond))
+		require.NoError(t, testNotifier.Notify(topic, getTestBasicMessageJSON()))
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Retries exhausted", func(t *testing.T) {
+		var calls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			resp.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		testNotifier := NewHTTPNotifier([]string{srv.URL}, WithRetries(2, time.Millisecond))
+		err := testNotifier.Notify(topic, getTestBasicMessageJSON())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "500 Internal Server Error")
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Not retried on 4xx", func(t *testing.T) {
+		var calls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			resp.WriteHeader(http.StatusBadRequest)
+		}))
+		defer srv.Close()
+
+		testNotifier := NewHTTPNotifier([]string{srv.URL}, WithRetries(2, time.Millisecond))
+		err := testNotifier.Notify(topic, getTestBasicMessageJSON())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "400 Bad Request")
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+}
+
 func getTestBasicMessageJSON() []byte {
 	return []byte(`
    {
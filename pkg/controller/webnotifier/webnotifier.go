@@ -29,16 +29,18 @@ var logger = log.New("aries-framework/webnotifier")
 
 // WebNotifier is a dispatcher capable of notifying multiple subscribers via HTTP Webhooks and WebSockets.
 type WebNotifier struct {
+	webhook   *HTTPNotifier
 	notifiers []command.Notifier
 	handlers  []rest.Handler
 }
 
-// New returns a new instance of a WebNotifier.
-func New(wsPath string, webhookURLs []string) *WebNotifier {
-	webhook := NewHTTPNotifier(webhookURLs)
+// New returns a new instance of a WebNotifier, the webhook options configure the HTTP Webhooks notifications.
+func New(wsPath string, webhookURLs []string, webhookOpts ...HTTPNotifierOpt) *WebNotifier {
+	webhook := NewHTTPNotifier(webhookURLs, webhookOpts...)
 	ws := NewWSNotifier(wsPath)
 
 	n := WebNotifier{
+		webhook:   webhook,
 		notifiers: []command.Notifier{webhook, ws},
 		handlers:  ws.GetRESTHandlers(),
 	}
@@ -59,6 +61,16 @@ func (n *WebNotifier) Notify(topic string, message []byte) error {
 	return allErrs
 }
 
+// RegisterWebhook registers the webhook URL to be notified of the given topics, or of all topics if none is given.
+func (n *WebNotifier) RegisterWebhook(webhookURL string, topics ...string) error {
+	return n.webhook.RegisterWebhook(webhookURL, topics...)
+}
+
+// UnregisterWebhook unregisters the webhook URL.
+func (n *WebNotifier) UnregisterWebhook(webhookURL string) error {
+	return n.webhook.UnregisterWebhook(webhookURL)
+}
+
 // GetRESTHandlers returns all REST handlers provided by notifier.
 func (n *WebNotifier) GetRESTHandlers() []rest.Handler {
 	return n.handlers
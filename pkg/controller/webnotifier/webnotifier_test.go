@@ -36,6 +36,19 @@ func TestNotify(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestWebhooks(t *testing.T) {
+	n := New("/", nil, WithSecret([]byte("secret")))
+	require.NotNil(t, n)
+	require.Equal(t, []byte("secret"), n.webhook.secret)
+
+	require.NoError(t, n.RegisterWebhook("http://localhost:8080", "example"))
+	require.Len(t, n.webhook.webhooks, 1)
+
+	require.NoError(t, n.UnregisterWebhook("http://localhost:8080"))
+	require.Empty(t, n.webhook.webhooks)
+	require.Error(t, n.UnregisterWebhook("http://localhost:8080"))
+}
+
 func TestGetHandlers(t *testing.T) {
 	n := New("/", []string{"http://localhost:8080"})
 	require.NotNil(t, n)
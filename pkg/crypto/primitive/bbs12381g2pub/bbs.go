@@ -0,0 +1,170 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bbs12381g2pub implements the BBS+ signature scheme over the BLS12-381 curve with the public keys in G2:
+// messages are signed at once, and a proof of knowledge of the signature revealing only some of the messages is
+// derived from it by the holder of the signature.
+//
+// The scheme and its proof of knowledge follow "Anonymous Attestation Using the Strong Diffie Hellman Assumption
+// Revisited" (Camenisch, Drijvers, Lehmann, https://eprint.iacr.org/2016/663.pdf).
+package bbs12381g2pub
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+// BBSG2Pub is the BBS+ signature scheme with the public keys in G2.
+type BBSG2Pub struct{}
+
+// New creates a new BBSG2Pub.
+func New() *BBSG2Pub {
+	return &BBSG2Pub{}
+}
+
+// Sign signs the messages with the marshalled private key, the signature is valid for these messages in this order.
+func (bbs *BBSG2Pub) Sign(messages [][]byte, privKeyBytes []byte) ([]byte, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("messages are not defined")
+	}
+
+	privKey, err := UnmarshalPrivateKey(privKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal private key: %w", err)
+	}
+
+	pubKey, err := privKey.PublicKey().withGenerators(len(messages))
+	if err != nil {
+		return nil, fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	signature, err := newSignature(privKey, pubKey, messagesToFr(messages))
+	if err != nil {
+		return nil, fmt.Errorf("sign messages: %w", err)
+	}
+
+	return signature.ToBytes(), nil
+}
+
+// Verify verifies the signature of the messages with the marshalled public key.
+func (bbs *BBSG2Pub) Verify(messages [][]byte, sigBytes, pubKeyBytes []byte) error {
+	signature, err := ParseSignature(sigBytes)
+	if err != nil {
+		return fmt.Errorf("parse signature: %w", err)
+	}
+
+	pubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("unmarshal public key: %w", err)
+	}
+
+	pubKeyWithGenerators, err := pubKey.withGenerators(len(messages))
+	if err != nil {
+		return fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	return signature.Verify(messagesToFr(messages), pubKeyWithGenerators)
+}
+
+// DeriveProof derives from the signature of the messages a proof of knowledge of the signature revealing only the
+// messages at revealedIndexes, the proof is bound to the nonce.
+func (bbs *BBSG2Pub) DeriveProof(messages [][]byte, sigBytes, nonce, pubKeyBytes []byte,
+	revealedIndexes []int) ([]byte, error) {
+	if len(revealedIndexes) == 0 {
+		return nil, errors.New("no message to reveal")
+	}
+
+	if len(messages) > math.MaxUint16 {
+		return nil, fmt.Errorf("proofs of more than %d messages are not supported", math.MaxUint16)
+	}
+
+	revealed, err := sortedIndexes(revealedIndexes, len(messages))
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := ParseSignature(sigBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse signature: %w", err)
+	}
+
+	pubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal public key: %w", err)
+	}
+
+	pubKeyWithGenerators, err := pubKey.withGenerators(len(messages))
+	if err != nil {
+		return nil, fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	messagesFr := messagesToFr(messages)
+
+	err = signature.Verify(messagesFr, pubKeyWithGenerators)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := newPoKOfSignature(signature, messagesFr, revealed, pubKeyWithGenerators, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("derive proof: %w", err)
+	}
+
+	return proof.ToBytes(), nil
+}
+
+// VerifyProof verifies the proof of knowledge of a signature of messages by the marshalled public key, where
+// revealedMessages are the messages revealed by the proof in the order they were signed.
+func (bbs *BBSG2Pub) VerifyProof(revealedMessages [][]byte, proofBytes, nonce, pubKeyBytes []byte) error {
+	proof, err := ParseSignatureProof(proofBytes)
+	if err != nil {
+		return fmt.Errorf("parse signature proof: %w", err)
+	}
+
+	if len(revealedMessages) != len(proof.revealed) {
+		return fmt.Errorf("the proof reveals %d messages, got %d", len(proof.revealed), len(revealedMessages))
+	}
+
+	pubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("unmarshal public key: %w", err)
+	}
+
+	pubKeyWithGenerators, err := pubKey.withGenerators(proof.messagesCount)
+	if err != nil {
+		return fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	return proof.Verify(messagesToFr(revealedMessages), pubKeyWithGenerators, nonce)
+}
+
+// sortedIndexes returns the sorted indexes, they must be unique and less than messagesCount.
+func sortedIndexes(indexes []int, messagesCount int) ([]int, error) {
+	sorted := append([]int(nil), indexes...)
+	sort.Ints(sorted)
+
+	for i, index := range sorted {
+		if index < 0 || index >= messagesCount {
+			return nil, fmt.Errorf("revealed index %d is out of range", index)
+		}
+
+		if i > 0 && index == sorted[i-1] {
+			return nil, fmt.Errorf("revealed index %d is duplicated", index)
+		}
+	}
+
+	return sorted, nil
+}
+
+// nolint:gochecknoglobals
+var (
+	// curveOrder is the order of the groups of the curve, the scalars are in [0, curveOrder).
+	curveOrder = bls12381.NewG1().Q()
+)
@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBBSG2Pub_SignVerify(t *testing.T) {
+	pubKeyBytes, privKeyBytes := generateKeyPair(t)
+
+	messages := [][]byte{[]byte("message1"), []byte("message2"), []byte("message3")}
+
+	bbs := New()
+
+	sigBytes, err := bbs.Sign(messages, privKeyBytes)
+	require.NoError(t, err)
+	require.Len(t, sigBytes, signatureSize)
+
+	require.NoError(t, bbs.Verify(messages, sigBytes, pubKeyBytes))
+
+	t.Run("invalid signatures", func(t *testing.T) {
+		err := bbs.Verify([][]byte{[]byte("message1"), []byte("message3"), []byte("message2")}, sigBytes, pubKeyBytes)
+		require.EqualError(t, err, "invalid BBS+ signature")
+
+		err = bbs.Verify(messages[:2], sigBytes, pubKeyBytes)
+		require.EqualError(t, err, "invalid BBS+ signature")
+
+		otherPubKeyBytes, _ := generateKeyPair(t)
+
+		err = bbs.Verify(messages, sigBytes, otherPubKeyBytes)
+		require.EqualError(t, err, "invalid BBS+ signature")
+
+		err = bbs.Verify(messages, sigBytes[1:], pubKeyBytes)
+		require.EqualError(t, err, "parse signature: invalid size of signature: 111")
+
+		err = bbs.Verify(messages, sigBytes, pubKeyBytes[1:])
+		require.EqualError(t, err, "unmarshal public key: invalid size of public key: 95")
+	})
+
+	t.Run("invalid sign arguments", func(t *testing.T) {
+		_, err := bbs.Sign(nil, privKeyBytes)
+		require.EqualError(t, err, "messages are not defined")
+
+		_, err = bbs.Sign(messages, privKeyBytes[1:])
+		require.EqualError(t, err, "unmarshal private key: invalid size of private key: 31")
+
+		_, err = bbs.Sign(messages, make([]byte, PrivateKeySize))
+		require.EqualError(t, err, "unmarshal private key: invalid private key")
+	})
+}
+
+func TestBBSG2Pub_DeriveProof(t *testing.T) {
+	pubKeyBytes, privKeyBytes := generateKeyPair(t)
+
+	messages := [][]byte{
+		[]byte("message1"), []byte("message2"), []byte("message3"), []byte("message4"), []byte("message5"),
+	}
+
+	bbs := New()
+
+	sigBytes, err := bbs.Sign(messages, privKeyBytes)
+	require.NoError(t, err)
+
+	nonce := []byte("nonce")
+
+	proofBytes, err := bbs.DeriveProof(messages, sigBytes, nonce, pubKeyBytes, []int{3, 0})
+	require.NoError(t, err)
+
+	revealedMessages := [][]byte{messages[0], messages[3]}
+
+	require.NoError(t, bbs.VerifyProof(revealedMessages, proofBytes, nonce, pubKeyBytes))
+
+	t.Run("all messages revealed", func(t *testing.T) {
+		allProofBytes, err := bbs.DeriveProof(messages, sigBytes, nonce, pubKeyBytes, []int{0, 1, 2, 3, 4})
+		require.NoError(t, err)
+		require.NoError(t, bbs.VerifyProof(messages, allProofBytes, nonce, pubKeyBytes))
+	})
+
+	t.Run("proofs are unlinkable", func(t *testing.T) {
+		otherProofBytes, err := bbs.DeriveProof(messages, sigBytes, nonce, pubKeyBytes, []int{0, 3})
+		require.NoError(t, err)
+		require.NotEqual(t, proofBytes, otherProofBytes)
+		require.NoError(t, bbs.VerifyProof(revealedMessages, otherProofBytes, nonce, pubKeyBytes))
+	})
+
+	t.Run("invalid proofs", func(t *testing.T) {
+		err := bbs.VerifyProof(revealedMessages, proofBytes, []byte("other nonce"), pubKeyBytes)
+		require.EqualError(t, err, "invalid proof of knowledge of the signature")
+
+		err = bbs.VerifyProof([][]byte{messages[0], messages[2]}, proofBytes, nonce, pubKeyBytes)
+		require.EqualError(t, err, "invalid proof of knowledge of the signature")
+
+		err = bbs.VerifyProof([][]byte{messages[3], messages[0]}, proofBytes, nonce, pubKeyBytes)
+		require.EqualError(t, err, "invalid proof of knowledge of the signature")
+
+		err = bbs.VerifyProof(messages[:1], proofBytes, nonce, pubKeyBytes)
+		require.EqualError(t, err, "the proof reveals 2 messages, got 1")
+
+		otherPubKeyBytes, _ := generateKeyPair(t)
+
+		err = bbs.VerifyProof(revealedMessages, proofBytes, nonce, otherPubKeyBytes)
+		require.EqualError(t, err, "invalid BBS+ signature proof")
+
+		err = bbs.VerifyProof(revealedMessages, proofBytes[:len(proofBytes)-1], nonce, pubKeyBytes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse signature proof")
+
+		err = bbs.VerifyProof(revealedMessages, append(proofBytes, 0), nonce, pubKeyBytes)
+		require.EqualError(t, err, "parse signature proof: invalid size of signature proof")
+
+		err = bbs.VerifyProof(revealedMessages, proofBytes[:1], nonce, pubKeyBytes)
+		require.EqualError(t, err, "parse signature proof: invalid size of signature proof")
+
+		// the proof reveals the messages 0 and 1 instead of 0 and 3
+		tampered := append([]byte(nil), proofBytes...)
+		tampered[2] = 0x03
+
+		err = bbs.VerifyProof(revealedMessages, tampered, nonce, pubKeyBytes)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid derive proof arguments", func(t *testing.T) {
+		_, err := bbs.DeriveProof(messages, sigBytes, nonce, pubKeyBytes, nil)
+		require.EqualError(t, err, "no message to reveal")
+
+		_, err = bbs.DeriveProof(messages, sigBytes, nonce, pubKeyBytes, []int{5})
+		require.EqualError(t, err, "revealed index 5 is out of range")
+
+		_, err = bbs.DeriveProof(messages, sigBytes, nonce, pubKeyBytes, []int{1, 1})
+		require.EqualError(t, err, "revealed index 1 is duplicated")
+
+		_, err = bbs.DeriveProof(messages[1:], sigBytes, nonce, pubKeyBytes, []int{1})
+		require.EqualError(t, err, "invalid BBS+ signature")
+
+		_, err = bbs.DeriveProof(messages, sigBytes[1:], nonce, pubKeyBytes, []int{1})
+		require.Error(t, err)
+
+		_, err = bbs.DeriveProof(messages, sigBytes, nonce, pubKeyBytes[1:], []int{1})
+		require.Error(t, err)
+	})
+}
+
+func TestGenerateKeyPair(t *testing.T) {
+	seed := make([]byte, 32)
+
+	pubKey, privKey, err := GenerateKeyPair(sha256.New, seed)
+	require.NoError(t, err)
+
+	// the key pair is derived from the seed
+	samePubKey, samePrivKey, err := GenerateKeyPair(sha256.New, seed)
+	require.NoError(t, err)
+	require.Equal(t, privKey, samePrivKey)
+	require.Equal(t, pubKey.PointG2, samePubKey.PointG2)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+	require.Len(t, privKeyBytes, PrivateKeySize)
+
+	unmarshalledPrivKey, err := UnmarshalPrivateKey(privKeyBytes)
+	require.NoError(t, err)
+	require.Equal(t, privKey, unmarshalledPrivKey)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+	require.Len(t, pubKeyBytes, PublicKeySize)
+
+	unmarshalledPubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	require.NoError(t, err)
+
+	samePubKeyBytes, err := unmarshalledPubKey.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, pubKeyBytes, samePubKeyBytes)
+
+	_, _, err = GenerateKeyPair(sha256.New, seed[1:])
+	require.EqualError(t, err, "seed is shorter than 32 bytes")
+
+	_, err = UnmarshalPublicKey(make([]byte, PublicKeySize))
+	require.Error(t, err)
+}
+
+func generateKeyPair(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	pubKey, privKey, err := GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	return pubKeyBytes, privKeyBytes
+}
@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// frSize is the size of the marshalled scalars.
+const frSize = 32
+
+// hashToFr hashes the data to a scalar, the 384 bits of the hash are reduced to avoid a biased scalar.
+func hashToFr(data ...[]byte) *big.Int {
+	// blake2b.New384 only fails for keys longer than 64 bytes
+	h, _ := blake2b.New384(nil) // nolint:errcheck
+
+	for _, d := range data {
+		h.Write(d) // nolint:errcheck
+	}
+
+	return new(big.Int).Mod(new(big.Int).SetBytes(h.Sum(nil)), curveOrder)
+}
+
+// messagesToFr returns the scalars of the messages to sign or verify.
+func messagesToFr(messages [][]byte) []*big.Int {
+	messagesFr := make([]*big.Int, len(messages))
+
+	for i, msg := range messages {
+		messagesFr[i] = hashToFr(msg)
+	}
+
+	return messagesFr
+}
+
+// randomFr returns a random non-zero scalar.
+func randomFr() (*big.Int, error) {
+	for {
+		r, err := rand.Int(rand.Reader, curveOrder)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.Sign() != 0 {
+			return r, nil
+		}
+	}
+}
+
+// randomFrs returns count random non-zero scalars.
+func randomFrs(count int) ([]*big.Int, error) {
+	frs := make([]*big.Int, count)
+
+	for i := range frs {
+		r, err := randomFr()
+		if err != nil {
+			return nil, err
+		}
+
+		frs[i] = r
+	}
+
+	return frs, nil
+}
+
+func frAdd(a, b *big.Int) *big.Int {
+	return new(big.Int).Mod(new(big.Int).Add(a, b), curveOrder)
+}
+
+func frSub(a, b *big.Int) *big.Int {
+	return new(big.Int).Mod(new(big.Int).Sub(a, b), curveOrder)
+}
+
+func frMul(a, b *big.Int) *big.Int {
+	return new(big.Int).Mod(new(big.Int).Mul(a, b), curveOrder)
+}
+
+func frNeg(a *big.Int) *big.Int {
+	return new(big.Int).Mod(new(big.Int).Neg(a), curveOrder)
+}
+
+func frInverse(a *big.Int) *big.Int {
+	return new(big.Int).ModInverse(a, curveOrder)
+}
+
+func frToBytes(a *big.Int) []byte {
+	b := make([]byte, frSize)
+
+	return a.FillBytes(b)
+}
+
+func frFromBytes(b []byte) (*big.Int, error) {
+	if len(b) != frSize {
+		return nil, errors.New("invalid size of scalar")
+	}
+
+	a := new(big.Int).SetBytes(b)
+	if a.Cmp(curveOrder) >= 0 {
+		return nil, errors.New("scalar is not in the field")
+	}
+
+	return a, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+
+	bls12381 "github.com/kilic/bls12-381"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// PrivateKeySize is the size of the marshalled private keys.
+	PrivateKeySize = 32
+	// PublicKeySize is the size of the marshalled public keys, the compressed G2 points.
+	PublicKeySize = 96
+
+	seedSize = 32
+	// the private key is reduced from 48 bytes of key material to avoid a biased key.
+	keyMaterialSize = 48
+
+	keyGenSalt   = "BBS-SIG-KEYGEN-SALT-"
+	generatorDST = "BLS12381G1_XMD:SHA-256_SSWU_RO_BBS+_SIGNATURES_GENERATORS_"
+)
+
+// PrivateKey is a BBS+ private key, the scalar x of the public key w = g2^x.
+type PrivateKey struct {
+	FR *big.Int
+}
+
+// PublicKey is a BBS+ public key, a point of G2.
+type PublicKey struct {
+	PointG2 *bls12381.PointG2
+}
+
+// publicKeyWithGenerators is a public key along with the G1 generators of the signatures of a number of messages:
+// h0 for the blinding factor of the signatures and h[i] for the message i.
+type publicKeyWithGenerators struct {
+	w  *bls12381.PointG2
+	h0 *bls12381.PointG1
+	h  []*bls12381.PointG1
+}
+
+// GenerateKeyPair generates a key pair from the seed with the HKDF of the hash function h, from a random seed if
+// seed is nil.
+func GenerateKeyPair(h func() hash.Hash, seed []byte) (*PublicKey, *PrivateKey, error) {
+	if seed == nil {
+		seed = make([]byte, seedSize)
+
+		_, err := rand.Read(seed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("generate seed: %w", err)
+		}
+	}
+
+	if len(seed) < seedSize {
+		return nil, nil, fmt.Errorf("seed is shorter than %d bytes", seedSize)
+	}
+
+	okm := make([]byte, keyMaterialSize)
+
+	_, err := io.ReadFull(hkdf.New(h, seed, []byte(keyGenSalt), nil), okm)
+	if err != nil {
+		return nil, nil, fmt.Errorf("derive key material: %w", err)
+	}
+
+	x := new(big.Int).Mod(new(big.Int).SetBytes(okm), curveOrder)
+	if x.Sign() == 0 {
+		return nil, nil, errors.New("invalid key material")
+	}
+
+	privKey := &PrivateKey{FR: x}
+
+	return privKey.PublicKey(), privKey, nil
+}
+
+// UnmarshalPrivateKey unmarshals a private key marshalled by PrivateKey.Marshal.
+func UnmarshalPrivateKey(privKeyBytes []byte) (*PrivateKey, error) {
+	if len(privKeyBytes) != PrivateKeySize {
+		return nil, fmt.Errorf("invalid size of private key: %d", len(privKeyBytes))
+	}
+
+	x := new(big.Int).SetBytes(privKeyBytes)
+	if x.Sign() == 0 || x.Cmp(curveOrder) >= 0 {
+		return nil, errors.New("invalid private key")
+	}
+
+	return &PrivateKey{FR: x}, nil
+}
+
+// Marshal marshals the private key to its big-endian bytes.
+func (k *PrivateKey) Marshal() ([]byte, error) {
+	return frToBytes(k.FR), nil
+}
+
+// PublicKey returns the public key of the private key.
+func (k *PrivateKey) PublicKey() *PublicKey {
+	g2 := bls12381.NewG2()
+
+	return &PublicKey{PointG2: g2.MulScalar(g2.New(), g2.One(), k.FR)}
+}
+
+// UnmarshalPublicKey unmarshals a public key marshalled by PublicKey.Marshal.
+func UnmarshalPublicKey(pubKeyBytes []byte) (*PublicKey, error) {
+	if len(pubKeyBytes) != PublicKeySize {
+		return nil, fmt.Errorf("invalid size of public key: %d", len(pubKeyBytes))
+	}
+
+	g2 := bls12381.NewG2()
+
+	w, err := g2.FromCompressed(pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize public key: %w", err)
+	}
+
+	if g2.IsZero(w) {
+		return nil, errors.New("invalid public key")
+	}
+
+	return &PublicKey{PointG2: w}, nil
+}
+
+// Marshal marshals the public key to its compressed G2 point.
+func (k *PublicKey) Marshal() ([]byte, error) {
+	return bls12381.NewG2().ToCompressed(k.PointG2), nil
+}
+
+// withGenerators returns the public key with the generators of the signatures of messagesCount messages, the
+// generators are hashed from the public key and the number of messages.
+func (k *PublicKey) withGenerators(messagesCount int) (*publicKeyWithGenerators, error) {
+	g1 := bls12381.NewG1()
+	data := bls12381.NewG2().ToCompressed(k.PointG2)
+
+	generator := func(i int) (*bls12381.PointG1, error) {
+		msg := make([]byte, len(data), len(data)+8)
+		copy(msg, data)
+
+		msg = append(msg, uint32ToBytes(uint32(i))...)
+		msg = append(msg, uint32ToBytes(uint32(messagesCount))...)
+
+		return g1.HashToCurve(msg, []byte(generatorDST))
+	}
+
+	h0, err := generator(0)
+	if err != nil {
+		return nil, err
+	}
+
+	h := make([]*bls12381.PointG1, messagesCount)
+
+	for i := range h {
+		h[i], err = generator(i + 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &publicKeyWithGenerators{w: k.PointG2, h0: h0, h: h}, nil
+}
+
+func uint32ToBytes(value uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, value)
+
+	return b
+}
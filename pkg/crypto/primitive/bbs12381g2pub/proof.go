@@ -0,0 +1,419 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+const (
+	bitsPerByte = 8
+
+	// pok1Responses is the number of responses of the proof of knowledge of (e, r2).
+	pok1Responses = 2
+	// pok2FixedResponses is the number of responses for (r3, s') of the proof of knowledge of the hidden messages.
+	pok2FixedResponses = 2
+)
+
+// PoKOfSignatureProof is a zero-knowledge proof of knowledge of a signature of messages revealing some of the
+// messages. With the signature (A, e, s) randomized by r1 and r2 (r3 = 1/r1):
+//   A' = A^r1, Abar = A'^-e * b^r1, d = b^r1 * h0^-r2 and s' = s - r2 * r3
+// it proves that e(A', w) = e(Abar, g2) along with the knowledge of:
+//   (e, r2) such that Abar / d = A'^-e * h0^r2
+//   (r3, s', hidden messages m[j]) such that g1 * Π revealed h[i]^m[i] = d^r3 * h0^-s' * Π hidden h[j]^-m[j].
+type PoKOfSignatureProof struct {
+	messagesCount int
+	revealed      []int
+
+	aPrime *bls12381.PointG1
+	aBar   *bls12381.PointG1
+	d      *bls12381.PointG1
+
+	proof1 *proofG1
+	proof2 *proofG1
+}
+
+// proofG1 is a Schnorr proof of knowledge of the exponents x[k] of a G1 point P = Π B[k]^x[k]: the commitment
+// T = Π B[k]^ρ[k] of random blindings ρ[k] and the responses z[k] = ρ[k] - c * x[k] to the challenge c.
+type proofG1 struct {
+	commitment *bls12381.PointG1
+	responses  []*big.Int
+}
+
+// proverCommittedG1 is the commitment of a proofG1 along with its blindings.
+type proverCommittedG1 struct {
+	blindings  []*big.Int
+	commitment *bls12381.PointG1
+}
+
+// newPoKOfSignature returns the proof of knowledge of the signature of the messages revealing the messages at the
+// sorted indexes revealed.
+func newPoKOfSignature(signature *Signature, messages []*big.Int, revealed []int,
+	pubKey *publicKeyWithGenerators, nonce []byte) (*PoKOfSignatureProof, error) {
+	g1 := bls12381.NewG1()
+
+	frs, err := randomFrs(2) // nolint:gomnd
+	if err != nil {
+		return nil, err
+	}
+
+	r1, r2 := frs[0], frs[1]
+	r3 := frInverse(r1)
+
+	b, err := computeB(signature.S, messages, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	aPrime := g1.MulScalar(g1.New(), signature.A, r1)
+
+	aBar := g1.MulScalar(g1.New(), aPrime, frNeg(signature.E))
+	g1.Add(aBar, aBar, g1.MulScalar(g1.New(), b, r1))
+
+	d := g1.MulScalar(g1.New(), b, r1)
+	g1.Add(d, d, g1.MulScalar(g1.New(), pubKey.h0, frNeg(r2)))
+
+	sPrime := frSub(signature.S, frMul(r2, r3))
+
+	// Abar / d = A'^-e * h0^r2
+	pok1, err := newProverCommittedG1([]*bls12381.PointG1{aPrime, pubKey.h0})
+	if err != nil {
+		return nil, err
+	}
+
+	// g1 * Π revealed h[i]^m[i] = d^r3 * h0^-s' * Π hidden h[j]^-m[j]
+	hidden := hiddenIndexes(revealed, len(messages))
+
+	bases2 := []*bls12381.PointG1{d, pubKey.h0}
+	secrets2 := []*big.Int{r3, frNeg(sPrime)}
+
+	for _, j := range hidden {
+		bases2 = append(bases2, pubKey.h[j])
+		secrets2 = append(secrets2, frNeg(messages[j]))
+	}
+
+	pok2, err := newProverCommittedG1(bases2)
+	if err != nil {
+		return nil, err
+	}
+
+	revealedMessages := make([]*big.Int, len(revealed))
+	for i, index := range revealed {
+		revealedMessages[i] = messages[index]
+	}
+
+	p2, err := revealedPoint(revealed, revealedMessages, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	c := challenge(aPrime, aBar, d, pok1.commitment, pok2.commitment, p2, nonce)
+
+	return &PoKOfSignatureProof{
+		messagesCount: len(messages),
+		revealed:      revealed,
+		aPrime:        aPrime,
+		aBar:          aBar,
+		d:             d,
+		proof1:        pok1.respond([]*big.Int{frNeg(signature.E), r2}, c),
+		proof2:        pok2.respond(secrets2, c),
+	}, nil
+}
+
+// Verify verifies the proof of knowledge of a signature of messages revealing revealedMessages, the messages at the
+// revealed indexes of the proof.
+func (p *PoKOfSignatureProof) Verify(revealedMessages []*big.Int, pubKey *publicKeyWithGenerators,
+	nonce []byte) error {
+	g1, g2 := bls12381.NewG1(), bls12381.NewG2()
+
+	if g1.IsZero(p.aPrime) {
+		return errors.New("invalid signature proof")
+	}
+
+	if !bls12381.NewEngine().AddPair(p.aPrime, pubKey.w).AddPairInv(p.aBar, g2.One()).Check() {
+		return errors.New("invalid BBS+ signature proof")
+	}
+
+	p2, err := revealedPoint(p.revealed, revealedMessages, pubKey)
+	if err != nil {
+		return err
+	}
+
+	c := challenge(p.aPrime, p.aBar, p.d, p.proof1.commitment, p.proof2.commitment, p2, nonce)
+
+	p1 := g1.Sub(g1.New(), p.aBar, p.d)
+
+	if !p.proof1.verify([]*bls12381.PointG1{p.aPrime, pubKey.h0}, p1, c) {
+		return errors.New("invalid proof of knowledge of the signature")
+	}
+
+	bases2 := []*bls12381.PointG1{p.d, pubKey.h0}
+	for _, j := range hiddenIndexes(p.revealed, p.messagesCount) {
+		bases2 = append(bases2, pubKey.h[j])
+	}
+
+	if !p.proof2.verify(bases2, p2, c) {
+		return errors.New("invalid proof of knowledge of the hidden messages")
+	}
+
+	return nil
+}
+
+// ToBytes marshals the proof:
+//   messages count (2 bytes) || revealed bit vector || A' || Abar || d || proof1 || proof2
+// where a proof of knowledge is its commitment || responses count (4 bytes) || responses.
+func (p *PoKOfSignatureProof) ToBytes() []byte {
+	g1 := bls12381.NewG1()
+
+	bytes := make([]byte, 2) // nolint:gomnd
+	binary.BigEndian.PutUint16(bytes, uint16(p.messagesCount))
+
+	bytes = append(bytes, bitVector(p.revealed, p.messagesCount)...)
+	bytes = append(bytes, g1.ToCompressed(p.aPrime)...)
+	bytes = append(bytes, g1.ToCompressed(p.aBar)...)
+	bytes = append(bytes, g1.ToCompressed(p.d)...)
+	bytes = append(bytes, p.proof1.toBytes()...)
+	bytes = append(bytes, p.proof2.toBytes()...)
+
+	return bytes
+}
+
+// ParseSignatureProof parses a proof marshalled by PoKOfSignatureProof.ToBytes.
+func ParseSignatureProof(proofBytes []byte) (*PoKOfSignatureProof, error) {
+	if len(proofBytes) < 2 { // nolint:gomnd
+		return nil, errors.New("invalid size of signature proof")
+	}
+
+	messagesCount := int(binary.BigEndian.Uint16(proofBytes))
+	offset := 2 // nolint:gomnd
+
+	bitVectorSize := (messagesCount + bitsPerByte - 1) / bitsPerByte
+	if len(proofBytes) < offset+bitVectorSize+3*g1CompressedSize {
+		return nil, errors.New("invalid size of signature proof")
+	}
+
+	revealed := revealedFromBitVector(proofBytes[offset:offset+bitVectorSize], messagesCount)
+	offset += bitVectorSize
+
+	points, err := parseG1Points(proofBytes[offset:], 3) // nolint:gomnd
+	if err != nil {
+		return nil, fmt.Errorf("parse signature proof: %w", err)
+	}
+
+	offset += 3 * g1CompressedSize
+
+	proof1, n, err := parseProofG1(proofBytes[offset:])
+	if err != nil {
+		return nil, fmt.Errorf("parse proof of knowledge of the signature: %w", err)
+	}
+
+	offset += n
+
+	proof2, n, err := parseProofG1(proofBytes[offset:])
+	if err != nil {
+		return nil, fmt.Errorf("parse proof of knowledge of the hidden messages: %w", err)
+	}
+
+	if offset+n != len(proofBytes) {
+		return nil, errors.New("invalid size of signature proof")
+	}
+
+	if len(proof1.responses) != pok1Responses ||
+		len(proof2.responses) != pok2FixedResponses+messagesCount-len(revealed) {
+		return nil, errors.New("invalid number of responses in signature proof")
+	}
+
+	return &PoKOfSignatureProof{
+		messagesCount: messagesCount,
+		revealed:      revealed,
+		aPrime:        points[0],
+		aBar:          points[1],
+		d:             points[2],
+		proof1:        proof1,
+		proof2:        proof2,
+	}, nil
+}
+
+func newProverCommittedG1(bases []*bls12381.PointG1) (*proverCommittedG1, error) {
+	blindings, err := randomFrs(len(bases))
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := multiExp(bases, blindings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proverCommittedG1{blindings: blindings, commitment: commitment}, nil
+}
+
+// respond returns the proof of knowledge of the secrets for the challenge c.
+func (pc *proverCommittedG1) respond(secrets []*big.Int, c *big.Int) *proofG1 {
+	responses := make([]*big.Int, len(secrets))
+
+	for i, secret := range secrets {
+		responses[i] = frSub(pc.blindings[i], frMul(c, secret))
+	}
+
+	return &proofG1{commitment: pc.commitment, responses: responses}
+}
+
+// verify checks that T = Π B[k]^z[k] * P^c.
+func (pg *proofG1) verify(bases []*bls12381.PointG1, p *bls12381.PointG1, c *big.Int) bool {
+	if len(bases) != len(pg.responses) {
+		return false
+	}
+
+	points := append(append([]*bls12381.PointG1(nil), bases...), p)
+	scalars := append(append([]*big.Int(nil), pg.responses...), c)
+
+	t, err := multiExp(points, scalars)
+	if err != nil {
+		return false
+	}
+
+	return bls12381.NewG1().Equal(t, pg.commitment)
+}
+
+func (pg *proofG1) toBytes() []byte {
+	bytes := bls12381.NewG1().ToCompressed(pg.commitment)
+	bytes = append(bytes, uint32ToBytes(uint32(len(pg.responses)))...)
+
+	for _, r := range pg.responses {
+		bytes = append(bytes, frToBytes(r)...)
+	}
+
+	return bytes
+}
+
+// parseProofG1 parses a proof of knowledge and returns the number of bytes read.
+func parseProofG1(bytes []byte) (*proofG1, int, error) {
+	const countSize = 4
+
+	if len(bytes) < g1CompressedSize+countSize {
+		return nil, 0, errors.New("invalid size of proof of knowledge")
+	}
+
+	commitment, err := bls12381.NewG1().FromCompressed(bytes[:g1CompressedSize])
+	if err != nil {
+		return nil, 0, fmt.Errorf("deserialize commitment: %w", err)
+	}
+
+	offset := g1CompressedSize
+
+	count := int(binary.BigEndian.Uint32(bytes[offset:]))
+	offset += countSize
+
+	if count > (len(bytes)-offset)/frSize {
+		return nil, 0, errors.New("invalid size of proof of knowledge")
+	}
+
+	responses := make([]*big.Int, count)
+
+	for i := range responses {
+		responses[i], err = frFromBytes(bytes[offset : offset+frSize])
+		if err != nil {
+			return nil, 0, fmt.Errorf("deserialize response: %w", err)
+		}
+
+		offset += frSize
+	}
+
+	return &proofG1{commitment: commitment, responses: responses}, offset, nil
+}
+
+// revealedPoint returns g1 * Π revealed h[i]^m[i].
+func revealedPoint(revealed []int, revealedMessages []*big.Int,
+	pubKey *publicKeyWithGenerators) (*bls12381.PointG1, error) {
+	g1 := bls12381.NewG1()
+
+	points := []*bls12381.PointG1{g1.One()}
+	scalars := []*big.Int{big.NewInt(1)}
+
+	for i, index := range revealed {
+		points = append(points, pubKey.h[index])
+		scalars = append(scalars, revealedMessages[i])
+	}
+
+	return multiExp(points, scalars)
+}
+
+// challenge returns the Fiat-Shamir challenge of the proof of knowledge.
+func challenge(aPrime, aBar, d, t1, t2, p2 *bls12381.PointG1, nonce []byte) *big.Int {
+	g1 := bls12381.NewG1()
+
+	return hashToFr(g1.ToCompressed(aPrime), g1.ToCompressed(aBar), g1.ToCompressed(d),
+		g1.ToCompressed(t1), g1.ToCompressed(t2), g1.ToCompressed(p2), nonce)
+}
+
+func multiExp(points []*bls12381.PointG1, scalars []*big.Int) (*bls12381.PointG1, error) {
+	g1 := bls12381.NewG1()
+
+	// MultiExp replaces the scalars of the slice
+	return g1.MultiExp(g1.New(), points, append([]*big.Int(nil), scalars...))
+}
+
+func parseG1Points(bytes []byte, count int) ([]*bls12381.PointG1, error) {
+	g1 := bls12381.NewG1()
+	points := make([]*bls12381.PointG1, count)
+
+	for i := range points {
+		p, err := g1.FromCompressed(bytes[i*g1CompressedSize : (i+1)*g1CompressedSize])
+		if err != nil {
+			return nil, err
+		}
+
+		points[i] = p
+	}
+
+	return points, nil
+}
+
+// hiddenIndexes returns the indexes of the messages not in the sorted indexes revealed.
+func hiddenIndexes(revealed []int, messagesCount int) []int {
+	hidden := make([]int, 0, messagesCount-len(revealed))
+
+	for i, j := 0, 0; i < messagesCount; i++ {
+		if j < len(revealed) && revealed[j] == i {
+			j++
+
+			continue
+		}
+
+		hidden = append(hidden, i)
+	}
+
+	return hidden
+}
+
+func bitVector(indexes []int, size int) []byte {
+	bytes := make([]byte, (size+bitsPerByte-1)/bitsPerByte)
+
+	for _, index := range indexes {
+		bytes[index/bitsPerByte] |= 1 << (index % bitsPerByte)
+	}
+
+	return bytes
+}
+
+func revealedFromBitVector(bytes []byte, size int) []int {
+	var indexes []int
+
+	for i := 0; i < size; i++ {
+		if bytes[i/bitsPerByte]&(1<<(i%bitsPerByte)) != 0 {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
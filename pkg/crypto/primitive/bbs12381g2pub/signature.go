@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+const (
+	g1CompressedSize = 48
+
+	// signatureSize is the size of the marshalled signatures: A || e || s.
+	signatureSize = g1CompressedSize + 2*frSize
+)
+
+// Signature is a BBS+ signature (A, e, s) of messages m[i] where A = b^(1/(x+e)) with b = g1 * h0^s * Π h[i]^m[i].
+type Signature struct {
+	A *bls12381.PointG1
+	E *big.Int
+	S *big.Int
+}
+
+// newSignature signs the messages with the private key.
+func newSignature(privKey *PrivateKey, pubKey *publicKeyWithGenerators, messages []*big.Int) (*Signature, error) {
+	frs, err := randomFrs(2) // nolint:gomnd
+	if err != nil {
+		return nil, err
+	}
+
+	e, s := frs[0], frs[1]
+
+	exp := frAdd(privKey.FR, e)
+	if exp.Sign() == 0 {
+		return nil, errors.New("invalid signature exponent")
+	}
+
+	g1 := bls12381.NewG1()
+
+	b, err := computeB(s, messages, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signature{A: g1.MulScalar(g1.New(), b, frInverse(exp)), E: e, S: s}, nil
+}
+
+// ParseSignature parses a signature marshalled by Signature.ToBytes.
+func ParseSignature(sigBytes []byte) (*Signature, error) {
+	if len(sigBytes) != signatureSize {
+		return nil, fmt.Errorf("invalid size of signature: %d", len(sigBytes))
+	}
+
+	g1 := bls12381.NewG1()
+
+	a, err := g1.FromCompressed(sigBytes[:g1CompressedSize])
+	if err != nil {
+		return nil, fmt.Errorf("deserialize G1 point of signature: %w", err)
+	}
+
+	e, err := frFromBytes(sigBytes[g1CompressedSize : g1CompressedSize+frSize])
+	if err != nil {
+		return nil, fmt.Errorf("deserialize e of signature: %w", err)
+	}
+
+	s, err := frFromBytes(sigBytes[g1CompressedSize+frSize:])
+	if err != nil {
+		return nil, fmt.Errorf("deserialize s of signature: %w", err)
+	}
+
+	return &Signature{A: a, E: e, S: s}, nil
+}
+
+// ToBytes marshals the signature.
+func (s *Signature) ToBytes() []byte {
+	sigBytes := make([]byte, 0, signatureSize)
+
+	sigBytes = append(sigBytes, bls12381.NewG1().ToCompressed(s.A)...)
+	sigBytes = append(sigBytes, frToBytes(s.E)...)
+	sigBytes = append(sigBytes, frToBytes(s.S)...)
+
+	return sigBytes
+}
+
+// Verify verifies the signature of the messages: e(A, w * g2^e) = e(b, g2).
+func (s *Signature) Verify(messages []*big.Int, pubKey *publicKeyWithGenerators) error {
+	g1, g2 := bls12381.NewG1(), bls12381.NewG2()
+
+	if g1.IsZero(s.A) {
+		return errors.New("invalid signature")
+	}
+
+	b, err := computeB(s.S, messages, pubKey)
+	if err != nil {
+		return err
+	}
+
+	p2 := g2.MulScalar(g2.New(), g2.One(), s.E)
+	g2.Add(p2, p2, pubKey.w)
+
+	if !bls12381.NewEngine().AddPair(s.A, p2).AddPairInv(b, g2.One()).Check() {
+		return errors.New("invalid BBS+ signature")
+	}
+
+	return nil
+}
+
+// computeB returns b = g1 * h0^s * Π h[i]^m[i].
+func computeB(s *big.Int, messages []*big.Int, pubKey *publicKeyWithGenerators) (*bls12381.PointG1, error) {
+	if len(messages) != len(pubKey.h) {
+		return nil, fmt.Errorf("%d messages for the generators of %d messages", len(messages), len(pubKey.h))
+	}
+
+	g1 := bls12381.NewG1()
+
+	points := append([]*bls12381.PointG1{g1.One(), pubKey.h0}, pubKey.h...)
+	scalars := append([]*big.Int{big.NewInt(1), s}, messages...)
+
+	return g1.MultiExp(g1.New(), points, scalars)
+}
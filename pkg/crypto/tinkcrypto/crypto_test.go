@@ -18,6 +18,7 @@ import (
 	chacha "golang.org/x/crypto/chacha20poly1305"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
 )
 
 const testMessage = "test message"
@@ -238,3 +239,47 @@ func TestCrypto_VerifyMAC(t *testing.T) {
 		require.EqualError(t, err, "mac_factory: not a MAC primitive")
 	})
 }
+
+func TestCrypto_SignMultiVerifyMulti(t *testing.T) {
+	t.Run("test with BBS+ signature", func(t *testing.T) {
+		kh, err := keyset.NewHandle(bbs.BLS12381G2KeyTemplate())
+		require.NoError(t, err)
+
+		badKH, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+		require.NoError(t, err)
+
+		c := Crypto{}
+		messages := [][]byte{[]byte(testMessage), []byte("other test message")}
+		s, err := c.SignMulti(messages, kh)
+		require.NoError(t, err)
+
+		// sign with nil key handle - should fail
+		_, err = c.SignMulti(messages, nil)
+		require.Error(t, err)
+		require.Equal(t, errBadKeyHandleFormat, err)
+
+		// sign with bad key handle - should fail
+		_, err = c.SignMulti(messages, badKH)
+		require.Error(t, err)
+
+		// get corresponding public key handle to verify
+		pubKH, err := kh.Public()
+		require.NoError(t, err)
+
+		err = c.VerifyMulti(messages, s, pubKH)
+		require.NoError(t, err)
+
+		// verify other messages - should fail
+		err = c.VerifyMulti(messages[:1], s, pubKH)
+		require.Error(t, err)
+
+		// verify with nil key handle - should fail
+		err = c.VerifyMulti(messages, s, nil)
+		require.Error(t, err)
+		require.Equal(t, errBadKeyHandleFormat, err)
+
+		// verify with bad key handle - should fail
+		err = c.VerifyMulti(messages, s, badKH)
+		require.Error(t, err)
+	})
+}
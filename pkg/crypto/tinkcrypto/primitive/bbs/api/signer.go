@@ -0,0 +1,16 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+// Signer is the signing interface primitive for BBS+ signatures used by Tink.
+type Signer interface {
+	// Sign will create a single BBS+ signature of all the messages using the signer's private key.
+	// returns:
+	// 		signature in []byte
+	//		error in case of errors
+	Sign(messages [][]byte) ([]byte, error)
+}
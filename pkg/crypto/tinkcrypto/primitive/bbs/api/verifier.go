@@ -0,0 +1,15 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+// Verifier is the verification interface primitive for BBS+ signatures used by Tink.
+type Verifier interface {
+	// Verify will verify a BBS+ signature of the messages against the signer's public key.
+	// returns:
+	// 		error in case of errors or nil if signature verification was successful
+	Verify(messages [][]byte, signature []byte) error
+}
@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bbs provides implementations of BBS+ key management and primitives.
+//
+// The functionality of BBS+ signatures is represented as a pair of
+// primitives (interfaces):
+//
+//  * Signer for signing a list of messages with a private key
+//
+//  * Verifier for verifying a signature of a list of messages with the signer's public key
+//
+//
+// Example:
+//
+//  package main
+//
+//  import (
+//      "github.com/google/tink/go/keyset"
+//
+//      "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
+//  )
+//
+//  func main() {
+//      // create signer keyset handle
+//      kh, err := keyset.NewHandle(bbs.BLS12381G2KeyTemplate())
+//      if err != nil {
+//          //handle error
+//      }
+//
+//      // extract signer public keyset handle
+//      pubKH, err := kh.Public()
+//      if err != nil {
+//          //handle error
+//      }
+//
+//      s, err := bbs.NewSigner(kh)
+//      if err != nil {
+//          //handle error
+//      }
+//
+//      messages := [][]byte{[]byte("message 1"), []byte("message 2")}
+//
+//      sig, err := s.Sign(messages)
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      v, err := bbs.NewVerifier(pubKH)
+//      if err != nil {
+//          //handle error
+//      }
+//
+//      err = v.Verify(messages, sig)
+//      if err != nil {
+//          // handle error
+//      }
+//  }
+package bbs
+
+import (
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+)
+
+// TODO - find a better way to setup tink than init.
+// nolint: gochecknoinits
+func init() {
+	// TODO - avoid the tink registry singleton.
+	err := registry.RegisterKeyManager(newBBSSignerKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("bbs.init() failed: %v", err))
+	}
+
+	err = registry.RegisterKeyManager(newBBSVerifierKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("bbs.init() failed: %v", err))
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"testing"
+
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBBSFactory(t *testing.T) {
+	messages := [][]byte{[]byte("message 1"), []byte("message 2")}
+
+	for _, prefixType := range []tinkpb.OutputPrefixType{
+		tinkpb.OutputPrefixType_RAW,
+		tinkpb.OutputPrefixType_TINK,
+		tinkpb.OutputPrefixType_LEGACY,
+	} {
+		kt := BLS12381G2KeyTemplate()
+		kt.OutputPrefixType = prefixType
+
+		kh, err := keyset.NewHandle(kt)
+		require.NoError(t, err)
+
+		pubKH, err := kh.Public()
+		require.NoError(t, err)
+
+		s, err := NewSigner(kh)
+		require.NoError(t, err)
+
+		sig, err := s.Sign(messages)
+		require.NoError(t, err)
+
+		v, err := NewVerifier(pubKH)
+		require.NoError(t, err)
+
+		require.NoError(t, v.Verify(messages, sig), prefixType.String())
+
+		err = v.Verify(messages[:1], sig)
+		require.EqualError(t, err, "bbs_verifier_factory: invalid signature")
+	}
+
+	t.Run("create primitives from keyset handles of other key types", func(t *testing.T) {
+		kh, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+		require.NoError(t, err)
+
+		_, err = NewSigner(kh)
+		require.EqualError(t, err, "bbs_signer_factory: not a BBS Signer primitive")
+
+		pubKH, err := kh.Public()
+		require.NoError(t, err)
+
+		_, err = NewVerifier(pubKH)
+		require.EqualError(t, err, "bbs_verifier_factory: not a BBS Verifier primitive")
+	})
+
+	t.Run("create signer from a public keyset handle", func(t *testing.T) {
+		kh, err := keyset.NewHandle(BLS12381G2KeyTemplate())
+		require.NoError(t, err)
+
+		pubKH, err := kh.Public()
+		require.NoError(t, err)
+
+		_, err = NewSigner(pubKH)
+		require.EqualError(t, err, "bbs_signer_factory: not a BBS Signer primitive")
+	})
+}
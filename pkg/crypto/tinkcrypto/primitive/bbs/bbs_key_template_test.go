@@ -0,0 +1,38 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"testing"
+
+	"github.com/google/tink/go/keyset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBBSKeyTemplateSuccess(t *testing.T) {
+	kh, err := keyset.NewHandle(BLS12381G2KeyTemplate())
+	require.NoError(t, err)
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	s, err := NewSigner(kh)
+	require.NoError(t, err)
+
+	messages := [][]byte{[]byte("message 1"), []byte("message 2"), []byte("message 3")}
+
+	sig, err := s.Sign(messages)
+	require.NoError(t, err)
+
+	v, err := NewVerifier(pubKH)
+	require.NoError(t, err)
+
+	require.NoError(t, v.Verify(messages, sig))
+
+	err = v.Verify([][]byte{messages[2], messages[1], messages[0]}, sig)
+	require.EqualError(t, err, "bbs_verifier_factory: invalid signature")
+}
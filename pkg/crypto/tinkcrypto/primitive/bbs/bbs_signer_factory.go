@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/core/cryptofmt"
+	"github.com/google/tink/go/core/primitiveset"
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	bbsapi "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs/api"
+)
+
+// NewSigner returns a BBS+ Signer primitive from the given keyset handle.
+func NewSigner(h *keyset.Handle) (bbsapi.Signer, error) {
+	return NewSignerWithKeyManager(h, nil /*keyManager*/)
+}
+
+// NewSignerWithKeyManager returns a BBS+ Signer primitive from the given keyset handle and custom key manager.
+func NewSignerWithKeyManager(h *keyset.Handle, km registry.KeyManager) (bbsapi.Signer, error) {
+	ps, err := h.PrimitivesWithKeyManager(km)
+	if err != nil {
+		return nil, fmt.Errorf("bbs_signer_factory: cannot obtain primitive set: %w", err)
+	}
+
+	return newWrappedSigner(ps)
+}
+
+var errNotSignerPrimitive = errors.New("bbs_signer_factory: not a BBS Signer primitive")
+
+// wrappedSigner is a BBS+ Signer implementation that uses the underlying primitive set for signing.
+type wrappedSigner struct {
+	ps *primitiveset.PrimitiveSet
+}
+
+// Asserts that wrappedSigner implements the Signer interface.
+var _ bbsapi.Signer = (*wrappedSigner)(nil)
+
+func newWrappedSigner(ps *primitiveset.PrimitiveSet) (*wrappedSigner, error) {
+	if _, ok := (ps.Primary.Primitive).(bbsapi.Signer); !ok {
+		return nil, errNotSignerPrimitive
+	}
+
+	for _, primitives := range ps.Entries {
+		for _, p := range primitives {
+			if _, ok := (p.Primitive).(bbsapi.Signer); !ok {
+				return nil, errNotSignerPrimitive
+			}
+		}
+	}
+
+	ret := new(wrappedSigner)
+	ret.ps = ps
+
+	return ret, nil
+}
+
+// Sign signs the given messages with the primary primitive and returns the signature prefixed with the identifier
+// of the primary primitive.
+func (ws *wrappedSigner) Sign(messages [][]byte) ([]byte, error) {
+	primary := ws.ps.Primary
+
+	signer, ok := (primary.Primitive).(bbsapi.Signer)
+	if !ok {
+		return nil, errNotSignerPrimitive
+	}
+
+	signature, err := signer.Sign(prefixedMessages(messages, primary))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]byte, 0, len(primary.Prefix)+len(signature))
+	ret = append(ret, primary.Prefix...)
+	ret = append(ret, signature...)
+
+	return ret, nil
+}
+
+// prefixedMessages appends the legacy start byte as a message for keys with a LEGACY output prefix.
+func prefixedMessages(messages [][]byte, entry *primitiveset.Entry) [][]byte {
+	if entry.PrefixType != tinkpb.OutputPrefixType_LEGACY {
+		return messages
+	}
+
+	return append(append([][]byte{}, messages...), []byte{cryptofmt.LegacyStartByte})
+}
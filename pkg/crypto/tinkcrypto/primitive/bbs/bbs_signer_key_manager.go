@@ -0,0 +1,200 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/subtle"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	bbssubtle "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs/subtle"
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
+)
+
+const (
+	bbsSignerKeyVersion = 0
+	bbsSignerKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey"
+)
+
+// common errors.
+var (
+	errInvalidBBSSignerKey       = errors.New("bbs_signer_key_manager: invalid key")
+	errInvalidBBSSignerKeyFormat = errors.New("bbs_signer_key_manager: invalid key format")
+)
+
+// bbsSignerKeyManager is an implementation of PrivateKeyManager interface for BBS+ signatures.
+// It generates new BBSPrivateKeys and produces new instances of BLS12381G2Signer subtle.
+type bbsSignerKeyManager struct{}
+
+// Assert that bbsSignerKeyManager implements the PrivateKeyManager interface.
+var _ registry.PrivateKeyManager = (*bbsSignerKeyManager)(nil)
+
+// newBBSSignerKeyManager creates a new bbsSignerKeyManager.
+func newBBSSignerKeyManager() *bbsSignerKeyManager {
+	return new(bbsSignerKeyManager)
+}
+
+// Primitive creates a BBS+ Signer subtle for the given serialized BBSPrivateKey proto.
+func (km *bbsSignerKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidBBSSignerKey
+	}
+
+	key := new(bbspb.BBSPrivateKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid proto: %v", errInvalidBBSSignerKey, err)
+	}
+
+	err = km.validateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidBBSSignerKey, err)
+	}
+
+	return bbssubtle.NewBLS12381G2Signer(key.KeyValue), nil
+}
+
+// NewKey creates a new key according to the specification of BBSPrivateKey format.
+func (km *bbsSignerKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if len(serializedKeyFormat) == 0 {
+		return nil, errInvalidBBSSignerKeyFormat
+	}
+
+	keyFormat := new(bbspb.BBSKeyFormat)
+
+	err := proto.Unmarshal(serializedKeyFormat, keyFormat)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid proto: %v", errInvalidBBSSignerKeyFormat, err)
+	}
+
+	// BBS+ keys are created on BLS12-381 curve with the public key in the G2 group only.
+	err = validateKeyParams(keyFormat.Params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidBBSSignerKeyFormat, err)
+	}
+
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(subtle.GetHashFunc(keyFormat.Params.HashType.String()), nil)
+	if err != nil {
+		return nil, fmt.Errorf("bbs_signer_key_manager: generate key pair: %w", err)
+	}
+
+	pubKeyBytes, err := pubKey.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("bbs_signer_key_manager: marshal public key: %w", err)
+	}
+
+	privKeyBytes, err := privKey.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("bbs_signer_key_manager: marshal private key: %w", err)
+	}
+
+	return &bbspb.BBSPrivateKey{
+		Version:  bbsSignerKeyVersion,
+		KeyValue: privKeyBytes,
+		PublicKey: &bbspb.BBSPublicKey{
+			Version:  bbsSignerKeyVersion,
+			Params:   keyFormat.Params,
+			KeyValue: pubKeyBytes,
+		},
+	}, nil
+}
+
+// NewKeyData creates a new KeyData according to the specification of BBSPrivateKey Format.
+// It should be used solely by the key management API.
+func (km *bbsSignerKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, fmt.Errorf("bbs_signer_key_manager: Proto.Marshal failed: %w", err)
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         bbsSignerKeyTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, nil
+}
+
+// PublicKeyData returns the enclosed public key data of serializedPrivKey.
+func (km *bbsSignerKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(bbspb.BBSPrivateKey)
+
+	err := proto.Unmarshal(serializedPrivKey, privKey)
+	if err != nil {
+		return nil, errInvalidBBSSignerKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidBBSSignerKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         bbsVerifierKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *bbsSignerKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == bbsSignerKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *bbsSignerKeyManager) TypeURL() string {
+	return bbsSignerKeyTypeURL
+}
+
+// validateKey validates the given BBSPrivateKey.
+func (km *bbsSignerKeyManager) validateKey(key *bbspb.BBSPrivateKey) error {
+	err := keyset.ValidateKeyVersion(key.Version, bbsSignerKeyVersion)
+	if err != nil {
+		return err
+	}
+
+	if key.PublicKey == nil {
+		return errors.New("missing public key")
+	}
+
+	return validateKeyParams(key.PublicKey.Params)
+}
+
+// validateKeyParams validates the BBS+ curve, group field and hash type of the key.
+func validateKeyParams(params *bbspb.BBSParams) error {
+	if params == nil {
+		return errors.New("missing key params")
+	}
+
+	if params.Curve != bbspb.BBSCurveType_BLS12_381 {
+		return fmt.Errorf("bad curve '%s'", params.Curve)
+	}
+
+	if params.Group != bbspb.GroupField_G2 {
+		return fmt.Errorf("bad group field '%s'", params.Group)
+	}
+
+	switch params.HashType {
+	case commonpb.HashType_SHA256, commonpb.HashType_SHA384, commonpb.HashType_SHA512:
+	default:
+		return fmt.Errorf("unsupported hash type '%s'", params.HashType)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/stretchr/testify/require"
+
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
+)
+
+func TestBBSSignerKeyManager_Primitive(t *testing.T) {
+	km := newBBSSignerKeyManager()
+
+	t.Run("Test signer key manager Primitive() with empty serialized key", func(t *testing.T) {
+		p, err := km.Primitive([]byte(""))
+		require.EqualError(t, err, errInvalidBBSSignerKey.Error())
+		require.Empty(t, p)
+	})
+
+	t.Run("Test signer key manager Primitive() with bad serialize key", func(t *testing.T) {
+		p, err := km.Primitive([]byte("bad.data"))
+		require.Contains(t, err.Error(), errInvalidBBSSignerKey.Error())
+		require.Contains(t, err.Error(), "invalid proto")
+		require.Empty(t, p)
+	})
+
+	t.Run("Test signer key manager Primitive() with invalid keys", func(t *testing.T) {
+		for _, key := range []*bbspb.BBSPrivateKey{
+			{Version: 1, PublicKey: &bbspb.BBSPublicKey{Params: validParams()}},
+			{},
+			{PublicKey: &bbspb.BBSPublicKey{}},
+			{PublicKey: &bbspb.BBSPublicKey{Params: &bbspb.BBSParams{
+				HashType: commonpb.HashType_SHA256,
+				Group:    bbspb.GroupField_G2,
+			}}},
+			{PublicKey: &bbspb.BBSPublicKey{Params: &bbspb.BBSParams{
+				HashType: commonpb.HashType_SHA256,
+				Curve:    bbspb.BBSCurveType_BLS12_381,
+				Group:    bbspb.GroupField_G1,
+			}}},
+			{PublicKey: &bbspb.BBSPublicKey{Params: &bbspb.BBSParams{
+				HashType: commonpb.HashType_SHA1,
+				Curve:    bbspb.BBSCurveType_BLS12_381,
+				Group:    bbspb.GroupField_G2,
+			}}},
+		} {
+			serializedKey, err := proto.Marshal(key)
+			require.NoError(t, err)
+
+			p, err := km.Primitive(serializedKey)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), errInvalidBBSSignerKey.Error())
+			require.Empty(t, p)
+		}
+	})
+}
+
+func TestBBSSignerKeyManager_NewKey(t *testing.T) {
+	km := newBBSSignerKeyManager()
+
+	t.Run("Test signer key manager NewKey() and NewKeyData()", func(t *testing.T) {
+		serializedFormat, err := proto.Marshal(&bbspb.BBSKeyFormat{Params: validParams()})
+		require.NoError(t, err)
+
+		key, err := km.NewKey(serializedFormat)
+		require.NoError(t, err)
+
+		privKey, ok := key.(*bbspb.BBSPrivateKey)
+		require.True(t, ok)
+		require.NotEmpty(t, privKey.KeyValue)
+		require.NotEmpty(t, privKey.PublicKey.KeyValue)
+
+		keyData, err := km.NewKeyData(serializedFormat)
+		require.NoError(t, err)
+		require.Equal(t, bbsSignerKeyTypeURL, keyData.TypeUrl)
+		require.Equal(t, tinkpb.KeyData_ASYMMETRIC_PRIVATE, keyData.KeyMaterialType)
+
+		p, err := km.Primitive(keyData.Value)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+
+		pubKeyData, err := km.PublicKeyData(keyData.Value)
+		require.NoError(t, err)
+		require.Equal(t, bbsVerifierKeyTypeURL, pubKeyData.TypeUrl)
+		require.Equal(t, tinkpb.KeyData_ASYMMETRIC_PUBLIC, pubKeyData.KeyMaterialType)
+
+		_, err = km.PublicKeyData([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidBBSSignerKey.Error())
+	})
+
+	t.Run("Test signer key manager NewKey() with empty or bad key format", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.EqualError(t, err, errInvalidBBSSignerKeyFormat.Error())
+
+		_, err = km.NewKey([]byte("bad.data"))
+		require.Contains(t, err.Error(), errInvalidBBSSignerKeyFormat.Error())
+		require.Contains(t, err.Error(), "invalid proto")
+
+		_, err = km.NewKeyData(nil)
+		require.EqualError(t, err, errInvalidBBSSignerKeyFormat.Error())
+
+		serializedFormat, err := proto.Marshal(&bbspb.BBSKeyFormat{Params: &bbspb.BBSParams{
+			HashType: commonpb.HashType_SHA256,
+			Curve:    bbspb.BBSCurveType_BLS12_381,
+		}})
+		require.NoError(t, err)
+
+		_, err = km.NewKey(serializedFormat)
+		require.EqualError(t, err, errInvalidBBSSignerKeyFormat.Error()+": bad group field 'UNKNOWN_GROUP_FIELD'")
+	})
+
+	t.Run("Test signer key manager DoesSupport() and TypeURL()", func(t *testing.T) {
+		require.True(t, km.DoesSupport(bbsSignerKeyTypeURL))
+		require.False(t, km.DoesSupport(bbsVerifierKeyTypeURL))
+		require.Equal(t, bbsSignerKeyTypeURL, km.TypeURL())
+	})
+}
+
+func validParams() *bbspb.BBSParams {
+	return &bbspb.BBSParams{
+		HashType: commonpb.HashType_SHA256,
+		Curve:    bbspb.BBSCurveType_BLS12_381,
+		Group:    bbspb.GroupField_G2,
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/core/cryptofmt"
+	"github.com/google/tink/go/core/primitiveset"
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+
+	bbsapi "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs/api"
+)
+
+// NewVerifier returns a BBS+ Verifier primitive from the given keyset handle.
+func NewVerifier(h *keyset.Handle) (bbsapi.Verifier, error) {
+	return NewVerifierWithKeyManager(h, nil /*keyManager*/)
+}
+
+// NewVerifierWithKeyManager returns a BBS+ Verifier primitive from the given keyset handle and custom key manager.
+func NewVerifierWithKeyManager(h *keyset.Handle, km registry.KeyManager) (bbsapi.Verifier, error) {
+	ps, err := h.PrimitivesWithKeyManager(km)
+	if err != nil {
+		return nil, fmt.Errorf("bbs_verifier_factory: cannot obtain primitive set: %w", err)
+	}
+
+	return newWrappedVerifier(ps)
+}
+
+var errNotVerifierPrimitive = errors.New("bbs_verifier_factory: not a BBS Verifier primitive")
+
+// wrappedVerifier is a BBS+ Verifier implementation that uses the underlying primitive set for signature
+// verification.
+type wrappedVerifier struct {
+	ps *primitiveset.PrimitiveSet
+}
+
+// Asserts that wrappedVerifier implements the Verifier interface.
+var _ bbsapi.Verifier = (*wrappedVerifier)(nil)
+
+func newWrappedVerifier(ps *primitiveset.PrimitiveSet) (*wrappedVerifier, error) {
+	if _, ok := (ps.Primary.Primitive).(bbsapi.Verifier); !ok {
+		return nil, errNotVerifierPrimitive
+	}
+
+	for _, primitives := range ps.Entries {
+		for _, p := range primitives {
+			if _, ok := (p.Primitive).(bbsapi.Verifier); !ok {
+				return nil, errNotVerifierPrimitive
+			}
+		}
+	}
+
+	ret := new(wrappedVerifier)
+	ret.ps = ps
+
+	return ret, nil
+}
+
+func (wv *wrappedVerifier) entries(signature []byte) map[string][]*primitiveset.Entry {
+	var signatureEntries = make(map[string][]*primitiveset.Entry)
+
+	prefixSize := cryptofmt.NonRawPrefixSize
+	if len(signature) > prefixSize {
+		if entries, err := wv.ps.EntriesForPrefix(string(signature[:prefixSize])); err == nil {
+			signatureEntries[string(signature[prefixSize:])] = entries
+		}
+	}
+
+	if entries, err := wv.ps.RawEntries(); err == nil {
+		signatureEntries[string(signature)] = entries
+	}
+
+	return signatureEntries
+}
+
+// Verify checks whether the given signature is a valid BBS+ signature of the given messages.
+func (wv *wrappedVerifier) Verify(messages [][]byte, signature []byte) error {
+	for sig, entries := range wv.entries(signature) {
+		for _, e := range entries {
+			v, ok := (e.Primitive).(bbsapi.Verifier)
+			if !ok {
+				return errNotVerifierPrimitive
+			}
+
+			if err := v.Verify(prefixedMessages(messages, e), []byte(sig)); err == nil {
+				return nil
+			}
+		}
+	}
+
+	return errors.New("bbs_verifier_factory: invalid signature")
+}
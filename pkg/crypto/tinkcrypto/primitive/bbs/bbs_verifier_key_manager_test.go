@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
+)
+
+func TestBBSVerifierKeyManager(t *testing.T) {
+	km := newBBSVerifierKeyManager()
+
+	t.Run("Test verifier key manager Primitive() with empty or bad serialized key", func(t *testing.T) {
+		p, err := km.Primitive(nil)
+		require.EqualError(t, err, errInvalidBBSVerifierKey.Error())
+		require.Empty(t, p)
+
+		p, err = km.Primitive([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidBBSVerifierKey.Error())
+		require.Empty(t, p)
+	})
+
+	t.Run("Test verifier key manager Primitive() with invalid keys", func(t *testing.T) {
+		for _, key := range []*bbspb.BBSPublicKey{
+			{Version: 1, Params: validParams()},
+			{KeyValue: []byte("key")},
+		} {
+			serializedKey, err := proto.Marshal(key)
+			require.NoError(t, err)
+
+			p, err := km.Primitive(serializedKey)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), errInvalidBBSVerifierKey.Error())
+			require.Empty(t, p)
+		}
+	})
+
+	t.Run("Test verifier key manager Primitive() with valid key", func(t *testing.T) {
+		serializedKey, err := proto.Marshal(&bbspb.BBSPublicKey{Params: validParams(), KeyValue: []byte("key")})
+		require.NoError(t, err)
+
+		p, err := km.Primitive(serializedKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+	})
+
+	t.Run("Test verifier key manager NewKey() and NewKeyData() not implemented", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.EqualError(t, err, "bbs_verifier_key_manager: NewKey not implemented")
+
+		_, err = km.NewKeyData(nil)
+		require.EqualError(t, err, "bbs_verifier_key_manager: NewKeyData not implemented")
+	})
+
+	t.Run("Test verifier key manager DoesSupport() and TypeURL()", func(t *testing.T) {
+		require.True(t, km.DoesSupport(bbsVerifierKeyTypeURL))
+		require.False(t, km.DoesSupport(bbsSignerKeyTypeURL))
+		require.Equal(t, bbsVerifierKeyTypeURL, km.TypeURL())
+	})
+}
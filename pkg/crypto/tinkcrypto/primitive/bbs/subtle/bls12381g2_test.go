@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+)
+
+func TestBLS12381G2SignerAndVerifier(t *testing.T) {
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	messages := [][]byte{[]byte("message 1"), []byte("message 2"), []byte("message 3")}
+
+	sig, err := NewBLS12381G2Signer(privKeyBytes).Sign(messages)
+	require.NoError(t, err)
+
+	v := NewBLS12381G2Verifier(pubKeyBytes)
+
+	require.NoError(t, v.Verify(messages, sig))
+
+	err = v.Verify(messages[1:], sig)
+	require.EqualError(t, err, "invalid BBS+ signature")
+}
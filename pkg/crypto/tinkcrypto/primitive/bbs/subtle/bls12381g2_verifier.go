@@ -0,0 +1,32 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+)
+
+// BLS12381G2Verifier is the BBS+ signature verifier for keys on BLS12-381 curve with a public key in the G2 group.
+type BLS12381G2Verifier struct {
+	signerPubKeyBytes []byte
+	bbsPrimitive      *bbs12381g2pub.BBSG2Pub
+}
+
+// NewBLS12381G2Verifier creates a new instance of BLS12381G2Verifier with the provided signerPublicKey.
+func NewBLS12381G2Verifier(signerPublicKey []byte) *BLS12381G2Verifier {
+	return &BLS12381G2Verifier{
+		signerPubKeyBytes: signerPublicKey,
+		bbsPrimitive:      bbs12381g2pub.New(),
+	}
+}
+
+// Verify will verify a BBS+ signature of the messages against the signer's public key.
+// returns:
+// 		error in case of errors or nil if signature verification was successful
+func (v *BLS12381G2Verifier) Verify(messages [][]byte, signature []byte) error {
+	return v.bbsPrimitive.Verify(messages, signature, v.signerPubKeyBytes)
+}
@@ -0,0 +1,341 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: protos/tink/bbs.proto
+
+package bbs_go_proto
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common_go_proto "github.com/google/tink/go/proto/common_go_proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// BBSCurveType, BLS12-381 is the only supported BBS+ curve.
+type BBSCurveType int32
+
+const (
+	BBSCurveType_UNKNOWN_BBS_CURVE_TYPE BBSCurveType = 0
+	BBSCurveType_BLS12_381              BBSCurveType = 1
+)
+
+var BBSCurveType_name = map[int32]string{
+	0: "UNKNOWN_BBS_CURVE_TYPE",
+	1: "BLS12_381",
+}
+
+var BBSCurveType_value = map[string]int32{
+	"UNKNOWN_BBS_CURVE_TYPE": 0,
+	"BLS12_381":              1,
+}
+
+func (x BBSCurveType) String() string {
+	return proto.EnumName(BBSCurveType_name, int32(x))
+}
+
+func (BBSCurveType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_be461ea8834f3da0, []int{0}
+}
+
+// GroupField of the public key point, G2 is the only supported group.
+type GroupField int32
+
+const (
+	GroupField_UNKNOWN_GROUP_FIELD GroupField = 0
+	GroupField_G1                  GroupField = 1
+	GroupField_G2                  GroupField = 2
+)
+
+var GroupField_name = map[int32]string{
+	0: "UNKNOWN_GROUP_FIELD",
+	1: "G1",
+	2: "G2",
+}
+
+var GroupField_value = map[string]int32{
+	"UNKNOWN_GROUP_FIELD": 0,
+	"G1":                  1,
+	"G2":                  2,
+}
+
+func (x GroupField) String() string {
+	return proto.EnumName(GroupField_name, int32(x))
+}
+
+func (GroupField) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_be461ea8834f3da0, []int{1}
+}
+
+// Parameters of BBS+ keys.
+type BBSParams struct {
+	// Required.
+	HashType common_go_proto.HashType `protobuf:"varint,1,opt,name=hash_type,json=hashType,proto3,enum=google.crypto.tink.HashType" json:"hash_type,omitempty"`
+	// Required.
+	Curve BBSCurveType `protobuf:"varint,2,opt,name=curve,proto3,enum=google.crypto.tink.BBSCurveType" json:"curve,omitempty"`
+	// Required.
+	Group                GroupField `protobuf:"varint,3,opt,name=group,proto3,enum=google.crypto.tink.GroupField" json:"group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BBSParams) Reset()         { *m = BBSParams{} }
+func (m *BBSParams) String() string { return proto.CompactTextString(m) }
+func (*BBSParams) ProtoMessage()    {}
+func (*BBSParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be461ea8834f3da0, []int{0}
+}
+
+func (m *BBSParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BBSParams.Unmarshal(m, b)
+}
+func (m *BBSParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BBSParams.Marshal(b, m, deterministic)
+}
+func (m *BBSParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BBSParams.Merge(m, src)
+}
+func (m *BBSParams) XXX_Size() int {
+	return xxx_messageInfo_BBSParams.Size(m)
+}
+func (m *BBSParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_BBSParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BBSParams proto.InternalMessageInfo
+
+func (m *BBSParams) GetHashType() common_go_proto.HashType {
+	if m != nil {
+		return m.HashType
+	}
+	return common_go_proto.HashType_UNKNOWN_HASH
+}
+
+func (m *BBSParams) GetCurve() BBSCurveType {
+	if m != nil {
+		return m.Curve
+	}
+	return BBSCurveType_UNKNOWN_BBS_CURVE_TYPE
+}
+
+func (m *BBSParams) GetGroup() GroupField {
+	if m != nil {
+		return m.Group
+	}
+	return GroupField_UNKNOWN_GROUP_FIELD
+}
+
+// BBSPublicKey represents BBSVerify primitive.
+// key_type: type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPublicKey
+type BBSPublicKey struct {
+	// Required.
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Required.
+	Params *BBSParams `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	// Required.
+	KeyValue             []byte   `protobuf:"bytes,3,opt,name=key_value,json=keyValue,proto3" json:"key_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BBSPublicKey) Reset()         { *m = BBSPublicKey{} }
+func (m *BBSPublicKey) String() string { return proto.CompactTextString(m) }
+func (*BBSPublicKey) ProtoMessage()    {}
+func (*BBSPublicKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be461ea8834f3da0, []int{1}
+}
+
+func (m *BBSPublicKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BBSPublicKey.Unmarshal(m, b)
+}
+func (m *BBSPublicKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BBSPublicKey.Marshal(b, m, deterministic)
+}
+func (m *BBSPublicKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BBSPublicKey.Merge(m, src)
+}
+func (m *BBSPublicKey) XXX_Size() int {
+	return xxx_messageInfo_BBSPublicKey.Size(m)
+}
+func (m *BBSPublicKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_BBSPublicKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BBSPublicKey proto.InternalMessageInfo
+
+func (m *BBSPublicKey) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *BBSPublicKey) GetParams() *BBSParams {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *BBSPublicKey) GetKeyValue() []byte {
+	if m != nil {
+		return m.KeyValue
+	}
+	return nil
+}
+
+// BBSPrivateKey represents BBSSign primitive.
+// key_type: type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey
+type BBSPrivateKey struct {
+	// Required.
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Required.
+	PublicKey *BBSPublicKey `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Required.
+	KeyValue             []byte   `protobuf:"bytes,3,opt,name=key_value,json=keyValue,proto3" json:"key_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BBSPrivateKey) Reset()         { *m = BBSPrivateKey{} }
+func (m *BBSPrivateKey) String() string { return proto.CompactTextString(m) }
+func (*BBSPrivateKey) ProtoMessage()    {}
+func (*BBSPrivateKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be461ea8834f3da0, []int{2}
+}
+
+func (m *BBSPrivateKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BBSPrivateKey.Unmarshal(m, b)
+}
+func (m *BBSPrivateKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BBSPrivateKey.Marshal(b, m, deterministic)
+}
+func (m *BBSPrivateKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BBSPrivateKey.Merge(m, src)
+}
+func (m *BBSPrivateKey) XXX_Size() int {
+	return xxx_messageInfo_BBSPrivateKey.Size(m)
+}
+func (m *BBSPrivateKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_BBSPrivateKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BBSPrivateKey proto.InternalMessageInfo
+
+func (m *BBSPrivateKey) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *BBSPrivateKey) GetPublicKey() *BBSPublicKey {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *BBSPrivateKey) GetKeyValue() []byte {
+	if m != nil {
+		return m.KeyValue
+	}
+	return nil
+}
+
+type BBSKeyFormat struct {
+	// Required.
+	Params               *BBSParams `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BBSKeyFormat) Reset()         { *m = BBSKeyFormat{} }
+func (m *BBSKeyFormat) String() string { return proto.CompactTextString(m) }
+func (*BBSKeyFormat) ProtoMessage()    {}
+func (*BBSKeyFormat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be461ea8834f3da0, []int{3}
+}
+
+func (m *BBSKeyFormat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BBSKeyFormat.Unmarshal(m, b)
+}
+func (m *BBSKeyFormat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BBSKeyFormat.Marshal(b, m, deterministic)
+}
+func (m *BBSKeyFormat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BBSKeyFormat.Merge(m, src)
+}
+func (m *BBSKeyFormat) XXX_Size() int {
+	return xxx_messageInfo_BBSKeyFormat.Size(m)
+}
+func (m *BBSKeyFormat) XXX_DiscardUnknown() {
+	xxx_messageInfo_BBSKeyFormat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BBSKeyFormat proto.InternalMessageInfo
+
+func (m *BBSKeyFormat) GetParams() *BBSParams {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("google.crypto.tink.BBSCurveType", BBSCurveType_name, BBSCurveType_value)
+	proto.RegisterEnum("google.crypto.tink.GroupField", GroupField_name, GroupField_value)
+	proto.RegisterType((*BBSParams)(nil), "google.crypto.tink.BBSParams")
+	proto.RegisterType((*BBSPublicKey)(nil), "google.crypto.tink.BBSPublicKey")
+	proto.RegisterType((*BBSPrivateKey)(nil), "google.crypto.tink.BBSPrivateKey")
+	proto.RegisterType((*BBSKeyFormat)(nil), "google.crypto.tink.BBSKeyFormat")
+}
+
+func init() { proto.RegisterFile("proto/bbs.proto", fileDescriptor_be461ea8834f3da0) }
+
+var fileDescriptor_be461ea8834f3da0 = []byte{
+	// 470 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x6f, 0x6b, 0xd3, 0x50,
+	0x14, 0xc6, 0x97, 0xca, 0xea, 0x7a, 0x6c, 0x35, 0x5c, 0x41, 0xcb, 0x9c, 0x32, 0xfa, 0x4a, 0x06,
+	0x4b, 0x68, 0xe7, 0xc4, 0xbd, 0x12, 0x32, 0xdb, 0x3a, 0x3a, 0xba, 0x90, 0xb6, 0x13, 0x45, 0xb8,
+	0x24, 0xd9, 0x31, 0xb9, 0x24, 0xe9, 0xbd, 0xdc, 0xfc, 0x91, 0x80, 0xef, 0xf7, 0x3d, 0xfc, 0x0c,
+	0x7e, 0x40, 0xc9, 0x4d, 0x57, 0x07, 0x76, 0x83, 0xbd, 0xca, 0x39, 0xf0, 0x3c, 0x27, 0xbf, 0xe7,
+	0x9c, 0x04, 0x9e, 0x09, 0xc9, 0x33, 0x6e, 0x7a, 0x5e, 0x6a, 0xa8, 0x8a, 0x90, 0x80, 0xf3, 0x20,
+	0x46, 0xc3, 0x97, 0xa5, 0xc8, 0xb8, 0x91, 0xb1, 0x65, 0xb4, 0x4b, 0x6a, 0x91, 0xcf, 0x93, 0x84,
+	0x2f, 0x6b, 0x5d, 0xef, 0x8f, 0x06, 0x2d, 0xcb, 0x9a, 0xd9, 0xae, 0x74, 0x93, 0x94, 0x9c, 0x40,
+	0x2b, 0x74, 0xd3, 0x90, 0x66, 0xa5, 0xc0, 0xae, 0xb6, 0xaf, 0xbd, 0x7d, 0x3a, 0xd8, 0x33, 0xfe,
+	0x9f, 0x64, 0x7c, 0x76, 0xd3, 0x70, 0x5e, 0x0a, 0x74, 0x76, 0xc2, 0x55, 0x45, 0xde, 0xc3, 0xb6,
+	0x9f, 0xcb, 0x02, 0xbb, 0x0d, 0x65, 0xdb, 0xdf, 0x64, 0xb3, 0xac, 0xd9, 0x69, 0xa5, 0x51, 0xd6,
+	0x5a, 0x4e, 0xde, 0xc1, 0x76, 0x20, 0x79, 0x2e, 0xba, 0x8f, 0x94, 0xef, 0xcd, 0x26, 0xdf, 0xb8,
+	0x12, 0x8c, 0x18, 0xc6, 0x57, 0x4e, 0x2d, 0xee, 0xfd, 0x82, 0x76, 0x45, 0x9d, 0x7b, 0x31, 0xf3,
+	0x27, 0x58, 0x92, 0x2e, 0x3c, 0x2e, 0x50, 0xa6, 0x8c, 0x2f, 0x15, 0x76, 0xc7, 0xb9, 0x69, 0xc9,
+	0x31, 0x34, 0x85, 0x0a, 0xa7, 0xc0, 0x9e, 0x0c, 0x5e, 0xdf, 0x01, 0x56, 0x6f, 0xc0, 0x59, 0x89,
+	0xc9, 0x2b, 0x68, 0x45, 0x58, 0xd2, 0xc2, 0x8d, 0x73, 0x54, 0x68, 0x6d, 0x67, 0x27, 0xc2, 0xf2,
+	0xb2, 0xea, 0x7b, 0xd7, 0x1a, 0x74, 0x2a, 0x8b, 0x64, 0x85, 0x9b, 0xe1, 0xfd, 0xef, 0xff, 0x08,
+	0x20, 0x14, 0x26, 0x8d, 0xb0, 0x5c, 0x31, 0xdc, 0xb5, 0x9c, 0x75, 0x1e, 0xa7, 0x25, 0xd6, 0xd1,
+	0xee, 0x25, 0x19, 0xaa, 0x3d, 0x4c, 0xb0, 0x1c, 0x71, 0x99, 0xb8, 0xd9, 0xad, 0xb4, 0xda, 0x03,
+	0xd2, 0x1e, 0x9c, 0x40, 0xfb, 0xf6, 0x6d, 0xc8, 0x2e, 0xbc, 0x58, 0x4c, 0x27, 0xd3, 0x8b, 0x2f,
+	0x53, 0x6a, 0x59, 0x33, 0x7a, 0xba, 0x70, 0x2e, 0x87, 0x74, 0xfe, 0xd5, 0x1e, 0xea, 0x5b, 0xa4,
+	0x03, 0x2d, 0xeb, 0x7c, 0xd6, 0x1f, 0xd0, 0xa3, 0x0f, 0x7d, 0x5d, 0x3b, 0x38, 0x06, 0xf8, 0x77,
+	0x1e, 0xf2, 0x12, 0x9e, 0xdf, 0x18, 0xc7, 0xce, 0xc5, 0xc2, 0xa6, 0xa3, 0xb3, 0xe1, 0xf9, 0x27,
+	0x7d, 0x8b, 0x34, 0xa1, 0x31, 0xee, 0xeb, 0x9a, 0x7a, 0x0e, 0xf4, 0x86, 0x75, 0xad, 0xc1, 0x9e,
+	0xcf, 0x93, 0x4d, 0x78, 0xea, 0xc3, 0xb4, 0xb5, 0x6f, 0xdf, 0x03, 0x96, 0x85, 0xb9, 0x67, 0xf8,
+	0x3c, 0x31, 0xc3, 0x52, 0xa0, 0x8c, 0xf1, 0x2a, 0x40, 0x69, 0xba, 0x92, 0x61, 0x7a, 0xf8, 0x43,
+	0xba, 0x09, 0xfe, 0xe4, 0x32, 0x3a, 0x0c, 0xb8, 0x29, 0xa2, 0xc0, 0xac, 0x47, 0x98, 0xd5, 0x88,
+	0x55, 0x29, 0x24, 0x4b, 0x58, 0xc6, 0x0a, 0x34, 0xd7, 0x7f, 0x07, 0x0d, 0x38, 0x55, 0xcd, 0xef,
+	0x46, 0x73, 0x7e, 0x36, 0x9d, 0xd8, 0x96, 0xd7, 0x54, 0xfd, 0xd1, 0xdf, 0x01, 0x00, 0x66, 0x60,
+	0x76, 0x55, 0x43, 0x03, 0x00, 0x00,
+}
@@ -9,6 +9,7 @@ package service
 import (
 	"fmt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 )
@@ -19,6 +20,8 @@ type Destination struct {
 	ServiceEndpoint      string
 	RoutingKeys          []string
 	TransportReturnRoute string
+	// DIDCommVersion of the envelope of the messages, negotiated with the recipients if unset.
+	DIDCommVersion transport.DIDCommVersion
 }
 
 const (
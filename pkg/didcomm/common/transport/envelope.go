@@ -16,4 +16,16 @@ type Envelope struct {
 	ToKey   []byte
 	FromDID string
 	ToDID   string
+	// DIDCommVersion is the version of the message envelope, negotiated when packing a message if unset.
+	DIDCommVersion DIDCommVersion
 }
+
+// DIDCommVersion is the version of the DIDComm message envelope.
+type DIDCommVersion string
+
+const (
+	// DIDCommV1 is the version of the Aries RFC 0019 envelopes.
+	DIDCommV1 DIDCommVersion = "v1"
+	// DIDCommV2 is the version of the DIDComm v2 encrypted envelopes.
+	DIDCommV2 DIDCommVersion = "v2"
+)
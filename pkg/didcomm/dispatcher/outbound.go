@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
@@ -23,6 +24,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
+// didScheme is the scheme of the service endpoints which are the DID of a mediator.
+const didScheme = "did"
+
 // provider interface for outbound ctx
 type provider interface {
 	Packager() commontransport.Packager
@@ -34,11 +38,14 @@ type provider interface {
 
 // OutboundDispatcher dispatch msgs to destination.
 type OutboundDispatcher struct {
+	// sent is accessed atomically, first field to be 64-bit aligned
+	sent                 uint64
 	outboundTransports   []transport.OutboundTransport
 	packager             commontransport.Packager
 	transportReturnRoute string
 	vdRegistry           vdri.Registry
 	kms                  kms.KeyManager
+	tracer               *Tracer
 }
 
 // NewOutbound return new dispatcher outbound instance.
@@ -49,6 +56,7 @@ func NewOutbound(prov provider) *OutboundDispatcher {
 		transportReturnRoute: prov.TransportReturnRoute(),
 		vdRegistry:           prov.VDRIRegistry(),
 		kms:                  prov.KMS(),
+		tracer:               NewTracer(),
 	}
 }
 
@@ -73,80 +81,182 @@ func (o *OutboundDispatcher) SendToDID(msg interface{}, myDID, theirDID string)
 	return o.Send(msg, key, dest)
 }
 
-// Send sends the message after packing with the sender key and recipient keys.
+// Send sends the message after packing with the sender key and recipient keys. The transport is selected based
+// on the scheme of the service endpoint, a service endpoint which is a DID is the DID of the mediator the message is
+// routed through.
 func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *service.Destination) error {
-	for _, v := range o.outboundTransports {
-		// check if outbound accepts routing keys, else use recipient keys
-		keys := des.RecipientKeys
-		if len(des.RoutingKeys) != 0 {
-			keys = des.RoutingKeys
-		}
+	des, err := o.resolveMediatorRoute(des)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Send: %w", err)
+	}
 
-		if !v.AcceptRecipient(keys) {
-			if !v.Accept(des.ServiceEndpoint) {
-				continue
-			}
-		}
+	// check if outbound accepts routing keys, else use recipient keys
+	keys := des.RecipientKeys
+	if len(des.RoutingKeys) != 0 {
+		keys = des.RoutingKeys
+	}
 
-		req, err := json.Marshal(msg)
-		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Send: failed marshal to bytes: %w", err)
-		}
+	outboundTransport, err := o.selectTransport(des.ServiceEndpoint, keys)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Send: %w", err)
+	}
 
-		// update the outbound message with transport return route option [all or thread]
-		req, err = o.addTransportRouteOptions(req, des)
-		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Send: failed to add transport route options : %w", err)
-		}
+	req, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Send: failed marshal to bytes: %w", err)
+	}
 
-		packedMsg, err := o.packager.PackMessage(
-			&commontransport.Envelope{Message: req, FromKey: base58.Decode(senderVerKey), ToKeys: des.RecipientKeys})
-		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Send: failed to pack msg: %w", err)
-		}
+	// update the outbound message with transport return route option [all or thread]
+	req, err = o.addTransportRouteOptions(req, des)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Send: failed to add transport route options : %w", err)
+	}
+
+	packedMsg, err := o.packager.PackMessage(
+		&commontransport.Envelope{
+			Message:        req,
+			FromKey:        base58.Decode(senderVerKey),
+			ToKeys:         des.RecipientKeys,
+			DIDCommVersion: des.DIDCommVersion,
+		})
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Send: failed to pack msg: %w", err)
+	}
 
-		// set the return route option
-		des.TransportReturnRoute = o.transportReturnRoute
+	// set the return route option
+	des.TransportReturnRoute = o.transportReturnRoute
 
-		packedMsg, err = o.createForwardMessage(packedMsg, des)
-		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Send: failed to create forward msg : %w", err)
-		}
+	packedMsg, err = o.createForwardMessage(packedMsg, des)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Send: failed to create forward msg : %w", err)
+	}
 
-		_, err = v.Send(packedMsg, des)
-		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
-		}
+	_, err = outboundTransport.Send(packedMsg, des)
 
-		return nil
+	o.trace(req, des, err)
+
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
 	}
 
-	return fmt.Errorf("outboundDispatcher.Send: no transport found for serviceEndpoint: %s", des.ServiceEndpoint)
+	atomic.AddUint64(&o.sent, 1)
+
+	return nil
 }
 
 // Forward forwards the message without packing to the destination.
 func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination) error {
+	outboundTransport, err := o.selectTransport(des.ServiceEndpoint, des.RecipientKeys)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Forward: %w", err)
+	}
+
+	req, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Forward: failed marshal to bytes: %w", err)
+	}
+
+	_, err = outboundTransport.Send(req, des)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Forward: failed to send msg using outbound transport: %w", err)
+	}
+
+	atomic.AddUint64(&o.sent, 1)
+
+	return nil
+}
+
+// MessagesSent returns the number of messages sent, or forwarded, by the dispatcher since it was created.
+func (o *OutboundDispatcher) MessagesSent() uint64 {
+	return atomic.LoadUint64(&o.sent)
+}
+
+// Tracer returns the tracer of the messages sent by the dispatcher, the inbound messages may be traced by the same
+// tracer to trace threads end-to-end.
+func (o *OutboundDispatcher) Tracer() *Tracer {
+	return o.tracer
+}
+
+// trace records the step of the message sent, or failed to be sent, to the destination on its thread.
+func (o *OutboundDispatcher) trace(req []byte, des *service.Destination, err error) {
+	msg, errParse := service.ParseDIDCommMsgMap(req)
+	if errParse != nil {
+		return
+	}
+
+	span := Span{Direction: DirectionOutbound, Endpoint: des.ServiceEndpoint}
+	span.Transport, _ = endpointScheme(des.ServiceEndpoint)
+
+	if err != nil {
+		span.Error = err.Error()
+	}
+
+	o.tracer.Record(msg, span)
+}
+
+// selectTransport returns the transport having a connection for the given keys, else the transport accepting the
+// service endpoint, its scheme (http(s), ws(s)) being lowercased.
+func (o *OutboundDispatcher) selectTransport(serviceEndpoint string, keys []string) (transport.OutboundTransport,
+	error) {
 	for _, v := range o.outboundTransports {
-		if !v.AcceptRecipient(des.RecipientKeys) {
-			if !v.Accept(des.ServiceEndpoint) {
-				continue
-			}
+		if v.AcceptRecipient(keys) {
+			return v, nil
 		}
+	}
 
-		req, err := json.Marshal(msg)
-		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Forward: failed marshal to bytes: %w", err)
-		}
+	scheme, endpoint := endpointScheme(serviceEndpoint)
+	if scheme == didScheme {
+		return nil, fmt.Errorf("no transport for the DID service endpoint %s, the message has to be routed through"+
+			" the mediator", serviceEndpoint)
+	}
 
-		_, err = v.Send(req, des)
-		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Forward: failed to send msg using outbound transport: %w", err)
+	for _, v := range o.outboundTransports {
+		if v.Accept(endpoint) {
+			return v, nil
 		}
+	}
+
+	return nil, fmt.Errorf("no transport found for serviceEndpoint: %s", serviceEndpoint)
+}
+
+// resolveMediatorRoute returns the destination routing the message through the mediator if the service endpoint
+// of des is the DID of the mediator, des otherwise.
+func (o *OutboundDispatcher) resolveMediatorRoute(des *service.Destination) (*service.Destination, error) {
+	if scheme, _ := endpointScheme(des.ServiceEndpoint); scheme != didScheme {
+		return des, nil
+	}
+
+	mediator, err := service.GetDestination(des.ServiceEndpoint, o.vdRegistry)
+	if err != nil {
+		return nil, fmt.Errorf("resolve mediator of service endpoint %s : %w", des.ServiceEndpoint, err)
+	}
 
-		return nil
+	if scheme, _ := endpointScheme(mediator.ServiceEndpoint); scheme == didScheme {
+		return nil, fmt.Errorf("service endpoint %s of mediator %s is a DID", mediator.ServiceEndpoint,
+			des.ServiceEndpoint)
 	}
 
-	return fmt.Errorf("outboundDispatcher.Forward: no transport found for serviceEndpoint: %s", des.ServiceEndpoint)
+	route := *des
+	route.ServiceEndpoint = mediator.ServiceEndpoint
+
+	// the message is forwarded by the mediator, unless the routing keys of the mediator are already set
+	if len(route.RoutingKeys) == 0 {
+		route.RoutingKeys = mediator.RecipientKeys
+	}
+
+	return &route, nil
+}
+
+// endpointScheme returns the lowercased scheme of the service endpoint and the endpoint with its scheme lowercased.
+func endpointScheme(serviceEndpoint string) (string, string) {
+	i := strings.Index(serviceEndpoint, ":")
+	if i < 0 {
+		return "", serviceEndpoint
+	}
+
+	scheme := strings.ToLower(serviceEndpoint[:i])
+
+	return scheme, scheme + serviceEndpoint[i:]
 }
 
 func (o *OutboundDispatcher) createForwardMessage(msg []byte, des *service.Destination) ([]byte, error) {
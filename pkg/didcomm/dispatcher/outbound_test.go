@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -20,6 +21,7 @@ import (
 	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
@@ -36,6 +38,7 @@ func TestOutboundDispatcher_Send(t *testing.T) {
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
 		})
 		require.NoError(t, o.Send("data", "", &service.Destination{ServiceEndpoint: "url"}))
+		require.EqualValues(t, 1, o.MessagesSent())
 	})
 
 	t.Run("test no outbound transport found", func(t *testing.T) {
@@ -61,6 +64,29 @@ func TestOutboundDispatcher_Send(t *testing.T) {
 		err := o.Send("data", "", &service.Destination{ServiceEndpoint: "url"})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "send error")
+		require.Zero(t, o.MessagesSent())
+	})
+
+	t.Run("test send traces the message", func(t *testing.T) {
+		o := NewOutbound(&mockProvider{packagerValue: &mockpackager.Packager{},
+			outboundTransportsValue: []transport.OutboundTransport{
+				&mockdidcomm.MockOutboundTransport{AcceptValue: true, SendErr: fmt.Errorf("send error")}}})
+
+		msg := service.DIDCommMsgMap{"@id": "msg-1", "@type": "https://didcomm.org/basicmessage/1.0/message"}
+
+		err := o.Send(msg, "", &service.Destination{ServiceEndpoint: "HTTP://bob.example.com"})
+		require.Error(t, err)
+
+		spans := o.Tracer().Trace("msg-1")
+		require.Len(t, spans, 1)
+		require.Equal(t, DirectionOutbound, spans[0].Direction)
+		require.Equal(t, "http", spans[0].Transport)
+		require.Equal(t, "HTTP://bob.example.com", spans[0].Endpoint)
+		require.Contains(t, spans[0].Error, "send error")
+
+		// messages which are not DIDComm messages are not traced
+		require.Error(t, o.Send("data", "", &service.Destination{ServiceEndpoint: "url"}))
+		require.Len(t, o.Tracer().threads, 1)
 	})
 
 	t.Run("test send with forward message - success", func(t *testing.T) {
@@ -125,6 +151,88 @@ func TestOutboundDispatcher_Send(t *testing.T) {
 	})
 }
 
+func TestOutboundDispatcher_SelectTransport(t *testing.T) {
+	httpTransport := &schemeTransport{prefix: "http"}
+	wsTransport := &schemeTransport{prefix: "ws"}
+
+	mediatorDoc := &did.Doc{
+		ID: "did:example:mediator",
+		Service: []did.Service{{
+			ID:              "did:example:mediator#didcomm",
+			Type:            "did-communication",
+			ServiceEndpoint: "wss://mediator.example.com",
+			RecipientKeys:   []string{"mediatorKey"},
+		}},
+	}
+
+	o := NewOutbound(&mockProvider{
+		packagerValue:           &mockpackager.Packager{PackValue: createPackedMsgForForward(t)},
+		outboundTransportsValue: []transport.OutboundTransport{httpTransport, wsTransport},
+		vdriRegistry:            &mockvdri.MockVDRIRegistry{ResolveValue: mediatorDoc},
+	})
+
+	for endpoint, expected := range map[string]*schemeTransport{
+		"http://agent.example.com":  httpTransport,
+		"https://agent.example.com": httpTransport,
+		"HTTPS://agent.example.com": httpTransport,
+		"ws://agent.example.com":    wsTransport,
+		"wss://agent.example.com":   wsTransport,
+		"did:example:mediator":      wsTransport,
+	} {
+		httpTransport.sent, wsTransport.sent = nil, nil
+
+		require.NoError(t, o.Send("data", "", &service.Destination{
+			ServiceEndpoint: endpoint,
+			RecipientKeys:   []string{"recipientKey"},
+		}), endpoint)
+
+		require.Len(t, expected.sent, 1, endpoint)
+		require.Len(t, append(httpTransport.sent, wsTransport.sent...), 1, endpoint)
+	}
+
+	t.Run("DID service endpoint is routed through the mediator", func(t *testing.T) {
+		wsTransport.sent = nil
+
+		require.NoError(t, o.Send("data", "", &service.Destination{
+			ServiceEndpoint: "did:example:mediator",
+			RecipientKeys:   []string{"recipientKey"},
+		}))
+
+		require.Len(t, wsTransport.sent, 1)
+		require.Equal(t, "wss://mediator.example.com", wsTransport.sent[0].ServiceEndpoint)
+		require.Equal(t, []string{"mediatorKey"}, wsTransport.sent[0].RoutingKeys)
+		require.Equal(t, []string{"recipientKey"}, wsTransport.sent[0].RecipientKeys)
+	})
+
+	t.Run("unsupported schemes", func(t *testing.T) {
+		err := o.Send("data", "", &service.Destination{ServiceEndpoint: "xmpp:agent@example.com"})
+		require.EqualError(t, err, "outboundDispatcher.Send: no transport found for serviceEndpoint: "+
+			"xmpp:agent@example.com")
+
+		err = o.Forward("data", &service.Destination{ServiceEndpoint: "did:example:mediator"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "has to be routed through the mediator")
+	})
+
+	t.Run("mediator resolution errors", func(t *testing.T) {
+		failing := NewOutbound(&mockProvider{
+			packagerValue:           &mockpackager.Packager{},
+			outboundTransportsValue: []transport.OutboundTransport{wsTransport},
+			vdriRegistry:            &mockvdri.MockVDRIRegistry{ResolveErr: errors.New("resolve error")},
+		})
+
+		err := failing.Send("data", "", &service.Destination{ServiceEndpoint: "did:example:mediator"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve error")
+
+		mediatorDoc.Service[0].ServiceEndpoint = "did:example:other"
+
+		err = o.Send("data", "", &service.Destination{ServiceEndpoint: "did:example:mediator"})
+		require.EqualError(t, err, "outboundDispatcher.Send: service endpoint did:example:other of mediator "+
+			"did:example:mediator is a DID")
+	})
+}
+
 func TestOutboundDispatcher_SendToDID(t *testing.T) {
 	mockDoc := mockdiddoc.GetMockDIDDoc()
 
@@ -258,6 +366,7 @@ func TestOutboundDispatcher_Forward(t *testing.T) {
 			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
 		})
 		require.NoError(t, o.Forward("data", &service.Destination{ServiceEndpoint: "url"}))
+		require.EqualValues(t, 1, o.MessagesSent())
 	})
 
 	t.Run("test forward - no outbound transport found", func(t *testing.T) {
@@ -346,6 +455,30 @@ func (o *mockOutboundTransport) Accept(url string) bool {
 	return true
 }
 
+// schemeTransport accepts the endpoints starting with its prefix, as the HTTP and WebSocket transports.
+type schemeTransport struct {
+	prefix string
+	sent   []*service.Destination
+}
+
+func (o *schemeTransport) Start(prov transport.Provider) error {
+	return nil
+}
+
+func (o *schemeTransport) Send(data []byte, destination *service.Destination) (string, error) {
+	o.sent = append(o.sent, destination)
+
+	return "", nil
+}
+
+func (o *schemeTransport) AcceptRecipient([]string) bool {
+	return false
+}
+
+func (o *schemeTransport) Accept(url string) bool {
+	return strings.HasPrefix(url, o.prefix)
+}
+
 // mockPackager mock packager
 type mockPackager struct {
 }
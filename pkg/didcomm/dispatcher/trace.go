@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+// the directions of the traced messages.
+const (
+	DirectionInbound  = "inbound"
+	DirectionOutbound = "outbound"
+)
+
+// maxTracedThreads is the number of threads kept by the tracer, the spans of the oldest thread are dropped first.
+const maxTracedThreads = 1000
+
+// Span is a protocol step of a thread, a message received or sent on the thread.
+type Span struct {
+	ThreadID    string `json:"thread_id"`
+	MessageID   string `json:"message_id"`
+	MessageType string `json:"message_type"`
+	// Protocol is the protocol of the message, its type without the message name.
+	Protocol  string `json:"protocol"`
+	Direction string `json:"direction"`
+	// Transport is the scheme of the endpoint the message was sent to, unknown for the inbound messages.
+	Transport string `json:"transport,omitempty"`
+	Endpoint  string `json:"endpoint,omitempty"`
+	// Error is the failure to handle or send the message, if any.
+	Error string    `json:"error,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+// Tracer records the protocol steps of the message threads, in memory.
+type Tracer struct {
+	spans   map[string][]Span
+	threads []string
+	lock    sync.RWMutex
+}
+
+// NewTracer returns a new message tracer.
+func NewTracer() *Tracer {
+	return &Tracer{spans: map[string][]Span{}}
+}
+
+// Record records the span of the message on its thread, the thread, ID, type and protocol of span are those of the
+// message. The messages without thread are not traced.
+func (t *Tracer) Record(msg service.DIDCommMsgMap, span Span) {
+	if t == nil {
+		return
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return
+	}
+
+	span.ThreadID = thID
+	span.MessageID = msg.ID()
+	span.MessageType = msg.Type()
+	span.Protocol = span.MessageType
+
+	if i := strings.LastIndex(span.MessageType, "/"); i > 0 {
+		span.Protocol = span.MessageType[:i]
+	}
+
+	if span.Time.IsZero() {
+		span.Time = time.Now()
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.spans[thID]; !ok {
+		if len(t.threads) == maxTracedThreads {
+			delete(t.spans, t.threads[0])
+			t.threads = t.threads[1:]
+		}
+
+		t.threads = append(t.threads, thID)
+	}
+
+	t.spans[thID] = append(t.spans[thID], span)
+}
+
+// Trace returns the spans of the thread in the order they were recorded.
+func (t *Tracer) Trace(thID string) []Span {
+	if t == nil {
+		return nil
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	spans := make([]Span, len(t.spans[thID]))
+	copy(spans, t.spans[thID])
+
+	return spans
+}
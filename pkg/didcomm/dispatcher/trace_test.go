@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+func TestTracer(t *testing.T) {
+	const protocol = "https://didcomm.org/present-proof/2.0"
+
+	message := func(id, msgType, thID string) service.DIDCommMsgMap {
+		msg := service.DIDCommMsgMap{"@id": id, "@type": protocol + "/" + msgType}
+		if thID != "" {
+			msg["~thread"] = map[string]interface{}{"thid": thID}
+		}
+
+		return msg
+	}
+
+	t.Run("steps of a thread", func(t *testing.T) {
+		tracer := NewTracer()
+		now := time.Now()
+
+		tracer.Record(message("thread-1", "request-presentation", ""), Span{Direction: DirectionInbound, Time: now})
+		tracer.Record(message("msg-2", "request-presentation", "thread-2"), Span{Direction: DirectionInbound})
+		tracer.Record(message("msg-3", "presentation", "thread-1"), Span{
+			Direction: DirectionOutbound,
+			Transport: "https",
+			Endpoint:  "https://verifier.example.com",
+			Error:     "send error",
+			Time:      now,
+		})
+
+		require.Equal(t, []Span{
+			{
+				ThreadID:    "thread-1",
+				MessageID:   "thread-1",
+				MessageType: protocol + "/request-presentation",
+				Protocol:    protocol,
+				Direction:   DirectionInbound,
+				Time:        now,
+			},
+			{
+				ThreadID:    "thread-1",
+				MessageID:   "msg-3",
+				MessageType: protocol + "/presentation",
+				Protocol:    protocol,
+				Direction:   DirectionOutbound,
+				Transport:   "https",
+				Endpoint:    "https://verifier.example.com",
+				Error:       "send error",
+				Time:        now,
+			},
+		}, tracer.Trace("thread-1"))
+
+		spans := tracer.Trace("thread-2")
+		require.Len(t, spans, 1)
+		require.Equal(t, "msg-2", spans[0].MessageID)
+		require.False(t, spans[0].Time.IsZero())
+
+		require.Empty(t, tracer.Trace("unknown"))
+	})
+
+	t.Run("messages without thread are not traced", func(t *testing.T) {
+		tracer := NewTracer()
+
+		tracer.Record(service.DIDCommMsgMap{"@type": protocol + "/ack"}, Span{Direction: DirectionInbound})
+		require.Empty(t, tracer.threads)
+	})
+
+	t.Run("the oldest threads are dropped", func(t *testing.T) {
+		tracer := NewTracer()
+
+		for i := 0; i <= maxTracedThreads; i++ {
+			tracer.Record(message(fmt.Sprintf("thread-%d", i), "request-presentation", ""), Span{})
+		}
+
+		require.Len(t, tracer.threads, maxTracedThreads)
+		require.Empty(t, tracer.Trace("thread-0"))
+		require.Len(t, tracer.Trace("thread-1"), 1)
+		require.Len(t, tracer.Trace(fmt.Sprintf("thread-%d", maxTracedThreads)), 1)
+
+		// new steps of a traced thread don't drop any thread
+		tracer.Record(message("msg", "presentation", "thread-1"), Span{})
+		require.Len(t, tracer.threads, maxTracedThreads)
+		require.Len(t, tracer.Trace("thread-1"), 2)
+	})
+
+	t.Run("nil tracer", func(t *testing.T) {
+		var tracer *Tracer
+
+		tracer.Record(message("thread-1", "request-presentation", ""), Span{})
+		require.Nil(t, tracer.Trace("thread-1"))
+	})
+}
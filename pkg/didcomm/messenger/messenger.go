@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 
@@ -23,6 +24,7 @@ const (
 	MessengerStore = "messenger_store"
 
 	metadataKey = "metadata_%s"
+	outboundKey = "outbound_%s"
 
 	jsonID             = "@id"
 	jsonThread         = "~thread"
@@ -40,6 +42,15 @@ type record struct {
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// outboundRecord is the last message sent on a thread, kept to be resent as is.
+type outboundRecord struct {
+	Message     service.DIDCommMsgMap `json:"message"`
+	MyDID       string                `json:"my_did,omitempty"`
+	TheirDID    string                `json:"their_did,omitempty"`
+	Sender      string                `json:"sender,omitempty"`
+	Destination *service.Destination  `json:"destination,omitempty"`
+}
+
 // Provider contains dependencies for the Messenger.
 type Provider interface {
 	OutboundDispatcher() dispatcher.Outbound
@@ -48,6 +59,8 @@ type Provider interface {
 
 // Messenger describes the messenger structure.
 type Messenger struct {
+	// received is accessed atomically, first field to be 64-bit aligned
+	received   uint64
 	store      storage.Store
 	dispatcher dispatcher.Outbound
 }
@@ -85,12 +98,24 @@ func (m *Messenger) HandleInbound(msg service.DIDCommMsgMap, myDID, theirDID str
 	}
 
 	// saves message payload
-	return m.saveRecord(msg.ID(), record{
+	err = m.saveRecord(msg.ID(), record{
 		ParentThreadID: msg.ParentThreadID(),
 		MyDID:          myDID,
 		TheirDID:       theirDID,
 		ThreadID:       thID,
 	})
+	if err != nil {
+		return err
+	}
+
+	atomic.AddUint64(&m.received, 1)
+
+	return nil
+}
+
+// MessagesReceived returns the number of inbound messages handled by the messenger since it was created.
+func (m *Messenger) MessagesReceived() uint64 {
+	return atomic.LoadUint64(&m.received)
 }
 
 func (m *Messenger) saveMetadata(msg service.DIDCommMsgMap) error {
@@ -141,7 +166,7 @@ func (m *Messenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string) erro
 		jsonThreadID: msg.ID(),
 	}
 
-	return m.dispatcher.SendToDID(msg, myDID, theirDID)
+	return m.sendToDID(msg, myDID, theirDID)
 }
 
 // SendToDestination sends the message to given destination by starting a new thread.
@@ -158,6 +183,10 @@ func (m *Messenger) SendToDestination(msg service.DIDCommMsgMap, sender string,
 
 	delete(msg, jsonThread)
 
+	if err := m.saveOutbound(msg, outboundRecord{Sender: sender, Destination: destination}); err != nil {
+		return fmt.Errorf("save outbound message: %w", err)
+	}
+
 	return m.dispatcher.Send(msg, sender, destination)
 }
 
@@ -189,7 +218,7 @@ func (m *Messenger) ReplyTo(msgID string, msg service.DIDCommMsgMap) error {
 		return fmt.Errorf("save metadata: %w", err)
 	}
 
-	return m.dispatcher.SendToDID(msg, rec.MyDID, rec.TheirDID)
+	return m.sendToDID(msg, rec.MyDID, rec.TheirDID)
 }
 
 // ReplyToNested sends the message by starting a new thread.
@@ -207,9 +236,56 @@ func (m *Messenger) ReplyToNested(threadID string, msg service.DIDCommMsgMap, my
 	// sets parent threadID
 	msg[jsonThread] = map[string]interface{}{jsonParentThreadID: threadID}
 
+	return m.sendToDID(msg, myDID, theirDID)
+}
+
+// Resend sends again the last message sent on the thread, for a protocol stalled by a lost message. The message
+// is sent as is, with the same ID, for the recipient to detect the duplicate.
+func (m *Messenger) Resend(thID string) error {
+	src, err := m.store.Get(fmt.Sprintf(outboundKey, thID))
+	if err != nil {
+		return fmt.Errorf("get outbound message: %w", err)
+	}
+
+	var rec outboundRecord
+	if err = json.Unmarshal(src, &rec); err != nil {
+		return fmt.Errorf("unmarshal outbound message: %w", err)
+	}
+
+	if rec.Destination != nil {
+		return m.dispatcher.Send(rec.Message, rec.Sender, rec.Destination)
+	}
+
+	return m.dispatcher.SendToDID(rec.Message, rec.MyDID, rec.TheirDID)
+}
+
+// sendToDID saves the message as the last one of its thread and sends it.
+func (m *Messenger) sendToDID(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	if err := m.saveOutbound(msg, outboundRecord{MyDID: myDID, TheirDID: theirDID}); err != nil {
+		return fmt.Errorf("save outbound message: %w", err)
+	}
+
 	return m.dispatcher.SendToDID(msg, myDID, theirDID)
 }
 
+// saveOutbound saves the message with its recipient as the last message of its thread, before sending it for the
+// message to be resent if the sending fails.
+func (m *Messenger) saveOutbound(msg service.DIDCommMsgMap, rec outboundRecord) error {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("threadID: %w", err)
+	}
+
+	rec.Message = msg
+
+	src, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal outbound message: %w", err)
+	}
+
+	return m.store.Put(fmt.Sprintf(outboundKey, thID), src)
+}
+
 // fillIfMissing populates message with common fields such as ID.
 func fillIfMissing(msg service.DIDCommMsgMap) {
 	// if ID is empty we will create a new one
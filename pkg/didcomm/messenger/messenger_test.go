@@ -20,6 +20,7 @@ import (
 	messengerMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/messenger"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
 )
 
 const (
@@ -82,7 +83,9 @@ func TestMessenger_HandleInbound(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, msgr)
 
+		require.Zero(t, msgr.MessagesReceived())
 		require.NoError(t, msgr.HandleInbound(service.DIDCommMsgMap{jsonID: ID}, myDID, theirDID))
+		require.EqualValues(t, 1, msgr.MessagesReceived())
 	})
 
 	t.Run("success without metadata", func(t *testing.T) {
@@ -118,6 +121,7 @@ func TestMessenger_HandleInbound(t *testing.T) {
 
 		err = msgr.HandleInbound(service.DIDCommMsgMap{}, myDID, theirDID)
 		require.Contains(t, fmt.Sprintf("%v", err), "message-id is absent")
+		require.Zero(t, msgr.MessagesReceived())
 	})
 
 	t.Run("metadata with error", func(t *testing.T) {
@@ -219,8 +223,11 @@ func TestMessenger_Send(t *testing.T) {
 	defer ctrl.Finish()
 
 	t.Run("send success", func(t *testing.T) {
+		store := storageMocks.NewMockStore(ctrl)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+
 		storageProvider := storageMocks.NewMockProvider(ctrl)
-		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
 
 		outbound := dispatcherMocks.NewMockOutbound(ctrl)
 		outbound.EXPECT().SendToDID(gomock.Any(), myDID, theirDID).
@@ -238,8 +245,11 @@ func TestMessenger_Send(t *testing.T) {
 	})
 
 	t.Run("send to destination success", func(t *testing.T) {
+		store := storageMocks.NewMockStore(ctrl)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+
 		storageProvider := storageMocks.NewMockProvider(ctrl)
-		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
 
 		outbound := dispatcherMocks.NewMockOutbound(ctrl)
 		outbound.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -257,8 +267,11 @@ func TestMessenger_Send(t *testing.T) {
 	})
 
 	t.Run("success msg without id", func(t *testing.T) {
+		store := storageMocks.NewMockStore(ctrl)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+
 		storageProvider := storageMocks.NewMockProvider(ctrl)
-		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
 
 		outbound := dispatcherMocks.NewMockOutbound(ctrl)
 		outbound.EXPECT().SendToDID(gomock.Any(), myDID, theirDID).
@@ -309,6 +322,7 @@ func TestMessenger_ReplyTo(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		store := storageMocks.NewMockStore(ctrl)
 		store.EXPECT().Get(ID).Return([]byte(`{"thread_id":"thID","parent_thread_id":"pthID"}`), nil)
+		store.EXPECT().Put("outbound_thID", gomock.Any()).Return(nil)
 
 		storageProvider := storageMocks.NewMockProvider(ctrl)
 		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
@@ -349,6 +363,7 @@ func TestMessenger_ReplyTo(t *testing.T) {
 	t.Run("success msg without id", func(t *testing.T) {
 		store := storageMocks.NewMockStore(ctrl)
 		store.EXPECT().Get(ID).Return([]byte(`{"thread_id":"thID"}`), nil)
+		store.EXPECT().Put("outbound_thID", gomock.Any()).Return(nil)
 
 		storageProvider := storageMocks.NewMockProvider(ctrl)
 		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
@@ -398,8 +413,11 @@ func TestMessenger_ReplyToNested(t *testing.T) {
 	const thID = "thID"
 
 	t.Run("success", func(t *testing.T) {
+		store := storageMocks.NewMockStore(ctrl)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+
 		storageProvider := storageMocks.NewMockProvider(ctrl)
-		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
 
 		outbound := dispatcherMocks.NewMockOutbound(ctrl)
 		outbound.EXPECT().SendToDID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -416,8 +434,11 @@ func TestMessenger_ReplyToNested(t *testing.T) {
 	})
 
 	t.Run("success msg without id", func(t *testing.T) {
+		store := storageMocks.NewMockStore(ctrl)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+
 		storageProvider := storageMocks.NewMockProvider(ctrl)
-		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
 
 		outbound := dispatcherMocks.NewMockOutbound(ctrl)
 		outbound.EXPECT().SendToDID(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -455,3 +476,103 @@ func TestMessenger_ReplyToNested(t *testing.T) {
 		require.Contains(t, fmt.Sprintf("%v", err), errMsg)
 	})
 }
+
+func TestMessenger_Resend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newMessenger := func(t *testing.T, outbound *dispatcherMocks.MockOutbound) *Messenger {
+		t.Helper()
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider())
+		provider.EXPECT().OutboundDispatcher().Return(outbound)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+
+		return msgr
+	}
+
+	t.Run("resend the last message of the thread", func(t *testing.T) {
+		var sent []service.DIDCommMsgMap
+
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().SendToDID(gomock.Any(), myDID, theirDID).
+			Do(func(msg service.DIDCommMsgMap, _, _ string) { sent = append(sent, msg) }).
+			Return(errors.New(errMsg)).Times(2)
+		outbound.EXPECT().SendToDID(gomock.Any(), myDID, theirDID).
+			Do(func(msg service.DIDCommMsgMap, _, _ string) { sent = append(sent, msg) }).
+			Return(nil)
+
+		msgr := newMessenger(t, outbound)
+
+		require.Error(t, msgr.Send(service.DIDCommMsgMap{jsonID: ID, "@type": "first"}, myDID, theirDID))
+		require.Error(t, msgr.ReplyToNested("pthID", service.DIDCommMsgMap{jsonID: ID, "@type": "last"},
+			myDID, theirDID))
+
+		require.NoError(t, msgr.Resend(ID))
+		require.Len(t, sent, 3)
+		require.Equal(t, ID, sent[2].ID())
+		require.Equal(t, "last", sent[2].Type())
+		require.Equal(t, "pthID", sent[2].ParentThreadID())
+	})
+
+	t.Run("resend to destination", func(t *testing.T) {
+		destination := &service.Destination{ServiceEndpoint: "http://example.com", RecipientKeys: []string{"key"}}
+
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().Send(gomock.Any(), "sender", destination).Return(nil)
+		outbound.EXPECT().Send(gomock.Any(), "sender", destination).
+			Do(func(msg service.DIDCommMsgMap, _ string, _ *service.Destination) {
+				require.Equal(t, ID, msg.ID())
+			}).Return(nil)
+
+		msgr := newMessenger(t, outbound)
+
+		require.NoError(t, msgr.SendToDestination(service.DIDCommMsgMap{jsonID: ID}, "sender", destination))
+		require.NoError(t, msgr.Resend(ID))
+	})
+
+	t.Run("unknown thread", func(t *testing.T) {
+		err := newMessenger(t, nil).Resend("unknown")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("save outbound message error", func(t *testing.T) {
+		store := storageMocks.NewMockStore(ctrl)
+		store.EXPECT().Put("outbound_"+ID, gomock.Any()).Return(errors.New(errMsg)).Times(2)
+
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+		provider.EXPECT().OutboundDispatcher().Return(nil)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+
+		err = msgr.Send(service.DIDCommMsgMap{jsonID: ID}, myDID, theirDID)
+		require.EqualError(t, err, "save outbound message: "+errMsg)
+
+		err = msgr.SendToDestination(service.DIDCommMsgMap{jsonID: ID}, "", &service.Destination{})
+		require.EqualError(t, err, "save outbound message: "+errMsg)
+	})
+
+	t.Run("invalid outbound message", func(t *testing.T) {
+		store := storageMocks.NewMockStore(ctrl)
+		store.EXPECT().Get("outbound_"+ID).Return([]byte("{"), nil)
+
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+		provider.EXPECT().OutboundDispatcher().Return(nil)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+		require.Contains(t, fmt.Sprintf("%v", msgr.Resend(ID)), "unmarshal outbound message")
+	})
+}
@@ -8,15 +8,19 @@ package packager_test
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	. "github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
@@ -400,6 +404,225 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 	})
 }
 
+func TestPackager_DIDCommV2(t *testing.T) {
+	customKMS, err := localkms.New("local-lock://test/key-uri/",
+		newMockKMSProvider(mockstorage.NewMockStoreProvider()))
+	require.NoError(t, err)
+
+	mockedProviders := &mockProvider{
+		storage: mockstorage.NewMockStoreProvider(),
+		kms:     customKMS,
+	}
+
+	v2AuthPacker, err := authcrypt.NewV2(mockedProviders, jose.A256GCM)
+	require.NoError(t, err)
+
+	v2AnonPacker, err := anoncrypt.NewV2(mockedProviders, jose.A256GCM)
+	require.NoError(t, err)
+
+	v1Packer := legacy.New(mockedProviders)
+	mockedProviders.primaryPacker = v1Packer
+	mockedProviders.packers = []packer.Packer{v1Packer, v2AuthPacker, v2AnonPacker}
+
+	packager, err := New(mockedProviders)
+	require.NoError(t, err)
+
+	aliceKID, _ := createJWEKey(t, customKMS, kms.ECDH1PU256AES256GCMType)
+	bobKID, bobKey := createJWEKey(t, customKMS, kms.ECDH1PU256AES256GCMType)
+
+	t.Run("v1 is the default version", func(t *testing.T) {
+		_, fromKey, err := customKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, toKey, err := customKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		packMsg, err := packager.PackMessage(&transport.Envelope{Message: []byte("msg1"),
+			FromKey: fromKey,
+			ToKeys:  []string{base58.Encode(toKey)}})
+		require.NoError(t, err)
+		require.Equal(t, "JWM/1.0", envelopeType(t, packMsg))
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("msg1"), unpackedMsg.Message)
+		require.Equal(t, transport.DIDCommV1, unpackedMsg.DIDCommVersion)
+	})
+
+	t.Run("v2 round trip and negotiation", func(t *testing.T) {
+		packMsg, err := packager.PackMessage(&transport.Envelope{Message: []byte("msg2"),
+			FromKey:        []byte(aliceKID),
+			ToKeys:         []string{base58.Encode(bobKey)},
+			DIDCommVersion: transport.DIDCommV2})
+		require.NoError(t, err)
+		require.Equal(t, packer.DIDCommV2EncodingType, envelopeType(t, packMsg))
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("msg2"), unpackedMsg.Message)
+		require.Equal(t, transport.DIDCommV2, unpackedMsg.DIDCommVersion)
+
+		senderKey := &composite.PublicKey{}
+		require.NoError(t, json.Unmarshal(unpackedMsg.FromKey, senderKey))
+		require.Equal(t, aliceKID, senderKey.KID)
+
+		// the reply to alice is negotiated as v2 since alice sent a v2 message
+		packMsg, err = packager.PackMessage(&transport.Envelope{Message: []byte("reply"),
+			FromKey: []byte(bobKID),
+			ToKeys:  []string{base58.Encode(unpackedMsg.FromKey)}})
+		require.NoError(t, err)
+		require.Equal(t, packer.DIDCommV2EncodingType, envelopeType(t, packMsg))
+
+		unpackedMsg, err = packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("reply"), unpackedMsg.Message)
+		require.Equal(t, transport.DIDCommV2, unpackedMsg.DIDCommVersion)
+	})
+
+	t.Run("v2 anoncrypt for anonymous senders", func(t *testing.T) {
+		_, anonKey := createJWEKey(t, customKMS, kms.ECDHES256AES256GCMType)
+
+		packMsg, err := packager.PackMessage(&transport.Envelope{Message: []byte("msg3"),
+			ToKeys:         []string{base58.Encode(anonKey)},
+			DIDCommVersion: transport.DIDCommV2})
+		require.NoError(t, err)
+		require.Equal(t, packer.DIDCommV2EncodingType, envelopeType(t, packMsg))
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("msg3"), unpackedMsg.Message)
+		require.Equal(t, transport.DIDCommV2, unpackedMsg.DIDCommVersion)
+		require.Empty(t, unpackedMsg.FromKey)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := packager.PackMessage(&transport.Envelope{Message: []byte("msg"),
+			FromKey:        []byte(aliceKID),
+			ToKeys:         []string{base58.Encode(bobKey)},
+			DIDCommVersion: "v3"})
+		require.EqualError(t, err, "packMessage: unsupported DIDComm version 'v3'")
+
+		v1Only, err := New(&mockProvider{
+			storage:       mockstorage.NewMockStoreProvider(),
+			kms:           customKMS,
+			primaryPacker: v1Packer,
+		})
+		require.NoError(t, err)
+
+		_, err = v1Only.PackMessage(&transport.Envelope{Message: []byte("msg"),
+			FromKey:        []byte(aliceKID),
+			ToKeys:         []string{base58.Encode(bobKey)},
+			DIDCommVersion: transport.DIDCommV2})
+		require.EqualError(t, err, "packMessage: no packer for DIDComm v2 envelopes")
+	})
+}
+
+func TestPackager_DIDCommV2VersionsCap(t *testing.T) {
+	customKMS, err := localkms.New("local-lock://test/key-uri/",
+		newMockKMSProvider(mockstorage.NewMockStoreProvider()))
+	require.NoError(t, err)
+
+	mockedProviders := &mockProvider{
+		storage: mockstorage.NewMockStoreProvider(),
+		kms:     customKMS,
+	}
+
+	v2AuthPacker, err := authcrypt.NewV2(mockedProviders, jose.A256GCM)
+	require.NoError(t, err)
+
+	v1Packer := legacy.New(mockedProviders)
+	mockedProviders.primaryPacker = v1Packer
+	mockedProviders.packers = []packer.Packer{v1Packer, v2AuthPacker}
+
+	packager, err := New(mockedProviders)
+	require.NoError(t, err)
+
+	aliceKID, _ := createJWEKey(t, customKMS, kms.ECDH1PU256AES256GCMType)
+	bobKID, bobKey := createJWEKey(t, customKMS, kms.ECDH1PU256AES256GCMType)
+
+	packMsg, err := packager.PackMessage(&transport.Envelope{Message: []byte("msg"),
+		FromKey:        []byte(aliceKID),
+		ToKeys:         []string{base58.Encode(bobKey)},
+		DIDCommVersion: transport.DIDCommV2})
+	require.NoError(t, err)
+
+	aliceMsg, err := packager.UnpackMessage(packMsg)
+	require.NoError(t, err)
+
+	reply := &transport.Envelope{Message: []byte("reply"),
+		FromKey: []byte(bobKID),
+		ToKeys:  []string{base58.Encode(aliceMsg.FromKey)}}
+
+	packMsg, err = packager.PackMessage(reply)
+	require.NoError(t, err)
+	require.Equal(t, packer.DIDCommV2EncodingType, envelopeType(t, packMsg))
+
+	_, toKey, err := customKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	// the version of alice is dropped once the versions of 1000 other senders are saved
+	for i := 0; i < 1000; i++ {
+		_, fromKey, err := customKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		packMsg, err = packager.PackMessage(&transport.Envelope{Message: []byte("msg"),
+			FromKey: fromKey,
+			ToKeys:  []string{base58.Encode(toKey)}})
+		require.NoError(t, err)
+
+		_, err = packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+	}
+
+	// the reply is packed by the v1 legacy packer, which doesn't support the JWE keys
+	_, err = packager.PackMessage(reply)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to convert public Ed25519 to Curve25519")
+}
+
+// createJWEKey creates a new ECDH key of the key type and returns its KMS key ID and its marshalled public key.
+func createJWEKey(t *testing.T, k *localkms.LocalKMS, kt kms.KeyType) (string, []byte) {
+	t.Helper()
+
+	kid, pubKeyBytes, err := k.CreateAndExportPubKeyBytes(kt)
+	require.NoError(t, err)
+
+	key := &composite.PublicKey{}
+	require.NoError(t, json.Unmarshal(pubKeyBytes, key))
+
+	key.KID = kid
+
+	keyBytes, err := json.Marshal(key)
+	require.NoError(t, err)
+
+	return kid, keyBytes
+}
+
+// envelopeType returns the `typ` protected header of the envelope.
+func envelopeType(t *testing.T, envelope []byte) string {
+	t.Helper()
+
+	env := struct {
+		Protected string `json:"protected"`
+	}{}
+
+	if strings.HasPrefix(string(envelope), "{") { // full serialized
+		require.NoError(t, json.Unmarshal(envelope, &env))
+	} else { // compact serialized
+		env.Protected = strings.Split(string(envelope), ".")[0]
+	}
+
+	protected, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(env.Protected, "="))
+	require.NoError(t, err)
+
+	header := struct {
+		Typ string `json:"typ"`
+	}{}
+	require.NoError(t, json.Unmarshal(protected, &header))
+
+	return header.Typ
+}
+
 func newMockKMSProvider(storagePvdr *mockstorage.MockStoreProvider) *mockProvider {
 	return &mockProvider{storagePvdr, nil, &noop.NoLock{}, nil, nil, nil}
 }
@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/btcsuite/btcutil/base58"
 
@@ -23,7 +24,13 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
 )
 
-const authSuffix = "-authcrypt"
+const (
+	authSuffix = "-authcrypt"
+
+	// maxVersions is the number of sender keys whose DIDComm version is kept, past it the version of the earliest
+	// saved sender is dropped: the messages to it are v1 until it sends a v2 message again.
+	maxVersions = 1000
+)
 
 // Provider contains dependencies for the base packager and is typically created by using aries.Context()
 type Provider interface {
@@ -41,6 +48,11 @@ type Packager struct {
 	primaryPacker   packer.Packer
 	packers         map[string]packer.Packer
 	connectionStore *did.ConnectionStore
+	// versions holds the DIDComm version of the last message unpacked from a sender key (base58), used to reply
+	// with the same version. versionKeys holds the sender keys of versions in the order they were saved.
+	versions     map[string]transport.DIDCommVersion
+	versionKeys  []string
+	versionsLock sync.RWMutex
 }
 
 // PackerCreator holds a creator function for a Packer and the name of the Packer's encoding method.
@@ -60,6 +72,7 @@ func New(ctx Provider) (*Packager, error) {
 		primaryPacker:   nil,
 		packers:         map[string]packer.Packer{},
 		connectionStore: didConnStore,
+		versions:        map[string]transport.DIDCommVersion{},
 	}
 
 	for _, packerType := range ctx.Packers() {
@@ -113,7 +126,12 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 
 	// TODO find a way to dynamically select a packer based on FromKey, recipients and their types.
 	//      https://github.com/hyperledger/aries-framework-go/issues/1112 Configurable packing
-	bytes, err := bp.primaryPacker.Pack(messageEnvelope.Message, messageEnvelope.FromKey, recipients)
+	p, err := bp.selectPacker(messageEnvelope)
+	if err != nil {
+		return nil, fmt.Errorf("packMessage: %w", err)
+	}
+
+	bytes, err := p.Pack(messageEnvelope.Message, messageEnvelope.FromKey, recipients)
 	if err != nil {
 		return nil, fmt.Errorf("packMessage: failed to pack: %w", err)
 	}
@@ -121,6 +139,77 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 	return bytes, nil
 }
 
+// selectPacker selects the packer of the DIDComm version of the envelope, or of the version negotiated with the
+// recipients if unset: DIDComm v2 if the last messages of all the recipients were DIDComm v2, v1 otherwise.
+func (bp *Packager) selectPacker(envelope *transport.Envelope) (packer.Packer, error) {
+	version := envelope.DIDCommVersion
+	if version == "" {
+		version = bp.negotiatedVersion(envelope.ToKeys)
+	}
+
+	switch version {
+	case transport.DIDCommV1:
+		return bp.primaryPacker, nil
+	case transport.DIDCommV2:
+		// the sender is authenticated with authcrypt, anoncrypt is used for anonymous senders
+		packerID := packer.DIDCommV2EncodingType
+		if len(envelope.FromKey) > 0 {
+			packerID += authSuffix
+		}
+
+		p, ok := bp.packers[packerID]
+		if !ok {
+			return nil, errors.New("no packer for DIDComm v2 envelopes")
+		}
+
+		return p, nil
+	default:
+		return nil, fmt.Errorf("unsupported DIDComm version '%s'", version)
+	}
+}
+
+func (bp *Packager) negotiatedVersion(keys []string) transport.DIDCommVersion {
+	bp.versionsLock.RLock()
+	defer bp.versionsLock.RUnlock()
+
+	if len(keys) == 0 {
+		return transport.DIDCommV1
+	}
+
+	for _, key := range keys {
+		if bp.versions[key] != transport.DIDCommV2 {
+			return transport.DIDCommV1
+		}
+	}
+
+	return transport.DIDCommV2
+}
+
+func (bp *Packager) saveVersion(key []byte, version transport.DIDCommVersion) {
+	if len(key) == 0 {
+		return
+	}
+
+	bp.versionsLock.Lock()
+	defer bp.versionsLock.Unlock()
+
+	senderKey := base58.Encode(key)
+
+	if _, ok := bp.versions[senderKey]; ok {
+		bp.versions[senderKey] = version
+
+		return
+	}
+
+	if len(bp.versionKeys) == maxVersions {
+		delete(bp.versions, bp.versionKeys[0])
+		bp.versionKeys = bp.versionKeys[1:]
+	}
+
+	bp.versions[senderKey] = version
+	bp.versionKeys = append(bp.versionKeys, senderKey)
+}
+
 type envelopeStub struct {
 	Protected string `json:"protected,omitempty"`
 }
@@ -208,5 +297,12 @@ func (bp *Packager) UnpackMessage(encMessage []byte) (*transport.Envelope, error
 	envelope.ToDID = myDID
 	envelope.FromDID = theirDID
 
+	envelope.DIDCommVersion = transport.DIDCommV1
+	if strings.TrimSuffix(encType, authSuffix) == packer.DIDCommV2EncodingType {
+		envelope.DIDCommVersion = transport.DIDCommV2
+	}
+
+	bp.saveVersion(envelope.FromKey, envelope.DIDCommVersion)
+
 	return envelope, nil
 }
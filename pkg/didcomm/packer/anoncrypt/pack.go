@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/keyio"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/internal/didcommv2"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
@@ -34,8 +35,9 @@ var logger = log.New("aries-framework/pkg/didcomm/packer/anoncrypt")
 
 // Packer represents an Anoncrypt Pack/Unpacker that outputs/reads Aries envelopes.
 type Packer struct {
-	kms    kms.KeyManager
-	encAlg jose.EncAlg
+	kms       kms.KeyManager
+	encAlg    jose.EncAlg
+	didCommV2 bool
 }
 
 // New will create an Packer instance to 'AnonCrypt' payloads for a given list of recipients.
@@ -52,6 +54,19 @@ func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
 	}, nil
 }
 
+// NewV2 will create a Packer like New which outputs/reads DIDComm v2 envelopes: the envelope `typ` is the DIDComm v2
+// encrypted media type and the recipient keys are identified by their did:key DID URLs.
+func NewV2(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
+	p, err := New(ctx, encAlg)
+	if err != nil {
+		return nil, err
+	}
+
+	p.didCommV2 = true
+
+	return p, nil
+}
+
 // Pack will encode the payload argument
 // Using the protocol defined by the Anoncrypt message of Aries RFC 0334
 // Anoncrypt ignores the sender argument, it's added to meet the Packer interface.
@@ -65,7 +80,14 @@ func (p *Packer) Pack(payload, _ []byte, recipientsPubKeys [][]byte) ([]byte, er
 		return nil, fmt.Errorf("anoncrypt Pack: failed to convert recipient keys: %w", err)
 	}
 
-	jweEncrypter, err := jose.NewJWEEncrypt(p.encAlg, encodingType, "", nil, recECKeys)
+	if p.didCommV2 {
+		recECKeys, err = didcommv2.RecipientKeys(recECKeys)
+		if err != nil {
+			return nil, fmt.Errorf("anoncrypt Pack: %w", err)
+		}
+	}
+
+	jweEncrypter, err := jose.NewJWEEncrypt(p.encAlg, p.EncodingType(), "", nil, recECKeys)
 	if err != nil {
 		return nil, fmt.Errorf("anoncrypt Pack: failed to new JWEEncrypt instance: %w", err)
 	}
@@ -115,7 +137,7 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 	}
 
 	for i := range jwe.Recipients {
-		kid, err := getKID(i, jwe)
+		kid, err := p.getKID(i, jwe)
 		if err != nil {
 			return nil, fmt.Errorf("anoncrypt Unpack: %w", err)
 		}
@@ -164,7 +186,9 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 	return nil, fmt.Errorf("anoncrypt Unpack: no matching recipient in envelope")
 }
 
-func getKID(i int, jwe *jose.JSONWebEncryption) (string, error) {
+// getKID returns the KMS key ID of the recipient i of the envelope, the KMS key ID of the did:key DID URL of the
+// recipient for DIDComm v2 envelopes.
+func (p *Packer) getKID(i int, jwe *jose.JSONWebEncryption) (string, error) {
 	var kid string
 
 	if i == 0 && len(jwe.Recipients) == 1 { // compact serialization, recipient headers are in jwe.ProtectedHeaders
@@ -178,6 +202,15 @@ func getKID(i int, jwe *jose.JSONWebEncryption) (string, error) {
 		kid = jwe.Recipients[i].Header.KID
 	}
 
+	if p.didCommV2 {
+		key, err := didcommv2.PublicKey(kid)
+		if err != nil {
+			return "", err
+		}
+
+		kid = key.KID
+	}
+
 	return kid, nil
 }
 
@@ -200,5 +233,9 @@ func exportPubKeyBytes(keyHandle *keyset.Handle) ([]byte, error) {
 
 // EncodingType for didcomm.
 func (p *Packer) EncodingType() string {
+	if p.didCommV2 {
+		return packer.DIDCommV2EncodingType
+	}
+
 	return encodingType
 }
@@ -9,6 +9,7 @@ package anoncrypt
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/tink/go/keyset"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
@@ -56,6 +58,50 @@ func TestAnoncryptPackerSuccess(t *testing.T) {
 	require.Equal(t, encodingType, anonPacker.EncodingType())
 }
 
+func TestAnoncryptPackerDIDCommV2(t *testing.T) {
+	k := createKMS(t)
+	recipientKIDs, recipientsKeys, keyHandles := createRecipients(t, k, 2)
+
+	anonPacker, err := NewV2(newMockProviderWithCustomKMS(k), jose.A256GCM)
+	require.NoError(t, err)
+	require.Equal(t, packer.DIDCommV2EncodingType, anonPacker.EncodingType())
+
+	origMsg := []byte("secret message")
+	ct, err := anonPacker.Pack(origMsg, nil, recipientsKeys)
+	require.NoError(t, err)
+
+	jwe, err := jose.Deserialize(string(ct))
+	require.NoError(t, err)
+
+	typ, ok := jwe.ProtectedHeaders.Type()
+	require.True(t, ok)
+	require.Equal(t, packer.DIDCommV2EncodingType, typ)
+
+	for i, recipient := range jwe.Recipients {
+		require.True(t, strings.HasPrefix(recipient.Header.KID, "did:key:z"))
+		require.NotEqual(t, recipientKIDs[i], recipient.Header.KID)
+	}
+
+	msg, err := anonPacker.Unpack(ct)
+	require.NoError(t, err)
+
+	recKey, err := exportPubKeyBytes(keyHandles[0])
+	require.NoError(t, err)
+
+	require.EqualValues(t, &transport.Envelope{Message: origMsg, ToKey: recKey}, msg)
+
+	t.Run("unpack v2 envelope with a non did:key recipient kid", func(t *testing.T) {
+		v1Packer, err := New(newMockProviderWithCustomKMS(k), jose.A256GCM)
+		require.NoError(t, err)
+
+		ct, err = v1Packer.Pack(origMsg, nil, [][]byte{recipientsKeys[0]})
+		require.NoError(t, err)
+
+		_, err = anonPacker.Unpack(ct)
+		require.EqualError(t, err, fmt.Sprintf("anoncrypt Unpack: kid %s is not a did:key", recipientKIDs[0]))
+	})
+}
+
 func TestAnoncryptPackerFail(t *testing.T) {
 	t.Run("new Pack fail with nil kms", func(t *testing.T) {
 		_, err := New(newMockProviderWithCustomKMS(nil), jose.A256GCM)
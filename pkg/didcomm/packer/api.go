@@ -12,6 +12,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
+// DIDCommV2EncodingType is the `typ` header of the DIDComm v2 encrypted envelopes.
+const DIDCommV2EncodingType = "application/didcomm-encrypted+json"
+
 // Provider interface for Packer ctx.
 type Provider interface {
 	KMS() kms.KeyManager
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/keyio"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/internal/didcommv2"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
@@ -40,9 +41,10 @@ var logger = log.New("aries-framework/pkg/didcomm/packer/authcrypt")
 
 // Packer represents an Authcrypt Pack/Unpacker that outputs/reads Aries envelopes.
 type Packer struct {
-	kms    kms.KeyManager
-	encAlg jose.EncAlg
-	store  storage.Store
+	kms       kms.KeyManager
+	encAlg    jose.EncAlg
+	store     storage.Store
+	didCommV2 bool
 }
 
 // New will create an Packer instance to 'AuthCrypt' payloads for a given sender and list of recipients keys.
@@ -73,6 +75,21 @@ func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
 	}, nil
 }
 
+// NewV2 will create a Packer like New which outputs/reads DIDComm v2 envelopes: the envelope `typ` is the DIDComm v2
+// encrypted media type and the sender and recipient keys are identified by their did:key DID URLs. The sender key of
+// a did:key sender is decoded from its key ID, it doesn't need to be in the third party keys store.
+func NewV2(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
+	p, err := New(ctx, encAlg)
+	if err != nil {
+		return nil, err
+	}
+
+	p.store = &didcommv2.SenderKeyStore{Store: p.store}
+	p.didCommV2 = true
+
+	return p, nil
+}
+
 // Pack will encode the payload argument
 // Using the protocol defined by the Authcrypt message of Aries RFC 0334
 // with the following arguments:
@@ -94,7 +111,16 @@ func (p *Packer) Pack(payload, senderID []byte, recipientsPubKeys [][]byte) ([]b
 		return nil, fmt.Errorf("authcrypt Pack: failed to get sender key from KMS: %w", err)
 	}
 
-	jweEncrypter, err := jose.NewJWEEncrypt(p.encAlg, encodingType, string(senderID), kh.(*keyset.Handle), recECKeys)
+	skid := string(senderID)
+
+	if p.didCommV2 {
+		skid, recECKeys, err = v2KeyIDs(kh.(*keyset.Handle), recECKeys)
+		if err != nil {
+			return nil, fmt.Errorf("authcrypt Pack: %w", err)
+		}
+	}
+
+	jweEncrypter, err := jose.NewJWEEncrypt(p.encAlg, p.EncodingType(), skid, kh.(*keyset.Handle), recECKeys)
 	if err != nil {
 		return nil, fmt.Errorf("authcrypt Pack: failed to new JWEEncrypt instance: %w", err)
 	}
@@ -119,6 +145,33 @@ func (p *Packer) Pack(payload, senderID []byte, recipientsPubKeys [][]byte) ([]b
 	return []byte(s), nil
 }
 
+// v2KeyIDs returns the did:key DID URLs of the sender key and the recipient keys identified by their did:key DID URLs.
+func v2KeyIDs(senderKH *keyset.Handle, recKeys []*composite.PublicKey) (string, []*composite.PublicKey, error) {
+	senderKeyBytes, err := exportPubKeyBytes(senderKH)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to export sender key: %w", err)
+	}
+
+	senderKey := &composite.PublicKey{}
+
+	err = json.Unmarshal(senderKeyBytes, senderKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to unmarshal sender key: %w", err)
+	}
+
+	skid, err := didcommv2.KeyID(senderKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	v2Keys, err := didcommv2.RecipientKeys(recKeys)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return skid, v2Keys, nil
+}
+
 func unmarshalRecipientKeys(keys [][]byte) ([]*composite.PublicKey, error) {
 	var pubKeys []*composite.PublicKey
 
@@ -148,9 +201,10 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 			kid                   string
 			kh                    interface{}
 			pt, ecdh1puPubKeyByes []byte
+			senderKey             []byte
 		)
 
-		kid, err = getKID(i, jwe)
+		kid, err = p.getKID(i, jwe)
 		if err != nil {
 			return nil, fmt.Errorf("authcrypt Unpack: %w", err)
 		}
@@ -190,8 +244,15 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 			return nil, fmt.Errorf("authcrypt Unpack: failed to export public key bytes: %w", err)
 		}
 
+		// the envelope decrypted with the sender key of the third party keys store, the sender is authenticated
+		senderKey, err = p.senderKey(jwe)
+		if err != nil {
+			return nil, fmt.Errorf("authcrypt Unpack: %w", err)
+		}
+
 		return &transport.Envelope{
 			Message: pt,
+			FromKey: senderKey,
 			ToKey:   ecdh1puPubKeyByes,
 		}, nil
 	}
@@ -199,7 +260,24 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 	return nil, fmt.Errorf("authcrypt Unpack: no matching recipient in envelope")
 }
 
-func getKID(i int, jwe *jose.JSONWebEncryption) (string, error) {
+// senderKey returns the marshalled public key of the sender identified by the 'skid' protected header.
+func (p *Packer) senderKey(jwe *jose.JSONWebEncryption) ([]byte, error) {
+	skid, ok := jwe.ProtectedHeaders.SenderKeyID()
+	if !ok {
+		return nil, errors.New("missing 'skid' in jwe.ProtectedHeaders")
+	}
+
+	senderKey, err := p.store.Get(skid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender key: %w", err)
+	}
+
+	return senderKey, nil
+}
+
+// getKID returns the KMS key ID of the recipient i of the envelope, the KMS key ID of the did:key DID URL of the
+// recipient for DIDComm v2 envelopes.
+func (p *Packer) getKID(i int, jwe *jose.JSONWebEncryption) (string, error) {
 	var kid string
 
 	if i == 0 && len(jwe.Recipients) == 1 { // compact serialization, recipient headers are in jwe.ProtectedHeaders
@@ -213,6 +291,15 @@ func getKID(i int, jwe *jose.JSONWebEncryption) (string, error) {
 		kid = jwe.Recipients[i].Header.KID
 	}
 
+	if p.didCommV2 {
+		key, err := didcommv2.PublicKey(kid)
+		if err != nil {
+			return "", err
+		}
+
+		kid = key.KID
+	}
+
 	return kid, nil
 }
 
@@ -235,5 +322,9 @@ func exportPubKeyBytes(keyHandle *keyset.Handle) ([]byte, error) {
 
 // EncodingType for didcomm.
 func (p *Packer) EncodingType() string {
+	if p.didCommV2 {
+		return packer.DIDCommV2EncodingType
+	}
+
 	return encodingType
 }
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/btcsuite/btcutil/base58"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
@@ -56,7 +58,7 @@ func TestAuthryptPackerSuccess(t *testing.T) {
 	recKey, err := exportPubKeyBytes(keyHandles[0])
 	require.NoError(t, err)
 
-	require.EqualValues(t, &transport.Envelope{Message: origMsg, ToKey: recKey}, msg)
+	require.EqualValues(t, &transport.Envelope{Message: origMsg, FromKey: senderKey, ToKey: recKey}, msg)
 
 	// try with only 1 recipient
 	ct, err = authPacker.Pack(origMsg, []byte(skid), [][]byte{recipientsKeys[0]})
@@ -65,11 +67,70 @@ func TestAuthryptPackerSuccess(t *testing.T) {
 	msg, err = authPacker.Unpack(ct)
 	require.NoError(t, err)
 
-	require.EqualValues(t, &transport.Envelope{Message: origMsg, ToKey: recKey}, msg)
+	require.EqualValues(t, &transport.Envelope{Message: origMsg, FromKey: senderKey, ToKey: recKey}, msg)
 
 	require.Equal(t, encodingType, authPacker.EncodingType())
 }
 
+func TestAuthcryptPackerDIDCommV2(t *testing.T) {
+	k := createKMS(t)
+	recipientKIDs, recipientsKeys, keyHandles := createRecipients(t, k, 2)
+
+	skid, _, _ := createAndMarshalKey(t, k)
+
+	// the sender key isn't in the store, it's decoded from the did:key skid
+	authPacker, err := NewV2(newMockProvider(mockstorage.NewMockStoreProvider(), k), jose.A256GCM)
+	require.NoError(t, err)
+	require.Equal(t, packer.DIDCommV2EncodingType, authPacker.EncodingType())
+
+	origMsg := []byte("secret message")
+	ct, err := authPacker.Pack(origMsg, []byte(skid), recipientsKeys)
+	require.NoError(t, err)
+
+	jwe, err := jose.Deserialize(string(ct))
+	require.NoError(t, err)
+
+	typ, ok := jwe.ProtectedHeaders.Type()
+	require.True(t, ok)
+	require.Equal(t, packer.DIDCommV2EncodingType, typ)
+
+	senderKID, ok := jwe.ProtectedHeaders.SenderKeyID()
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(senderKID, "did:key:z"))
+
+	for i, recipient := range jwe.Recipients {
+		require.True(t, strings.HasPrefix(recipient.Header.KID, "did:key:z"))
+		require.NotEqual(t, recipientKIDs[i], recipient.Header.KID)
+	}
+
+	msg, err := authPacker.Unpack(ct)
+	require.NoError(t, err)
+	require.Equal(t, origMsg, msg.Message)
+
+	recKey, err := exportPubKeyBytes(keyHandles[0])
+	require.NoError(t, err)
+	require.Equal(t, recKey, msg.ToKey)
+
+	// the sender key is identified by its KMS key ID
+	fromKey := &composite.PublicKey{}
+	require.NoError(t, json.Unmarshal(msg.FromKey, fromKey))
+	require.Equal(t, skid, fromKey.KID)
+
+	t.Run("unpack v2 envelope with a non did:key recipient kid", func(t *testing.T) {
+		v1Packer, err := New(newMockProvider(mockstorage.NewMockStoreProvider(), k), jose.A256GCM)
+		require.NoError(t, err)
+
+		_, err = v1Packer.Unpack(ct)
+		require.Error(t, err)
+
+		ct, err = v1Packer.Pack(origMsg, []byte(skid), [][]byte{recipientsKeys[0]})
+		require.NoError(t, err)
+
+		_, err = authPacker.Unpack(ct)
+		require.EqualError(t, err, fmt.Sprintf("authcrypt Unpack: kid %s is not a did:key", recipientKIDs[0]))
+	})
+}
+
 func TestAuthcryptPackerFail(t *testing.T) {
 	k := createKMS(t)
 
@@ -0,0 +1,173 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package didcommv2 holds the key identification of the DIDComm v2 envelopes shared by the JWE packers: the sender
+// and recipient keys are identified by their did:key DID URLs instead of their KMS key IDs.
+package didcommv2
+
+import (
+	"crypto/elliptic"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	hybrid "github.com/google/tink/go/hybrid/subtle"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/fingerprint"
+)
+
+const (
+	didKeyPrefix = "did:key:"
+
+	// source: https://github.com/multiformats/multicodec/blob/master/table.csv.
+	p256Pub = 0x1200 // NIST P-256 public key (compressed) in multicodec table
+	p384Pub = 0x1201 // NIST P-384 public key (compressed) in multicodec table
+	p521Pub = 0x1202 // NIST P-521 public key (compressed) in multicodec table
+
+	ecKeyType = "EC"
+)
+
+// curve is a NIST curve of the composite keys along with its multicodec code.
+type curve struct {
+	name  string
+	curve elliptic.Curve
+	code  uint64
+}
+
+// nolint:gochecknoglobals
+var curves = []curve{
+	{name: "NIST_P256", curve: elliptic.P256(), code: p256Pub},
+	{name: "NIST_P384", curve: elliptic.P384(), code: p384Pub},
+	{name: "NIST_P521", curve: elliptic.P521(), code: p521Pub},
+}
+
+// IsKeyID reports whether kid is a did:key DID URL.
+func IsKeyID(kid string) bool {
+	return strings.HasPrefix(kid, didKeyPrefix)
+}
+
+// KeyID returns the did:key DID URL identifying the public key in the envelopes.
+func KeyID(key *composite.PublicKey) (string, error) {
+	c, err := hybrid.GetCurve(key.Curve)
+	if err != nil {
+		return "", fmt.Errorf("key ID: %w", err)
+	}
+
+	for _, kc := range curves {
+		if kc.curve != c {
+			continue
+		}
+
+		point := elliptic.MarshalCompressed(c, new(big.Int).SetBytes(key.X), new(big.Int).SetBytes(key.Y))
+		methodID := fingerprint.KeyFingerprint(kc.code, point)
+
+		return didKeyPrefix + methodID + "#" + methodID, nil
+	}
+
+	return "", fmt.Errorf("key ID: curve %s not supported", key.Curve)
+}
+
+// RecipientKeys returns copies of the recipient keys identified by their did:key DID URLs.
+func RecipientKeys(keys []*composite.PublicKey) ([]*composite.PublicKey, error) {
+	v2Keys := make([]*composite.PublicKey, len(keys))
+
+	for i, key := range keys {
+		v2Key := *key
+
+		var err error
+
+		v2Key.KID, err = KeyID(key)
+		if err != nil {
+			return nil, err
+		}
+
+		v2Keys[i] = &v2Key
+	}
+
+	return v2Keys, nil
+}
+
+// PublicKey returns the public key identified by the did:key DID URL kid, the KID of the returned key is the ID of
+// the key in the KMS: the JWK thumbprint of the key, as created by the local KMS.
+func PublicKey(kid string) (*composite.PublicKey, error) {
+	if !IsKeyID(kid) {
+		return nil, fmt.Errorf("kid %s is not a did:key", kid)
+	}
+
+	// did:key:<fingerprint>#<fingerprint>
+	methodID := strings.Split(strings.TrimPrefix(kid, didKeyPrefix), "#")[0]
+	if !strings.HasPrefix(methodID, "z") {
+		return nil, fmt.Errorf("kid %s has an invalid key fingerprint", kid)
+	}
+
+	mc := base58.Decode(methodID[1:])
+
+	code, n := binary.Uvarint(mc)
+	if n <= 0 {
+		return nil, fmt.Errorf("kid %s has an invalid key fingerprint", kid)
+	}
+
+	for _, kc := range curves {
+		if kc.code != code {
+			continue
+		}
+
+		x, y := elliptic.UnmarshalCompressed(kc.curve, mc[n:])
+		if x == nil {
+			return nil, fmt.Errorf("kid %s has an invalid public key", kid)
+		}
+
+		key := &composite.PublicKey{Type: ecKeyType, Curve: kc.name, X: x.Bytes(), Y: y.Bytes()}
+
+		keyID, err := kmsKeyID(key)
+		if err != nil {
+			return nil, fmt.Errorf("kid %s : %w", kid, err)
+		}
+
+		key.KID = keyID
+
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("kid %s : not supported public key (multicodec code: %#x)", kid, code)
+}
+
+func kmsKeyID(key *composite.PublicKey) (string, error) {
+	keyBytes, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	// the key ID is the thumbprint of the public key, the same for every ECDH key type
+	return localkms.CreateKID(keyBytes, kms.ECDH1PU256AES256GCMType)
+}
+
+// SenderKeyStore wraps the store of the sender keys of the envelopes: the keys identified by a did:key DID URL are
+// decoded from their ID, the other sender keys are the ones of the wrapped store.
+type SenderKeyStore struct {
+	storage.Store
+}
+
+// Get returns the marshalled sender key of the key ID k.
+func (s *SenderKeyStore) Get(k string) ([]byte, error) {
+	if !IsKeyID(k) {
+		return s.Store.Get(k)
+	}
+
+	key, err := PublicKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(key)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didcommv2
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+func TestKeyID(t *testing.T) {
+	for _, kc := range curves {
+		t.Run(kc.name, func(t *testing.T) {
+			key := createKey(t, kc)
+
+			kid, err := KeyID(key)
+			require.NoError(t, err)
+			require.True(t, IsKeyID(kid))
+
+			decodedKey, err := PublicKey(kid)
+			require.NoError(t, err)
+			require.Equal(t, key.Curve, decodedKey.Curve)
+			require.Equal(t, key.Type, decodedKey.Type)
+			require.Equal(t, new(big.Int).SetBytes(key.X), new(big.Int).SetBytes(decodedKey.X))
+			require.Equal(t, new(big.Int).SetBytes(key.Y), new(big.Int).SetBytes(decodedKey.Y))
+
+			// the KID of the decoded key is the local KMS key ID
+			require.Equal(t, key.KID, decodedKey.KID)
+		})
+	}
+
+	t.Run("not supported curve", func(t *testing.T) {
+		_, err := KeyID(&composite.PublicKey{Curve: "P-256K"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key ID:")
+	})
+}
+
+func TestRecipientKeys(t *testing.T) {
+	key := createKey(t, curves[0])
+
+	v2Keys, err := RecipientKeys([]*composite.PublicKey{key})
+	require.NoError(t, err)
+	require.Len(t, v2Keys, 1)
+	require.True(t, IsKeyID(v2Keys[0].KID))
+	require.Equal(t, key.X, v2Keys[0].X)
+
+	// the recipient keys are left unchanged
+	require.False(t, IsKeyID(key.KID))
+
+	_, err = RecipientKeys([]*composite.PublicKey{{Curve: "P-256K"}})
+	require.Error(t, err)
+}
+
+func TestPublicKey(t *testing.T) {
+	for _, tc := range []struct {
+		kid    string
+		errMsg string
+	}{
+		{"kid1", "kid kid1 is not a did:key"},
+		{"did:key:abc#abc", "kid did:key:abc#abc has an invalid key fingerprint"},
+		{"did:key:z#z", "kid did:key:z#z has an invalid key fingerprint"},
+		{fingerprintKID(p256Pub, []byte{2, 1}), "has an invalid public key"},
+		{fingerprintKID(0xed, make([]byte, 32)), "not supported public key (multicodec code: 0xed)"},
+	} {
+		_, err := PublicKey(tc.kid)
+		require.Error(t, err, tc.kid)
+		require.Contains(t, err.Error(), tc.errMsg, tc.kid)
+	}
+}
+
+func TestSenderKeyStore(t *testing.T) {
+	key := createKey(t, curves[0])
+
+	kid, err := KeyID(key)
+	require.NoError(t, err)
+
+	store := &SenderKeyStore{Store: &mockstorage.MockStore{Store: map[string][]byte{"sender": []byte("key")}}}
+
+	keyBytes, err := store.Get(kid)
+	require.NoError(t, err)
+
+	senderKey := &composite.PublicKey{}
+	require.NoError(t, json.Unmarshal(keyBytes, senderKey))
+	require.Equal(t, key.KID, senderKey.KID)
+
+	keyBytes, err = store.Get("sender")
+	require.NoError(t, err)
+	require.Equal(t, []byte("key"), keyBytes)
+
+	_, err = store.Get("unknown")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	_, err = store.Get("did:key:abc")
+	require.EqualError(t, err, "kid did:key:abc has an invalid key fingerprint")
+}
+
+// createKey returns a new composite key of the curve, its KID is the local KMS key ID.
+func createKey(t *testing.T, kc curve) *composite.PublicKey {
+	t.Helper()
+
+	privKey, err := ecdsa.GenerateKey(kc.curve, rand.Reader)
+	require.NoError(t, err)
+
+	key := &composite.PublicKey{
+		Type:  ecKeyType,
+		Curve: kc.name,
+		X:     privKey.X.Bytes(),
+		Y:     privKey.Y.Bytes(),
+	}
+
+	keyBytes, err := json.Marshal(key)
+	require.NoError(t, err)
+
+	key.KID, err = localkms.CreateKID(keyBytes, kms.ECDH1PU256AES256GCMType)
+	require.NoError(t, err)
+
+	return key
+}
+
+func fingerprintKID(code uint64, keyBytes []byte) string {
+	mc := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(mc, code)
+
+	methodID := "z" + base58.Encode(append(mc[:n], keyBytes...))
+
+	return didKeyPrefix + methodID + "#" + methodID
+}
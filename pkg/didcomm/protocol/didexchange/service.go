@@ -59,6 +59,17 @@ type message struct {
 	err error
 }
 
+// Action contains helpful information about a did-exchange action awaiting the user's acceptance.
+type Action struct {
+	// Protocol instance ID
+	PIID string
+	// ConnectionID of the connection to accept with AcceptInvitation or AcceptExchangeRequest.
+	ConnectionID string
+	Msg          service.DIDCommMsgMap
+	MyDID        string
+	TheirDID     string
+}
+
 // provider contains dependencies for the DID exchange protocol and is typically created by using aries.Context()
 type provider interface {
 	OutboundDispatcher() dispatcher.Outbound
@@ -401,6 +412,13 @@ func (s *Service) sendMsgEvents(msg *service.StateMsg) {
 // startInternalListener listens to messages in gochannel for callback messages from clients.
 func (s *Service) startInternalListener() {
 	for msg := range s.callbackChannel {
+		// the connection might have been removed while its action event was pending
+		if s.connectionRemoved(msg.ConnRecord) {
+			logger.Warnf("process callback : connection %s was removed", msg.ConnRecord.ConnectionID)
+
+			continue
+		}
+
 		// TODO https://github.com/hyperledger/aries-framework-go/issues/242 - retry logic
 		// if no error - do handle
 		if msg.err == nil {
@@ -418,6 +436,17 @@ func (s *Service) startInternalListener() {
 	}
 }
 
+// connectionRemoved tells whether the connection record was removed from the connection store.
+func (s *Service) connectionRemoved(record *connection.Record) bool {
+	if record == nil {
+		return false
+	}
+
+	_, err := s.connectionStore.GetConnectionRecord(record.ConnectionID)
+
+	return errors.Is(err, storage.ErrDataNotFound)
+}
+
 // AcceptInvitation accepts/approves connection invitation.
 func (s *Service) AcceptInvitation(connectionID, publicDID, label string) error {
 	return s.accept(connectionID, publicDID, label, StateIDInvited, "accept exchange invitation")
@@ -447,6 +476,42 @@ func (s *Service) SaveInvitation(i *OOBInvitation) error {
 	return nil
 }
 
+// Actions returns the received invitations and exchange requests awaiting the user's acceptance.
+func (s *Service) Actions() ([]Action, error) {
+	records, err := s.connectionStore.QueryConnectionRecords()
+	if err != nil {
+		return nil, fmt.Errorf("actions : %w", err)
+	}
+
+	var actions []Action
+
+	for _, record := range records {
+		if !canTriggerActionEvents(record.State, record.Namespace) {
+			continue
+		}
+
+		msg, err := s.getEventProtocolStateData(record.ConnectionID)
+		if errors.Is(err, storage.ErrDataNotFound) {
+			// no action event was triggered for this connection (eg: out-of-band invitations)
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("actions : %w", err)
+		}
+
+		actions = append(actions, Action{
+			PIID:         record.ThreadID,
+			ConnectionID: record.ConnectionID,
+			Msg:          msg.Msg,
+			MyDID:        record.MyDID,
+			TheirDID:     record.TheirDID,
+		})
+	}
+
+	return actions, nil
+}
+
 func (s *Service) accept(connectionID, publicDID, label, stateID, errMsg string) error {
 	msg, err := s.getEventProtocolStateData(connectionID)
 	if err != nil {
@@ -718,21 +718,68 @@ func (m *mockStore) Put(k string, v []byte) error {
 	return m.put(k, v)
 }
 
+// PutWithTTL stores the key and the record.
+func (m *mockStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	return m.put(k, v)
+}
+
+// PutIfVersion stores the key and the record, versions aren't checked.
+func (m *mockStore) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	return "", m.put(k, v)
+}
+
 // Get fetches the record based on key.
 func (m *mockStore) Get(k string) ([]byte, error) {
 	return m.get(k)
 }
 
+// Has tells whether the record with key exists.
+func (m *mockStore) Has(k string) (bool, error) {
+	_, err := m.get(k)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
 // Delete the record based on key.
 func (m *mockStore) Delete(k string) error {
 	return m.delete(k)
 }
 
+// DeletePrefix deletes records with prefix.
+func (m *mockStore) DeletePrefix(prefix string) (int, error) {
+	return 0, nil
+}
+
+// Reset deletes all the records.
+func (m *mockStore) Reset() error {
+	return nil
+}
+
 // Search returns storage iterator.
 func (m *mockStore) Iterator(start, limit string) storage.StoreIterator {
 	return nil
 }
 
+// IteratorWithFilter returns storage iterator.
+func (m *mockStore) IteratorWithFilter(start, limit string, keyPredicate func(key string) bool) storage.StoreIterator {
+	return nil
+}
+
+// SoftDelete the record based on key.
+func (m *mockStore) SoftDelete(k string) error {
+	return m.delete(k)
+}
+
+// GetIncludingDeleted fetches the record based on key, including soft deleted records.
+func (m *mockStore) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	v, err := m.get(k)
+
+	return v, false, err
+}
+
 func randomString() string {
 	u := uuid.New()
 	return u.String()
@@ -850,6 +897,76 @@ func (to *testOptions) Label() string {
 	return to.label
 }
 
+func TestContinueAfterConnectionRemoved(t *testing.T) {
+	prov := &protocol.MockProvider{
+		StoreProvider:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStoreProvider: mockstorage.NewMockStoreProvider(),
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+	}
+
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	actionCh := make(chan service.DIDCommAction, 10)
+	err = svc.RegisterActionEvent(actionCh)
+	require.NoError(t, err)
+
+	statusCh := make(chan service.StateMsg, 10)
+	err = svc.RegisterMsgEvent(statusCh)
+	require.NoError(t, err)
+
+	k := newKMS(t, mockstorage.NewMockStoreProvider())
+	invite, err := json.Marshal(
+		&Invitation{
+			Type:          InvitationMsgType,
+			ID:            randomString(),
+			Label:         "test",
+			RecipientKeys: []string{newED25519Key(t, k)},
+		},
+	)
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(invite)
+	require.NoError(t, err)
+
+	connID, err := svc.HandleInbound(didMsg, "", "")
+	require.NoError(t, err)
+
+	var action service.DIDCommAction
+
+	select {
+	case action = <-actionCh:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "didn't receive action event")
+	}
+
+	recorder, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+	require.NoError(t, recorder.RemoveConnection(connID))
+
+	// the pending protocol instance of the removed connection doesn't resume
+	action.Continue(nil)
+
+	timeout := time.After(200 * time.Millisecond)
+
+	for done := false; !done; {
+		select {
+		case e := <-statusCh:
+			require.NotEqual(t, StateIDRequested, e.StateID)
+		case <-timeout:
+			done = true
+		}
+	}
+
+	_, err = recorder.GetConnectionRecord(connID)
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	err = svc.AcceptInvitation(connID, "", "")
+	require.Error(t, err)
+}
+
 func TestEventsUserError(t *testing.T) {
 	svc, err := New(&protocol.MockProvider{
 		ServiceMap: map[string]interface{}{
@@ -1245,6 +1362,90 @@ func TestAcceptExchangeRequest(t *testing.T) {
 	}
 }
 
+func TestActions(t *testing.T) {
+	sp := mockstorage.NewMockStoreProvider()
+	svc, err := New(&protocol.MockProvider{
+		StoreProvider: sp,
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+	})
+	require.NoError(t, err)
+
+	actionCh := make(chan service.DIDCommAction, 10)
+	err = svc.RegisterActionEvent(actionCh)
+	require.NoError(t, err)
+
+	k := newKMS(t, sp)
+	pubKey := newED25519Key(t, k)
+	invitation := &Invitation{
+		Type:            InvitationMsgType,
+		ID:              randomString(),
+		Label:           "Bob",
+		RecipientKeys:   []string{pubKey},
+		ServiceEndpoint: "http://alice.agent.example.com:8081",
+	}
+
+	err = svc.connectionStore.SaveInvitation(invitation.ID, invitation)
+	require.NoError(t, err)
+
+	actions, err := svc.Actions()
+	require.NoError(t, err)
+	require.Empty(t, actions)
+
+	statusCh := make(chan service.StateMsg, 10)
+	err = svc.RegisterMsgEvent(statusCh)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+
+	go func() {
+		for e := range statusCh {
+			if e.Type == service.PostState && e.StateID == StateIDResponded {
+				done <- struct{}{}
+			}
+		}
+	}()
+
+	_, err = svc.HandleInbound(generateRequestMsgPayload(t, &protocol.MockProvider{
+		StoreProvider: mockstorage.NewMockStoreProvider(),
+	}, randomString(), invitation.ID), "", "")
+	require.NoError(t, err)
+
+	var connectionID string
+
+	select {
+	case e := <-actionCh:
+		prop, ok := e.Properties.(event)
+		require.True(t, ok, "Failed to cast the event properties to service.Event")
+
+		connectionID = prop.ConnectionID()
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "action event not received")
+	}
+
+	// the exchange request awaits acceptance
+	actions, err = svc.Actions()
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+	require.Equal(t, connectionID, actions[0].ConnectionID)
+	require.NotEmpty(t, actions[0].PIID)
+	require.NotEmpty(t, actions[0].TheirDID)
+	require.Equal(t, RequestMsgType, actions[0].Msg.Type())
+
+	require.NoError(t, svc.AcceptExchangeRequest(connectionID, "", ""))
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "tests are not validated")
+	}
+
+	actions, err = svc.Actions()
+	require.NoError(t, err)
+	require.Empty(t, actions)
+}
+
 func TestAcceptExchangeRequestWithPublicDID(t *testing.T) {
 	sp := mockstorage.NewMockStoreProvider()
 	svc, err := New(&protocol.MockProvider{
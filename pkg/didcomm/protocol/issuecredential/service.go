@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 
@@ -161,6 +162,8 @@ type Provider interface {
 
 // Service for the issuecredential protocol.
 type Service struct {
+	// credentialsIssued is accessed atomically, first field to be 64-bit aligned
+	credentialsIssued uint64
 	service.Action
 	service.Message
 	store      storage.Store
@@ -368,9 +371,18 @@ func (s *Service) handle(md *metaData) error {
 		}
 	}
 
+	if stateName == stateNameCredentialIssued {
+		atomic.AddUint64(&s.credentialsIssued, 1)
+	}
+
 	return nil
 }
 
+// CredentialsIssued returns the number of credentials issued by the service since it was created.
+func (s *Service) CredentialsIssued() uint64 {
+	return atomic.LoadUint64(&s.credentialsIssued)
+}
+
 func getPIID(msg service.DIDCommMsg) (string, error) {
 	if pthID := msg.ParentThreadID(); pthID != "" {
 		return pthID, nil
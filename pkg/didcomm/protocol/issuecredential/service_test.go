@@ -728,11 +728,16 @@ func TestService_HandleInbound(t *testing.T) {
 		require.Equal(t, properties.MyDID(), Alice)
 		require.Equal(t, properties.TheirDID(), Bob)
 
+		require.Zero(t, svc.CredentialsIssued())
+
 		action.Continue(WithIssueCredential(&IssueCredential{}))
 
 		select {
 		case <-done:
-			return
+			// the credential is counted once issued
+			require.Eventually(t, func() bool {
+				return svc.CredentialsIssued() == 1
+			}, time.Second, 10*time.Millisecond)
 		case <-time.After(time.Second):
 			t.Error("timeout")
 		}
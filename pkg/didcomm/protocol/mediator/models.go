@@ -55,3 +55,38 @@ type UpdateResponse struct {
 	Action       string `json:"action,omitempty"`
 	Result       string `json:"result,omitempty"`
 }
+
+// KeylistQuery route keylist query message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination#key-list-query
+type KeylistQuery struct {
+	Type     string    `json:"@type,omitempty"`
+	ID       string    `json:"@id,omitempty"`
+	Paginate *Paginate `json:"paginate,omitempty"`
+}
+
+// Paginate is the page of the key list requested by a key list query.
+type Paginate struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// Keylist route key list message, the response to a key list query.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination#key-list
+type Keylist struct {
+	Type       string      `json:"@type,omitempty"`
+	ID         string      `json:"@id,omitempty"`
+	Keys       []Key       `json:"keys,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Key route key of a key list.
+type Key struct {
+	RecipientKey string `json:"recipient_key,omitempty"`
+}
+
+// Pagination is the page of the key list returned in a key list message.
+type Pagination struct {
+	Count     int `json:"count"`
+	Offset    int `json:"offset"`
+	Remaining int `json:"remaining"`
+}
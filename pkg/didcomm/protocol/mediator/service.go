@@ -10,6 +10,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -50,6 +52,12 @@ const (
 
 	// KeyListUpdateResponseMsgType defines the route coordination key list update message response type.
 	KeylistUpdateResponseMsgType = CoordinationSpec + "keylist_update_response"
+
+	// KeylistQueryMsgType defines the route coordination key list query message type.
+	KeylistQueryMsgType = CoordinationSpec + "keylist_query"
+
+	// KeylistMsgType defines the route coordination key list message type.
+	KeylistMsgType = CoordinationSpec + "keylist"
 )
 
 // constants for key list update processing
@@ -78,6 +86,9 @@ const (
 
 const (
 	updateTimeout = 5 * time.Second
+
+	// number of keys returned by a key list query without limit
+	defaultKeylistLimit = 100
 )
 
 // ErrConnectionNotFound connection not found error.
@@ -139,6 +150,8 @@ type Service struct {
 	routeRegistrationMapLock sync.RWMutex
 	keylistUpdateMap         map[string]chan *KeylistUpdateResponse
 	keylistUpdateMapLock     sync.RWMutex
+	keylistMap               map[string]chan *Keylist
+	keylistMapLock           sync.RWMutex
 	callbacks                chan *callback
 	messagePickupSvc         messagepickup.ProtocolService
 }
@@ -174,6 +187,7 @@ func New(prov provider) (*Service, error) {
 		connectionLookup:     connectionLookup,
 		routeRegistrationMap: make(map[string]chan Grant),
 		keylistUpdateMap:     make(map[string]chan *KeylistUpdateResponse),
+		keylistMap:           make(map[string]chan *Keylist),
 		callbacks:            make(chan *callback),
 		messagePickupSvc:     messagePickupSvc,
 	}
@@ -272,6 +286,10 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string)
 			err = s.handleKeylistUpdate(msg, myDID, theirDID)
 		case KeylistUpdateResponseMsgType:
 			err = s.handleKeylistUpdateResponse(msg)
+		case KeylistQueryMsgType:
+			err = s.handleKeylistQuery(msg, myDID, theirDID)
+		case KeylistMsgType:
+			err = s.handleKeylist(msg)
 		case service.ForwardMsgType:
 			err = s.handleForward(msg)
 		}
@@ -323,7 +341,8 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 // Accept checks whether the service can handle the message type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
-	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType, service.ForwardMsgType:
+	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType,
+		KeylistQueryMsgType, KeylistMsgType, service.ForwardMsgType:
 		return true
 	}
 
@@ -485,6 +504,108 @@ func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 	return nil
 }
 
+func (s *Service) handleKeylistQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// unmarshal the payload
+	query := &KeylistQuery{}
+
+	err := msg.Decode(query)
+	if err != nil {
+		return fmt.Errorf("route key list query message unmarshal : %w", err)
+	}
+
+	keys, err := s.recipientKeys(theirDID)
+	if err != nil {
+		return fmt.Errorf("route key list query : %w", err)
+	}
+
+	paginate := query.Paginate
+	if paginate == nil {
+		paginate = &Paginate{}
+	}
+
+	limit := paginate.Limit
+	if limit <= 0 {
+		limit = defaultKeylistLimit
+	}
+
+	offset := paginate.Offset
+	if offset < 0 || offset > len(keys) {
+		offset = len(keys)
+	}
+
+	end := offset + limit
+	if end > len(keys) {
+		end = len(keys)
+	}
+
+	keylist := &Keylist{
+		Type: KeylistMsgType,
+		ID:   msg.ID(),
+		Pagination: &Pagination{
+			Count:     end - offset,
+			Offset:    offset,
+			Remaining: len(keys) - end,
+		},
+	}
+
+	for _, key := range keys[offset:end] {
+		keylist.Keys = append(keylist.Keys, Key{RecipientKey: key})
+	}
+
+	return s.outbound.SendToDID(keylist, myDID, theirDID)
+}
+
+// recipientKeys returns the recipient keys routed to theirDID, sorted to page through them consistently.
+func (s *Service) recipientKeys(theirDID string) ([]string, error) {
+	records := s.routeStore.Iterator(dataKey(""), dataKey(storage.EndKeySuffix))
+	defer records.Release()
+
+	var keys []string
+
+	for records.Next() {
+		if records.Error() != nil {
+			return nil, records.Error()
+		}
+
+		key := string(records.Key())
+
+		// the router connection ID and config share the key prefix of the routes
+		if key == routeConnIDDataKey || key == routeConfigDataKey || string(records.Value()) != theirDID {
+			continue
+		}
+
+		keys = append(keys, strings.TrimPrefix(key, dataKey("")))
+	}
+
+	if records.Error() != nil {
+		return nil, records.Error()
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (s *Service) handleKeylist(msg service.DIDCommMsg) error {
+	// unmarshal the payload
+	keylist := &Keylist{}
+
+	err := msg.Decode(keylist)
+	if err != nil {
+		return fmt.Errorf("route key list message unmarshal : %w", err)
+	}
+
+	// check if there are any channels registered for the message ID
+	keylistCh := s.getKeylistCh(keylist.ID)
+
+	if keylistCh != nil {
+		// invoke the channel for the incoming message
+		keylistCh <- keylist
+	}
+
+	return nil
+}
+
 func (s *Service) handleForward(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	forward := &model.Forward{}
@@ -675,6 +796,50 @@ func (s *Service) AddKey(recKey string) error {
 	return nil
 }
 
+// KeylistQuery queries the registered router for a page of the recipient keys of the agent, starting at offset.
+// A limit lower than or equal to zero returns the default number of keys. To resume the query, the next page
+// starts at the offset of the returned page plus its count, while keys are remaining. This method blocks until
+// a response is received from the router or it times out.
+func (s *Service) KeylistQuery(limit, offset int) (*Keylist, error) {
+	routerConnID, err := s.GetConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	// get the connection record for the ID to fetch DID information
+	conn, err := s.getConnection(routerConnID)
+	if err != nil {
+		return nil, err
+	}
+
+	// generate message ID
+	msgID := uuid.New().String()
+
+	// register chan for callback processing
+	keylistCh := make(chan *Keylist)
+	s.setKeylistCh(msgID, keylistCh)
+
+	// remove the channel once its been processed
+	defer s.setKeylistCh(msgID, nil)
+
+	query := &KeylistQuery{
+		ID:       msgID,
+		Type:     KeylistQueryMsgType,
+		Paginate: &Paginate{Limit: limit, Offset: offset},
+	}
+
+	if err := s.outbound.SendToDID(query, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send key list query: %w", err)
+	}
+
+	select {
+	case keylist := <-keylistCh:
+		return keylist, nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for key list from the router")
+	}
+}
+
 // Config fetches the router config - endpoint and routingKeys.
 func (s *Service) Config() (*Config, error) {
 	// check if router is already registered
@@ -731,6 +896,24 @@ func (s *Service) setKeyUpdateResponseCh(msgID string, keyUpdateCh chan *Keylist
 	}
 }
 
+func (s *Service) getKeylistCh(msgID string) chan *Keylist {
+	s.keylistMapLock.RLock()
+	defer s.keylistMapLock.RUnlock()
+
+	return s.keylistMap[msgID]
+}
+
+func (s *Service) setKeylistCh(msgID string, keylistCh chan *Keylist) {
+	s.keylistMapLock.Lock()
+	defer s.keylistMapLock.Unlock()
+
+	if keylistCh == nil {
+		delete(s.keylistMap, msgID)
+	} else {
+		s.keylistMap[msgID] = keylistCh
+	}
+}
+
 func (s *Service) getRouterConnectionID() (string, error) {
 	id, err := s.routeStore.Get(routeConnIDDataKey)
 	if err != nil {
@@ -72,6 +72,8 @@ func TestServiceAccept(t *testing.T) {
 	require.Equal(t, true, s.Accept(GrantMsgType))
 	require.Equal(t, true, s.Accept(KeylistUpdateMsgType))
 	require.Equal(t, true, s.Accept(KeylistUpdateResponseMsgType))
+	require.Equal(t, true, s.Accept(KeylistQueryMsgType))
+	require.Equal(t, true, s.Accept(KeylistMsgType))
 	require.Equal(t, true, s.Accept(service.ForwardMsgType))
 	require.Equal(t, false, s.Accept("unsupported msg type"))
 }
@@ -1290,6 +1292,230 @@ func TestKeylistUpdate(t *testing.T) {
 	})
 }
 
+func TestKeylistQuery(t *testing.T) {
+	const keyCount = 250
+
+	newRouter := func(t *testing.T, outbound *mockdispatcher.MockOutbound) *Service {
+		s := make(map[string][]byte)
+
+		for i := 0; i < keyCount; i++ {
+			s[dataKey(fmt.Sprintf("key-%03d", i))] = []byte(MYDID)
+		}
+
+		// keys routed to another agent and the router own data aren't listed
+		s[dataKey("other-key")] = []byte("otherDID")
+		s[routeConnIDDataKey] = []byte(MYDID)
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           outbound,
+		})
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	newEdge := func(t *testing.T, outbound *mockdispatcher.MockOutbound) *Service {
+		s := make(map[string][]byte)
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           outbound,
+		})
+		require.NoError(t, err)
+
+		// save router connID
+		require.NoError(t, svc.saveRouterConnectionID("conn1"))
+
+		// save connections
+		connRec := &connection.Record{
+			ConnectionID: "conn1", MyDID: MYDID, TheirDID: THEIRDID, State: "complete"}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn1"] = connBytes
+
+		return svc
+	}
+
+	t.Run("test keylist query - page through the keys", func(t *testing.T) {
+		var router, edge *Service
+
+		router = newRouter(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, THEIRDID, myDID)
+				require.Equal(t, MYDID, theirDID)
+
+				keylist, ok := msg.(*Keylist)
+				require.True(t, ok)
+				require.Equal(t, KeylistMsgType, keylist.Type)
+
+				return edge.handleKeylist(toDIDCommMsg(t, keylist))
+			},
+		})
+
+		edge = newEdge(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, MYDID, myDID)
+				require.Equal(t, THEIRDID, theirDID)
+
+				query, ok := msg.(*KeylistQuery)
+				require.True(t, ok)
+
+				go func() {
+					require.NoError(t, router.handleKeylistQuery(toDIDCommMsg(t, query), THEIRDID, MYDID))
+				}()
+
+				return nil
+			},
+		})
+
+		var keys []string
+
+		offset := 0
+
+		for {
+			keylist, err := edge.KeylistQuery(100, offset)
+			require.NoError(t, err)
+			require.Equal(t, offset, keylist.Pagination.Offset)
+			require.Len(t, keylist.Keys, keylist.Pagination.Count)
+
+			for _, key := range keylist.Keys {
+				keys = append(keys, key.RecipientKey)
+			}
+
+			require.Equal(t, keyCount-len(keys), keylist.Pagination.Remaining)
+
+			if keylist.Pagination.Remaining == 0 {
+				require.Equal(t, keyCount%100, keylist.Pagination.Count)
+
+				break
+			}
+
+			require.Equal(t, 100, keylist.Pagination.Count)
+
+			offset += keylist.Pagination.Count
+		}
+
+		require.Len(t, keys, keyCount)
+
+		for i, key := range keys {
+			require.Equal(t, fmt.Sprintf("key-%03d", i), key)
+		}
+	})
+
+	t.Run("test keylist query - page boundaries", func(t *testing.T) {
+		keylists := make(chan *Keylist, 1)
+
+		router := newRouter(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				keylist, ok := msg.(*Keylist)
+				require.True(t, ok)
+
+				keylists <- keylist
+
+				return nil
+			},
+		})
+
+		for _, tc := range []struct {
+			paginate  *Paginate
+			first     string
+			count     int
+			remaining int
+		}{
+			{paginate: nil, first: "key-000", count: defaultKeylistLimit, remaining: keyCount - defaultKeylistLimit},
+			{paginate: &Paginate{Limit: 10, Offset: 0}, first: "key-000", count: 10, remaining: keyCount - 10},
+			{paginate: &Paginate{Limit: 10, Offset: 245}, first: "key-245", count: 5, remaining: 0},
+			{paginate: &Paginate{Limit: 10, Offset: keyCount}, count: 0, remaining: 0},
+			{paginate: &Paginate{Limit: 10, Offset: keyCount + 1}, count: 0, remaining: 0},
+			{paginate: &Paginate{Limit: keyCount, Offset: 0}, first: "key-000", count: keyCount, remaining: 0},
+		} {
+			msgID := randomID()
+
+			err := router.handleKeylistQuery(toDIDCommMsg(t, &KeylistQuery{
+				Type:     KeylistQueryMsgType,
+				ID:       msgID,
+				Paginate: tc.paginate,
+			}), THEIRDID, MYDID)
+			require.NoError(t, err)
+
+			keylist := <-keylists
+			require.Equal(t, msgID, keylist.ID)
+			require.Len(t, keylist.Keys, tc.count)
+			require.Equal(t, tc.count, keylist.Pagination.Count)
+			require.Equal(t, tc.remaining, keylist.Pagination.Remaining)
+
+			if tc.count > 0 {
+				require.Equal(t, tc.first, keylist.Keys[0].RecipientKey)
+			}
+		}
+	})
+
+	t.Run("test keylist query - router not registered", func(t *testing.T) {
+		svc := newRouter(t, &mockdispatcher.MockOutbound{})
+
+		require.NoError(t, svc.saveRouterConnectionID(""))
+
+		_, err := svc.KeylistQuery(10, 0)
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+
+	t.Run("test keylist query - send error", func(t *testing.T) {
+		svc := newEdge(t, &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				return errors.New("send error")
+			},
+		})
+
+		_, err := svc.KeylistQuery(10, 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send key list query")
+	})
+
+	t.Run("test keylist query - invalid messages", func(t *testing.T) {
+		svc := newRouter(t, &mockdispatcher.MockOutbound{})
+
+		msg := &service.DIDCommMsgMap{"@id": map[int]int{}}
+
+		err := svc.handleKeylistQuery(msg, THEIRDID, MYDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route key list query message unmarshal")
+
+		err = svc.handleKeylist(msg)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route key list message unmarshal")
+	})
+
+	t.Run("test keylist query - store error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue: &mockstore.MockStoreProvider{Store: &mockstore.MockStore{
+				Store:  make(map[string][]byte),
+				ErrItr: errors.New("iterator error"),
+			}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		err = svc.handleKeylistQuery(toDIDCommMsg(t, &KeylistQuery{Type: KeylistQueryMsgType}), THEIRDID, MYDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "iterator error")
+	})
+}
+
 func TestConfig(t *testing.T) {
 	var routingKeys = []string{"abc", "xyz"}
 
@@ -1541,6 +1767,16 @@ func generateKeylistUpdateResponseMsgPayload(t *testing.T, id string, updates []
 	return didMsg
 }
 
+func toDIDCommMsg(t *testing.T, msg interface{}) service.DIDCommMsg {
+	msgBytes, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(msgBytes)
+	require.NoError(t, err)
+
+	return didMsg
+}
+
 func generateForwardMsgPayload(t *testing.T, id, to string, msg *model.Envelope) service.DIDCommMsg {
 	requestBytes, err := json.Marshal(&model.Forward{
 		Type: service.ForwardMsgType,
@@ -459,10 +459,32 @@ func (s *Service) StatusRequest(connectionID string) (*Status, error) {
 
 // BatchPickup a request to have multiple waiting messages sent inside a batch message.
 func (s *Service) BatchPickup(connectionID string, size int) (int, error) {
+	batch, err := s.DeliveryRequest(connectionID, size)
+	if err != nil {
+		return -1, err
+	}
+
+	var processed int
+
+	for _, msg := range batch.Messages {
+		err := s.handle(msg)
+		if err != nil {
+			logger.Errorf("error handling batch message %s: %w", msg.ID, err)
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// DeliveryRequest a request to have multiple waiting messages sent inside a batch message, the batch is returned
+// instead of dispatching its messages.
+func (s *Service) DeliveryRequest(connectionID string, size int) (*Batch, error) {
 	// get the connection record for the ID to fetch DID information
 	conn, err := s.getConnection(connectionID)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 
 	// generate message ID
@@ -483,27 +505,17 @@ func (s *Service) BatchPickup(connectionID string, size int) (int, error) {
 
 	// send message to the router
 	if err := s.outbound.SendToDID(req, conn.MyDID, conn.TheirDID); err != nil {
-		return -1, fmt.Errorf("send batch pickup request: %w", err)
+		return nil, fmt.Errorf("send batch pickup request: %w", err)
 	}
 
 	// callback processing (to make this function look like a sync function)
-	var processed int
 	select {
-	case batchResp := <-batchCh:
-		for _, msg := range batchResp.Messages {
-			err := s.handle(msg)
-			if err != nil {
-				logger.Errorf("error handling batch message %s: %w", msg.ID, err)
-				continue
-			}
-			processed++
-		}
+	case batch := <-batchCh:
+		return &batch, nil
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/1134 configure this timeout at decorator level
 	case <-time.After(updateTimeout):
-		return -1, errors.New("timeout waiting for batch")
+		return nil, errors.New("timeout waiting for batch")
 	}
-
-	return processed, nil
 }
 
 // Noop a noop message.
@@ -0,0 +1,201 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	stateNameOfferSent       = "offer-sent"
+	stateNameRequestReceived = "request-received"
+	// jsonSchema2018Type is the type of the credential schemas validated with a JSON Schema.
+	jsonSchema2018Type = "JsonSchemaValidator2018"
+)
+
+var logger = log.New("aries-framework/issuecredential/middleware")
+
+// SchemaLoader loads the JSON Schema with given id, the URL of the schema referenced by the credentials.
+type SchemaLoader func(id string) ([]byte, error)
+
+// HTTPSchemaLoader returns the SchemaLoader downloading the schemas with the HTTP client.
+func HTTPSchemaLoader(client *http.Client) SchemaLoader {
+	return func(id string) ([]byte, error) {
+		resp, err := client.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("download schema: %w", err)
+		}
+
+		defer closeResponseBody(resp.Body)
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("download schema: HTTP status %d", resp.StatusCode)
+		}
+
+		return ioutil.ReadAll(resp.Body)
+	}
+}
+
+func closeResponseBody(respBody io.Closer) {
+	if err := respBody.Close(); err != nil {
+		logger.Errorf("failed to close response body: %s", err)
+	}
+}
+
+// ValidateCredentialSchemas the helper function for the issue credential protocol which rejects the offered and
+// the issued credentials whose subject does not conform to the JSON Schemas (JsonSchemaValidator2018) referenced in
+// their credentialSchema, the schemas are loaded by the loader.
+func ValidateCredentialSchemas(loader SchemaLoader) issuecredential.Middleware {
+	return func(next issuecredential.Handler) issuecredential.Handler {
+		return issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+			attachments, err := issuedAttachments(metadata)
+			if err != nil {
+				return err
+			}
+
+			for i := range attachments {
+				rawVC, err := attachments[i].Data.Fetch()
+				if err != nil {
+					return fmt.Errorf("fetch: %w", err)
+				}
+
+				err = validateSchemas(loader, rawVC)
+				if err != nil {
+					return fmt.Errorf("validate credential schemas: %w", err)
+				}
+			}
+
+			return next.Handle(metadata)
+		})
+	}
+}
+
+// issuedAttachments returns the credentials attachments sent by the Issuer in the state of the metadata, the offers
+// of the offer (sent by SendOffer or on the proposal) and the credentials of the issue credential message.
+func issuedAttachments(metadata issuecredential.Metadata) ([]decorator.Attachment, error) {
+	switch metadata.StateName() {
+	case stateNameOfferSent:
+		offer := metadata.OfferCredential()
+		if offer != nil {
+			return offer.OffersAttach, nil
+		}
+
+		if metadata.Message().Type() != issuecredential.OfferCredentialMsgType {
+			return nil, nil
+		}
+
+		offer = &issuecredential.OfferCredential{}
+
+		if err := metadata.Message().Decode(offer); err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+
+		return offer.OffersAttach, nil
+	case stateNameRequestReceived:
+		if metadata.IssueCredential() == nil {
+			return nil, nil
+		}
+
+		return metadata.IssueCredential().CredentialsAttach, nil
+	}
+
+	return nil, nil
+}
+
+func validateSchemas(loader SchemaLoader, rawVC []byte) error {
+	vc, err := verifiable.ParseCredential(rawVC, verifiable.WithDisabledProofCheck(),
+		verifiable.WithNoCustomSchemaCheck())
+	if err != nil {
+		return fmt.Errorf("new credential: %w", err)
+	}
+
+	subjects, err := credentialSubjects(vc)
+	if err != nil {
+		return err
+	}
+
+	for _, schema := range vc.Schemas {
+		if schema.Type != jsonSchema2018Type {
+			logger.Warnf("credential %s: schema %s of type %s is not validated", vc.ID, schema.ID, schema.Type)
+
+			continue
+		}
+
+		schemaBytes, err := loader(schema.ID)
+		if err != nil {
+			return fmt.Errorf("load schema %s: %w", schema.ID, err)
+		}
+
+		for _, subject := range subjects {
+			result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schemaBytes),
+				gojsonschema.NewBytesLoader(subject))
+			if err != nil {
+				return fmt.Errorf("validate against schema %s: %w", schema.ID, err)
+			}
+
+			if !result.Valid() {
+				return fmt.Errorf("credential %s does not conform to schema %s: %s",
+					vc.ID, schema.ID, describeErrors(result))
+			}
+		}
+	}
+
+	return nil
+}
+
+// credentialSubjects returns the JSON of the attributes of each subject of the parsed credential, id included.
+func credentialSubjects(vc *verifiable.Credential) ([][]byte, error) {
+	subjects, ok := vc.Subject.([]verifiable.Subject)
+	if !ok {
+		return nil, fmt.Errorf("unexpected credential subject of type %T", vc.Subject)
+	}
+
+	var subjectsBytes [][]byte
+
+	for _, subject := range subjects {
+		attributes := map[string]interface{}{}
+
+		for k, v := range subject.CustomFields {
+			attributes[k] = v
+		}
+
+		if subject.ID != "" {
+			attributes["id"] = subject.ID
+		}
+
+		subjectBytes, err := json.Marshal(attributes)
+		if err != nil {
+			return nil, fmt.Errorf("marshal credential subject: %w", err)
+		}
+
+		subjectsBytes = append(subjectsBytes, subjectBytes)
+	}
+
+	return subjectsBytes, nil
+}
+
+func describeErrors(result *gojsonschema.Result) string {
+	var errs []string
+
+	for _, desc := range result.Errors() {
+		errs = append(errs, desc.String())
+	}
+
+	return strings.Join(errs, "; ")
+}
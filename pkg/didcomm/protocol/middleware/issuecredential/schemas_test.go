@@ -0,0 +1,224 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuecredential
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/issuecredential"
+)
+
+const (
+	degreeSchemaID = "https://example.edu/schemas/degree.json"
+	degreeSchema   = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "id": {"type": "string"},
+    "degree": {"type": "string"}
+  },
+  "required": ["degree"]
+}`
+)
+
+func degreeCredential(subject map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"@context":          []string{"https://www.w3.org/2018/credentials/v1"},
+		"id":                "http://example.edu/credentials/1872",
+		"type":              []string{"VerifiableCredential", "UniversityDegreeCredential"},
+		"issuer":            "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"issuanceDate":      "2010-01-01T19:23:24Z",
+		"credentialSubject": subject,
+		"credentialSchema":  map[string]string{"id": degreeSchemaID, "type": jsonSchema2018Type},
+	}
+}
+
+func degreeSchemaLoader(id string) ([]byte, error) {
+	if id != degreeSchemaID {
+		return nil, errors.New("schema not found")
+	}
+
+	return []byte(degreeSchema), nil
+}
+
+func TestValidateCredentialSchemas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conforming := degreeCredential(map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"})
+	conforming["credentialSubject"].(map[string]interface{})["degree"] = "BachelorDegree"
+
+	nonConforming := degreeCredential(map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"})
+
+	offer := func(vc interface{}) *issuecredential.OfferCredential {
+		return &issuecredential.OfferCredential{
+			Type:         issuecredential.OfferCredentialMsgType,
+			OffersAttach: []decorator.Attachment{{Data: decorator.AttachmentData{JSON: vc}}},
+		}
+	}
+
+	handled := false
+	next := issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+		handled = true
+
+		return nil
+	})
+
+	t.Run("Ignores processing", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameCredentialReceived)
+
+		handled = false
+		require.NoError(t, ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata))
+		require.True(t, handled)
+	})
+
+	t.Run("Issues a conforming credential", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredential().Return(&issuecredential.IssueCredential{
+			CredentialsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{JSON: conforming}}},
+		}).AnyTimes()
+
+		handled = false
+		require.NoError(t, ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata))
+		require.True(t, handled)
+	})
+
+	t.Run("Rejects a non-conforming credential", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredential().Return(&issuecredential.IssueCredential{
+			CredentialsAttach: []decorator.Attachment{{Data: decorator.AttachmentData{JSON: nonConforming}}},
+		}).AnyTimes()
+
+		handled = false
+		err := ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata)
+		require.EqualError(t, err, "validate credential schemas: credential http://example.edu/credentials/1872 "+
+			"does not conform to schema "+degreeSchemaID+": (root): degree is required")
+		require.False(t, handled)
+	})
+
+	t.Run("Request received without issue credential", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredential().Return(nil)
+
+		require.NoError(t, ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata))
+	})
+
+	t.Run("Rejects a non-conforming offer sent on the proposal", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameOfferSent)
+		metadata.EXPECT().OfferCredential().Return(offer(nonConforming))
+
+		err := ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata)
+		require.Contains(t, err.Error(), "degree is required")
+	})
+
+	t.Run("Validates the offer sent by SendOffer", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameOfferSent).Times(2)
+		metadata.EXPECT().OfferCredential().Return(nil).Times(2)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(offer(conforming))).Times(2)
+		require.NoError(t, ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata))
+
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(offer(nonConforming))).Times(2)
+		err := ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata)
+		require.Contains(t, err.Error(), "degree is required")
+	})
+
+	t.Run("Offer sent on the proposal without offer", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameOfferSent)
+		metadata.EXPECT().OfferCredential().Return(nil)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.ProposeCredential{
+			Type: issuecredential.ProposeCredentialMsgType,
+		}))
+
+		require.NoError(t, ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata))
+	})
+
+	t.Run("Load schema error", func(t *testing.T) {
+		vc := degreeCredential(map[string]interface{}{"degree": "BachelorDegree"})
+		vc["credentialSchema"] = map[string]string{"id": "https://example.edu/unknown.json", "type": jsonSchema2018Type}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameOfferSent)
+		metadata.EXPECT().OfferCredential().Return(offer(vc))
+
+		err := ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata)
+		require.Contains(t, err.Error(), "load schema https://example.edu/unknown.json: schema not found")
+	})
+
+	t.Run("Schemas of other types are not validated", func(t *testing.T) {
+		vc := degreeCredential(map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"})
+		vc["credentialSchema"] = map[string]string{"id": degreeSchemaID, "type": "ZkpExampleSchema2018"}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameOfferSent)
+		metadata.EXPECT().OfferCredential().Return(offer(vc))
+
+		require.NoError(t, ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata))
+	})
+
+	t.Run("Validates each subject", func(t *testing.T) {
+		vc := degreeCredential(nil)
+		vc["credentialSubject"] = []map[string]interface{}{{"degree": "BachelorDegree"}, {"id": "did:example:123"}}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameOfferSent)
+		metadata.EXPECT().OfferCredential().Return(offer(vc))
+
+		err := ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata)
+		require.Contains(t, err.Error(), "degree is required")
+	})
+
+	t.Run("Invalid credential", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameOfferSent)
+		metadata.EXPECT().OfferCredential().Return(offer(map[string]interface{}{"id": "http://example.edu/1"}))
+
+		err := ValidateCredentialSchemas(degreeSchemaLoader)(next).Handle(metadata)
+		require.Contains(t, err.Error(), "validate credential schemas: new credential")
+	})
+}
+
+func TestHTTPSchemaLoader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/degree.json" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, err := w.Write([]byte(degreeSchema))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	loader := HTTPSchemaLoader(server.Client())
+
+	schema, err := loader(server.URL + "/degree.json")
+	require.NoError(t, err)
+	require.Equal(t, degreeSchema, string(schema))
+
+	_, err = loader(server.URL + "/unknown.json")
+	require.EqualError(t, err, "download schema: HTTP status 404")
+
+	_, err = loader("://invalid")
+	require.Contains(t, err.Error(), "download schema")
+}
@@ -28,4 +28,6 @@ type Invitation struct {
 	GoalCode  string        `json:"goal-code,omitempty"`
 	Service   []interface{} `json:"service"` // Service is an array of either DIDs or 'service' block entries.
 	Protocols []string      `json:"protocols"`
+	// Requests are the optional requests attached to the invitation, processed once the connection is established.
+	Requests []*decorator.Attachment `json:"requests~attach,omitempty"`
 }
@@ -539,7 +539,8 @@ func (s *Service) handleInvitationCallback(c *callback) (string, error) {
 	}
 
 	state := &myState{
-		ID:           didInv.ID,
+		// the pthid of the didexchange thread will equal this invitation's ID as per the RFC
+		ID:           didInv.ThreadID,
 		ConnectionID: connID,
 		Invitation:   oobInv,
 	}
@@ -636,12 +637,26 @@ func (s *Service) fetchMyState(id string) (*myState, error) {
 //  - https://github.com/hyperledger/aries-rfcs/issues/468
 //  - https://github.com/hyperledger/aries-rfcs/issues/451
 //  This logic should be injected into the service.
+//  The requests attached to invitations are processed the same way as those of requests.
 func chooseRequest(state *myState) (*decorator.Attachment, bool) {
-	if !state.Done {
-		return state.Request.Requests[0], true
+	if state.Done {
+		return nil, false
+	}
+
+	var requests []*decorator.Attachment
+
+	switch {
+	case state.Request != nil:
+		requests = state.Request.Requests
+	case state.Invitation != nil:
+		requests = state.Invitation.Requests
+	}
+
+	if len(requests) == 0 {
+		return nil, false
 	}
 
-	return nil, false
+	return requests[0], true
 }
 
 func extractDIDCommMsgBytes(a *decorator.Attachment) ([]byte, error) {
@@ -656,7 +671,7 @@ func extractDIDCommMsgBytes(a *decorator.Attachment) ([]byte, error) {
 func (s *Service) extractDIDCommMsg(state *myState) (service.DIDCommMsg, error) {
 	req, found := s.chooseRequestFunc(state)
 	if !found {
-		return nil, fmt.Errorf("no requests found to extract for msgId=%s", state.Request.ID)
+		return nil, fmt.Errorf("no requests found to extract for state=%s : %w", state.ID, errIgnoredDidEvent)
 	}
 
 	bytes, err := s.extractDIDCommMsgBytesFunc(req)
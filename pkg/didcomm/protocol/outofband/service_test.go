@@ -719,6 +719,119 @@ func TestHandleDIDEvent(t *testing.T) {
 	})
 }
 
+func TestHandleInvitationCallback(t *testing.T) {
+	t.Run("dispatches the request attached to the invitation once connected", func(t *testing.T) {
+		dispatched := make(chan service.DIDCommMsg, 1)
+		connID := uuid.New().String()
+
+		provider := testProvider()
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(*didexchange.OOBInvitation) (string, error) {
+					return connID, nil
+				},
+			},
+		}
+		provider.OutboundMsgHandler = &outboundMsgHandlerStub{
+			handleFunc: func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				dispatched <- msg
+				return "", nil
+			},
+		}
+
+		inv := newInvitation()
+		inv.Requests = []*decorator.Attachment{{
+			ID:       uuid.New().String(),
+			MimeType: "application/json",
+			Data: decorator.AttachmentData{
+				JSON: map[string]interface{}{
+					"@id":   "offer-1",
+					"@type": "https://didcomm.org/issue-credential/2.0/offer-credential",
+				},
+			},
+		}}
+
+		// the invitation is received encoded
+		bytes, err := json.Marshal(inv)
+		require.NoError(t, err)
+
+		msg, err := service.ParseDIDCommMsgMap(bytes)
+		require.NoError(t, err)
+
+		s := newAutoService(t, provider)
+		result, err := s.handleInvitationCallback(&callback{msg: msg, options: &userOptions{}})
+		require.NoError(t, err)
+		require.Equal(t, connID, result)
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+			ConnectionID:   connID,
+			MyDID:          myDID,
+			TheirDID:       theirDID,
+			ParentThreadID: inv.ID,
+		}))
+
+		err = s.handleDIDEvent(service.StateMsg{
+			ProtocolName: didexchange.DIDExchange,
+			Type:         service.PostState,
+			Msg:          service.NewDIDCommMsgMap(newAck(inv.ID)),
+			StateID:      didexchange.StateIDCompleted,
+			Properties:   &mockdidexchange.MockEventProperties{ConnID: connID},
+		})
+		require.NoError(t, err)
+
+		select {
+		case m := <-dispatched:
+			require.Equal(t, "offer-1", m.ID())
+			require.Equal(t, "https://didcomm.org/issue-credential/2.0/offer-credential", m.Type())
+		case <-time.After(1 * time.Second):
+			t.Error("timeout")
+		}
+
+		state, err := s.fetchMyState(inv.ID)
+		require.NoError(t, err)
+		require.True(t, state.Done)
+	})
+	t.Run("ignores did event of an invitation without requests", func(t *testing.T) {
+		connID := uuid.New().String()
+		inv := newInvitation()
+
+		provider := testProvider()
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(*didexchange.OOBInvitation) (string, error) {
+					return connID, nil
+				},
+			},
+		}
+
+		s := newAutoService(t, provider)
+		_, err := s.handleInvitationCallback(&callback{
+			msg:     service.NewDIDCommMsgMap(inv),
+			options: &userOptions{},
+		})
+		require.NoError(t, err)
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+			ConnectionID:   connID,
+			ParentThreadID: inv.ID,
+		}))
+
+		err = s.handleDIDEvent(service.StateMsg{
+			ProtocolName: didexchange.DIDExchange,
+			Type:         service.PostState,
+			Msg:          service.NewDIDCommMsgMap(newAck(inv.ID)),
+			StateID:      didexchange.StateIDCompleted,
+			Properties:   &mockdidexchange.MockEventProperties{ConnID: connID},
+		})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, errIgnoredDidEvent))
+	})
+}
+
 func TestListener(t *testing.T) {
 	t.Run("invokes handleReqFunc", func(t *testing.T) {
 		invoked := make(chan struct{})
@@ -1261,18 +1374,50 @@ func (s *stubStore) Put(k string, v []byte) error {
 	return nil
 }
 
+func (s *stubStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	panic("implement me")
+}
+
+func (s *stubStore) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	panic("implement me")
+}
+
 func (s *stubStore) Get(k string) ([]byte, error) {
 	panic("implement me")
 }
 
+func (s *stubStore) Has(k string) (bool, error) {
+	panic("implement me")
+}
+
 func (s *stubStore) Iterator(start, limit string) storage.StoreIterator {
 	panic("implement me")
 }
 
+func (s *stubStore) IteratorWithFilter(start, limit string, keyPredicate func(key string) bool) storage.StoreIterator {
+	panic("implement me")
+}
+
 func (s *stubStore) Delete(k string) error {
 	panic("implement me")
 }
 
+func (s *stubStore) DeletePrefix(prefix string) (int, error) {
+	panic("implement me")
+}
+
+func (s *stubStore) Reset() error {
+	panic("implement me")
+}
+
+func (s *stubStore) SoftDelete(k string) error {
+	panic("implement me")
+}
+
+func (s *stubStore) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	panic("implement me")
+}
+
 type outboundMsgHandlerStub struct {
 	handleFunc func(service.DIDCommMsg, string, string) (string, error)
 }
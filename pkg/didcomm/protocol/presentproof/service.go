@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 
@@ -156,6 +157,8 @@ type Provider interface {
 
 // Service for the presentproof protocol.
 type Service struct {
+	// presentationsVerified is accessed atomically, first field to be 64-bit aligned
+	presentationsVerified uint64
 	service.Action
 	service.Message
 	store      storage.Store
@@ -345,12 +348,23 @@ func (s *Service) handle(md *metaData) error {
 			return fmt.Errorf("action %s: %w", md.state.Name(), err)
 		}
 
+		// the verifier accepted the presentation received
+		if current.Name() == stateNamePresentationReceived && next.Name() == stateNameDone {
+			atomic.AddUint64(&s.presentationsVerified, 1)
+		}
+
 		current = next
 	}
 
 	return nil
 }
 
+// PresentationsVerified returns the number of presentations received and accepted by the verifier since the service
+// was created.
+func (s *Service) PresentationsVerified() uint64 {
+	return atomic.LoadUint64(&s.presentationsVerified)
+}
+
 func getPIID(msg service.DIDCommMsg) (string, error) {
 	if pthID := msg.ParentThreadID(); pthID != "" {
 		return pthID, nil
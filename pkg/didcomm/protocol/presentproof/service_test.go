@@ -887,11 +887,16 @@ func TestService_HandleInbound(t *testing.T) {
 		require.Equal(t, properties.MyDID(), Alice)
 		require.Equal(t, properties.TheirDID(), Bob)
 
+		require.Zero(t, svc.PresentationsVerified())
+
 		action.Continue(nil)
 
 		select {
 		case <-done:
-			return
+			// the presentation is counted once accepted
+			require.Eventually(t, func() bool {
+				return svc.PresentationsVerified() == 1
+			}, time.Second, 10*time.Millisecond)
 		case <-time.After(time.Second * 10):
 			t.Error("timeout")
 		}
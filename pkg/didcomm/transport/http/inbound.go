@@ -10,9 +10,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/rs/cors"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
@@ -21,7 +24,49 @@ import (
 
 var logger = log.New("aries-framework/http")
 
-// TODO https://github.com/hyperledger/aries-framework-go/issues/891 Support for Transport Return Route (Duplex)
+const (
+	// DefaultMaxBodySize is the default maximum size in bytes of the inbound messages.
+	DefaultMaxBodySize = 10 << 20
+
+	// DefaultReturnRouteTimeout is the default duration the response of an inbound message requesting the return
+	// route is waited for.
+	DefaultReturnRouteTimeout = 10 * time.Second
+)
+
+// inboundCommHTTPOpts holds options for the HTTP transport implementation of the inbound handler.
+type inboundCommHTTPOpts struct {
+	path               string
+	maxBodySize        int64
+	returnRouteTimeout time.Duration
+}
+
+// InboundHTTPOpt is an inbound HTTP transport option.
+type InboundHTTPOpt func(opts *inboundCommHTTPOpts)
+
+// WithInboundPath option is for serving the inbound messages at the given path only, the requests to the other
+// paths are answered with 404 Not Found. The messages are accepted at every path by default.
+func WithInboundPath(path string) InboundHTTPOpt {
+	return func(opts *inboundCommHTTPOpts) {
+		opts.path = path
+	}
+}
+
+// WithInboundMaxBodySize option is for rejecting the inbound messages larger than size bytes with
+// 413 Request Entity Too Large, DefaultMaxBodySize is used by default.
+func WithInboundMaxBodySize(size int64) InboundHTTPOpt {
+	return func(opts *inboundCommHTTPOpts) {
+		opts.maxBodySize = size
+	}
+}
+
+// WithInboundReturnRouteTimeout option is for the duration the response of an inbound message requesting the return
+// route (`~transport: return_route`) is waited for, DefaultReturnRouteTimeout is used by default. The request is
+// answered with 202 Accepted with no body if no response is sent in time.
+func WithInboundReturnRouteTimeout(timeout time.Duration) InboundHTTPOpt {
+	return func(opts *inboundCommHTTPOpts) {
+		opts.returnRouteTimeout = timeout
+	}
+}
 
 // NewInboundHandler will create a new handler to enforce Did-Comm HTTP transport specs
 // then routes processing to the mandatory 'msgHandler' argument.
@@ -29,29 +74,60 @@ var logger = log.New("aries-framework/http")
 // Arguments:
 // * 'msgHandler' is the handler function that will be executed with the inbound request payload.
 //    Users of this library must manage the handling of all inbound payloads in this function.
-func NewInboundHandler(prov transport.Provider) (http.Handler, error) {
+func NewInboundHandler(prov transport.Provider, opts ...InboundHTTPOpt) (http.Handler, error) {
 	if prov == nil || prov.InboundMessageHandler() == nil {
 		logger.Errorf("Error creating a new inbound handler: message handler function is nil")
 		return nil, errors.New("creation of inbound handler failed")
 	}
 
-	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		processPOSTRequest(w, r, prov)
-	})
+	handlerOpts := &inboundCommHTTPOpts{maxBodySize: DefaultMaxBodySize, returnRouteTimeout: DefaultReturnRouteTimeout}
+
+	for _, opt := range opts {
+		opt(handlerOpts)
+	}
+
+	if handlerOpts.maxBodySize <= 0 {
+		return nil, fmt.Errorf("invalid max body size %d", handlerOpts.maxBodySize)
+	}
+
+	inbound := &inboundHandler{
+		prov:               prov,
+		routes:             getReturnRoutes(prov.AriesFrameworkID()),
+		maxBodySize:        handlerOpts.maxBodySize,
+		returnRouteTimeout: handlerOpts.returnRouteTimeout,
+	}
+
+	var handler http.Handler = http.HandlerFunc(inbound.processPOSTRequest)
+
+	if handlerOpts.path != "" {
+		mux := http.NewServeMux()
+		mux.Handle(handlerOpts.path, handler)
+
+		handler = mux
+	}
 
 	return cors.Default().Handler(handler), nil
 }
 
-func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider) {
+// inboundHandler processes the inbound messages.
+type inboundHandler struct {
+	prov               transport.Provider
+	routes             *returnRoutes
+	maxBodySize        int64
+	returnRouteTimeout time.Duration
+}
+
+func (h *inboundHandler) processPOSTRequest(w http.ResponseWriter, r *http.Request) {
 	if valid := validateHTTPMethod(w, r); !valid {
 		return
 	}
 
-	if valid := validatePayload(r, w); !valid {
+	if valid := validatePayload(r, w, h.maxBodySize); !valid {
 		return
 	}
 
-	body, err := ioutil.ReadAll(r.Body)
+	// one more byte than the max size is read to detect the oversized payloads of unknown content length
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, h.maxBodySize+1))
 	if err != nil {
 		logger.Errorf("Error reading request body: %s - returning Code: %d", err, http.StatusInternalServerError)
 		http.Error(w, "Failed to read payload", http.StatusInternalServerError)
@@ -59,15 +135,31 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		return
 	}
 
-	unpackMsg, err := prov.Packager().UnpackMessage(body)
+	if int64(len(body)) > h.maxBodySize {
+		http.Error(w, "Payload too large", http.StatusRequestEntityTooLarge)
+
+		return
+	}
+
+	unpackMsg, err := h.prov.Packager().UnpackMessage(body)
 	if err != nil {
-		logger.Errorf("failed to unpack msg: %s - returning Code: %d", err, http.StatusInternalServerError)
-		http.Error(w, "failed to unpack msg", http.StatusInternalServerError)
+		logger.Errorf("failed to unpack msg: %s - returning Code: %d", err, http.StatusBadRequest)
+		http.Error(w, "failed to unpack msg", http.StatusBadRequest)
 
 		return
 	}
 
-	messageHandler := prov.InboundMessageHandler()
+	var route chan []byte
+
+	// the route is opened before handling the message, the response may be sent while handling it
+	if len(unpackMsg.FromKey) > 0 && requestsReturnRoute(unpackMsg.Message) {
+		senderKey := base58.Encode(unpackMsg.FromKey)
+
+		route = h.routes.open(senderKey)
+		defer h.routes.close(senderKey, route)
+	}
+
+	messageHandler := h.prov.InboundMessageHandler()
 
 	err = messageHandler(unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
 	if err != nil {
@@ -75,18 +167,42 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		//  from service
 		logger.Errorf("incoming msg processing failed: %s", err)
 		w.WriteHeader(http.StatusInternalServerError)
-	} else {
+
+		return
+	}
+
+	if route == nil {
+		w.WriteHeader(http.StatusAccepted)
+
+		return
+	}
+
+	select {
+	case response := <-route:
+		w.Header().Set("Content-Type", commContentType)
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := w.Write(response); err != nil {
+			logger.Errorf("failed to write the return route response: %s", err)
+		}
+	case <-time.After(h.returnRouteTimeout):
 		w.WriteHeader(http.StatusAccepted)
+	case <-r.Context().Done():
 	}
 }
 
 // validatePayload validate and get the payload from the request.
-func validatePayload(r *http.Request, w http.ResponseWriter) bool {
+func validatePayload(r *http.Request, w http.ResponseWriter, maxBodySize int64) bool {
 	if r.ContentLength == 0 { // empty payload should not be accepted
 		http.Error(w, "Empty payload", http.StatusBadRequest)
 		return false
 	}
 
+	if r.ContentLength > maxBodySize {
+		http.Error(w, "Payload too large", http.StatusRequestEntityTooLarge)
+		return false
+	}
+
 	return true
 }
 
@@ -111,10 +227,12 @@ type Inbound struct {
 	externalAddr      string
 	server            *http.Server
 	certFile, keyFile string
+	handlerOpts       []InboundHTTPOpt
 }
 
-// NewInbound creates a new HTTP inbound transport instance.
-func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound, error) {
+// NewInbound creates a new HTTP inbound transport instance. When the messages are served at a path, see
+// WithInboundPath, the external address has to include the path.
+func NewInbound(internalAddr, externalAddr, certFile, keyFile string, opts ...InboundHTTPOpt) (*Inbound, error) {
 	if internalAddr == "" {
 		return nil, errors.New("http address is mandatory")
 	}
@@ -128,12 +246,13 @@ func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound,
 		keyFile:      keyFile,
 		externalAddr: externalAddr,
 		server:       &http.Server{Addr: internalAddr},
+		handlerOpts:  opts,
 	}, nil
 }
 
 // Start the http server.
 func (i *Inbound) Start(prov transport.Provider) error {
-	handler, err := NewInboundHandler(prov)
+	handler, err := NewInboundHandler(prov, i.handlerOpts...)
 	if err != nil {
 		return fmt.Errorf("HTTP server start failed: %w", err)
 	}
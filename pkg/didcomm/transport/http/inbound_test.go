@@ -15,6 +15,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -124,13 +125,98 @@ func TestInboundHandler(t *testing.T) {
 	resp, err = client.Post(serverURL+"/", commContentType, bytes.NewBuffer([]byte(data)))
 	require.NoError(t, err)
 	require.NotNil(t, resp)
-	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	body, err := ioutil.ReadAll(resp.Body)
 	require.NoError(t, err)
 	require.Contains(t, string(body), "failed to unpack msg")
 	require.NoError(t, resp.Body.Close())
 }
 
+type routingProvider struct {
+	mockProvider
+	received []*commontransport.Envelope
+}
+
+func (p *routingProvider) InboundMessageHandler() transport.InboundMessageHandler {
+	return func(message []byte, myDID, theirDID string) error {
+		p.received = append(p.received, &commontransport.Envelope{Message: message, ToDID: myDID, FromDID: theirDID})
+		return nil
+	}
+}
+
+func TestInboundHandler_Options(t *testing.T) {
+	const (
+		path        = "/didcomm"
+		maxBodySize = 64
+	)
+
+	prov := &routingProvider{mockProvider: mockProvider{packagerValue: &mockpackager.Packager{
+		UnpackValue: &commontransport.Envelope{
+			Message: []byte("data"),
+			ToDID:   "did:example:alice",
+			FromDID: "did:example:bob",
+		},
+	}}}
+
+	inHandler, err := NewInboundHandler(prov, WithInboundPath(path), WithInboundMaxBodySize(maxBodySize))
+	require.NoError(t, err)
+
+	post := func(target string, body []byte, knownLength bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+		req.Header.Set("Content-Type", commContentType)
+
+		if !knownLength {
+			req.ContentLength = -1
+		}
+
+		rr := httptest.NewRecorder()
+		inHandler.ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	t.Run("valid packed message is routed", func(t *testing.T) {
+		rr := post(path, []byte(`{"protected":"eyJ0eXAiOiJKV00vMS4wIn0","ciphertext":"c2VjcmV0"}`), true)
+		require.Equal(t, http.StatusAccepted, rr.Code)
+
+		require.Len(t, prov.received, 1)
+		require.Equal(t, []byte("data"), prov.received[0].Message)
+		require.Equal(t, "did:example:alice", prov.received[0].ToDID)
+		require.Equal(t, "did:example:bob", prov.received[0].FromDID)
+	})
+
+	t.Run("other paths are not served", func(t *testing.T) {
+		rr := post("/other", []byte("data"), true)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("oversized bodies are rejected", func(t *testing.T) {
+		oversized := bytes.Repeat([]byte("a"), maxBodySize+1)
+
+		rr := post(path, oversized, true)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+
+		rr = post(path, oversized, false)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+
+		rr = post(path, oversized[:maxBodySize], false)
+		require.Equal(t, http.StatusAccepted, rr.Code)
+	})
+
+	t.Run("garbage bodies are rejected", func(t *testing.T) {
+		prov.packagerValue = &mockpackager.Packager{UnpackErr: errors.New("invalid JWE")}
+
+		rr := post(path, []byte("garbage"), true)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), "failed to unpack msg")
+	})
+
+	t.Run("invalid max body size", func(t *testing.T) {
+		_, err := NewInboundHandler(prov, WithInboundMaxBodySize(0))
+		require.EqualError(t, err, "invalid max body size 0")
+	})
+}
+
 func TestInboundTransport(t *testing.T) {
 	t.Run("test inbound transport - with host/port", func(t *testing.T) {
 		port := "26601"
@@ -63,6 +63,8 @@ func WithOutboundTLSConfig(tlsConfig *tls.Config) OutboundHTTPOpt {
 // OutboundHTTPClient represents the Outbound HTTP transport instance.
 type OutboundHTTPClient struct {
 	client *http.Client
+	prov   transport.Provider
+	routes *returnRoutes
 }
 
 // NewOutbound creates a new instance of Outbound HTTP transport to Post requests to other Agents.
@@ -85,13 +87,22 @@ func NewOutbound(opts ...OutboundHTTPOpt) (*OutboundHTTPClient, error) {
 	return cs, nil
 }
 
-// Start starts outbound transport.
+// Start starts outbound transport. Once started, the responses to the messages sent with the return route are
+// written to their HTTP request if still waiting for it, and the responses returned to the messages sent by the
+// transport are routed to the inbound message handler.
 func (cs *OutboundHTTPClient) Start(prov transport.Provider) error {
+	cs.prov = prov
+	cs.routes = getReturnRoutes(prov.AriesFrameworkID())
+
 	return nil
 }
 
 // Send sends a2a exchange data via HTTP (client side).
 func (cs *OutboundHTTPClient) Send(data []byte, destination *service.Destination) (string, error) {
+	if cs.routes != nil && cs.routes.send(destination.RecipientKeys, data) {
+		return "", nil
+	}
+
 	resp, err := cs.client.Post(destination.ServiceEndpoint, commContentType, bytes.NewBuffer(data))
 	if err != nil {
 		logger.Errorf("posting DID envelope to agent failed [%s, %v]", destination.ServiceEndpoint, err)
@@ -126,14 +137,33 @@ func (cs *OutboundHTTPClient) Send(data []byte, destination *service.Destination
 			return "", fmt.Errorf("received unsuccessful POST HTTP status from agent "+
 				"[%s, %v %s]", destination.ServiceEndpoint, resp.Status, respData)
 		}
+
+		if resp.StatusCode == http.StatusOK && buf.Len() > 0 && cs.prov != nil {
+			go cs.handleReturnRouteResponse(buf.Bytes())
+		}
 	}
 
 	return respData, nil
 }
 
-// AcceptRecipient checks if there is a connection for the list of recipient keys.
-func (cs *OutboundHTTPClient) AcceptRecipient([]string) bool {
-	return false
+// handleReturnRouteResponse routes the response returned on the HTTP connection to the inbound message handler.
+func (cs *OutboundHTTPClient) handleReturnRouteResponse(response []byte) {
+	unpackMsg, err := cs.prov.Packager().UnpackMessage(response)
+	if err != nil {
+		logger.Errorf("failed to unpack the return route response: %v", err)
+
+		return
+	}
+
+	err = cs.prov.InboundMessageHandler()(unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
+	if err != nil {
+		logger.Errorf("return route response processing failed: %v", err)
+	}
+}
+
+// AcceptRecipient checks if an inbound request waits for its response to be returned to one of the recipient keys.
+func (cs *OutboundHTTPClient) AcceptRecipient(keys []string) bool {
+	return cs.routes != nil && cs.routes.accept(keys)
 }
 
 // Accept url.
@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package http
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// returnRoutes holds the HTTP requests waiting for their response to be returned on the same connection, by the
+// public key (base58) of their sender. The outbound transport writes the response to the route of its recipient
+// instead of dialing out.
+type returnRoutes struct {
+	routes map[string]chan []byte
+	sync.Mutex
+}
+
+// nolint: gochecknoglobals
+var (
+	frameworkRoutes     = make(map[string]*returnRoutes)
+	frameworkRoutesLock sync.Mutex
+)
+
+// getReturnRoutes returns the return routes shared by the inbound and outbound transports of the framework.
+func getReturnRoutes(frameworkID string) *returnRoutes {
+	frameworkRoutesLock.Lock()
+	defer frameworkRoutesLock.Unlock()
+
+	if _, ok := frameworkRoutes[frameworkID]; !ok {
+		frameworkRoutes[frameworkID] = &returnRoutes{routes: make(map[string]chan []byte)}
+	}
+
+	return frameworkRoutes[frameworkID]
+}
+
+// open opens the return route of the sender key, the response is received on the returned channel.
+func (r *returnRoutes) open(key string) chan []byte {
+	r.Lock()
+	defer r.Unlock()
+
+	route := make(chan []byte, 1)
+	r.routes[key] = route
+
+	return route
+}
+
+// close closes the return route of the sender key, if not replaced by a newer request of the sender.
+func (r *returnRoutes) close(key string, route chan []byte) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.routes[key] == route {
+		delete(r.routes, key)
+	}
+}
+
+// accept checks if a return route is open for one of the keys.
+func (r *returnRoutes) accept(keys []string) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, key := range keys {
+		if _, ok := r.routes[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// send writes the data to the return route of one of the keys, the route is closed once the data is written as
+// only one response can be returned to an HTTP request. False is returned if no return route is open.
+func (r *returnRoutes) send(keys []string, data []byte) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, key := range keys {
+		if route, ok := r.routes[key]; ok {
+			route <- data
+
+			delete(r.routes, key)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// requestsReturnRoute checks if the message has the transport decorator requesting the response to be returned on
+// the same connection.
+func requestsReturnRoute(msg []byte) bool {
+	trans := &decorator.Transport{}
+
+	err := json.Unmarshal(msg, trans)
+	if err != nil || trans.ReturnRoute == nil {
+		return false
+	}
+
+	return trans.ReturnRoute.Value == decorator.TransportReturnRouteAll ||
+		trans.ReturnRoute.Value == decorator.TransportReturnRouteThread
+}
@@ -0,0 +1,164 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+)
+
+const returnRouteMsg = `{"@type": "https://didcomm.org/trust_ping/1.0/ping", "~transport": {"~return_route": "all"}}`
+
+// returnRouteProvider is the provider of a framework handling the inbound messages with handle.
+type returnRouteProvider struct {
+	id       string
+	packager commontransport.Packager
+	handle   func(message []byte) error
+}
+
+func (p *returnRouteProvider) InboundMessageHandler() transport.InboundMessageHandler {
+	return func(message []byte, myDID, theirDID string) error {
+		return p.handle(message)
+	}
+}
+
+func (p *returnRouteProvider) Packager() commontransport.Packager {
+	return p.packager
+}
+
+func (p *returnRouteProvider) AriesFrameworkID() string {
+	return p.id
+}
+
+func TestInboundHandler_ReturnRoute(t *testing.T) {
+	senderKey := []byte("sender-public-key")
+
+	// responder creates a framework whose inbound messages are answered with the outbound transport
+	responder := func(t *testing.T, message []byte, respond bool, opts ...InboundHTTPOpt) http.Handler {
+		t.Helper()
+
+		outbound, err := NewOutbound(WithOutboundHTTPClient(&http.Client{}))
+		require.NoError(t, err)
+
+		prov := &returnRouteProvider{
+			id: uuid.New().String(),
+			packager: &mockpackager.Packager{
+				UnpackValue: &commontransport.Envelope{Message: message, FromKey: senderKey},
+			},
+		}
+
+		prov.handle = func([]byte) error {
+			if !respond {
+				return nil
+			}
+
+			// the service answers asynchronously, as the protocol services do
+			go func() {
+				keys := []string{base58.Encode(senderKey)}
+				require.True(t, outbound.AcceptRecipient(keys))
+
+				_, err := outbound.Send([]byte("packed-response"), &service.Destination{RecipientKeys: keys})
+				require.NoError(t, err)
+			}()
+
+			return nil
+		}
+
+		require.NoError(t, outbound.Start(prov))
+
+		handler, err := NewInboundHandler(prov, opts...)
+		require.NoError(t, err)
+
+		return handler
+	}
+
+	post := func(handler http.Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("packed-request"))
+		req.Header.Set("Content-Type", commContentType)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	t.Run("response is written on the request", func(t *testing.T) {
+		rr := post(responder(t, []byte(returnRouteMsg), true))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, commContentType, rr.Header().Get("Content-Type"))
+		require.Equal(t, "packed-response", rr.Body.String())
+	})
+
+	t.Run("no response in time", func(t *testing.T) {
+		rr := post(responder(t, []byte(returnRouteMsg), false, WithInboundReturnRouteTimeout(10*time.Millisecond)))
+		require.Equal(t, http.StatusAccepted, rr.Code)
+		require.Empty(t, rr.Body.String())
+	})
+
+	t.Run("return route not requested", func(t *testing.T) {
+		rr := post(responder(t, []byte(`{"@type": "https://didcomm.org/trust_ping/1.0/ping"}`), false))
+		require.Equal(t, http.StatusAccepted, rr.Code)
+	})
+}
+
+func TestOutboundHTTPClient_ReturnRouteResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", commContentType)
+		_, err := w.Write([]byte("packed-response"))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	received := make(chan []byte, 1)
+
+	prov := &returnRouteProvider{
+		id:       uuid.New().String(),
+		packager: &mockpackager.Packager{UnpackValue: &commontransport.Envelope{Message: []byte("response")}},
+		handle: func(message []byte) error {
+			received <- message
+
+			return nil
+		},
+	}
+
+	outbound, err := NewOutbound(WithOutboundHTTPClient(server.Client()))
+	require.NoError(t, err)
+	require.NoError(t, outbound.Start(prov))
+
+	require.False(t, outbound.AcceptRecipient([]string{"recipient"}))
+
+	resp, err := outbound.Send([]byte(returnRouteMsg), &service.Destination{ServiceEndpoint: server.URL})
+	require.NoError(t, err)
+	require.Equal(t, "packed-response", resp)
+
+	select {
+	case msg := <-received:
+		require.Equal(t, []byte("response"), msg)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "return route response not routed to the inbound handler")
+	}
+}
+
+func TestRequestsReturnRoute(t *testing.T) {
+	require.True(t, requestsReturnRoute([]byte(returnRouteMsg)))
+	require.True(t, requestsReturnRoute([]byte(`{"~transport": {"~return_route": "thread"}}`)))
+	require.False(t, requestsReturnRoute([]byte(`{"~transport": {"~return_route": "none"}}`)))
+	require.False(t, requestsReturnRoute([]byte(`{}`)))
+	require.False(t, requestsReturnRoute([]byte(`[]`)))
+}
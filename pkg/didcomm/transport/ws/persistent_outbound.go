@@ -0,0 +1,294 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"nhooyr.io/websocket"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+const (
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+	defaultQueueSize      = 100
+)
+
+// errQueueFull is returned when a message is sent while disconnected and the queue of the messages is full.
+var errQueueFull = errors.New("websocket message queue is full")
+
+// persistentOutboundOpts holds the options of the persistent outbound transport.
+type persistentOutboundOpts struct {
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	queueSize      int
+	pingFrequency  time.Duration
+}
+
+// PersistentOutboundOpt is a persistent outbound WS transport option.
+type PersistentOutboundOpt func(opts *persistentOutboundOpts)
+
+// WithReconnectBackoff option is for the delay before reconnecting, doubled after each failed attempt up to max.
+func WithReconnectBackoff(initial, max time.Duration) PersistentOutboundOpt {
+	return func(opts *persistentOutboundOpts) {
+		opts.initialBackoff = initial
+		opts.maxBackoff = max
+	}
+}
+
+// WithQueueSize option is for the maximum number of messages queued while disconnected.
+func WithQueueSize(size int) PersistentOutboundOpt {
+	return func(opts *persistentOutboundOpts) {
+		opts.queueSize = size
+	}
+}
+
+// PersistentOutbound is a websocket outbound transport keeping a persistent connection to a single endpoint, eg. the
+// mediator of a mobile agent. The messages sent while disconnected are queued and flushed once reconnected, the
+// connection being re-established with an exponential backoff. The messages received on the connection are routed
+// to the inbound message handler.
+//
+// The transport has to be registered before the OutboundClient, which accepts every websocket endpoint.
+type PersistentOutbound struct {
+	endpoint string
+	opts     persistentOutboundOpts
+	pool     *connPool
+	conn     *websocket.Conn
+	queue    [][]byte
+	lock     sync.Mutex
+	started  bool
+	stop     chan struct{}
+}
+
+// NewPersistentOutbound creates a persistent outbound WS transport to the given endpoint.
+func NewPersistentOutbound(endpoint string, opts ...PersistentOutboundOpt) (*PersistentOutbound, error) {
+	if endpoint == "" {
+		return nil, errors.New("websocket endpoint is mandatory")
+	}
+
+	outboundOpts := persistentOutboundOpts{
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		queueSize:      defaultQueueSize,
+		pingFrequency:  pingFrequency,
+	}
+
+	for _, opt := range opts {
+		opt(&outboundOpts)
+	}
+
+	if outboundOpts.initialBackoff <= 0 || outboundOpts.maxBackoff < outboundOpts.initialBackoff {
+		return nil, fmt.Errorf("invalid reconnect backoff [%s, %s]", outboundOpts.initialBackoff,
+			outboundOpts.maxBackoff)
+	}
+
+	return &PersistentOutbound{
+		endpoint: endpoint,
+		opts:     outboundOpts,
+		stop:     make(chan struct{}),
+	}, nil
+}
+
+// Start starts the outbound transport, connecting to the endpoint in the background.
+func (o *PersistentOutbound) Start(prov transport.Provider) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.started {
+		return nil
+	}
+
+	o.pool = getConnPool(prov)
+	o.started = true
+
+	go o.run()
+
+	return nil
+}
+
+// Stop closes the connection and stops reconnecting, the queued messages are dropped.
+func (o *PersistentOutbound) Stop() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	select {
+	case <-o.stop:
+		return nil
+	default:
+		close(o.stop)
+	}
+
+	if o.conn != nil {
+		err := o.conn.Close(websocket.StatusNormalClosure, "closing the connection")
+		if err != nil && websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+			return fmt.Errorf("close websocket connection : %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Send sends a2a data via the persistent connection, the data is queued if disconnected.
+func (o *PersistentOutbound) Send(data []byte, _ *service.Destination) (string, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.conn != nil {
+		err := o.conn.Write(context.Background(), websocket.MessageText, data)
+		if err == nil {
+			return "", nil
+		}
+
+		logger.Warnf("didcomm failed, message queued : transport=ws serviceEndpoint=%s errMsg=%s", o.endpoint,
+			err.Error())
+
+		o.closeConn()
+	}
+
+	if len(o.queue) >= o.opts.queueSize {
+		return "", errQueueFull
+	}
+
+	o.queue = append(o.queue, data)
+
+	return "", nil
+}
+
+// Accept checks if the url is the endpoint of the persistent connection.
+func (o *PersistentOutbound) Accept(url string) bool {
+	return url == o.endpoint
+}
+
+// AcceptRecipient returns false, the messages are accepted based on the endpoint.
+func (o *PersistentOutbound) AcceptRecipient([]string) bool {
+	return false
+}
+
+// run maintains the connection until the transport is stopped.
+func (o *PersistentOutbound) run() {
+	backoff := o.opts.initialBackoff
+
+	for {
+		conn, _, err := websocket.Dial(context.Background(), o.endpoint, nil) // nolint: bodyclose
+		if err == nil {
+			backoff = o.opts.initialBackoff
+
+			if o.connected(conn) {
+				o.listen(conn)
+			}
+		} else {
+			logger.Warnf("websocket connection to %s failed, retrying in %s : %v", o.endpoint, backoff, err)
+		}
+
+		select {
+		case <-o.stop:
+			return
+		case <-time.After(backoff):
+		}
+
+		if err != nil {
+			backoff *= 2
+			if backoff > o.opts.maxBackoff {
+				backoff = o.opts.maxBackoff
+			}
+		}
+	}
+}
+
+// connected flushes the queued messages to the new connection, false is returned if the connection failed.
+func (o *PersistentOutbound) connected(conn *websocket.Conn) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	select {
+	case <-o.stop:
+		closeConn(conn)
+
+		return false
+	default:
+	}
+
+	for len(o.queue) > 0 {
+		if err := conn.Write(context.Background(), websocket.MessageText, o.queue[0]); err != nil {
+			logger.Warnf("flushing the queued messages to %s failed : %v", o.endpoint, err)
+			closeConn(conn)
+
+			return false
+		}
+
+		o.queue = o.queue[1:]
+	}
+
+	o.conn = conn
+
+	return true
+}
+
+// listen routes the messages received on the connection until the connection is closed.
+func (o *PersistentOutbound) listen(conn *websocket.Conn) {
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		ticker := time.NewTicker(o.opts.pingFrequency)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.Ping(context.Background()); err != nil {
+					logger.Warnf("websocket ping error : %v", err)
+
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		_, message, err := conn.Read(context.Background())
+		if err != nil {
+			if websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+				logger.Warnf("websocket connection to %s lost : %v", o.endpoint, err)
+			}
+
+			break
+		}
+
+		o.pool.handleMessage(conn, message)
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.conn == conn {
+		o.closeConn()
+	}
+}
+
+// closeConn closes the current connection, the lock has to be held.
+func (o *PersistentOutbound) closeConn() {
+	closeConn(o.conn)
+
+	o.conn = nil
+}
+
+func closeConn(conn *websocket.Conn) {
+	err := conn.Close(websocket.StatusNormalClosure, "closing the connection")
+	if err != nil && websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+		logger.Debugf("failed to close connection: %v", err)
+	}
+}
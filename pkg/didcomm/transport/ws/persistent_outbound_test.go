@@ -0,0 +1,217 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ws
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+
+	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+)
+
+// mediatorServer is a websocket server recording the received messages, which can drop its connections and
+// refuse the new ones to simulate an outage.
+type mediatorServer struct {
+	received chan string
+	conns    []*websocket.Conn
+	down     bool
+	lock     sync.Mutex
+}
+
+func (s *mediatorServer) handle(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+
+	if s.down {
+		s.lock.Unlock()
+		http.Error(w, "mediator is down", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	c, err := Accept(w, r)
+	require.NoError(t, err)
+
+	s.conns = append(s.conns, c)
+	s.lock.Unlock()
+
+	for {
+		_, message, err := c.Read(context.Background())
+		if err != nil {
+			return
+		}
+
+		s.received <- string(message)
+	}
+}
+
+// goDown drops the connections and refuses the new ones until goUp is called.
+func (s *mediatorServer) goDown() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.down = true
+
+	for _, c := range s.conns {
+		_ = c.Close(websocket.StatusGoingAway, "mediator going down") // nolint: errcheck
+	}
+
+	s.conns = nil
+}
+
+func (s *mediatorServer) goUp() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.down = false
+}
+
+func (s *mediatorServer) requireReceived(t *testing.T, expected ...string) {
+	t.Helper()
+
+	for _, msg := range expected {
+		select {
+		case received := <-s.received:
+			require.Equal(t, msg, received)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "message not received", msg)
+		}
+	}
+}
+
+type recordingProvider struct {
+	mockProvider
+	received chan []byte
+}
+
+func (p *recordingProvider) InboundMessageHandler() transport.InboundMessageHandler {
+	return func(message []byte, myDID, theirDID string) error {
+		p.received <- message
+
+		return nil
+	}
+}
+
+func TestPersistentOutbound(t *testing.T) {
+	t.Run("test persistent outbound - options", func(t *testing.T) {
+		_, err := NewPersistentOutbound("")
+		require.EqualError(t, err, "websocket endpoint is mandatory")
+
+		_, err = NewPersistentOutbound("ws://localhost:8080", WithReconnectBackoff(time.Second, time.Millisecond))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid reconnect backoff")
+
+		outbound, err := NewPersistentOutbound("ws://localhost:8080")
+		require.NoError(t, err)
+		require.True(t, outbound.Accept("ws://localhost:8080"))
+		require.False(t, outbound.Accept("ws://localhost:8081"))
+		require.False(t, outbound.AcceptRecipient([]string{"XYZ"}))
+	})
+
+	t.Run("test persistent outbound - queued messages flush on reconnect", func(t *testing.T) {
+		server := &mediatorServer{received: make(chan string, 10)}
+		addr := startWebSocketServer(t, server.handle)
+
+		// the messages sent before the connection is established are queued
+		server.goDown()
+
+		outbound, err := NewPersistentOutbound("ws://"+addr,
+			WithReconnectBackoff(10*time.Millisecond, 50*time.Millisecond))
+		require.NoError(t, err)
+
+		require.NoError(t, outbound.Start(&mockProvider{}))
+
+		defer func() {
+			require.NoError(t, outbound.Stop())
+		}()
+
+		_, err = outbound.Send([]byte("msg-1"), nil)
+		require.NoError(t, err)
+
+		server.goUp()
+		server.requireReceived(t, "msg-1")
+
+		_, err = outbound.Send([]byte("msg-2"), nil)
+		require.NoError(t, err)
+		server.requireReceived(t, "msg-2")
+
+		// drop the connection and wait for the transport to detect it
+		server.goDown()
+		require.Eventually(t, func() bool {
+			outbound.lock.Lock()
+			defer outbound.lock.Unlock()
+
+			return outbound.conn == nil
+		}, 5*time.Second, 10*time.Millisecond)
+
+		for _, msg := range []string{"msg-3", "msg-4"} {
+			_, err = outbound.Send([]byte(msg), nil)
+			require.NoError(t, err)
+		}
+
+		outbound.lock.Lock()
+		require.Len(t, outbound.queue, 2)
+		outbound.lock.Unlock()
+
+		server.goUp()
+		server.requireReceived(t, "msg-3", "msg-4")
+
+		outbound.lock.Lock()
+		require.Empty(t, outbound.queue)
+		outbound.lock.Unlock()
+	})
+
+	t.Run("test persistent outbound - queue full", func(t *testing.T) {
+		outbound, err := NewPersistentOutbound("ws://localhost:1", WithQueueSize(1))
+		require.NoError(t, err)
+
+		_, err = outbound.Send([]byte("msg-1"), nil)
+		require.NoError(t, err)
+
+		_, err = outbound.Send([]byte("msg-2"), nil)
+		require.Equal(t, errQueueFull, err)
+
+		require.NoError(t, outbound.Stop())
+		require.NoError(t, outbound.Stop())
+	})
+
+	t.Run("test persistent outbound - inbound messages", func(t *testing.T) {
+		addr := startWebSocketServer(t, echo)
+
+		outbound, err := NewPersistentOutbound("ws://" + addr)
+		require.NoError(t, err)
+
+		prov := &recordingProvider{
+			mockProvider: mockProvider{packagerValue: &mockpackager.Packager{
+				UnpackValue: &commontransport.Envelope{Message: []byte("response")},
+			}},
+			received: make(chan []byte, 1),
+		}
+
+		require.NoError(t, outbound.Start(prov))
+		require.NoError(t, outbound.Start(&mockProvider{}))
+
+		_, err = outbound.Send([]byte("request"), nil)
+		require.NoError(t, err)
+
+		select {
+		case msg := <-prov.received:
+			require.Equal(t, []byte("response"), msg)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "inbound message not received")
+		}
+
+		require.NoError(t, outbound.Stop())
+	})
+}
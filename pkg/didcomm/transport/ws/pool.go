@@ -86,31 +86,35 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 			break
 		}
 
-		unpackMsg, err := d.packager.UnpackMessage(message)
+		d.handleMessage(conn, message)
+	}
+}
 
-		if err != nil {
-			logger.Errorf("failed to unpack msg: %v", err)
+// handleMessage unpacks the message received on conn and routes it to the inbound message handler.
+func (d *connPool) handleMessage(conn *websocket.Conn, message []byte) {
+	unpackMsg, err := d.packager.UnpackMessage(message)
+	if err != nil {
+		logger.Errorf("failed to unpack msg: %v", err)
 
-			continue
-		}
+		return
+	}
 
-		trans := &decorator.Transport{}
+	trans := &decorator.Transport{}
 
-		err = json.Unmarshal(unpackMsg.Message, trans)
-		if err != nil {
-			logger.Errorf("unmarshal transport decorator : %v", err)
-		}
+	err = json.Unmarshal(unpackMsg.Message, trans)
+	if err != nil {
+		logger.Errorf("unmarshal transport decorator : %v", err)
+	}
 
-		if trans != nil && trans.ReturnRoute != nil && trans.ReturnRoute.Value == decorator.TransportReturnRouteAll {
-			d.add(base58.Encode(unpackMsg.FromKey), conn)
-		}
+	if trans != nil && trans.ReturnRoute != nil && trans.ReturnRoute.Value == decorator.TransportReturnRouteAll {
+		d.add(base58.Encode(unpackMsg.FromKey), conn)
+	}
 
-		messageHandler := d.msgHandler
+	messageHandler := d.msgHandler
 
-		err = messageHandler(unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
-		if err != nil {
-			logger.Errorf("incoming msg processing failed: %v", err)
-		}
+	err = messageHandler(unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
+	if err != nil {
+		logger.Errorf("incoming msg processing failed: %v", err)
 	}
 }
 
@@ -0,0 +1,267 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package credentialstatus checks the revocation status of verifiable credentials, as published in the status
+// list credentials their `credentialStatus` refers to. The StatusList2021
+// (https://w3c-ccg.github.io/vc-status-list-2021/) and RevocationList2020
+// (https://w3c-ccg.github.io/vc-status-rl-2020/) status types are supported.
+package credentialstatus
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+var logger = log.New("aries-framework/doc/credentialstatus")
+
+const (
+	// StatusList2021EntryType is the `credentialStatus` type of StatusList2021.
+	StatusList2021EntryType = "StatusList2021Entry"
+	// RevocationList2020StatusType is the `credentialStatus` type of RevocationList2020.
+	RevocationList2020StatusType = "RevocationList2020Status"
+
+	statusPurposeRevocation = "revocation"
+
+	statusPurposeField            = "statusPurpose"
+	statusListIndexField          = "statusListIndex"
+	statusListCredentialField     = "statusListCredential"
+	revocationListIndexField      = "revocationListIndex"
+	revocationListCredentialField = "revocationListCredential"
+
+	defaultTimeout = 10 * time.Second
+	bitsPerByte    = 8
+)
+
+// HTTPClient fetches the status list credentials.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Checker checks the revocation status of verifiable credentials.
+type Checker struct {
+	httpClient     HTTPClient
+	credentialOpts []verifiable.CredentialOpt
+}
+
+// Opt configures the Checker.
+type Opt func(c *Checker)
+
+// WithHTTPClient option is for fetching the status list credentials with the given client, the default HTTP
+// client is used otherwise.
+func WithHTTPClient(client HTTPClient) Opt {
+	return func(c *Checker) {
+		c.httpClient = client
+	}
+}
+
+// WithCredentialOpts option is for parsing (and verifying) the fetched status list credentials with the given
+// options, eg: verifiable.WithPublicKeyFetcher to check their proofs.
+func WithCredentialOpts(opts ...verifiable.CredentialOpt) Opt {
+	return func(c *Checker) {
+		c.credentialOpts = opts
+	}
+}
+
+// New returns a new status Checker.
+func New(opts ...Opt) *Checker {
+	c := &Checker{httpClient: http.DefaultClient}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Revoked tells whether the credential is revoked, that is whether its bit is set in the status list credential
+// its `credentialStatus` refers to. The status list credential must be issued by the issuer of the credential.
+func (c *Checker) Revoked(vc *verifiable.Credential) (bool, error) {
+	if vc.Status == nil {
+		return false, errors.New("vc has no credentialStatus")
+	}
+
+	var indexField, listField string
+
+	switch vc.Status.Type {
+	case StatusList2021EntryType:
+		purpose, _ := vc.Status.CustomFields[statusPurposeField].(string) // nolint: errcheck
+		if purpose != statusPurposeRevocation {
+			return false, fmt.Errorf("unsupported status purpose '%s'", purpose)
+		}
+
+		indexField, listField = statusListIndexField, statusListCredentialField
+	case RevocationList2020StatusType:
+		indexField, listField = revocationListIndexField, revocationListCredentialField
+	default:
+		return false, fmt.Errorf("unsupported credentialStatus type '%s'", vc.Status.Type)
+	}
+
+	index, err := statusIndex(vc.Status.CustomFields[indexField])
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %w", indexField, err)
+	}
+
+	listURL, ok := vc.Status.CustomFields[listField].(string)
+	if !ok || listURL == "" {
+		return false, fmt.Errorf("%s is missing", listField)
+	}
+
+	statusList, err := c.fetchStatusList(listURL)
+	if err != nil {
+		return false, err
+	}
+
+	if statusList.Issuer.ID != vc.Issuer.ID {
+		return false, fmt.Errorf("status list issuer %s is not the vc issuer %s", statusList.Issuer.ID, vc.Issuer.ID)
+	}
+
+	bitstring, err := encodedList(statusList)
+	if err != nil {
+		return false, err
+	}
+
+	if index >= len(bitstring)*bitsPerByte {
+		return false, fmt.Errorf("status index %d is out of the status list range", index)
+	}
+
+	// the first index is the left-most bit of the first byte
+	return bitstring[index/bitsPerByte]&(1<<(bitsPerByte-1-index%bitsPerByte)) != 0, nil
+}
+
+func (c *Checker) fetchStatusList(listURL string) (*verifiable.Credential, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new status list request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch status list %s: %w", listURL, err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read status list %s: %w", listURL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch status list %s: %s was received", listURL, resp.Status)
+	}
+
+	statusList, err := verifiable.ParseCredential(body, c.credentialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse status list %s: %w", listURL, err)
+	}
+
+	return statusList, nil
+}
+
+// statusIndex returns the status index, as a string (per the specs) or a number.
+func statusIndex(value interface{}) (int, error) {
+	var index int
+
+	switch v := value.(type) {
+	case string:
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, err
+		}
+
+		index = i
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("%v is not an integer", v)
+		}
+
+		index = int(v)
+	case nil:
+		return 0, errors.New("index is missing")
+	default:
+		return 0, fmt.Errorf("unsupported index %v", v)
+	}
+
+	if index < 0 {
+		return 0, fmt.Errorf("index %d is negative", index)
+	}
+
+	return index, nil
+}
+
+// encodedList returns the decoded bitstring of the status list credential subject, GZIP-compressed and base64
+// encoded in its `encodedList`.
+func encodedList(statusList *verifiable.Credential) ([]byte, error) {
+	subjectBytes, err := json.Marshal(statusList.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("marshal status list subject: %w", err)
+	}
+
+	var subject struct {
+		EncodedList string `json:"encodedList"`
+	}
+
+	var subjects []json.RawMessage
+
+	// a parsed subject is an array of a single subject
+	if json.Unmarshal(subjectBytes, &subjects) == nil && len(subjects) == 1 {
+		subjectBytes = subjects[0]
+	}
+
+	if err = json.Unmarshal(subjectBytes, &subject); err != nil || subject.EncodedList == "" {
+		return nil, errors.New("status list has no encodedList")
+	}
+
+	compressed, err := decodeBase64(subject.EncodedList)
+	if err != nil {
+		return nil, fmt.Errorf("decode encodedList: %w", err)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress encodedList: %w", err)
+	}
+
+	bitstring, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("decompress encodedList: %w", err)
+	}
+
+	return bitstring, nil
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+
+	if strings.ContainsAny(s, "-_") {
+		return base64.RawURLEncoding.DecodeString(s)
+	}
+
+	return base64.RawStdEncoding.DecodeString(s)
+}
+
+func closeResponseBody(c io.Closer) {
+	if err := c.Close(); err != nil {
+		logger.Errorf("failed to close response body: %s", err)
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package credentialstatus
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	issuerDID     = "did:example:issuer"
+	statusListURL = "https://example.com/credentials/status/3"
+	revokedIndex  = 94567
+)
+
+type httpClientFunc func(req *http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestChecker_Revoked(t *testing.T) {
+	statusList := newStatusList(t, issuerDID, "StatusList2021", revokedIndex)
+	revocationList := newStatusList(t, issuerDID, "RevocationList2020", revokedIndex)
+
+	checker := func(lists map[string][]byte) *Checker {
+		return New(
+			WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				require.Equal(t, http.MethodGet, req.Method)
+
+				list, ok := lists[req.URL.String()]
+				if !ok {
+					return &http.Response{
+						Status:     "404 Not Found",
+						StatusCode: http.StatusNotFound,
+						Body:       ioutil.NopCloser(&bytes.Buffer{}),
+					}, nil
+				}
+
+				return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(list))}, nil
+			})),
+			WithCredentialOpts(verifiable.WithDisabledProofCheck()),
+		)
+	}
+
+	t.Run("StatusList2021", func(t *testing.T) {
+		c := checker(map[string][]byte{statusListURL: statusList})
+
+		revoked, err := c.Revoked(newStatusList2021VC(revokedIndex))
+		require.NoError(t, err)
+		require.True(t, revoked)
+
+		for _, index := range []int{0, revokedIndex - 1, revokedIndex + 1} {
+			revoked, err = c.Revoked(newStatusList2021VC(index))
+			require.NoError(t, err)
+			require.False(t, revoked, index)
+		}
+	})
+
+	t.Run("RevocationList2020", func(t *testing.T) {
+		c := checker(map[string][]byte{statusListURL: revocationList})
+
+		revoked, err := c.Revoked(newRevocationList2020VC(float64(revokedIndex)))
+		require.NoError(t, err)
+		require.True(t, revoked)
+
+		revoked, err = c.Revoked(newRevocationList2020VC("12"))
+		require.NoError(t, err)
+		require.False(t, revoked)
+	})
+
+	t.Run("Invalid credential status", func(t *testing.T) {
+		c := checker(map[string][]byte{statusListURL: statusList})
+
+		vc := newStatusList2021VC(revokedIndex)
+		vc.Status = nil
+		_, err := c.Revoked(vc)
+		require.EqualError(t, err, "vc has no credentialStatus")
+
+		vc = newStatusList2021VC(revokedIndex)
+		vc.Status.Type = "CredentialStatusList2017"
+		_, err = c.Revoked(vc)
+		require.EqualError(t, err, "unsupported credentialStatus type 'CredentialStatusList2017'")
+
+		vc = newStatusList2021VC(revokedIndex)
+		vc.Status.CustomFields[statusPurposeField] = "suspension"
+		_, err = c.Revoked(vc)
+		require.EqualError(t, err, "unsupported status purpose 'suspension'")
+
+		vc = newStatusList2021VC(revokedIndex)
+		vc.Status.CustomFields[statusListIndexField] = "first"
+		_, err = c.Revoked(vc)
+		require.Contains(t, err.Error(), "invalid statusListIndex")
+
+		for _, negative := range []interface{}{"-9", float64(-1)} {
+			vc = newStatusList2021VC(revokedIndex)
+			vc.Status.CustomFields[statusListIndexField] = negative
+			_, err = c.Revoked(vc)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid statusListIndex: index")
+			require.Contains(t, err.Error(), "is negative")
+		}
+
+		vc = newStatusList2021VC(revokedIndex)
+		delete(vc.Status.CustomFields, statusListCredentialField)
+		_, err = c.Revoked(vc)
+		require.EqualError(t, err, "statusListCredential is missing")
+
+		_, err = c.Revoked(newStatusList2021VC(1 << 20))
+		require.EqualError(t, err, "status index 1048576 is out of the status list range")
+	})
+
+	t.Run("Invalid status list", func(t *testing.T) {
+		_, err := checker(nil).Revoked(newStatusList2021VC(revokedIndex))
+		require.EqualError(t, err, "fetch status list "+statusListURL+": 404 Not Found was received")
+
+		c := checker(map[string][]byte{statusListURL: newStatusList(t, "did:example:other", "StatusList2021", 1)})
+		_, err = c.Revoked(newStatusList2021VC(revokedIndex))
+		require.EqualError(t, err, "status list issuer did:example:other is not the vc issuer "+issuerDID)
+
+		c = checker(map[string][]byte{statusListURL: []byte("{}")})
+		_, err = c.Revoked(newStatusList2021VC(revokedIndex))
+		require.Contains(t, err.Error(), "parse status list")
+
+		c = New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		})))
+		_, err = c.Revoked(newStatusList2021VC(revokedIndex))
+		require.EqualError(t, err, "fetch status list "+statusListURL+": connection refused")
+	})
+}
+
+func newStatusList2021VC(index int) *verifiable.Credential {
+	vc := newVC()
+	vc.Status = &verifiable.TypedID{
+		ID:   statusListURL + "#94567",
+		Type: StatusList2021EntryType,
+		CustomFields: verifiable.CustomFields{
+			statusPurposeField:        statusPurposeRevocation,
+			statusListIndexField:      strconv.Itoa(index),
+			statusListCredentialField: statusListURL,
+		},
+	}
+
+	return vc
+}
+
+func newRevocationList2020VC(index interface{}) *verifiable.Credential {
+	vc := newVC()
+	vc.Status = &verifiable.TypedID{
+		ID:   statusListURL + "#94567",
+		Type: RevocationList2020StatusType,
+		CustomFields: verifiable.CustomFields{
+			revocationListIndexField:      index,
+			revocationListCredentialField: statusListURL,
+		},
+	}
+
+	return vc
+}
+
+func newVC() *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      "http://example.edu/credentials/1989",
+		Types:   []string{"VerifiableCredential"},
+		Subject: "did:example:holder",
+		Issuer:  verifiable.Issuer{ID: issuerDID},
+		Issued:  util.NewTime(time.Date(2020, 1, 1, 10, 54, 1, 0, time.UTC)),
+	}
+}
+
+// newStatusList returns a status list credential of 16KB (131,072 entries), the bit of the revoked index set.
+func newStatusList(t *testing.T, issuer, listType string, revoked int) []byte {
+	bitstring := make([]byte, 16*1024)
+	bitstring[revoked/8] |= 1 << (7 - revoked%8)
+
+	var compressed bytes.Buffer
+
+	w := gzip.NewWriter(&compressed)
+	_, err := w.Write(bitstring)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	listBytes, err := json.Marshal(map[string]interface{}{
+		"@context":     []string{"https://www.w3.org/2018/credentials/v1"},
+		"id":           statusListURL,
+		"type":         []string{"VerifiableCredential", listType + "Credential"},
+		"issuer":       issuer,
+		"issuanceDate": "2021-04-05T14:27:40Z",
+		"credentialSubject": map[string]interface{}{
+			"id":            statusListURL + "#list",
+			"type":          listType,
+			"statusPurpose": statusPurposeRevocation,
+			"encodedList":   base64.RawURLEncoding.EncodeToString(compressed.Bytes()),
+		},
+	})
+	require.NoError(t, err)
+
+	return listBytes
+}
@@ -0,0 +1,115 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"bytes"
+	"reflect"
+)
+
+// DocDiff holds the IDs of the public keys (verification methods), services and authentication references which
+// differ between two DID docs, in their order of appearance in the docs.
+type DocDiff struct {
+	AddedPublicKeys       []string `json:"addedPublicKeys,omitempty"`
+	RemovedPublicKeys     []string `json:"removedPublicKeys,omitempty"`
+	ChangedPublicKeys     []string `json:"changedPublicKeys,omitempty"`
+	AddedServices         []string `json:"addedServices,omitempty"`
+	RemovedServices       []string `json:"removedServices,omitempty"`
+	ChangedServices       []string `json:"changedServices,omitempty"`
+	AddedAuthentication   []string `json:"addedAuthentication,omitempty"`
+	RemovedAuthentication []string `json:"removedAuthentication,omitempty"`
+}
+
+// Empty tells whether the docs compared have the same public keys, services and authentication references.
+func (d *DocDiff) Empty() bool {
+	return reflect.DeepEqual(d, &DocDiff{})
+}
+
+// Diff compares the public keys, services and authentication references of the old and new DID docs, matched by ID.
+// Public keys are changed if their type, controller or value differ, services if any of their fields differ.
+func Diff(oldDoc, newDoc *Doc) *DocDiff {
+	diff := &DocDiff{}
+
+	diff.AddedPublicKeys, diff.RemovedPublicKeys, diff.ChangedPublicKeys = diffIDs(
+		publicKeyIDs(oldDoc.PublicKey), publicKeyIDs(newDoc.PublicKey), func(i, j int) bool {
+			return !equalPublicKeys(&oldDoc.PublicKey[i], &newDoc.PublicKey[j])
+		})
+
+	diff.AddedServices, diff.RemovedServices, diff.ChangedServices = diffIDs(
+		serviceIDs(oldDoc.Service), serviceIDs(newDoc.Service), func(i, j int) bool {
+			return !reflect.DeepEqual(oldDoc.Service[i], newDoc.Service[j])
+		})
+
+	diff.AddedAuthentication, diff.RemovedAuthentication, _ = diffIDs(
+		verificationMethodIDs(oldDoc.Authentication), verificationMethodIDs(newDoc.Authentication),
+		func(int, int) bool { return false })
+
+	return diff
+}
+
+// diffIDs returns the IDs added to newIDs, removed from oldIDs and the IDs found in both for which changed,
+// called with their respective indexes, returns true.
+func diffIDs(oldIDs, newIDs []string, changed func(i, j int) bool) (added, removed, changes []string) {
+	newIndexes := make(map[string]int, len(newIDs))
+	for j, id := range newIDs {
+		newIndexes[id] = j
+	}
+
+	oldIndexes := make(map[string]int, len(oldIDs))
+
+	for i, id := range oldIDs {
+		oldIndexes[id] = i
+
+		if _, ok := newIndexes[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+
+	for j, id := range newIDs {
+		i, ok := oldIndexes[id]
+		if !ok {
+			added = append(added, id)
+			continue
+		}
+
+		if changed(i, j) {
+			changes = append(changes, id)
+		}
+	}
+
+	return added, removed, changes
+}
+
+func equalPublicKeys(pk1, pk2 *PublicKey) bool {
+	return pk1.Type == pk2.Type && pk1.Controller == pk2.Controller && bytes.Equal(pk1.Value, pk2.Value)
+}
+
+func publicKeyIDs(pks []PublicKey) []string {
+	ids := make([]string, len(pks))
+	for i := range pks {
+		ids[i] = pks[i].ID
+	}
+
+	return ids
+}
+
+func serviceIDs(services []Service) []string {
+	ids := make([]string, len(services))
+	for i := range services {
+		ids[i] = services[i].ID
+	}
+
+	return ids
+}
+
+func verificationMethodIDs(vms []VerificationMethod) []string {
+	ids := make([]string, len(vms))
+	for i := range vms {
+		ids[i] = vms[i].PublicKey.ID
+	}
+
+	return ids
+}
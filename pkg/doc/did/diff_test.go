@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+func newDiffDoc() *Doc {
+	pk1 := PublicKey{ID: "did:example:123#key-1", Type: "Ed25519VerificationKey2018", Value: []byte("value-1")}
+	pk2 := PublicKey{ID: "did:example:123#key-2", Type: "Ed25519VerificationKey2018", Value: []byte("value-2")}
+
+	doc := BuildDoc(
+		WithPublicKey([]PublicKey{pk1, pk2}),
+		WithService([]Service{
+			{ID: "did:example:123#agent", Type: "did-communication", ServiceEndpoint: "https://agent.example.com"},
+			{ID: "did:example:123#hub", Type: "hub", ServiceEndpoint: "https://hub.example.com"},
+		}),
+		WithAuthentication([]VerificationMethod{*NewReferencedVerificationMethod(&pk1, Authentication, false)}),
+	)
+	doc.ID = "did:example:123"
+
+	return doc
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("same docs", func(t *testing.T) {
+		diff := Diff(newDiffDoc(), newDiffDoc())
+		require.True(t, diff.Empty())
+		require.Equal(t, &DocDiff{}, diff)
+	})
+
+	t.Run("service endpoint and public key changed", func(t *testing.T) {
+		newDoc := newDiffDoc()
+		newDoc.Service[1].ServiceEndpoint = "https://new-hub.example.com"
+		newDoc.PublicKey[0].Value = []byte("new-value-1")
+
+		diff := Diff(newDiffDoc(), newDoc)
+		require.False(t, diff.Empty())
+		require.Equal(t, &DocDiff{
+			ChangedPublicKeys: []string{"did:example:123#key-1"},
+			ChangedServices:   []string{"did:example:123#hub"},
+		}, diff)
+	})
+
+	t.Run("added and removed entries", func(t *testing.T) {
+		newDoc := newDiffDoc()
+		newDoc.PublicKey[1] = PublicKey{ID: "did:example:123#key-3", Type: "Ed25519VerificationKey2018"}
+		newDoc.Service = newDoc.Service[1:]
+		newDoc.Authentication = []VerificationMethod{
+			*NewReferencedVerificationMethod(&newDoc.PublicKey[1], Authentication, false),
+		}
+
+		diff := Diff(newDiffDoc(), newDoc)
+		require.Equal(t, &DocDiff{
+			AddedPublicKeys:       []string{"did:example:123#key-3"},
+			RemovedPublicKeys:     []string{"did:example:123#key-2"},
+			RemovedServices:       []string{"did:example:123#agent"},
+			AddedAuthentication:   []string{"did:example:123#key-3"},
+			RemovedAuthentication: []string{"did:example:123#key-1"},
+		}, diff)
+	})
+}
@@ -12,7 +12,6 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"net/http"
 	"regexp"
 	"strings"
 	"time"
@@ -844,6 +843,41 @@ func (doc *Doc) VerificationMethods(customVerificationRelationships ...Verificat
 	return verificationMethods
 }
 
+// VerificationMethod returns the verification method with the given key ID. The public keys of the doc are looked
+// up first, as VerificationRelationshipGeneral verification methods, then the keys embedded in or referenced by the
+// verification relationships. Key IDs relative to the doc (eg: #key-1) match their absolute form and vice versa.
+func (doc *Doc) VerificationMethod(id string) (*VerificationMethod, bool) {
+	methods := doc.VerificationMethods()
+
+	for _, relationship := range []VerificationRelationship{
+		VerificationRelationshipGeneral, Authentication, AssertionMethod,
+		CapabilityDelegation, CapabilityInvocation, KeyAgreement,
+	} {
+		for _, vm := range methods[relationship] {
+			if doc.absoluteKeyID(vm.PublicKey.ID) == doc.absoluteKeyID(id) {
+				return &vm, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// PublicKeysByRelationship returns the verification methods of the given relationship, embedded and referenced,
+// in the order of the doc. VerificationRelationshipGeneral returns all the public keys of the doc.
+func (doc *Doc) PublicKeysByRelationship(relationship VerificationRelationship) []VerificationMethod {
+	return doc.VerificationMethods(relationship)[relationship]
+}
+
+// absoluteKeyID resolves the key IDs relative to the doc.
+func (doc *Doc) absoluteKeyID(keyID string) string {
+	if strings.HasPrefix(keyID, "#") {
+		return resolveRelativeDIDURL(doc.ID, keyID)
+	}
+
+	return keyID
+}
+
 // ErrProofNotFound is returned when proof is not found.
 var ErrProofNotFound = errors.New("proof not found")
 
@@ -1036,20 +1070,12 @@ func BuildDoc(opts ...DocOption) *Doc {
 	return doc
 }
 
-// CachingJSONLDLoader creates JSON-LD CachingDocumentLoader with preloaded base JSON-LD DID and security contexts.
+// CachingJSONLDLoader creates JSON-LD DocumentLoader with preloaded base JSON-LD DID and security contexts.
 func CachingJSONLDLoader() ld.DocumentLoader {
-	loader := ld.NewCachingDocumentLoader(ld.NewRFC7324CachingDocumentLoader(&http.Client{}))
-
-	cacheContext := func(source, url string) {
-		reader, _ := ld.DocumentFromReader(strings.NewReader(source)) //nolint:errcheck
-		loader.AddDocument(url, reader)
+	loader, err := jsonld.NewDocumentLoader()
+	if err != nil {
+		panic(err)
 	}
 
-	cacheContext(didV011Context, "https://w3id.org/did/v0.11")
-	cacheContext(didV1Context, "https://w3id.org/did/v1")
-	cacheContext(didV1Context, "https://www.w3.org/ns/did/v1")
-	cacheContext(securityV1Context, "https://w3id.org/security/v1")
-	cacheContext(securityV2Context, "https://w3id.org/security/v2")
-
 	return loader
 }
@@ -1417,6 +1417,87 @@ func TestDoc_VerificationMethods(t *testing.T) {
 	require.Len(t, methods[VerificationRelationshipGeneral], 4)
 }
 
+func TestDoc_VerificationMethod(t *testing.T) {
+	const didID = "did:example:123"
+
+	general := PublicKey{ID: didID + "#general", Type: "Ed25519VerificationKey2018", Value: []byte("general")}
+	referenced := PublicKey{ID: "#referenced", Type: "Ed25519VerificationKey2018", Value: []byte("referenced")}
+	embedded := PublicKey{ID: didID + "#embedded", Type: "X25519KeyAgreementKey2019", Value: []byte("embedded")}
+
+	doc := BuildDoc(
+		WithPublicKey([]PublicKey{general, referenced}),
+		WithAuthentication([]VerificationMethod{
+			*NewReferencedVerificationMethod(&referenced, Authentication, true),
+			*NewEmbeddedVerificationMethod(&embedded, Authentication),
+		}),
+	)
+	doc.ID = didID
+	doc.KeyAgreement = []VerificationMethod{*NewEmbeddedVerificationMethod(&embedded, KeyAgreement)}
+
+	t.Run("test lookup by key ID", func(t *testing.T) {
+		vm, ok := doc.VerificationMethod(didID + "#general")
+		require.True(t, ok)
+		require.Equal(t, general, vm.PublicKey)
+		require.Equal(t, VerificationRelationshipGeneral, vm.Relationship)
+
+		// public keys are looked up first
+		vm, ok = doc.VerificationMethod("#referenced")
+		require.True(t, ok)
+		require.Equal(t, referenced, vm.PublicKey)
+		require.Equal(t, VerificationRelationshipGeneral, vm.Relationship)
+
+		// embedded keys aren't public keys of the doc
+		vm, ok = doc.VerificationMethod(didID + "#embedded")
+		require.True(t, ok)
+		require.Equal(t, embedded, vm.PublicKey)
+		require.Equal(t, Authentication, vm.Relationship)
+		require.True(t, vm.Embedded)
+
+		_, ok = doc.VerificationMethod("#missing")
+		require.False(t, ok)
+	})
+
+	t.Run("test lookup by relative and absolute key ID", func(t *testing.T) {
+		vm, ok := doc.VerificationMethod("#general")
+		require.True(t, ok)
+		require.Equal(t, general, vm.PublicKey)
+
+		vm, ok = doc.VerificationMethod(didID + "#referenced")
+		require.True(t, ok)
+		require.Equal(t, referenced, vm.PublicKey)
+
+		_, ok = doc.VerificationMethod("did:example:456#general")
+		require.False(t, ok)
+	})
+
+	t.Run("test public keys by relationship", func(t *testing.T) {
+		authentication := doc.PublicKeysByRelationship(Authentication)
+		require.Len(t, authentication, 2)
+		require.Equal(t, referenced, authentication[0].PublicKey)
+		require.False(t, authentication[0].Embedded)
+		require.Equal(t, embedded, authentication[1].PublicKey)
+		require.True(t, authentication[1].Embedded)
+
+		keyAgreement := doc.PublicKeysByRelationship(KeyAgreement)
+		require.Len(t, keyAgreement, 1)
+		require.Equal(t, embedded, keyAgreement[0].PublicKey)
+
+		require.Len(t, doc.PublicKeysByRelationship(VerificationRelationshipGeneral), 2)
+		require.Empty(t, doc.PublicKeysByRelationship(AssertionMethod))
+	})
+
+	t.Run("test parsed doc", func(t *testing.T) {
+		parsed, err := ParseDocument([]byte(validDocV011))
+		require.NoError(t, err)
+
+		for _, vm := range parsed.PublicKeysByRelationship(Authentication) {
+			found, ok := parsed.VerificationMethod(vm.PublicKey.ID)
+			require.True(t, ok)
+			require.Equal(t, vm.PublicKey, found.PublicKey)
+		}
+	})
+}
+
 func createDidDocumentWithSigningKey(pubKey []byte) *Doc {
 	const (
 		didContext      = "https://www.w3.org/ns/did/v1"
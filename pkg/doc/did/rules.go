@@ -0,0 +1,296 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Violation is a DID Core rule violated by a DID document.
+type Violation struct {
+	// Path is the path of the violating property in the document (eg. authentication[1]), empty for the document.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// RuleOption configures the rules a DID document is validated against.
+type RuleOption func(opts *ruleOptions)
+
+type ruleOptions struct {
+	serviceTypes map[string]bool
+}
+
+// WithServiceTypes option is for rejecting the services of types other than the given types, the types of the
+// services are not restricted by default.
+func WithServiceTypes(types ...string) RuleOption {
+	return func(opts *ruleOptions) {
+		if opts.serviceTypes == nil {
+			opts.serviceTypes = map[string]bool{}
+		}
+
+		for _, t := range types {
+			opts.serviceTypes[t] = true
+		}
+	}
+}
+
+// ValidateRules validates the DID document against the DID Core rules: the document conforms to the DID document
+// data model, its id is a DID, the IDs of its verification methods and services are unique, its verification
+// relationships reference verification methods of the document (or embed valid ones) and its services have a
+// known type (see WithServiceTypes). All the violations are returned, unlike ParseDocument which fails on the first
+// one. An error is returned if data is not a JSON document.
+func ValidateRules(data []byte, opts ...RuleOption) ([]Violation, error) {
+	o := &ruleOptions{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("did doc is not a JSON document")
+	}
+
+	raw := &rawDoc{}
+
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		// the document doesn't conform to the data model, the other rules can't be checked
+		return schemaViolations(data, raw.schemaLoader())
+	}
+
+	violations, err := schemaViolations(data, raw.schemaLoader())
+	if err != nil {
+		return nil, err
+	}
+
+	if raw.ID != "" {
+		if _, err = Parse(raw.ID); err != nil {
+			violations = append(violations, Violation{Path: jsonldID, Message: fmt.Sprintf("invalid DID: %s", err)})
+		}
+	}
+
+	v := &rulesValidator{
+		doc:     raw,
+		context: raw.ParseContext()[0],
+		ids:     map[string]string{},
+		methods: map[string]bool{},
+	}
+
+	v.validate(o.serviceTypes)
+
+	return append(violations, v.violations...), nil
+}
+
+// schemaViolations returns the violations of the DID document data model.
+func schemaViolations(data []byte, schemaLoader gojsonschema.JSONLoader) ([]Violation, error) {
+	result, err := gojsonschema.Validate(schemaLoader, gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return nil, fmt.Errorf("validation of DID doc failed: %w", err)
+	}
+
+	var violations []Violation
+
+	for _, desc := range result.Errors() {
+		path := desc.Field()
+		if path == gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+			path = ""
+		}
+
+		violations = append(violations, Violation{Path: path, Message: desc.Description()})
+	}
+
+	return violations, nil
+}
+
+type rulesValidator struct {
+	doc     *rawDoc
+	context string
+	// ids maps the absolute IDs of the verification methods and services to the path of their first occurrence
+	ids map[string]string
+	// methods holds the absolute IDs of the verification methods, embedded or not
+	methods    map[string]bool
+	violations []Violation
+}
+
+// relationship is a verification relationship of the document, named after its property.
+type relationship struct {
+	name    string
+	entries []interface{}
+}
+
+// validate checks the uniqueness of the IDs of the document then its relationships and services.
+func (v *rulesValidator) validate(serviceTypes map[string]bool) {
+	relationships := []relationship{
+		{"authentication", v.doc.Authentication},
+		{"assertionMethod", v.doc.AssertionMethod},
+		{"keyAgreement", v.doc.KeyAgreement},
+		{"capabilityDelegation", v.doc.CapabilityDelegation},
+		{"capabilityInvocation", v.doc.CapabilityInvocation},
+	}
+
+	for i, pk := range v.doc.PublicKey {
+		v.checkUniqueID(fmt.Sprintf("%s[%d]", jsonldPublicKey, i), pk[jsonldID], true)
+	}
+
+	for _, r := range relationships {
+		for i, entry := range r.entries {
+			if method, ok := v.embeddedMethod(entry); ok {
+				v.checkUniqueID(fmt.Sprintf("%s[%d]", r.name, i), method[jsonldID], true)
+			}
+		}
+	}
+
+	for i, service := range v.doc.Service {
+		v.checkUniqueID(fmt.Sprintf("service[%d]", i), service[jsonldID], false)
+	}
+
+	for _, r := range relationships {
+		for i, entry := range r.entries {
+			v.checkRelationship(fmt.Sprintf("%s[%d]", r.name, i), entry)
+		}
+	}
+
+	for i, service := range v.doc.Service {
+		v.checkServiceType(fmt.Sprintf("service[%d]", i), service, serviceTypes)
+	}
+}
+
+func (v *rulesValidator) addViolation(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// absoluteID returns the DID URL of the id, relative to the document if it starts with '#'.
+func (v *rulesValidator) absoluteID(id string) string {
+	if strings.HasPrefix(id, "#") {
+		return v.doc.ID + id
+	}
+
+	return id
+}
+
+func (v *rulesValidator) checkUniqueID(path string, rawID interface{}, method bool) {
+	id, ok := rawID.(string)
+	if !ok || id == "" {
+		return
+	}
+
+	if method {
+		v.methods[v.absoluteID(id)] = true
+	}
+
+	if first, ok := v.ids[v.absoluteID(id)]; ok {
+		v.addViolation(path, "duplicate id %s, already used by %s", id, first)
+
+		return
+	}
+
+	v.ids[v.absoluteID(id)] = path
+}
+
+// embeddedMethod returns the verification method embedded in the relationship entry, the entries of the v0.11 and
+// 2019 contexts referencing the methods with their publicKey property are no embedded methods.
+func (v *rulesValidator) embeddedMethod(entry interface{}) (map[string]interface{}, bool) {
+	method, ok := entry.(map[string]interface{})
+	if !ok || len(v.references(method)) > 0 {
+		return nil, false
+	}
+
+	return method, true
+}
+
+// references returns the references of the verification methods of the entry, for the v0.11 and 2019 contexts.
+func (v *rulesValidator) references(entry map[string]interface{}) []string {
+	switch refs := entry[jsonldPublicKey].(type) {
+	case string:
+		if v.context == contextV011 {
+			return []string{refs}
+		}
+	case []interface{}:
+		if v.context == contextV12019 {
+			ids := make([]string, len(refs))
+			for i, ref := range refs {
+				ids[i], _ = ref.(string)
+			}
+
+			return ids
+		}
+	}
+
+	return nil
+}
+
+// checkRelationship checks the entry of a verification relationship references verification methods of the
+// document, or embeds a verification method with an ID, a type and a controller.
+func (v *rulesValidator) checkRelationship(path string, entry interface{}) {
+	if ref, ok := entry.(string); ok {
+		v.checkReference(path, ref)
+
+		return
+	}
+
+	method, ok := entry.(map[string]interface{})
+	if !ok {
+		v.addViolation(path, "verification relationship is neither a reference nor a verification method")
+
+		return
+	}
+
+	if refs := v.references(method); len(refs) > 0 {
+		for _, ref := range refs {
+			v.checkReference(path, ref)
+		}
+
+		return
+	}
+
+	for _, property := range []string{jsonldID, jsonldType} {
+		if s, _ := method[property].(string); s == "" {
+			v.addViolation(path, "embedded verification method has no %s", property)
+		}
+	}
+
+	controller, _ := method[jsonldController].(string)
+	if owner, _ := method[jsonldOwner].(string); controller == "" && owner != "" && v.context == contextV011 {
+		controller = owner
+	}
+
+	if controller == "" {
+		v.addViolation(path, "embedded verification method has no %s", jsonldController)
+	}
+}
+
+func (v *rulesValidator) checkReference(path, ref string) {
+	if ref == "" {
+		v.addViolation(path, "empty verification method reference")
+
+		return
+	}
+
+	if !v.methods[v.absoluteID(ref)] {
+		v.addViolation(path, "references missing verification method %s", ref)
+	}
+}
+
+func (v *rulesValidator) checkServiceType(path string, service map[string]interface{}, types map[string]bool) {
+	serviceType, _ := service[jsonldType].(string)
+	if serviceType == "" || types == nil || types[serviceType] {
+		return
+	}
+
+	known := make([]string, 0, len(types))
+	for t := range types {
+		known = append(known, t)
+	}
+
+	sort.Strings(known)
+
+	v.addViolation(path, "unknown service type %s, known types: %s", serviceType, strings.Join(known, ", "))
+}
@@ -0,0 +1,180 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rulesDoc is a DID document for the rules with relative IDs.
+const rulesDoc = `{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "did:example:123",
+  "publicKey": [
+    {
+      "id": "#key-1",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:example:123",
+      "publicKeyBase58": "B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u"
+    },
+    {
+      "id": "did:example:123#key-2",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:example:123",
+      "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+    }
+  ],
+  "authentication": ["did:example:123#key-1", "#key-2"],
+  "keyAgreement": [
+    {
+      "id": "#key-3",
+      "type": "X25519KeyAgreementKey2019",
+      "controller": "did:example:123",
+      "publicKeyBase58": "JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr"
+    }
+  ],
+  "assertionMethod": ["#key-3"],
+  "service": [
+    {
+      "id": "#agent",
+      "type": "did-communication",
+      "serviceEndpoint": "https://agent.example.com/"
+    }
+  ]
+}`
+
+// updateRulesDoc returns rulesDoc updated by update.
+func updateRulesDoc(t *testing.T, update func(doc map[string]interface{})) []byte {
+	t.Helper()
+
+	doc := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(rulesDoc), &doc))
+
+	update(doc)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestValidateRules(t *testing.T) {
+	t.Run("valid documents", func(t *testing.T) {
+		for _, doc := range []string{rulesDoc, validDoc, validDocV011, docV011WithVerificationRelationships} {
+			violations, err := ValidateRules([]byte(doc))
+			require.NoError(t, err)
+			require.Empty(t, violations)
+		}
+
+		violations, err := ValidateRules([]byte(validDoc),
+			WithServiceTypes("did-communication"), WithServiceTypes("SocialWebInboxService"))
+		require.NoError(t, err)
+		require.Empty(t, violations)
+	})
+
+	t.Run("duplicate IDs", func(t *testing.T) {
+		violations, err := ValidateRules(updateRulesDoc(t, func(doc map[string]interface{}) {
+			doc["publicKey"].([]interface{})[1].(map[string]interface{})["id"] = "did:example:123#key-1"
+			doc["keyAgreement"].([]interface{})[0].(map[string]interface{})["id"] = "#key-1"
+			doc["service"].([]interface{})[0].(map[string]interface{})["id"] = "#key-1"
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []Violation{
+			{Path: "publicKey[1]", Message: "duplicate id did:example:123#key-1, already used by publicKey[0]"},
+			{Path: "keyAgreement[0]", Message: "duplicate id #key-1, already used by publicKey[0]"},
+			{Path: "service[0]", Message: "duplicate id #key-1, already used by publicKey[0]"},
+			{Path: "authentication[1]", Message: "references missing verification method #key-2"},
+			{Path: "assertionMethod[0]", Message: "references missing verification method #key-3"},
+		}, violations)
+	})
+
+	t.Run("missing verification methods", func(t *testing.T) {
+		violations, err := ValidateRules(updateRulesDoc(t, func(doc map[string]interface{}) {
+			doc["authentication"] = []interface{}{"did:example:123#key-1", "did:example:123#key-4", ""}
+			doc["capabilityInvocation"] = []interface{}{"did:example:456#key-1"}
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []Violation{
+			{Path: "authentication[1]", Message: "references missing verification method did:example:123#key-4"},
+			{Path: "authentication[2]", Message: "empty verification method reference"},
+			{Path: "capabilityInvocation[0]", Message: "references missing verification method did:example:456#key-1"},
+		}, violations)
+
+		// the v0.11 relationships reference the verification methods with their publicKey property
+		violations, err = ValidateRules([]byte(`{
+  "@context": ["https://w3id.org/did/v0.11"],
+  "id": "did:example:123",
+  "publicKey": [{"id": "did:example:123#key-1", "type": "Ed25519VerificationKey2018", "owner": "did:example:123",
+    "publicKeyBase58": "B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u"}],
+  "authentication": [
+    {"type": "Ed25519SignatureAuthentication2018", "publicKey": "did:example:123#key-1"},
+    {"type": "Ed25519SignatureAuthentication2018", "publicKey": "did:example:123#key-2"}
+  ]
+}`))
+		require.NoError(t, err)
+		require.Equal(t, []Violation{
+			{Path: "authentication[1]", Message: "references missing verification method did:example:123#key-2"},
+		}, violations)
+	})
+
+	t.Run("invalid embedded verification methods", func(t *testing.T) {
+		violations, err := ValidateRules(updateRulesDoc(t, func(doc map[string]interface{}) {
+			doc["keyAgreement"] = []interface{}{
+				map[string]interface{}{"id": "#key-3", "publicKeyBase58": "JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr"},
+				1,
+			}
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []Violation{
+			{Path: "keyAgreement[0]", Message: "embedded verification method has no type"},
+			{Path: "keyAgreement[0]", Message: "embedded verification method has no controller"},
+			{Path: "keyAgreement[1]", Message: "verification relationship is neither a reference nor a verification method"},
+		}, violations)
+	})
+
+	t.Run("unknown service types", func(t *testing.T) {
+		violations, err := ValidateRules([]byte(validDoc), WithServiceTypes("did-communication", "IdentityHub"))
+		require.NoError(t, err)
+		require.Equal(t, []Violation{
+			{
+				Path:    "service[0]",
+				Message: "unknown service type SocialWebInboxService, known types: IdentityHub, did-communication",
+			},
+		}, violations)
+	})
+
+	t.Run("invalid DID", func(t *testing.T) {
+		violations, err := ValidateRules(updateRulesDoc(t, func(doc map[string]interface{}) {
+			doc["id"] = "example:123"
+		}))
+		require.NoError(t, err)
+		require.NotEmpty(t, violations)
+		require.Equal(t, "id", violations[0].Path)
+		require.Contains(t, violations[0].Message, "invalid DID")
+	})
+
+	t.Run("schema violations", func(t *testing.T) {
+		violations, err := ValidateRules(updateRulesDoc(t, func(doc map[string]interface{}) {
+			delete(doc, "id")
+		}))
+		require.NoError(t, err)
+		require.Contains(t, violations, Violation{Message: "id is required"})
+
+		// the other rules can't be checked on documents not matching the data model
+		violations, err = ValidateRules([]byte(`{"@context": ["https://www.w3.org/ns/did/v1"], "id": 1}`))
+		require.NoError(t, err)
+		require.NotEmpty(t, violations)
+	})
+
+	t.Run("not a JSON document", func(t *testing.T) {
+		violations, err := ValidateRules([]byte("--"))
+		require.EqualError(t, err, "did doc is not a JSON document")
+		require.Nil(t, violations)
+	})
+}
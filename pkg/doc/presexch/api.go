@@ -37,8 +37,22 @@ type PresentationDefinitions struct {
 
 // InputDescriptor input descriptors.
 type InputDescriptor struct {
-	ID     string  `json:"id,omitempty"`
-	Schema *Schema `json:"schema,omitempty"`
+	ID          string       `json:"id,omitempty"`
+	Schema      *Schema      `json:"schema,omitempty"`
+	Constraints *Constraints `json:"constraints,omitempty"`
+}
+
+// Constraints input descriptor constraints.
+type Constraints struct {
+	Fields []*Field `json:"fields,omitempty"`
+}
+
+// Field constrains the value selected in the candidate credential by the first of the JSONPath expressions
+// in `Path` evaluating to a value satisfying `Filter`, the JSON Schema the value must be valid against if set.
+type Field struct {
+	Path    []string               `json:"path,omitempty"`
+	Purpose string                 `json:"purpose,omitempty"`
+	Filter  map[string]interface{} `json:"filter,omitempty"`
 }
 
 // Schema input descriptor schema.
@@ -136,7 +150,14 @@ func (p *PresentationDefinitions) Match(vp *verifiable.Presentation, // nolint:g
 			)
 		}
 
-		// TODO add support for constraints: https://github.com/hyperledger/aries-framework-go/issues/2108
+		satisfied, constraintsErr := inputDescriptor.satisfiedBy(vc)
+		if constraintsErr != nil {
+			return nil, fmt.Errorf("failed to evaluate constraints: %w", constraintsErr)
+		}
+
+		if !satisfied {
+			return nil, fmt.Errorf("vc for input descriptor id [%s] does not satisfy its constraints", inputDescriptor.ID)
+		}
 
 		result[mapping.ID] = vc
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
@@ -365,7 +366,7 @@ func randomURI() string {
 	return fmt.Sprintf("https://my.test.context.jsonld/%s", uuid.New().String())
 }
 
-func jsonldContextLoader(t *testing.T, contextURL string) *ld.CachingDocumentLoader {
+func jsonldContextLoader(t *testing.T, contextURL string) *jsonld.DocumentLoader {
 	const jsonLDContext = `{
     "@context":{
       "@version":1.1,
@@ -13,6 +13,7 @@ import (
 
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
 
@@ -145,7 +146,7 @@ func toExampleMap(v interface{}) map[string]interface{} {
 	return m
 }
 
-func cachedJSONLDContextLoader(ctxURLToVocab map[string]string) *ld.CachingDocumentLoader {
+func cachedJSONLDContextLoader(ctxURLToVocab map[string]string) *jsonld.DocumentLoader {
 	loader := verifiable.CachingJSONLDLoader()
 
 	for contextURL, vocab := range ctxURLToVocab {
@@ -0,0 +1,197 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const verifiableCredentialPathPattern = "$.verifiableCredential[%d]"
+
+// descriptorMatch is a credential satisfying an input descriptor.
+type descriptorMatch struct {
+	descriptorID string
+	credential   int
+}
+
+// Match selects the held credentials satisfying the input descriptors of the definition, that is having the
+// descriptor schema URI in their context and satisfying the descriptor constraints. The returned submission
+// maps each input descriptor to all of its satisfying credentials, their paths pointing to the credentials
+// as embedded in that order in a verifiable presentation: "$.verifiableCredential[i]" is the i-th credential.
+//
+// An error is returned if any of the input descriptors isn't satisfied by the credentials.
+func Match(definition *PresentationDefinitions, credentials []*verifiable.Credential) (*PresentationSubmission, error) {
+	matches, err := match(definition, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	submission := &PresentationSubmission{DescriptorMap: []*InputDescriptorMapping{}}
+
+	for _, m := range matches {
+		submission.DescriptorMap = append(submission.DescriptorMap, &InputDescriptorMapping{
+			ID:   m.descriptorID,
+			Path: fmt.Sprintf(verifiableCredentialPathPattern, m.credential),
+		})
+	}
+
+	return submission, nil
+}
+
+// NewPresentation returns the unsigned presentation submission of the held credentials satisfying the input
+// descriptors of the definition (see Match), the credentials which aren't selected are left out.
+func NewPresentation(definition *PresentationDefinitions,
+	credentials []*verifiable.Credential) (*verifiable.Presentation, error) {
+	matches, err := match(definition, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		selected   []interface{}
+		submission = &PresentationSubmission{}
+		indexes    = make(map[int]int)
+	)
+
+	for _, m := range matches {
+		idx, ok := indexes[m.credential]
+		if !ok {
+			idx = len(selected)
+			indexes[m.credential] = idx
+
+			selected = append(selected, credentials[m.credential])
+		}
+
+		submission.DescriptorMap = append(submission.DescriptorMap, &InputDescriptorMapping{
+			ID:   m.descriptorID,
+			Path: fmt.Sprintf(verifiableCredentialPathPattern, idx),
+		})
+	}
+
+	bits, err := json.Marshal(submission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal presentation submission: %w", err)
+	}
+
+	submissionMap := make(map[string]interface{})
+
+	err = json.Unmarshal(bits, &submissionMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal presentation submission: %w", err)
+	}
+
+	vp := &verifiable.Presentation{
+		Context:      []string{"https://www.w3.org/2018/credentials/v1", PresentationSubmissionJSONLDContext},
+		Type:         []string{"VerifiablePresentation", PresentationSubmissionJSONLDType},
+		CustomFields: map[string]interface{}{submissionProperty: submissionMap},
+	}
+
+	err = vp.SetCredentials(selected...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set credentials: %w", err)
+	}
+
+	return vp, nil
+}
+
+func match(definition *PresentationDefinitions, credentials []*verifiable.Credential) ([]descriptorMatch, error) {
+	var matches []descriptorMatch
+
+	for _, descriptor := range definition.InputDescriptors {
+		satisfied := false
+
+		for i, vc := range credentials {
+			if descriptor.Schema != nil && descriptor.Schema.URI != "" &&
+				!stringsContain(vc.Context, descriptor.Schema.URI) {
+				continue
+			}
+
+			ok, err := descriptor.satisfiedBy(vc)
+			if err != nil {
+				return nil, fmt.Errorf("input descriptor id [%s]: %w", descriptor.ID, err)
+			}
+
+			if !ok {
+				continue
+			}
+
+			satisfied = true
+
+			matches = append(matches, descriptorMatch{descriptorID: descriptor.ID, credential: i})
+		}
+
+		if !satisfied {
+			return nil, fmt.Errorf("no credential satisfies input descriptor %s", descriptor.ID)
+		}
+	}
+
+	return matches, nil
+}
+
+// satisfiedBy tells whether the credential satisfies all of the fields constraints of the input descriptor.
+func (d *InputDescriptor) satisfiedBy(vc *verifiable.Credential) (bool, error) {
+	if d.Constraints == nil || len(d.Constraints.Fields) == 0 {
+		return true, nil
+	}
+
+	vcBits, err := vc.MarshalJSON()
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal vc: %w", err)
+	}
+
+	typelessVC := interface{}(nil)
+
+	err = json.Unmarshal(vcBits, &typelessVC)
+	if err != nil {
+		return false, fmt.Errorf("failed to unmarshal vc: %w", err)
+	}
+
+	for _, field := range d.Constraints.Fields {
+		ok, err := field.satisfiedBy(typelessVC)
+		if err != nil {
+			return false, err
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// satisfiedBy tells whether one of the field paths selects a value of the credential, valid against the filter.
+func (f *Field) satisfiedBy(vc interface{}) (bool, error) {
+	for _, path := range f.Path {
+		// the paths which don't select any value are skipped
+		value, err := jsonpath.Get(path, vc)
+		if err != nil {
+			continue
+		}
+
+		if f.Filter == nil {
+			return true, nil
+		}
+
+		result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(f.Filter), gojsonschema.NewGoLoader(value))
+		if err != nil {
+			return false, fmt.Errorf("failed to validate field [%s] against filter: %w", path, err)
+		}
+
+		if result.Valid() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
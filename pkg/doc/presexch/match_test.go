@@ -0,0 +1,164 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+func TestMatch(t *testing.T) {
+	uri := randomURI()
+
+	adult := newSubjectVC("http://test.credential.com/adult", uri, map[string]interface{}{"age": 32.0})
+	minor := newSubjectVC("http://test.credential.com/minor", uri, map[string]interface{}{"age": 12.0})
+	senior := newSubjectVC("http://test.credential.com/senior", uri, map[string]interface{}{"age": 70.0})
+	other := newSubjectVC("http://test.credential.com/other", randomURI(), map[string]interface{}{"age": 40.0})
+	nameless := newSubjectVC("http://test.credential.com/nameless", uri, map[string]interface{}{})
+
+	adultDescriptor := func() *InputDescriptor {
+		return &InputDescriptor{
+			ID:     uuid.New().String(),
+			Schema: &Schema{URI: uri},
+			Constraints: &Constraints{Fields: []*Field{{
+				Path:   []string{"$.credentialSubject.dob", "$.credentialSubject.age"},
+				Filter: map[string]interface{}{"type": "number", "minimum": 18},
+			}}},
+		}
+	}
+
+	t.Run("field path filters", func(t *testing.T) {
+		defs := &PresentationDefinitions{InputDescriptors: []*InputDescriptor{adultDescriptor()}}
+
+		submission, err := Match(defs, []*verifiable.Credential{minor, other, adult})
+		require.NoError(t, err)
+		require.Equal(t, []*InputDescriptorMapping{{
+			ID:   defs.InputDescriptors[0].ID,
+			Path: "$.verifiableCredential[2]",
+		}}, submission.DescriptorMap)
+	})
+
+	t.Run("multiple satisfying credentials", func(t *testing.T) {
+		defs := &PresentationDefinitions{InputDescriptors: []*InputDescriptor{adultDescriptor(), {
+			ID:     uuid.New().String(),
+			Schema: &Schema{URI: uri},
+		}}}
+
+		submission, err := Match(defs, []*verifiable.Credential{adult, minor, senior})
+		require.NoError(t, err)
+		require.Equal(t, []*InputDescriptorMapping{
+			{ID: defs.InputDescriptors[0].ID, Path: "$.verifiableCredential[0]"},
+			{ID: defs.InputDescriptors[0].ID, Path: "$.verifiableCredential[2]"},
+			{ID: defs.InputDescriptors[1].ID, Path: "$.verifiableCredential[0]"},
+			{ID: defs.InputDescriptors[1].ID, Path: "$.verifiableCredential[1]"},
+			{ID: defs.InputDescriptors[1].ID, Path: "$.verifiableCredential[2]"},
+		}, submission.DescriptorMap)
+	})
+
+	t.Run("error if a required field is missing", func(t *testing.T) {
+		defs := &PresentationDefinitions{InputDescriptors: []*InputDescriptor{{
+			ID:     uuid.New().String(),
+			Schema: &Schema{URI: uri},
+			Constraints: &Constraints{Fields: []*Field{
+				{Path: []string{"$.credentialSubject.age"}},
+				{Path: []string{"$.credentialSubject.name"}},
+			}},
+		}}}
+
+		_, err := Match(defs, []*verifiable.Credential{adult, nameless})
+		require.EqualError(t, err, "no credential satisfies input descriptor "+defs.InputDescriptors[0].ID)
+	})
+
+	t.Run("error if no credential has the schema", func(t *testing.T) {
+		defs := &PresentationDefinitions{InputDescriptors: []*InputDescriptor{{
+			ID:     uuid.New().String(),
+			Schema: &Schema{URI: randomURI()},
+		}}}
+
+		_, err := Match(defs, []*verifiable.Credential{adult, minor})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no credential satisfies input descriptor")
+	})
+
+	t.Run("error if the filter is invalid", func(t *testing.T) {
+		defs := &PresentationDefinitions{InputDescriptors: []*InputDescriptor{{
+			ID: uuid.New().String(),
+			Constraints: &Constraints{Fields: []*Field{{
+				Path:   []string{"$.credentialSubject.age"},
+				Filter: map[string]interface{}{"type": 42},
+			}}},
+		}}}
+
+		_, err := Match(defs, []*verifiable.Credential{adult})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to validate field [$.credentialSubject.age] against filter")
+	})
+}
+
+func TestNewPresentation(t *testing.T) {
+	uri := randomURI()
+
+	adult := newSubjectVC("http://test.credential.com/adult", uri, map[string]interface{}{"age": 32.0})
+	minor := newSubjectVC("http://test.credential.com/minor", uri, map[string]interface{}{"age": 12.0})
+
+	defs := &PresentationDefinitions{InputDescriptors: []*InputDescriptor{{
+		ID:     uuid.New().String(),
+		Schema: &Schema{URI: uri},
+		Constraints: &Constraints{Fields: []*Field{{
+			Path:   []string{"$.credentialSubject.age"},
+			Filter: map[string]interface{}{"type": "number", "minimum": 18},
+		}}},
+	}}}
+
+	t.Run("selected credentials only", func(t *testing.T) {
+		vp, err := NewPresentation(defs, []*verifiable.Credential{minor, adult})
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		// the verifier matches the submission against their definitions
+		receivedVP, err := verifiable.ParseUnverifiedPresentation(marshal(t, vp))
+		require.NoError(t, err)
+
+		matched, err := defs.Match(receivedVP, WithJSONLDDocumentLoader(jsonldContextLoader(t, uri)))
+		require.NoError(t, err)
+		require.Len(t, matched, 1)
+		require.Equal(t, adult.ID, matched[defs.InputDescriptors[0].ID].ID)
+	})
+
+	t.Run("verifier rejects credentials not satisfying the constraints", func(t *testing.T) {
+		vp := newVP(t,
+			&PresentationSubmission{DescriptorMap: []*InputDescriptorMapping{{
+				ID:   defs.InputDescriptors[0].ID,
+				Path: "$.verifiableCredential[0]",
+			}}},
+			minor,
+		)
+
+		_, err := defs.Match(vp, WithJSONLDDocumentLoader(jsonldContextLoader(t, uri)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not satisfy its constraints")
+	})
+
+	t.Run("error if no credential satisfies the definition", func(t *testing.T) {
+		_, err := NewPresentation(defs, []*verifiable.Credential{minor})
+		require.Error(t, err)
+	})
+}
+
+func newSubjectVC(id, context string, subject map[string]interface{}) *verifiable.Credential {
+	vc := newVC([]string{context})
+	vc.ID = id
+
+	subject["id"] = uuid.New().String()
+	vc.Subject = subject
+
+	return vc
+}
@@ -0,0 +1,152 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonld
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/piprate/json-gold/ld"
+)
+
+// ErrContextNotCached is returned when a context which isn't cached is loaded while the remote document loader
+// is disabled.
+var ErrContextNotCached = errors.New("JSON-LD context is not cached")
+
+// ContextDocument is a JSON-LD context served by the DocumentLoader under its URL.
+type ContextDocument struct {
+	URL     string
+	Content []byte
+}
+
+// embeddedContexts returns the contexts embedded in the DocumentLoader: the VC, DID, security and BBS+ contexts, the
+// VC examples context (along with the ODRL context it imports) and the TrustBloc VC contexts (JsonWebSignature2020
+// and examples).
+func embeddedContexts() []ContextDocument {
+	return []ContextDocument{
+		{URL: "https://www.w3.org/2018/credentials/v1", Content: []byte(credentialsV1Context)},
+		{URL: "https://www.w3.org/2018/credentials/examples/v1", Content: []byte(credentialsExamplesV1Context)},
+		{URL: "https://www.w3.org/ns/odrl.jsonld", Content: []byte(odrlContext)},
+		{URL: "https://w3id.org/did/v0.11", Content: []byte(didV011Context)},
+		{URL: "https://w3id.org/did/v1", Content: []byte(didV1Context)},
+		{URL: "https://www.w3.org/ns/did/v1", Content: []byte(didV1Context)},
+		{URL: "https://w3id.org/security/v1", Content: []byte(securityV1Context)},
+		{URL: "https://w3id.org/security/v2", Content: []byte(securityV2Context)},
+		{URL: "https://w3id.org/security/bbs/v1", Content: []byte(bbsV1Context)},
+		{URL: "https://trustbloc.github.io/context/vc/credentials-v1.jsonld", Content: []byte(trustblocCredentialsV1Context)},
+		{URL: "https://trustbloc.github.io/context/vc/examples-v1.jsonld", Content: []byte(trustblocExamplesV1Context)},
+	}
+}
+
+// documentLoaderOpts holds the options of the DocumentLoader.
+type documentLoaderOpts struct {
+	contexts       []ContextDocument
+	remoteLoader   ld.DocumentLoader
+	remoteDisabled bool
+}
+
+// DocumentLoaderOpts are the options of the DocumentLoader.
+type DocumentLoaderOpts func(opts *documentLoaderOpts)
+
+// WithCachedContexts option is for caching the given contexts in addition to the embedded ones, overriding them
+// if under the same URL.
+func WithCachedContexts(contexts ...ContextDocument) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.contexts = append(opts.contexts, contexts...)
+	}
+}
+
+// WithRemoteDocumentLoader option is for loading the contexts which aren't cached with the given loader, an RFC7324
+// caching document loader is used by default.
+func WithRemoteDocumentLoader(loader ld.DocumentLoader) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.remoteLoader = loader
+	}
+}
+
+// WithRemoteDocumentLoaderDisabled option is for never fetching the contexts, eg. in air-gapped deployments: loading
+// a context which isn't cached fails with ErrContextNotCached.
+func WithRemoteDocumentLoaderDisabled() DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.remoteDisabled = true
+	}
+}
+
+// DocumentLoader is a JSON-LD document loader serving the embedded and cached contexts locally, the other ones
+// are loaded by the remote document loader (unless disabled) and cached.
+type DocumentLoader struct {
+	cache        map[string]*ld.RemoteDocument
+	remoteLoader ld.DocumentLoader
+	lock         sync.RWMutex
+}
+
+// NewDocumentLoader returns a new DocumentLoader, with the VC, DID and security contexts embedded.
+func NewDocumentLoader(opts ...DocumentLoaderOpts) (*DocumentLoader, error) {
+	loaderOpts := &documentLoaderOpts{}
+
+	for _, opt := range opts {
+		opt(loaderOpts)
+	}
+
+	loader := &DocumentLoader{cache: make(map[string]*ld.RemoteDocument)}
+
+	if !loaderOpts.remoteDisabled {
+		loader.remoteLoader = loaderOpts.remoteLoader
+
+		if loader.remoteLoader == nil {
+			loader.remoteLoader = ld.NewRFC7324CachingDocumentLoader(&http.Client{})
+		}
+	}
+
+	for _, context := range append(embeddedContexts(), loaderOpts.contexts...) {
+		document, err := ld.DocumentFromReader(bytes.NewReader(context.Content))
+		if err != nil {
+			return nil, fmt.Errorf("parse JSON-LD context %s: %w", context.URL, err)
+		}
+
+		loader.AddDocument(context.URL, document)
+	}
+
+	return loader, nil
+}
+
+// LoadDocument returns the cached document of u, loading it with the remote document loader if not cached.
+func (l *DocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	l.lock.RLock()
+	document, ok := l.cache[u]
+	l.lock.RUnlock()
+
+	if ok {
+		return document, nil
+	}
+
+	if l.remoteLoader == nil {
+		return nil, fmt.Errorf("load %s: %w", u, ErrContextNotCached)
+	}
+
+	document, err := l.remoteLoader.LoadDocument(u)
+	if err != nil {
+		return nil, err
+	}
+
+	l.lock.Lock()
+	l.cache[u] = document
+	l.lock.Unlock()
+
+	return document, nil
+}
+
+// AddDocument caches the parsed document doc under u.
+func (l *DocumentLoader) AddDocument(u string, doc interface{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.cache[u] = &ld.RemoteDocument{DocumentURL: u, Document: doc}
+}
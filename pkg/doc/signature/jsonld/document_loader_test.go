@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonld
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/piprate/json-gold/ld"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleContextURL = "https://example.org/examples/v1"
+
+type mockDocumentLoader struct {
+	loaded []string
+	err    error
+}
+
+func (m *mockDocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	m.loaded = append(m.loaded, u)
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return &ld.RemoteDocument{DocumentURL: u, Document: map[string]interface{}{"@context": map[string]interface{}{}}}, nil
+}
+
+func TestDocumentLoader(t *testing.T) {
+	t.Run("embedded contexts are served locally", func(t *testing.T) {
+		remote := &mockDocumentLoader{}
+
+		loader, err := NewDocumentLoader(WithRemoteDocumentLoader(remote))
+		require.NoError(t, err)
+
+		for _, context := range embeddedContexts() {
+			document, err := loader.LoadDocument(context.URL)
+			require.NoError(t, err)
+			require.Equal(t, context.URL, document.DocumentURL)
+			require.Contains(t, document.Document, "@context")
+		}
+
+		require.Empty(t, remote.loaded)
+	})
+
+	t.Run("uncached contexts are loaded remotely once", func(t *testing.T) {
+		remote := &mockDocumentLoader{}
+
+		loader, err := NewDocumentLoader(WithRemoteDocumentLoader(remote))
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			document, err := loader.LoadDocument(exampleContextURL)
+			require.NoError(t, err)
+			require.Equal(t, exampleContextURL, document.DocumentURL)
+		}
+
+		require.Equal(t, []string{exampleContextURL}, remote.loaded)
+
+		remote.err = errors.New("connection refused")
+
+		_, err = loader.LoadDocument("https://example.com/context/v1")
+		require.EqualError(t, err, "connection refused")
+	})
+
+	t.Run("remote document loader disabled", func(t *testing.T) {
+		loader, err := NewDocumentLoader(WithRemoteDocumentLoaderDisabled(),
+			WithRemoteDocumentLoader(&mockDocumentLoader{}))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument("https://w3id.org/security/v2")
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument(exampleContextURL)
+		require.True(t, errors.Is(err, ErrContextNotCached))
+		require.EqualError(t, err, "load "+exampleContextURL+": "+ErrContextNotCached.Error())
+
+		loader.AddDocument(exampleContextURL, map[string]interface{}{"@context": map[string]interface{}{}})
+
+		_, err = loader.LoadDocument(exampleContextURL)
+		require.NoError(t, err)
+	})
+
+	t.Run("cached contexts", func(t *testing.T) {
+		loader, err := NewDocumentLoader(WithRemoteDocumentLoaderDisabled(), WithCachedContexts(ContextDocument{
+			URL:     exampleContextURL,
+			Content: []byte(`{"@context": {"ex": "https://example.org/examples#"}}`),
+		}))
+		require.NoError(t, err)
+
+		document, err := loader.LoadDocument(exampleContextURL)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"@context": map[string]interface{}{"ex": "https://example.org/examples#"},
+		}, document.Document)
+
+		_, err = NewDocumentLoader(WithCachedContexts(ContextDocument{URL: exampleContextURL, Content: []byte("{")}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse JSON-LD context "+exampleContextURL)
+	})
+
+	t.Run("canonicalization with the remote document loader disabled", func(t *testing.T) {
+		loader, err := NewDocumentLoader(WithRemoteDocumentLoaderDisabled())
+		require.NoError(t, err)
+
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"@context": ["https://www.w3.org/2018/credentials/v1"],
+			"type": ["VerifiableCredential"],
+			"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+			"issuanceDate": "2010-01-01T19:23:24Z",
+			"credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}
+		}`), &doc))
+
+		canonical, err := Default().GetCanonicalDocument(doc, WithDocumentLoader(loader), WithValidateRDF())
+		require.NoError(t, err)
+		require.NotEmpty(t, canonical)
+	})
+}
@@ -9,6 +9,7 @@ package jsonld
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/piprate/json-gold/ld"
@@ -31,6 +32,7 @@ var ErrInvalidRDFFound = errors.New("invalid JSON-LD context")
 type normalizeOpts struct {
 	removeInvalidRDF bool
 	validateRDF      bool
+	frameBlankNodes  bool
 	documentLoader   ld.DocumentLoader
 	externalContexts []string
 }
@@ -67,6 +69,14 @@ func WithValidateRDF() ProcessorOpts {
 	}
 }
 
+// WithFrameBlankNodes option is for framing the blank nodes of the document as nodes identified by IRIs, the
+// document is canonicalized first and a blank node such as _:c14n0 is framed as the node <urn:bnid:_:c14n0>.
+func WithFrameBlankNodes() ProcessorOpts {
+	return func(opts *normalizeOpts) {
+		opts.frameBlankNodes = true
+	}
+}
+
 // Processor is JSON-LD processor for aries.
 // processing mode JSON-LD 1.0 {RFC: https://www.w3.org/TR/2014/REC-json-ld-20140116}
 type Processor struct {
@@ -172,6 +182,65 @@ func (p *Processor) Compact(input, context map[string]interface{},
 	return proc.Compact(input, context, options)
 }
 
+// Frame frames given json ld object with the frame document: the result holds the nodes and properties matching
+// the frame, compacted with the context of the frame.
+func (p *Processor) Frame(input, frameDoc map[string]interface{},
+	opts ...ProcessorOpts) (map[string]interface{}, error) {
+	proc := ld.NewJsonLdProcessor()
+	options := ld.NewJsonLdOptions("")
+	options.ProcessingMode = ld.JsonLd_1_1
+	options.Format = format
+	options.OmitGraph = true
+
+	procOptions := prepareOpts(opts)
+
+	if procOptions.documentLoader != nil {
+		options.DocumentLoader = procOptions.documentLoader
+	}
+
+	var frameInput interface{} = input
+
+	if procOptions.frameBlankNodes {
+		canonicalDoc, err := p.GetCanonicalDocument(input, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		frameInput, err = proc.FromRDF(BlankNodesToIRIs(string(canonicalDoc)), options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to frame blank nodes: %w", err)
+		}
+	}
+
+	framedDoc, err := proc.Frame(frameInput, frameDoc, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to frame JSON-LD document: %w", err)
+	}
+
+	// the framed document is compacted with the context of the frame, which is processed into a single context
+	// losing its type-scoped contexts
+	framedDoc["@context"] = frameDoc["@context"]
+
+	return framedDoc, nil
+}
+
+// nolint:gochecknoglobals
+var (
+	blankNodeRegexp    = regexp.MustCompile(`(^|\s)(_:c14n[0-9]+)`)
+	blankNodeIRIRegexp = regexp.MustCompile(`<urn:bnid:(_:c14n[0-9]+)>`)
+)
+
+// BlankNodesToIRIs replaces the canonical blank node identifiers of the N-Quads statements by IRIs, eg. _:c14n0 by
+// <urn:bnid:_:c14n0>.
+func BlankNodesToIRIs(statements string) string {
+	return blankNodeRegexp.ReplaceAllString(statements, "$1<urn:bnid:$2>")
+}
+
+// IRIsToBlankNodes restores the canonical blank node identifiers replaced by BlankNodesToIRIs.
+func IRIsToBlankNodes(statements string) string {
+	return blankNodeIRIRegexp.ReplaceAllString(statements, "$1")
+}
+
 // removeMatchingInvalidRDFs validates normalized view to find any invalid RDF and
 // returns filtered view after removing all invalid data except the ones given in rdfMatches argument.
 // [Note : handling invalid RDF data, by following pattern https://github.com/digitalbazaar/jsonld.js/issues/199]
@@ -198,6 +198,76 @@ func TestCompact(t *testing.T) {
 	})
 }
 
+func TestFrame(t *testing.T) {
+	doc := map[string]interface{}{
+		"@context": map[string]interface{}{
+			"ex":    "http://example.org/vocab#",
+			"name":  "ex:name",
+			"age":   "ex:age",
+			"child": "ex:child",
+		},
+		"@id":  "http://example.org/john",
+		"name": "John",
+		"age":  "42",
+		"child": map[string]interface{}{
+			"name": "Jane",
+			"age":  "7",
+		},
+	}
+
+	frame := map[string]interface{}{
+		"@context":  doc["@context"],
+		"@explicit": true,
+		"name":      map[string]interface{}{},
+		"child": map[string]interface{}{
+			"@explicit": true,
+			"age":       map[string]interface{}{},
+		},
+	}
+
+	t.Run("frame document", func(t *testing.T) {
+		framedDoc, err := Default().Frame(doc, frame)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"@context": doc["@context"],
+			"@id":      "http://example.org/john",
+			"name":     "John",
+			"child":    map[string]interface{}{"age": "7"},
+		}, framedDoc)
+	})
+
+	t.Run("frame blank nodes", func(t *testing.T) {
+		framedDoc, err := Default().Frame(doc, frame, WithFrameBlankNodes())
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"@id": "urn:bnid:_:c14n0",
+			"age": "7",
+		}, framedDoc["child"])
+	})
+
+	t.Run("invalid frame", func(t *testing.T) {
+		_, err := Default().Frame(doc, map[string]interface{}{"@context": "invalid"},
+			WithDocumentLoader(createInMemoryDocumentLoader("http://example.org/context", "{}")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to frame JSON-LD document")
+	})
+}
+
+func TestBlankNodesToIRIs(t *testing.T) {
+	statements := `<http://example.org/john> <http://example.org/vocab#child> _:c14n0 .
+_:c14n0 <http://example.org/vocab#name> "Jane" .
+_:c14n1 <http://example.org/vocab#sibling> _:c14n10 _:c14n2 .
+`
+
+	iriStatements := BlankNodesToIRIs(statements)
+	require.Equal(t, `<http://example.org/john> <http://example.org/vocab#child> <urn:bnid:_:c14n0> .
+<urn:bnid:_:c14n0> <http://example.org/vocab#name> "Jane" .
+<urn:bnid:_:c14n1> <http://example.org/vocab#sibling> <urn:bnid:_:c14n10> <urn:bnid:_:c14n2> .
+`, iriStatements)
+
+	require.Equal(t, statements, IRIsToBlankNodes(iriStatements))
+}
+
 func createInMemoryDocumentLoader(url, inMemoryContext string) *ld.CachingDocumentLoader {
 	loader := ld.NewCachingDocumentLoader(ld.NewRFC7324CachingDocumentLoader(&http.Client{}))
 
@@ -12,7 +12,13 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 )
 
-const jsonldContext = "@context"
+const (
+	jsonldContext = "@context"
+
+	// bbsBlsSignatureProof2020 is the type of the proofs derived from BBS+ signatures: their nonce is chosen when
+	// deriving the proof, it is not part of the proof options signed by the BBS+ signature.
+	bbsBlsSignatureProof2020 = "BbsBlsSignatureProof2020"
+)
 
 // signatureSuite encapsulates signature suite methods required for normalizing document.
 type signatureSuite interface {
@@ -46,7 +52,13 @@ func CreateVerifyData(suite signatureSuite, jsonldDoc map[string]interface{}, pr
 	opts ...jsonld.ProcessorOpts) ([]byte, error) {
 	switch proof.SignatureRepresentation {
 	case SignatureProofValue:
-		return CreateVerifyHash(suite, jsonldDoc, proof.JSONLdObject(), opts...)
+		proofOptions := proof.JSONLdObject()
+
+		if proof.Type == bbsBlsSignatureProof2020 {
+			delete(proofOptions, jsonldNonce)
+		}
+
+		return CreateVerifyHash(suite, jsonldDoc, proofOptions, opts...)
 	case SignatureJWS:
 		return createVerifyJWS(suite, jsonldDoc, proof, opts...)
 	}
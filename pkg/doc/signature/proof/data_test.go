@@ -107,6 +107,36 @@ func TestCreateVerifyData(t *testing.T) {
 	require.Nil(t, signature)
 }
 
+func TestCreateVerifyData_BBSSignatureProof(t *testing.T) {
+	created, err := time.Parse(time.RFC3339, "2018-03-15T00:00:00Z")
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	err = json.Unmarshal([]byte(validDoc), &doc)
+	require.NoError(t, err)
+
+	loader, err := jsonld.NewDocumentLoader()
+	require.NoError(t, err)
+
+	p := &Proof{
+		Type:                    "BbsBlsSignatureProof2020",
+		Created:                 util.NewTime(created),
+		Creator:                 "key1",
+		Nonce:                   []byte("nonce"),
+		SignatureRepresentation: SignatureProofValue,
+	}
+
+	verifyData, err := CreateVerifyData(&mockSignatureSuite{}, doc, p, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	// the nonce of the proofs derived from BBS+ signatures is not signed
+	p.Nonce = nil
+
+	verifyDataWithoutNonce, err := CreateVerifyData(&mockSignatureSuite{}, doc, p, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+	require.Equal(t, verifyDataWithoutNonce, verifyData)
+}
+
 type mockSignatureSuite struct {
 	compactProof bool
 }
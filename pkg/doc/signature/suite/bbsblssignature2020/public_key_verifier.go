@@ -0,0 +1,21 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignature2020
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// G2PublicKeyType is the type of the BLS12-381 G2 public keys verifying BBS+ signatures.
+const G2PublicKeyType = "Bls12381G2Key2020"
+
+// NewG2PublicKeyVerifier creates a signature verifier that verifies a BBS+ signature
+// taking Bls12381G2Key2020 public key bytes as input.
+func NewG2PublicKeyVerifier() *verifier.PublicKeyVerifier {
+	return verifier.NewPublicKeyVerifier(verifier.NewBBSG2SignatureVerifier(),
+		verifier.WithExactPublicKeyType(G2PublicKeyType))
+}
@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignature2020
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+func TestPublicKeyVerifier_Verify(t *testing.T) {
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	msg := []byte("statement 1\nstatement 2\n")
+
+	msgSig, err := bbs12381g2pub.New().Sign(verifier.SplitStatements(msg), privKeyBytes)
+	require.NoError(t, err)
+
+	v := NewG2PublicKeyVerifier()
+
+	err = v.Verify(&verifier.PublicKey{
+		Type:  "Bls12381G2Key2020",
+		Value: pubKeyBytes,
+	}, msg, msgSig)
+	require.NoError(t, err)
+
+	err = v.Verify(&verifier.PublicKey{
+		Type:  "Ed25519VerificationKey2018",
+		Value: pubKeyBytes,
+	}, msg, msgSig)
+	require.EqualError(t, err, "a type of public key is not 'Bls12381G2Key2020'")
+}
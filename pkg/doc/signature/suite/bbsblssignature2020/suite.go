@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bbsblssignature2020 implements the BbsBlsSignature2020 signature suite
+// for the Linked Data Signatures specification (https://w3c-ccg.github.io/ldp-bbs2020).
+// It uses the RDF Dataset Normalization Algorithm
+// to transform the input document into its canonical form.
+// It signs each N-Quads statement of the canonical form as a message of a BBS+ signature
+// over the BLS12-381 curve, so that a proof revealing some statements only can be derived from it
+// (see the bbsblssignatureproof2020 package).
+package bbsblssignature2020
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+)
+
+// Suite implements BbsBlsSignature2020 signature suite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+}
+
+const (
+	// SignatureType is the signature type of BBS+ signatures.
+	SignatureType = "BbsBlsSignature2020"
+	rdfDataSetAlg = "URDNA2015"
+)
+
+// New an instance of BbsBlsSignature2020 signature suite.
+func New(opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document
+// BbsBlsSignature2020 signature SignatureSuite uses RDF Dataset Normalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns the document itself: its N-Quads statements are the messages of the signature.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	return doc
+}
+
+// Accept will accept only BbsBlsSignature2020 signature type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
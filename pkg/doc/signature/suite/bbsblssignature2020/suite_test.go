@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignature2020
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{
+			"name": "http://schema.org/name",
+		},
+		"@id":  "did:example:123",
+		"name": "Jayden Doe",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<did:example:123> <http://schema.org/name> \"Jayden Doe\" .\n", string(doc))
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	// the statements are signed as they are
+	digest := New().GetDigest([]byte("test doc"))
+	require.Equal(t, []byte("test doc"), digest)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	accepted := ss.Accept("BbsBlsSignature2020")
+	require.True(t, accepted)
+
+	accepted = ss.Accept("BbsBlsSignatureProof2020")
+	require.False(t, accepted)
+}
@@ -0,0 +1,19 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignatureproof2020
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// NewG2PublicKeyVerifier creates a signature verifier that verifies a proof derived from a BBS+ signature for the
+// nonce taking Bls12381G2Key2020 public key bytes as input.
+func NewG2PublicKeyVerifier(nonce []byte) *verifier.PublicKeyVerifier {
+	return verifier.NewPublicKeyVerifier(verifier.NewBBSG2SignatureProofVerifier(nonce),
+		verifier.WithExactPublicKeyType(bbsblssignature2020.G2PublicKeyType))
+}
@@ -0,0 +1,196 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignatureproof2020
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// keyResolver encapsulates key resolution.
+type keyResolver interface {
+
+	// Resolve will return public key bytes and the type of public key
+	Resolve(id string) (*verifier.PublicKey, error)
+}
+
+// SelectiveDisclosure derives from the BbsBlsSignature2020 proofs of the document the BbsBlsSignatureProof2020 proofs
+// of the document framed with revealDoc, bound to the nonce. It returns the framed document with the derived proofs:
+// it reveals the nodes and properties of the document selected by the frame only.
+func (s *Suite) SelectiveDisclosure(doc, revealDoc map[string]interface{}, nonce []byte, resolver keyResolver,
+	opts ...jsonld.ProcessorOpts) (map[string]interface{}, error) {
+	signatures, err := getBBSSignatures(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	docWithoutProof := proof.GetCopyWithoutProof(doc)
+
+	revealedDoc, err := s.jsonldProcessor.Frame(docWithoutProof, revealDoc,
+		append(opts, jsonld.WithFrameBlankNodes())...)
+	if err != nil {
+		return nil, fmt.Errorf("frame document with reveal document: %w", err)
+	}
+
+	docStatements, err := s.canonicalStatements(docWithoutProof, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	revealedStatements, err := s.canonicalStatements(revealedDoc, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	revealedIndexes, err := statementIndexes(revealedStatements, docStatements)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &disclosure{
+		doc:                docWithoutProof,
+		docStatementsCount: len(docStatements),
+		revealedIndexes:    revealedIndexes,
+	}
+
+	for _, signature := range signatures {
+		derivedProof, e := d.deriveProof(signature, nonce, resolver, opts)
+		if e != nil {
+			return nil, e
+		}
+
+		err = proof.AddProof(revealedDoc, derivedProof)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return revealedDoc, nil
+}
+
+func getBBSSignatures(doc map[string]interface{}) ([]*proof.Proof, error) {
+	proofs, err := proof.GetProofs(doc)
+	if err != nil {
+		return nil, fmt.Errorf("get document proofs: %w", err)
+	}
+
+	var signatures []*proof.Proof
+
+	for _, p := range proofs {
+		if p.Type == bbsblssignature2020.SignatureType {
+			signatures = append(signatures, p)
+		}
+	}
+
+	if len(signatures) == 0 {
+		return nil, errors.New("document has no BbsBlsSignature2020 proof")
+	}
+
+	return signatures, nil
+}
+
+// disclosure holds the document without proof and the indexes of its revealed statements.
+type disclosure struct {
+	doc                map[string]interface{}
+	docStatementsCount int
+	revealedIndexes    []int
+}
+
+// deriveProof derives the proof revealing the statements of the document at revealedIndexes (and all the statements
+// of the proof options) from the BBS+ signature of the document.
+func (d *disclosure) deriveProof(signature *proof.Proof, nonce []byte, resolver keyResolver,
+	opts []jsonld.ProcessorOpts) (*proof.Proof, error) {
+	if len(signature.ProofValue) == 0 {
+		return nil, errors.New("BBS+ signature is not defined in proofValue")
+	}
+
+	// the signed statements are the statements of the proof options followed by the statements of the document
+	verifyData, err := proof.CreateVerifyHash(bbsblssignature2020.New(), d.doc, signature.JSONLdObject(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create verify data of BBS+ signature: %w", err)
+	}
+
+	messages := verifier.SplitStatements(verifyData)
+	proofStatementsCount := len(messages) - d.docStatementsCount
+
+	indexes := make([]int, 0, proofStatementsCount+len(d.revealedIndexes))
+
+	for i := 0; i < proofStatementsCount; i++ {
+		indexes = append(indexes, i)
+	}
+
+	for _, i := range d.revealedIndexes {
+		indexes = append(indexes, proofStatementsCount+i)
+	}
+
+	publicKeyID, err := signature.PublicKeyID()
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := resolver.Resolve(publicKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve public key of BBS+ signature: %w", err)
+	}
+
+	signatureProof, err := bbs12381g2pub.New().DeriveProof(messages, signature.ProofValue, nonce, publicKey.Value,
+		indexes)
+	if err != nil {
+		return nil, fmt.Errorf("derive BBS+ signature proof: %w", err)
+	}
+
+	derivedProof := *signature
+	derivedProof.Type = SignatureProofType
+	derivedProof.ProofValue = signatureProof
+	derivedProof.Nonce = nonce
+
+	return &derivedProof, nil
+}
+
+func (s *Suite) canonicalStatements(doc map[string]interface{}, opts []jsonld.ProcessorOpts) ([]string, error) {
+	canonicalDoc, err := s.GetCanonicalDocument(doc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := verifier.SplitStatements(canonicalDoc)
+	statements := make([]string, len(messages))
+
+	for i := range messages {
+		statements[i] = string(messages[i])
+	}
+
+	return statements, nil
+}
+
+// statementIndexes returns the indexes of the revealed statements in the statements of the document.
+func statementIndexes(revealedStatements, docStatements []string) ([]int, error) {
+	docIndexes := make(map[string]int, len(docStatements))
+
+	for i, statement := range docStatements {
+		docIndexes[statement] = i
+	}
+
+	indexes := make([]int, len(revealedStatements))
+
+	for i, statement := range revealedStatements {
+		index, ok := docIndexes[statement]
+		if !ok {
+			return nil, fmt.Errorf("revealed statement is not in the signed document: %s", statement)
+		}
+
+		indexes[i] = index
+	}
+
+	return indexes, nil
+}
@@ -0,0 +1,237 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignatureproof2020
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const keyID = "did:example:489398593#key-1"
+
+func TestSuite_SelectiveDisclosure(t *testing.T) {
+	pubKey, privKey := generateKeyPair(t)
+	resolver := &testKeyResolver{publicKey: pubKey}
+
+	loader, err := jsonld.NewDocumentLoader()
+	require.NoError(t, err)
+
+	signedDoc := signDocument(t, privKey, loader)
+
+	nonce := []byte("nonce")
+
+	revealedDoc, err := New().SelectiveDisclosure(signedDoc, toMap(t, revealDocument), nonce, resolver,
+		jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	subject, ok := revealedDoc["credentialSubject"].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "Jayden Doe", subject["givenName"])
+	require.NotContains(t, subject, "familyName")
+	require.NotContains(t, subject, "birthDate")
+
+	proofs, ok := revealedDoc["proof"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, proofs, 1)
+
+	derivedProof, ok := proofs[0].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, SignatureProofType, derivedProof["type"])
+	require.Equal(t, keyID, derivedProof["verificationMethod"])
+
+	revealedDocBytes, err := json.Marshal(revealedDoc)
+	require.NoError(t, err)
+
+	t.Run("verify derived proof", func(t *testing.T) {
+		v, err := verifier.New(resolver, New(suite.WithVerifier(NewG2PublicKeyVerifier(nonce))))
+		require.NoError(t, err)
+
+		require.NoError(t, v.Verify(revealedDocBytes, jsonld.WithDocumentLoader(loader)))
+	})
+
+	t.Run("derived proof bound to the nonce", func(t *testing.T) {
+		v, err := verifier.New(resolver, New(suite.WithVerifier(NewG2PublicKeyVerifier([]byte("other nonce")))))
+		require.NoError(t, err)
+
+		err = v.Verify(revealedDocBytes, jsonld.WithDocumentLoader(loader))
+		require.EqualError(t, err, "bbs: invalid proof of knowledge of the signature")
+	})
+
+	t.Run("tampered revealed document", func(t *testing.T) {
+		tamperedDoc := toMap(t, string(revealedDocBytes))
+		tamperedDoc["credentialSubject"].(map[string]interface{})["givenName"] = "Alice"
+
+		tamperedDocBytes, err := json.Marshal(tamperedDoc)
+		require.NoError(t, err)
+
+		v, err := verifier.New(resolver, New(suite.WithVerifier(NewG2PublicKeyVerifier(nonce))))
+		require.NoError(t, err)
+
+		err = v.Verify(tamperedDocBytes, jsonld.WithDocumentLoader(loader))
+		require.EqualError(t, err, "bbs: invalid proof of knowledge of the signature")
+	})
+
+	t.Run("reveal the whole document", func(t *testing.T) {
+		fullDoc, err := New().SelectiveDisclosure(signedDoc, map[string]interface{}{
+			"@context": toMap(t, revealDocument)["@context"],
+			"type":     []interface{}{"VerifiableCredential", "PermanentResidentCard"},
+		}, nonce, resolver, jsonld.WithDocumentLoader(loader))
+		require.NoError(t, err)
+
+		subject, ok := fullDoc["credentialSubject"].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, "Doe", subject["familyName"])
+		require.Equal(t, "1958-07-17", subject["birthDate"])
+
+		fullDocBytes, err := json.Marshal(fullDoc)
+		require.NoError(t, err)
+
+		v, err := verifier.New(resolver, New(suite.WithVerifier(NewG2PublicKeyVerifier(nonce))))
+		require.NoError(t, err)
+
+		require.NoError(t, v.Verify(fullDocBytes, jsonld.WithDocumentLoader(loader)))
+	})
+
+	t.Run("no BBS+ signature", func(t *testing.T) {
+		_, err := New().SelectiveDisclosure(toMap(t, document), toMap(t, revealDocument), nonce, resolver,
+			jsonld.WithDocumentLoader(loader))
+		require.EqualError(t, err, "get document proofs: proof not found")
+
+		docWithOtherProof := toMap(t, document)
+		docWithOtherProof["proof"] = map[string]interface{}{
+			"type":       "Ed25519Signature2018",
+			"created":    "2020-01-01T00:00:00Z",
+			"proofValue": "c2lnbmF0dXJl",
+		}
+
+		_, err = New().SelectiveDisclosure(docWithOtherProof, toMap(t, revealDocument), nonce, resolver,
+			jsonld.WithDocumentLoader(loader))
+		require.EqualError(t, err, "document has no BbsBlsSignature2020 proof")
+	})
+
+	t.Run("unknown public key", func(t *testing.T) {
+		_, err := New().SelectiveDisclosure(signedDoc, toMap(t, revealDocument), nonce,
+			&testKeyResolver{err: errors.New("key not found")}, jsonld.WithDocumentLoader(loader))
+		require.EqualError(t, err, "resolve public key of BBS+ signature: key not found")
+
+		otherPubKey, _ := generateKeyPair(t)
+
+		_, err = New().SelectiveDisclosure(signedDoc, toMap(t, revealDocument), nonce,
+			&testKeyResolver{publicKey: otherPubKey}, jsonld.WithDocumentLoader(loader))
+		require.EqualError(t, err, "derive BBS+ signature proof: invalid BBS+ signature")
+	})
+}
+
+func signDocument(t *testing.T, privKey []byte, loader *jsonld.DocumentLoader) map[string]interface{} {
+	t.Helper()
+
+	s := signer.New(bbsblssignature2020.New(suite.WithSigner(&testSigner{privKey: privKey})))
+
+	signedDocBytes, err := s.Sign(&signer.Context{
+		SignatureType:      bbsblssignature2020.SignatureType,
+		VerificationMethod: keyID,
+	}, []byte(document), jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	return toMap(t, string(signedDocBytes))
+}
+
+func generateKeyPair(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	return pubKeyBytes, privKeyBytes
+}
+
+func toMap(t *testing.T, doc string) map[string]interface{} {
+	t.Helper()
+
+	var docMap map[string]interface{}
+
+	require.NoError(t, json.Unmarshal([]byte(doc), &docMap))
+
+	return docMap
+}
+
+type testSigner struct {
+	privKey []byte
+}
+
+func (s *testSigner) Sign(data []byte) ([]byte, error) {
+	return bbs12381g2pub.New().Sign(verifier.SplitStatements(data), s.privKey)
+}
+
+type testKeyResolver struct {
+	publicKey []byte
+	err       error
+}
+
+func (r *testKeyResolver) Resolve(string) (*verifier.PublicKey, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &verifier.PublicKey{Type: bbsblssignature2020.G2PublicKeyType, Value: r.publicKey}, nil
+}
+
+const testContext = `
+    "https://www.w3.org/2018/credentials/v1",
+    "https://w3id.org/security/bbs/v1",
+    {
+      "givenName": "http://schema.org/givenName",
+      "familyName": "http://schema.org/familyName",
+      "birthDate": "http://schema.org/birthDate",
+      "PermanentResidentCard": "https://w3id.org/citizenship#PermanentResidentCard",
+      "PermanentResident": "https://w3id.org/citizenship#PermanentResident"
+    }
+`
+
+const document = `{
+  "@context": [` + testContext + `],
+  "id": "https://issuer.oidp.uscis.gov/credentials/83627465",
+  "type": ["VerifiableCredential", "PermanentResidentCard"],
+  "issuer": "did:example:489398593",
+  "issuanceDate": "2019-12-03T12:19:52Z",
+  "credentialSubject": {
+    "type": ["PermanentResident"],
+    "givenName": "Jayden Doe",
+    "familyName": "Doe",
+    "birthDate": "1958-07-17"
+  }
+}`
+
+const revealDocument = `{
+  "@context": [` + testContext + `],
+  "type": ["VerifiableCredential", "PermanentResidentCard"],
+  "@explicit": true,
+  "issuer": {},
+  "issuanceDate": {},
+  "credentialSubject": {
+    "type": ["PermanentResident"],
+    "@explicit": true,
+    "givenName": {}
+  }
+}`
@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bbsblssignatureproof2020 implements the BbsBlsSignatureProof2020 signature suite
+// for the Linked Data Signatures specification (https://w3c-ccg.github.io/ldp-bbs2020).
+// Its proofs are derived from the BbsBlsSignature2020 signatures of a document and reveal a frame of the document
+// only: the N-Quads statements of the canonical form of the frame are the messages revealed by a zero-knowledge proof
+// of knowledge of the BBS+ signature of all the statements of the document.
+package bbsblssignatureproof2020
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+)
+
+// Suite implements BbsBlsSignatureProof2020 signature suite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+}
+
+const (
+	// SignatureProofType is the signature type of the proofs derived from BBS+ signatures.
+	SignatureProofType = "BbsBlsSignatureProof2020"
+	rdfDataSetAlg      = "URDNA2015"
+)
+
+// New an instance of BbsBlsSignatureProof2020 signature suite.
+func New(opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document
+// BbsBlsSignatureProof2020 signature SignatureSuite uses RDF Dataset Normalization as canonicalization algorithm.
+// The blank nodes of the signed document are framed as IRIs in the revealed document, they are restored to get
+// the statements as signed, in the order they were signed.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	canonicalDoc, err := s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []string
+
+	for _, row := range strings.Split(jsonld.IRIsToBlankNodes(string(canonicalDoc)), "\n") {
+		if row != "" {
+			statements = append(statements, row+"\n")
+		}
+	}
+
+	sort.Strings(statements)
+
+	return []byte(strings.Join(statements, "")), nil
+}
+
+// GetDigest returns the document itself: its N-Quads statements are the messages revealed by the proof.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	return doc
+}
+
+// Accept will accept only BbsBlsSignatureProof2020 signature type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureProofType
+}
@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignatureproof2020
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	// the blank nodes framed as IRIs are restored in the order of the signed statements
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{
+			"name":  "http://schema.org/name",
+			"knows": map[string]interface{}{"@id": "http://schema.org/knows", "@type": "@id"},
+		},
+		"@id":   "did:example:123",
+		"name":  "Jayden Doe",
+		"knows": "urn:bnid:_:c14n0",
+	})
+	require.NoError(t, err)
+	require.Equal(t, `<did:example:123> <http://schema.org/knows> _:c14n0 .
+<did:example:123> <http://schema.org/name> "Jayden Doe" .
+`, string(doc))
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	// the statements are verified as they are
+	digest := New().GetDigest([]byte("test doc"))
+	require.Equal(t, []byte("test doc"), digest)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	accepted := ss.Accept("BbsBlsSignatureProof2020")
+	require.True(t, accepted)
+
+	accepted = ss.Accept("BbsBlsSignature2020")
+	require.False(t, accepted)
+}
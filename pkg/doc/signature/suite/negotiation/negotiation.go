@@ -0,0 +1,148 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package negotiation selects the signature suite of the proofs of verifiable credentials and presentations among
+// the suites supported by both the issuer (or holder) and the verifier.
+package negotiation
+
+import (
+	"errors"
+	"sync"
+)
+
+// the proof types of the suites of the default registry.
+const (
+	Ed25519Signature2018        = "Ed25519Signature2018"
+	JSONWebSignature2020        = "JsonWebSignature2020"
+	EcdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
+	BbsBlsSignature2020         = "BbsBlsSignature2020"
+)
+
+// the priorities of the suites of the default registry.
+const (
+	bbsBlsPriority = 40 - iota*10
+	ed25519Priority
+	jsonWebPriority
+	ecdsaSecp256k1Priority
+)
+
+// ErrNoCommonSuite is returned when the parties have no registered suite in common (satisfying the requirements).
+var ErrNoCommonSuite = errors.New("no mutually supported signature suite")
+
+// Suite describes a signature suite to the negotiation.
+type Suite struct {
+	// Type is the proof type of the suite (eg. Ed25519Signature2018).
+	Type string
+	// Priority ranks the suites supported by both parties, the suite with the highest priority is selected.
+	Priority int
+	// Privacy tells whether the proofs of the suite allow the selective disclosure of the claims, with derived
+	// proofs which can't be correlated (eg. BbsBlsSignature2020).
+	Privacy bool
+}
+
+// Registry holds the suites known to the negotiation.
+type Registry struct {
+	suites map[string]Suite
+	lock   sync.RWMutex
+}
+
+// NewRegistry returns a new registry of the suites.
+func NewRegistry(suites ...Suite) *Registry {
+	r := &Registry{suites: map[string]Suite{}}
+
+	for _, s := range suites {
+		r.Register(s)
+	}
+
+	return r
+}
+
+// DefaultRegistry returns a new registry of the well-known suites: BbsBlsSignature2020 is selected over the
+// other suites, then Ed25519Signature2018, JsonWebSignature2020 and EcdsaSecp256k1Signature2019.
+func DefaultRegistry() *Registry {
+	return NewRegistry(
+		Suite{Type: BbsBlsSignature2020, Priority: bbsBlsPriority, Privacy: true},
+		Suite{Type: Ed25519Signature2018, Priority: ed25519Priority},
+		Suite{Type: JSONWebSignature2020, Priority: jsonWebPriority},
+		Suite{Type: EcdsaSecp256k1Signature2019, Priority: ecdsaSecp256k1Priority},
+	)
+}
+
+// Register adds the suite to the registry, replacing the registered suite of the same type.
+func (r *Registry) Register(s Suite) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.suites[s.Type] = s
+}
+
+// Get returns the registered suite of the proof type.
+func (r *Registry) Get(proofType string) (Suite, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	s, ok := r.suites[proofType]
+
+	return s, ok
+}
+
+// Opt is the negotiation option.
+type Opt func(opts *options)
+
+type options struct {
+	privacy bool
+}
+
+// WithPrivacy option is for selecting a suite allowing selective disclosure only (eg. when the verifier asks for a
+// subset of the claims).
+func WithPrivacy() Opt {
+	return func(opts *options) {
+		opts.privacy = true
+	}
+}
+
+// Negotiate selects the signature suite among the registered suites supported by the issuer (its capabilities)
+// and accepted by the verifier (the suites of its proof request). The suite with the highest priority is selected,
+// ties are broken by the order of the verifier's accepted suites. ErrNoCommonSuite is returned if the parties have
+// no registered suite (satisfying the options) in common.
+func (r *Registry) Negotiate(issuerSuites, verifierSuites []string, opts ...Opt) (Suite, error) {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	supported := make(map[string]bool, len(issuerSuites))
+
+	for _, t := range issuerSuites {
+		supported[t] = true
+	}
+
+	var (
+		selected Suite
+		found    bool
+	)
+
+	for _, t := range verifierSuites {
+		if !supported[t] {
+			continue
+		}
+
+		s, ok := r.Get(t)
+		if !ok || (o.privacy && !s.Privacy) {
+			continue
+		}
+
+		if !found || s.Priority > selected.Priority {
+			selected, found = s, true
+		}
+	}
+
+	if !found {
+		return Suite{}, ErrNoCommonSuite
+	}
+
+	return selected, nil
+}
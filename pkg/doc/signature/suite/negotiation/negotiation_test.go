@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package negotiation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Negotiate(t *testing.T) {
+	r := DefaultRegistry()
+
+	t.Run("overlapping capabilities", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			issuer   []string
+			verifier []string
+			opts     []Opt
+			expected string
+		}{
+			{
+				name:     "single suite in common",
+				issuer:   []string{Ed25519Signature2018, EcdsaSecp256k1Signature2019},
+				verifier: []string{JSONWebSignature2020, EcdsaSecp256k1Signature2019},
+				expected: EcdsaSecp256k1Signature2019,
+			},
+			{
+				name:     "highest priority of the suites in common",
+				issuer:   []string{EcdsaSecp256k1Signature2019, Ed25519Signature2018, JSONWebSignature2020},
+				verifier: []string{JSONWebSignature2020, EcdsaSecp256k1Signature2019, Ed25519Signature2018},
+				expected: Ed25519Signature2018,
+			},
+			{
+				name:     "BBS+ over Ed25519",
+				issuer:   []string{Ed25519Signature2018, BbsBlsSignature2020},
+				verifier: []string{Ed25519Signature2018, BbsBlsSignature2020},
+				expected: BbsBlsSignature2020,
+			},
+			{
+				name:     "BBS+ over Ed25519 when privacy is required",
+				issuer:   []string{Ed25519Signature2018, BbsBlsSignature2020},
+				verifier: []string{BbsBlsSignature2020, Ed25519Signature2018},
+				opts:     []Opt{WithPrivacy()},
+				expected: BbsBlsSignature2020,
+			},
+			{
+				name:     "unregistered suites are ignored",
+				issuer:   []string{"UnknownSignature2020", JSONWebSignature2020},
+				verifier: []string{"UnknownSignature2020", JSONWebSignature2020},
+				expected: JSONWebSignature2020,
+			},
+		} {
+			s, err := r.Negotiate(tc.issuer, tc.verifier, tc.opts...)
+			require.NoError(t, err, tc.name)
+			require.Equal(t, tc.expected, s.Type, tc.name)
+		}
+	})
+
+	t.Run("non-overlapping capabilities", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			issuer   []string
+			verifier []string
+			opts     []Opt
+		}{
+			{
+				name:     "no suite in common",
+				issuer:   []string{Ed25519Signature2018, BbsBlsSignature2020},
+				verifier: []string{JSONWebSignature2020, EcdsaSecp256k1Signature2019},
+			},
+			{
+				name:     "privacy required but no privacy suite in common",
+				issuer:   []string{Ed25519Signature2018, BbsBlsSignature2020},
+				verifier: []string{Ed25519Signature2018, JSONWebSignature2020},
+				opts:     []Opt{WithPrivacy()},
+			},
+			{
+				name:     "only unregistered suites in common",
+				issuer:   []string{"UnknownSignature2020"},
+				verifier: []string{"UnknownSignature2020"},
+			},
+			{
+				name:   "no accepted suite",
+				issuer: []string{Ed25519Signature2018},
+			},
+		} {
+			s, err := r.Negotiate(tc.issuer, tc.verifier, tc.opts...)
+			require.Equal(t, ErrNoCommonSuite, err, tc.name)
+			require.Empty(t, s, tc.name)
+		}
+	})
+
+	t.Run("ties are broken by the verifier's order", func(t *testing.T) {
+		r := NewRegistry(Suite{Type: "A", Priority: 1}, Suite{Type: "B", Priority: 1})
+
+		s, err := r.Negotiate([]string{"A", "B"}, []string{"B", "A"})
+		require.NoError(t, err)
+		require.Equal(t, "B", s.Type)
+	})
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := DefaultRegistry()
+
+	s, ok := r.Get(Ed25519Signature2018)
+	require.True(t, ok)
+	require.False(t, s.Privacy)
+
+	_, ok = r.Get("UnknownSignature2020")
+	require.False(t, ok)
+
+	// a suite is promoted over BBS+ by registering it again
+	r.Register(Suite{Type: Ed25519Signature2018, Priority: 100})
+
+	s, err := r.Negotiate([]string{Ed25519Signature2018, BbsBlsSignature2020},
+		[]string{Ed25519Signature2018, BbsBlsSignature2020})
+	require.NoError(t, err)
+	require.Equal(t, Ed25519Signature2018, s.Type)
+}
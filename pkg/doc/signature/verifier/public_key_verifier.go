@@ -16,10 +16,12 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/btcsuite/btcd/btcec"
 	gojose "github.com/square/go-jose/v3"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 )
 
@@ -375,3 +377,74 @@ type ellipticCurve struct {
 	keySize int
 	hash    crypto.Hash
 }
+
+// BBSG2SignatureVerifier verifies a BBS+ signature of the N-Quads statements of the message taking BLS12-381 G2
+// public key bytes as input, each statement being a signed message.
+type BBSG2SignatureVerifier struct {
+	baseSignatureVerifier
+}
+
+// NewBBSG2SignatureVerifier creates a new BBSG2SignatureVerifier.
+func NewBBSG2SignatureVerifier() *BBSG2SignatureVerifier {
+	return &BBSG2SignatureVerifier{
+		baseSignatureVerifier: baseSignatureVerifier{
+			keyType:   "EC",
+			curve:     "BLS12381_G2",
+			algorithm: "BBS+",
+		},
+	}
+}
+
+// Verify verifies the signature.
+func (sv BBSG2SignatureVerifier) Verify(pubKey *PublicKey, msg, signature []byte) error {
+	err := bbs12381g2pub.New().Verify(SplitStatements(msg), signature, pubKey.Value)
+	if err != nil {
+		return fmt.Errorf("bbs: %w", err)
+	}
+
+	return nil
+}
+
+// BBSG2SignatureProofVerifier verifies a proof of knowledge of a BBS+ signature derived for the nonce, revealing
+// the N-Quads statements of the message, taking BLS12-381 G2 public key bytes as input.
+type BBSG2SignatureProofVerifier struct {
+	baseSignatureVerifier
+
+	nonce []byte
+}
+
+// NewBBSG2SignatureProofVerifier creates a new BBSG2SignatureProofVerifier of the proofs derived for the nonce.
+func NewBBSG2SignatureProofVerifier(nonce []byte) *BBSG2SignatureProofVerifier {
+	return &BBSG2SignatureProofVerifier{
+		baseSignatureVerifier: baseSignatureVerifier{
+			keyType:   "EC",
+			curve:     "BLS12381_G2",
+			algorithm: "BBS+",
+		},
+		nonce: nonce,
+	}
+}
+
+// Verify verifies the signature proof.
+func (sv BBSG2SignatureProofVerifier) Verify(pubKey *PublicKey, msg, signatureProof []byte) error {
+	err := bbs12381g2pub.New().VerifyProof(SplitStatements(msg), signatureProof, sv.nonce, pubKey.Value)
+	if err != nil {
+		return fmt.Errorf("bbs: %w", err)
+	}
+
+	return nil
+}
+
+// SplitStatements splits the N-Quads statements into the messages signed by BBS+ signatures, one per statement.
+func SplitStatements(statements []byte) [][]byte {
+	rows := strings.Split(string(statements), "\n")
+	messages := make([][]byte, 0, len(rows))
+
+	for _, row := range rows {
+		if strings.TrimSpace(row) != "" {
+			messages = append(messages, []byte(row))
+		}
+	}
+
+	return messages
+}
@@ -10,6 +10,7 @@ import (
 	"crypto"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/sha256"
 	"errors"
 	"testing"
 
@@ -17,6 +18,7 @@ import (
 	gojose "github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
@@ -368,6 +370,88 @@ func TestNewECDSAES256SignatureVerifier(t *testing.T) {
 	})
 }
 
+func TestNewBBSG2SignatureVerifier(t *testing.T) {
+	pubKeyBytes, privKeyBytes := generateBBSKeyPair(t)
+
+	msg := []byte("statement 1\nstatement 2\n")
+
+	bbs := bbs12381g2pub.New()
+
+	msgSig, err := bbs.Sign([][]byte{[]byte("statement 1"), []byte("statement 2")}, privKeyBytes)
+	require.NoError(t, err)
+
+	pubKey := &PublicKey{
+		Type:  "Bls12381G2Key2020",
+		Value: pubKeyBytes,
+	}
+
+	v := NewBBSG2SignatureVerifier()
+
+	err = v.Verify(pubKey, msg, msgSig)
+	require.NoError(t, err)
+
+	// invalid signature
+	err = v.Verify(pubKey, []byte("statement 1\nstatement 3\n"), msgSig)
+	require.EqualError(t, err, "bbs: invalid BBS+ signature")
+
+	// invalid public key
+	err = v.Verify(&PublicKey{Type: "Bls12381G2Key2020", Value: []byte("invalid-key")}, msg, msgSig)
+	require.EqualError(t, err, "bbs: unmarshal public key: invalid size of public key: 11")
+}
+
+func TestNewBBSG2SignatureProofVerifier(t *testing.T) {
+	pubKeyBytes, privKeyBytes := generateBBSKeyPair(t)
+
+	bbs := bbs12381g2pub.New()
+	messages := [][]byte{[]byte("statement 1"), []byte("statement 2"), []byte("statement 3")}
+
+	msgSig, err := bbs.Sign(messages, privKeyBytes)
+	require.NoError(t, err)
+
+	nonce := []byte("nonce")
+
+	msgSigProof, err := bbs.DeriveProof(messages, msgSig, nonce, pubKeyBytes, []int{0, 2})
+	require.NoError(t, err)
+
+	pubKey := &PublicKey{
+		Type:  "Bls12381G2Key2020",
+		Value: pubKeyBytes,
+	}
+
+	err = NewBBSG2SignatureProofVerifier(nonce).Verify(pubKey, []byte("statement 1\nstatement 3\n"), msgSigProof)
+	require.NoError(t, err)
+
+	// other nonce
+	err = NewBBSG2SignatureProofVerifier([]byte("other nonce")).Verify(pubKey,
+		[]byte("statement 1\nstatement 3\n"), msgSigProof)
+	require.EqualError(t, err, "bbs: invalid proof of knowledge of the signature")
+
+	// hidden statement
+	err = NewBBSG2SignatureProofVerifier(nonce).Verify(pubKey, []byte("statement 1\nstatement 2\n"), msgSigProof)
+	require.EqualError(t, err, "bbs: invalid proof of knowledge of the signature")
+}
+
+func TestSplitStatements(t *testing.T) {
+	require.Equal(t, [][]byte{[]byte("statement 1"), []byte("statement 2")},
+		SplitStatements([]byte("statement 1\n\nstatement 2\n")))
+	require.Empty(t, SplitStatements(nil))
+}
+
+func generateBBSKeyPair(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	return pubKeyBytes, privKeyBytes
+}
+
 type testSignatureVerifier struct {
 	baseSignatureVerifier
 
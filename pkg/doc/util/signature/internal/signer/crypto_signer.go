@@ -13,8 +13,10 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"strings"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -25,13 +27,33 @@ type CryptoSigner struct {
 
 	crypto cryptoapi.Crypto
 	kh     interface{}
+	bbs    bool
 }
 
 // Sign will sign document and return signature.
+// A BBS+ signature signs each N-Quads statement of the document as a separate message.
 func (s *CryptoSigner) Sign(msg []byte) ([]byte, error) {
+	if s.bbs {
+		return s.crypto.SignMulti(splitStatements(msg), s.kh)
+	}
+
 	return s.crypto.Sign(msg, s.kh)
 }
 
+// splitStatements splits the N-Quads statements of a canonical document into messages.
+func splitStatements(doc []byte) [][]byte {
+	rows := strings.Split(string(doc), "\n")
+	messages := make([][]byte, 0, len(rows))
+
+	for _, row := range rows {
+		if strings.TrimSpace(row) != "" {
+			messages = append(messages, []byte(row))
+		}
+	}
+
+	return messages
+}
+
 // PublicKey returns a public key object (e.g. ed25519.PublicKey or *ecdsa.PublicKey).
 func (s *CryptoSigner) PublicKey() interface{} {
 	return s.PubKey
@@ -64,6 +86,7 @@ func NewCryptoSigner(crypto cryptoapi.Crypto, kms kmsapi.KeyManager, keyType kms
 		kh:          kh,
 		PubKey:      pubKey,
 		PubKeyBytes: pubKeyBytes,
+		bbs:         keyType == kmsapi.BLS12381G2Type,
 	}, nil
 }
 
@@ -112,6 +135,14 @@ func getPublicKey(keyType kmsapi.KeyType, pubKeyBytes []byte) (interface{}, erro
 	case kmsapi.ED25519Type:
 		return ed25519.PublicKey(pubKeyBytes), nil
 
+	case kmsapi.BLS12381G2Type:
+		pubKey, err := bbs12381g2pub.UnmarshalPublicKey(pubKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse BBS+ public key: %w", err)
+		}
+
+		return pubKey, nil
+
 	default:
 		return nil, errors.New("unsupported key type")
 	}
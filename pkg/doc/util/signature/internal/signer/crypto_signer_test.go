@@ -17,6 +17,7 @@ import (
 	"github.com/google/tink/go/keyset"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
@@ -66,6 +67,31 @@ func TestNewCryptoSigner(t *testing.T) {
 		require.NoError(t, err)
 	}
 
+	t.Run("BBS+ signer", func(t *testing.T) {
+		signer, err := NewCryptoSigner(tinkCrypto, localKMS, kmsapi.BLS12381G2Type)
+		require.NoError(t, err)
+		require.IsType(t, &bbs12381g2pub.PublicKey{}, signer.PublicKey())
+		require.NotEmpty(t, signer.PublicKeyBytes())
+
+		msg := []byte("statement 1\nstatement 2\n")
+		sigMsg, err := signer.Sign(msg)
+		require.NoError(t, err)
+
+		keyHandle, ok := signer.kh.(*keyset.Handle)
+		require.True(t, ok)
+
+		publicKeyHandle, err := keyHandle.Public()
+		require.NoError(t, err)
+
+		// each statement is signed as a message
+		err = tinkCrypto.VerifyMulti([][]byte{[]byte("statement 1"), []byte("statement 2")}, sigMsg, publicKeyHandle)
+		require.NoError(t, err)
+
+		err = bbs12381g2pub.New().Verify([][]byte{[]byte("statement 1"), []byte("statement 2")}, sigMsg,
+			signer.PublicKeyBytes())
+		require.NoError(t, err)
+	})
+
 	t.Run("error corner cases", func(t *testing.T) {
 		kms := &mockkms.KeyManager{
 			CreateKeyErr: errors.New("key creation error"),
@@ -103,6 +129,14 @@ func TestNewCryptoSigner(t *testing.T) {
 		require.EqualError(t, err, "unexpected type of ecdsa public key")
 		require.Nil(t, signer)
 
+		kms = &mockkms.KeyManager{
+			ExportPubKeyBytesValue: []byte("not a public key"),
+		}
+		signer, err = NewCryptoSigner(tinkCrypto, kms, kmsapi.BLS12381G2Type)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse BBS+ public key")
+		require.Nil(t, signer)
+
 		kms = &mockkms.KeyManager{}
 		signer, err = NewCryptoSigner(tinkCrypto, kms, kmsapi.ChaCha20Poly1305Type)
 		require.Error(t, err)
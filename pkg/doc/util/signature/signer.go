@@ -21,7 +21,7 @@ func NewCryptoSigner(crypto cryptoapi.Crypto, kms kmsapi.KeyManager, keyType kms
 	case kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP256TypeIEEEP1363,
 		kmsapi.ECDSAP384TypeDER, kmsapi.ECDSAP384TypeIEEEP1363,
 		kmsapi.ECDSAP521TypeDER, kmsapi.ECDSAP521TypeIEEEP1363,
-		kmsapi.ED25519Type:
+		kmsapi.ED25519Type, kmsapi.BLS12381G2Type:
 		return signer.NewCryptoSigner(crypto, kms, keyType)
 
 	case kmsapi.ECDSASecp256k1TypeIEEEP1363:
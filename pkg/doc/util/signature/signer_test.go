@@ -34,6 +34,7 @@ func TestNewCryptoSigner(t *testing.T) {
 		kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP384TypeDER, kmsapi.ECDSAP521TypeDER,
 		kmsapi.ECDSAP256TypeIEEEP1363, kmsapi.ECDSAP521TypeIEEEP1363, kmsapi.ED25519Type,
 		kmsapi.ECDSAP384TypeIEEEP1363, kmsapi.ECDSASecp256k1TypeIEEEP1363, kmsapi.RSARS256Type, kmsapi.RSAPS256Type,
+		kmsapi.BLS12381G2Type,
 	} {
 		newSigner, signerErr := NewCryptoSigner(tinkCrypto, localKMS, keyType)
 		require.NoError(t, signerErr)
@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+)
+
+// DeriveProof derives from the BbsBlsSignature2020 proofs of the Verifiable Credential a new Verifiable Credential
+// revealing only the attributes selected by revealDoc, a JSON-LD frame of the credential. The BbsBlsSignatureProof2020
+// proofs of the derived credential are bound to the nonce. The public key fetcher option is required to resolve
+// the public keys of the BBS+ signatures.
+func (vc *Credential) DeriveProof(revealDoc map[string]interface{}, nonce []byte,
+	opts ...CredentialOpt) (*Credential, error) {
+	if len(vc.Proofs) == 0 {
+		return nil, errors.New("derive proof: VC has no proof")
+	}
+
+	vcOpts := getCredentialOpts(opts)
+
+	if vcOpts.publicKeyFetcher == nil {
+		return nil, errors.New("derive proof: public key fetcher is not defined")
+	}
+
+	vcDoc, err := toMap(vc)
+	if err != nil {
+		return nil, fmt.Errorf("derive proof: %w", err)
+	}
+
+	derivedDoc, err := bbsblssignatureproof2020.New().SelectiveDisclosure(vcDoc, revealDoc, nonce,
+		&keyResolverAdapter{vcOpts.publicKeyFetcher}, mapJSONLDProcessorOpts(&vcOpts.jsonldCredentialOpts)...)
+	if err != nil {
+		return nil, fmt.Errorf("derive proof: %w", err)
+	}
+
+	// the framed VC lists its types in the order of their IRIs, the VC types are restored in their order
+	// (the type statements of the canonical document do not depend on it)
+	derivedDoc["type"] = vcDoc["type"]
+
+	derivedBytes, err := json.Marshal(derivedDoc)
+	if err != nil {
+		return nil, fmt.Errorf("derive proof: marshal derived VC: %w", err)
+	}
+
+	// the BBS+ signatures are verified when deriving the proofs
+	return ParseCredential(derivedBytes, append(opts, WithDisabledProofCheck())...)
+}
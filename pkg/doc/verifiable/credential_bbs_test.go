@@ -0,0 +1,176 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const credentialToSignWithBBS = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1",
+    "https://w3id.org/security/bbs/v1"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "givenName": "Jayden",
+    "familyName": "Doe",
+    "degree": {
+      "type": "BachelorDegree",
+      "name": "Bachelor of Science and Arts"
+    }
+  }
+}`
+
+const revealDocWithBBS = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1",
+    "https://w3id.org/security/bbs/v1"
+  ],
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "@explicit": true,
+  "issuer": {},
+  "issuanceDate": {},
+  "credentialSubject": {
+    "@explicit": true,
+    "givenName": {}
+  }
+}`
+
+func TestCredential_DeriveProof(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.BLS12381G2Type)
+	r.NoError(err)
+
+	pubKeyFetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), bbsblssignature2020.G2PublicKeyType))
+
+	vc, err := parseTestCredential([]byte(credentialToSignWithBBS))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "BbsBlsSignature2020",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   bbsblssignature2020.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1",
+	}, jsonld.WithDocumentLoader(testDocumentLoader))
+	r.NoError(err)
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	// the BBS+ signature is verified by the default suites
+	vcWithBBS, err := parseTestCredential(vcBytes, pubKeyFetcher)
+	r.NoError(err)
+	r.Len(vcWithBBS.Proofs, 1)
+	r.Equal("BbsBlsSignature2020", vcWithBBS.Proofs[0]["type"])
+
+	var revealDoc map[string]interface{}
+
+	r.NoError(json.Unmarshal([]byte(revealDocWithBBS), &revealDoc))
+
+	nonce := []byte("nonce")
+
+	derivedVC, err := vcWithBBS.DeriveProof(revealDoc, nonce, pubKeyFetcher,
+		WithJSONLDDocumentLoader(testDocumentLoader))
+	r.NoError(err)
+	r.Equal(vc.ID, derivedVC.ID)
+	r.Equal(vc.Issuer, derivedVC.Issuer)
+	r.Equal(vc.Issued, derivedVC.Issued)
+
+	r.Len(derivedVC.Proofs, 1)
+	r.Equal(bbsblssignatureproof2020.SignatureProofType, derivedVC.Proofs[0]["type"])
+	r.Equal(base64.RawURLEncoding.EncodeToString(nonce), derivedVC.Proofs[0]["nonce"])
+
+	derivedVCBytes, err := json.Marshal(derivedVC)
+	r.NoError(err)
+
+	subjects, ok := derivedVC.Subject.([]Subject)
+	r.True(ok)
+	r.Len(subjects, 1)
+	r.Equal("did:example:ebfeb1f712ebc6f1c276e12ec21", subjects[0].ID)
+	r.Equal("Jayden", subjects[0].CustomFields["givenName"])
+	r.NotContains(subjects[0].CustomFields, "familyName")
+	r.NotContains(subjects[0].CustomFields, "degree")
+	r.NotContains(string(derivedVCBytes), "Doe")
+	r.NotContains(string(derivedVCBytes), "Bachelor of Science and Arts")
+
+	t.Run("verify derived proof", func(t *testing.T) {
+		// the derived BBS+ proof is verified by the default suites with the nonce of the proof
+		verifiedVC, err := parseTestCredential(derivedVCBytes, pubKeyFetcher)
+		require.NoError(t, err)
+		require.Equal(t, derivedVC.Proofs, verifiedVC.Proofs)
+
+		otherSigner, err := newCryptoSigner(kms.BLS12381G2Type)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(derivedVCBytes, WithPublicKeyFetcher(
+			SingleKey(otherSigner.PublicKeyBytes(), bbsblssignature2020.G2PublicKeyType)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+	})
+
+	t.Run("verify tampered derived VC", func(t *testing.T) {
+		var derivedDoc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(derivedVCBytes, &derivedDoc))
+
+		derivedDoc["credentialSubject"].(map[string]interface{})["givenName"] = "Alice"
+
+		tamperedBytes, err := json.Marshal(derivedDoc)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(tamperedBytes, pubKeyFetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid proof of knowledge of the signature")
+	})
+
+	t.Run("derive proof from VC without BBS+ signature", func(t *testing.T) {
+		vcWithoutProof, err := parseTestCredential([]byte(credentialToSignWithBBS))
+		require.NoError(t, err)
+
+		_, err = vcWithoutProof.DeriveProof(revealDoc, nonce, pubKeyFetcher,
+			WithJSONLDDocumentLoader(testDocumentLoader))
+		require.EqualError(t, err, "derive proof: VC has no proof")
+
+		edSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		err = vcWithoutProof.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(edSigner)),
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key-2",
+		}, jsonld.WithDocumentLoader(testDocumentLoader))
+		require.NoError(t, err)
+
+		_, err = vcWithoutProof.DeriveProof(revealDoc, nonce, pubKeyFetcher,
+			WithJSONLDDocumentLoader(testDocumentLoader))
+		require.EqualError(t, err, "derive proof: document has no BbsBlsSignature2020 proof")
+	})
+
+	t.Run("derive proof without public key fetcher", func(t *testing.T) {
+		_, err := vcWithBBS.DeriveProof(revealDoc, nonce, WithJSONLDDocumentLoader(testDocumentLoader))
+		require.EqualError(t, err, "derive proof: public key fetcher is not defined")
+	})
+}
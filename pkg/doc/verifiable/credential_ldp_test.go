@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
@@ -27,6 +28,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 )
@@ -64,6 +66,86 @@ func TestParseCredentialFromLinkedDataProof_Ed25519Signature2018(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialFromLinkedDataProof_CachedContexts(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	// all of the contexts are cached, they are never fetched
+	loader, err := jsonld.NewDocumentLoader(jsonld.WithRemoteDocumentLoaderDisabled())
+	r.NoError(err)
+
+	vc := &Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      "http://example.edu/credentials/1872",
+		Types:   []string{"VerifiableCredential"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Issuer:  Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Issued:  util.NewTime(time.Now()),
+	}
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1",
+	}, jsonld.WithDocumentLoader(loader))
+	r.NoError(err)
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	vcWithLdp, err := ParseCredential(vcBytes,
+		WithJSONLDDocumentLoader(loader),
+		WithJSONLDValidation(),
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	r.NoError(err)
+	r.Equal(vc.Proofs, vcWithLdp.Proofs)
+
+	vp, err := vc.Presentation()
+	r.NoError(err)
+
+	vp.Holder = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+
+	err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:ebfeb1f712ebc6f1c276e12ec21#key-1",
+		Challenge:               uuid.New().String(),
+	}, jsonld.WithDocumentLoader(loader))
+	r.NoError(err)
+
+	vpBytes, err := json.Marshal(vp)
+	r.NoError(err)
+
+	_, err = ParsePresentation(vpBytes,
+		WithPresJSONLDDocumentLoader(loader),
+		WithPresEmbeddedSignatureSuites(sigSuite),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	r.NoError(err)
+
+	t.Run("uncached context", func(t *testing.T) {
+		vc.Context = append(vc.Context, "https://www.w3.org/2018/credentials/examples/v1")
+		vc.Proofs = nil
+
+		err := vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   sigSuite,
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1",
+		}, jsonld.WithDocumentLoader(loader))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "loading remote context failed")
+	})
+}
+
 //nolint:lll
 func TestParseCredentialFromLinkedDataProof_JSONLD_Validation(t *testing.T) {
 	r := require.New(t)
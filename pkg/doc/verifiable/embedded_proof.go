@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,6 +16,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
@@ -24,6 +27,8 @@ const (
 	ed25519Signature2018        = "Ed25519Signature2018"
 	jsonWebSignature2020        = "JsonWebSignature2020"
 	ecdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
+	bbsBlsSignature2020         = "BbsBlsSignature2020"
+	bbsBlsSignatureProof2020    = "BbsBlsSignatureProof2020"
 )
 
 func getProofType(proofMap map[string]interface{}) (string, error) {
@@ -34,7 +39,8 @@ func getProofType(proofMap map[string]interface{}) (string, error) {
 
 	proofTypeStr := safeStringValue(proofType)
 	switch proofTypeStr {
-	case ed25519Signature2018, jsonWebSignature2020, ecdsaSecp256k1Signature2019:
+	case ed25519Signature2018, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
+		bbsBlsSignature2020, bbsBlsSignatureProof2020:
 		return proofTypeStr, nil
 	default:
 		return "", fmt.Errorf("unsupported proof type: %s", proofType)
@@ -117,6 +123,18 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 			case ecdsaSecp256k1Signature2019:
 				ldpSuites = append(ldpSuites, ecdsasecp256k1signature2019.New(
 					suite.WithVerifier(ecdsasecp256k1signature2019.NewPublicKeyVerifier())))
+			case bbsBlsSignature2020:
+				ldpSuites = append(ldpSuites, bbsblssignature2020.New(
+					suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier())))
+			case bbsBlsSignatureProof2020:
+				// the derived proof is bound to the nonce of the proof
+				nonce, e := base64.RawURLEncoding.DecodeString(safeStringValue(proofs[i]["nonce"]))
+				if e != nil {
+					return nil, fmt.Errorf("check embedded proof: decode nonce: %w", e)
+				}
+
+				ldpSuites = append(ldpSuites, bbsblssignatureproof2020.New(
+					suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))))
 			}
 		}
 	}
@@ -35,6 +35,18 @@ func Test_parseEmbeddedProof(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.Equal(t, ecdsaSecp256k1Signature2019, s)
+
+		s, err = getProofType(map[string]interface{}{
+			"type": bbsBlsSignature2020,
+		})
+		require.NoError(t, err)
+		require.Equal(t, bbsBlsSignature2020, s)
+
+		s, err = getProofType(map[string]interface{}{
+			"type": bbsBlsSignatureProof2020,
+		})
+		require.NoError(t, err)
+		require.Equal(t, bbsBlsSignatureProof2020, s)
 	})
 
 	t.Run("parse embedded proof without \"type\" element", func(t *testing.T) {
@@ -184,6 +196,24 @@ func Test_checkEmbeddedProof(t *testing.T) {
 		r.Nil(docBytes)
 	})
 
+	t.Run("error on invalid nonce of BBS+ signature proof", func(t *testing.T) {
+		docWithInvalidNonce := `{
+  "@context": "https://www.w3.org/2018/credentials/v1",
+  "proof": {
+    "type": "BbsBlsSignatureProof2020",
+    "created": "2020-01-21T12:59:31+02:00",
+    "verificationMethod": "did:example:123456#key1",
+    "proofValue": "invalid value",
+    "nonce": "invalid nonce"
+  }
+}`
+		docBytes, err := checkEmbeddedProof([]byte(docWithInvalidNonce),
+			&embeddedProofCheckOpts{publicKeyFetcher: SingleKey([]byte("pub key bytes"), "Bls12381G2Key2020")})
+		r.Error(err)
+		r.Contains(err.Error(), "check embedded proof: decode nonce")
+		r.Nil(docBytes)
+	})
+
 	t.Run("no public key fetcher defined", func(t *testing.T) {
 		docWithNotSupportedProof := `{
   "@context": "https://www.w3.org/2018/credentials/v1",
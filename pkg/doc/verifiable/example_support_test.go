@@ -14,6 +14,7 @@ import (
 
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
 
@@ -47,7 +48,7 @@ func (udc *UniversityDegreeCredential) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&cp)
 }
 
-func getJSONLDDocumentLoader() *ld.CachingDocumentLoader {
+func getJSONLDDocumentLoader() *jsonld.DocumentLoader {
 	loader := verifiable.CachingJSONLDLoader()
 
 	addJSONLDCachedContextFromFile(loader,
@@ -66,7 +67,7 @@ func getJSONLDDocumentLoader() *ld.CachingDocumentLoader {
 	return loader
 }
 
-func addJSONLDCachedContextFromFile(loader *ld.CachingDocumentLoader, contextURL, contextFile string) {
+func addJSONLDCachedContextFromFile(loader *jsonld.DocumentLoader, contextURL, contextFile string) {
 	contextContent, err := ioutil.ReadFile(filepath.Clean(filepath.Join(
 		"testdata/context", contextFile)))
 	if err != nil {
@@ -76,7 +77,7 @@ func addJSONLDCachedContextFromFile(loader *ld.CachingDocumentLoader, contextURL
 	addJSONLDCachedContext(loader, contextURL, string(contextContent))
 }
 
-func addJSONLDCachedContext(loader *ld.CachingDocumentLoader, contextURL, contextContent string) {
+func addJSONLDCachedContext(loader *jsonld.DocumentLoader, contextURL, contextContent string) {
 	reader, err := ld.DocumentFromReader(strings.NewReader(contextContent))
 	if err != nil {
 		panic(err)
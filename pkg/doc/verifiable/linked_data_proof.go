@@ -71,6 +71,15 @@ func checkLinkedDataProof(jsonldBytes []byte, suites []verifier.SignatureSuite,
 		return fmt.Errorf("create new signature verifier: %w", err)
 	}
 
+	err = documentVerifier.Verify(jsonldBytes, mapJSONLDProcessorOpts(jsonldOpts)...)
+	if err != nil {
+		return fmt.Errorf("check linked data proof: %w", err)
+	}
+
+	return nil
+}
+
+func mapJSONLDProcessorOpts(jsonldOpts *jsonldCredentialOpts) []jsonld.ProcessorOpts {
 	var processorOpts []jsonld.ProcessorOpts
 
 	if jsonldOpts.jsonldDocumentLoader != nil {
@@ -83,12 +92,7 @@ func checkLinkedDataProof(jsonldBytes []byte, suites []verifier.SignatureSuite,
 		processorOpts = append(processorOpts, jsonld.WithValidateRDF())
 	}
 
-	err = documentVerifier.Verify(jsonldBytes, processorOpts...)
-	if err != nil {
-		return fmt.Errorf("check linked data proof: %w", err)
-	}
-
-	return nil
+	return processorOpts
 }
 
 type rawProof struct {
@@ -245,7 +245,7 @@ func newCryptoSigner(keyType kmsapi.KeyType) (signature.Signer, error) {
 //nolint:gochecknoglobals
 var testDocumentLoader = createTestJSONLDDocumentLoader()
 
-func createTestJSONLDDocumentLoader() *ld.CachingDocumentLoader {
+func createTestJSONLDDocumentLoader() *jsonld.DocumentLoader {
 	loader := CachingJSONLDLoader()
 
 	addJSONLDCachedContextFromFile(loader,
@@ -267,7 +267,7 @@ func createTestJSONLDDocumentLoader() *ld.CachingDocumentLoader {
 	return loader
 }
 
-func addJSONLDCachedContextFromFile(loader *ld.CachingDocumentLoader, contextURL, contextFile string) {
+func addJSONLDCachedContextFromFile(loader *jsonld.DocumentLoader, contextURL, contextFile string) {
 	contextContent, err := ioutil.ReadFile(filepath.Clean(filepath.Join(
 		jsonldContextPrefix, contextFile)))
 	if err != nil {
@@ -277,7 +277,7 @@ func addJSONLDCachedContextFromFile(loader *ld.CachingDocumentLoader, contextURL
 	addJSONLDCachedContext(loader, contextURL, string(contextContent))
 }
 
-func addJSONLDCachedContext(loader *ld.CachingDocumentLoader, contextURL, contextContent string) {
+func addJSONLDCachedContext(loader *jsonld.DocumentLoader, contextURL, contextContent string) {
 	reader, err := ld.DocumentFromReader(strings.NewReader(contextContent))
 	if err != nil {
 		panic(err)
@@ -22,13 +22,20 @@ var ErrNotFound = errors.New("DID not found")
 // DIDCommServiceType default DID Communication service endpoint type.
 const DIDCommServiceType = "did-communication"
 
+// DIDLDJSONContentType content type of a DID Document resolved as JSON-LD.
+const DIDLDJSONContentType = "application/did+ld+json"
+
 // JSONWebKey2020 key type for general encryption key as key agreement, see following discussion:
 // https://github.com/w3c/did-core/issues/240#issuecomment-663230565.
 const JSONWebKey2020 = "JsonWebKey2020"
 
+// X25519KeyAgreementKey2019 key type for X25519 encryption key as key agreement.
+const X25519KeyAgreementKey2019 = "X25519KeyAgreementKey2019"
+
 // Registry vdri registry.
 type Registry interface {
 	Resolve(did string, opts ...ResolveOpts) (*did.Doc, error)
+	ResolveWithMetadata(did string, opts ...ResolveOpts) (*ResolveResult, error)
 	Store(doc *did.Doc) error
 	Create(method string, opts ...DocOpts) (*did.Doc, error)
 	Close() error
@@ -43,6 +50,48 @@ type VDRI interface {
 	Close() error
 }
 
+// MethodRegistrar is implemented by registries allowing VDRIs to be registered at runtime.
+type MethodRegistrar interface {
+	// RegisterVDRI registers given VDRI resolving DIDs of given method, fails if the method is already supported.
+	RegisterVDRI(method string, v VDRI) error
+}
+
+// MethodDescriber is implemented by VDRIs and registries describing the DID methods they support along with the
+// operations supported for each method.
+type MethodDescriber interface {
+	Methods() []MethodCapabilities
+}
+
+// MethodCapabilities holds the operations supported for a DID method. KeyAgreement tells whether the DIDs created
+// with the method have the keyAgreement verification method of the encryption key given with WithEncryptionKey.
+type MethodCapabilities struct {
+	Method       string `json:"method"`
+	Resolve      bool   `json:"resolve"`
+	Create       bool   `json:"create"`
+	Update       bool   `json:"update"`
+	Deactivate   bool   `json:"deactivate"`
+	KeyAgreement bool   `json:"keyAgreement"`
+}
+
+// MetadataReader is implemented by VDRIs able to return DID resolution metadata along with the DID Document.
+type MetadataReader interface {
+	ReadWithMetadata(did string, opts ...ResolveOpts) (*ResolveResult, error)
+}
+
+// ResolutionMetadata holds metadata about the resolution of a DID (https://w3c-ccg.github.io/did-resolution/).
+type ResolutionMetadata struct {
+	ContentType    string                 `json:"contentType,omitempty"`
+	Retrieved      time.Time              `json:"retrieved,omitempty"`
+	Deactivated    bool                   `json:"deactivated,omitempty"`
+	MethodMetadata map[string]interface{} `json:"methodMetadata,omitempty"`
+}
+
+// ResolveResult holds the resolved DID Document along with its resolution metadata.
+type ResolveResult struct {
+	Doc      *did.Doc
+	Metadata ResolutionMetadata
+}
+
 // ResultType input option can be used to request a certain type of result.
 type ResultType int
 
@@ -98,8 +147,10 @@ type CreateDIDOpts struct {
 	KeyType         string
 	ServiceEndpoint string
 	RoutingKeys     []string
+	Services        []did.Service
 	RequestBuilder  func([]byte) (io.Reader, error)
 	EncryptionKey   *PubKey
+	NumAlgo         int
 }
 
 // DocOpts is a create DID option.
@@ -133,6 +184,14 @@ func WithRoutingKeys(routingKeys []string) DocOpts {
 	}
 }
 
+// WithServices allows for setting services to be added to the DID document, along with the service of
+// WithServiceType if any.
+func WithServices(services []did.Service) DocOpts {
+	return func(opts *CreateDIDOpts) {
+		opts.Services = services
+	}
+}
+
 // WithEncryptionKey allows for setting encryption key.
 func WithEncryptionKey(encryptionKey *PubKey) DocOpts {
 	return func(opts *CreateDIDOpts) {
@@ -140,6 +199,14 @@ func WithEncryptionKey(encryptionKey *PubKey) DocOpts {
 	}
 }
 
+// WithNumAlgo allows for setting the numeric algorithm of did:peer DIDs, 2 creates deterministic DIDs which
+// encode their keys and services.
+func WithNumAlgo(numAlgo int) DocOpts {
+	return func(opts *CreateDIDOpts) {
+		opts.NumAlgo = numAlgo
+	}
+}
+
 // WithRequestBuilder allows to supply request builder
 // which can be used to add headers to request stream to be sent to HTTP binding URL.
 func WithRequestBuilder(builder func(payload []byte) (io.Reader, error)) DocOpts {
@@ -161,15 +228,20 @@ type ModifiedBy struct {
 	Sig string `json:"sig,omitempty"`
 }
 
-// RetrieveEncryptionKey retrieves an encryption PublicKey in JWK format from key.
+// RetrieveEncryptionKey retrieves an encryption PublicKey in JWK format from key, X25519 keys are retrieved in raw
+// format.
 func RetrieveEncryptionKey(didKey string, key *PubKey) (*did.PublicKey, error) {
+	keyID := fmt.Sprintf("%s#%s", didKey, key.ID)
+
+	if key.Type == X25519KeyAgreementKey2019 {
+		return did.NewPublicKeyFromBytes(keyID, key.Type, didKey, key.Value), nil
+	}
+
 	jwk, err := toJWK(key)
 	if err != nil {
 		return nil, err
 	}
 
-	keyID := fmt.Sprintf("%s#%s", didKey, key.ID)
-
 	publicKey, err := did.NewPublicKeyFromJWK(keyID, key.Type, didKey, jwk)
 	if err != nil {
 		return nil, err
@@ -111,7 +111,10 @@ func newIssueCredentialSvc() api.ProtocolSvcCreator {
 		}
 
 		// sets default middleware to the service
-		service.Use(mdissuecredential.SaveCredentials(prv))
+		service.Use(
+			mdissuecredential.SaveCredentials(prv),
+			mdissuecredential.ValidateCredentialSchemas(mdissuecredential.HTTPSchemaLoader(&http.Client{})),
+		)
 
 		return service, nil
 	}
@@ -186,6 +189,12 @@ func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 			func(provider packer.Provider) (packer.Packer, error) {
 				return anoncrypt.New(provider, jose.A256GCM)
 			},
+			func(provider packer.Provider) (packer.Packer, error) {
+				return authcrypt.NewV2(provider, jose.A256GCM)
+			},
+			func(provider packer.Provider) (packer.Packer, error) {
+				return anoncrypt.NewV2(provider, jose.A256GCM)
+			},
 		}
 	}
 
@@ -15,9 +15,10 @@ import (
 )
 
 // WithInboundHTTPAddr return new default http inbound transport.
-func WithInboundHTTPAddr(internalAddr, externalAddr, certFile, keyFile string) aries.Option {
+func WithInboundHTTPAddr(internalAddr, externalAddr, certFile, keyFile string,
+	inboundOpts ...http.InboundHTTPOpt) aries.Option {
 	return func(opts *aries.Aries) error {
-		inbound, err := http.NewInbound(internalAddr, externalAddr, certFile, keyFile)
+		inbound, err := http.NewInbound(internalAddr, externalAddr, certFile, keyFile, inboundOpts...)
 		if err != nil {
 			return fmt.Errorf("http inbound transport initialization failed : %w", err)
 		}
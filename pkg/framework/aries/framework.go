@@ -61,6 +61,7 @@ type Aries struct {
 	packers                    []packer.Packer
 	vdriRegistry               vdriapi.Registry
 	vdri                       []vdriapi.VDRI
+	fallbackVDRI               vdriapi.VDRI
 	verifiableStore            verifiable.Store
 	transportReturnRoute       string
 	id                         string
@@ -238,6 +239,15 @@ func WithVDRI(v vdriapi.VDRI) Option {
 	}
 }
 
+// WithFallbackVDRI injects a VDRI resolving the DIDs of the methods not supported by any other VDRI
+// (eg: a universal resolver VDRI) to the Aries framework.
+func WithFallbackVDRI(v vdriapi.VDRI) Option {
+	return func(opts *Aries) error {
+		opts.fallbackVDRI = v
+		return nil
+	}
+}
+
 // WithMessageServiceProvider injects a message service provider to the Aries framework.
 // Message service provider returns list of message services which can be used to provide custom handle
 // functionality based on incoming messages type and purpose.
@@ -380,6 +390,10 @@ func createVDRI(frameworkOpts *Aries) error {
 	k := key.New()
 	opts = append(opts, vdri.WithVDRI(k))
 
+	if frameworkOpts.fallbackVDRI != nil {
+		opts = append(opts, vdri.WithFallbackVDRI(frameworkOpts.fallbackVDRI))
+	}
+
 	frameworkOpts.vdriRegistry = vdri.New(ctx, opts...)
 
 	return nil
@@ -429,6 +443,8 @@ func startTransports(frameworkOpts *Aries) error {
 		context.WithAriesFrameworkID(frameworkOpts.id),
 		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
 		context.WithMessengerHandler(frameworkOpts.messenger),
+		// traces the inbound messages with the tracer of the outbound dispatcher
+		context.WithOutboundDispatcher(frameworkOpts.outboundDispatcher),
 	)
 	if err != nil {
 		return fmt.Errorf("context creation failed: %w", err)
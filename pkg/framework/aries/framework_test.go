@@ -32,6 +32,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	verifiableStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
@@ -150,6 +151,29 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test vdri - with fallback vdri", func(t *testing.T) {
+		path, cleanup := generateTempDir(t)
+		defer cleanup()
+		dbPath = path
+
+		fallback := &mockvdri.MockVDRI{
+			ReadFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+				return &did.Doc{ID: didID}, nil
+			},
+		}
+
+		aries, err := New(WithFallbackVDRI(fallback), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotEmpty(t, aries)
+
+		resolvedDoc, err := aries.vdriRegistry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", resolvedDoc.ID)
+
+		err = aries.Close()
+		require.NoError(t, err)
+	})
+
 	t.Run("test error create vdri", func(t *testing.T) {
 		_, err := New(
 			WithStoreProvider(&storage.MockStoreProvider{FailNamespace: peer.StoreNamespace}),
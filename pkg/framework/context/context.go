@@ -8,7 +8,9 @@ package context
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
@@ -20,9 +22,12 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
+var logger = log.New("aries-framework/framework/context")
+
 // package context creates a framework Provider context to add optional (non default) framework services and provides
 // simple accessor methods to those same services.
 
@@ -147,18 +152,108 @@ func (p *Provider) RouterEndpoint() string {
 	return p.routerEndpoint
 }
 
-func (p *Provider) tryToHandle(svc service.InboundHandler, msg service.DIDCommMsgMap, myDID, theirDID string) error {
+// tryToHandle handles the inbound message with svc and records the step on the thread of the message.
+func (p *Provider) tryToHandle(svc service.InboundHandler, msg service.DIDCommMsgMap, myDID, theirDID string,
+	threads *connection.Recorder, tracer *dispatcher.Tracer) error {
+	err := p.handle(svc, msg, myDID, theirDID, threads)
+
+	span := dispatcher.Span{Direction: dispatcher.DirectionInbound}
+	if err != nil {
+		span.Error = err.Error()
+	}
+
+	tracer.Record(msg, span)
+
+	return err
+}
+
+func (p *Provider) handle(svc service.InboundHandler, msg service.DIDCommMsgMap, myDID, theirDID string,
+	threads *connection.Recorder) error {
 	if err := p.messenger.HandleInbound(msg, myDID, theirDID); err != nil {
 		return fmt.Errorf("messenger HandleInbound: %w", err)
 	}
 
+	indexThread(threads, msg, myDID, theirDID)
+
 	_, err := svc.HandleInbound(msg, myDID, theirDID)
 
 	return err
 }
 
+// messageTracer is implemented by the outbound dispatchers tracing the messages they send.
+type messageTracer interface {
+	Tracer() *dispatcher.Tracer
+}
+
+// tracer returns the tracer of the outbound dispatcher tracing the inbound messages along with the messages sent,
+// nil if the outbound dispatcher doesn't trace messages.
+func (p *Provider) tracer() *dispatcher.Tracer {
+	if t, ok := p.outboundDispatcher.(messageTracer); ok {
+		return t.Tracer()
+	}
+
+	return nil
+}
+
+// threadRecorder returns the connection recorder indexing the threads of the inbound messages, nil if the storage
+// providers aren't set.
+func (p *Provider) threadRecorder() *connection.Recorder {
+	if p.storeProvider == nil || p.protocolStateStoreProvider == nil {
+		return nil
+	}
+
+	recorder, err := connection.NewRecorder(p)
+	if err != nil {
+		logger.Warnf("threads of inbound messages won't be indexed : %s", err)
+
+		return nil
+	}
+
+	return recorder
+}
+
+// indexThread saves the thread of the message received on the connection between myDID and theirDID, so that
+// the thread can be correlated to its connection. The messages received out of a connection aren't indexed.
+func indexThread(threads *connection.Recorder, msg service.DIDCommMsgMap, myDID, theirDID string) {
+	if threads == nil || myDID == "" || theirDID == "" {
+		return
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return
+	}
+
+	connectionID, err := threads.GetConnectionIDByDIDs(myDID, theirDID)
+	if err != nil {
+		logger.Debugf("thread %s is not indexed, no connection found : %s", thID, err)
+
+		return
+	}
+
+	msgType := msg.Type()
+
+	protocol := msgType
+	if i := strings.LastIndex(msgType, "/"); i > 0 {
+		protocol = msgType[:i]
+	}
+
+	err = threads.SaveThreadRecord(&connection.ThreadRecord{
+		ThreadID:     thID,
+		ConnectionID: connectionID,
+		Protocol:     protocol,
+		MessageType:  msgType,
+	})
+	if err != nil {
+		logger.Warnf("failed to index thread %s : %s", thID, err)
+	}
+}
+
 // InboundMessageHandler return an inbound message handler.
 func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
+	threads := p.threadRecorder()
+	tracer := p.tracer()
+
 	return func(message []byte, myDID, theirDID string) error {
 		msg, err := service.ParseDIDCommMsgMap(message)
 		if err != nil {
@@ -168,7 +263,7 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 		// find the service which accepts the message type
 		for _, svc := range p.services {
 			if svc.Accept(msg.Type()) {
-				return p.tryToHandle(svc, msg, myDID, theirDID)
+				return p.tryToHandle(svc, msg, myDID, theirDID, threads, tracer)
 			}
 		}
 
@@ -185,7 +280,7 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 			}
 
 			if svc.Accept(msg.Type(), h.Purpose) {
-				return p.tryToHandle(svc, msg, myDID, theirDID)
+				return p.tryToHandle(svc, msg, myDID, theirDID, threads, tracer)
 			}
 		}
 
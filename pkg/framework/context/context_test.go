@@ -18,6 +18,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	verifiableStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
@@ -32,6 +33,8 @@ import (
 	mocklock "github.com/hyperledger/aries-framework-go/pkg/mock/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -260,6 +263,136 @@ func TestNewProvider(t *testing.T) {
 		}
 	})
 
+	t.Run("inbound messages index their threads", func(t *testing.T) {
+		const (
+			myDID    = "did:example:alice"
+			theirDID = "did:example:bob"
+			msgType  = "https://didcomm.org/issue-credential/2.0/offer-credential"
+		)
+
+		messenger := serviceMocks.NewMockMessengerHandler(ctrl)
+		messenger.EXPECT().HandleInbound(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		prov, err := New(
+			WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{}),
+			WithMessengerHandler(messenger),
+			WithStorageProvider(mem.NewProvider()),
+			WithProtocolStateStorageProvider(mem.NewProvider()),
+		)
+		require.NoError(t, err)
+
+		recorder, err := connection.NewRecorder(prov)
+		require.NoError(t, err)
+		require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
+			ConnectionID: "conn-1",
+			State:        connection.StateNameCompleted,
+			MyDID:        myDID,
+			TheirDID:     theirDID,
+		}))
+
+		inboundHandler := prov.InboundMessageHandler()
+
+		msg := fmt.Sprintf(`{"@id": "%s", "@type": "%s", "~thread": {"thid": "%s"}}`, uuid.New(), msgType, "thread-1")
+		require.NoError(t, inboundHandler([]byte(msg), myDID, theirDID))
+
+		record, err := recorder.GetConnectionByThread("thread-1")
+		require.NoError(t, err)
+		require.Equal(t, "conn-1", record.ConnectionID)
+
+		thread, err := recorder.GetThreadRecord("thread-1")
+		require.NoError(t, err)
+		require.Equal(t, "https://didcomm.org/issue-credential/2.0", thread.Protocol)
+		require.Equal(t, msgType, thread.MessageType)
+
+		// message received out of a known connection
+		msg = fmt.Sprintf(`{"@id": "%s", "@type": "%s", "~thread": {"thid": "%s"}}`, uuid.New(), msgType, "thread-2")
+		require.NoError(t, inboundHandler([]byte(msg), myDID, "did:example:carol"))
+
+		_, err = recorder.GetConnectionByThread("thread-2")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "data not found")
+	})
+
+	t.Run("inbound and outbound messages trace their threads", func(t *testing.T) {
+		const protocol = "https://didcomm.org/issue-credential/2.0"
+
+		messenger := serviceMocks.NewMockMessengerHandler(ctrl)
+		messenger.EXPECT().HandleInbound(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		outboundProv, err := New(
+			WithPackager(&mockpackager.Packager{}),
+			WithOutboundTransports(&mockdidcomm.MockOutboundTransport{AcceptValue: true}),
+			WithKMS(&mockkms.KeyManager{}),
+		)
+		require.NoError(t, err)
+
+		outbound := dispatcher.NewOutbound(outboundProv)
+
+		prov, err := New(
+			WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+				HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+					if msg.Type() == protocol+"/request-credential" {
+						return "", errors.New("invalid request")
+					}
+
+					return "", nil
+				},
+			}),
+			WithMessengerHandler(messenger),
+			WithOutboundDispatcher(outbound),
+		)
+		require.NoError(t, err)
+
+		inboundHandler := prov.InboundMessageHandler()
+
+		message := func(msgType, thID string) service.DIDCommMsgMap {
+			msg := service.DIDCommMsgMap{"@id": uuid.New().String(), "@type": protocol + "/" + msgType}
+			if thID != "" {
+				msg["~thread"] = map[string]interface{}{"thid": thID}
+			}
+
+			return msg
+		}
+
+		receive := func(msg service.DIDCommMsgMap) error {
+			msgBytes, errMarshal := msg.MarshalJSON()
+			require.NoError(t, errMarshal)
+
+			return inboundHandler(msgBytes, "did:example:alice", "did:example:bob")
+		}
+
+		// the protocol of thread-1, interleaved with the proposal of another thread
+		proposal := message("propose-credential", "")
+		require.NoError(t, receive(proposal))
+		require.NoError(t, receive(message("propose-credential", "")))
+		require.NoError(t, outbound.Send(message("offer-credential", proposal.ID()), "",
+			&service.Destination{ServiceEndpoint: "http://bob.example.com"}))
+		require.EqualError(t, receive(message("request-credential", proposal.ID())), "invalid request")
+		require.NoError(t, outbound.Send(message("issue-credential", proposal.ID()), "",
+			&service.Destination{ServiceEndpoint: "ws://bob.example.com"}))
+
+		type step struct {
+			direction, msgType, transport, err string
+		}
+
+		var steps []step
+
+		for _, span := range outbound.Tracer().Trace(proposal.ID()) {
+			require.Equal(t, proposal.ID(), span.ThreadID)
+			require.Equal(t, protocol, span.Protocol)
+			require.False(t, span.Time.IsZero())
+
+			steps = append(steps, step{span.Direction, span.MessageType, span.Transport, span.Error})
+		}
+
+		require.Equal(t, []step{
+			{dispatcher.DirectionInbound, protocol + "/propose-credential", "", ""},
+			{dispatcher.DirectionOutbound, protocol + "/offer-credential", "http", ""},
+			{dispatcher.DirectionInbound, protocol + "/request-credential", "", "invalid request"},
+			{dispatcher.DirectionOutbound, protocol + "/issue-credential", "ws", ""},
+		}, steps)
+	})
+
 	t.Run("test new with crypto, KMS, packer and packager services", func(t *testing.T) {
 		prov, err := New(
 			WithKMS(&mockkms.KeyManager{CreateKeyID: "123"}),
@@ -88,6 +88,26 @@ func (mr *MockRegistryMockRecorder) Resolve(arg0 interface{}, arg1 ...interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockRegistry)(nil).Resolve), varargs...)
 }
 
+// ResolveWithMetadata mocks base method
+func (m *MockRegistry) ResolveWithMetadata(arg0 string, arg1 ...vdri.ResolveOpts) (*vdri.ResolveResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResolveWithMetadata", varargs...)
+	ret0, _ := ret[0].(*vdri.ResolveResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveWithMetadata indicates an expected call of ResolveWithMetadata
+func (mr *MockRegistryMockRecorder) ResolveWithMetadata(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveWithMetadata", reflect.TypeOf((*MockRegistry)(nil).ResolveWithMetadata), varargs...)
+}
+
 // Store mocks base method
 func (m *MockRegistry) Store(arg0 *did.Doc) error {
 	m.ctrl.T.Helper()
@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	storage "github.com/hyperledger/aries-framework-go/pkg/storage"
 	reflect "reflect"
+	time "time"
 )
 
 // MockProvider is a mock of Provider interface
@@ -113,6 +114,21 @@ func (mr *MockStoreMockRecorder) Delete(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStore)(nil).Delete), arg0)
 }
 
+// DeletePrefix mocks base method
+func (m *MockStore) DeletePrefix(arg0 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrefix", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePrefix indicates an expected call of DeletePrefix
+func (mr *MockStoreMockRecorder) DeletePrefix(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrefix", reflect.TypeOf((*MockStore)(nil).DeletePrefix), arg0)
+}
+
 // Get mocks base method
 func (m *MockStore) Get(arg0 string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -128,6 +144,37 @@ func (mr *MockStoreMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), arg0)
 }
 
+// GetIncludingDeleted mocks base method
+func (m *MockStore) GetIncludingDeleted(arg0 string) ([]byte, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncludingDeleted", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetIncludingDeleted indicates an expected call of GetIncludingDeleted
+func (mr *MockStoreMockRecorder) GetIncludingDeleted(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncludingDeleted", reflect.TypeOf((*MockStore)(nil).GetIncludingDeleted), arg0)
+}
+
+// Has mocks base method
+func (m *MockStore) Has(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Has", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Has indicates an expected call of Has
+func (mr *MockStoreMockRecorder) Has(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Has", reflect.TypeOf((*MockStore)(nil).Has), arg0)
+}
+
 // Iterator mocks base method
 func (m *MockStore) Iterator(arg0, arg1 string) storage.StoreIterator {
 	m.ctrl.T.Helper()
@@ -142,6 +189,20 @@ func (mr *MockStoreMockRecorder) Iterator(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterator", reflect.TypeOf((*MockStore)(nil).Iterator), arg0, arg1)
 }
 
+// IteratorWithFilter mocks base method
+func (m *MockStore) IteratorWithFilter(arg0, arg1 string, arg2 func(string) bool) storage.StoreIterator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IteratorWithFilter", arg0, arg1, arg2)
+	ret0, _ := ret[0].(storage.StoreIterator)
+	return ret0
+}
+
+// IteratorWithFilter indicates an expected call of IteratorWithFilter
+func (mr *MockStoreMockRecorder) IteratorWithFilter(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IteratorWithFilter", reflect.TypeOf((*MockStore)(nil).IteratorWithFilter), arg0, arg1, arg2)
+}
+
 // Put mocks base method
 func (m *MockStore) Put(arg0 string, arg1 []byte) error {
 	m.ctrl.T.Helper()
@@ -155,3 +216,60 @@ func (mr *MockStoreMockRecorder) Put(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), arg0, arg1)
 }
+
+// PutIfVersion mocks base method
+func (m *MockStore) PutIfVersion(arg0 string, arg1 []byte, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutIfVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutIfVersion indicates an expected call of PutIfVersion
+func (mr *MockStoreMockRecorder) PutIfVersion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutIfVersion", reflect.TypeOf((*MockStore)(nil).PutIfVersion), arg0, arg1, arg2)
+}
+
+// PutWithTTL mocks base method
+func (m *MockStore) PutWithTTL(arg0 string, arg1 []byte, arg2 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutWithTTL", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutWithTTL indicates an expected call of PutWithTTL
+func (mr *MockStoreMockRecorder) PutWithTTL(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutWithTTL", reflect.TypeOf((*MockStore)(nil).PutWithTTL), arg0, arg1, arg2)
+}
+
+// Reset mocks base method
+func (m *MockStore) Reset() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset
+func (mr *MockStoreMockRecorder) Reset() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockStore)(nil).Reset))
+}
+
+// SoftDelete mocks base method
+func (m *MockStore) SoftDelete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete
+func (mr *MockStoreMockRecorder) SoftDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockStore)(nil).SoftDelete), arg0)
+}
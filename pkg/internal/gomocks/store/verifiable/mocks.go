@@ -79,6 +79,21 @@ func (mr *MockStoreMockRecorder) GetCredentials() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentials", reflect.TypeOf((*MockStore)(nil).GetCredentials))
 }
 
+// GetCredentialsByTag mocks base method
+func (m *MockStore) GetCredentialsByTag(arg0, arg1 string) ([]*verifiable0.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentialsByTag", arg0, arg1)
+	ret0, _ := ret[0].([]*verifiable0.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentialsByTag indicates an expected call of GetCredentialsByTag
+func (mr *MockStoreMockRecorder) GetCredentialsByTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialsByTag", reflect.TypeOf((*MockStore)(nil).GetCredentialsByTag), arg0, arg1)
+}
+
 // GetPresentation mocks base method
 func (m *MockStore) GetPresentation(arg0 string) (*verifiable.Presentation, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package passphrase
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// scrypt parameters of the key derivation.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+
+	saltSize = 16
+)
+
+// Encrypted is data encrypted with XChaCha20-Poly1305 under a key derived from a passphrase with scrypt.
+type Encrypted struct {
+	// Salt of the scrypt key derivation
+	Salt []byte
+	// Nonce of the encryption
+	Nonce []byte
+	// Ciphertext of the data
+	Ciphertext []byte
+}
+
+// Encrypt encrypts plaintext under the key derived from passphrase with a random salt.
+func Encrypt(plaintext []byte, passphrase string) (*Encrypted, error) {
+	salt := make([]byte, saltSize)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, fmt.Errorf("generate salt : %w", err)
+	}
+
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, fmt.Errorf("generate nonce : %w", err)
+	}
+
+	return &Encrypted{
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+	}, nil
+}
+
+// Decrypt returns the plaintext of encrypted, it fails if the passphrase is wrong or encrypted was tampered with.
+func Decrypt(encrypted *Encrypted, passphrase string) ([]byte, error) {
+	aead, err := newAEAD(passphrase, encrypted.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encrypted.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+
+	return aead.Open(nil, encrypted.Nonce, encrypted.Ciphertext, nil)
+}
+
+// newAEAD returns the XChaCha20-Poly1305 AEAD keyed with the scrypt key derived from passphrase and salt.
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive key : %w", err)
+	}
+
+	return chacha20poly1305.NewX(key)
+}
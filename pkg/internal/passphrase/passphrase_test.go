@@ -0,0 +1,38 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package passphrase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncrypt(t *testing.T) {
+	plaintext := []byte("secret data")
+
+	encrypted, err := Encrypt(plaintext, "passphrase")
+	require.NoError(t, err)
+	require.Len(t, encrypted.Salt, saltSize)
+	require.NotContains(t, string(encrypted.Ciphertext), string(plaintext))
+
+	decrypted, err := Decrypt(encrypted, "passphrase")
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	// the salt is random
+	other, err := Encrypt(plaintext, "passphrase")
+	require.NoError(t, err)
+	require.NotEqual(t, encrypted.Salt, other.Salt)
+
+	_, err = Decrypt(encrypted, "wrong passphrase")
+	require.Error(t, err)
+
+	_, err = Decrypt(&Encrypted{Salt: encrypted.Salt, Nonce: encrypted.Nonce[1:], Ciphertext: encrypted.Ciphertext},
+		"passphrase")
+	require.EqualError(t, err, "invalid nonce")
+}
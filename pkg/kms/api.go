@@ -120,6 +120,8 @@ const (
 	ECDH1PU384AES256GCM = "ECDH1PU384AES256GCM"
 	// ECDH1PU521AES256GCM key type value.
 	ECDH1PU521AES256GCM = "ECDH1PU521AES256GCM"
+	// BLS12381G2 BBS+ key type value.
+	BLS12381G2 = "BLS12381G2"
 )
 
 // KeyType represents a key type supported by the KMS.
@@ -170,4 +172,6 @@ const (
 	ECDH1PU384AES256GCMType = KeyType(ECDH1PU384AES256GCM)
 	// ECDH1PU521AES256GCMType key type value.
 	ECDH1PU521AES256GCMType = KeyType(ECDH1PU521AES256GCM)
+	// BLS12381G2Type BBS+ key type value.
+	BLS12381G2Type = KeyType(BLS12381G2)
 )
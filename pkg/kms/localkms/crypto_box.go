@@ -7,23 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package localkms
 
 import (
-	"bytes"
 	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"io"
 
 	"github.com/golang/protobuf/proto"
-	"github.com/google/tink/go/aead"
-	"github.com/google/tink/go/keyset"
 	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"golang.org/x/crypto/nacl/box"
 
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
-	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
-	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 )
 
 // TODO: move CryptoBox out of the KMS package.
@@ -185,49 +180,15 @@ func (b *CryptoBox) SealOpen(cipherText, myPub []byte) ([]byte, error) {
 
 // exportEncPrivKeyBytes temporary support function for crypto_box to be used with legacyPacker only.
 func (l *LocalKMS) exportEncPrivKeyBytes(id string) ([]byte, error) {
-	kh, err := l.getKeySet(id)
+	ks, err := l.privateKeySet(id)
 	if err != nil {
 		return nil, err
 	}
 
-	buf := new(bytes.Buffer)
-	bWriter := keyset.NewBinaryWriter(buf)
-
-	kw, err := keywrapper.New(&noop.NoLock{}, "local-lock://tmp")
-	if err != nil {
-		return nil, err
-	}
-
-	primaryKeyEnvAEAD := aead.NewKMSEnvelopeAEAD(*aead.AES256GCMKeyTemplate(), kw)
-
-	err = kh.Write(bWriter, primaryKeyEnvAEAD)
-	if err != nil {
-		return nil, err
-	}
-
-	encryptedKS := &tinkpb.EncryptedKeyset{}
-
-	err = proto.Unmarshal(buf.Bytes(), encryptedKS)
-	if err != nil {
-		return nil, err
-	}
-
-	decryptedKS, err := primaryKeyEnvAEAD.Decrypt(encryptedKS.EncryptedKeyset, []byte{})
-	if err != nil {
-		return nil, err
-	}
-
-	return extractPrivKey(decryptedKS)
+	return extractPrivKey(ks)
 }
 
-func extractPrivKey(marshalledKeySet []byte) ([]byte, error) {
-	ks := &tinkpb.Keyset{}
-
-	err := proto.Unmarshal(marshalledKeySet, ks)
-	if err != nil {
-		return nil, err
-	}
-
+func extractPrivKey(ks *tinkpb.Keyset) ([]byte, error) {
 	for _, key := range ks.Key {
 		if key.KeyId != ks.PrimaryKeyId || key.Status != tinkpb.KeyStatusType_ENABLED {
 			continue
@@ -235,7 +196,7 @@ func extractPrivKey(marshalledKeySet []byte) ([]byte, error) {
 
 		prvKey := &ed25519pb.Ed25519PrivateKey{}
 
-		err = proto.Unmarshal(key.KeyData.Value, prvKey)
+		err := proto.Unmarshal(key.KeyData.Value, prvKey)
 		if err != nil {
 			return nil, err
 		}
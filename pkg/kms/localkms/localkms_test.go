@@ -268,6 +268,7 @@ func TestLocalKMS_Success(t *testing.T) {
 		kms.ECDH1PU256AES256GCMType,
 		kms.ECDH1PU384AES256GCMType,
 		kms.ECDH1PU521AES256GCMType,
+		kms.BLS12381G2Type,
 	}
 
 	for _, v := range keyTemplates {
@@ -325,7 +326,7 @@ func TestLocalKMS_Success(t *testing.T) {
 		require.Equal(t, len(newKHPrimitives.Entries), len(rotatedKHPrimitives.Entries))
 		require.Equal(t, len(readKHPrimitives.Entries), len(rotatedKHPrimitives.Entries))
 
-		if strings.Contains(string(v), "ECDSA") || v == kms.ED25519Type {
+		if strings.Contains(string(v), "ECDSA") || v == kms.ED25519Type || v == kms.BLS12381G2Type {
 			pubKeyBytes, e := kmsService.ExportPubKeyBytes(keyID)
 			require.Errorf(t, e, "KeyID has been rotated. An error must be returned")
 			require.Empty(t, pubKeyBytes)
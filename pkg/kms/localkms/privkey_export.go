@@ -0,0 +1,168 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+// ecdsaKeyType identifies the ECDSA key type of the curve and signature encoding of ECDSA key sets.
+type ecdsaKeyType struct {
+	curve    commonpb.EllipticCurveType
+	encoding ecdsapb.EcdsaSignatureEncoding
+}
+
+// nolint:gochecknoglobals
+var ecdsaKeyTypes = map[ecdsaKeyType]kms.KeyType{
+	{commonpb.EllipticCurveType_NIST_P256, ecdsapb.EcdsaSignatureEncoding_DER}:        kms.ECDSAP256TypeDER,
+	{commonpb.EllipticCurveType_NIST_P384, ecdsapb.EcdsaSignatureEncoding_DER}:        kms.ECDSAP384TypeDER,
+	{commonpb.EllipticCurveType_NIST_P521, ecdsapb.EcdsaSignatureEncoding_DER}:        kms.ECDSAP521TypeDER,
+	{commonpb.EllipticCurveType_NIST_P256, ecdsapb.EcdsaSignatureEncoding_IEEE_P1363}: kms.ECDSAP256TypeIEEEP1363,
+	{commonpb.EllipticCurveType_NIST_P384, ecdsapb.EcdsaSignatureEncoding_IEEE_P1363}: kms.ECDSAP384TypeIEEEP1363,
+	{commonpb.EllipticCurveType_NIST_P521, ecdsapb.EcdsaSignatureEncoding_IEEE_P1363}: kms.ECDSAP521TypeIEEEP1363,
+}
+
+// ExportPrivateKey exports the private key of the signing key set of id (ed25519.PrivateKey or *ecdsa.PrivateKey)
+// along with its key type, for the key to be imported back with ImportPrivateKey. The private key is exported in
+// the clear, callers must protect it (eg: encrypt it) before moving it out of the agent.
+func (l *LocalKMS) ExportPrivateKey(id string) (interface{}, kms.KeyType, error) {
+	ks, err := l.privateKeySet(id)
+	if err != nil {
+		return nil, "", fmt.Errorf("export private key failed: %w", err)
+	}
+
+	for _, key := range ks.Key {
+		if key.KeyId != ks.PrimaryKeyId || key.Status != tinkpb.KeyStatusType_ENABLED {
+			continue
+		}
+
+		switch key.KeyData.TypeUrl {
+		case ed25519SignerTypeURL:
+			return exportEd25519Key(key.KeyData.Value)
+		case ecdsaSignerTypeURL:
+			return exportECDSAKey(key.KeyData.Value)
+		default:
+			return nil, "", fmt.Errorf("export private key failed: key type %s not supported", key.KeyData.TypeUrl)
+		}
+	}
+
+	return nil, "", errors.New("export private key failed: private key not found")
+}
+
+func exportEd25519Key(marshalledKey []byte) (interface{}, kms.KeyType, error) {
+	privKey := &ed25519pb.Ed25519PrivateKey{}
+
+	err := proto.Unmarshal(marshalledKey, privKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("export private ED25519 key failed: %w", err)
+	}
+
+	if len(privKey.KeyValue) != ed25519.SeedSize {
+		return nil, "", errors.New("export private ED25519 key failed: invalid key size")
+	}
+
+	return ed25519.NewKeyFromSeed(privKey.KeyValue), kms.ED25519Type, nil
+}
+
+func exportECDSAKey(marshalledKey []byte) (interface{}, kms.KeyType, error) {
+	privKey := &ecdsapb.EcdsaPrivateKey{}
+
+	err := proto.Unmarshal(marshalledKey, privKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("export private EC key failed: %w", err)
+	}
+
+	params := privKey.GetPublicKey().GetParams()
+
+	kt, ok := ecdsaKeyTypes[ecdsaKeyType{curve: params.GetCurve(), encoding: params.GetEncoding()}]
+	if !ok {
+		return nil, "", errors.New("export private EC key failed: invalid ECDSA key type")
+	}
+
+	var curve elliptic.Curve
+
+	switch params.GetCurve() {
+	case commonpb.EllipticCurveType_NIST_P256:
+		curve = elliptic.P256()
+	case commonpb.EllipticCurveType_NIST_P384:
+		curve = elliptic.P384()
+	default:
+		curve = elliptic.P521()
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(privKey.PublicKey.X),
+			Y:     new(big.Int).SetBytes(privKey.PublicKey.Y),
+		},
+		D: new(big.Int).SetBytes(privKey.KeyValue),
+	}, kt, nil
+}
+
+// privateKeySet returns the key set of id with its private key material, the key set is written encrypted with a
+// temporary key then decrypted since tink doesn't hand out the private key material of key set handles.
+func (l *LocalKMS) privateKeySet(id string) (*tinkpb.Keyset, error) {
+	kh, err := l.getKeySet(id)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	bWriter := keyset.NewBinaryWriter(buf)
+
+	kw, err := keywrapper.New(&noop.NoLock{}, "local-lock://tmp")
+	if err != nil {
+		return nil, err
+	}
+
+	primaryKeyEnvAEAD := aead.NewKMSEnvelopeAEAD(*aead.AES256GCMKeyTemplate(), kw)
+
+	err = kh.Write(bWriter, primaryKeyEnvAEAD)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedKS := &tinkpb.EncryptedKeyset{}
+
+	err = proto.Unmarshal(buf.Bytes(), encryptedKS)
+	if err != nil {
+		return nil, err
+	}
+
+	decryptedKS, err := primaryKeyEnvAEAD.Decrypt(encryptedKS.EncryptedKeyset, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &tinkpb.Keyset{}
+
+	err = proto.Unmarshal(decryptedKS, ks)
+	if err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestLocalKMS_ExportPrivateKey(t *testing.T) {
+	t.Run("export the private keys of signing key sets", func(t *testing.T) {
+		for _, kt := range []kms.KeyType{
+			kms.ED25519Type,
+			kms.ECDSAP256TypeDER,
+			kms.ECDSAP384TypeDER,
+			kms.ECDSAP256TypeIEEEP1363,
+			kms.ECDSAP521TypeIEEEP1363,
+		} {
+			k := createKMS(t)
+
+			keyID, pubKey, err := k.CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err, kt)
+
+			privKey, exportedType, err := k.ExportPrivateKey(keyID)
+			require.NoError(t, err, kt)
+			require.Equal(t, kt, exportedType)
+
+			if kt == kms.ED25519Type {
+				require.IsType(t, ed25519.PrivateKey{}, privKey)
+				require.Equal(t, ed25519.PublicKey(pubKey), privKey.(ed25519.PrivateKey).Public())
+			} else {
+				require.IsType(t, &ecdsa.PrivateKey{}, privKey)
+			}
+
+			// the exported key is imported back as the same key in another KMS
+			other := createKMS(t)
+
+			_, _, err = other.ImportPrivateKey(privKey, exportedType, kms.WithKeyID(keyID))
+			require.NoError(t, err, kt)
+
+			importedPubKey, err := other.ExportPubKeyBytes(keyID)
+			require.NoError(t, err, kt)
+			require.Equal(t, pubKey, importedPubKey, kt)
+		}
+	})
+
+	t.Run("export the private key of a key set other than a signing key set", func(t *testing.T) {
+		k := createKMS(t)
+
+		keyID, _, err := k.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+
+		_, _, err = k.ExportPrivateKey(keyID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "export private key failed: key type")
+		require.Contains(t, err.Error(), "not supported")
+	})
+
+	t.Run("export the private key of an unknown key set", func(t *testing.T) {
+		_, _, err := createKMS(t).ExportPrivateKey("unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "export private key failed")
+	})
+}
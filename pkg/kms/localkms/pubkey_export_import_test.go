@@ -18,6 +18,7 @@ import (
 	"github.com/google/tink/go/signature"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -70,6 +71,11 @@ func TestPubKeyExportAndRead(t *testing.T) {
 			keyTemplate: signature.ED25519KeyWithoutPrefixTemplate(),
 			doSign:      true,
 		},
+		{
+			tcName:      "export then read BLS12381G2 public key",
+			keyType:     kms.BLS12381G2Type,
+			keyTemplate: bbs.BLS12381G2KeyTemplate(),
+		},
 	}
 
 	for _, tc := range flagTests {
@@ -99,6 +105,22 @@ func TestPubKeyExportAndRead(t *testing.T) {
 				err = verifier.Verify(s, msg)
 				require.NoError(t, err)
 			}
+
+			if tt.keyType == kms.BLS12381G2Type {
+				// test BBS+ signing with origKH then verifying with kh read from exported public key
+				messages := [][]byte{[]byte("Lorem ipsum dolor sit amet,"), []byte("consectetur adipiscing elit.")}
+				signer, err := bbs.NewSigner(origKH)
+				require.NoError(t, err)
+
+				s, err := signer.Sign(messages)
+				require.NoError(t, err)
+
+				verifier, err := bbs.NewVerifier(kh)
+				require.NoError(t, err)
+
+				err = verifier.Verify(messages, s)
+				require.NoError(t, err)
+			}
 		})
 	}
 }
@@ -21,6 +21,7 @@ import (
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/subtle"
 
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -147,6 +148,22 @@ func getMarshalledProtoKeyAndKeyURL(pubKey []byte, kt kms.KeyType) ([]byte, stri
 		pubKeyProto.KeyValue = make([]byte, len(pubKey))
 		copy(pubKeyProto.KeyValue, pubKey)
 
+		keyValue, err = proto.Marshal(pubKeyProto)
+		if err != nil {
+			return nil, "", err
+		}
+	case kms.BLS12381G2Type:
+		tURL = bbsVerifierKeyTypeURL
+		pubKeyProto := new(bbspb.BBSPublicKey)
+		pubKeyProto.Version = 0
+		pubKeyProto.Params = &bbspb.BBSParams{
+			HashType: commonpb.HashType_SHA256,
+			Curve:    bbspb.BBSCurveType_BLS12_381,
+			Group:    bbspb.GroupField_G2,
+		}
+		pubKeyProto.KeyValue = make([]byte, len(pubKey))
+		copy(pubKeyProto.KeyValue, pubKey)
+
 		keyValue, err = proto.Marshal(pubKeyProto)
 		if err != nil {
 			return nil, "", err
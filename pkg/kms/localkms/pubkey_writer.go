@@ -22,6 +22,7 @@ import (
 	"github.com/google/tink/go/subtle"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/keyio"
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
 )
 
 const (
@@ -29,6 +30,7 @@ const (
 	ed25519VerifierTypeURL     = "type.googleapis.com/google.crypto.tink.Ed25519PublicKey"
 	ecdhesAESPublicKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.EcdhesAesAeadPublicKey"
 	ecdh1puAESPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.Ecdh1puAesAeadPublicKey"
+	bbsVerifierKeyTypeURL      = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPublicKey"
 )
 
 // PubKeyWriter will write the raw bytes of a Tink KeySet's primary public key
@@ -66,7 +68,7 @@ func write(w io.Writer, msg *tinkpb.Keyset) error {
 	for _, key := range ks {
 		if key.KeyId == primaryKID && key.Status == tinkpb.KeyStatusType_ENABLED {
 			switch key.KeyData.TypeUrl {
-			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL:
+			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL, bbsVerifierKeyTypeURL:
 				created, err = writePubKey(w, key)
 				if err != nil {
 					return err
@@ -120,6 +122,16 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) {
 			return false, err
 		}
 
+		marshaledRawPubKey = make([]byte, len(pubKeyProto.KeyValue))
+		copy(marshaledRawPubKey, pubKeyProto.KeyValue)
+	case bbsVerifierKeyTypeURL:
+		pubKeyProto := new(bbspb.BBSPublicKey)
+
+		err := proto.Unmarshal(key.KeyData.Value, pubKeyProto)
+		if err != nil {
+			return false, err
+		}
+
 		marshaledRawPubKey = make([]byte, len(pubKeyProto.KeyValue))
 		copy(marshaledRawPubKey, pubKeyProto.KeyValue)
 	default:
@@ -24,6 +24,9 @@ type Crypto struct {
 	ComputeMACValue   []byte
 	ComputeMACErr     error
 	VerifyMACErr      error
+	SignMultiValue    []byte
+	SignMultiErr      error
+	VerifyMultiErr    error
 }
 
 // Encrypt returns mocked values and a mocked error.
@@ -59,3 +62,13 @@ func (c *Crypto) ComputeMAC(data []byte, kh interface{}) ([]byte, error) {
 func (c *Crypto) VerifyMAC(mac, data []byte, kh interface{}) error {
 	return c.VerifyMACErr
 }
+
+// SignMulti returns a mocked value and a mocked error.
+func (c *Crypto) SignMulti(messages [][]byte, kh interface{}) ([]byte, error) {
+	return c.SignMultiValue, c.SignMultiErr
+}
+
+// VerifyMulti returns a mocked value.
+func (c *Crypto) VerifyMulti(messages [][]byte, signature []byte, kh interface{}) error {
+	return c.VerifyMultiErr
+}
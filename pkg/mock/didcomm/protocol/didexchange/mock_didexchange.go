@@ -41,6 +41,7 @@ type MockDIDExchangeSvc struct {
 	RespondToFunc            func(*didexchange.OOBInvitation) (string, error)
 	SaveFunc                 func(invitation *didexchange.OOBInvitation) error
 	CreateConnRecordFunc     func(*connection.Record, *did.Doc) error
+	ActionsFunc              func() ([]didexchange.Action, error)
 }
 
 // HandleInbound msg.
@@ -169,6 +170,15 @@ func (m *MockDIDExchangeSvc) CreateConnection(r *connection.Record, theirDID *di
 	return nil
 }
 
+// Actions returns the pending actions.
+func (m *MockDIDExchangeSvc) Actions() ([]didexchange.Action, error) {
+	if m.ActionsFunc != nil {
+		return m.ActionsFunc()
+	}
+
+	return nil, nil
+}
+
 // MockProvider is provider for DIDExchange Service.
 type MockProvider struct {
 	StoreProvider              *mockstore.MockStoreProvider
@@ -30,6 +30,7 @@ type MockMediatorSvc struct {
 	ConnectionID       string
 	GetConnectionIDErr error
 	AddKeyFunc         func(string) error
+	KeylistQueryFunc   func(limit, offset int) (*mediator.Keylist, error)
 }
 
 // HandleInbound msg.
@@ -117,3 +118,12 @@ func (m *MockMediatorSvc) GetConnection() (string, error) {
 
 	return m.ConnectionID, nil
 }
+
+// KeylistQuery queries the router for a page of the recipient keys.
+func (m *MockMediatorSvc) KeylistQuery(limit, offset int) (*mediator.Keylist, error) {
+	if m.KeylistQueryFunc != nil {
+		return m.KeylistQueryFunc(limit, offset)
+	}
+
+	return &mediator.Keylist{Pagination: &mediator.Pagination{Offset: offset}}, nil
+}
@@ -15,18 +15,20 @@ import (
 // MockMessagePickupSvc mock messagepickup service.
 type MockMessagePickupSvc struct {
 	service.DIDComm
-	ProtocolName       string
-	StatusRequestErr   error
-	StatusRequestFunc  func(connectionID string) (*messagepickup.Status, error)
-	BatchPickupErr     error
-	BatchPickupFunc    func(connectionID string, size int) (int, error)
-	HandleInboundFunc  func(msg service.DIDCommMsg, myDID, theirDID string) (string, error)
-	HandleOutboundFunc func(_ service.DIDCommMsg, _, _ string) (string, error)
-	AddMessageFunc     func(message *model.Envelope, theirDID string) error
-	AddMessageErr      error
-	AcceptFunc         func(msgType string) bool
-	NoopErr            error
-	NoopFunc           func(connectionID string) error
+	ProtocolName        string
+	StatusRequestErr    error
+	StatusRequestFunc   func(connectionID string) (*messagepickup.Status, error)
+	BatchPickupErr      error
+	BatchPickupFunc     func(connectionID string, size int) (int, error)
+	DeliveryRequestErr  error
+	DeliveryRequestFunc func(connectionID string, size int) (*messagepickup.Batch, error)
+	HandleInboundFunc   func(msg service.DIDCommMsg, myDID, theirDID string) (string, error)
+	HandleOutboundFunc  func(_ service.DIDCommMsg, _, _ string) (string, error)
+	AddMessageFunc      func(message *model.Envelope, theirDID string) error
+	AddMessageErr       error
+	AcceptFunc          func(msgType string) bool
+	NoopErr             error
+	NoopFunc            func(connectionID string) error
 }
 
 // Name return service name.
@@ -64,6 +66,19 @@ func (m *MockMessagePickupSvc) BatchPickup(connectionID string, size int) (int,
 	return 0, nil
 }
 
+// DeliveryRequest perform DeliveryRequest.
+func (m *MockMessagePickupSvc) DeliveryRequest(connectionID string, size int) (*messagepickup.Batch, error) {
+	if m.DeliveryRequestErr != nil {
+		return nil, m.DeliveryRequestErr
+	}
+
+	if m.DeliveryRequestFunc != nil {
+		return m.DeliveryRequestFunc(connectionID, size)
+	}
+
+	return &messagepickup.Batch{}, nil
+}
+
 // HandleInbound msg.
 func (m *MockMessagePickupSvc) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
 	if m.HandleInboundFunc != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -34,6 +35,7 @@ type MockProvider struct {
 	CustomKMS                  kms.KeyManager
 	CustomLock                 secretlock.Service
 	CustomCrypto               *mockcrypto.Crypto
+	CustomPackers              []packer.Packer
 	ServiceErr                 error
 	ServiceMap                 map[string]interface{}
 	InboundMsgHandler          transport.InboundMessageHandler
@@ -117,6 +119,11 @@ func (p *MockProvider) Messenger() service.Messenger {
 	return &mockservice.MockMessenger{}
 }
 
+// Packers returns the custom packers.
+func (p *MockProvider) Packers() []packer.Packer {
+	return p.CustomPackers
+}
+
 // InboundMessageHandler handles an unpacked inbound message.
 func (p *MockProvider) InboundMessageHandler() transport.InboundMessageHandler {
 	return p.InboundMsgHandler
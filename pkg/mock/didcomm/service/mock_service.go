@@ -14,6 +14,9 @@ type MockMessenger struct {
 	ErrReplyToNested     error
 	ErrSend              error
 	ErrSendToDestination error
+	ErrResend            error
+	SendFunc             func(msg service.DIDCommMsgMap, myDID, theirDID string) error
+	ResendFunc           func(thID string) error
 }
 
 // ReplyTo mock messenger reply to.
@@ -31,6 +34,10 @@ func (m *MockMessenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string)
 		return m.ErrSend
 	}
 
+	if m.SendFunc != nil {
+		return m.SendFunc(msg, myDID, theirDID)
+	}
+
 	return nil
 }
 
@@ -52,3 +59,16 @@ func (m *MockMessenger) SendToDestination(msg service.DIDCommMsgMap, sender stri
 
 	return nil
 }
+
+// Resend mock messenger Resend.
+func (m *MockMessenger) Resend(thID string) error {
+	if m.ErrResend != nil {
+		return m.ErrResend
+	}
+
+	if m.ResendFunc != nil {
+		return m.ResendFunc(thID)
+	}
+
+	return nil
+}
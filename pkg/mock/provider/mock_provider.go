@@ -7,6 +7,7 @@ package provider
 
 import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
@@ -28,6 +29,7 @@ type Provider struct {
 	OutboundDispatcherValue           dispatcher.Outbound
 	VDRIRegistryValue                 vdriapi.Registry
 	CryptoValue                       crypto.Crypto
+	MessengerValue                    service.Messenger
 }
 
 // Service return service.
@@ -92,3 +94,8 @@ func (p *Provider) OutboundDispatcher() dispatcher.Outbound {
 func (p *Provider) VDRIRegistry() vdriapi.Registry {
 	return p.VDRIRegistryValue
 }
+
+// Messenger return messenger.
+func (p *Provider) Messenger() service.Messenger {
+	return p.MessengerValue
+}
@@ -8,8 +8,10 @@ package storage
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
@@ -66,6 +68,10 @@ type MockStore struct {
 	ErrGet    error
 	ErrItr    error
 	ErrDelete error
+	// versions holds the versions of the records written through the store, taken from a counter of the store so
+	// that a record put back to a previous value doesn't get back a previous version
+	versions    map[string]uint64
+	lastVersion uint64
 }
 
 // Put stores the key and the record.
@@ -80,11 +86,66 @@ func (s *MockStore) Put(k string, v []byte) error {
 
 	s.lock.Lock()
 	s.Store[k] = v
+	s.newVersion(k)
 	s.lock.Unlock()
 
 	return s.ErrPut
 }
 
+// PutWithTTL stores the key and the record, records don't expire in the mock store.
+func (s *MockStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return storage.ErrInvalidTTL
+	}
+
+	return s.Put(k, v)
+}
+
+// PutIfVersion stores the key and the record if the version of the record is expectedVersion, the versions are
+// bumped by each write of the record through the store.
+func (s *MockStore) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	if k == "" {
+		return "", errors.New("key is mandatory")
+	}
+
+	if s.ErrPut != nil {
+		return "", s.ErrPut
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.currentVersion(k) != expectedVersion {
+		return "", storage.ErrConflict
+	}
+
+	s.Store[k] = v
+
+	return s.newVersion(k), nil
+}
+
+// currentVersion returns the version of the record with k key, empty if absent, caller holds the lock.
+func (s *MockStore) currentVersion(k string) string {
+	val, ok := s.Store[k]
+	if !ok || storage.IsTombstone(val) {
+		return ""
+	}
+
+	return strconv.FormatUint(s.versions[k], 10)
+}
+
+// newVersion sets the next version of the store to the record with k key, caller holds the lock.
+func (s *MockStore) newVersion(k string) string {
+	if s.versions == nil {
+		s.versions = make(map[string]uint64)
+	}
+
+	s.lastVersion++
+	s.versions[k] = s.lastVersion
+
+	return strconv.FormatUint(s.lastVersion, 10)
+}
+
 // Get fetches the record based on key.
 func (s *MockStore) Get(k string) ([]byte, error) {
 	if s.ErrGet != nil {
@@ -95,13 +156,42 @@ func (s *MockStore) Get(k string) ([]byte, error) {
 	defer s.lock.RUnlock()
 
 	val, ok := s.Store[k]
-	if !ok {
+	if !ok || storage.IsTombstone(val) {
 		return nil, storage.ErrDataNotFound
 	}
 
 	return val, s.ErrGet
 }
 
+// Has tells whether the record with k key exists.
+func (s *MockStore) Has(k string) (bool, error) {
+	_, err := s.Get(k)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// GetIncludingDeleted fetches the record based on key, including soft deleted records.
+func (s *MockStore) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	if s.ErrGet != nil {
+		return nil, false, s.ErrGet
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	val, ok := s.Store[k]
+	if !ok {
+		return nil, false, storage.ErrDataNotFound
+	}
+
+	val, deleted := storage.ParseTombstone(val)
+
+	return val, deleted, nil
+}
+
 // Iterator returns an iterator for the underlying mockstore.
 func (s *MockStore) Iterator(start, limit string) storage.StoreIterator {
 	if s.ErrItr != nil {
@@ -114,7 +204,7 @@ func (s *MockStore) Iterator(start, limit string) storage.StoreIterator {
 	var batch [][]string
 
 	for k, v := range s.Store {
-		if strings.HasPrefix(k, start) {
+		if strings.HasPrefix(k, start) && !storage.IsTombstone(v) {
 			batch = append(batch, []string{k, string(v)})
 		}
 	}
@@ -122,15 +212,81 @@ func (s *MockStore) Iterator(start, limit string) storage.StoreIterator {
 	return NewMockIterator(batch)
 }
 
+// IteratorWithFilter returns an iterator for the underlying mockstore skipping the records with keys rejected by
+// keyPredicate.
+func (s *MockStore) IteratorWithFilter(start, limit string, keyPredicate func(key string) bool) storage.StoreIterator {
+	return storage.FilterKeys(s.Iterator(start, limit), keyPredicate)
+}
+
 // Delete will delete record with k key.
 func (s *MockStore) Delete(k string) error {
 	s.lock.Lock()
 	delete(s.Store, k)
+	delete(s.versions, k)
 	s.lock.Unlock()
 
 	return s.ErrDelete
 }
 
+// DeletePrefix will delete the records with keys starting with prefix, soft deleted records aren't counted.
+func (s *MockStore) DeletePrefix(prefix string) (int, error) {
+	if s.ErrDelete != nil {
+		return 0, s.ErrDelete
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var deleted int
+
+	for k := range s.Store {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		if !storage.IsTombstone(s.Store[k]) {
+			deleted++
+		}
+
+		delete(s.Store, k)
+		delete(s.versions, k)
+	}
+
+	return deleted, nil
+}
+
+// Reset will delete all the records.
+func (s *MockStore) Reset() error {
+	if s.ErrDelete != nil {
+		return s.ErrDelete
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.Store = make(map[string][]byte)
+	s.versions = nil
+
+	return nil
+}
+
+// SoftDelete will replace record with k key by its tombstone.
+func (s *MockStore) SoftDelete(k string) error {
+	if s.ErrDelete != nil {
+		return s.ErrDelete
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if val, ok := s.Store[k]; ok && !storage.IsTombstone(val) {
+		s.Store[k] = storage.NewTombstone(val)
+		s.newVersion(k)
+	}
+
+	return nil
+}
+
 // NewMockIterator returns new mock iterator for given batch.
 func NewMockIterator(batch [][]string) *MockIterator {
 	if len(batch) == 0 {
@@ -199,3 +355,8 @@ func (s *MockIterator) Value() []byte {
 
 	return []byte(s.currentItem[1])
 }
+
+// TotalItems returns the number of key/value pairs of the iterator.
+func (s *MockIterator) TotalItems() int {
+	return len(s.items)
+}
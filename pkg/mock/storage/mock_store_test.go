@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+func TestMockStore_PutIfVersion(t *testing.T) {
+	const key = "did:example:1234"
+
+	store := &MockStore{Store: make(map[string][]byte)}
+
+	// create if absent
+	v1, err := store.PutIfVersion(key, []byte("value1"), "")
+	require.NoError(t, err)
+	require.NotEmpty(t, v1)
+
+	_, err = store.PutIfVersion(key, []byte("value2"), "")
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	v2, err := store.PutIfVersion(key, []byte("value2"), v1)
+	require.NoError(t, err)
+	require.NotEqual(t, v1, v2)
+
+	// conflicting CAS, with the stale version of a record put back to its previous value
+	v3, err := store.PutIfVersion(key, []byte("value1"), v2)
+	require.NoError(t, err)
+	require.NotEqual(t, v1, v3)
+
+	_, err = store.PutIfVersion(key, []byte("value3"), v1)
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	// Put changes the version
+	require.NoError(t, store.Put(key, []byte("value1")))
+
+	_, err = store.PutIfVersion(key, []byte("value3"), v3)
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	doc, err := store.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), doc)
+
+	// soft deleted records are absent
+	require.NoError(t, store.SoftDelete(key))
+
+	_, err = store.PutIfVersion(key, []byte("value3"), "")
+	require.NoError(t, err)
+
+	store.ErrPut = errors.New("put error")
+
+	_, err = store.PutIfVersion(key, []byte("value4"), "")
+	require.EqualError(t, err, "put error")
+}
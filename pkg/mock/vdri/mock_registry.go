@@ -27,6 +27,9 @@ type MockVDRIRegistry struct {
 	ResolveErr   error
 	ResolveValue *did.Doc
 	ResolveFunc  func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error)
+
+	ResolveMetadata         vdriapi.ResolutionMetadata
+	ResolveWithMetadataFunc func(didID string, opts ...vdriapi.ResolveOpts) (*vdriapi.ResolveResult, error)
 }
 
 // Store stores the key and the record.
@@ -81,6 +84,21 @@ func (m *MockVDRIRegistry) Resolve(didID string, opts ...vdriapi.ResolveOpts) (*
 	return m.ResolveValue, nil
 }
 
+// ResolveWithMetadata did document along with ResolveMetadata.
+func (m *MockVDRIRegistry) ResolveWithMetadata(didID string,
+	opts ...vdriapi.ResolveOpts) (*vdriapi.ResolveResult, error) {
+	if m.ResolveWithMetadataFunc != nil {
+		return m.ResolveWithMetadataFunc(didID, opts...)
+	}
+
+	doc, err := m.Resolve(didID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vdriapi.ResolveResult{Doc: doc, Metadata: m.ResolveMetadata}, nil
+}
+
 // Close frees resources being maintained by vdri.
 func (m *MockVDRIRegistry) Close() error {
 	return nil
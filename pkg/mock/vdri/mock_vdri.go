@@ -14,11 +14,14 @@ import (
 // MockVDRI mock implementation of vdri
 // to be used only for unit tests.
 type MockVDRI struct {
-	AcceptValue bool
-	StoreErr    error
-	ReadFunc    func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error)
-	BuildFunc   func(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc, error)
-	CloseErr    error
+	AcceptValue  bool
+	StoreErr     error
+	ReadFunc     func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error)
+	BuildFunc    func(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc, error)
+	CloseErr     error
+	MethodsValue []vdriapi.MethodCapabilities
+
+	ReadWithMetadataFunc func(didID string, opts ...vdriapi.ResolveOpts) (*vdriapi.ResolveResult, error)
 }
 
 // Read did.
@@ -30,6 +33,25 @@ func (m *MockVDRI) Read(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, er
 	return nil, nil
 }
 
+// ReadWithMetadata did along with its resolution metadata.
+func (m *MockVDRI) ReadWithMetadata(didID string, opts ...vdriapi.ResolveOpts) (*vdriapi.ResolveResult, error) {
+	if m.ReadWithMetadataFunc != nil {
+		return m.ReadWithMetadataFunc(didID, opts...)
+	}
+
+	doc, err := m.Read(didID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vdriapi.ResolveResult{Doc: doc}, nil
+}
+
+// Methods described by the vdri.
+func (m *MockVDRI) Methods() []vdriapi.MethodCapabilities {
+	return m.MethodsValue
+}
+
 // Store did.
 func (m *MockVDRI) Store(doc *did.Doc, by *[]vdriapi.ModifiedBy) error {
 	return m.StoreErr
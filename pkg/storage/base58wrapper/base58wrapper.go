@@ -6,6 +6,9 @@ SPDX-License-Identifier: Apache-2.0
 package base58wrapper
 
 import (
+	"errors"
+	"time"
+
 	"github.com/btcsuite/btcutil/base58"
 
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
@@ -40,6 +43,22 @@ func (b *Base58StoreWrapper) Put(k string, v []byte) error {
 	return b.store.Put(b58k, v)
 }
 
+// PutWithTTL stores the key and the record expiring after ttl by converting the key from base64 to base58 encoded
+// value first.
+func (b *Base58StoreWrapper) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	b58k := convert(k)
+
+	return b.store.PutWithTTL(b58k, v, ttl)
+}
+
+// PutIfVersion stores the key and the record if the version of the record is expectedVersion by converting the key
+// from base64 to base58 encoded value first.
+func (b *Base58StoreWrapper) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	b58k := convert(k)
+
+	return b.store.PutIfVersion(b58k, v, expectedVersion)
+}
+
 // Get fetches the record based on key by converting the key ID from base58 to base64 encoded value first.
 func (b *Base58StoreWrapper) Get(k string) ([]byte, error) {
 	b58k := convert(k)
@@ -62,7 +81,22 @@ func (b *Base58StoreWrapper) Iterator(startKey, endKey string) storage.StoreIter
 	// eg `abc_123` encoded is "4h3c6pUcw4" and `abc_` encoded "3VNr6J" which is not the prefix of `abc_123` base58
 	// encoded.  This means StoreIterator cannot iterate through base58 encoded keys.
 	// For this reason, this wrapper doesn't support the iterator.
-	return nil
+	return storage.EmptyIterator(errors.New("base58 wrapper doesn't support iterating"))
+}
+
+// IteratorWithFilter isn't supported as base58 encoded keys don't keep the prefixes of their keys, see Iterator.
+func (b *Base58StoreWrapper) IteratorWithFilter(startKey, endKey string, _ func(string) bool) storage.StoreIterator {
+	return b.Iterator(startKey, endKey)
+}
+
+// DeletePrefix isn't supported as base58 encoded keys don't keep the prefixes of their keys, see Iterator.
+func (b *Base58StoreWrapper) DeletePrefix(string) (int, error) {
+	return 0, errors.New("base58 wrapper doesn't support deleting by prefix")
+}
+
+// Reset deletes all the records of the underlying store.
+func (b *Base58StoreWrapper) Reset() error {
+	return b.store.Reset()
 }
 
 // Delete will delete a record with k by converting it from base64 to base58 encoded value first.
@@ -71,3 +105,25 @@ func (b *Base58StoreWrapper) Delete(k string) error {
 
 	return b.store.Delete(b58k)
 }
+
+// SoftDelete will soft delete a record with k by converting it from base64 to base58 encoded value first.
+func (b *Base58StoreWrapper) SoftDelete(k string) error {
+	b58k := convert(k)
+
+	return b.store.SoftDelete(b58k)
+}
+
+// Has tells whether the record with k key exists by converting the key ID from base58 to base64 encoded value first.
+func (b *Base58StoreWrapper) Has(k string) (bool, error) {
+	b58k := convert(k)
+
+	return b.store.Has(b58k)
+}
+
+// GetIncludingDeleted fetches the record based on key, including soft deleted records, by converting the key ID from
+// base58 to base64 encoded value first.
+func (b *Base58StoreWrapper) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	b58k := convert(k)
+
+	return b.store.GetIncludingDeleted(b58k)
+}
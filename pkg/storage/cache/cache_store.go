@@ -0,0 +1,310 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// package cache offers a storage.Store wrapper caching the records read from any underlying storage.Store
+// implementation in a bounded LRU cache.
+
+const defaultSize = 100
+
+// Option configures the cache store.
+type Option func(opts *Store)
+
+// WithSize option is for setting the maximum number of records cached, least recently used records are evicted
+// first (defaults to 100).
+func WithSize(size int) Option {
+	return func(opts *Store) {
+		opts.size = size
+	}
+}
+
+// WithNegativeTTL option is for caching the missing records (storage.ErrDataNotFound) for the given duration,
+// repeated misses are then answered without reaching the underlying store. Missing records aren't cached by default.
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(opts *Store) {
+		opts.negativeTTL = ttl
+	}
+}
+
+// WithClock option is for setting the clock used to expire the cached records (defaults to time.Now).
+func WithClock(now func() time.Time) Option {
+	return func(opts *Store) {
+		opts.now = now
+	}
+}
+
+// Store is a storage.Store wrapper caching the records returned by Get. Records written or deleted through the
+// wrapper are invalidated, records written to the underlying store by other means are served from the cache until
+// evicted. Records put with a TTL are cached until they expire.
+type Store struct {
+	store       storage.Store
+	size        int
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	entries map[string]*list.Element
+	lru     *list.List
+	// version is incremented by every invalidation, records read from the underlying store are only cached
+	// if no invalidation happened while they were read.
+	version uint64
+	lock    sync.Mutex
+}
+
+type entry struct {
+	key      string
+	value    []byte
+	notFound bool
+	// expiry of the entry, zero for entries which don't expire.
+	expiry time.Time
+}
+
+// New returns a new Store caching the records of store.
+func New(store storage.Store, opts ...Option) *Store {
+	s := &Store{
+		store:   store,
+		size:    defaultSize,
+		now:     time.Now,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Put stores the key and the record in the underlying store and invalidates the cached record.
+func (s *Store) Put(k string, v []byte) error {
+	defer s.invalidate(k)
+
+	return s.store.Put(k, v)
+}
+
+// PutIfVersion stores the key and the record in the underlying store if the version of the record is expectedVersion
+// and invalidates the cached record.
+func (s *Store) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	defer s.invalidate(k)
+
+	return s.store.PutIfVersion(k, v, expectedVersion)
+}
+
+// PutWithTTL stores the key and the record expiring after ttl in the underlying store, the record is cached
+// until it expires.
+func (s *Store) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	err := s.store.PutWithTTL(k, v, ttl)
+	if err != nil {
+		s.invalidate(k)
+
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.version++
+	s.add(&entry{key: k, value: copyBytes(v), expiry: s.now().Add(ttl)})
+
+	return nil
+}
+
+// Get fetches the record based on key from the cache, or from the underlying store if not cached.
+func (s *Store) Get(k string) ([]byte, error) {
+	s.lock.Lock()
+
+	if e, ok := s.lookup(k); ok {
+		s.lock.Unlock()
+
+		if e.notFound {
+			return nil, storage.ErrDataNotFound
+		}
+
+		return copyBytes(e.value), nil
+	}
+
+	version := s.version
+
+	s.lock.Unlock()
+
+	v, err := s.store.Get(k)
+
+	switch {
+	case err == nil:
+		s.addIfUnchanged(version, &entry{key: k, value: copyBytes(v)})
+	case errors.Is(err, storage.ErrDataNotFound) && s.negativeTTL > 0:
+		s.addIfUnchanged(version, &entry{key: k, notFound: true, expiry: s.now().Add(s.negativeTTL)})
+	}
+
+	return v, err
+}
+
+// Has tells whether the record with k key exists in the cache, or in the underlying store if not cached.
+// The existence of records isn't cached on its own.
+func (s *Store) Has(k string) (bool, error) {
+	s.lock.Lock()
+	e, ok := s.lookup(k)
+	s.lock.Unlock()
+
+	if ok {
+		return !e.notFound, nil
+	}
+
+	return s.store.Has(k)
+}
+
+// Iterator returns an iterator of the underlying store, iterated records aren't cached.
+func (s *Store) Iterator(startKey, endKey string) storage.StoreIterator {
+	return s.store.Iterator(startKey, endKey)
+}
+
+// IteratorWithFilter returns a filtered iterator of the underlying store, iterated records aren't cached.
+func (s *Store) IteratorWithFilter(startKey, endKey string,
+	keyPredicate func(key string) bool) storage.StoreIterator {
+	return s.store.IteratorWithFilter(startKey, endKey, keyPredicate)
+}
+
+// Delete will delete the record with k key from the underlying store and invalidate the cached record.
+func (s *Store) Delete(k string) error {
+	defer s.invalidate(k)
+
+	return s.store.Delete(k)
+}
+
+// DeletePrefix will delete the records of the prefix range from the underlying store and invalidate the cached
+// records with keys starting with prefix.
+func (s *Store) DeletePrefix(prefix string) (int, error) {
+	defer s.invalidatePrefix(prefix)
+
+	return s.store.DeletePrefix(prefix)
+}
+
+// Reset deletes all the records of the underlying store and invalidates all the cached records.
+func (s *Store) Reset() error {
+	defer s.invalidateAll()
+
+	return s.store.Reset()
+}
+
+// SoftDelete will soft delete the record with k key in the underlying store and invalidate the cached record.
+func (s *Store) SoftDelete(k string) error {
+	defer s.invalidate(k)
+
+	return s.store.SoftDelete(k)
+}
+
+// GetIncludingDeleted fetches the record based on key from the underlying store, including soft deleted records.
+func (s *Store) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	return s.store.GetIncludingDeleted(k)
+}
+
+// lookup returns the cached entry of key k unless expired, marking it as most recently used.
+func (s *Store) lookup(k string) (*entry, bool) {
+	elem, ok := s.entries[k]
+	if !ok {
+		return nil, false
+	}
+
+	e := elem.Value.(*entry)
+
+	if !e.expiry.IsZero() && !s.now().Before(e.expiry) {
+		s.remove(elem)
+
+		return nil, false
+	}
+
+	s.lru.MoveToFront(elem)
+
+	return e, true
+}
+
+// addIfUnchanged caches e unless an invalidation happened since version.
+func (s *Store) addIfUnchanged(version uint64, e *entry) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.version == version {
+		s.add(e)
+	}
+}
+
+// add caches e as most recently used entry, evicting the least recently used entry if the cache is full.
+func (s *Store) add(e *entry) {
+	if s.size <= 0 {
+		return
+	}
+
+	if elem, ok := s.entries[e.key]; ok {
+		s.remove(elem)
+	}
+
+	s.entries[e.key] = s.lru.PushFront(e)
+
+	if s.lru.Len() > s.size {
+		s.remove(s.lru.Back())
+	}
+}
+
+func (s *Store) remove(elem *list.Element) {
+	s.lru.Remove(elem)
+	delete(s.entries, elem.Value.(*entry).key)
+}
+
+// invalidate removes the cached record of key k.
+func (s *Store) invalidate(k string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.version++
+
+	if elem, ok := s.entries[k]; ok {
+		s.remove(elem)
+	}
+}
+
+// invalidatePrefix removes the cached records with keys starting with prefix.
+func (s *Store) invalidatePrefix(prefix string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.version++
+
+	for k, elem := range s.entries {
+		if strings.HasPrefix(k, prefix) {
+			s.remove(elem)
+		}
+	}
+}
+
+// invalidateAll removes all the cached records.
+func (s *Store) invalidateAll() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.version++
+	s.entries = map[string]*list.Element{}
+	s.lru.Init()
+}
+
+// copyBytes copies cached values so that callers can't alter them.
+func copyBytes(v []byte) []byte {
+	if v == nil {
+		return nil
+	}
+
+	return append([]byte(nil), v...)
+}
@@ -0,0 +1,303 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+// countingStore counts the Get calls reaching the underlying store.
+type countingStore struct {
+	storage.Store
+	gets int
+}
+
+func (s *countingStore) Get(k string) ([]byte, error) {
+	s.gets++
+
+	return s.Store.Get(k)
+}
+
+func newCountingStore(t *testing.T) *countingStore {
+	store, err := mem.NewProvider().OpenStore("test")
+	require.NoError(t, err)
+
+	return &countingStore{Store: store}
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestStore(t *testing.T) {
+	t.Run("test cache hit", func(t *testing.T) {
+		underlying := newCountingStore(t)
+		store := New(underlying)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		for i := 0; i < 3; i++ {
+			v, err := store.Get("k1")
+			require.NoError(t, err)
+			require.Equal(t, []byte("v1"), v)
+		}
+
+		require.Equal(t, 1, underlying.gets)
+
+		// cached values can't be altered by callers
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+		v[0] = 'x'
+
+		v, err = store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v1"), v)
+	})
+
+	t.Run("test invalidation on write", func(t *testing.T) {
+		underlying := newCountingStore(t)
+		store := New(underlying)
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		_, err := store.Get("k1")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("k1", []byte("v2")))
+
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v2"), v)
+		require.Equal(t, 2, underlying.gets)
+
+		require.NoError(t, store.Delete("k1"))
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, store.Put("k2", []byte("v2")))
+
+		_, err = store.Get("k2")
+		require.NoError(t, err)
+
+		require.NoError(t, store.SoftDelete("k2"))
+
+		_, err = store.Get("k2")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		v, deleted, err := store.GetIncludingDeleted("k2")
+		require.NoError(t, err)
+		require.True(t, deleted)
+		require.Equal(t, []byte("v2"), v)
+
+		_, err = store.Get("k1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = store.PutIfVersion("k1", []byte("v3"), "")
+		require.NoError(t, err)
+
+		v, err = store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v3"), v)
+	})
+
+	t.Run("test records put with ttl are cached until expired", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		underlying := newCountingStore(t)
+		store := New(underlying, WithClock(clock.Now))
+
+		require.NoError(t, store.PutWithTTL("k1", []byte("v1"), time.Minute))
+
+		v, err := store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v1"), v)
+		require.Zero(t, underlying.gets)
+
+		clock.now = clock.now.Add(time.Minute)
+
+		_, _ = store.Get("k1") //nolint:errcheck
+		require.Equal(t, 1, underlying.gets)
+
+		err = store.PutWithTTL("k1", []byte("v1"), 0)
+		require.True(t, errors.Is(err, storage.ErrInvalidTTL))
+	})
+
+	t.Run("test negative caching expiry", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		underlying := newCountingStore(t)
+		store := New(underlying, WithNegativeTTL(time.Second), WithClock(clock.Now))
+
+		for i := 0; i < 3; i++ {
+			_, err := store.Get("missing")
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		}
+
+		require.Equal(t, 1, underlying.gets)
+
+		clock.now = clock.now.Add(time.Second)
+
+		_, err := store.Get("missing")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		require.Equal(t, 2, underlying.gets)
+
+		// the missing record is invalidated once written
+		require.NoError(t, store.Put("missing", []byte("v1")))
+
+		v, err := store.Get("missing")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v1"), v)
+	})
+
+	t.Run("test missing records aren't cached by default", func(t *testing.T) {
+		underlying := newCountingStore(t)
+		store := New(underlying)
+
+		for i := 0; i < 3; i++ {
+			_, err := store.Get("missing")
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		}
+
+		require.Equal(t, 3, underlying.gets)
+	})
+
+	t.Run("test least recently used records are evicted", func(t *testing.T) {
+		underlying := newCountingStore(t)
+		store := New(underlying, WithSize(2))
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, store.Put(fmt.Sprintf("k%d", i), []byte("v")))
+		}
+
+		_, err := store.Get("k0")
+		require.NoError(t, err)
+		_, err = store.Get("k1")
+		require.NoError(t, err)
+		_, err = store.Get("k0")
+		require.NoError(t, err)
+		_, err = store.Get("k2") // evicts k1
+		require.NoError(t, err)
+		require.Equal(t, 3, underlying.gets)
+
+		_, err = store.Get("k0")
+		require.NoError(t, err)
+		require.Equal(t, 3, underlying.gets)
+
+		_, err = store.Get("k1")
+		require.NoError(t, err)
+		require.Equal(t, 4, underlying.gets)
+	})
+
+	t.Run("test has", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		underlying := newCountingStore(t)
+		store := New(underlying, WithNegativeTTL(time.Second), WithClock(clock.Now))
+
+		require.NoError(t, store.Put("k1", []byte("v1")))
+
+		exists, err := store.Has("k1")
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		// cached records and misses are answered from the cache
+		_, err = store.Get("k1")
+		require.NoError(t, err)
+
+		_, err = store.Get("missing")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, underlying.Delete("k1"))
+		require.NoError(t, underlying.Put("missing", []byte("v")))
+
+		exists, err = store.Has("k1")
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		exists, err = store.Has("missing")
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		clock.now = clock.now.Add(time.Second)
+
+		exists, err = store.Has("missing")
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		_, err = store.Has("")
+		require.Error(t, err)
+	})
+
+	t.Run("test delete prefix invalidates cached records", func(t *testing.T) {
+		underlying := newCountingStore(t)
+		store := New(underlying)
+
+		for _, k := range []string{"abc_1", "abc_2", "dab_1"} {
+			require.NoError(t, store.Put(k, []byte("v")))
+
+			_, err := store.Get(k)
+			require.NoError(t, err)
+		}
+
+		deleted, err := store.DeletePrefix("abc_")
+		require.NoError(t, err)
+		require.Equal(t, 2, deleted)
+
+		for _, k := range []string{"abc_1", "abc_2"} {
+			_, err = store.Get(k)
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		}
+
+		v, err := store.Get("dab_1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v"), v)
+		require.Equal(t, 5, underlying.gets)
+	})
+
+	t.Run("test reset invalidates all cached records", func(t *testing.T) {
+		underlying := newCountingStore(t)
+		store := New(underlying)
+
+		for _, k := range []string{"abc_1", "dab_1"} {
+			require.NoError(t, store.Put(k, []byte("v")))
+
+			_, err := store.Get(k)
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, store.Reset())
+
+		for _, k := range []string{"abc_1", "dab_1"} {
+			_, err := store.Get(k)
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		}
+
+		require.Equal(t, 4, underlying.gets)
+	})
+
+	t.Run("test iterator", func(t *testing.T) {
+		store := New(newCountingStore(t))
+
+		require.NoError(t, store.Put("abc_1", []byte("v1")))
+
+		itr := store.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+		require.True(t, itr.Next())
+		require.Equal(t, []byte("abc_1"), itr.Key())
+		require.False(t, itr.Next())
+		itr.Release()
+	})
+}
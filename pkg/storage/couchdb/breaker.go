@@ -0,0 +1,119 @@
+// +build !js,!wasm
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdbstore
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by the operations failing fast while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("couchdb circuit breaker is open")
+
+// breakerState is the state of a circuit breaker.
+type breakerState int
+
+const (
+	// breakerClosed lets operations through, counting consecutive failures.
+	breakerClosed breakerState = iota
+	// breakerOpen fails operations fast until the cool-down period elapses.
+	breakerOpen
+	// breakerHalfOpen lets a single probe operation through, its outcome closes or re-opens the breaker.
+	breakerHalfOpen
+)
+
+// breaker is a circuit breaker shared by the stores of a provider, which opens after threshold consecutive
+// CouchDB failures. A nil breaker lets all operations through.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	state    breakerState
+	failures int
+	openedAt time.Time
+	lock     sync.Mutex
+}
+
+func newBreaker(threshold int, cooldown time.Duration, now func() time.Time) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown, now: now}
+}
+
+// do runs op unless the breaker is open, and records its outcome.
+func (b *breaker) do(op func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	err := op()
+
+	b.record(err)
+
+	return err
+}
+
+// allow tells whether an operation can be performed, the first operation after the cool-down period
+// is let through as a probe.
+func (b *breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Before(b.openedAt.Add(b.cooldown)) {
+			return ErrCircuitOpen
+		}
+
+		b.state = breakerHalfOpen
+
+		return nil
+	case breakerHalfOpen:
+		// a probe is already in flight
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+// record records the outcome of an operation let through by allow.
+func (b *breaker) record(err error) {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !isCouchDBFailure(err) {
+		b.state = breakerClosed
+		b.failures = 0
+
+		return
+	}
+
+	b.failures++
+
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// isCouchDBFailure tells whether err is a CouchDB server or connection failure, as opposed to
+// client errors (eg: not found, conflict, validation) which don't reflect CouchDB health.
+func isCouchDBFailure(err error) bool {
+	var coder interface{ StatusCode() int }
+
+	return errors.As(err, &coder) && coder.StatusCode() >= http.StatusInternalServerError
+}
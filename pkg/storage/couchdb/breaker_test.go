@@ -0,0 +1,231 @@
+// +build !js,!wasm
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdbstore
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+type statusError int
+
+func (e statusError) Error() string {
+	return http.StatusText(int(e))
+}
+
+func (e statusError) StatusCode() int {
+	return int(e)
+}
+
+func TestBreaker(t *testing.T) {
+	failure := func() error { return statusError(http.StatusServiceUnavailable) }
+	success := func() error { return nil }
+
+	t.Run("trips after threshold consecutive failures and recovers after cooldown", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		b := newBreaker(2, time.Minute, clock.Now)
+
+		require.Error(t, b.do(failure))
+		require.NoError(t, b.do(success))
+		require.Error(t, b.do(failure))
+		require.NotEqual(t, ErrCircuitOpen, b.do(failure))
+
+		calls := 0
+		err := b.do(func() error {
+			calls++
+			return nil
+		})
+		require.True(t, errors.Is(err, ErrCircuitOpen))
+		require.Zero(t, calls)
+
+		clock.Add(time.Minute)
+
+		require.NoError(t, b.do(success))
+		require.NoError(t, b.do(success))
+		require.Error(t, b.do(failure))
+		require.NotEqual(t, ErrCircuitOpen, b.do(success))
+	})
+
+	t.Run("failed probe re-opens the breaker", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		b := newBreaker(1, time.Minute, clock.Now)
+
+		require.Error(t, b.do(failure))
+		require.Equal(t, ErrCircuitOpen, b.do(success))
+
+		clock.Add(time.Minute)
+
+		require.NotEqual(t, ErrCircuitOpen, b.do(failure))
+		require.Equal(t, ErrCircuitOpen, b.do(success))
+
+		clock.Add(time.Minute)
+
+		require.NoError(t, b.do(success))
+	})
+
+	t.Run("single probe while half-open", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		b := newBreaker(1, time.Minute, clock.Now)
+
+		require.Error(t, b.do(failure))
+
+		clock.Add(time.Minute)
+
+		err := b.do(func() error {
+			require.Equal(t, ErrCircuitOpen, b.do(success))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("client errors aren't failures", func(t *testing.T) {
+		b := newBreaker(1, time.Minute, time.Now)
+
+		require.Error(t, b.do(func() error { return statusError(http.StatusNotFound) }))
+		require.Error(t, b.do(func() error { return storage.ErrDataNotFound }))
+		require.NoError(t, b.do(success))
+	})
+
+	t.Run("nil breaker lets operations through", func(t *testing.T) {
+		var b *breaker
+
+		require.Error(t, b.do(failure))
+		require.Error(t, b.do(failure))
+		require.NoError(t, b.do(success))
+	})
+}
+
+// failingCouchDB is a minimal fake CouchDB server failing the doc requests with 503 while unavailable,
+// docs are never found otherwise.
+type failingCouchDB struct {
+	*httptest.Server
+	unavailable bool
+	docRequests int
+	mu          sync.Mutex
+}
+
+func newFailingCouchDB() *failingCouchDB {
+	c := &failingCouchDB{}
+
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		// doc paths are /{db}/{docID}
+		if strings.Count(strings.Trim(r.URL.Path, "/"), "/") == 1 {
+			c.mu.Lock()
+			c.docRequests++
+			unavailable := c.unavailable
+			c.mu.Unlock()
+
+			if unavailable {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"error":"unavailable","reason":"unavailable"}`))
+
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found","reason":"missing"}`))
+
+			return
+		}
+
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusCreated)
+		}
+
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+
+	return c
+}
+
+func (c *failingCouchDB) setUnavailable(unavailable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.unavailable = unavailable
+}
+
+func (c *failingCouchDB) requests() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.docRequests
+}
+
+func TestCouchDBStore_CircuitBreaker(t *testing.T) {
+	server := newFailingCouchDB()
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now()}
+
+	prov, err := NewProvider(server.URL, WithCircuitBreaker(3, time.Minute), WithClock(clock.Now))
+	require.NoError(t, err)
+
+	store, err := prov.OpenStore(randomKey())
+	require.NoError(t, err)
+
+	// not found isn't a failure
+	for i := 0; i < 3; i++ {
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	}
+
+	server.setUnavailable(true)
+
+	for i := 0; i < 3; i++ {
+		_, err = store.Get("key")
+		require.Error(t, err)
+		require.False(t, errors.Is(err, ErrCircuitOpen))
+	}
+
+	// the breaker is shared by the stores of the provider and fails fast
+	requests := server.requests()
+
+	_, err = store.Get("key")
+	require.True(t, errors.Is(err, ErrCircuitOpen))
+
+	err = store.Put("key", []byte("value"))
+	require.True(t, errors.Is(err, ErrCircuitOpen))
+
+	_, err = prov.OpenStore(randomKey())
+	require.True(t, errors.Is(err, ErrCircuitOpen))
+
+	require.Equal(t, requests, server.requests())
+
+	// failed probe
+	clock.Add(time.Minute)
+
+	_, err = store.Get("key")
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrCircuitOpen))
+
+	_, err = store.Get("key")
+	require.True(t, errors.Is(err, ErrCircuitOpen))
+
+	// successful probe
+	server.setUnavailable(false)
+	clock.Add(time.Minute)
+
+	_, err = store.Get("key")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	_, err = store.Get("key")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+}
@@ -0,0 +1,74 @@
+// +build !js,!wasm
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdbstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Compact triggers the compaction of the databases of all the stores opened by the provider, sorted by name.
+// It fails on the first store failing to compact.
+func (p *Provider) Compact(ctx context.Context) error {
+	p.RLock()
+
+	names := make([]string, 0, len(p.dbs))
+	stores := make(map[string]*CouchDBStore, len(p.dbs))
+
+	for name, store := range p.dbs {
+		names = append(names, name)
+		stores[name] = store
+	}
+
+	p.RUnlock()
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := stores[name].Compact(ctx); err != nil {
+			return fmt.Errorf("store %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Compact triggers the compaction of the database of the store, reclaiming the space held by the old revisions of
+// its updated and deleted docs. CouchDB compacts the database in the background once the compaction is accepted.
+func (c *CouchDBStore) Compact(ctx context.Context) error {
+	return c.run(func() error {
+		return c.compact(ctx)
+	})
+}
+
+func (c *CouchDBStore) compact(ctx context.Context) error {
+	if err := c.db.Compact(ctx); err != nil {
+		return fmt.Errorf("failed to compact db: %w", err)
+	}
+
+	return nil
+}
+
+// autoCompact compacts the database at the given interval until the store is closed.
+func (c *CouchDBStore) autoCompact(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.do(c.compact); err != nil {
+				logger.Warnf("failed to compact db: %s", err)
+			}
+		case <-c.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// +build !js,!wasm
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdbstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// compactingCouchDB is a minimal fake CouchDB server counting the compaction requests by database, the compactions
+// are rejected while failing.
+type compactingCouchDB struct {
+	*httptest.Server
+	compactions map[string]int
+	failing     bool
+	mu          sync.Mutex
+}
+
+func newCompactingCouchDB() *compactingCouchDB {
+	c := &compactingCouchDB{compactions: map[string]int{}}
+
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/_compact") {
+			c.mu.Lock()
+			c.compactions[strings.Trim(strings.TrimSuffix(r.URL.Path, "/_compact"), "/")]++
+			failing := c.failing
+			c.mu.Unlock()
+
+			if failing {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"unauthorized","reason":"admin only"}`))
+
+				return
+			}
+
+			w.WriteHeader(http.StatusAccepted)
+		}
+
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusCreated)
+		}
+
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+
+	return c
+}
+
+func (c *compactingCouchDB) compactionCount(name string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.compactions[name]
+}
+
+func (c *compactingCouchDB) setFailing(failing bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failing = failing
+}
+
+func TestCouchDBStore_Compact(t *testing.T) {
+	server := newCompactingCouchDB()
+	defer server.Close()
+
+	prov, err := NewProvider(server.URL)
+	require.NoError(t, err)
+
+	store1, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	_, err = prov.OpenStore("store2")
+	require.NoError(t, err)
+
+	t.Run("compact a store", func(t *testing.T) {
+		require.NoError(t, store1.(*CouchDBStore).Compact(context.Background()))
+		require.Equal(t, 1, server.compactionCount("store1"))
+		require.Equal(t, 0, server.compactionCount("store2"))
+	})
+
+	t.Run("compact the stores of the provider", func(t *testing.T) {
+		require.NoError(t, prov.Compact(context.Background()))
+		require.Equal(t, 2, server.compactionCount("store1"))
+		require.Equal(t, 1, server.compactionCount("store2"))
+	})
+
+	t.Run("compaction failure", func(t *testing.T) {
+		server.setFailing(true)
+		defer server.setFailing(false)
+
+		err := store1.(*CouchDBStore).Compact(context.Background())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to compact db")
+
+		err = prov.Compact(context.Background())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "store store1: failed to compact db")
+	})
+
+	t.Run("compact a closed store", func(t *testing.T) {
+		require.NoError(t, prov.CloseStore("store1"))
+
+		require.Equal(t, storage.ErrStoreClosed, store1.(*CouchDBStore).Compact(context.Background()))
+	})
+
+	require.NoError(t, prov.Close())
+}
+
+func TestCouchDBStore_AutoCompact(t *testing.T) {
+	server := newCompactingCouchDB()
+	defer server.Close()
+
+	prov, err := NewProvider(server.URL, WithAutoCompact(10*time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = prov.OpenStore("store")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return server.compactionCount("store") >= 2
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, prov.Close())
+
+	// a compaction in flight while closing may still complete
+	time.Sleep(20 * time.Millisecond)
+
+	compactions := server.compactionCount("store")
+
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, compactions, server.compactionCount("store"))
+}
@@ -8,287 +8,1554 @@ SPDX-License-Identifier: Apache-2.0
 package couchdbstore
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
-	_ "github.com/go-kivik/couchdb" // The CouchDB driver
+	"github.com/go-kivik/couchdb" // The CouchDB driver
 	"github.com/go-kivik/kivik"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
+var logger = log.New("aries-framework/storage/couchdb")
+
+// tenantIDRegexp matches the tenant IDs allowed in CouchDB database names.
+var tenantIDRegexp = regexp.MustCompile(`^[a-z0-9_$()+-]+$`) // nolint:gochecknoglobals
+
 // Provider represents an CouchDB implementation of the storage.Provider interface.
 type Provider struct {
 	hostURL       string
 	couchDBClient *kivik.Client
 	dbs           map[string]*CouchDBStore
 	dbPrefix      string
+	tenant        string
+	indexes       []Index
+	transport     *http.Transport
+	now           func() time.Time
+	sweepInterval time.Duration
+	compactEvery  time.Duration
+	mapKey        func(string) string
+	unmapKey      func(string) string
+	threshold     int
+	cooldown      time.Duration
+	breaker       *breaker
+	timeout       time.Duration
+	nativeJSON    bool
+	readQuorum    int
+	stableReads   bool
+	closeWorkers  int
 	sync.RWMutex
 }
 
-const (
-	blankHostErrMsg           = "hostURL for new CouchDB provider can't be blank"
-	failToCloseProviderErrMsg = "failed to close provider"
-	couchDBNotFoundErr        = "Not Found:"
-)
+// Index is a CouchDB Mango index definition.
+type Index struct {
+	// DesignDoc is the design document holding the index, CouchDB generates one if left blank.
+	DesignDoc string
+	// Name of the index.
+	Name string
+	// Fields to be indexed, values are stored under the `payload` field (eg: "payload.state"), or as the doc body
+	// with WithNativeJSON (eg: "state").
+	Fields []string
+}
+
+const (
+	blankHostErrMsg           = "hostURL for new CouchDB provider can't be blank"
+	failToCloseProviderErrMsg = "failed to close provider"
+	couchDBNotFoundErr        = "Not Found:"
+	// expiryField holds the expiry time (unix milliseconds) of docs put with a TTL.
+	expiryField = "expiry"
+	// payloadField holds the JSON values which aren't stored as the doc body.
+	payloadField = "payload"
+	// changesHeartbeat is the interval (milliseconds) at which CouchDB keeps the continuous changes feed alive.
+	changesHeartbeat = 10000
+	designDocPrefix  = "_design/"
+	// defaultCloseWorkers is the default number of stores closed concurrently by Provider.Close.
+	defaultCloseWorkers = 8
+)
+
+// Option configures the couchdb provider.
+type Option func(opts *Provider)
+
+// WithDBPrefix option is for adding prefix to db name.
+func WithDBPrefix(dbPrefix string) Option {
+	return func(opts *Provider) {
+		opts.dbPrefix = dbPrefix
+	}
+}
+
+// WithTenant option is for isolating the stores of a tenant, OpenStore(name) opens the `prefix_tenant_name` database
+// (`tenant_name` without WithDBPrefix). The tenant ID may only hold lowercase letters (a-z), digits (0-9) and any of
+// the characters _, $, (, ), + and -. Close closes all the stores of the tenant, StoreNames enumerates them.
+func WithTenant(tenantID string) Option {
+	return func(opts *Provider) {
+		opts.tenant = tenantID
+	}
+}
+
+// WithIndexes option is for creating the given Mango indexes on every store opened by the provider, if absent.
+func WithIndexes(indexes []Index) Option {
+	return func(opts *Provider) {
+		opts.indexes = indexes
+	}
+}
+
+// WithMaxIdleConns option is for setting the maximum number of idle (keep-alive) connections kept open to CouchDB.
+// The provider is bound to a single CouchDB host, so the limit is applied per host as well.
+func WithMaxIdleConns(n int) Option {
+	return func(opts *Provider) {
+		t := opts.getTransport()
+		t.MaxIdleConns = n
+		t.MaxIdleConnsPerHost = n
+	}
+}
+
+// WithMaxConnsPerHost option is for limiting the total number of connections (active and idle) opened to CouchDB.
+func WithMaxConnsPerHost(n int) Option {
+	return func(opts *Provider) {
+		opts.getTransport().MaxConnsPerHost = n
+	}
+}
+
+// WithClock option is for setting the clock used to expire the docs put with a TTL (defaults to time.Now).
+func WithClock(now func() time.Time) Option {
+	return func(opts *Provider) {
+		opts.now = now
+	}
+}
+
+// WithExpirySweepInterval option is for purging the expired docs of every store opened by the provider in the
+// background at the given interval. Expired docs are filtered out of Get and Iterator whether they are purged or not.
+func WithExpirySweepInterval(interval time.Duration) Option {
+	return func(opts *Provider) {
+		opts.sweepInterval = interval
+	}
+}
+
+// WithAutoCompact option is for compacting the databases of every store opened by the provider in the background at
+// the given interval, reclaiming the space held by the old revisions of updated and deleted docs. The compactions
+// stop once the stores or the provider are closed.
+func WithAutoCompact(interval time.Duration) Option {
+	return func(opts *Provider) {
+		opts.compactEvery = interval
+	}
+}
+
+// WithKeyMapper option is for mapping the keys of the stores opened by the provider to valid CouchDB doc IDs
+// (eg: CouchDB rejects doc IDs starting with '_') on write, unmapKey inverts mapKey on iteration. mapKey must be
+// reversible and preserve the order and prefixes of keys (mapKey(a+b) starts with mapKey(a)) for Iterator ranges
+// to stay consistent. Keys are hex encoded by default, see WithRawKeys.
+func WithKeyMapper(mapKey, unmapKey func(string) string) Option {
+	return func(opts *Provider) {
+		opts.mapKey = mapKey
+		opts.unmapKey = unmapKey
+	}
+}
+
+// WithCircuitBreaker option is for failing the operations of the provider and its stores fast with ErrCircuitOpen
+// after threshold consecutive CouchDB failures (server errors or unreachable CouchDB), for the cooldown period.
+// Once the cooldown period elapses, a single operation is let through to probe CouchDB, its success closes the
+// breaker while its failure re-opens it for another cooldown period. Client errors (eg: not found) aren't failures.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(opts *Provider) {
+		opts.threshold = threshold
+		opts.cooldown = cooldown
+	}
+}
+
+// WithOperationTimeout option is for bounding the duration of every operation of the stores opened by the provider
+// (including the iteration of Iterator results until released), timed out operations fail with an error wrapping
+// context.DeadlineExceeded. Operations aren't bounded by default.
+func WithOperationTimeout(timeout time.Duration) Option {
+	return func(opts *Provider) {
+		opts.timeout = timeout
+	}
+}
+
+// WithNativeJSON option is for storing the values which are JSON objects as the doc body, instead of under the
+// `payload` field, so that Mango queries and indexes select on their fields directly. Objects with fields reserved
+// by CouchDB (starting with `_`) or by the store (`payload`, `expiry`) are still stored under the `payload` field,
+// and values which aren't JSON objects as attachments. Docs stored either way are read back in both modes.
+func WithNativeJSON() Option {
+	return func(opts *Provider) {
+		opts.nativeJSON = true
+	}
+}
+
+// WithReadQuorum option is for reading the docs of the stores opened by the provider (Get and GetIncludingDeleted)
+// from n replicas, through the `r` query parameter, so that they are consistent with the writes acknowledged by a
+// write quorum on clustered CouchDB. The read quorum of CouchDB is used by default.
+func WithReadQuorum(n int) Option {
+	return func(opts *Provider) {
+		opts.readQuorum = n
+	}
+}
+
+// WithStableReads option is for reading the Iterator ranges of the stores opened by the provider from the same shard
+// replicas, through the `stable` query parameter, so that consecutive iterations are consistent on clustered CouchDB.
+// Ranges are read from the fastest replicas by default.
+func WithStableReads(stable bool) Option {
+	return func(opts *Provider) {
+		opts.stableReads = stable
+	}
+}
+
+// WithCloseConcurrency option is for setting the maximum number of stores closed concurrently when the provider is
+// closed (defaults to 8).
+func WithCloseConcurrency(n int) Option {
+	return func(opts *Provider) {
+		opts.closeWorkers = n
+	}
+}
+
+// WithRawKeys option is for using the keys of the stores opened by the provider as doc IDs as is, instead of hex
+// encoding them (the default), for the databases written before keys were mapped to doc IDs. Keys CouchDB rejects
+// as doc IDs (eg: keys starting with '_') fail to be stored.
+//
+// To migrate an existing database, copy the records iterated from its stores opened with WithRawKeys into the
+// stores of the same names of a default provider on a database prefix of its own (see WithDBPrefix), then switch
+// to the default provider. The expiry of records put with a TTL isn't copied.
+func WithRawKeys() Option {
+	return WithKeyMapper(identityKey, identityKey)
+}
+
+// identityKey is the key mapper of WithRawKeys, keys are used as doc IDs as is.
+func identityKey(k string) string {
+	return k
+}
+
+// hexMapKey is the default key mapper, hex encoding preserves the order and prefixes of keys.
+func hexMapKey(k string) string {
+	return hex.EncodeToString([]byte(k))
+}
+
+// hexUnmapKey inverts hexMapKey, doc IDs which aren't hex encoded (eg: not written by the store) are returned as is.
+func hexUnmapKey(docID string) string {
+	k, err := hex.DecodeString(docID)
+	if err != nil {
+		return docID
+	}
+
+	return string(k)
+}
+
+// getTransport returns the custom HTTP transport of the provider, created from the default transport if not yet set.
+func (p *Provider) getTransport() *http.Transport {
+	if p.transport == nil {
+		p.transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+
+	return p.transport
+}
+
+// NewProvider instantiates Provider.
+// Keys are hex encoded into doc IDs so that any key is stored (CouchDB rejects doc IDs starting with '_'), the
+// databases written with the keys as doc IDs are opened with WithRawKeys.
+func NewProvider(hostURL string, opts ...Option) (*Provider, error) {
+	if hostURL == "" {
+		return nil, errors.New(blankHostErrMsg)
+	}
+
+	client, err := kivik.New("couch", hostURL)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		hostURL:       hostURL,
+		couchDBClient: client,
+		dbs:           map[string]*CouchDBStore{},
+		now:           time.Now,
+		mapKey:        hexMapKey,
+		unmapKey:      hexUnmapKey,
+		closeWorkers:  defaultCloseWorkers,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.tenant != "" && !tenantIDRegexp.MatchString(p.tenant) {
+		return nil, fmt.Errorf("invalid tenant ID '%s'", p.tenant)
+	}
+
+	if p.threshold > 0 {
+		p.breaker = newBreaker(p.threshold, p.cooldown, p.now)
+	}
+
+	// the default transport is kept unless the connection pool is configured
+	if p.transport != nil {
+		err = client.Authenticate(context.Background(), couchdb.SetTransport(p.transport))
+		if err != nil {
+			return nil, fmt.Errorf("failed to set couchdb transport : %w", err)
+		}
+	}
+
+	_, err = client.Ping(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failure while pinging couchdb at url %s : %w", hostURL, err)
+	}
+
+	return p, nil
+}
+
+// OpenStore opens an existing store with the given name and returns it.
+// Opening an already opened store returns the cached store without any round-trip to CouchDB.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	name = p.dbName(name)
+
+	// Check cache first
+	p.RLock()
+	cachedStore, existsInCache := p.dbs[name]
+	p.RUnlock()
+
+	if existsInCache {
+		return cachedStore, nil
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	// the store may have been opened concurrently while waiting for the lock
+	cachedStore, existsInCache = p.dbs[name]
+	if existsInCache {
+		return cachedStore, nil
+	}
+
+	var db *kivik.DB
+
+	err := p.breaker.do(func() error {
+		var errOpen error
+		db, errOpen = p.openDB(name)
+
+		return errOpen
+	})
+	if err != nil {
+		return nil, classifyErr(err)
+	}
+
+	store := &CouchDBStore{db: db, now: p.now, mapKey: p.mapKey, unmapKey: p.unmapKey, breaker: p.breaker,
+		timeout: p.timeout, nativeJSON: p.nativeJSON, readQuorum: p.readQuorum, stableReads: p.stableReads,
+		stop: make(chan struct{})}
+
+	if p.sweepInterval > 0 {
+		go store.sweep(p.sweepInterval)
+	}
+
+	if p.compactEvery > 0 {
+		go store.autoCompact(p.compactEvery)
+	}
+
+	p.dbs[name] = store
+
+	return store, nil
+}
+
+// dbName returns the name of the database of the store with the given name.
+func (p *Provider) dbName(name string) string {
+	if p.tenant != "" {
+		name = p.tenant + "_" + name
+	}
+
+	if p.dbPrefix != "" {
+		name = p.dbPrefix + "_" + name
+	}
+
+	return name
+}
+
+// StoreNames returns the names of the stores of the provider existing in CouchDB, opened or not, sorted by name.
+// Only the stores of the tenant are returned with WithTenant, the CouchDB system databases are never returned.
+func (p *Provider) StoreNames() ([]string, error) {
+	var dbs []string
+
+	err := p.breaker.do(func() error {
+		var errList error
+		dbs, errList = p.couchDBClient.AllDBs(context.Background())
+
+		return errList
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dbs: %w", classifyErr(err))
+	}
+
+	prefix := p.dbName("")
+	names := []string{}
+
+	for _, db := range dbs {
+		if strings.HasPrefix(db, "_") || !strings.HasPrefix(db, prefix) {
+			continue
+		}
+
+		names = append(names, strings.TrimPrefix(db, prefix))
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// openDB creates the db with the given name along with its indexes, if absent.
+func (p *Provider) openDB(name string) (*kivik.DB, error) {
+	err := p.couchDBClient.CreateDB(context.Background(), name)
+	if err != nil {
+		if err.Error() != "Precondition Failed: The database could not be created, the file already exists." {
+			return nil, fmt.Errorf("failed to create db: %w", err)
+		}
+	}
+
+	db := p.couchDBClient.DB(context.Background(), name)
+
+	if db.Err() != nil {
+		return nil, db.Err()
+	}
+
+	err = createIndexes(db, p.indexes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return db, nil
+}
+
+// createIndexes creates the indexes missing from db. Index creation is idempotent on CouchDB side, conflicts
+// caused by other agents creating the same index concurrently are tolerated.
+func createIndexes(db *kivik.DB, indexes []Index) error {
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	existingIndexes, err := db.GetIndexes(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get indexes: %w", err)
+	}
+
+	existing := make(map[string]struct{})
+	for _, index := range existingIndexes {
+		existing[index.Name] = struct{}{}
+	}
+
+	for _, index := range indexes {
+		if _, ok := existing[index.Name]; ok {
+			continue
+		}
+
+		err = db.CreateIndex(context.Background(), index.DesignDoc, index.Name,
+			map[string]interface{}{"fields": index.Fields})
+		if err != nil && kivik.StatusCode(err) != http.StatusConflict {
+			return fmt.Errorf("failed to create index %s: %w", index.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// CloseStore closes a previously opened store.
+func (p *Provider) CloseStore(name string) error {
+	p.Lock()
+	defer p.Unlock()
+
+	name = p.dbName(name)
+
+	store, exists := p.dbs[name]
+	if !exists {
+		return nil
+	}
+
+	delete(p.dbs, name)
+
+	store.stopSweeper()
+
+	return store.db.Close(context.Background())
+}
+
+// DeleteStore closes the store with the given name and destroys its underlying CouchDB database along with all of
+// its docs, a subsequent OpenStore starts with an empty store. Deleting a non existing store isn't an error.
+func (p *Provider) DeleteStore(name string) error {
+	p.Lock()
+	defer p.Unlock()
+
+	name = p.dbName(name)
+
+	if store, exists := p.dbs[name]; exists {
+		delete(p.dbs, name)
+
+		store.stopSweeper()
+
+		if err := store.db.Close(context.Background()); err != nil {
+			return fmt.Errorf("failed to close store: %w", err)
+		}
+	}
+
+	err := p.couchDBClient.DestroyDB(context.Background(), name)
+	if err != nil && kivik.StatusCode(err) != http.StatusNotFound {
+		return fmt.Errorf("failed to destroy db: %w", err)
+	}
+
+	return nil
+}
+
+// CloseError is returned by Provider.Close when some of the stores failed to close, it holds the close errors by
+// store name.
+type CloseError struct {
+	Errors map[string]error
+}
+
+func (e *CloseError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	errs := make([]string, len(names))
+	for i, name := range names {
+		errs[i] = fmt.Sprintf("store %s: %s", name, e.Errors[name])
+	}
+
+	return fmt.Sprintf("%s: %s", failToCloseProviderErrMsg, strings.Join(errs, "; "))
+}
+
+// Close closes the provider. The stores are closed concurrently (see WithCloseConcurrency) and all removed from the
+// provider, the stores which failed to close are reported by a *CloseError. The CouchDB client is only closed if
+// all the stores closed, a subsequent Close then closes it.
+func (p *Provider) Close() error {
+	p.Lock()
+	defer p.Unlock()
+
+	dbs := p.dbs
+	p.dbs = map[string]*CouchDBStore{}
+
+	closeErr := &CloseError{Errors: closeStores(dbs, p.closeWorkers)}
+	if len(closeErr.Errors) > 0 {
+		return closeErr
+	}
+
+	return p.couchDBClient.Close(context.Background())
+}
+
+// closeStores closes the stores with at most workers stores closed at a time, the close errors are returned by
+// store name.
+func closeStores(dbs map[string]*CouchDBStore, workers int) map[string]error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		errs = map[string]error{}
+		sem  = make(chan struct{}, workers)
+	)
+
+	for name, store := range dbs {
+		wg.Add(1)
+
+		sem <- struct{}{}
+
+		go func(name string, store *CouchDBStore) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			store.stopSweeper()
+
+			if err := store.db.Close(context.Background()); err != nil {
+				lock.Lock()
+				errs[name] = err
+				lock.Unlock()
+			}
+		}(name, store)
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+// CouchDBStore represents a CouchDB-backed database.
+type CouchDBStore struct {
+	db         *kivik.DB
+	now        func() time.Time
+	mapKey     func(string) string
+	unmapKey   func(string) string
+	breaker    *breaker
+	timeout    time.Duration
+	nativeJSON bool
+	// readQuorum is the `r` query parameter of doc reads, unset if 0
+	readQuorum  int
+	stableReads bool
+	// stop is closed once the store is closed by its provider, stopping the sweeper and the auto compaction
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// newContext returns the context of an operation, bounded by the operation timeout if set.
+func (c *CouchDBStore) newContext() (context.Context, context.CancelFunc) {
+	if c.timeout > 0 {
+		return context.WithTimeout(context.Background(), c.timeout)
+	}
+
+	return context.WithCancel(context.Background())
+}
+
+// do runs op through the circuit breaker, with a context bounded by the operation timeout.
+func (c *CouchDBStore) do(op func(ctx context.Context) error) error {
+	ctx, cancel := c.newContext()
+	defer cancel()
+
+	return c.run(func() error {
+		return op(ctx)
+	})
+}
+
+// run runs op through the circuit breaker unless the store is closed, its backend failures are classified into the
+// storage errors.
+func (c *CouchDBStore) run(op func() error) error {
+	if c.closed() {
+		return storage.ErrStoreClosed
+	}
+
+	return classifyErr(c.breaker.do(op))
+}
+
+// closed tells whether the store was closed by its provider.
+func (c *CouchDBStore) closed() bool {
+	select {
+	case <-c.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// Put stores the given key-value pair in the store.
+func (c *CouchDBStore) Put(k string, v []byte) error {
+	return c.do(func(ctx context.Context) error {
+		return c.put(ctx, k, v, "")
+	})
+}
+
+// PutWithTTL stores the given key-value pair in the store, the doc expires after ttl.
+func (c *CouchDBStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return storage.ErrInvalidTTL
+	}
+
+	return c.do(func(ctx context.Context) error {
+		return c.put(ctx, k, v, strconv.FormatInt(toUnixMillis(c.now().Add(ttl)), 10))
+	})
+}
+
+func (c *CouchDBStore) put(ctx context.Context, k string, v []byte, expiry string) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	valueToPut := c.docBody(v, expiry)
+	docID := c.mapKey(k)
+
+	revID, err := c.getRevID(ctx, docID)
+	if err != nil {
+		return err
+	}
+
+	if revID != "" {
+		valueToPut = []byte(`{"_rev":"` + revID + `",` + string(valueToPut[1:]))
+	}
+
+	_, err = c.db.Put(ctx, docID, valueToPut)
+	if err != nil {
+		return fmt.Errorf("failed to store data: %w", err)
+	}
+
+	return nil
+}
+
+// docBody returns the body of the doc storing v, expiring at expiry (unix millis) if set.
+func (c *CouchDBStore) docBody(v []byte, expiry string) []byte {
+	var body []byte
+
+	switch {
+	case c.nativeJSON && isNativeJSON(v):
+		body = bytes.TrimSpace(v)
+	case isJSON(v):
+		body = []byte(`{"` + payloadField + `":` + string(v) + `}`)
+	default:
+		body = wrapTextAsCouchDBAttachment(v)
+	}
+
+	if expiry != "" {
+		body = []byte(`{"` + expiryField + `":` + expiry + `,` + string(body[1:]))
+	}
+
+	return body
+}
+
+// PutIfVersion stores the given key-value pair in the store if the `_rev` of the doc is expectedVersion and returns
+// the new `_rev` of the doc. The CouchDB revision check makes the update atomic, a concurrent update of the doc fails
+// with storage.ErrConflict.
+func (c *CouchDBStore) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	var newVersion string
+
+	err := c.do(func(ctx context.Context) error {
+		var errPut error
+		newVersion, errPut = c.putIfVersion(ctx, k, v, expectedVersion)
+
+		return errPut
+	})
+
+	return newVersion, err
+}
+
+func (c *CouchDBStore) putIfVersion(ctx context.Context, k string, v []byte, expectedVersion string) (string, error) {
+	if k == "" || v == nil {
+		return "", errors.New("key and value are mandatory")
+	}
+
+	docID := c.mapKey(k)
+	rawDoc := make(map[string]interface{})
+
+	var revID, version string
+
+	err := c.db.Get(ctx, docID).ScanDoc(&rawDoc)
+
+	switch {
+	case err == nil:
+		revID = rawDoc["_rev"].(string)
+
+		// an expired doc is absent, it's overwritten when creating the record
+		if !c.isExpired(rawDoc) {
+			version = revID
+		}
+	case !strings.Contains(err.Error(), couchDBNotFoundErr):
+		return "", err
+	}
+
+	if version != expectedVersion {
+		return "", storage.ErrConflict
+	}
+
+	valueToPut := c.docBody(v, "")
+
+	if revID != "" {
+		valueToPut = []byte(`{"_rev":"` + revID + `",` + string(valueToPut[1:]))
+	}
+
+	newRevID, err := c.db.Put(ctx, docID, valueToPut)
+	if err != nil {
+		return "", fmt.Errorf("failed to store data: %w", err)
+	}
+
+	return newRevID, nil
+}
+
+func toUnixMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// isExpired tells whether the doc was put with a TTL and is expired.
+func (c *CouchDBStore) isExpired(rawDoc map[string]interface{}) bool {
+	expiry, ok := rawDoc[expiryField].(float64)
+
+	return ok && float64(toUnixMillis(c.now())) >= expiry
+}
+
+func isJSON(textToCheck []byte) bool {
+	var js struct{}
+	return json.Unmarshal(textToCheck, &js) == nil
+}
+
+// isNativeJSON tells whether v is a non empty JSON object without any field reserved by CouchDB or the store,
+// which can be stored as the doc body.
+func isNativeJSON(v []byte) bool {
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(v, &fields); err != nil || len(fields) == 0 {
+		return false
+	}
+
+	for name := range fields {
+		if strings.HasPrefix(name, "_") || name == payloadField || name == expiryField {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getJSONValueFromRawDoc returns the JSON value of a doc without attachment, stored under the payload field or as
+// the doc body.
+func getJSONValueFromRawDoc(rawDoc map[string]interface{}) ([]byte, error) {
+	if payload, ok := rawDoc[payloadField]; ok {
+		return json.Marshal(payload)
+	}
+
+	value := make(map[string]interface{}, len(rawDoc))
+
+	for name, field := range rawDoc {
+		if !strings.HasPrefix(name, "_") && name != expiryField {
+			value[name] = field
+		}
+	}
+
+	return json.Marshal(value)
+}
+
+// Kivik has a PutAttachment method, but it requires creating a document first and then adding an attachment after.
+// We want to do it all in one step, hence this manual stuff below.
+func wrapTextAsCouchDBAttachment(textToWrap []byte) []byte {
+	encodedTextToWrap := base64.StdEncoding.EncodeToString(textToWrap)
+	return []byte(`{"_attachments": {"data": {"data": "` + encodedTextToWrap + `", "content_type": "text/plain"}}}`)
+}
+
+// Get retrieves the value in the store associated with the given key.
+func (c *CouchDBStore) Get(k string) ([]byte, error) {
+	var v []byte
+
+	err := c.do(func(ctx context.Context) error {
+		var errGet error
+		v, errGet = c.get(ctx, k)
+
+		return errGet
+	})
+
+	return v, err
+}
+
+func (c *CouchDBStore) get(ctx context.Context, k string) ([]byte, error) {
+	if k == "" {
+		return nil, errors.New("key is mandatory")
+	}
+
+	docID := c.mapKey(k)
+	rawDoc := make(map[string]interface{})
+
+	row := c.db.Get(ctx, docID, c.docReadOptions()...)
+
+	err := row.ScanDoc(&rawDoc)
+	if err != nil {
+		if strings.Contains(err.Error(), couchDBNotFoundErr) {
+			return nil, storage.ErrDataNotFound
+		}
+
+		return nil, err
+	}
+
+	if c.isExpired(rawDoc) {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return c.getStoredValueFromRawDoc(ctx, rawDoc, docID)
+}
+
+// docReadOptions returns the options of doc reads, CouchDB defaults are kept unless the read quorum is set.
+func (c *CouchDBStore) docReadOptions() []kivik.Options {
+	if c.readQuorum <= 0 {
+		return nil
+	}
+
+	return []kivik.Options{{"r": c.readQuorum}}
+}
+
+// Has tells whether the doc with k key exists, the meta of the doc is read first (deleted and soft deleted docs
+// aren't found) and the body is only read for the docs found to tell whether they're expired.
+func (c *CouchDBStore) Has(k string) (bool, error) {
+	var exists bool
+
+	err := c.do(func(ctx context.Context) error {
+		var errHas error
+		exists, errHas = c.has(ctx, k)
+
+		return errHas
+	})
+
+	return exists, err
+}
+
+func (c *CouchDBStore) has(ctx context.Context, k string) (bool, error) {
+	if k == "" {
+		return false, errors.New("key is mandatory")
+	}
+
+	docID := c.mapKey(k)
+
+	_, _, err := c.db.GetMeta(ctx, docID, c.docReadOptions()...)
+	if err != nil {
+		if kivik.StatusCode(err) == http.StatusNotFound {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to get doc meta: %w", err)
+	}
+
+	rawDoc := make(map[string]interface{})
+
+	err = c.db.Get(ctx, docID, c.docReadOptions()...).ScanDoc(&rawDoc)
+	if err != nil {
+		// the doc was deleted since its meta was read
+		if kivik.StatusCode(err) == http.StatusNotFound {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to get doc: %w", err)
+	}
+
+	return !c.isExpired(rawDoc), nil
+}
+
+// get rev ID of the doc with given doc ID.
+func (c *CouchDBStore) getRevID(ctx context.Context, docID string) (string, error) {
+	rawDoc := make(map[string]interface{})
+
+	row := c.db.Get(ctx, docID)
+
+	err := row.ScanDoc(&rawDoc)
+	if err != nil {
+		if strings.Contains(err.Error(), couchDBNotFoundErr) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return rawDoc["_rev"].(string), nil
+}
+
+// Delete will delete record with k key.
+func (c *CouchDBStore) Delete(k string) error {
+	return c.do(func(ctx context.Context) error {
+		return c.delete(ctx, k)
+	})
+}
+
+func (c *CouchDBStore) delete(ctx context.Context, k string) error {
+	if k == "" {
+		return errors.New("key is mandatory")
+	}
+
+	docID := c.mapKey(k)
+
+	revID, err := c.getRevID(ctx, docID)
+	if err != nil {
+		return err
+	}
+
+	// no error if nothing to delete
+	if revID == "" {
+		return nil
+	}
+
+	_, err = c.db.Delete(ctx, docID, revID)
+	if err != nil {
+		return fmt.Errorf("failed to delete doc: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePrefix deletes the docs of the prefix range, including expired docs which aren't counted, with a single bulk
+// request. Soft deleted docs are already CouchDB deleted docs, they aren't deleted again.
+func (c *CouchDBStore) DeletePrefix(prefix string) (int, error) {
+	var deleted int
+
+	err := c.do(func(ctx context.Context) error {
+		var errDelete error
+		deleted, errDelete = c.deletePrefix(ctx, prefix)
+
+		return errDelete
+	})
+
+	return deleted, err
+}
+
+func (c *CouchDBStore) deletePrefix(ctx context.Context, prefix string) (int, error) {
+	if prefix == "" {
+		return 0, errors.New("prefix is mandatory")
+	}
+
+	// the docs are fetched to tell the expired ones
+	return c.deleteDocs(ctx, kivik.Options{
+		"startkey":      c.mapKey(prefix),
+		"endkey":        c.mapEndKey(prefix + storage.EndKeySuffix),
+		"inclusive_end": "false",
+		"include_docs":  "true",
+	})
+}
+
+// Reset deletes all the docs of the store, including expired docs, with a single bulk request. Design docs
+// (eg: indexes) are kept and soft deleted docs, already CouchDB deleted docs, aren't deleted again.
+// The store remains open.
+func (c *CouchDBStore) Reset() error {
+	return c.do(func(ctx context.Context) error {
+		_, err := c.deleteDocs(ctx, nil)
+
+		return err
+	})
+}
+
+// deleteDocs deletes the docs listed by the all docs query of options with a single bulk request, design docs
+// are skipped. The number of deleted docs is returned, the docs known to be expired, when the query includes the
+// docs, aren't counted.
+func (c *CouchDBStore) deleteDocs(ctx context.Context, options kivik.Options) (int, error) {
+	docs, expired, err := c.listDocRevisions(ctx, options)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(docs) == 0 {
+		return 0, nil
+	}
+
+	results, err := c.db.BulkDocs(ctx, docs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete docs: %w", err)
+	}
 
-// Option configures the couchdb provider.
-type Option func(opts *Provider)
+	defer func() {
+		if errClose := results.Close(); errClose != nil {
+			logger.Warnf("failed to close bulk delete results: %s", errClose)
+		}
+	}()
 
-// WithDBPrefix option is for adding prefix to db name.
-func WithDBPrefix(dbPrefix string) Option {
-	return func(opts *Provider) {
-		opts.dbPrefix = dbPrefix
+	var deleted int
+
+	for results.Next() {
+		if errUpdate := results.UpdateErr(); errUpdate != nil {
+			return deleted, fmt.Errorf("failed to delete doc %s: %w", results.ID(), errUpdate)
+		}
+
+		if !expired[results.ID()] {
+			deleted++
+		}
 	}
-}
 
-// NewProvider instantiates Provider.
-// Certain stores like couchdb cannot accept key IDs with '_' prefix, to avoid getting errors with such values, key ID
-// need to be base58 encoded for these stores. In order to do so, the store must be wrapped using base58wrapper.
-func NewProvider(hostURL string, opts ...Option) (*Provider, error) {
-	if hostURL == "" {
-		return nil, errors.New(blankHostErrMsg)
+	if err = results.Err(); err != nil {
+		return deleted, fmt.Errorf("failed to delete docs: %w", err)
 	}
 
-	client, err := kivik.New("couch", hostURL)
+	return deleted, nil
+}
+
+// listDocRevisions returns the deletions of the docs listed by the all docs query of options, design docs are
+// skipped, along with the IDs of the listed docs which are expired if the query includes the docs.
+func (c *CouchDBStore) listDocRevisions(ctx context.Context, options kivik.Options) ([]interface{},
+	map[string]bool, error) {
+	rows, err := c.db.AllDocs(ctx, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, fmt.Errorf("failed to query docs: %w", err)
 	}
 
-	p := &Provider{hostURL: hostURL, couchDBClient: client, dbs: map[string]*CouchDBStore{}}
+	defer func() {
+		if errClose := rows.Close(); errClose != nil {
+			logger.Warnf("failed to close doc rows: %s", errClose)
+		}
+	}()
+
+	var docs []interface{}
 
-	_, err = client.Ping(context.Background())
-	if err != nil {
-		return nil, fmt.Errorf("failure while pinging couchdb at url %s : %w", hostURL, err)
+	expired := make(map[string]bool)
+
+	for rows.Next() {
+		if strings.HasPrefix(rows.ID(), designDocPrefix) {
+			continue
+		}
+
+		var value struct {
+			Rev string `json:"rev"`
+		}
+
+		if err = rows.ScanValue(&value); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan doc revision: %w", err)
+		}
+
+		if options["include_docs"] == "true" {
+			var rawDoc map[string]interface{}
+
+			if err = rows.ScanDoc(&rawDoc); err != nil {
+				return nil, nil, fmt.Errorf("failed to scan doc: %w", err)
+			}
+
+			expired[rows.ID()] = c.isExpired(rawDoc)
+		}
+
+		docs = append(docs, map[string]interface{}{"_id": rows.ID(), "_rev": value.Rev, "_deleted": true})
 	}
 
-	for _, opt := range opts {
-		opt(p)
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to query docs: %w", err)
 	}
 
-	return p, nil
+	return docs, expired, nil
 }
 
-// OpenStore opens an existing store with the given name and returns it.
-func (p *Provider) OpenStore(name string) (storage.Store, error) {
-	p.Lock()
-	defer p.Unlock()
+// SoftDelete marks the doc with k key as deleted using CouchDB native deleted docs, the deleted revision keeps the
+// body of the doc so that it remains readable with GetIncludingDeleted.
+func (c *CouchDBStore) SoftDelete(k string) error {
+	return c.do(func(ctx context.Context) error {
+		return c.softDelete(ctx, k)
+	})
+}
 
-	if p.dbPrefix != "" {
-		name = p.dbPrefix + "_" + name
+func (c *CouchDBStore) softDelete(ctx context.Context, k string) error {
+	if k == "" {
+		return errors.New("key is mandatory")
 	}
 
-	// Check cache first
-	cachedStore, existsInCache := p.dbs[name]
-	if existsInCache {
-		return cachedStore, nil
-	}
+	docID := c.mapKey(k)
+	rawDoc := make(map[string]interface{})
 
-	err := p.couchDBClient.CreateDB(context.Background(), name)
+	err := c.db.Get(ctx, docID).ScanDoc(&rawDoc)
 	if err != nil {
-		if err.Error() != "Precondition Failed: The database could not be created, the file already exists." {
-			return nil, fmt.Errorf("failed to create db: %w", err)
+		// no error if nothing to delete
+		if strings.Contains(err.Error(), couchDBNotFoundErr) {
+			return nil
 		}
+
+		return err
 	}
 
-	db := p.couchDBClient.DB(context.Background(), name)
+	if c.isExpired(rawDoc) {
+		return nil
+	}
 
-	if db.Err() != nil {
-		return nil, db.Err()
+	rawDoc["_deleted"] = true
+
+	_, err = c.db.Put(ctx, docID, rawDoc)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete doc: %w", err)
 	}
 
-	store := &CouchDBStore{db: db}
+	return nil
+}
 
-	p.dbs[name] = store
+// GetIncludingDeleted retrieves the value in the store associated with the given key, including soft deleted docs.
+func (c *CouchDBStore) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	var (
+		v       []byte
+		deleted bool
+	)
 
-	return store, nil
+	err := c.do(func(ctx context.Context) error {
+		var errGet error
+		v, deleted, errGet = c.getIncludingDeleted(ctx, k)
+
+		return errGet
+	})
+
+	return v, deleted, err
 }
 
-// CloseStore closes a previously opened store.
-func (p *Provider) CloseStore(name string) error {
-	p.Lock()
-	defer p.Unlock()
+func (c *CouchDBStore) getIncludingDeleted(ctx context.Context, k string) ([]byte, bool, error) {
+	v, err := c.get(ctx, k)
+	if err == nil {
+		return v, false, nil
+	}
 
-	if p.dbPrefix != "" {
-		name = p.dbPrefix + "_" + name
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return nil, false, err
 	}
 
-	store, exists := p.dbs[name]
-	if !exists {
-		return nil
+	docID := c.mapKey(k)
+
+	revID, err := c.getDeletedRevID(ctx, docID)
+	if err != nil {
+		return nil, false, err
 	}
 
-	delete(p.dbs, name)
+	rawDoc := make(map[string]interface{})
 
-	return store.db.Close(context.Background())
+	err = c.db.Get(ctx, docID, kivik.Options{"rev": revID, "attachments": true}).ScanDoc(&rawDoc)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get deleted doc: %w", err)
+	}
+
+	v, err = getStoredValueFromDeletedDoc(rawDoc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return v, true, nil
 }
 
-// Close closes the provider.
-func (p *Provider) Close() error {
-	p.Lock()
-	defer p.Unlock()
+// getDeletedRevID returns the rev ID of the deleted doc with given doc ID, all docs view reports the latest revision
+// of deleted docs when queried by keys.
+func (c *CouchDBStore) getDeletedRevID(ctx context.Context, docID string) (string, error) {
+	rows, err := c.db.AllDocs(ctx, kivik.Options{"keys": []string{docID}})
+	if err != nil {
+		return "", fmt.Errorf("failed to query docs: %w", err)
+	}
 
-	for _, store := range p.dbs {
-		err := store.db.Close(context.Background())
-		if err != nil {
-			return fmt.Errorf(failToCloseProviderErrMsg+": %w", err)
-		}
+	var value struct {
+		Rev     string `json:"rev"`
+		Deleted bool   `json:"deleted"`
 	}
 
-	if err := p.couchDBClient.Close(context.Background()); err != nil {
-		return err
+	found := rows.Next() && rows.ScanValue(&value) == nil && value.Deleted
+
+	err = rows.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to close rows: %w", err)
 	}
 
-	p.dbs = make(map[string]*CouchDBStore)
+	if !found {
+		return "", storage.ErrDataNotFound
+	}
 
-	return nil
+	return value.Rev, nil
 }
 
-// CouchDBStore represents a CouchDB-backed database.
-type CouchDBStore struct {
-	db *kivik.DB
-}
+// getStoredValueFromDeletedDoc reads the value of a deleted doc, attachments of deleted docs
+// are no longer served individually so they are expected to be inlined in the doc.
+func getStoredValueFromDeletedDoc(rawDoc map[string]interface{}) ([]byte, error) {
+	attachments, containsAttachment := rawDoc["_attachments"].(map[string]interface{})
+	if !containsAttachment {
+		return getJSONValueFromRawDoc(rawDoc)
+	}
 
-// Put stores the given key-value pair in the store.
-func (c *CouchDBStore) Put(k string, v []byte) error {
-	if k == "" || v == nil {
-		return errors.New("key and value are mandatory")
+	attachment, ok := attachments["data"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("deleted doc is missing data attachment")
 	}
 
-	var valueToPut []byte
-	if isJSON(v) {
-		valueToPut = []byte(`{"payload":` + string(v) + `}`)
-	} else {
-		valueToPut = wrapTextAsCouchDBAttachment(v)
+	data, ok := attachment["data"].(string)
+	if !ok {
+		return nil, errors.New("deleted doc data attachment is not inlined")
+	}
+
+	return base64.StdEncoding.DecodeString(data)
+}
+
+// sweep purges the expired docs at the given interval until the store is closed.
+func (c *CouchDBStore) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.do(c.purgeExpired); err != nil {
+				logger.Warnf("failed to purge expired docs: %s", err)
+			}
+		case <-c.stop:
+			return
+		}
 	}
+}
+
+// stopSweeper stops the background purge of expired docs, if any.
+func (c *CouchDBStore) stopSweeper() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
 
-	revID, err := c.getRevID(k)
+// purgeExpired deletes the docs expired by now.
+func (c *CouchDBStore) purgeExpired(ctx context.Context) error {
+	rows, err := c.db.Find(ctx, map[string]interface{}{
+		"selector": map[string]interface{}{
+			expiryField: map[string]interface{}{"$lte": toUnixMillis(c.now())},
+		},
+		"fields": []string{"_id", "_rev"},
+	})
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to find expired docs: %w", err)
 	}
 
-	if revID != "" {
-		valueToPut = []byte(`{"_rev":"` + revID + `",` + string(valueToPut[1:]))
+	defer func() {
+		if errClose := rows.Close(); errClose != nil {
+			logger.Warnf("failed to close expired docs rows: %s", errClose)
+		}
+	}()
+
+	type expiredDoc struct {
+		ID  string `json:"_id"`
+		Rev string `json:"_rev"`
 	}
 
-	_, err = c.db.Put(context.Background(), k, valueToPut)
-	if err != nil {
-		return fmt.Errorf("failed to store data: %w", err)
+	var docs []expiredDoc
+
+	for rows.Next() {
+		var doc expiredDoc
+
+		if err = rows.ScanDoc(&doc); err != nil {
+			return fmt.Errorf("failed to scan expired doc: %w", err)
+		}
+
+		docs = append(docs, doc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to find expired docs: %w", err)
+	}
+
+	for _, doc := range docs {
+		_, err = c.db.Delete(ctx, doc.ID, doc.Rev)
+		if err != nil && kivik.StatusCode(err) != http.StatusConflict {
+			return fmt.Errorf("failed to delete expired doc: %w", err)
+		}
 	}
 
 	return nil
 }
 
-func isJSON(textToCheck []byte) bool {
-	var js struct{}
-	return json.Unmarshal(textToCheck, &js) == nil
+// ChangeEvent is a change of a doc reported by the CouchDB changes feed.
+type ChangeEvent struct {
+	// Key of the changed doc.
+	Key string
+	// Rev is the revision of the doc after the change.
+	Rev string
+	// Deleted tells whether the doc was deleted (or soft deleted) by the change.
+	Deleted bool
+	// Seq is the update sequence of the change, it can be passed as since to resume the subscription after it.
+	Seq string
 }
 
-// Kivik has a PutAttachment method, but it requires creating a document first and then adding an attachment after.
-// We want to do it all in one step, hence this manual stuff below.
-func wrapTextAsCouchDBAttachment(textToWrap []byte) []byte {
-	encodedTextToWrap := base64.StdEncoding.EncodeToString(textToWrap)
-	return []byte(`{"_attachments": {"data": {"data": "` + encodedTextToWrap + `", "content_type": "text/plain"}}}`)
-}
+// Subscribe returns a channel of the changes to the docs of the store following the CouchDB continuous changes feed,
+// starting after the update sequence since ("now" for future changes only, blank for all changes since creation).
+// Changes are emitted in the order they were made, design docs (eg: indexes) are skipped. Once ctx is done,
+// the feed is stopped and the channel closed.
+func (c *CouchDBStore) Subscribe(ctx context.Context, since string) (<-chan ChangeEvent, error) {
+	options := kivik.Options{"feed": "continuous", "heartbeat": changesHeartbeat}
 
-// Get retrieves the value in the store associated with the given key.
-func (c *CouchDBStore) Get(k string) ([]byte, error) {
-	if k == "" {
-		return nil, errors.New("key is mandatory")
+	if since != "" {
+		options["since"] = since
 	}
 
-	rawDoc := make(map[string]interface{})
+	var changes *kivik.Changes
 
-	row := c.db.Get(context.Background(), k)
+	err := c.run(func() error {
+		var errChanges error
+		changes, errChanges = c.db.Changes(ctx, options)
 
-	err := row.ScanDoc(&rawDoc)
+		return errChanges
+	})
 	if err != nil {
-		if strings.Contains(err.Error(), couchDBNotFoundErr) {
-			return nil, storage.ErrDataNotFound
+		return nil, fmt.Errorf("failed to subscribe to changes feed: %w", err)
+	}
+
+	events := make(chan ChangeEvent)
+
+	// kivik closes the feed once ctx is done, which unblocks changes.Next
+	go func() {
+		defer close(events)
+
+		defer func() {
+			if errClose := changes.Close(); errClose != nil {
+				logger.Warnf("failed to close changes feed: %s", errClose)
+			}
+		}()
+
+		for changes.Next() {
+			if strings.HasPrefix(changes.ID(), designDocPrefix) {
+				continue
+			}
+
+			event := ChangeEvent{Key: c.unmapKey(changes.ID()), Deleted: changes.Deleted(), Seq: changes.Seq()}
+
+			if revs := changes.Changes(); len(revs) > 0 {
+				event.Rev = revs[0]
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
 		}
 
-		return nil, err
-	}
+		if errFeed := changes.Err(); errFeed != nil && ctx.Err() == nil {
+			logger.Warnf("changes feed stopped: %s", errFeed)
+		}
+	}()
 
-	return c.getStoredValueFromRawDoc(rawDoc, k)
+	return events, nil
 }
 
-// get rev ID.
-func (c *CouchDBStore) getRevID(k string) (string, error) {
-	rawDoc := make(map[string]interface{})
+// Iterator returns iterator for the latest snapshot of the underlying db, soft deleted and expired docs are skipped.
+func (c *CouchDBStore) Iterator(startKey, endKey string) storage.StoreIterator {
+	var resultRows *kivik.Rows
 
-	row := c.db.Get(context.Background(), k)
+	// the context has to outlive the query as results are read until the iterator is released
+	ctx, cancel := c.newContext()
 
-	err := row.ScanDoc(&rawDoc)
+	options := kivik.Options{
+		"startkey":      c.mapKey(startKey),
+		"endkey":        c.mapEndKey(endKey),
+		"inclusive_end": "false", // endkey should be exclusive to be consistent with goleveldb
+		"include_docs":  "true",
+	}
+
+	if c.stableReads {
+		options["stable"] = "true"
+	}
+
+	err := c.run(func() error {
+		var errQuery error
+		resultRows, errQuery = c.db.AllDocs(ctx, options)
+
+		return errQuery
+	})
 	if err != nil {
-		if strings.Contains(err.Error(), couchDBNotFoundErr) {
-			return "", nil
-		}
+		cancel()
 
-		return "", err
+		return storage.WithTotalItems(&couchDBResultsIterator{store: c, ctx: ctx, cancel: cancel,
+			err: fmt.Errorf("failed to query docs: %w", err)})
 	}
 
-	return rawDoc["_rev"].(string), nil
+	return storage.WithTotalItems(&couchDBResultsIterator{store: c, resultRows: resultRows, ctx: ctx, cancel: cancel})
 }
 
-// Delete will delete record with k key.
-func (c *CouchDBStore) Delete(k string) error {
-	if k == "" {
-		return errors.New("key is mandatory")
+// IteratorWithFilter returns an iterator of the range iterated by Iterator(startKey, endKey) skipping the docs with
+// keys rejected by keyPredicate. The doc IDs of the range are queried first, only the docs with accepted keys are
+// then fetched.
+func (c *CouchDBStore) IteratorWithFilter(startKey, endKey string,
+	keyPredicate func(key string) bool) storage.StoreIterator {
+	docIDs, err := c.filterDocIDs(startKey, endKey, keyPredicate)
+	if err != nil {
+		return storage.EmptyIterator(fmt.Errorf("failed to query doc IDs: %w", err))
 	}
 
-	revID, err := c.getRevID(k)
-	if err != nil {
-		return err
+	if len(docIDs) == 0 {
+		return storage.EmptyIterator(nil)
 	}
 
-	// no error if nothing to delete
-	if revID == "" {
-		return nil
+	// the context has to outlive the query as results are read until the iterator is released
+	ctx, cancel := c.newContext()
+
+	options := kivik.Options{
+		"keys":         docIDs,
+		"include_docs": "true",
+	}
+
+	if c.stableReads {
+		options["stable"] = "true"
 	}
 
-	_, err = c.db.Delete(context.TODO(), k, revID)
+	var resultRows *kivik.Rows
+
+	err = c.run(func() error {
+		var errQuery error
+		resultRows, errQuery = c.db.AllDocs(ctx, options)
+
+		return errQuery
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete doc: %w", err)
+		cancel()
+
+		return storage.WithTotalItems(&couchDBResultsIterator{store: c, ctx: ctx, cancel: cancel,
+			err: fmt.Errorf("failed to query docs: %w", err)})
 	}
 
-	return nil
+	return storage.WithTotalItems(&couchDBResultsIterator{store: c, resultRows: resultRows, ctx: ctx, cancel: cancel})
 }
 
-// Iterator returns iterator for the latest snapshot of the underlying db.
-func (c *CouchDBStore) Iterator(startKey, endKey string) storage.StoreIterator {
-	resultRows, err := c.db.AllDocs(context.TODO(), kivik.Options{
-		"startkey":      startKey,
-		"endkey":        strings.ReplaceAll(endKey, storage.EndKeySuffix, kivik.EndKeySuffix),
-		"inclusive_end": "false", // endkey should be exclusive to be consistent with goleveldb
-		"include_docs":  "true",
+// filterDocIDs returns the IDs of the docs of the range with keys accepted by keyPredicate, in the order of the range.
+func (c *CouchDBStore) filterDocIDs(startKey, endKey string, keyPredicate func(key string) bool) ([]string, error) {
+	var docIDs []string
+
+	err := c.do(func(ctx context.Context) error {
+		options := kivik.Options{
+			"startkey":      c.mapKey(startKey),
+			"endkey":        c.mapEndKey(endKey),
+			"inclusive_end": "false",
+		}
+
+		if c.stableReads {
+			options["stable"] = "true"
+		}
+
+		rows, err := c.db.AllDocs(ctx, options)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			if errClose := rows.Close(); errClose != nil {
+				logger.Warnf("failed to close doc ID rows: %s", errClose)
+			}
+		}()
+
+		for rows.Next() {
+			docID, errUnquote := strconv.Unquote(rows.Key())
+			if errUnquote != nil {
+				return errUnquote
+			}
+
+			if keyPredicate(c.unmapKey(docID)) {
+				docIDs = append(docIDs, docID)
+			}
+		}
+
+		return rows.Err()
 	})
-	if err != nil {
-		return &couchDBResultsIterator{store: c, resultRows: &kivik.Rows{},
-			err: fmt.Errorf("failed to query docs: %w", err)}
+
+	return docIDs, err
+}
+
+// mapEndKey maps the end key of an iterator, the end key suffix is kept as mapKey preserves prefixes.
+func (c *CouchDBStore) mapEndKey(endKey string) string {
+	if strings.HasSuffix(endKey, storage.EndKeySuffix) {
+		return c.mapKey(strings.TrimSuffix(endKey, storage.EndKeySuffix)) + kivik.EndKeySuffix
 	}
 
-	return &couchDBResultsIterator{store: c, resultRows: resultRows}
+	return c.mapKey(endKey)
 }
 
 type couchDBResultsIterator struct {
-	store      *CouchDBStore
+	store *CouchDBStore
+	// resultRows is nil if the query failed
 	resultRows *kivik.Rows
+	ctx        context.Context
+	cancel     context.CancelFunc
 	err        error
 }
 
 func (i *couchDBResultsIterator) Next() bool {
-	return i.resultRows.Next()
+	if i.resultRows == nil {
+		return false
+	}
+
+	for i.resultRows.Next() {
+		rawDoc := make(map[string]interface{})
+
+		if err := i.resultRows.ScanDoc(&rawDoc); err != nil {
+			i.err = err
+
+			return false
+		}
+
+		// docs queried by ID are null if deleted since
+		if rawDoc != nil && !i.store.isExpired(rawDoc) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (i *couchDBResultsIterator) Release() {
+	defer i.cancel()
+
+	if i.resultRows == nil {
+		return
+	}
+
 	if err := i.resultRows.Close(); err != nil {
 		i.err = err
 	}
@@ -304,19 +1571,29 @@ func (i *couchDBResultsIterator) Error() error {
 
 // Key returns the key of the current key-value pair.
 func (i *couchDBResultsIterator) Key() []byte {
+	docID := i.docID()
+	if docID == "" {
+		return nil
+	}
+
+	return []byte(i.store.unmapKey(docID))
+}
+
+// docID returns the doc ID of the current key-value pair.
+func (i *couchDBResultsIterator) docID() string {
 	key := i.resultRows.Key()
 	if key != "" {
 		// The returned key is a raw JSON string. It needs to be unescaped:
 		v, err := strconv.Unquote(key)
 		if err != nil {
 			i.err = err
-			return nil
+			return ""
 		}
 
-		return []byte(v)
+		return v
 	}
 
-	return nil
+	return ""
 }
 
 // Value returns the value of the current key-value pair.
@@ -330,9 +1607,7 @@ func (i *couchDBResultsIterator) Value() []byte {
 		return nil
 	}
 
-	key := i.Key()
-
-	v, err := i.store.getStoredValueFromRawDoc(rawDoc, string(key))
+	v, err := i.store.getStoredValueFromRawDoc(i.ctx, rawDoc, i.docID())
 	if err != nil {
 		i.err = err
 
@@ -342,22 +1617,18 @@ func (i *couchDBResultsIterator) Value() []byte {
 	return v
 }
 
-func (c *CouchDBStore) getStoredValueFromRawDoc(rawDoc map[string]interface{}, k string) ([]byte, error) {
+func (c *CouchDBStore) getStoredValueFromRawDoc(ctx context.Context, rawDoc map[string]interface{},
+	docID string) ([]byte, error) {
 	_, containsAttachment := rawDoc["_attachments"]
 	if containsAttachment {
-		return c.getDataFromAttachment(k)
-	}
-
-	strippedJSON, err := json.Marshal(rawDoc["payload"])
-	if err != nil {
-		return nil, err
+		return c.getDataFromAttachment(ctx, docID)
 	}
 
-	return strippedJSON, nil
+	return getJSONValueFromRawDoc(rawDoc)
 }
 
-func (c *CouchDBStore) getDataFromAttachment(k string) ([]byte, error) {
-	attachment, err := c.db.GetAttachment(context.Background(), k, "data")
+func (c *CouchDBStore) getDataFromAttachment(ctx context.Context, docID string) ([]byte, error) {
+	attachment, err := c.db.GetAttachment(ctx, docID, "data")
 	if err != nil {
 		return nil, err
 	}
@@ -10,11 +10,17 @@ package couchdbstore
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-kivik/kivik"
 	"github.com/google/uuid"
@@ -269,6 +275,9 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 
 	var vals []string
 
+	require.NoError(t, itr.Error())
+	require.Equal(t, count, itr.TotalItems())
+
 	for itr.Next() {
 		if prefix != "" {
 			require.True(t, strings.HasPrefix(string(itr.Key()), prefix))
@@ -277,6 +286,7 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 		vals = append(vals, string(itr.Value()))
 	}
 	require.Len(t, vals, count)
+	require.Equal(t, count, itr.TotalItems())
 
 	itr.Release()
 	require.False(t, itr.Next())
@@ -284,6 +294,7 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 	require.Empty(t, itr.Value())
 	require.Error(t, itr.Error())
 	require.Contains(t, itr.Error().Error(), "Iterator is closed")
+	require.Zero(t, itr.TotalItems())
 }
 
 func TestCouchDBStore_Delete(t *testing.T) {
@@ -324,9 +335,1294 @@ func TestCouchDBStore_Delete(t *testing.T) {
 	require.Empty(t, doc)
 }
 
-func randomKey() string {
-	// prefix `key` is needed for couchdb due to error e.g Name: '7c80bdcd-b0e3-405a-bb82-fae75f9f2470'.
-	// Only lowercase characters (a-z), digits (0-9), and any of the characters _, $, (, ), +, -, and / are allowed.
-	// Must begin with a letter.
-	return "key" + uuid.New().String()
+func TestCouchDBStore_WithIndexes(t *testing.T) {
+	indexes := []Index{
+		{Name: "state-index", Fields: []string{"payload.state"}},
+		{DesignDoc: "theirdid-ddoc", Name: "theirdid-index", Fields: []string{"payload.theirDID"}},
+	}
+
+	prov, err := NewProvider(couchDBURL, WithIndexes(indexes))
+	require.NoError(t, err)
+
+	storeName := randomKey()
+
+	store, err := prov.OpenStore(storeName)
+	require.NoError(t, err)
+
+	verifyIndexes := func(db *kivik.DB) {
+		existing, e := db.GetIndexes(context.Background())
+		require.NoError(t, e)
+
+		names := make(map[string]struct{})
+		for _, index := range existing {
+			names[index.Name] = struct{}{}
+		}
+
+		for _, index := range indexes {
+			require.Contains(t, names, index.Name)
+		}
+	}
+
+	verifyIndexes(store.(*CouchDBStore).db)
+
+	require.NoError(t, prov.Close())
+
+	// re-opening the store with the same indexes from another provider must not fail
+	prov2, err := NewProvider(couchDBURL, WithIndexes(indexes))
+	require.NoError(t, err)
+
+	store, err = prov2.OpenStore(storeName)
+	require.NoError(t, err)
+
+	verifyIndexes(store.(*CouchDBStore).db)
+
+	require.NoError(t, prov2.Close())
+}
+
+func TestCouchDBStore_ConnectionPool(t *testing.T) {
+	t.Run("default transport is kept when the pool isn't configured", func(t *testing.T) {
+		prov, err := NewProvider(couchDBURL)
+		require.NoError(t, err)
+		require.Nil(t, prov.transport)
+	})
+
+	t.Run("configured transport limits", func(t *testing.T) {
+		prov, err := NewProvider(couchDBURL, WithMaxIdleConns(50), WithMaxConnsPerHost(100))
+		require.NoError(t, err)
+
+		require.NotNil(t, prov.transport)
+		require.Equal(t, 50, prov.transport.MaxIdleConns)
+		require.Equal(t, 50, prov.transport.MaxIdleConnsPerHost)
+		require.Equal(t, 100, prov.transport.MaxConnsPerHost)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		type getResult struct {
+			value []byte
+			err   error
+		}
+
+		const concurrentOps = 20
+
+		results := make(chan getResult, concurrentOps)
+
+		// concurrent operations go through the configured transport
+		for i := 0; i < concurrentOps; i++ {
+			go func(i int) {
+				key := fmt.Sprintf("key%d", i)
+
+				if e := store.Put(key, []byte("value")); e != nil {
+					results <- getResult{err: e}
+
+					return
+				}
+
+				v, e := store.Get(key)
+				results <- getResult{value: v, err: e}
+			}(i)
+		}
+
+		for i := 0; i < concurrentOps; i++ {
+			r := <-results
+			require.NoError(t, r.err)
+			require.Equal(t, []byte("value"), r.value)
+		}
+
+		require.NoError(t, prov.Close())
+	})
+}
+
+func TestCouchDBStore_DeleteStore(t *testing.T) {
+	prov, err := NewProvider(couchDBURL, WithDBPrefix("dbprefix"))
+	require.NoError(t, err)
+
+	name := randomKey()
+
+	store, err := prov.OpenStore(name)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("key1", []byte("value1")))
+
+	require.NoError(t, prov.DeleteStore(name))
+
+	// the store is re-created empty
+	store, err = prov.OpenStore(name)
+	require.NoError(t, err)
+
+	_, err = store.Get("key1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	// deleting a store which isn't opened destroys its db too
+	require.NoError(t, store.Put("key1", []byte("value1")))
+	require.NoError(t, prov.CloseStore(name))
+	require.NoError(t, prov.DeleteStore(name))
+
+	store, err = prov.OpenStore(name)
+	require.NoError(t, err)
+
+	_, err = store.Get("key1")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	// try to delete non existing store
+	require.NoError(t, prov.DeleteStore("store_x"))
+
+	require.NoError(t, prov.Close())
+}
+
+func TestCouchDBStore_Tenant(t *testing.T) {
+	prefix := randomKey()
+
+	provA, err := NewProvider(couchDBURL, WithDBPrefix(prefix), WithTenant("tenanta"))
+	require.NoError(t, err)
+
+	provB, err := NewProvider(couchDBURL, WithDBPrefix(prefix), WithTenant("tenantb"))
+	require.NoError(t, err)
+
+	storeA, err := provA.OpenStore("connections")
+	require.NoError(t, err)
+
+	storeB, err := provB.OpenStore("connections")
+	require.NoError(t, err)
+
+	require.NoError(t, storeA.Put("conn_1", []byte("tenant a")))
+	require.NoError(t, storeA.Put("conn_2", []byte("tenant a")))
+	require.NoError(t, storeB.Put("conn_1", []byte("tenant b")))
+
+	v, err := storeA.Get("conn_1")
+	require.NoError(t, err)
+	require.Equal(t, "tenant a", string(v))
+
+	v, err = storeB.Get("conn_1")
+	require.NoError(t, err)
+	require.Equal(t, "tenant b", string(v))
+
+	_, err = storeB.Get("conn_2")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	// iteration and counting stay within the store of the tenant
+	itr := storeB.Iterator("conn_", "conn_"+storage.EndKeySuffix)
+	require.Equal(t, 1, itr.TotalItems())
+	require.True(t, itr.Next())
+	require.Equal(t, "conn_1", string(itr.Key()))
+	require.Equal(t, "tenant b", string(itr.Value()))
+	require.False(t, itr.Next())
+	itr.Release()
+
+	itr = storeA.Iterator("conn_", "conn_"+storage.EndKeySuffix)
+	require.Equal(t, 2, itr.TotalItems())
+	itr.Release()
+
+	_, err = provB.OpenStore("dids")
+	require.NoError(t, err)
+
+	names, err := provA.StoreNames()
+	require.NoError(t, err)
+	require.Equal(t, []string{"connections"}, names)
+
+	names, err = provB.StoreNames()
+	require.NoError(t, err)
+	require.Equal(t, []string{"connections", "dids"}, names)
+
+	// closing the stores of a tenant leaves the stores of the other tenants open
+	require.NoError(t, provA.DeleteStore("connections"))
+	require.NoError(t, provA.Close())
+
+	v, err = storeB.Get("conn_1")
+	require.NoError(t, err)
+	require.Equal(t, "tenant b", string(v))
+
+	require.NoError(t, provB.DeleteStore("connections"))
+	require.NoError(t, provB.DeleteStore("dids"))
+	require.NoError(t, provB.Close())
+}
+
+func TestProvider_StoreNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/_all_dbs" {
+			_, _ = w.Write([]byte(`["_replicator","_users","app_tenanta_dids","app_tenanta_connections",` +
+				`"app_tenantb_connections","tenanta_connections","app_other"]`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	for _, test := range []struct {
+		opts  []Option
+		db    string
+		names []string
+	}{
+		{
+			opts:  []Option{WithDBPrefix("app"), WithTenant("tenanta")},
+			db:    "app_tenanta_connections",
+			names: []string{"connections", "dids"},
+		},
+		{
+			opts:  []Option{WithDBPrefix("app"), WithTenant("tenantb")},
+			db:    "app_tenantb_connections",
+			names: []string{"connections"},
+		},
+		{
+			opts:  []Option{WithTenant("tenanta")},
+			db:    "tenanta_connections",
+			names: []string{"connections"},
+		},
+		{
+			opts:  []Option{WithDBPrefix("app")},
+			db:    "app_connections",
+			names: []string{"other", "tenanta_connections", "tenanta_dids", "tenantb_connections"},
+		},
+	} {
+		prov, err := NewProvider(server.URL, test.opts...)
+		require.NoError(t, err)
+		require.Equal(t, test.db, prov.dbName("connections"))
+
+		names, err := prov.StoreNames()
+		require.NoError(t, err)
+		require.Equal(t, test.names, names)
+	}
+
+	_, err := NewProvider(server.URL, WithTenant("Tenant A"))
+	require.EqualError(t, err, "invalid tenant ID 'Tenant A'")
+}
+
+func TestCouchDBStore_Subscribe(t *testing.T) {
+	prov, err := NewProvider(couchDBURL, WithDBPrefix("dbprefix"))
+	require.NoError(t, err)
+
+	store, err := prov.OpenStore(randomKey())
+	require.NoError(t, err)
+
+	couchDBStore, ok := store.(*CouchDBStore)
+	require.True(t, ok)
+
+	t.Run("test put and delete events arrive in order", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := couchDBStore.Subscribe(ctx, "now")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("key1", []byte("value1")))
+		require.NoError(t, store.Put("key2", []byte(`{"field":"value2"}`)))
+		require.NoError(t, store.Delete("key1"))
+
+		expected := []struct {
+			key     string
+			deleted bool
+		}{{"key1", false}, {"key2", false}, {"key1", true}}
+
+		for _, e := range expected {
+			select {
+			case event := <-events:
+				require.Equal(t, e.key, event.Key)
+				require.Equal(t, e.deleted, event.Deleted)
+				require.NotEmpty(t, event.Rev)
+				require.NotEmpty(t, event.Seq)
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "timeout waiting for change event of "+e.key)
+			}
+		}
+	})
+
+	t.Run("test subscription resumes after since", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := couchDBStore.Subscribe(ctx, "")
+		require.NoError(t, err)
+
+		var first ChangeEvent
+
+		select {
+		case first = <-events:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timeout waiting for change event")
+		}
+
+		cancel()
+
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err = couchDBStore.Subscribe(ctx, first.Seq)
+		require.NoError(t, err)
+
+		select {
+		case event := <-events:
+			require.NotEqual(t, first.Seq, event.Seq)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timeout waiting for change event")
+		}
+	})
+
+	t.Run("test cancelling context closes the channel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		events, err := couchDBStore.Subscribe(ctx, "now")
+		require.NoError(t, err)
+
+		cancel()
+
+		select {
+		case _, open := <-events:
+			require.False(t, open)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timeout waiting for the channel to be closed")
+		}
+	})
+
+	require.NoError(t, prov.Close())
+}
+
+func TestCouchDBStore_KeyMapper(t *testing.T) {
+	keys := []string{"did:example:123", "did:example:456/path", "did:other:789", "123abc", "_underscore", "a/b/c"}
+
+	t.Run("test keys mapped to valid doc IDs by default", func(t *testing.T) {
+		prov, err := NewProvider(couchDBURL)
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		for _, k := range keys {
+			require.NoError(t, store.Put(k, []byte("value of "+k)))
+		}
+
+		for _, k := range keys {
+			v, e := store.Get(k)
+			require.NoError(t, e)
+			require.Equal(t, []byte("value of "+k), v)
+		}
+
+		// the doc is stored under the hex encoded key
+		var rawDoc map[string]interface{}
+		err = store.(*CouchDBStore).db.Get(context.Background(), hexMapKey("_underscore")).ScanDoc(&rawDoc)
+		require.NoError(t, err)
+
+		itr := store.Iterator("did:example:", "did:example:"+storage.EndKeySuffix)
+
+		var iterated []string
+		for itr.Next() {
+			iterated = append(iterated, string(itr.Key()))
+			require.Equal(t, []byte("value of "+string(itr.Key())), itr.Value())
+		}
+
+		require.NoError(t, itr.Error())
+		itr.Release()
+
+		require.Equal(t, []string{"did:example:123", "did:example:456/path"}, iterated)
+
+		require.NoError(t, store.Delete("did:example:123"))
+
+		_, err = store.Get("did:example:123")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("test raw keys used as doc IDs", func(t *testing.T) {
+		prov, err := NewProvider(couchDBURL, WithRawKeys())
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("did:example:123", []byte("value")))
+
+		var rawDoc map[string]interface{}
+		err = store.(*CouchDBStore).db.Get(context.Background(), "did:example:123").ScanDoc(&rawDoc)
+		require.NoError(t, err)
+
+		// the docs written before keys were mapped to doc IDs stay readable
+		_, err = store.(*CouchDBStore).db.Put(context.Background(), "did:example:456", map[string]interface{}{
+			"payload": "value",
+		})
+		require.NoError(t, err)
+
+		v, err := store.Get("did:example:456")
+		require.NoError(t, err)
+		require.Equal(t, []byte(`"value"`), v)
+
+		// keys CouchDB rejects as doc IDs fail
+		require.Error(t, store.Put("_underscore", []byte("value")))
+
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("test custom key mapper", func(t *testing.T) {
+		prefix := func(k string) string { return "k" + k }
+		unprefix := func(docID string) string { return strings.TrimPrefix(docID, "k") }
+
+		prov, err := NewProvider(couchDBURL, WithKeyMapper(prefix, unprefix))
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("did:example:123", []byte("value")))
+
+		// the doc is stored under the mapped doc ID
+		var rawDoc map[string]interface{}
+		err = store.(*CouchDBStore).db.Get(context.Background(), "kdid:example:123").ScanDoc(&rawDoc)
+		require.NoError(t, err)
+
+		itr := store.Iterator("did:", "did:"+storage.EndKeySuffix)
+		require.True(t, itr.Next())
+		require.Equal(t, []byte("did:example:123"), itr.Key())
+		require.False(t, itr.Next())
+		itr.Release()
+
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("test hex key mapping", func(t *testing.T) {
+		for _, k := range keys {
+			require.Equal(t, k, hexUnmapKey(hexMapKey(k)))
+		}
+
+		// order and prefixes are preserved
+		require.True(t, strings.HasPrefix(hexMapKey("did:example:123"), hexMapKey("did:example:")))
+		require.Less(t, hexMapKey("did:example:123"), hexMapKey("did:example:456"))
+
+		// doc IDs not written by the store are returned as is
+		require.Equal(t, "_design/index", hexUnmapKey("_design/index"))
+	})
+}
+
+func TestCouchDBStore_OpenStoreIdempotent(t *testing.T) {
+	server := newCountingCouchDB()
+	defer server.Close()
+
+	prov, err := NewProvider(server.URL, WithDBPrefix("dbprefix"))
+	require.NoError(t, err)
+
+	type openResult struct {
+		store storage.Store
+		err   error
+	}
+
+	const concurrentOpens = 10
+
+	results := make(chan openResult, concurrentOpens)
+
+	for i := 0; i < concurrentOpens; i++ {
+		go func() {
+			s, e := prov.OpenStore("store1")
+			results <- openResult{store: s, err: e}
+		}()
+	}
+
+	opened := make([]openResult, concurrentOpens)
+	for i := range opened {
+		opened[i] = <-results
+	}
+
+	store, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	for _, r := range opened {
+		require.NoError(t, r.err)
+		require.Same(t, store, r.store)
+	}
+
+	require.Equal(t, 1, server.createDBCount("dbprefix_store1"))
+
+	// a closed store is re-created on next open
+	require.NoError(t, prov.CloseStore("store1"))
+
+	_, err = prov.OpenStore("store1")
+	require.NoError(t, err)
+	require.Equal(t, 2, server.createDBCount("dbprefix_store1"))
+}
+
+// countingCouchDB is a minimal fake CouchDB server counting the database creation requests.
+type countingCouchDB struct {
+	*httptest.Server
+	createDB map[string]int
+	mu       sync.Mutex
+}
+
+func newCountingCouchDB() *countingCouchDB {
+	c := &countingCouchDB{createDB: map[string]int{}}
+
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPut {
+			c.mu.Lock()
+			c.createDB[strings.Trim(r.URL.Path, "/")]++
+			c.mu.Unlock()
+
+			w.WriteHeader(http.StatusCreated)
+		}
+
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+
+	return c
+}
+
+func (c *countingCouchDB) createDBCount(name string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.createDB[name]
+}
+
+type fakeClock struct {
+	now time.Time
+	mu  sync.RWMutex
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func TestCouchDBStore_PutWithTTL(t *testing.T) {
+	t.Run("expired docs are filtered out", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+
+		prov, err := NewProvider(couchDBURL, WithClock(clock.Now))
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		err = store.PutWithTTL("abc_123", []byte("value1"), 0)
+		require.True(t, errors.Is(err, storage.ErrInvalidTTL))
+
+		err = store.PutWithTTL("abc_123", []byte("value1"), time.Minute)
+		require.NoError(t, err)
+
+		err = store.PutWithTTL("abc_124", []byte(`{"key1":"value1"}`), time.Hour)
+		require.NoError(t, err)
+
+		doc, err := store.Get("abc_123")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), doc)
+
+		clock.Add(time.Minute)
+
+		doc, err = store.Get("abc_123")
+		require.EqualError(t, err, storage.ErrDataNotFound.Error())
+		require.Empty(t, doc)
+
+		itr := store.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+		verifyItr(t, itr, 1, "abc_")
+
+		// put without TTL clears the expiry
+		err = store.Put("abc_124", []byte(`{"key1":"value1"}`))
+		require.NoError(t, err)
+
+		clock.Add(time.Hour)
+
+		doc, err = store.Get("abc_124")
+		require.NoError(t, err)
+		require.Equal(t, []byte(`{"key1":"value1"}`), doc)
+
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("expired docs are purged", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+
+		prov, err := NewProvider(couchDBURL, WithClock(clock.Now),
+			WithExpirySweepInterval(10*time.Millisecond))
+		require.NoError(t, err)
+
+		name := randomKey()
+
+		store, err := prov.OpenStore(name)
+		require.NoError(t, err)
+
+		err = store.PutWithTTL("abc_123", []byte("value1"), time.Minute)
+		require.NoError(t, err)
+
+		err = store.PutWithTTL("abc_124", []byte("value2"), time.Hour)
+		require.NoError(t, err)
+
+		clock.Add(time.Minute)
+
+		db := store.(*CouchDBStore).db
+		docID := store.(*CouchDBStore).mapKey("abc_123")
+
+		require.Eventually(t, func() bool {
+			return kivik.StatusCode(db.Get(context.Background(), docID).Err) == http.StatusNotFound
+		}, time.Second, 10*time.Millisecond)
+
+		doc, err := store.Get("abc_124")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value2"), doc)
+
+		require.NoError(t, prov.CloseStore(name))
+	})
+}
+
+func randomKey() string {
+	// prefix `key` is needed for couchdb due to error e.g Name: '7c80bdcd-b0e3-405a-bb82-fae75f9f2470'.
+	// Only lowercase characters (a-z), digits (0-9), and any of the characters _, $, (, ), +, -, and / are allowed.
+	// Must begin with a letter.
+	return "key" + uuid.New().String()
+}
+
+// newSlowCouchDB returns a minimal fake CouchDB server answering the doc requests after the given delay,
+// or once the request is canceled.
+func newSlowCouchDB(delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		// doc paths are /{db}/{docID}
+		if strings.Count(strings.Trim(r.URL.Path, "/"), "/") == 1 {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found","reason":"missing"}`))
+
+			return
+		}
+
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusCreated)
+		}
+
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+}
+
+// capturingCouchDB is a minimal fake CouchDB server capturing the query parameters of the doc and all docs requests,
+// and the methods of the doc requests.
+type capturingCouchDB struct {
+	*httptest.Server
+	docQuery     url.Values
+	docMethods   []string
+	allDocsQuery url.Values
+	mu           sync.Mutex
+}
+
+func newCapturingCouchDB() *capturingCouchDB {
+	c := &capturingCouchDB{}
+
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/_all_docs"):
+			c.allDocsQuery = r.URL.Query()
+
+			_, _ = w.Write([]byte(`{"total_rows":0,"offset":0,"rows":[]}`))
+		// doc paths are /{db}/{docID}
+		case strings.Count(strings.Trim(r.URL.Path, "/"), "/") == 1:
+			c.docQuery = r.URL.Query()
+			c.docMethods = append(c.docMethods, r.Method)
+
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found","reason":"missing"}`))
+		default:
+			if r.Method == http.MethodPut {
+				w.WriteHeader(http.StatusCreated)
+			}
+
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+
+	return c
+}
+
+func (c *capturingCouchDB) queries() (url.Values, url.Values) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.docQuery, c.allDocsQuery
+}
+
+func (c *capturingCouchDB) methods() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.docMethods
+}
+
+// newFilteringCouchDB is a minimal fake CouchDB server listing the given docs by ID, the keys of the docs fetched by
+// ID are sent to fetched.
+func newFilteringCouchDB(keys []string, fetched chan<- []string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/_all_docs") && r.Method == http.MethodPost:
+			var request struct {
+				Keys []string `json:"keys"`
+			}
+
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			rows := make([]string, len(request.Keys))
+			unmapped := make([]string, len(request.Keys))
+
+			for i, docID := range request.Keys {
+				unmapped[i] = hexUnmapKey(docID)
+				rows[i] = fmt.Sprintf(`{"id":%q,"key":%q,"value":{"rev":"1-a"},"doc":{"_id":%q,"_rev":"1-a",`+
+					`"payload":{"key":%q}}}`, docID, docID, docID, unmapped[i])
+			}
+
+			fetched <- unmapped
+
+			_, _ = fmt.Fprintf(w, `{"total_rows":%d,"offset":0,"rows":[%s]}`, len(keys), strings.Join(rows, ","))
+		case strings.HasSuffix(r.URL.Path, "/_all_docs"):
+			if r.URL.Query().Get("include_docs") == "true" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			rows := make([]string, len(keys))
+
+			for i, k := range keys {
+				rows[i] = fmt.Sprintf(`{"id":%q,"key":%q,"value":{"rev":"1-a"}}`, hexMapKey(k), hexMapKey(k))
+			}
+
+			_, _ = fmt.Fprintf(w, `{"total_rows":%d,"offset":0,"rows":[%s]}`, len(keys), strings.Join(rows, ","))
+		default:
+			if r.Method == http.MethodPut {
+				w.WriteHeader(http.StatusCreated)
+			}
+
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+}
+
+func TestCouchDBStore_IteratorWithFilter(t *testing.T) {
+	keys := []string{"abc_123", "abc_124", "abc_125", "abc_126", "abc_127"}
+
+	openStore := func(t *testing.T, fetched chan<- []string) storage.Store {
+		t.Helper()
+
+		server := newFilteringCouchDB(keys, fetched)
+		t.Cleanup(server.Close)
+
+		prov, err := NewProvider(server.URL)
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		return store
+	}
+
+	t.Run("only the docs with accepted keys are fetched", func(t *testing.T) {
+		fetched := make(chan []string, 1)
+		store := openStore(t, fetched)
+
+		itr := store.IteratorWithFilter("abc_", "abc_"+storage.EndKeySuffix, func(key string) bool {
+			return key != "abc_124" && key != "abc_126"
+		})
+
+		require.Equal(t, []string{"abc_123", "abc_125", "abc_127"}, <-fetched)
+
+		for _, k := range []string{"abc_123", "abc_125", "abc_127"} {
+			require.True(t, itr.Next())
+			require.Equal(t, k, string(itr.Key()))
+			require.JSONEq(t, fmt.Sprintf(`{"key":%q}`, k), string(itr.Value()))
+		}
+
+		require.False(t, itr.Next())
+		require.NoError(t, itr.Error())
+		itr.Release()
+	})
+
+	t.Run("no docs are fetched if all keys are rejected", func(t *testing.T) {
+		fetched := make(chan []string, 1)
+		store := openStore(t, fetched)
+
+		itr := store.IteratorWithFilter("abc_", "abc_"+storage.EndKeySuffix, func(string) bool { return false })
+		require.False(t, itr.Next())
+		require.NoError(t, itr.Error())
+		require.Zero(t, itr.TotalItems())
+		itr.Release()
+
+		require.Empty(t, fetched)
+	})
+
+	t.Run("doc IDs query failure", func(t *testing.T) {
+		server := newFailingCouchDB()
+		defer server.Close()
+
+		prov, err := NewProvider(server.URL)
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		itr := store.IteratorWithFilter("abc_", "abc_"+storage.EndKeySuffix, func(string) bool { return true })
+		require.False(t, itr.Next())
+		require.Error(t, itr.Error())
+		require.Contains(t, itr.Error().Error(), "failed to query doc IDs")
+		itr.Release()
+	})
+}
+
+func TestCouchDBStore_ReadConsistency(t *testing.T) {
+	read := func(t *testing.T, opts ...Option) (url.Values, url.Values) {
+		t.Helper()
+
+		server := newCapturingCouchDB()
+		defer server.Close()
+
+		prov, err := NewProvider(server.URL, opts...)
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound), err)
+
+		itr := store.Iterator("key", "key"+storage.EndKeySuffix)
+		require.False(t, itr.Next())
+		require.NoError(t, itr.Error())
+		itr.Release()
+
+		return server.queries()
+	}
+
+	t.Run("read quorum and stable reads are passed to CouchDB", func(t *testing.T) {
+		docQuery, allDocsQuery := read(t, WithReadQuorum(2), WithStableReads(true))
+		require.Equal(t, "2", docQuery.Get("r"))
+		require.Equal(t, "true", allDocsQuery.Get("stable"))
+	})
+
+	t.Run("CouchDB defaults are kept by default", func(t *testing.T) {
+		docQuery, allDocsQuery := read(t)
+		require.Empty(t, docQuery)
+		require.NotContains(t, allDocsQuery, "stable")
+		require.Equal(t, "false", allDocsQuery.Get("inclusive_end"))
+	})
+}
+
+func TestCouchDBStore_OperationTimeout(t *testing.T) {
+	server := newSlowCouchDB(200 * time.Millisecond)
+	defer server.Close()
+
+	t.Run("operations exceeding the timeout fail", func(t *testing.T) {
+		prov, err := NewProvider(server.URL, WithOperationTimeout(50*time.Millisecond))
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+
+		err = store.Put("key", []byte("value"))
+		require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+
+		err = store.Delete("key")
+		require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+
+		itr := store.Iterator("key", "key"+storage.EndKeySuffix)
+		require.False(t, itr.Next())
+		require.True(t, errors.Is(itr.Error(), context.DeadlineExceeded), itr.Error())
+		itr.Release()
+	})
+
+	t.Run("operations within the timeout succeed", func(t *testing.T) {
+		prov, err := NewProvider(server.URL, WithOperationTimeout(5*time.Second))
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound), err)
+	})
+}
+
+func TestCouchDBStore_Has(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+
+	prov, err := NewProvider(couchDBURL, WithClock(clock.Now))
+	require.NoError(t, err)
+
+	store, err := prov.OpenStore(randomKey())
+	require.NoError(t, err)
+
+	err = store.Put("abc_123", []byte("value1"))
+	require.NoError(t, err)
+
+	err = store.Put("abc_124", []byte(`{"key1":"value1"}`))
+	require.NoError(t, err)
+
+	err = store.PutWithTTL("abc_125", []byte("value3"), time.Minute)
+	require.NoError(t, err)
+
+	for _, k := range []string{"abc_123", "abc_124", "abc_125"} {
+		exists, errHas := store.Has(k)
+		require.NoError(t, errHas)
+		require.True(t, exists)
+	}
+
+	// missing doc isn't an error
+	exists, err := store.Has("abc_000")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// soft deleted and expired docs don't exist
+	err = store.SoftDelete("abc_123")
+	require.NoError(t, err)
+
+	clock.Add(time.Minute)
+
+	for _, k := range []string{"abc_123", "abc_125"} {
+		exists, err = store.Has(k)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+
+	_, err = store.Has("")
+	require.EqualError(t, err, "key is mandatory")
+}
+
+func TestCouchDBStore_HasReadsMeta(t *testing.T) {
+	server := newCapturingCouchDB()
+	defer server.Close()
+
+	prov, err := NewProvider(server.URL, WithReadQuorum(2))
+	require.NoError(t, err)
+
+	store, err := prov.OpenStore(randomKey())
+	require.NoError(t, err)
+
+	// the body of a missing doc isn't read, the read quorum is passed to CouchDB
+	exists, err := store.Has("key")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	docQuery, _ := server.queries()
+	require.Equal(t, "2", docQuery.Get("r"))
+	require.Equal(t, []string{http.MethodHead}, server.methods())
+}
+
+func TestCouchDBStore_DeletePrefix(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+
+	prov, err := NewProvider(couchDBURL, WithClock(clock.Now))
+	require.NoError(t, err)
+
+	store, err := prov.OpenStore(randomKey())
+	require.NoError(t, err)
+
+	for _, k := range []string{"abc_123", "abc_124", "abc_125", "abc123", "dab_123"} {
+		require.NoError(t, store.Put(k, []byte(`{"key1":"value1"}`)))
+	}
+
+	require.NoError(t, store.PutWithTTL("abc_126", []byte("value"), time.Minute))
+	require.NoError(t, store.SoftDelete("abc_125"))
+
+	clock.Add(time.Minute)
+
+	// expired docs are deleted as well but aren't counted, soft deleted docs are already deleted
+	deleted, err := store.DeletePrefix("abc_")
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	itr := store.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+	require.False(t, itr.Next())
+	itr.Release()
+
+	for _, k := range []string{"abc123", "dab_123"} {
+		exists, errHas := store.Has(k)
+		require.NoError(t, errHas)
+		require.True(t, exists)
+	}
+
+	deleted, err = store.DeletePrefix("abc_")
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	_, err = store.DeletePrefix("")
+	require.EqualError(t, err, "prefix is mandatory")
+
+	require.NoError(t, prov.Close())
+}
+
+func TestCouchDBStore_DeletePrefixExpiredDocs(t *testing.T) {
+	now := time.Now()
+
+	var allDocsQuery url.Values
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/_all_docs"):
+			allDocsQuery = r.URL.Query()
+
+			_, _ = fmt.Fprintf(w, `{"total_rows":2,"offset":0,"rows":[`+
+				`{"id":"abc_1","key":"abc_1","value":{"rev":"1-a"},"doc":{"_id":"abc_1","_rev":"1-a"}},`+
+				`{"id":"abc_2","key":"abc_2","value":{"rev":"1-a"},"doc":{"_id":"abc_2","_rev":"1-a","expiry":%d}}]}`,
+				toUnixMillis(now.Add(-time.Second)))
+		case strings.HasSuffix(r.URL.Path, "/_bulk_docs"):
+			_, _ = w.Write([]byte(`[{"ok":true,"id":"abc_1","rev":"2-b"},{"ok":true,"id":"abc_2","rev":"2-b"}]`))
+		default:
+			if r.Method == http.MethodPut {
+				w.WriteHeader(http.StatusCreated)
+			}
+
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	defer server.Close()
+
+	prov, err := NewProvider(server.URL, WithClock(func() time.Time { return now }))
+	require.NoError(t, err)
+
+	store, err := prov.OpenStore(randomKey())
+	require.NoError(t, err)
+
+	// both docs are deleted, the expired one isn't counted
+	deleted, err := store.DeletePrefix("abc_")
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+	require.Equal(t, "true", allDocsQuery.Get("include_docs"))
+}
+
+func TestCouchDBStore_Reset(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+
+	prov, err := NewProvider(couchDBURL, WithClock(clock.Now))
+	require.NoError(t, err)
+
+	store, err := prov.OpenStore(randomKey())
+	require.NoError(t, err)
+
+	for _, k := range []string{"abc_123", "abc_124", "dab_123"} {
+		require.NoError(t, store.Put(k, []byte(`{"key1":"value1"}`)))
+	}
+
+	require.NoError(t, store.PutWithTTL("abc_125", []byte("value"), time.Minute))
+
+	clock.Add(time.Minute)
+
+	require.NoError(t, store.Reset())
+
+	itr := store.Iterator("", storage.EndKeySuffix)
+	require.False(t, itr.Next())
+	itr.Release()
+
+	// the store remains usable
+	require.NoError(t, store.Put("abc_123", []byte("value")))
+
+	v, err := store.Get("abc_123")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), v)
+
+	require.NoError(t, store.Reset())
+
+	exists, err := store.Has("abc_123")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, prov.Close())
+}
+
+func TestCouchDBStore_NativeJSON(t *testing.T) {
+	prov, err := NewProvider(couchDBURL, WithNativeJSON())
+	require.NoError(t, err)
+
+	name := randomKey()
+
+	store, err := prov.OpenStore(name)
+	require.NoError(t, err)
+
+	values := map[string][]byte{
+		"abc_1": []byte(`{"state":"active","count":2,"nested":{"_id":"x"}}`),
+		"abc_2": []byte(`"value"`),
+		"abc_3": []byte("value3"),
+		"abc_4": []byte(`{"payload":"value4","_rev":"1"}`),
+		"abc_5": []byte(`{}`),
+		"abc_6": []byte(`["value6"]`),
+	}
+
+	for k, v := range values {
+		require.NoError(t, store.Put(k, v))
+	}
+
+	require.NoError(t, store.PutWithTTL("abc_7", []byte(`{"state":"active"}`), time.Minute))
+	values["abc_7"] = []byte(`{"state":"active"}`)
+
+	assertValues := func(store storage.Store) {
+		for k, v := range values {
+			stored, errGet := store.Get(k)
+			require.NoError(t, errGet)
+
+			if isJSON(v) {
+				require.JSONEq(t, string(v), string(stored))
+			} else {
+				require.Equal(t, v, stored)
+			}
+		}
+	}
+
+	assertValues(store)
+
+	// JSON objects are stored as the doc body
+	couchDBStore, ok := store.(*CouchDBStore)
+	require.True(t, ok)
+
+	rawDoc := make(map[string]interface{})
+	require.NoError(t, couchDBStore.db.Get(context.Background(), couchDBStore.mapKey("abc_1")).ScanDoc(&rawDoc))
+	require.Equal(t, "active", rawDoc["state"])
+	require.NotContains(t, rawDoc, payloadField)
+
+	rows, err := couchDBStore.db.Find(context.Background(), map[string]interface{}{
+		"selector": map[string]interface{}{"state": "active"},
+	})
+	require.NoError(t, err)
+
+	var found int
+	for rows.Next() {
+		found++
+	}
+
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, 2, found)
+
+	// the iterator and soft deleted docs return the stored values
+	itr := store.Iterator("abc_1", "abc_1"+storage.EndKeySuffix)
+	require.True(t, itr.Next())
+	require.JSONEq(t, string(values["abc_1"]), string(itr.Value()))
+	itr.Release()
+
+	require.NoError(t, store.SoftDelete("abc_1"))
+
+	v, deleted, err := store.GetIncludingDeleted("abc_1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+	require.JSONEq(t, string(values["abc_1"]), string(v))
+
+	require.NoError(t, store.Put("abc_1", values["abc_1"]))
+
+	// docs stored as native JSON are read by stores without the option
+	prov2, err := NewProvider(couchDBURL)
+	require.NoError(t, err)
+
+	store2, err := prov2.OpenStore(name)
+	require.NoError(t, err)
+
+	assertValues(store2)
+
+	require.NoError(t, prov.Close())
+	require.NoError(t, prov2.Close())
+}
+
+func TestProvider_ClosePartial(t *testing.T) {
+	server := newFailingCouchDB()
+	defer server.Close()
+
+	prov, err := NewProvider(server.URL)
+	require.NoError(t, err)
+
+	_, err = prov.OpenStore("closeable1")
+	require.NoError(t, err)
+
+	_, err = prov.OpenStore("closeable2")
+	require.NoError(t, err)
+
+	// kivik returns a DB failing every operation, including Close, when its name is empty
+	for _, name := range []string{"failing1", "failing2"} {
+		prov.dbs[name] = &CouchDBStore{db: prov.couchDBClient.DB(context.Background(), ""), stop: make(chan struct{})}
+	}
+
+	err = prov.Close()
+	require.Error(t, err)
+
+	var closeErr *CloseError
+	require.True(t, errors.As(err, &closeErr))
+	require.Len(t, closeErr.Errors, 2)
+	require.Contains(t, closeErr.Errors, "failing1")
+	require.Contains(t, closeErr.Errors, "failing2")
+	require.Contains(t, err.Error(), failToCloseProviderErrMsg)
+	require.Contains(t, err.Error(), "store failing1: ")
+	require.Contains(t, err.Error(), "store failing2: ")
+
+	// all the stores are removed, the client is closed by the next close
+	require.Empty(t, prov.dbs)
+
+	require.NoError(t, prov.Close())
+	require.Empty(t, prov.dbs)
+}
+
+func TestProvider_CloseConcurrently(t *testing.T) {
+	server := newFailingCouchDB()
+	defer server.Close()
+
+	prov, err := NewProvider(server.URL, WithCloseConcurrency(4))
+	require.NoError(t, err)
+
+	const storesCount = 50
+
+	var stopped []chan struct{}
+
+	for i := 0; i < storesCount; i++ {
+		store := &CouchDBStore{db: prov.couchDBClient.DB(context.Background(), "db"), stop: make(chan struct{})}
+
+		// one store out of five fails to close
+		if i%5 == 0 {
+			store.db = prov.couchDBClient.DB(context.Background(), "")
+		}
+
+		prov.dbs[fmt.Sprintf("store%d", i)] = store
+		stopped = append(stopped, store.stop)
+	}
+
+	err = prov.Close()
+	require.Error(t, err)
+
+	var closeErr *CloseError
+	require.True(t, errors.As(err, &closeErr))
+	require.Len(t, closeErr.Errors, storesCount/5)
+
+	for i := 0; i < storesCount; i += 5 {
+		require.Contains(t, closeErr.Errors, fmt.Sprintf("store%d", i))
+	}
+
+	require.Empty(t, prov.dbs)
+
+	// the sweepers of all the stores are stopped
+	for _, stop := range stopped {
+		_, open := <-stop
+		require.False(t, open)
+	}
+
+	require.NoError(t, prov.Close())
 }
@@ -0,0 +1,62 @@
+// +build !js,!wasm
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdbstore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-kivik/kivik"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// backendError is a CouchDB failure classified into one of the storage errors, errors.Is matches both the storage
+// error and the underlying CouchDB error.
+type backendError struct {
+	class error
+	err   error
+}
+
+func (e *backendError) Error() string {
+	return e.err.Error()
+}
+
+func (e *backendError) Is(target error) bool {
+	return target == e.class
+}
+
+func (e *backendError) Unwrap() error {
+	return e.err
+}
+
+// classifyErr wraps the CouchDB failures into storage.ErrTimeout, storage.ErrConflict or storage.ErrStoreClosed,
+// other errors (eg: storage.ErrDataNotFound) are returned as is.
+func classifyErr(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var class error
+
+	switch status := kivik.StatusCode(err); {
+	case errors.Is(err, context.DeadlineExceeded), status == http.StatusRequestTimeout,
+		status == http.StatusGatewayTimeout:
+		class = storage.ErrTimeout
+	case status == http.StatusConflict:
+		class = storage.ErrConflict
+	case errors.Is(err, ErrCircuitOpen), status == http.StatusBadGateway, status == http.StatusServiceUnavailable:
+		// kivik reports the connection failures as bad gateway
+		class = storage.ErrStoreClosed
+	default:
+		return err
+	}
+
+	return &backendError{class: class, err: err}
+}
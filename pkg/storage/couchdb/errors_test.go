@@ -0,0 +1,158 @@
+// +build !js,!wasm
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdbstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// newErroringCouchDB is a minimal fake CouchDB server failing the doc updates with updateStatus, docs are never
+// found.
+func newErroringCouchDB(updateStatus int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		// doc paths are /{db}/{docID}
+		if strings.Count(strings.Trim(r.URL.Path, "/"), "/") == 1 {
+			if r.Method == http.MethodPut || r.Method == http.MethodDelete {
+				w.WriteHeader(updateStatus)
+				_, _ = fmt.Fprintf(w, `{"error":"%d","reason":"update failed"}`, updateStatus)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found","reason":"missing"}`))
+
+			return
+		}
+
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusCreated)
+		}
+
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+}
+
+func TestClassifyErr(t *testing.T) {
+	require.NoError(t, classifyErr(nil))
+	require.Equal(t, storage.ErrDataNotFound, classifyErr(storage.ErrDataNotFound))
+	require.Equal(t, statusError(http.StatusBadRequest), classifyErr(statusError(http.StatusBadRequest)))
+
+	tests := []struct {
+		err   error
+		class error
+	}{
+		{err: fmt.Errorf("get : %w", context.DeadlineExceeded), class: storage.ErrTimeout},
+		{err: statusError(http.StatusRequestTimeout), class: storage.ErrTimeout},
+		{err: statusError(http.StatusGatewayTimeout), class: storage.ErrTimeout},
+		{err: statusError(http.StatusConflict), class: storage.ErrConflict},
+		{err: ErrCircuitOpen, class: storage.ErrStoreClosed},
+		{err: statusError(http.StatusBadGateway), class: storage.ErrStoreClosed},
+		{err: statusError(http.StatusServiceUnavailable), class: storage.ErrStoreClosed},
+	}
+
+	for _, tc := range tests {
+		err := classifyErr(tc.err)
+		require.True(t, errors.Is(err, tc.class), err)
+		require.True(t, errors.Is(err, tc.err), err)
+		require.Equal(t, tc.err.Error(), err.Error())
+	}
+}
+
+func TestCouchDBStore_Errors(t *testing.T) {
+	t.Run("conflict", func(t *testing.T) {
+		server := newErroringCouchDB(http.StatusConflict)
+		defer server.Close()
+
+		prov, err := NewProvider(server.URL)
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		err = store.Put("key", []byte("value"))
+		require.True(t, errors.Is(err, storage.ErrConflict), err)
+	})
+
+	t.Run("backend unavailable", func(t *testing.T) {
+		server := newErroringCouchDB(http.StatusServiceUnavailable)
+		defer server.Close()
+
+		prov, err := NewProvider(server.URL)
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		err = store.Put("key", []byte("value"))
+		require.True(t, errors.Is(err, storage.ErrStoreClosed), err)
+
+		// connection failures
+		server.Close()
+
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, storage.ErrStoreClosed), err)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server := newSlowCouchDB(200 * time.Millisecond)
+		defer server.Close()
+
+		prov, err := NewProvider(server.URL, WithOperationTimeout(50*time.Millisecond))
+		require.NoError(t, err)
+
+		store, err := prov.OpenStore(randomKey())
+		require.NoError(t, err)
+
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, storage.ErrTimeout), err)
+
+		itr := store.Iterator("key", "key"+storage.EndKeySuffix)
+		require.False(t, itr.Next())
+		require.True(t, errors.Is(itr.Error(), storage.ErrTimeout), itr.Error())
+		itr.Release()
+	})
+
+	t.Run("store closed", func(t *testing.T) {
+		server := newErroringCouchDB(http.StatusCreated)
+		defer server.Close()
+
+		prov, err := NewProvider(server.URL)
+		require.NoError(t, err)
+
+		name := randomKey()
+
+		store, err := prov.OpenStore(name)
+		require.NoError(t, err)
+
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound), err)
+
+		require.NoError(t, prov.CloseStore(name))
+
+		_, err = store.Get("key")
+		require.Equal(t, storage.ErrStoreClosed, err)
+
+		err = store.Put("key", []byte("value"))
+		require.Equal(t, storage.ErrStoreClosed, err)
+	})
+}
@@ -0,0 +1,310 @@
+// +build !js,!wasm
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdbstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// txLogStoreName is the store holding the write-ahead log entries of the transactions being committed.
+	txLogStoreName = "txlog"
+	txLogKeyPrefix = "tx_"
+)
+
+// txOp is a put, or a delete, of a record by a transaction.
+type txOp struct {
+	Store  string `json:"store"`
+	Key    string `json:"key"`
+	Value  []byte `json:"value,omitempty"`
+	Delete bool   `json:"delete,omitempty"`
+}
+
+// txLogEntry is the write-ahead log entry of a transaction being committed, Before[i] restores the record updated
+// by Ops[i] as it was before the commit (a delete if the record was absent).
+type txLogEntry struct {
+	Ops    []txOp `json:"ops"`
+	Before []txOp `json:"before"`
+}
+
+// transaction is a best-effort transaction across CouchDB databases, see Provider.BeginTransaction.
+type transaction struct {
+	openStore func(name string) (storage.Store, error)
+	newID     func() string
+	ops       []txOp
+	done      bool
+	lock      sync.Mutex
+}
+
+// BeginTransaction starts a transaction across the stores of the provider. CouchDB has no transactions across
+// databases nor across docs, the commit is best-effort:
+//
+// - the records as they were before the commit are saved to a write-ahead log entry before any update.
+//
+// - if an update fails, the updates already applied are rolled back and Commit returns the error; a nil error
+// means all the updates were applied.
+//
+// - if the rollback fails too, or the process stops midway, the log entry is kept and the transaction is rolled
+// back by RecoverTransactions.
+//
+// There is no isolation, readers can see the transaction partially committed and writes made to the records of the
+// transaction before it's rolled back are overwritten. Rolled back records lose their TTL.
+func (p *Provider) BeginTransaction() (storage.Transaction, error) {
+	return &transaction{openStore: p.OpenStore, newID: uuid.New().String}, nil
+}
+
+// RecoverTransactions rolls back the transactions whose commit stopped midway, left in the write-ahead log, and
+// returns the number of transactions rolled back. It is meant to be called on startup, before the stores are used.
+func (p *Provider) RecoverTransactions() (int, error) {
+	return recoverTransactions(p.OpenStore)
+}
+
+// Put buffers storing the key and the record in the store of given name space.
+func (t *transaction) Put(store, k string, v []byte) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	return t.add(txOp{Store: store, Key: k, Value: v})
+}
+
+// Delete buffers deleting the record with k key from the store of given name space.
+func (t *transaction) Delete(store, k string) error {
+	if k == "" {
+		return storage.ErrKeyRequired
+	}
+
+	return t.add(txOp{Store: store, Key: k, Delete: true})
+}
+
+func (t *transaction) add(op txOp) error {
+	if op.Store == "" {
+		return errors.New("store name is mandatory")
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.done {
+		return storage.ErrTransactionDone
+	}
+
+	t.ops = append(t.ops, op)
+
+	return nil
+}
+
+// Discard drops the buffered puts and deletes.
+func (t *transaction) Discard() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.done = true
+	t.ops = nil
+}
+
+// Commit writes the buffered puts and deletes, see Provider.BeginTransaction for the guarantees.
+func (t *transaction) Commit() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.done {
+		return storage.ErrTransactionDone
+	}
+
+	t.done = true
+
+	if len(t.ops) == 0 {
+		return nil
+	}
+
+	stores, err := openStores(t.openStore, t.ops)
+	if err != nil {
+		return err
+	}
+
+	logStore, err := t.openStore(txLogStoreName)
+	if err != nil {
+		return fmt.Errorf("open transaction log: %w", err)
+	}
+
+	entry, entryBytes, err := t.logEntry(stores)
+	if err != nil {
+		return err
+	}
+
+	id := txLogKeyPrefix + t.newID()
+
+	err = logStore.Put(id, entryBytes)
+	if err != nil {
+		return fmt.Errorf("write transaction log entry: %w", err)
+	}
+
+	for i, op := range t.ops {
+		if err = apply(stores[op.Store], op); err != nil {
+			return rollback(logStore, id, stores, entry.Before[:i], fmt.Errorf("commit transaction: %w", err))
+		}
+	}
+
+	err = logStore.Delete(id)
+	if err != nil {
+		// the transaction would be rolled back by RecoverTransactions, hence reported as failed
+		return rollback(logStore, id, stores, entry.Before, fmt.Errorf("remove transaction log entry: %w", err))
+	}
+
+	return nil
+}
+
+// logEntry returns the write-ahead log entry of the transaction, holding the records as they currently are.
+func (t *transaction) logEntry(stores map[string]storage.Store) (*txLogEntry, []byte, error) {
+	entry := &txLogEntry{Ops: t.ops}
+
+	for _, op := range t.ops {
+		before, err := readBefore(stores[op.Store], op)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entry.Before = append(entry.Before, before)
+	}
+
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal transaction log entry: %w", err)
+	}
+
+	return entry, entryBytes, nil
+}
+
+// openStores opens the stores updated by the ops, by name.
+func openStores(openStore func(name string) (storage.Store, error), ops []txOp) (map[string]storage.Store, error) {
+	stores := map[string]storage.Store{}
+
+	for _, op := range ops {
+		if _, ok := stores[op.Store]; ok {
+			continue
+		}
+
+		store, err := openStore(op.Store)
+		if err != nil {
+			return nil, fmt.Errorf("open store %s: %w", op.Store, err)
+		}
+
+		stores[op.Store] = store
+	}
+
+	return stores, nil
+}
+
+// readBefore returns the op restoring the record updated by op as it currently is.
+func readBefore(store storage.Store, op txOp) (txOp, error) {
+	v, err := store.Get(op.Key)
+
+	switch {
+	case errors.Is(err, storage.ErrDataNotFound):
+		return txOp{Store: op.Store, Key: op.Key, Delete: true}, nil
+	case err != nil:
+		return txOp{}, fmt.Errorf("read record %s of store %s: %w", op.Key, op.Store, err)
+	default:
+		return txOp{Store: op.Store, Key: op.Key, Value: v}, nil
+	}
+}
+
+func apply(store storage.Store, op txOp) error {
+	if op.Delete {
+		return store.Delete(op.Key)
+	}
+
+	return store.Put(op.Key, op.Value)
+}
+
+// rollback restores the records in the reverse order of their updates and removes the log entry, the cause
+// of the rollback is returned. The log entry is kept if the rollback fails.
+func rollback(logStore storage.Store, id string, stores map[string]storage.Store, before []txOp, cause error) error {
+	for i := len(before) - 1; i >= 0; i-- {
+		if err := apply(stores[before[i].Store], before[i]); err != nil {
+			return fmt.Errorf("%w, rollback failed, pending RecoverTransactions: %s", cause, err)
+		}
+	}
+
+	if err := logStore.Delete(id); err != nil {
+		// rolling back again is harmless
+		logger.Warnf("failed to remove log entry of rolled back transaction %s: %s", id, err)
+	}
+
+	return cause
+}
+
+func recoverTransactions(openStore func(name string) (storage.Store, error)) (int, error) {
+	logStore, err := openStore(txLogStoreName)
+	if err != nil {
+		return 0, fmt.Errorf("open transaction log: %w", err)
+	}
+
+	entries := map[string][]byte{}
+
+	itr := logStore.Iterator(txLogKeyPrefix, txLogKeyPrefix+storage.EndKeySuffix)
+	for itr.Next() {
+		entries[string(itr.Key())] = append([]byte(nil), itr.Value()...)
+	}
+
+	err = itr.Error()
+
+	itr.Release()
+
+	if err != nil {
+		return 0, fmt.Errorf("read transaction log: %w", err)
+	}
+
+	recovered := 0
+
+	for id, entryBytes := range entries {
+		if err = recoverTransaction(openStore, logStore, id, entryBytes); err != nil {
+			return recovered, err
+		}
+
+		recovered++
+	}
+
+	return recovered, nil
+}
+
+// recoverTransaction rolls back the transaction of the log entry and removes the entry.
+func recoverTransaction(openStore func(name string) (storage.Store, error), logStore storage.Store, id string,
+	entryBytes []byte) error {
+	var entry txLogEntry
+
+	err := json.Unmarshal(entryBytes, &entry)
+	if err != nil {
+		return fmt.Errorf("unmarshal transaction log entry %s: %w", id, err)
+	}
+
+	stores, err := openStores(openStore, entry.Before)
+	if err != nil {
+		return err
+	}
+
+	for i := len(entry.Before) - 1; i >= 0; i-- {
+		if err = apply(stores[entry.Before[i].Store], entry.Before[i]); err != nil {
+			return fmt.Errorf("roll back transaction %s: %w", id, err)
+		}
+	}
+
+	if err = logStore.Delete(id); err != nil {
+		return fmt.Errorf("remove transaction log entry %s: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+// +build !js,!wasm
+
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdbstore
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+var _ storage.TransactionProvider = (*Provider)(nil)
+
+// failingStore fails the updates of the records with the keys in fail.
+type failingStore struct {
+	storage.Store
+	fail map[string]bool
+}
+
+func (s *failingStore) Put(k string, v []byte) error {
+	if s.fail[k] {
+		return fmt.Errorf("put %s failed", k)
+	}
+
+	return s.Store.Put(k, v)
+}
+
+func (s *failingStore) Delete(k string) error {
+	if s.fail[k] {
+		return fmt.Errorf("delete %s failed", k)
+	}
+
+	return s.Store.Delete(k)
+}
+
+// newFailingStores returns a function opening the mem stores of prov, wrapped to fail the updates of the keys
+// in fail.
+func newFailingStores(prov storage.Provider, fail map[string]bool) func(name string) (storage.Store, error) {
+	return func(name string) (storage.Store, error) {
+		store, err := prov.OpenStore(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return &failingStore{Store: store, fail: fail}, nil
+	}
+}
+
+func requireRecord(t *testing.T, prov storage.Provider, store, k, expected string) {
+	t.Helper()
+
+	s, err := prov.OpenStore(store)
+	require.NoError(t, err)
+
+	v, err := s.Get(k)
+	if expected == "" {
+		require.True(t, errors.Is(err, storage.ErrDataNotFound), err)
+
+		return
+	}
+
+	require.NoError(t, err)
+	require.Equal(t, expected, string(v))
+}
+
+func TestTransaction(t *testing.T) {
+	// seed puts the records all the tests start from
+	seed := func(t *testing.T) storage.Provider {
+		prov := mem.NewProvider()
+
+		connections, err := prov.OpenStore("connections")
+		require.NoError(t, err)
+		require.NoError(t, connections.Put("conn-1", []byte("invited")))
+
+		dids, err := prov.OpenStore("dids")
+		require.NoError(t, err)
+		require.NoError(t, dids.Put("did-old", []byte("old-doc")))
+
+		return prov
+	}
+
+	// buffer buffers an update of the connection, DID and routing stores
+	buffer := func(t *testing.T, tx storage.Transaction) {
+		t.Helper()
+
+		require.NoError(t, tx.Put("connections", "conn-1", []byte("completed")))
+		require.NoError(t, tx.Put("dids", "did-new", []byte("new-doc")))
+		require.NoError(t, tx.Delete("dids", "did-old"))
+		require.NoError(t, tx.Put("routing", "route-1", []byte("mediator")))
+	}
+
+	t.Run("commit multi-store transaction", func(t *testing.T) {
+		prov := seed(t)
+
+		tx := &transaction{openStore: newFailingStores(prov, nil), newID: uuid.New().String}
+		buffer(t, tx)
+
+		// nothing is written before commit
+		requireRecord(t, prov, "connections", "conn-1", "invited")
+		requireRecord(t, prov, "routing", "route-1", "")
+
+		require.NoError(t, tx.Commit())
+
+		requireRecord(t, prov, "connections", "conn-1", "completed")
+		requireRecord(t, prov, "dids", "did-new", "new-doc")
+		requireRecord(t, prov, "dids", "did-old", "")
+		requireRecord(t, prov, "routing", "route-1", "mediator")
+
+		// the log entry is removed
+		n, err := recoverTransactions(prov.OpenStore)
+		require.NoError(t, err)
+		require.Zero(t, n)
+
+		require.Equal(t, storage.ErrTransactionDone, tx.Commit())
+		require.Equal(t, storage.ErrTransactionDone, tx.Put("dids", "k", []byte("v")))
+	})
+
+	t.Run("mid-commit failure is rolled back", func(t *testing.T) {
+		prov := seed(t)
+
+		tx := &transaction{openStore: newFailingStores(prov, map[string]bool{"route-1": true}), newID: uuid.New().String}
+		buffer(t, tx)
+
+		err := tx.Commit()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "commit transaction: put route-1 failed")
+
+		requireRecord(t, prov, "connections", "conn-1", "invited")
+		requireRecord(t, prov, "dids", "did-new", "")
+		requireRecord(t, prov, "dids", "did-old", "old-doc")
+		requireRecord(t, prov, "routing", "route-1", "")
+
+		n, err := recoverTransactions(prov.OpenStore)
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("failed rollback is recovered from the log", func(t *testing.T) {
+		prov := seed(t)
+
+		// the backend goes down while committing the DIDs, failing their rollback too
+		tx := &transaction{openStore: func(name string) (storage.Store, error) {
+			s, err := prov.OpenStore(name)
+			if err == nil && name == "dids" {
+				return &rollbackFailingStore{Store: s}, nil
+			}
+
+			return s, err
+		}, newID: uuid.New().String}
+		buffer(t, tx)
+
+		err := tx.Commit()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rollback failed, pending RecoverTransactions")
+
+		// the transaction is partially committed until recovered
+		requireRecord(t, prov, "connections", "conn-1", "completed")
+
+		n, err := recoverTransactions(prov.OpenStore)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+
+		requireRecord(t, prov, "connections", "conn-1", "invited")
+		requireRecord(t, prov, "dids", "did-new", "")
+		requireRecord(t, prov, "dids", "did-old", "old-doc")
+		requireRecord(t, prov, "routing", "route-1", "")
+
+		n, err = recoverTransactions(prov.OpenStore)
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("failed log removal is rolled back", func(t *testing.T) {
+		prov := seed(t)
+
+		tx := &transaction{openStore: func(name string) (storage.Store, error) {
+			s, err := prov.OpenStore(name)
+			if err == nil && name == txLogStoreName {
+				return &failingLogStore{Store: s}, nil
+			}
+
+			return s, err
+		}, newID: uuid.New().String}
+		buffer(t, tx)
+
+		err := tx.Commit()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "remove transaction log entry")
+
+		requireRecord(t, prov, "connections", "conn-1", "invited")
+		requireRecord(t, prov, "dids", "did-old", "old-doc")
+		requireRecord(t, prov, "routing", "route-1", "")
+
+		// rolling back again from the left over log entry is harmless
+		n, err := recoverTransactions(prov.OpenStore)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+
+		requireRecord(t, prov, "connections", "conn-1", "invited")
+	})
+
+	t.Run("failure before any update", func(t *testing.T) {
+		prov := seed(t)
+
+		tx := &transaction{openStore: func(name string) (storage.Store, error) {
+			if name == txLogStoreName {
+				return nil, errors.New("open failed")
+			}
+
+			return prov.OpenStore(name)
+		}, newID: uuid.New().String}
+		buffer(t, tx)
+
+		require.EqualError(t, tx.Commit(), "open transaction log: open failed")
+		requireRecord(t, prov, "connections", "conn-1", "invited")
+	})
+
+	t.Run("invalid and discarded transactions", func(t *testing.T) {
+		tx := &transaction{openStore: mem.NewProvider().OpenStore}
+
+		require.EqualError(t, tx.Put("dids", "", []byte("v")), "key and value are mandatory")
+		require.EqualError(t, tx.Put("dids", "k", nil), "key and value are mandatory")
+		require.Equal(t, storage.ErrKeyRequired, tx.Delete("dids", ""))
+		require.EqualError(t, tx.Put("", "k", []byte("v")), "store name is mandatory")
+
+		require.NoError(t, tx.Commit())
+
+		tx = &transaction{openStore: mem.NewProvider().OpenStore}
+		require.NoError(t, tx.Put("dids", "k", []byte("v")))
+
+		tx.Discard()
+		require.Equal(t, storage.ErrTransactionDone, tx.Commit())
+	})
+}
+
+// rollbackFailingStore fails every update once an update was made, as a backend going down midway would.
+type rollbackFailingStore struct {
+	storage.Store
+	updated bool
+}
+
+func (s *rollbackFailingStore) Put(k string, v []byte) error {
+	return s.update(func() error { return s.Store.Put(k, v) })
+}
+
+func (s *rollbackFailingStore) Delete(k string) error {
+	return s.update(func() error { return s.Store.Delete(k) })
+}
+
+func (s *rollbackFailingStore) update(op func() error) error {
+	if s.updated {
+		return errors.New("backend unavailable")
+	}
+
+	s.updated = true
+
+	return op()
+}
+
+// failingLogStore fails removing the log entries.
+type failingLogStore struct {
+	storage.Store
+}
+
+func (s *failingLogStore) Delete(k string) error {
+	return errors.New("delete failed")
+}
+
+func TestProvider_Transaction(t *testing.T) {
+	prov, err := NewProvider(couchDBURL, WithDBPrefix("txprefix"))
+	require.NoError(t, err)
+
+	connections, dids := randomKey(), randomKey()
+
+	tx, err := prov.BeginTransaction()
+	require.NoError(t, err)
+
+	require.NoError(t, tx.Put(connections, "conn-1", []byte("completed")))
+	require.NoError(t, tx.Put(dids, "did-1", []byte("doc")))
+	require.NoError(t, tx.Commit())
+
+	requireRecord(t, prov, connections, "conn-1", "completed")
+	requireRecord(t, prov, dids, "did-1", "doc")
+
+	tx, err = prov.BeginTransaction()
+	require.NoError(t, err)
+
+	require.NoError(t, tx.Delete(dids, "did-1"))
+	require.NoError(t, tx.Commit())
+
+	requireRecord(t, prov, dids, "did-1", "")
+
+	n, err := prov.RecoverTransactions()
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	require.NoError(t, prov.Close())
+}
@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dualwrite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// package dualwrite offers a storage.Provider mirroring the writes made to a primary storage.Provider into a
+// secondary storage.Provider (eg: while migrating from CouchDB to LevelDB), reads are served by the primary provider.
+
+var logger = log.New("aries-framework/storage/dualwrite")
+
+// Option configures the dual write provider.
+type Option func(opts *Provider)
+
+// WithSecondaryFatal option is for failing the operations which fail on the secondary provider. Secondary failures
+// are logged and the primary outcome returned by default, letting the secondary provider diverge.
+func WithSecondaryFatal(fatal bool) Option {
+	return func(opts *Provider) {
+		opts.secondaryFatal = fatal
+	}
+}
+
+// Provider is a storage.Provider writing to both its primary and secondary providers and reading from the primary
+// provider only. Writes are made to the primary store first, failed writes aren't mirrored.
+type Provider struct {
+	primary        storage.Provider
+	secondary      storage.Provider
+	secondaryFatal bool
+}
+
+// NewProvider returns a new Provider mirroring the writes made to primary into secondary.
+func NewProvider(primary, secondary storage.Provider, opts ...Option) *Provider {
+	p := &Provider{primary: primary, secondary: secondary}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// OpenStore opens the store with given name from both providers. Unless secondary failures are fatal, the store
+// is only backed by the primary provider if it fails to open on the secondary provider.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	primary, err := p.primary.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	secondary, err := p.secondary.OpenStore(name)
+	if err != nil {
+		if err = p.secondaryFailed("open store", name, err); err != nil {
+			return nil, err
+		}
+
+		secondary = nil
+	}
+
+	return &dualWriteStore{name: name, primary: primary, secondary: secondary, provider: p}, nil
+}
+
+// CloseStore closes the store of given name in both providers.
+func (p *Provider) CloseStore(name string) error {
+	err := p.primary.CloseStore(name)
+	if err != nil {
+		return err
+	}
+
+	if err = p.secondary.CloseStore(name); err != nil {
+		return p.secondaryFailed("close store", name, err)
+	}
+
+	return nil
+}
+
+// Close closes both providers, the secondary provider is closed even if the primary provider fails to close.
+func (p *Provider) Close() error {
+	errPrimary := p.primary.Close()
+
+	if err := p.secondary.Close(); err != nil {
+		if err = p.secondaryFailed("close", "", err); err != nil && errPrimary == nil {
+			return err
+		}
+	}
+
+	return errPrimary
+}
+
+// secondaryFailed returns the failure of operation on the secondary provider if secondary failures are fatal,
+// the failure is logged otherwise.
+func (p *Provider) secondaryFailed(operation, name string, err error) error {
+	if p.secondaryFatal {
+		return fmt.Errorf("secondary %s %s: %w", operation, name, err)
+	}
+
+	logger.Warnf("failed to %s %s on secondary provider: %s", operation, name, err)
+
+	return nil
+}
+
+type dualWriteStore struct {
+	name    string
+	primary storage.Store
+	// secondary is nil if the store failed to open on the secondary provider
+	secondary storage.Store
+	provider  *Provider
+}
+
+// Put stores the key and the record in both stores.
+func (s *dualWriteStore) Put(k string, v []byte) error {
+	return s.write("put", func(store storage.Store) error {
+		return store.Put(k, v)
+	})
+}
+
+// PutWithTTL stores the key and the record expiring after ttl in both stores.
+func (s *dualWriteStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	return s.write("put with ttl", func(store storage.Store) error {
+		return store.PutWithTTL(k, v, ttl)
+	})
+}
+
+// PutIfVersion stores the key and the record in the primary store if the version of the record in the primary store
+// is expectedVersion, the record is then put as is in the secondary store. The returned version is the version of
+// the primary store.
+func (s *dualWriteStore) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	version, err := s.primary.PutIfVersion(k, v, expectedVersion)
+	if err != nil {
+		return "", err
+	}
+
+	return version, s.mirror("put if version", func(store storage.Store) error {
+		return store.Put(k, v)
+	})
+}
+
+// Get fetches the record based on key from the primary store.
+func (s *dualWriteStore) Get(k string) ([]byte, error) {
+	return s.primary.Get(k)
+}
+
+// Has tells whether the record with k key exists in the primary store.
+func (s *dualWriteStore) Has(k string) (bool, error) {
+	return s.primary.Has(k)
+}
+
+// Iterator returns an iterator of the primary store.
+func (s *dualWriteStore) Iterator(startKey, endKey string) storage.StoreIterator {
+	return s.primary.Iterator(startKey, endKey)
+}
+
+// IteratorWithFilter returns a filtered iterator of the primary store.
+func (s *dualWriteStore) IteratorWithFilter(startKey, endKey string,
+	keyPredicate func(key string) bool) storage.StoreIterator {
+	return s.primary.IteratorWithFilter(startKey, endKey, keyPredicate)
+}
+
+// Delete will delete the record with k key from both stores.
+func (s *dualWriteStore) Delete(k string) error {
+	return s.write("delete", func(store storage.Store) error {
+		return store.Delete(k)
+	})
+}
+
+// DeletePrefix will delete the records of the prefix range from both stores, the number of records deleted from
+// the primary store is returned.
+func (s *dualWriteStore) DeletePrefix(prefix string) (int, error) {
+	deleted, err := s.primary.DeletePrefix(prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, s.mirror("delete prefix", func(store storage.Store) error {
+		_, errDelete := store.DeletePrefix(prefix)
+
+		return errDelete
+	})
+}
+
+// Reset deletes all the records of both stores.
+func (s *dualWriteStore) Reset() error {
+	return s.write("reset", func(store storage.Store) error {
+		return store.Reset()
+	})
+}
+
+// SoftDelete will soft delete the record with k key in both stores.
+func (s *dualWriteStore) SoftDelete(k string) error {
+	return s.write("soft delete", func(store storage.Store) error {
+		return store.SoftDelete(k)
+	})
+}
+
+// GetIncludingDeleted fetches the record based on key from the primary store, including soft deleted records.
+func (s *dualWriteStore) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	return s.primary.GetIncludingDeleted(k)
+}
+
+// write performs the write operation on the primary store, then mirrors it on the secondary store if it succeeded.
+func (s *dualWriteStore) write(operation string, op func(store storage.Store) error) error {
+	if err := op(s.primary); err != nil {
+		return err
+	}
+
+	return s.mirror(operation, op)
+}
+
+// mirror performs the write operation on the secondary store.
+func (s *dualWriteStore) mirror(operation string, op func(store storage.Store) error) error {
+	if s.secondary == nil {
+		return nil
+	}
+
+	if err := op(s.secondary); err != nil {
+		return s.provider.secondaryFailed(operation+" in store", s.name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dualwrite
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+// failingCloseProvider fails to close its stores.
+type failingCloseProvider struct {
+	storage.Provider
+	err error
+}
+
+func (p *failingCloseProvider) CloseStore(string) error {
+	return p.err
+}
+
+func (p *failingCloseProvider) Close() error {
+	return p.err
+}
+
+func TestDualWriteStore(t *testing.T) {
+	t.Run("test writes are made to both stores", func(t *testing.T) {
+		primary, secondary := mem.NewProvider(), mem.NewProvider()
+
+		store, err := NewProvider(primary, secondary).OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("abc_1", []byte("v1")))
+		require.NoError(t, store.Put("abc_2", []byte("v2")))
+		require.NoError(t, store.Put("abc_3", []byte("v3")))
+		require.NoError(t, store.PutWithTTL("dab_1", []byte("v"), time.Minute))
+		require.NoError(t, store.Delete("abc_1"))
+		require.NoError(t, store.SoftDelete("abc_2"))
+
+		version, err := store.PutIfVersion("dab_2", []byte("v"), "")
+		require.NoError(t, err)
+		require.NotEmpty(t, version)
+
+		_, err = store.PutIfVersion("dab_2", []byte("v"), "")
+		require.True(t, errors.Is(err, storage.ErrConflict))
+
+		for _, prov := range []storage.Provider{primary, secondary} {
+			s, err := prov.OpenStore("test")
+			require.NoError(t, err)
+
+			_, err = s.Get("abc_1")
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+			_, deleted, err := s.GetIncludingDeleted("abc_2")
+			require.NoError(t, err)
+			require.True(t, deleted)
+
+			for _, k := range []string{"abc_3", "dab_1", "dab_2"} {
+				exists, err := s.Has(k)
+				require.NoError(t, err)
+				require.True(t, exists)
+			}
+		}
+
+		// the soft deleted record isn't counted
+		deleted, err := store.DeletePrefix("abc_")
+		require.NoError(t, err)
+		require.Equal(t, 1, deleted)
+
+		require.NoError(t, store.Reset())
+
+		for _, prov := range []storage.Provider{primary, secondary} {
+			s, err := prov.OpenStore("test")
+			require.NoError(t, err)
+
+			itr := s.Iterator("", storage.EndKeySuffix)
+			require.False(t, itr.Next())
+			itr.Release()
+		}
+	})
+
+	t.Run("test reads are served by the primary store", func(t *testing.T) {
+		primary, secondary := mem.NewProvider(), mem.NewProvider()
+
+		store, err := NewProvider(primary, secondary).OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("abc_1", []byte("v1")))
+
+		// the secondary store diverges
+		secondaryStore, err := secondary.OpenStore("test")
+		require.NoError(t, err)
+		require.NoError(t, secondaryStore.Put("abc_1", []byte("diverged")))
+		require.NoError(t, secondaryStore.Put("abc_2", []byte("diverged")))
+
+		v, err := store.Get("abc_1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v1"), v)
+
+		exists, err := store.Has("abc_2")
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		v, deleted, err := store.GetIncludingDeleted("abc_1")
+		require.NoError(t, err)
+		require.False(t, deleted)
+		require.Equal(t, []byte("v1"), v)
+
+		itr := store.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+		require.True(t, itr.Next())
+		require.Equal(t, []byte("v1"), itr.Value())
+		require.False(t, itr.Next())
+		itr.Release()
+	})
+
+	t.Run("test secondary failures", func(t *testing.T) {
+		errSecondary := errors.New("secondary error")
+		secondary := &mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+			Store:     map[string][]byte{},
+			ErrPut:    errSecondary,
+			ErrDelete: errSecondary,
+		}}
+
+		store, err := NewProvider(mem.NewProvider(), secondary).OpenStore("test")
+		require.NoError(t, err)
+
+		// secondary failures aren't fatal by default
+		require.NoError(t, store.Put("abc_1", []byte("v1")))
+		require.NoError(t, store.Delete("abc_1"))
+
+		store, err = NewProvider(mem.NewProvider(), secondary, WithSecondaryFatal(true)).OpenStore("test")
+		require.NoError(t, err)
+
+		err = store.Put("abc_1", []byte("v1"))
+		require.True(t, errors.Is(err, errSecondary))
+		require.EqualError(t, err, "secondary put in store test: secondary error")
+
+		// the primary store is written nonetheless
+		v, err := store.Get("abc_1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v1"), v)
+
+		require.True(t, errors.Is(store.PutWithTTL("abc_2", []byte("v2"), time.Minute), errSecondary))
+		require.True(t, errors.Is(store.Delete("abc_1"), errSecondary))
+		require.True(t, errors.Is(store.Reset(), errSecondary))
+
+		_, err = store.DeletePrefix("abc_")
+		require.True(t, errors.Is(err, errSecondary))
+	})
+
+	t.Run("test primary failures aren't mirrored", func(t *testing.T) {
+		errPrimary := errors.New("primary error")
+		primary := &mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+			Store:     map[string][]byte{},
+			ErrPut:    errPrimary,
+			ErrDelete: errPrimary,
+		}}
+		secondary := mem.NewProvider()
+
+		store, err := NewProvider(primary, secondary).OpenStore("test")
+		require.NoError(t, err)
+
+		secondaryStore, err := secondary.OpenStore("test")
+		require.NoError(t, err)
+		require.NoError(t, secondaryStore.Put("abc_2", []byte("v2")))
+
+		require.True(t, errors.Is(store.Put("abc_1", []byte("v1")), errPrimary))
+		require.True(t, errors.Is(store.SoftDelete("abc_2"), errPrimary))
+
+		_, err = store.DeletePrefix("abc_")
+		require.True(t, errors.Is(err, errPrimary))
+
+		exists, err := secondaryStore.Has("abc_1")
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		exists, err = secondaryStore.Has("abc_2")
+		require.NoError(t, err)
+		require.True(t, exists)
+	})
+}
+
+func TestProvider(t *testing.T) {
+	t.Run("test open store failures", func(t *testing.T) {
+		errOpen := errors.New("open error")
+		failing := &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errOpen}
+
+		_, err := NewProvider(failing, mem.NewProvider()).OpenStore("test")
+		require.True(t, errors.Is(err, errOpen))
+
+		_, err = NewProvider(mem.NewProvider(), failing, WithSecondaryFatal(true)).OpenStore("test")
+		require.True(t, errors.Is(err, errOpen))
+
+		// the store is only backed by the primary provider
+		primary := mem.NewProvider()
+
+		store, err := NewProvider(primary, failing).OpenStore("test")
+		require.NoError(t, err)
+		require.NoError(t, store.Put("abc_1", []byte("v1")))
+
+		primaryStore, err := primary.OpenStore("test")
+		require.NoError(t, err)
+
+		v, err := primaryStore.Get("abc_1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("v1"), v)
+	})
+
+	t.Run("test close", func(t *testing.T) {
+		errClose := errors.New("close error")
+		failing := &failingCloseProvider{Provider: mem.NewProvider(), err: errClose}
+
+		prov := NewProvider(mem.NewProvider(), mem.NewProvider())
+
+		_, err := prov.OpenStore("test")
+		require.NoError(t, err)
+		require.NoError(t, prov.CloseStore("test"))
+		require.NoError(t, prov.Close())
+
+		prov = NewProvider(mem.NewProvider(), failing)
+		require.NoError(t, prov.CloseStore("test"))
+		require.NoError(t, prov.Close())
+
+		prov = NewProvider(mem.NewProvider(), failing, WithSecondaryFatal(true))
+		require.True(t, errors.Is(prov.CloseStore("test"), errClose))
+		require.True(t, errors.Is(prov.Close(), errClose))
+
+		prov = NewProvider(failing, mem.NewProvider())
+		require.True(t, errors.Is(prov.CloseStore("test"), errClose))
+		require.True(t, errors.Is(prov.Close(), errClose))
+	})
+}
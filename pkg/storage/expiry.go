@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+)
+
+// expiryPrefix marks the value of a record put with a TTL, the expiry time (unix nanoseconds) and the original
+// value follow the marker.
+var expiryPrefix = []byte("\x00aries-expiry\x00") //nolint:gochecknoglobals
+
+const expiryLen = 8
+
+// NewExpiringValue returns the value stored in place of v for a record expiring at expiry.
+// This is the default TTL implementation for stores lacking native support of record expiry.
+func NewExpiringValue(v []byte, expiry time.Time) []byte {
+	value := make([]byte, len(expiryPrefix)+expiryLen, len(expiryPrefix)+expiryLen+len(v))
+	copy(value, expiryPrefix)
+	binary.BigEndian.PutUint64(value[len(expiryPrefix):], uint64(expiry.UnixNano()))
+
+	return append(value, v...)
+}
+
+// ParseExpiringValue returns the original value and the expiry time of a record put with a TTL along with true,
+// otherwise v is returned as is along with false.
+func ParseExpiringValue(v []byte) ([]byte, time.Time, bool) {
+	if len(v) < len(expiryPrefix)+expiryLen || !bytes.HasPrefix(v, expiryPrefix) {
+		return v, time.Time{}, false
+	}
+
+	expiry := int64(binary.BigEndian.Uint64(v[len(expiryPrefix):]))
+
+	return v[len(expiryPrefix)+expiryLen:], time.Unix(0, expiry), true
+}
+
+// ParseUnexpiredValue returns the original value of v and true unless v is the value of a record expired at now.
+func ParseUnexpiredValue(v []byte, now time.Time) ([]byte, bool) {
+	value, expiry, ok := ParseExpiringValue(v)
+	if ok && !now.Before(expiry) {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// IsLive tells whether the stored value v is neither the tombstone of a soft deleted record nor the value of a
+// record expired at now, ie whether Get returns the record.
+func IsLive(v []byte, now time.Time) bool {
+	if IsTombstone(v) {
+		return false
+	}
+
+	_, ok := ParseUnexpiredValue(v, now)
+
+	return ok
+}
+
+// SkipExpired wraps itr to skip the records expired according to now, the values of records
+// put with a TTL are returned without expiry.
+func SkipExpired(itr BaseIterator, now func() time.Time) StoreIterator {
+	if itr == nil {
+		return nil
+	}
+
+	return WithTotalItems(&expirySkippingIterator{BaseIterator: itr, now: now})
+}
+
+type expirySkippingIterator struct {
+	BaseIterator
+	now   func() time.Time
+	value []byte
+}
+
+// Next moves the iterator to the next key/value pair which isn't expired.
+// It returns false if the iterator is exhausted.
+func (i *expirySkippingIterator) Next() bool {
+	i.value = nil
+
+	for i.BaseIterator.Next() {
+		if v, ok := ParseUnexpiredValue(i.BaseIterator.Value(), i.now()); ok {
+			i.value = v
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// Value returns the value of the current key/value pair, or nil if done.
+func (i *expirySkippingIterator) Value() []byte {
+	return i.value
+}
+
+// Release releases associated resources.
+func (i *expirySkippingIterator) Release() {
+	i.value = nil
+
+	i.BaseIterator.Release()
+}
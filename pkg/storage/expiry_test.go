@@ -0,0 +1,48 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpiringValue(t *testing.T) {
+	value := []byte("value")
+	expiry := time.Now().Add(time.Minute)
+
+	v, e, ok := ParseExpiringValue(NewExpiringValue(value, expiry))
+	require.True(t, ok)
+	require.Equal(t, value, v)
+	require.Equal(t, expiry.UnixNano(), e.UnixNano())
+
+	v, _, ok = ParseExpiringValue(value)
+	require.False(t, ok)
+	require.Equal(t, value, v)
+
+	v, ok = ParseUnexpiredValue(NewExpiringValue(value, expiry), expiry.Add(-time.Second))
+	require.True(t, ok)
+	require.Equal(t, value, v)
+
+	v, ok = ParseUnexpiredValue(NewExpiringValue(value, expiry), expiry)
+	require.False(t, ok)
+	require.Nil(t, v)
+
+	require.Nil(t, SkipExpired(nil, time.Now))
+}
+
+func TestIsLive(t *testing.T) {
+	value := []byte("value")
+	expiry := time.Now().Add(time.Minute)
+
+	require.True(t, IsLive(value, expiry))
+	require.True(t, IsLive(NewExpiringValue(value, expiry), expiry.Add(-time.Second)))
+	require.False(t, IsLive(NewExpiringValue(value, expiry), expiry))
+	require.False(t, IsLive(NewTombstone(value), expiry))
+}
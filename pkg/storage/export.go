@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import "fmt"
+
+// Record is a key/value pair of a store, as exported by Export.
+type Record struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Export returns all of the records of the store in key order, the soft deleted and expired records excluded.
+func Export(store Store) ([]Record, error) {
+	itr := store.Iterator("", EndKeySuffix)
+	defer itr.Release()
+
+	records := []Record{}
+
+	for itr.Next() {
+		records = append(records, Record{Key: string(itr.Key()), Value: copyBytes(itr.Value())})
+	}
+
+	if err := itr.Error(); err != nil {
+		return nil, fmt.Errorf("iterate store records: %w", err)
+	}
+
+	return records, nil
+}
+
+// Import puts the records in the store, replacing the records with the same keys.
+func Import(store Store, records []Record) error {
+	for _, record := range records {
+		if err := store.Put(record.Key, record.Value); err != nil {
+			return fmt.Errorf("put record %s: %w", record.Key, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestExportImport(t *testing.T) {
+	provider := mem.NewProvider()
+
+	store, err := provider.OpenStore("source")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("xyz_1", []byte("value3")))
+	require.NoError(t, store.Put("abc_1", []byte("value1")))
+	require.NoError(t, store.Put("abc_2", []byte("value2")))
+	require.NoError(t, store.Put("abc_3", []byte("deleted")))
+	require.NoError(t, store.SoftDelete("abc_3"))
+
+	records, err := storage.Export(store)
+	require.NoError(t, err)
+	require.Equal(t, []storage.Record{
+		{Key: "abc_1", Value: []byte("value1")},
+		{Key: "abc_2", Value: []byte("value2")},
+		{Key: "xyz_1", Value: []byte("value3")},
+	}, records)
+
+	target, err := provider.OpenStore("target")
+	require.NoError(t, err)
+
+	require.NoError(t, target.Put("abc_1", []byte("replaced")))
+	require.NoError(t, storage.Import(target, records))
+
+	imported, err := storage.Export(target)
+	require.NoError(t, err)
+	require.Equal(t, records, imported)
+
+	t.Run("empty store", func(t *testing.T) {
+		empty, err := provider.OpenStore("empty")
+		require.NoError(t, err)
+
+		records, err := storage.Export(empty)
+		require.NoError(t, err)
+		require.Empty(t, records)
+	})
+
+	t.Run("import failure", func(t *testing.T) {
+		err := storage.Import(storage.ReadOnly(target), records)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrReadOnly))
+	})
+}
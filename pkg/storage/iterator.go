@@ -0,0 +1,207 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import "errors"
+
+// errIteratorReleased is returned by the Error of released iterators.
+var errIteratorReleased = errors.New("iterator released")
+
+// WithTotalItems wraps itr to implement StoreIterator, the key/value pairs not iterated yet are read ahead
+// and buffered once TotalItems is called.
+func WithTotalItems(itr BaseIterator) StoreIterator {
+	if itr == nil {
+		return nil
+	}
+
+	if storeItr, ok := itr.(StoreIterator); ok {
+		return storeItr
+	}
+
+	return &totalItemsIterator{BaseIterator: itr}
+}
+
+// FilterKeys wraps itr to skip the key/value pairs with keys rejected by keyPredicate, the values of the skipped
+// pairs aren't read.
+func FilterKeys(itr BaseIterator, keyPredicate func(key string) bool) StoreIterator {
+	if itr == nil {
+		return nil
+	}
+
+	return WithTotalItems(&keyFilteringIterator{BaseIterator: itr, keyPredicate: keyPredicate})
+}
+
+type keyFilteringIterator struct {
+	BaseIterator
+	keyPredicate func(key string) bool
+}
+
+// Next moves the iterator to the next key/value pair with a key accepted by the predicate.
+// It returns false if the iterator is exhausted.
+func (i *keyFilteringIterator) Next() bool {
+	for i.BaseIterator.Next() {
+		if i.keyPredicate(string(i.BaseIterator.Key())) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EmptyIterator returns an iterator of no key/value pairs, its Error returns err until released.
+// A nil err stands for a valid empty range.
+func EmptyIterator(err error) StoreIterator {
+	return &emptyIterator{err: err}
+}
+
+type keyValue struct {
+	key   []byte
+	value []byte
+}
+
+type totalItemsIterator struct {
+	BaseIterator
+	iterated int
+	// readAhead is set once the pairs not iterated yet are buffered in pending, the current pair
+	// is then served from current
+	readAhead bool
+	pending   []keyValue
+	current   *keyValue
+	released  bool
+}
+
+// Next moves the iterator to the next key/value pair.
+// It returns false if the iterator is exhausted.
+func (i *totalItemsIterator) Next() bool {
+	if !i.readAhead {
+		if !i.BaseIterator.Next() {
+			return false
+		}
+
+		i.iterated++
+
+		return true
+	}
+
+	if len(i.pending) == 0 {
+		i.current = nil
+
+		return false
+	}
+
+	i.current, i.pending = &i.pending[0], i.pending[1:]
+	i.iterated++
+
+	return true
+}
+
+// Key returns the key of the current key/value pair, or nil if done.
+func (i *totalItemsIterator) Key() []byte {
+	if !i.readAhead {
+		return i.BaseIterator.Key()
+	}
+
+	if i.current == nil {
+		return nil
+	}
+
+	return i.current.key
+}
+
+// Value returns the value of the current key/value pair, or nil if done.
+func (i *totalItemsIterator) Value() []byte {
+	if !i.readAhead {
+		return i.BaseIterator.Value()
+	}
+
+	if i.current == nil {
+		return nil
+	}
+
+	return i.current.value
+}
+
+// TotalItems returns the number of key/value pairs in the range of the iterator, the pairs not iterated yet
+// are read ahead on the first call.
+func (i *totalItemsIterator) TotalItems() int {
+	if i.released {
+		return 0
+	}
+
+	if !i.readAhead {
+		if i.iterated > 0 {
+			i.current = &keyValue{key: copyBytes(i.BaseIterator.Key()), value: copyBytes(i.BaseIterator.Value())}
+		}
+
+		for i.BaseIterator.Next() {
+			i.pending = append(i.pending, keyValue{
+				key:   copyBytes(i.BaseIterator.Key()),
+				value: copyBytes(i.BaseIterator.Value()),
+			})
+		}
+
+		i.readAhead = true
+	}
+
+	return i.iterated + len(i.pending)
+}
+
+// Release releases associated resources.
+func (i *totalItemsIterator) Release() {
+	// released iterators are served by the underlying iterator again
+	i.released = true
+	i.readAhead = false
+	i.pending = nil
+	i.current = nil
+
+	i.BaseIterator.Release()
+}
+
+// copyBytes copies the key/value pairs read ahead, iterators may reuse their buffers.
+func copyBytes(v []byte) []byte {
+	if v == nil {
+		return nil
+	}
+
+	return append([]byte(nil), v...)
+}
+
+type emptyIterator struct {
+	err error
+}
+
+// Next returns false, the iterator is exhausted.
+func (i *emptyIterator) Next() bool {
+	return false
+}
+
+// Release releases the iterator.
+func (i *emptyIterator) Release() {
+	if i.err == nil {
+		i.err = errIteratorReleased
+	}
+}
+
+// Error returns the error of the iterator.
+func (i *emptyIterator) Error() error {
+	return i.err
+}
+
+// Key returns nil.
+func (i *emptyIterator) Key() []byte {
+	return nil
+}
+
+// Value returns nil.
+func (i *emptyIterator) Value() []byte {
+	return nil
+}
+
+// TotalItems returns 0.
+func (i *emptyIterator) TotalItems() int {
+	return 0
+}
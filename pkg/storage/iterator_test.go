@@ -0,0 +1,191 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sliceIterator is a BaseIterator reusing its key/value buffers, like the iterators of most databases.
+type sliceIterator struct {
+	items    [][2]string
+	index    int
+	key      []byte
+	value    []byte
+	released bool
+}
+
+func (i *sliceIterator) Next() bool {
+	if i.released || i.index == len(i.items) {
+		return false
+	}
+
+	i.key = append(i.key[:0], i.items[i.index][0]...)
+	i.value = append(i.value[:0], i.items[i.index][1]...)
+	i.index++
+
+	return true
+}
+
+func (i *sliceIterator) Release() {
+	i.released = true
+}
+
+func (i *sliceIterator) Error() error {
+	if i.released {
+		return errIteratorReleased
+	}
+
+	return nil
+}
+
+func (i *sliceIterator) Key() []byte {
+	return i.key
+}
+
+func (i *sliceIterator) Value() []byte {
+	return i.value
+}
+
+func TestWithTotalItems(t *testing.T) {
+	items := [][2]string{{"k1", "v1"}, {"k2", "v2"}, {"k3", "v3"}}
+
+	t.Run("test total items before iterating", func(t *testing.T) {
+		itr := WithTotalItems(&sliceIterator{items: items})
+		require.Equal(t, 3, itr.TotalItems())
+
+		for _, item := range items {
+			require.True(t, itr.Next())
+			require.Equal(t, item[0], string(itr.Key()))
+			require.Equal(t, item[1], string(itr.Value()))
+		}
+
+		require.False(t, itr.Next())
+		require.Nil(t, itr.Key())
+		require.Nil(t, itr.Value())
+		require.Equal(t, 3, itr.TotalItems())
+		require.NoError(t, itr.Error())
+
+		itr.Release()
+		require.Zero(t, itr.TotalItems())
+		require.Error(t, itr.Error())
+	})
+
+	t.Run("test total items while iterating", func(t *testing.T) {
+		itr := WithTotalItems(&sliceIterator{items: items})
+		require.True(t, itr.Next())
+		require.Equal(t, 3, itr.TotalItems())
+
+		// the current pair is kept once the remaining pairs are read ahead
+		require.Equal(t, "k1", string(itr.Key()))
+		require.Equal(t, "v1", string(itr.Value()))
+
+		require.True(t, itr.Next())
+		require.Equal(t, "k2", string(itr.Key()))
+		require.True(t, itr.Next())
+		require.Equal(t, "v3", string(itr.Value()))
+		require.False(t, itr.Next())
+		require.Equal(t, 3, itr.TotalItems())
+	})
+
+	t.Run("test empty range", func(t *testing.T) {
+		itr := WithTotalItems(&sliceIterator{})
+		require.Zero(t, itr.TotalItems())
+		require.False(t, itr.Next())
+		require.NoError(t, itr.Error())
+	})
+
+	t.Run("test store iterators aren't wrapped", func(t *testing.T) {
+		itr := EmptyIterator(nil)
+		require.Equal(t, itr, WithTotalItems(itr))
+		require.Nil(t, WithTotalItems(nil))
+	})
+}
+
+func TestEmptyIterator(t *testing.T) {
+	t.Run("test valid empty range", func(t *testing.T) {
+		itr := EmptyIterator(nil)
+		require.False(t, itr.Next())
+		require.Nil(t, itr.Key())
+		require.Nil(t, itr.Value())
+		require.Zero(t, itr.TotalItems())
+		require.NoError(t, itr.Error())
+
+		itr.Release()
+		require.EqualError(t, itr.Error(), "iterator released")
+	})
+
+	t.Run("test error", func(t *testing.T) {
+		itr := EmptyIterator(errors.New("iterator error"))
+		require.False(t, itr.Next())
+		require.EqualError(t, itr.Error(), "iterator error")
+
+		itr.Release()
+		require.EqualError(t, itr.Error(), "iterator error")
+	})
+}
+
+// valueCountingIterator counts the values read from the underlying iterator.
+type valueCountingIterator struct {
+	BaseIterator
+	reads []string
+}
+
+func (i *valueCountingIterator) Value() []byte {
+	i.reads = append(i.reads, string(i.BaseIterator.Key()))
+
+	return i.BaseIterator.Value()
+}
+
+func TestFilterKeys(t *testing.T) {
+	items := [][2]string{{"k1", "v1"}, {"k2", "v2"}, {"k3", "v3"}, {"k4", "v4"}, {"k5", "v5"}}
+
+	t.Run("test rejected keys are skipped without reading their values", func(t *testing.T) {
+		base := &valueCountingIterator{BaseIterator: &sliceIterator{items: items}}
+
+		itr := FilterKeys(base, func(key string) bool {
+			return key == "k2" || key == "k3" || key == "k5"
+		})
+
+		for _, key := range []string{"k2", "k3", "k5"} {
+			require.True(t, itr.Next())
+			require.Equal(t, key, string(itr.Key()))
+			require.Equal(t, "v"+key[1:], string(itr.Value()))
+		}
+
+		require.False(t, itr.Next())
+		require.NoError(t, itr.Error())
+		require.Equal(t, []string{"k2", "k3", "k5"}, base.reads)
+		require.Equal(t, 3, itr.TotalItems())
+
+		itr.Release()
+		require.Error(t, itr.Error())
+	})
+
+	t.Run("test total items of filtered pairs", func(t *testing.T) {
+		itr := FilterKeys(&sliceIterator{items: items}, func(key string) bool { return key != "k1" })
+		require.Equal(t, 4, itr.TotalItems())
+
+		require.True(t, itr.Next())
+		require.Equal(t, "k2", string(itr.Key()))
+		require.Equal(t, 4, itr.TotalItems())
+	})
+
+	t.Run("test all keys rejected", func(t *testing.T) {
+		itr := FilterKeys(&sliceIterator{items: items}, func(string) bool { return false })
+		require.False(t, itr.Next())
+		require.Zero(t, itr.TotalItems())
+		require.NoError(t, itr.Error())
+	})
+
+	t.Run("test nil iterator", func(t *testing.T) {
+		require.Nil(t, FilterKeys(nil, func(string) bool { return true }))
+	})
+}
@@ -11,6 +11,7 @@ package jsindexeddb
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall/js"
@@ -36,6 +37,10 @@ const (
 
 var dbVersion = 1 //nolint:gochecknoglobals
 
+// writeLock serializes the writes of the records, each write reads the version of the record to bump it and the
+// records are read and written in separate indexedDB transactions.
+var writeLock sync.Mutex //nolint:gochecknoglobals
+
 // Provider jsindexeddb implementation of storage.Provider interface.
 type Provider struct {
 	sync.RWMutex
@@ -130,22 +135,95 @@ func (s *store) Put(k string, v []byte) error {
 	m["key"] = k
 	m["value"] = string(v)
 
-	req := s.db.Call("transaction", s.name, "readwrite").Call("objectStore", s.name).Call("put", m)
+	_, err := s.put(k, m, nil)
 
-	_, err := getResult(req)
-	if err != nil {
-		return fmt.Errorf("failed to store data: %w", err)
+	return err
+}
+
+// PutWithTTL stores the key and the record expiring after ttl, the expiry is kept in unix milliseconds along with
+// the record.
+func (s *store) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
 	}
 
-	return nil
+	if ttl <= 0 {
+		return storage.ErrInvalidTTL
+	}
+
+	m := make(map[string]interface{})
+	m["key"] = k
+	m["value"] = string(v)
+	m["expiry"] = time.Now().Add(ttl).UnixNano() / int64(time.Millisecond)
+
+	_, err := s.put(k, m, nil)
+
+	return err
 }
 
-// Get fetches the record based on key.
-func (s *store) Get(k string) ([]byte, error) {
-	if k == "" {
-		return nil, errors.New("key is mandatory")
+// PutIfVersion stores the key and the record if the version of the record is expectedVersion, the versions are
+// counters kept along with the records and bumped by each write of the record.
+func (s *store) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	if k == "" || v == nil {
+		return "", errors.New("key and value are mandatory")
+	}
+
+	m := make(map[string]interface{})
+	m["key"] = k
+	m["value"] = string(v)
+
+	return s.put(k, m, func(version string) bool {
+		return version == expectedVersion
+	})
+}
+
+// put stores the record m with k key at the next version of the record and returns that version. The write is
+// rejected with storage.ErrConflict if check, when set, rejects the current version of the record (empty if the
+// record is absent, soft deleted or expired).
+func (s *store) put(k string, m map[string]interface{}, check func(version string) bool) (string, error) {
+	writeLock.Lock()
+	defer writeLock.Unlock()
+
+	record, err := s.getRecord(k)
+	if err != nil {
+		return "", err
 	}
 
+	var current uint64
+
+	var version string
+
+	if record != nil {
+		current = recordVersion(*record)
+
+		if !isExpired(*record, time.Now()) && !storage.IsTombstone([]byte(record.Get("value").String())) {
+			version = storage.FormatVersion(current)
+		}
+	}
+
+	if check != nil && !check(version) {
+		return "", storage.ErrConflict
+	}
+
+	next, err := storage.NextVersion(current)
+	if err != nil {
+		return "", err
+	}
+
+	m["version"] = storage.FormatVersion(next)
+
+	req := s.db.Call("transaction", s.name, "readwrite").Call("objectStore", s.name).Call("put", m)
+
+	_, err = getResult(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to store data: %w", err)
+	}
+
+	return storage.FormatVersion(next), nil
+}
+
+// getRecord fetches the record with k key, nil if absent.
+func (s *store) getRecord(k string) (*js.Value, error) {
 	req := s.db.Call("transaction", s.name).Call("objectStore", s.name).Call("get", k)
 
 	data, err := getResult(req)
@@ -154,16 +232,82 @@ func (s *store) Get(k string) ([]byte, error) {
 	}
 
 	if !data.Truthy() {
+		return nil, nil
+	}
+
+	return data, nil
+}
+
+// recordVersion returns the version counter kept along with the record, 0 for the records stored without version.
+func recordVersion(record js.Value) uint64 {
+	version := record.Get("version")
+	if version.Type() != js.TypeString {
+		return 0
+	}
+
+	counter, err := strconv.ParseUint(version.String(), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return counter
+}
+
+// Get fetches the record based on key.
+func (s *store) Get(k string) ([]byte, error) {
+	data, deleted, err := s.GetIncludingDeleted(k)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted {
 		return nil, storage.ErrDataNotFound
 	}
 
-	return []byte(data.Get("value").String()), nil
+	return data, nil
+}
+
+// Has tells whether the record with k key exists, the value is read as it tells whether the record is soft deleted.
+func (s *store) Has(k string) (bool, error) {
+	_, err := s.Get(k)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// GetIncludingDeleted fetches the record based on key, including soft deleted records.
+func (s *store) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	if k == "" {
+		return nil, false, errors.New("key is mandatory")
+	}
+
+	data, err := s.getRecord(k)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if data == nil || isExpired(*data, time.Now()) {
+		return nil, false, storage.ErrDataNotFound
+	}
+
+	v, deleted := storage.ParseTombstone([]byte(data.Get("value").String()))
+
+	return v, deleted, nil
+}
+
+// isExpired tells whether the record was put with a TTL and is expired at now.
+func isExpired(record js.Value, now time.Time) bool {
+	expiry := record.Get("expiry")
+
+	return expiry.Truthy() && now.UnixNano()/int64(time.Millisecond) >= int64(expiry.Float())
 }
 
-// Iterator returns iterator for the latest snapshot of the underlying db.
+// Iterator returns iterator for the latest snapshot of the underlying db, soft deleted and expired records are skipped.
 func (s *store) Iterator(start, limit string) storage.StoreIterator {
 	if limit == "" {
-		return newIterator(nil, false, fmt.Errorf("limit key is mandatory"))
+		return storage.EmptyIterator(nil)
 	}
 
 	skipLast := true
@@ -177,7 +321,13 @@ func (s *store) Iterator(start, limit string) storage.StoreIterator {
 	openCursor := s.db.Call("transaction", s.name).Call("objectStore", s.name).Call("getAll", keyRange)
 	batch, err := getResult(openCursor)
 
-	return newIterator(batch, skipLast, err)
+	return storage.SkipTombstones(newIterator(batch, skipLast, err))
+}
+
+// IteratorWithFilter returns iterator for the latest snapshot of the underlying db skipping the records with keys
+// rejected by keyPredicate, soft deleted and expired records are skipped.
+func (s *store) IteratorWithFilter(start, limit string, keyPredicate func(key string) bool) storage.StoreIterator {
+	return storage.FilterKeys(s.Iterator(start, limit), keyPredicate)
 }
 
 // Delete will delete record with k key.
@@ -196,6 +346,70 @@ func (s *store) Delete(k string) error {
 	return nil
 }
 
+// DeletePrefix deletes the records of the prefix range, including soft deleted and expired records which aren't
+// counted.
+func (s *store) DeletePrefix(prefix string) (int, error) {
+	if prefix == "" {
+		return 0, errors.New("prefix is mandatory")
+	}
+
+	keyRange := js.Global().Get("IDBKeyRange").Call("bound", prefix, prefix+"\uffff")
+
+	req := s.db.Call("transaction", s.name).Call("objectStore", s.name).Call("getAll", keyRange)
+
+	records, err := getResult(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count data with prefix: %s - error: %w", prefix, err)
+	}
+
+	now := time.Now()
+
+	var count int
+
+	for i := 0; i < records.Length(); i++ {
+		record := records.Index(i)
+
+		if !isExpired(record, now) && !storage.IsTombstone([]byte(record.Get("value").String())) {
+			count++
+		}
+	}
+
+	req = s.db.Call("transaction", s.name, "readwrite").Call("objectStore", s.name).Call("delete", keyRange)
+
+	_, err = getResult(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete data with prefix: %s - error: %w", prefix, err)
+	}
+
+	return count, nil
+}
+
+// Reset clears all the records, including soft deleted records.
+func (s *store) Reset() error {
+	req := s.db.Call("transaction", s.name, "readwrite").Call("objectStore", s.name).Call("clear")
+
+	_, err := getResult(req)
+	if err != nil {
+		return fmt.Errorf("failed to clear data - error: %w", err)
+	}
+
+	return nil
+}
+
+// SoftDelete replaces the record with k key by its tombstone.
+func (s *store) SoftDelete(k string) error {
+	data, err := s.Get(k)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	return s.Put(k, storage.NewTombstone(data))
+}
+
 type iterator struct {
 	batch    *js.Value
 	err      error
@@ -211,17 +425,21 @@ func newIterator(batch *js.Value, skipLast bool, err error) *iterator {
 // Next moves pointer to next value of iterator.
 // It returns false if the iterator is exhausted.
 func (s *iterator) Next() bool {
-	s.index++
+	for {
+		s.index++
+
+		if s.batch == nil || !s.batch.Index(s.index).Truthy() {
+			return false
+		}
 
-	if s.batch != nil && s.batch.Index(s.index).Truthy() {
 		if s.skipLast && !s.batch.Index(s.index+1).Truthy() {
 			return false
 		}
 
-		return true
+		if !isExpired(s.batch.Index(s.index), time.Now()) {
+			return true
+		}
 	}
-
-	return false
 }
 
 // Release releases associated resources.
@@ -192,6 +192,9 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 
 	var vals []string
 
+	require.NoError(t, itr.Error())
+	require.Equal(t, count, itr.TotalItems())
+
 	for itr.Next() {
 		if prefix != "" {
 			require.True(t, strings.HasPrefix(string(itr.Key()), prefix))
@@ -200,6 +203,7 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 		vals = append(vals, string(itr.Value()))
 	}
 	require.Len(t, vals, count)
+	require.Equal(t, count, itr.TotalItems())
 
 	itr.Release()
 	require.False(t, itr.Next())
@@ -207,6 +211,7 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 	require.Empty(t, itr.Value())
 	require.Error(t, itr.Error())
 	require.Contains(t, itr.Error().Error(), "iterator released")
+	require.Zero(t, itr.TotalItems())
 }
 
 func TestStore_Delete(t *testing.T) {
@@ -246,3 +251,52 @@ func TestStore_Delete(t *testing.T) {
 	require.EqualError(t, err, storage.ErrDataNotFound.Error())
 	require.Empty(t, doc)
 }
+
+func TestStore_PutIfVersion(t *testing.T) {
+	const commonKey = "did:example:5678"
+
+	prov, err := NewProvider("sampledb")
+	require.NoError(t, err)
+
+	store1, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	_, err = store1.PutIfVersion(commonKey, nil, "")
+	require.EqualError(t, err, "key and value are mandatory")
+
+	// create if absent
+	v1, err := store1.PutIfVersion(commonKey, []byte("value1"), "")
+	require.NoError(t, err)
+	require.NotEmpty(t, v1)
+
+	_, err = store1.PutIfVersion(commonKey, []byte("value2"), "")
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	v2, err := store1.PutIfVersion(commonKey, []byte("value2"), v1)
+	require.NoError(t, err)
+	require.NotEqual(t, v1, v2)
+
+	// conflicting CAS, with the stale version of a record put back to its previous value
+	v3, err := store1.PutIfVersion(commonKey, []byte("value1"), v2)
+	require.NoError(t, err)
+	require.NotEqual(t, v1, v3)
+
+	_, err = store1.PutIfVersion(commonKey, []byte("value3"), v1)
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	// Put changes the version
+	require.NoError(t, store1.Put(commonKey, []byte("value1")))
+
+	_, err = store1.PutIfVersion(commonKey, []byte("value3"), v3)
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	doc, err := store1.Get(commonKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), doc)
+
+	// soft deleted records are absent
+	require.NoError(t, store1.SoftDelete(commonKey))
+
+	_, err = store1.PutIfVersion(commonKey, []byte("value3"), "")
+	require.NoError(t, err)
+}
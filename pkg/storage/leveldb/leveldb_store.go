@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/util"
@@ -64,7 +65,7 @@ func (p *Provider) newLeveldbStore(name string) (*leveldbStore, error) {
 		return nil, err
 	}
 
-	store := &leveldbStore{db}
+	store := &leveldbStore{db: db}
 	p.dbs[strings.ToLower(name)] = store
 
 	return store, nil
@@ -111,6 +112,8 @@ func (p *Provider) CloseStore(name string) error {
 
 type leveldbStore struct {
 	db *leveldb.DB
+	// lock serializes the writes of the records, each write reads the version of the record to bump it
+	lock sync.Mutex
 }
 
 // Put stores the key and the record.
@@ -119,7 +122,82 @@ func (s *leveldbStore) Put(k string, v []byte) error {
 		return errors.New("key and value are mandatory")
 	}
 
-	return s.db.Put([]byte(k), v, nil)
+	_, err := s.put(k, v, nil)
+
+	return err
+}
+
+// PutWithTTL stores the key and the record expiring after ttl.
+func (s *leveldbStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	if ttl <= 0 {
+		return storage.ErrInvalidTTL
+	}
+
+	_, err := s.put(k, storage.NewExpiringValue(v, time.Now().Add(ttl)), nil)
+
+	return err
+}
+
+// PutIfVersion stores the key and the record if the version of the record is expectedVersion, the versions are
+// counters stored next to the values (see storage.NewVersionedValue) and bumped by each write of the record.
+func (s *leveldbStore) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	if k == "" || v == nil {
+		return "", errors.New("key and value are mandatory")
+	}
+
+	return s.put(k, v, func(version string) bool {
+		return version == expectedVersion
+	})
+}
+
+// put stores v as the value of the record with k key at the next version of the record and returns that version.
+// The write is rejected with storage.ErrConflict if check, when set, rejects the current version of the record
+// (empty if the record is absent, soft deleted or expired).
+func (s *leveldbStore) put(k string, v []byte, check func(version string) bool) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var current uint64
+
+	var live bool
+
+	data, err := s.db.Get([]byte(k), nil)
+
+	switch {
+	case errors.Is(err, leveldb.ErrNotFound):
+	case err != nil:
+		return "", fmt.Errorf("failed to get record: %w", err)
+	default:
+		data, current = storage.ParseVersionedValue(data)
+		live = storage.IsLive(data, time.Now())
+	}
+
+	if check != nil {
+		var version string
+
+		if live {
+			version = storage.FormatVersion(current)
+		}
+
+		if !check(version) {
+			return "", storage.ErrConflict
+		}
+	}
+
+	next, err := storage.NextVersion(current)
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.db.Put([]byte(k), storage.NewVersionedValue(v, next), nil); err != nil {
+		return "", fmt.Errorf("failed to put record: %w", err)
+	}
+
+	return storage.FormatVersion(next), nil
 }
 
 // Get fetches the record based on key.
@@ -128,22 +206,79 @@ func (s *leveldbStore) Get(k string) ([]byte, error) {
 		return nil, errors.New("key is mandatory")
 	}
 
-	data, err := s.db.Get([]byte(k), nil)
+	data, deleted, err := s.GetIncludingDeleted(k)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return data, nil
+}
+
+// Has tells whether the record with k key exists, the value is read as it tells whether the record is soft deleted
+// or expired.
+func (s *leveldbStore) Has(k string) (bool, error) {
+	_, err := s.Get(k)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// GetIncludingDeleted fetches the record based on key, including soft deleted records.
+func (s *leveldbStore) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	if k == "" {
+		return nil, false, errors.New("key is mandatory")
+	}
+
+	return parseRecord(s.db.Get([]byte(k), nil))
+}
+
+// parseRecord returns the value of a record read from the db, and whether the record is soft deleted.
+func parseRecord(data []byte, err error) ([]byte, bool, error) {
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			return nil, storage.ErrDataNotFound
+			return nil, false, storage.ErrDataNotFound
 		}
 
-		return nil, err
+		return nil, false, err
 	}
 
-	return data, nil
+	v, _ := storage.ParseVersionedValue(data)
+
+	v, deleted := storage.ParseTombstone(v)
+	if deleted {
+		return v, true, nil
+	}
+
+	v, ok := storage.ParseUnexpiredValue(v, time.Now())
+	if !ok {
+		return nil, false, storage.ErrDataNotFound
+	}
+
+	return v, false, nil
 }
 
-// Iterator returns iterator for the latest snapshot of the underlying db.
+// Iterator returns iterator for the latest snapshot of the underlying db, soft deleted and expired
+// records are skipped.
 func (s *leveldbStore) Iterator(start, limit string) storage.StoreIterator {
-	return s.db.NewIterator(&util.Range{Start: []byte(start),
-		Limit: []byte(strings.ReplaceAll(limit, storage.EndKeySuffix, "~"))}, nil)
+	return storage.SkipTombstones(storage.SkipExpired(
+		storage.SkipVersions(s.db.NewIterator(keyRange(start, limit), nil)), time.Now))
+}
+
+// IteratorWithFilter returns iterator for the latest snapshot of the underlying db skipping the records with keys
+// rejected by keyPredicate, soft deleted and expired records are skipped.
+func (s *leveldbStore) IteratorWithFilter(start, limit string, keyPredicate func(key string) bool) storage.StoreIterator {
+	return storage.SkipTombstones(storage.SkipExpired(storage.SkipVersions(
+		storage.FilterKeys(s.db.NewIterator(keyRange(start, limit), nil), keyPredicate)), time.Now))
+}
+
+func keyRange(start, limit string) *util.Range {
+	return &util.Range{Start: []byte(start), Limit: []byte(strings.ReplaceAll(limit, storage.EndKeySuffix, "~"))}
 }
 
 // Delete will delete record with k key.
@@ -154,3 +289,75 @@ func (s *leveldbStore) Delete(k string) error {
 
 	return s.db.Delete([]byte(k), nil)
 }
+
+// DeletePrefix deletes the records in the prefix range in a single batch, including soft deleted and
+// expired records which aren't counted.
+func (s *leveldbStore) DeletePrefix(prefix string) (int, error) {
+	if prefix == "" {
+		return 0, errors.New("prefix is mandatory")
+	}
+
+	itr := s.db.NewIterator(keyRange(prefix, prefix+storage.EndKeySuffix), nil)
+	defer itr.Release()
+
+	batch := new(leveldb.Batch)
+	now := time.Now()
+
+	var deleted int
+
+	for itr.Next() {
+		if v, _ := storage.ParseVersionedValue(itr.Value()); storage.IsLive(v, now) {
+			deleted++
+		}
+
+		batch.Delete(itr.Key())
+	}
+
+	if err := itr.Error(); err != nil {
+		return 0, fmt.Errorf("failed to iterate records: %w", err)
+	}
+
+	if err := s.db.Write(batch, nil); err != nil {
+		return 0, fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// Reset deletes all the records in a single batch, including soft deleted and expired records.
+func (s *leveldbStore) Reset() error {
+	itr := s.db.NewIterator(nil, nil)
+	defer itr.Release()
+
+	batch := new(leveldb.Batch)
+
+	for itr.Next() {
+		batch.Delete(itr.Key())
+	}
+
+	if err := itr.Error(); err != nil {
+		return fmt.Errorf("failed to iterate records: %w", err)
+	}
+
+	if err := s.db.Write(batch, nil); err != nil {
+		return fmt.Errorf("failed to delete records: %w", err)
+	}
+
+	return nil
+}
+
+// SoftDelete replaces the record with k key by its tombstone.
+func (s *leveldbStore) SoftDelete(k string) error {
+	data, err := s.Get(k)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	_, err = s.put(k, storage.NewTombstone(data), nil)
+
+	return err
+}
@@ -9,24 +9,40 @@ package mem
 import (
 	"errors"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
-// TODO https://github.com/hyperledger/aries-framework-go/issues/750 - we will need to consider
-//  automatic eviction based on TTL.
-
 // Provider leveldb implementation of storage.Provider interface.
 type Provider struct {
 	dbs  map[string]*memStore
 	lock sync.RWMutex
+	now  func() time.Time
+}
+
+// Option configures the mem provider.
+type Option func(opts *Provider)
+
+// WithClock option is for setting the clock used to expire the records put with a TTL (defaults to time.Now).
+func WithClock(now func() time.Time) Option {
+	return func(opts *Provider) {
+		opts.now = now
+	}
 }
 
 // NewProvider instantiates Provider.
-func NewProvider() *Provider {
-	return &Provider{dbs: make(map[string]*memStore)}
+func NewProvider(opts ...Option) *Provider {
+	p := &Provider{dbs: make(map[string]*memStore), now: time.Now}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
 }
 
 // OpenStore opens and returns a store for given name space.
@@ -54,7 +70,12 @@ func (p *Provider) newMemStore(name string) *memStore {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	store := &memStore{db: make(map[string][]byte)}
+	store := &memStore{
+		db:       make(map[string][]byte),
+		expiry:   make(map[string]time.Time),
+		versions: make(map[string]uint64),
+		now:      p.now,
+	}
 	p.dbs[strings.ToLower(name)] = store
 
 	return store
@@ -66,7 +87,7 @@ func (p *Provider) Close() error {
 	defer p.lock.Unlock()
 
 	for _, memStore := range p.dbs {
-		memStore.db = make(map[string][]byte)
+		memStore.clear()
 	}
 
 	p.dbs = make(map[string]*memStore)
@@ -85,17 +106,30 @@ func (p *Provider) CloseStore(name string) error {
 	if ok {
 		delete(p.dbs, k)
 
-		memStore.db = make(map[string][]byte)
+		memStore.clear()
 	}
 
 	return nil
 }
 
 type memStore struct {
-	db map[string][]byte
+	db     map[string][]byte
+	expiry map[string]time.Time
+	// versions holds the versions of the records, taken from a counter of the store so that a record deleted then
+	// put again doesn't get back a previous version
+	versions    map[string]uint64
+	lastVersion uint64
+	now         func() time.Time
 	sync.RWMutex
 }
 
+// clear deletes all the records.
+func (s *memStore) clear() {
+	s.db = make(map[string][]byte)
+	s.expiry = make(map[string]time.Time)
+	s.versions = make(map[string]uint64)
+}
+
 // Put stores the key and the record.
 func (s *memStore) Put(k string, v []byte) error {
 	if k == "" || v == nil {
@@ -104,11 +138,88 @@ func (s *memStore) Put(k string, v []byte) error {
 
 	s.Lock()
 	s.db[k] = v
+	delete(s.expiry, k)
+	s.newVersion(k)
+	s.Unlock()
+
+	return nil
+}
+
+// PutIfVersion stores the key and the record if the current version of the record is expectedVersion.
+func (s *memStore) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	if k == "" || v == nil {
+		return "", errors.New("key and value are mandatory")
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.currentVersion(k) != expectedVersion {
+		return "", storage.ErrConflict
+	}
+
+	s.db[k] = v
+	delete(s.expiry, k)
+
+	return s.newVersion(k), nil
+}
+
+// currentVersion returns the version of the record with k key, empty if absent, caller holds the lock.
+func (s *memStore) currentVersion(k string) string {
+	data, ok := s.db[k]
+	if !ok || s.isExpired(k) || storage.IsTombstone(data) {
+		return ""
+	}
+
+	return strconv.FormatUint(s.versions[k], 10)
+}
+
+// newVersion sets the next version of the store to the record with k key, caller holds the lock.
+func (s *memStore) newVersion(k string) string {
+	s.lastVersion++
+	s.versions[k] = s.lastVersion
+
+	return strconv.FormatUint(s.lastVersion, 10)
+}
+
+// PutWithTTL stores the key and the record expiring after ttl.
+func (s *memStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	if ttl <= 0 {
+		return storage.ErrInvalidTTL
+	}
+
+	s.Lock()
+	s.db[k] = v
+	s.expiry[k] = s.now().Add(ttl)
+	s.newVersion(k)
 	s.Unlock()
 
 	return nil
 }
 
+// evict deletes the record with k key if expired.
+func (s *memStore) evict(k string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.isExpired(k) {
+		delete(s.db, k)
+		delete(s.expiry, k)
+		delete(s.versions, k)
+	}
+}
+
+// isExpired tells whether the record with k key is expired, caller holds the lock.
+func (s *memStore) isExpired(k string) bool {
+	expiry, ok := s.expiry[k]
+
+	return ok && !s.now().Before(expiry)
+}
+
 // Get fetches the record based on key.
 func (s *memStore) Get(k string) ([]byte, error) {
 	if k == "" {
@@ -117,15 +228,54 @@ func (s *memStore) Get(k string) ([]byte, error) {
 
 	s.RLock()
 	data, ok := s.db[k]
+	expired := s.isExpired(k)
 	s.RUnlock()
 
-	if !ok {
+	if expired {
+		s.evict(k)
+	}
+
+	if !ok || expired || storage.IsTombstone(data) {
 		return nil, storage.ErrDataNotFound
 	}
 
 	return data, nil
 }
 
+// Has tells whether the record with k key exists.
+func (s *memStore) Has(k string) (bool, error) {
+	if k == "" {
+		return false, errors.New("key is mandatory")
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	data, ok := s.db[k]
+
+	return ok && !s.isExpired(k) && !storage.IsTombstone(data), nil
+}
+
+// GetIncludingDeleted fetches the record based on key, including soft deleted records.
+func (s *memStore) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	if k == "" {
+		return nil, false, errors.New("key is mandatory")
+	}
+
+	s.RLock()
+	data, ok := s.db[k]
+	expired := s.isExpired(k)
+	s.RUnlock()
+
+	if !ok || expired {
+		return nil, false, storage.ErrDataNotFound
+	}
+
+	v, deleted := storage.ParseTombstone(data)
+
+	return v, deleted, nil
+}
+
 // Iterator returns iterator for the latest snapshot of the underlying db.
 func (s *memStore) Iterator(start, limit string) storage.StoreIterator {
 	if limit == "" {
@@ -172,12 +322,22 @@ func (s *memStore) Iterator(start, limit string) storage.StoreIterator {
 	}
 
 	for _, k := range keys[sIDx:eIDx] {
+		if storage.IsTombstone(data[k]) || s.isExpired(k) {
+			continue
+		}
+
 		batch = append(batch, []string{k, string(data[k])})
 	}
 
 	return newMemIterator(batch)
 }
 
+// IteratorWithFilter returns iterator for the latest snapshot of the underlying db skipping the records with keys
+// rejected by keyPredicate.
+func (s *memStore) IteratorWithFilter(start, limit string, keyPredicate func(key string) bool) storage.StoreIterator {
+	return storage.FilterKeys(s.Iterator(start, limit), keyPredicate)
+}
+
 // Delete will delete record with k key.
 func (s *memStore) Delete(k string) error {
 	if k == "" {
@@ -186,11 +346,72 @@ func (s *memStore) Delete(k string) error {
 
 	s.Lock()
 	delete(s.db, k)
+	delete(s.expiry, k)
+	delete(s.versions, k)
 	s.Unlock()
 
 	return nil
 }
 
+// DeletePrefix deletes the records with keys starting with prefix, including soft deleted and expired records
+// which aren't counted.
+func (s *memStore) DeletePrefix(prefix string) (int, error) {
+	if prefix == "" {
+		return 0, errors.New("prefix is mandatory")
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	var deleted int
+
+	for k := range s.db {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		if !s.isExpired(k) && !storage.IsTombstone(s.db[k]) {
+			deleted++
+		}
+
+		delete(s.db, k)
+		delete(s.expiry, k)
+		delete(s.versions, k)
+	}
+
+	return deleted, nil
+}
+
+// Reset deletes all the records, including soft deleted and expired records.
+func (s *memStore) Reset() error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.clear()
+
+	return nil
+}
+
+// SoftDelete replaces the record with k key by its tombstone.
+func (s *memStore) SoftDelete(k string) error {
+	if k == "" {
+		return errors.New("key is mandatory")
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	data, ok := s.db[k]
+	if ok && !s.isExpired(k) && !storage.IsTombstone(data) {
+		// soft deleted records are kept for audit, they no longer expire
+		s.db[k] = storage.NewTombstone(data)
+		delete(s.expiry, k)
+		delete(s.versions, k)
+	}
+
+	return nil
+}
+
 type memIterator struct {
 	currentIndex int
 	currentItem  []string
@@ -255,3 +476,8 @@ func (s *memIterator) Value() []byte {
 
 	return []byte(s.currentItem[1])
 }
+
+// TotalItems returns the number of key/value pairs of the iterator.
+func (s *memIterator) TotalItems() int {
+	return len(s.items)
+}
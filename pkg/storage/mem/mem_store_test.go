@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -228,6 +229,9 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 
 	var vals []string
 
+	require.NoError(t, itr.Error())
+	require.Equal(t, count, itr.TotalItems())
+
 	for itr.Next() {
 		if prefix != "" {
 			require.True(t, strings.HasPrefix(string(itr.Key()), prefix))
@@ -236,6 +240,7 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 		vals = append(vals, string(itr.Value()))
 	}
 	require.Len(t, vals, count)
+	require.Equal(t, count, itr.TotalItems())
 
 	itr.Release()
 	require.False(t, itr.Next())
@@ -243,6 +248,7 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 	require.Empty(t, itr.Value())
 	require.Error(t, itr.Error())
 	require.Contains(t, itr.Error().Error(), "iterator released")
+	require.Zero(t, itr.TotalItems())
 }
 
 func TestMemStore_Delete(t *testing.T) {
@@ -279,3 +285,378 @@ func TestMemStore_Delete(t *testing.T) {
 	require.EqualError(t, err, storage.ErrDataNotFound.Error())
 	require.Empty(t, doc)
 }
+
+func TestMemStore_SoftDelete(t *testing.T) {
+	const commonKey = "did:example:1234"
+
+	prov := NewProvider()
+	data := []byte("value1")
+
+	store1, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	err = store1.Put(commonKey, data)
+	require.NoError(t, err)
+
+	err = store1.Put("did:example:5678", []byte("value2"))
+	require.NoError(t, err)
+
+	// now try SoftDelete with an empty key - should fail
+	err = store1.SoftDelete("")
+	require.EqualError(t, err, "key is mandatory")
+
+	// no error if nothing to delete
+	err = store1.SoftDelete("k1")
+	require.NoError(t, err)
+
+	err = store1.SoftDelete(commonKey)
+	require.NoError(t, err)
+
+	// soft deleted record isn't found anymore
+	doc, err := store1.Get(commonKey)
+	require.EqualError(t, err, storage.ErrDataNotFound.Error())
+	require.Empty(t, doc)
+
+	// iterator skips soft deleted record
+	itr := store1.Iterator("did:example:", "did:example:"+storage.EndKeySuffix)
+
+	var keys []string
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+
+	itr.Release()
+	require.Equal(t, []string{"did:example:5678"}, keys)
+
+	// soft deleted record can still be read explicitly
+	doc, deleted, err := store1.GetIncludingDeleted(commonKey)
+	require.NoError(t, err)
+	require.True(t, deleted)
+	require.Equal(t, data, doc)
+
+	doc, deleted, err = store1.GetIncludingDeleted("did:example:5678")
+	require.NoError(t, err)
+	require.False(t, deleted)
+	require.Equal(t, []byte("value2"), doc)
+
+	// soft deleting twice keeps the original value
+	err = store1.SoftDelete(commonKey)
+	require.NoError(t, err)
+
+	doc, deleted, err = store1.GetIncludingDeleted(commonKey)
+	require.NoError(t, err)
+	require.True(t, deleted)
+	require.Equal(t, data, doc)
+
+	_, _, err = store1.GetIncludingDeleted("k1")
+	require.EqualError(t, err, storage.ErrDataNotFound.Error())
+
+	// record can be put again after being soft deleted
+	err = store1.Put(commonKey, data)
+	require.NoError(t, err)
+
+	doc, err = store1.Get(commonKey)
+	require.NoError(t, err)
+	require.Equal(t, data, doc)
+}
+
+func TestMemStore_PutWithTTL(t *testing.T) {
+	now := time.Now()
+
+	prov := NewProvider(WithClock(func() time.Time {
+		return now
+	}))
+
+	store1, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	err = store1.PutWithTTL("", []byte("value1"), time.Minute)
+	require.EqualError(t, err, "key and value are mandatory")
+
+	err = store1.PutWithTTL("did:example:1234", []byte("value1"), 0)
+	require.True(t, errors.Is(err, storage.ErrInvalidTTL))
+
+	err = store1.PutWithTTL("did:example:1234", []byte("value1"), time.Minute)
+	require.NoError(t, err)
+
+	err = store1.PutWithTTL("did:example:5678", []byte("value2"), time.Hour)
+	require.NoError(t, err)
+
+	err = store1.Put("did:example:9012", []byte("value3"))
+	require.NoError(t, err)
+
+	doc, err := store1.Get("did:example:1234")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), doc)
+
+	// first record expires
+	now = now.Add(time.Minute)
+
+	doc, err = store1.Get("did:example:1234")
+	require.EqualError(t, err, storage.ErrDataNotFound.Error())
+	require.Empty(t, doc)
+
+	_, _, err = store1.GetIncludingDeleted("did:example:1234")
+	require.EqualError(t, err, storage.ErrDataNotFound.Error())
+
+	itr := store1.Iterator("did:example:", "did:example:"+storage.EndKeySuffix)
+
+	var keys []string
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+
+	itr.Release()
+	require.Equal(t, []string{"did:example:5678", "did:example:9012"}, keys)
+
+	// put without TTL clears the expiry
+	err = store1.Put("did:example:5678", []byte("value2"))
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour)
+
+	doc, err = store1.Get("did:example:5678")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), doc)
+
+	doc, err = store1.Get("did:example:9012")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value3"), doc)
+}
+
+func TestMemStore_Has(t *testing.T) {
+	now := time.Now()
+
+	prov := NewProvider(WithClock(func() time.Time {
+		return now
+	}))
+
+	store1, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	err = store1.Put("did:example:1234", []byte("value1"))
+	require.NoError(t, err)
+
+	err = store1.PutWithTTL("did:example:5678", []byte("value2"), time.Minute)
+	require.NoError(t, err)
+
+	exists, err := store1.Has("did:example:1234")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = store1.Has("did:example:5678")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// missing record isn't an error
+	exists, err = store1.Has("did:example:9012")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// soft deleted and expired records don't exist
+	err = store1.SoftDelete("did:example:1234")
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+
+	for _, k := range []string{"did:example:1234", "did:example:5678"} {
+		exists, err = store1.Has(k)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+
+	_, err = store1.Has("")
+	require.EqualError(t, err, "key is mandatory")
+}
+
+func TestMemStore_IteratorWithFilter(t *testing.T) {
+	now := time.Now()
+
+	prov := NewProvider(WithClock(func() time.Time {
+		return now
+	}))
+
+	store1, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	for _, k := range []string{"abc_123", "abc_124", "abc_125", "abc_126", "abc_127", "dab_123"} {
+		require.NoError(t, store1.Put(k, []byte("value-"+k)))
+	}
+
+	require.NoError(t, store1.PutWithTTL("abc_128", []byte("value"), time.Minute))
+	require.NoError(t, store1.SoftDelete("abc_125"))
+
+	now = now.Add(time.Minute)
+
+	var checked []string
+
+	itr := store1.IteratorWithFilter("abc_", "abc_"+storage.EndKeySuffix, func(key string) bool {
+		checked = append(checked, key)
+
+		return key != "abc_124" && key != "abc_126"
+	})
+
+	// soft deleted and expired records are skipped as well
+	for _, k := range []string{"abc_123", "abc_127"} {
+		require.True(t, itr.Next())
+		require.Equal(t, k, string(itr.Key()))
+		require.Equal(t, "value-"+k, string(itr.Value()))
+	}
+
+	require.False(t, itr.Next())
+	require.NoError(t, itr.Error())
+	require.NotContains(t, checked, "dab_123")
+	itr.Release()
+}
+
+func TestMemStore_DeletePrefix(t *testing.T) {
+	now := time.Now()
+
+	prov := NewProvider(WithClock(func() time.Time {
+		return now
+	}))
+
+	store1, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	for _, k := range []string{"abc_123", "abc_124", "abc123", "dab_123"} {
+		require.NoError(t, store1.Put(k, []byte("value")))
+	}
+
+	require.NoError(t, store1.PutWithTTL("abc_125", []byte("value"), time.Minute))
+	require.NoError(t, store1.SoftDelete("abc_124"))
+
+	now = now.Add(time.Minute)
+
+	// soft deleted and expired records are deleted as well but aren't counted
+	deleted, err := store1.DeletePrefix("abc_")
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+
+	_, err = store1.Get("abc_123")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	_, _, err = store1.GetIncludingDeleted("abc_124")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	for _, k := range []string{"abc123", "dab_123"} {
+		exists, err := store1.Has(k)
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	_, err = store1.DeletePrefix("")
+	require.EqualError(t, err, "prefix is mandatory")
+}
+
+func TestMemStore_Reset(t *testing.T) {
+	prov := NewProvider()
+
+	store1, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	store2, err := prov.OpenStore("store2")
+	require.NoError(t, err)
+
+	for _, k := range []string{"abc_123", "abc_124", "dab_123"} {
+		require.NoError(t, store1.Put(k, []byte("value")))
+	}
+
+	require.NoError(t, store1.PutWithTTL("abc_125", []byte("value"), time.Minute))
+	require.NoError(t, store1.SoftDelete("abc_124"))
+	require.NoError(t, store2.Put("abc_123", []byte("value")))
+
+	require.NoError(t, store1.Reset())
+
+	itr := store1.Iterator("", storage.EndKeySuffix)
+	require.False(t, itr.Next())
+	itr.Release()
+
+	_, _, err = store1.GetIncludingDeleted("abc_124")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	// the store remains open, other stores are left untouched
+	require.NoError(t, store1.Put("abc_123", []byte("new value")))
+
+	v, err := store1.Get("abc_123")
+	require.NoError(t, err)
+	require.Equal(t, []byte("new value"), v)
+
+	exists, err := store2.Has("abc_123")
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestMemStore_PutIfVersion(t *testing.T) {
+	now := time.Now()
+
+	prov := NewProvider(WithClock(func() time.Time {
+		return now
+	}))
+
+	store1, err := prov.OpenStore("store1")
+	require.NoError(t, err)
+
+	_, err = store1.PutIfVersion("", []byte("value1"), "")
+	require.EqualError(t, err, "key and value are mandatory")
+
+	// create if absent
+	v1, err := store1.PutIfVersion("did:example:1234", []byte("value1"), "")
+	require.NoError(t, err)
+	require.NotEmpty(t, v1)
+
+	_, err = store1.PutIfVersion("did:example:1234", []byte("value2"), "")
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	// successful CAS
+	v2, err := store1.PutIfVersion("did:example:1234", []byte("value2"), v1)
+	require.NoError(t, err)
+	require.NotEqual(t, v1, v2)
+
+	doc, err := store1.Get("did:example:1234")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), doc)
+
+	// conflicting CAS, with a stale version
+	_, err = store1.PutIfVersion("did:example:1234", []byte("value3"), v1)
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	// a put changes the version
+	require.NoError(t, store1.Put("did:example:1234", []byte("value2")))
+
+	_, err = store1.PutIfVersion("did:example:1234", []byte("value3"), v2)
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	doc, err = store1.Get("did:example:1234")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), doc)
+
+	// deleted, soft deleted and expired records are absent
+	require.NoError(t, store1.Delete("did:example:1234"))
+
+	v3, err := store1.PutIfVersion("did:example:1234", []byte("value3"), "")
+	require.NoError(t, err)
+	require.NotContains(t, []string{v1, v2}, v3)
+
+	require.NoError(t, store1.SoftDelete("did:example:1234"))
+
+	_, err = store1.PutIfVersion("did:example:1234", []byte("value4"), v3)
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	_, err = store1.PutIfVersion("did:example:1234", []byte("value4"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, store1.PutWithTTL("did:example:5678", []byte("value1"), time.Minute))
+
+	now = now.Add(time.Minute)
+
+	_, err = store1.PutIfVersion("did:example:5678", []byte("value2"), "")
+	require.NoError(t, err)
+
+	// the record no longer expires
+	now = now.Add(time.Hour)
+
+	doc, err = store1.Get("did:example:5678")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), doc)
+}
@@ -0,0 +1,242 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package metrics offers a storage.Provider wrapper recording latency and error metrics of the storage operations
+// performed against any underlying storage.Provider implementation.
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// Storage operation labels reported to the Collector.
+const (
+	// PutOperation label of storage.Store Put operation.
+	PutOperation = "Put"
+	// PutWithTTLOperation label of storage.Store PutWithTTL operation.
+	PutWithTTLOperation = "PutWithTTL"
+	// PutIfVersionOperation label of storage.Store PutIfVersion operation.
+	PutIfVersionOperation = "PutIfVersion"
+	// GetOperation label of storage.Store Get operation.
+	GetOperation = "Get"
+	// HasOperation label of storage.Store Has operation.
+	HasOperation = "Has"
+	// DeleteOperation label of storage.Store Delete operation.
+	DeleteOperation = "Delete"
+	// DeletePrefixOperation label of storage.Store DeletePrefix operation.
+	DeletePrefixOperation = "DeletePrefix"
+	// ResetOperation label of storage.Store Reset operation.
+	ResetOperation = "Reset"
+	// IteratorOperation label of storage.Store Iterator operation.
+	IteratorOperation = "Iterator"
+	// SoftDeleteOperation label of storage.Store SoftDelete operation.
+	SoftDeleteOperation = "SoftDelete"
+	// GetIncludingDeletedOperation label of storage.Store GetIncludingDeleted operation.
+	GetIncludingDeletedOperation = "GetIncludingDeleted"
+)
+
+// Collector collects metrics of storage operations (eg: backed by prometheus histograms and counters).
+type Collector interface {
+	// ObserveLatency records the duration of the given operation performed against the given store.
+	ObserveLatency(store, operation string, duration time.Duration)
+	// IncrementError counts a failure of the given operation performed against the given store.
+	IncrementError(store, operation string)
+}
+
+// Provider is a storage.Provider wrapper recording metrics of operations performed on its stores.
+type Provider struct {
+	provider  storage.Provider
+	collector Collector
+}
+
+// NewProvider returns a new Provider wrapping provider and reporting metrics to collector.
+func NewProvider(provider storage.Provider, collector Collector) *Provider {
+	return &Provider{provider: provider, collector: collector}
+}
+
+// OpenStore opens the store with given name from the underlying provider and returns a metrics recording wrapper.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	store, err := p.provider.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricsStore{name: name, store: store, collector: p.collector}, nil
+}
+
+// CloseStore closes the store of given name in the underlying provider.
+func (p *Provider) CloseStore(name string) error {
+	return p.provider.CloseStore(name)
+}
+
+// Close closes the underlying provider.
+func (p *Provider) Close() error {
+	return p.provider.Close()
+}
+
+type metricsStore struct {
+	name      string
+	store     storage.Store
+	collector Collector
+}
+
+// Put stores the key and the record in the underlying store.
+func (s *metricsStore) Put(k string, v []byte) error {
+	start := time.Now()
+	err := s.store.Put(k, v)
+
+	s.record(PutOperation, start, err)
+
+	return err
+}
+
+// PutWithTTL stores the key and the record expiring after ttl in the underlying store.
+func (s *metricsStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	start := time.Now()
+	err := s.store.PutWithTTL(k, v, ttl)
+
+	s.record(PutWithTTLOperation, start, err)
+
+	return err
+}
+
+// PutIfVersion stores the key and the record in the underlying store if the version of the record is expectedVersion.
+func (s *metricsStore) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	start := time.Now()
+	version, err := s.store.PutIfVersion(k, v, expectedVersion)
+
+	s.record(PutIfVersionOperation, start, err)
+
+	return version, err
+}
+
+// Get fetches the record based on key from the underlying store.
+func (s *metricsStore) Get(k string) ([]byte, error) {
+	start := time.Now()
+	v, err := s.store.Get(k)
+
+	s.record(GetOperation, start, err)
+
+	return v, err
+}
+
+// Has tells whether the record with k key exists in the underlying store.
+func (s *metricsStore) Has(k string) (bool, error) {
+	start := time.Now()
+	exists, err := s.store.Has(k)
+
+	s.record(HasOperation, start, err)
+
+	return exists, err
+}
+
+// Iterator returns an iterator of the underlying store, iterator errors are counted when released.
+func (s *metricsStore) Iterator(startKey, endKey string) storage.StoreIterator {
+	start := time.Now()
+	itr := s.store.Iterator(startKey, endKey)
+
+	s.collector.ObserveLatency(s.name, IteratorOperation, time.Since(start))
+
+	if itr == nil {
+		return nil
+	}
+
+	return &metricsIterator{StoreIterator: itr, store: s}
+}
+
+// IteratorWithFilter returns a filtered iterator of the underlying store, iterator errors are counted when released.
+func (s *metricsStore) IteratorWithFilter(startKey, endKey string,
+	keyPredicate func(key string) bool) storage.StoreIterator {
+	start := time.Now()
+	itr := s.store.IteratorWithFilter(startKey, endKey, keyPredicate)
+
+	s.collector.ObserveLatency(s.name, IteratorOperation, time.Since(start))
+
+	if itr == nil {
+		return nil
+	}
+
+	return &metricsIterator{StoreIterator: itr, store: s}
+}
+
+// Delete will delete the record with k key from the underlying store.
+func (s *metricsStore) Delete(k string) error {
+	start := time.Now()
+	err := s.store.Delete(k)
+
+	s.record(DeleteOperation, start, err)
+
+	return err
+}
+
+// DeletePrefix deletes the records with keys of the prefix range from the underlying store.
+func (s *metricsStore) DeletePrefix(prefix string) (int, error) {
+	start := time.Now()
+	deleted, err := s.store.DeletePrefix(prefix)
+
+	s.record(DeletePrefixOperation, start, err)
+
+	return deleted, err
+}
+
+// Reset deletes all the records of the underlying store.
+func (s *metricsStore) Reset() error {
+	start := time.Now()
+	err := s.store.Reset()
+
+	s.record(ResetOperation, start, err)
+
+	return err
+}
+
+// SoftDelete will soft delete the record with k key in the underlying store.
+func (s *metricsStore) SoftDelete(k string) error {
+	start := time.Now()
+	err := s.store.SoftDelete(k)
+
+	s.record(SoftDeleteOperation, start, err)
+
+	return err
+}
+
+// GetIncludingDeleted fetches the record based on key from the underlying store, including soft deleted records.
+func (s *metricsStore) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	start := time.Now()
+	v, deleted, err := s.store.GetIncludingDeleted(k)
+
+	s.record(GetIncludingDeletedOperation, start, err)
+
+	return v, deleted, err
+}
+
+// record reports operation latency and errors, a missing record is a regular outcome and isn't counted as error.
+func (s *metricsStore) record(operation string, start time.Time, err error) {
+	s.collector.ObserveLatency(s.name, operation, time.Since(start))
+
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		s.collector.IncrementError(s.name, operation)
+	}
+}
+
+type metricsIterator struct {
+	storage.StoreIterator
+	store    *metricsStore
+	released bool
+}
+
+// Release counts accumulated iterator error (if any) and releases the underlying iterator.
+func (i *metricsIterator) Release() {
+	if !i.released && i.StoreIterator.Error() != nil {
+		i.store.collector.IncrementError(i.store.name, IteratorOperation)
+	}
+
+	i.released = true
+
+	i.StoreIterator.Release()
+}
@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+type mockCollector struct {
+	latencies map[string]int
+	errors    map[string]int
+	lock      sync.Mutex
+}
+
+func newMockCollector() *mockCollector {
+	return &mockCollector{latencies: map[string]int{}, errors: map[string]int{}}
+}
+
+func (c *mockCollector) ObserveLatency(store, operation string, _ time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.latencies[store+"/"+operation]++
+}
+
+func (c *mockCollector) IncrementError(store, operation string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.errors[store+"/"+operation]++
+}
+
+func TestMetricsStore(t *testing.T) {
+	t.Run("test operations are observed with operation labels", func(t *testing.T) {
+		collector := newMockCollector()
+		prov := NewProvider(mem.NewProvider(), collector)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("abc_1", []byte("value")))
+
+		version, err := store.PutIfVersion("dab_1", []byte("value"), "")
+		require.NoError(t, err)
+		require.NotEmpty(t, version)
+
+		v, err := store.Get("abc_1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), v)
+
+		exists, err := store.Has("abc_1")
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		itr := store.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+		require.True(t, itr.Next())
+		require.Equal(t, []byte("abc_1"), itr.Key())
+		itr.Release()
+
+		require.NoError(t, store.Delete("abc_1"))
+
+		deleted, err := store.DeletePrefix("abc_")
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+
+		require.NoError(t, store.Reset())
+
+		require.Equal(t, map[string]int{
+			"test/" + PutOperation:          1,
+			"test/" + PutIfVersionOperation: 1,
+			"test/" + GetOperation:          1,
+			"test/" + HasOperation:          1,
+			"test/" + IteratorOperation:     1,
+			"test/" + DeleteOperation:       1,
+			"test/" + DeletePrefixOperation: 1,
+			"test/" + ResetOperation:        1,
+		}, collector.latencies)
+		require.Empty(t, collector.errors)
+
+		require.NoError(t, prov.CloseStore("test"))
+		require.NoError(t, prov.Close())
+	})
+
+	t.Run("test data not found is returned as is and not counted as error", func(t *testing.T) {
+		collector := newMockCollector()
+		prov := NewProvider(mem.NewProvider(), collector)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		_, err = store.Get("missing")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		require.Equal(t, 1, collector.latencies["test/"+GetOperation])
+		require.Empty(t, collector.errors)
+	})
+
+	t.Run("test errors are counted and returned", func(t *testing.T) {
+		collector := newMockCollector()
+		errPut := errors.New("put error")
+		errGet := errors.New("get error")
+		errItr := errors.New("iterator error")
+		errDelete := errors.New("delete error")
+
+		prov := NewProvider(&mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+			Store:     map[string][]byte{},
+			ErrPut:    errPut,
+			ErrGet:    errGet,
+			ErrItr:    errItr,
+			ErrDelete: errDelete,
+		}}, collector)
+
+		store, err := prov.OpenStore("test")
+		require.NoError(t, err)
+
+		require.True(t, errors.Is(store.Put("k", []byte("v")), errPut))
+
+		_, err = store.Get("k")
+		require.True(t, errors.Is(err, errGet))
+
+		_, err = store.Has("k")
+		require.True(t, errors.Is(err, errGet))
+
+		require.True(t, errors.Is(store.Delete("k"), errDelete))
+
+		_, err = store.DeletePrefix("k")
+		require.True(t, errors.Is(err, errDelete))
+
+		require.True(t, errors.Is(store.Reset(), errDelete))
+
+		itr := store.Iterator("", storage.EndKeySuffix)
+		require.True(t, errors.Is(itr.Error(), errItr))
+		itr.Release()
+		itr.Release()
+
+		require.Equal(t, map[string]int{
+			"test/" + PutOperation:          1,
+			"test/" + GetOperation:          1,
+			"test/" + HasOperation:          1,
+			"test/" + IteratorOperation:     1,
+			"test/" + DeleteOperation:       1,
+			"test/" + DeletePrefixOperation: 1,
+			"test/" + ResetOperation:        1,
+		}, collector.errors)
+	})
+
+	t.Run("test open store error", func(t *testing.T) {
+		errOpen := errors.New("open error")
+		prov := NewProvider(&mockstorage.MockStoreProvider{ErrOpenStoreHandle: errOpen}, newMockCollector())
+
+		store, err := prov.OpenStore("test")
+		require.True(t, errors.Is(err, errOpen))
+		require.Nil(t, store)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	// Add as per the documentation - https://github.com/go-sql-driver/mysql
 	_ "github.com/go-sql-driver/mysql"
@@ -43,6 +44,7 @@ const (
 	failToCloseProviderErrMsg = "failed to close provider"
 	tablePrefix               = "t_"
 	sqlDBNotFound             = "no rows"
+	sqlDBDuplicateKey         = "Duplicate entry"
 	createDBQuery             = "CREATE DATABASE IF NOT EXISTS `%s`"
 	useDBQuery                = "USE `%s`"
 )
@@ -190,34 +192,164 @@ func (s *sqlDBStore) Put(k string, v []byte) error {
 		return errors.New("key and value are mandatory")
 	}
 
-	// Use query is used to select the created database without this DDL operations are not permitted
-	_, err := s.db.Exec(fmt.Sprintf(useDBQuery, s.dbName))
+	_, err := s.put(k, v, nil)
+
+	return err
+}
+
+// PutWithTTL stores the key and the record expiring after ttl.
+func (s *sqlDBStore) PutWithTTL(k string, v []byte, ttl time.Duration) error {
+	if k == "" || v == nil {
+		return errors.New("key and value are mandatory")
+	}
+
+	if ttl <= 0 {
+		return storage.ErrInvalidTTL
+	}
+
+	return s.Put(k, storage.NewExpiringValue(v, time.Now().Add(ttl)))
+}
+
+// PutIfVersion stores the key and the value if the version of the record is expectedVersion, the versions are
+// counters stored next to the values (see storage.NewVersionedValue) and bumped by each write of the record.
+// Concurrent creations of the record fail with storage.ErrConflict.
+func (s *sqlDBStore) PutIfVersion(k string, v []byte, expectedVersion string) (string, error) {
+	if k == "" || v == nil {
+		return "", errors.New("key and value are mandatory")
+	}
+
+	return s.put(k, v, func(version string) bool {
+		return version == expectedVersion
+	})
+}
+
+// put stores v as the value of the record with k key at the next version of the record and returns that version.
+// The row of the record is locked by a transaction while its version is read and bumped, the write is rejected with
+// storage.ErrConflict if check, when set, rejects the current version of the record (empty if the record is absent,
+// soft deleted or expired).
+func (s *sqlDBStore) put(k string, v []byte, check func(version string) bool) (string, error) {
+	tx, err := s.db.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to use db %s: %w", s.dbName, err)
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
+	// no-op once committed
+	defer tx.Rollback() // nolint: errcheck
+
+	version, current, exists, err := s.currentVersion(tx, k)
+	if err != nil {
+		return "", err
+	}
+
+	if check != nil && !check(version) {
+		return "", storage.ErrConflict
+	}
+
+	next, err := storage.NextVersion(current)
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.writeRow(tx, k, storage.NewVersionedValue(v, next), check == nil, exists); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return storage.FormatVersion(next), nil
+}
+
+// writeRow writes the value of the row with k key, the row is inserted unless it exists. An upsert overwrites the row
+// created concurrently, otherwise storage.ErrConflict is returned.
+func (s *sqlDBStore) writeRow(tx *sql.Tx, k string, value []byte, upsert, exists bool) error {
+	var err error
+
 	//nolint: gosec
-	// create upsert query to insert the record, checking whether the key is already mapped to a value in the store.
-	createStmt := "INSERT INTO `" + s.tableName + "` VALUES (?, ?) ON DUPLICATE KEY UPDATE value=?"
-	// executing the prepared insert statement
-	_, err = s.db.Exec(createStmt, k, v, v)
+	switch {
+	case upsert:
+		_, err = tx.Exec("INSERT INTO `"+s.tableName+"` VALUES (?, ?) ON DUPLICATE KEY UPDATE value=?", k, value, value)
+	case exists:
+		_, err = tx.Exec("UPDATE `"+s.tableName+"` SET `value` = ? WHERE `key` = ?", value, k)
+	default:
+		_, err = tx.Exec("INSERT INTO `"+s.tableName+"` VALUES (?, ?)", k, value)
+	}
+
 	if err != nil {
-		return fmt.Errorf("failed to insert key and value record into %s %w ", s.tableName, err)
+		if strings.Contains(err.Error(), sqlDBDuplicateKey) {
+			return storage.ErrConflict
+		}
+
+		return fmt.Errorf("failed to put key and value record into %s %w ", s.tableName, err)
 	}
 
 	return nil
 }
 
+// currentVersion locks the row of the record with k key and returns the version of the record, empty if the record
+// is absent, soft deleted or expired, along with the counter stored in the row. The returned flag tells whether the
+// row exists.
+func (s *sqlDBStore) currentVersion(tx *sql.Tx, k string) (string, uint64, bool, error) {
+	_, err := tx.Exec(fmt.Sprintf(useDBQuery, s.dbName))
+	if err != nil {
+		return "", 0, false, fmt.Errorf("failed to use db %s: %w", s.dbName, err)
+	}
+
+	var value []byte
+	//nolint: gosec
+	err = tx.QueryRow("SELECT `value` FROM `"+s.tableName+"` WHERE `key` = ? FOR UPDATE", k).Scan(&value)
+	if err != nil {
+		if strings.Contains(err.Error(), sqlDBNotFound) {
+			return "", 0, false, nil
+		}
+
+		return "", 0, false, fmt.Errorf("failed to get row %w", err)
+	}
+
+	value, counter := storage.ParseVersionedValue(value)
+	if !storage.IsLive(value, time.Now()) {
+		return "", counter, true, nil
+	}
+
+	return storage.FormatVersion(counter), counter, true, nil
+}
+
 // Get fetches the value based on key.
 func (s *sqlDBStore) Get(k string) ([]byte, error) {
+	value, deleted, err := s.GetIncludingDeleted(k)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return value, nil
+}
+
+// Has tells whether the record with k key exists, the value is read as it tells whether the record is soft deleted
+// or expired.
+func (s *sqlDBStore) Has(k string) (bool, error) {
+	_, err := s.Get(k)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// GetIncludingDeleted fetches the value based on key, including soft deleted records.
+func (s *sqlDBStore) GetIncludingDeleted(k string) ([]byte, bool, error) {
 	if k == "" {
-		return nil, storage.ErrKeyRequired
+		return nil, false, storage.ErrKeyRequired
 	}
 
 	// Use query is used to select the created database without this DDL operations are not permitted
 	_, err := s.db.Exec(fmt.Sprintf(useDBQuery, s.dbName))
 	if err != nil {
-		return nil, fmt.Errorf("failed to use db %s: %w", s.dbName, err)
+		return nil, false, fmt.Errorf("failed to use db %s: %w", s.dbName, err)
 	}
 
 	var value []byte
@@ -227,13 +359,39 @@ func (s *sqlDBStore) Get(k string) ([]byte, error) {
 		" WHERE `key` = ?", k).Scan(&value)
 	if err != nil {
 		if strings.Contains(err.Error(), sqlDBNotFound) {
-			return nil, storage.ErrDataNotFound
+			return nil, false, storage.ErrDataNotFound
 		}
 
-		return nil, fmt.Errorf("failed to get row %w", err)
+		return nil, false, fmt.Errorf("failed to get row %w", err)
 	}
 
-	return value, nil
+	value, _ = storage.ParseVersionedValue(value)
+
+	value, deleted := storage.ParseTombstone(value)
+	if deleted {
+		return value, true, nil
+	}
+
+	value, ok := storage.ParseUnexpiredValue(value, time.Now())
+	if !ok {
+		return nil, false, storage.ErrDataNotFound
+	}
+
+	return value, false, nil
+}
+
+// SoftDelete replaces the record with k key by its tombstone.
+func (s *sqlDBStore) SoftDelete(k string) error {
+	value, err := s.Get(k)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	return s.Put(k, storage.NewTombstone(value))
 }
 
 // Delete will delete record with k key.
@@ -259,24 +417,114 @@ func (s *sqlDBStore) Delete(k string) error {
 	return nil
 }
 
+// DeletePrefix deletes the rows in the prefix range in a transaction, including soft deleted and expired records
+// which aren't counted: the rows are locked and counted before being deleted with a single query.
+func (s *sqlDBStore) DeletePrefix(prefix string) (int, error) {
+	if prefix == "" {
+		return 0, errors.New("prefix is mandatory")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// no-op once committed
+	defer tx.Rollback() // nolint: errcheck
+
+	deleted, err := s.countLiveRows(tx, prefix, mapEndKey(prefix+storage.EndKeySuffix))
+	if err != nil {
+		return 0, err
+	}
+
+	//nolint: gosec
+	// delete query to delete the rows of the same range as the iterator
+	_, err = tx.Exec("DELETE FROM `"+s.tableName+"` WHERE `key` >= ? AND `key` < ?",
+		prefix, mapEndKey(prefix+storage.EndKeySuffix))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete rows %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// countLiveRows locks the rows with keys in the [startKey, endKey) range and returns the number of those which
+// are neither soft deleted nor expired.
+func (s *sqlDBStore) countLiveRows(tx *sql.Tx, startKey, endKey string) (int, error) {
+	// Use query is used to select the created database without this DDL operations are not permitted
+	_, err := tx.Exec(fmt.Sprintf(useDBQuery, s.dbName))
+	if err != nil {
+		return 0, fmt.Errorf("failed to use db %s: %w", s.dbName, err)
+	}
+
+	//nolint: gosec
+	rows, err := tx.Query("SELECT `value` FROM `"+s.tableName+"` WHERE `key` >= ? AND `key` < ? FOR UPDATE",
+		startKey, endKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query rows %w", err)
+	}
+
+	defer rows.Close() // nolint: errcheck
+
+	now := time.Now()
+
+	var live int
+
+	for rows.Next() {
+		var value []byte
+
+		if err = rows.Scan(&value); err != nil {
+			return 0, fmt.Errorf("failed to scan row %w", err)
+		}
+
+		if value, _ = storage.ParseVersionedValue(value); storage.IsLive(value, now) {
+			live++
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read rows %w", err)
+	}
+
+	return live, nil
+}
+
+// Reset deletes all the rows of the table, including soft deleted and expired records.
+func (s *sqlDBStore) Reset() error {
+	// Use query is used to select the created database without this DDL operations are not permitted
+	_, err := s.db.Exec(fmt.Sprintf(useDBQuery, s.dbName))
+	if err != nil {
+		return fmt.Errorf("failed to use db %s: %w", s.dbName, err)
+	}
+
+	//nolint: gosec
+	_, err = s.db.Exec("DELETE FROM `" + s.tableName + "`")
+	if err != nil {
+		return fmt.Errorf("failed to delete rows %w", err)
+	}
+
+	return nil
+}
+
 type sqlDBResultsIterator struct {
 	resultRows *sql.Rows
 	result     result
 	err        error
 }
 
+// Iterator returns iterator for the latest snapshot of the underlying db, soft deleted and expired records are skipped.
 func (s *sqlDBStore) Iterator(startKey, endKey string) storage.StoreIterator {
-	// reference : https://dev.mysql.com/doc/refman/8.0/en/fulltext-boolean.html
-	if strings.Contains(endKey, storage.EndKeySuffix) {
-		endKey = strings.ReplaceAll(endKey, storage.EndKeySuffix, "*")
-		endKey = strings.ReplaceAll(endKey, "_", `\_`)
-	}
+	endKey = mapEndKey(endKey)
 
 	// Use query is used to select the created database without this DDL operations are not permitted
 	_, err := s.db.Exec(fmt.Sprintf(useDBQuery, s.dbName))
 	if err != nil {
-		return &sqlDBResultsIterator{
-			err: fmt.Errorf("failed to use db %s: %w", s.dbName, err)}
+		return storage.WithTotalItems(&sqlDBResultsIterator{
+			err: fmt.Errorf("failed to use db %s: %w", s.dbName, err)})
 	}
 
 	//nolint:gosec
@@ -285,24 +533,51 @@ func (s *sqlDBStore) Iterator(startKey, endKey string) storage.StoreIterator {
 
 	resultRows, err := s.db.Query(queryStmt, startKey, endKey)
 	if err != nil {
-		return &sqlDBResultsIterator{
-			err: fmt.Errorf("failed to query rows %w", err)}
+		return storage.WithTotalItems(&sqlDBResultsIterator{
+			err: fmt.Errorf("failed to query rows %w", err)})
 	}
 
 	if err = resultRows.Err(); err != nil {
-		return &sqlDBResultsIterator{
-			err: fmt.Errorf("failed to get resulted rows %w", err)}
+		return storage.WithTotalItems(&sqlDBResultsIterator{
+			err: fmt.Errorf("failed to get resulted rows %w", err)})
+	}
+
+	return storage.SkipTombstones(storage.SkipExpired(storage.SkipVersions(&sqlDBResultsIterator{resultRows: resultRows}),
+		time.Now))
+}
+
+// IteratorWithFilter returns iterator for the latest snapshot of the underlying db skipping the records with keys
+// rejected by keyPredicate, soft deleted and expired records are skipped.
+func (s *sqlDBStore) IteratorWithFilter(startKey, endKey string,
+	keyPredicate func(key string) bool) storage.StoreIterator {
+	return storage.FilterKeys(s.Iterator(startKey, endKey), keyPredicate)
+}
+
+// mapEndKey maps the end key suffix of iterator ranges.
+func mapEndKey(endKey string) string {
+	// reference : https://dev.mysql.com/doc/refman/8.0/en/fulltext-boolean.html
+	if strings.Contains(endKey, storage.EndKeySuffix) {
+		endKey = strings.ReplaceAll(endKey, storage.EndKeySuffix, "*")
+		endKey = strings.ReplaceAll(endKey, "_", `\_`)
 	}
 
-	return &sqlDBResultsIterator{resultRows: resultRows}
+	return endKey
 }
 
 func (i *sqlDBResultsIterator) Next() bool {
-	fmt.Printf("resultRows %v\v", i.resultRows)
+	// resultRows is nil if the query failed
+	if i.resultRows == nil {
+		return false
+	}
+
 	return i.resultRows.Next()
 }
 
 func (i *sqlDBResultsIterator) Release() {
+	if i.resultRows == nil {
+		return
+	}
+
 	if err := i.resultRows.Close(); err != nil {
 		i.err = err
 	}
@@ -319,6 +319,54 @@ func TestSqlDBStore(t *testing.T) {
 	})
 }
 
+func TestSqlDBStore_PutIfVersion(t *testing.T) {
+	const commonKey = "did:example:1234"
+
+	prov, err := NewProvider(sqlStoreDBURL)
+	require.NoError(t, err)
+
+	store1, err := prov.OpenStore("store-put-if-version")
+	require.NoError(t, err)
+
+	require.NoError(t, store1.Delete(commonKey))
+
+	// create if absent
+	v1, err := store1.PutIfVersion(commonKey, []byte("value1"), "")
+	require.NoError(t, err)
+	require.NotEmpty(t, v1)
+
+	_, err = store1.PutIfVersion(commonKey, []byte("value2"), "")
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	v2, err := store1.PutIfVersion(commonKey, []byte("value2"), v1)
+	require.NoError(t, err)
+	require.NotEqual(t, v1, v2)
+
+	// conflicting CAS, with the stale version of a record put back to its previous value
+	v3, err := store1.PutIfVersion(commonKey, []byte("value1"), v2)
+	require.NoError(t, err)
+	require.NotEqual(t, v1, v3)
+
+	_, err = store1.PutIfVersion(commonKey, []byte("value3"), v1)
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	// Put changes the version
+	require.NoError(t, store1.Put(commonKey, []byte("value1")))
+
+	_, err = store1.PutIfVersion(commonKey, []byte("value3"), v3)
+	require.True(t, errors.Is(err, storage.ErrConflict))
+
+	doc, err := store1.Get(commonKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), doc)
+
+	// soft deleted records are absent
+	require.NoError(t, store1.SoftDelete(commonKey))
+
+	_, err = store1.PutIfVersion(commonKey, []byte("value3"), "")
+	require.NoError(t, err)
+}
+
 func TestCouchDBStore_Delete(t *testing.T) {
 	const commonKey = "did:example:1234"
 
@@ -362,6 +410,9 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 
 	var vals []string
 
+	require.NoError(t, itr.Error())
+	require.Equal(t, count, itr.TotalItems())
+
 	for itr.Next() {
 		if prefix != "" {
 			require.True(t, strings.HasPrefix(string(itr.Key()), prefix))
@@ -371,6 +422,7 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 	}
 
 	require.Len(t, vals, count)
+	require.Equal(t, count, itr.TotalItems())
 
 	itr.Release()
 	require.False(t, itr.Next())
@@ -378,4 +430,5 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 	require.Empty(t, itr.Value())
 	require.Error(t, itr.Error())
 	require.Contains(t, itr.Error().Error(), "sql: Rows are closed")
+	require.Zero(t, itr.TotalItems())
 }
@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrReadOnly is returned by the mutating methods of read-only stores.
+var ErrReadOnly = errors.New("store is read-only")
+
+// ReadOnly wraps store so that its records can be read but not written, Put, PutWithTTL, PutIfVersion, Delete,
+// DeletePrefix, Reset and SoftDelete return ErrReadOnly without reaching store.
+func ReadOnly(store Store) Store {
+	return &readOnlyStore{store: store}
+}
+
+type readOnlyStore struct {
+	store Store
+}
+
+// Put returns ErrReadOnly.
+func (s *readOnlyStore) Put(string, []byte) error {
+	return ErrReadOnly
+}
+
+// PutWithTTL returns ErrReadOnly.
+func (s *readOnlyStore) PutWithTTL(string, []byte, time.Duration) error {
+	return ErrReadOnly
+}
+
+// PutIfVersion returns ErrReadOnly.
+func (s *readOnlyStore) PutIfVersion(string, []byte, string) (string, error) {
+	return "", ErrReadOnly
+}
+
+// Get fetches the record based on key from the underlying store.
+func (s *readOnlyStore) Get(k string) ([]byte, error) {
+	return s.store.Get(k)
+}
+
+// Has tells whether the record with k key exists in the underlying store.
+func (s *readOnlyStore) Has(k string) (bool, error) {
+	return s.store.Has(k)
+}
+
+// Iterator returns an iterator of the underlying store.
+func (s *readOnlyStore) Iterator(startKey, endKey string) StoreIterator {
+	return s.store.Iterator(startKey, endKey)
+}
+
+// IteratorWithFilter returns a filtered iterator of the underlying store.
+func (s *readOnlyStore) IteratorWithFilter(startKey, endKey string, keyPredicate func(key string) bool) StoreIterator {
+	return s.store.IteratorWithFilter(startKey, endKey, keyPredicate)
+}
+
+// Delete returns ErrReadOnly.
+func (s *readOnlyStore) Delete(string) error {
+	return ErrReadOnly
+}
+
+// DeletePrefix returns ErrReadOnly.
+func (s *readOnlyStore) DeletePrefix(string) (int, error) {
+	return 0, ErrReadOnly
+}
+
+// Reset returns ErrReadOnly.
+func (s *readOnlyStore) Reset() error {
+	return ErrReadOnly
+}
+
+// SoftDelete returns ErrReadOnly.
+func (s *readOnlyStore) SoftDelete(string) error {
+	return ErrReadOnly
+}
+
+// GetIncludingDeleted fetches the record based on key from the underlying store, including soft deleted records.
+func (s *readOnlyStore) GetIncludingDeleted(k string) ([]byte, bool, error) {
+	return s.store.GetIncludingDeleted(k)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+func TestReadOnly(t *testing.T) {
+	store, err := mem.NewProvider().OpenStore("test")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("abc_1", []byte("value1")))
+	require.NoError(t, store.Put("abc_2", []byte("value2")))
+	require.NoError(t, store.SoftDelete("abc_2"))
+
+	readOnly := storage.ReadOnly(store)
+
+	t.Run("reads pass through", func(t *testing.T) {
+		v, err := readOnly.Get("abc_1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), v)
+
+		_, err = readOnly.Get("abc_2")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		exists, err := readOnly.Has("abc_1")
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		v, deleted, err := readOnly.GetIncludingDeleted("abc_2")
+		require.NoError(t, err)
+		require.True(t, deleted)
+		require.Equal(t, []byte("value2"), v)
+
+		itr := readOnly.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+		require.True(t, itr.Next())
+		require.Equal(t, []byte("abc_1"), itr.Key())
+		require.False(t, itr.Next())
+		itr.Release()
+	})
+
+	t.Run("writes fail", func(t *testing.T) {
+		require.True(t, errors.Is(readOnly.Put("abc_3", []byte("value3")), storage.ErrReadOnly))
+		require.True(t, errors.Is(readOnly.PutWithTTL("abc_3", []byte("value3"), time.Minute), storage.ErrReadOnly))
+		require.True(t, errors.Is(readOnly.Delete("abc_1"), storage.ErrReadOnly))
+		require.True(t, errors.Is(readOnly.SoftDelete("abc_1"), storage.ErrReadOnly))
+		require.True(t, errors.Is(readOnly.Reset(), storage.ErrReadOnly))
+
+		deleted, err := readOnly.DeletePrefix("abc_")
+		require.True(t, errors.Is(err, storage.ErrReadOnly))
+		require.Zero(t, deleted)
+
+		version, err := readOnly.PutIfVersion("abc_3", []byte("value3"), "")
+		require.True(t, errors.Is(err, storage.ErrReadOnly))
+		require.Empty(t, version)
+
+		// the underlying store is left untouched
+		_, err = store.Get("abc_3")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		v, err := store.Get("abc_1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), v)
+	})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sharded
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// package sharded offers a storage.Provider distributing stores across a set of backend storage.Provider
+// (eg: couchdb providers of several CouchDB nodes) for horizontal scaling.
+
+const errNoBackends = "sharded provider requires at least one backend provider"
+
+// HashFunc maps a store name to a hash, the store is routed to the backend at index hash % number of backends.
+type HashFunc func(name string) uint32
+
+// FNVHash is the default HashFunc, returning the 32-bit FNV-1a hash of the store name.
+func FNVHash(name string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name)) // hash.Hash Write never returns an error
+
+	return h.Sum32()
+}
+
+// Provider is a storage.Provider routing every store to one of its backend providers.
+// Routing only depends on the store name, the hash function and the number of backends: given the same backends
+// in the same order, a given store always lands on the same backend. Changing the backends list re-routes stores
+// and doesn't migrate their data.
+type Provider struct {
+	backends []storage.Provider
+	hash     HashFunc
+}
+
+// NewProvider returns a new Provider sharding stores across backends using hash (FNVHash if nil).
+func NewProvider(backends []storage.Provider, hash HashFunc) (*Provider, error) {
+	if len(backends) == 0 {
+		return nil, errors.New(errNoBackends)
+	}
+
+	if hash == nil {
+		hash = FNVHash
+	}
+
+	return &Provider{backends: backends, hash: hash}, nil
+}
+
+// OpenStore opens the store with given name on its backend provider.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	return p.backend(name).OpenStore(name)
+}
+
+// CloseStore closes the store with given name on its backend provider.
+func (p *Provider) CloseStore(name string) error {
+	return p.backend(name).CloseStore(name)
+}
+
+// Close closes all backend providers, all of them are closed even if some fail.
+func (p *Provider) Close() error {
+	var errs []error
+
+	for _, backend := range p.backends {
+		if e := backend.Close(); e != nil {
+			errs = append(errs, e)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close backends, %v", errs)
+	}
+
+	return nil
+}
+
+// backend returns the backend provider the store with given name is routed to.
+func (p *Provider) backend(name string) storage.Provider {
+	return p.backends[p.hash(name)%uint32(len(p.backends))]
+}
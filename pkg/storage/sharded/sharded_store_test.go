@@ -0,0 +1,159 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sharded
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/storage/mem"
+)
+
+// countingProvider records the stores opened/closed on the wrapped provider.
+type countingProvider struct {
+	storage.Provider
+	opened   map[string]int
+	closed   map[string]int
+	closeErr error
+	isClosed bool
+}
+
+func newCountingProvider() *countingProvider {
+	return &countingProvider{Provider: mem.NewProvider(), opened: map[string]int{}, closed: map[string]int{}}
+}
+
+func (p *countingProvider) OpenStore(name string) (storage.Store, error) {
+	p.opened[name]++
+
+	return p.Provider.OpenStore(name)
+}
+
+func (p *countingProvider) CloseStore(name string) error {
+	p.closed[name]++
+
+	return p.Provider.CloseStore(name)
+}
+
+func (p *countingProvider) Close() error {
+	p.isClosed = true
+
+	if p.closeErr != nil {
+		return p.closeErr
+	}
+
+	return p.Provider.Close()
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Run("test no backends", func(t *testing.T) {
+		prov, err := NewProvider(nil, nil)
+		require.EqualError(t, err, errNoBackends)
+		require.Nil(t, prov)
+	})
+
+	t.Run("test default hash", func(t *testing.T) {
+		prov, err := NewProvider([]storage.Provider{mem.NewProvider()}, nil)
+		require.NoError(t, err)
+		require.NotNil(t, prov.hash)
+	})
+}
+
+func TestProvider_Routing(t *testing.T) {
+	t.Run("test store routed by hash", func(t *testing.T) {
+		backends := []*countingProvider{newCountingProvider(), newCountingProvider(), newCountingProvider()}
+
+		prov, err := NewProvider([]storage.Provider{backends[0], backends[1], backends[2]},
+			func(name string) uint32 { return uint32(len(name)) })
+		require.NoError(t, err)
+
+		_, err = prov.OpenStore("abc")
+		require.NoError(t, err)
+
+		_, err = prov.OpenStore("abcd")
+		require.NoError(t, err)
+
+		require.Equal(t, 1, backends[0].opened["abc"])
+		require.Equal(t, 1, backends[1].opened["abcd"])
+		require.Empty(t, backends[2].opened)
+
+		require.NoError(t, prov.CloseStore("abcd"))
+		require.Equal(t, 1, backends[1].closed["abcd"])
+		require.Empty(t, backends[0].closed)
+	})
+
+	t.Run("test store always lands on the same backend", func(t *testing.T) {
+		backends := []*countingProvider{newCountingProvider(), newCountingProvider(), newCountingProvider()}
+
+		prov, err := NewProvider([]storage.Provider{backends[0], backends[1], backends[2]}, nil)
+		require.NoError(t, err)
+
+		for i := 0; i < 30; i++ {
+			name := fmt.Sprintf("store%d", i)
+
+			store, err := prov.OpenStore(name)
+			require.NoError(t, err)
+			require.NoError(t, store.Put("key", []byte(name)))
+
+			// reopening the store through a new provider over the same backends reads back the same data
+			other, err := NewProvider([]storage.Provider{backends[0], backends[1], backends[2]}, nil)
+			require.NoError(t, err)
+
+			store, err = other.OpenStore(name)
+			require.NoError(t, err)
+
+			v, err := store.Get("key")
+			require.NoError(t, err)
+			require.Equal(t, []byte(name), v)
+		}
+
+		var used int
+
+		for _, backend := range backends {
+			total := 0
+			for _, count := range backend.opened {
+				require.Equal(t, 2, count)
+				total++
+			}
+
+			if total > 0 {
+				used++
+			}
+		}
+
+		require.Greater(t, used, 1, "stores are expected to be spread across backends")
+	})
+}
+
+func TestProvider_Close(t *testing.T) {
+	t.Run("test close closes all backends", func(t *testing.T) {
+		backends := []*countingProvider{newCountingProvider(), newCountingProvider()}
+
+		prov, err := NewProvider([]storage.Provider{backends[0], backends[1]}, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, prov.Close())
+		require.True(t, backends[0].isClosed)
+		require.True(t, backends[1].isClosed)
+	})
+
+	t.Run("test close error doesn't prevent closing other backends", func(t *testing.T) {
+		backends := []*countingProvider{newCountingProvider(), newCountingProvider()}
+		backends[0].closeErr = errors.New("close error")
+
+		prov, err := NewProvider([]storage.Provider{backends[0], backends[1]}, nil)
+		require.NoError(t, err)
+
+		err = prov.Close()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "close error")
+		require.True(t, backends[1].isClosed)
+	})
+}
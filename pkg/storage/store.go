@@ -6,9 +6,14 @@ SPDX-License-Identifier: Apache-2.0
 
 package storage
 
-import "errors"
-
-// EndKeySuffix end key suffix.
+import (
+	"errors"
+	"time"
+)
+
+// EndKeySuffix suffixes the end key of an iterator range to include all the keys prefixed by the end key,
+// (eg: Iterator("abc_", "abc_"+EndKeySuffix) iterates all the keys starting with "abc_"). Without the suffix, the
+// end key itself is excluded from the range and keys are compared as is.
 const EndKeySuffix = "!!"
 
 // ErrDataNotFound is returned when data not found.
@@ -17,6 +22,21 @@ var ErrDataNotFound = errors.New("data not found")
 // ErrKeyRequired is returned when key is mandatory.
 var ErrKeyRequired = errors.New("key is mandatory")
 
+// ErrInvalidTTL is returned when the TTL of a record isn't positive.
+var ErrInvalidTTL = errors.New("ttl must be positive")
+
+// ErrConflict is returned when data was concurrently modified in the backend of the store.
+var ErrConflict = errors.New("data conflict")
+
+// ErrTimeout is returned when the backend of the store didn't respond in time.
+var ErrTimeout = errors.New("store operation timed out")
+
+// ErrStoreClosed is returned when the store is closed or its backend is unavailable.
+var ErrStoreClosed = errors.New("store is closed or unavailable")
+
+// ErrTransactionDone is returned when a transaction is used after being committed or discarded.
+var ErrTransactionDone = errors.New("transaction already committed or discarded")
+
 // Provider storage provider interface.
 type Provider interface {
 	// OpenStore opens a store with given name space and returns the handle
@@ -29,33 +49,106 @@ type Provider interface {
 	Close() error
 }
 
+// TransactionProvider is implemented by providers able to update the records of several of their stores together.
+type TransactionProvider interface {
+	// BeginTransaction starts a new transaction across the stores of the provider
+	BeginTransaction() (Transaction, error)
+}
+
+// Transaction buffers puts and deletes of records across the stores of a provider, nothing is written until Commit.
+// The atomicity and isolation guarantees of Commit depend on the provider.
+type Transaction interface {
+	// Put buffers storing the key and the record in the store of given name space
+	Put(store, k string, v []byte) error
+
+	// Delete buffers deleting the record with k key from the store of given name space
+	Delete(store, k string) error
+
+	// Commit writes the buffered puts and deletes, in the order they were buffered
+	Commit() error
+
+	// Discard drops the buffered puts and deletes, the transaction can't be used afterwards
+	Discard()
+}
+
 // Store is the storage interface.
 type Store interface {
 	// Put stores the key and the record
 	Put(k string, v []byte) error
 
+	// PutWithTTL stores the key and the record expiring after ttl, once expired
+	// the record is no longer returned by Get and Iterator
+	PutWithTTL(k string, v []byte, ttl time.Duration) error
+
+	// PutIfVersion stores the key and the record if the current version of the record
+	// is expectedVersion and returns the new version of the record, ErrConflict is
+	// returned otherwise. An empty expectedVersion stores the record only if absent
+	// (including expired and soft deleted records). Versions are opaque and compared
+	// for equality only, Put and PutWithTTL change the version of the record
+	PutIfVersion(k string, v []byte, expectedVersion string) (string, error)
+
 	// Get fetches the record based on key
 	Get(k string) ([]byte, error)
 
+	// Has tells whether the record with k key exists, as it would be returned by Get,
+	// a missing record isn't an error
+	Has(k string) (bool, error)
+
 	// Iterator returns an iterator for the latest snapshot of the
 	// underlying store
 	//
 	// Args:
 	//
 	// startKey: Start of the key range, include in the range.
-	// endKey: End of the key range, not include in the range.
+	// endKey: End of the key range, not include in the range, see EndKeySuffix.
 	//
 	// Returns:
 	//
-	// StoreIterator: iterator for result range
+	// StoreIterator: iterator for result range, never nil. An empty range (eg: a
+	// blank end key) isn't an error, the iterator is empty and its Error is nil
+	// until released. Failures are reported by Error.
 	Iterator(startKey, endKey string) StoreIterator
 
+	// IteratorWithFilter returns an iterator of the range iterated by Iterator(startKey, endKey)
+	// skipping the records with keys rejected by keyPredicate, the values of the skipped
+	// records aren't fetched
+	IteratorWithFilter(startKey, endKey string, keyPredicate func(key string) bool) StoreIterator
+
 	// Delete will delete a record with k key
 	Delete(k string) error
+
+	// DeletePrefix deletes, as Delete would, every record with a key in the range
+	// iterated by Iterator(prefix, prefix+EndKeySuffix) and returns the number of
+	// records deleted which Get would have returned: the soft deleted and expired
+	// records of the range may be deleted as well but aren't counted
+	DeletePrefix(prefix string) (int, error)
+
+	// Reset deletes every record of the store, including soft deleted records,
+	// the store remains open
+	Reset() error
+
+	// SoftDelete marks the record with k key as deleted, the record is no longer
+	// returned by Get and Iterator but remains readable with GetIncludingDeleted
+	SoftDelete(k string) error
+
+	// GetIncludingDeleted fetches the record based on key even if it was soft deleted,
+	// the returned flag tells whether the record is soft deleted
+	GetIncludingDeleted(k string) ([]byte, bool, error)
 }
 
 // StoreIterator is the iterator for the latest snapshot of the underlying store.
 type StoreIterator interface {
+	BaseIterator
+
+	// TotalItems returns the number of key/value pairs in the range of the
+	// iterator, including the pairs already iterated. The pairs not iterated
+	// yet may be read ahead to be counted. It returns 0 once released.
+	TotalItems() int
+}
+
+// BaseIterator is the key/value pairs iteration part of StoreIterator, implemented
+// as is by the iterators of most databases (eg: goleveldb), see WithTotalItems.
+type BaseIterator interface {
 	// Next moves the iterator to the next key/value pair.
 	// It returns false if the iterator is exhausted.
 	Next() bool
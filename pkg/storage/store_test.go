@@ -168,6 +168,42 @@ func TestStore(t *testing.T) {
 			verifyItr(t, itr, 0, "")
 		})
 
+		t.Run("Iterator with filter "+provider.Name, func(t *testing.T) {
+			t.Parallel()
+
+			store, err := provider.OpenStore(randomKey())
+			require.NoError(t, err)
+
+			for _, key := range []string{"abc_123", "abc_124", "abc_125", "abc_126", "dab_123"} {
+				require.NoError(t, store.Put(key, []byte("val-for-"+key)))
+			}
+
+			odd := func(key string) bool {
+				return (key[len(key)-1]-'0')%2 == 1
+			}
+
+			itr := store.IteratorWithFilter("abc_", "abc_"+storage.EndKeySuffix, odd)
+
+			for _, key := range []string{"abc_123", "abc_125"} {
+				require.True(t, itr.Next())
+				require.Equal(t, key, string(itr.Key()))
+				require.Equal(t, "val-for-"+key, string(itr.Value()))
+			}
+
+			require.False(t, itr.Next())
+			require.NoError(t, itr.Error())
+			itr.Release()
+
+			itr = store.IteratorWithFilter("abc_", "abc_"+storage.EndKeySuffix, odd)
+			verifyItr(t, itr, 2, "abc_")
+
+			itr = store.IteratorWithFilter("abc_", "abc_"+storage.EndKeySuffix, func(string) bool { return false })
+			verifyItr(t, itr, 0, "")
+
+			itr = store.IteratorWithFilter("abc_", "", odd)
+			verifyItr(t, itr, 0, "")
+		})
+
 		t.Run("Delete "+provider.Name, func(t *testing.T) {
 			t.Parallel()
 
@@ -204,6 +240,222 @@ func TestStore(t *testing.T) {
 			require.EqualError(t, err, storage.ErrDataNotFound.Error())
 			require.Empty(t, doc)
 		})
+
+		t.Run("Has "+provider.Name, func(t *testing.T) {
+			t.Parallel()
+
+			store, err := provider.OpenStore(randomKey())
+			require.NoError(t, err)
+
+			err = store.Put("abc_123", []byte("value1"))
+			require.NoError(t, err)
+
+			err = store.Put("abc_124", []byte(`{"key1":"value1"}`))
+			require.NoError(t, err)
+
+			for _, k := range []string{"abc_123", "abc_124"} {
+				exists, err := store.Has(k)
+				require.NoError(t, err)
+				require.True(t, exists)
+			}
+
+			exists, err := store.Has("abc_000")
+			require.NoError(t, err)
+			require.False(t, exists)
+
+			err = store.SoftDelete("abc_123")
+			require.NoError(t, err)
+
+			exists, err = store.Has("abc_123")
+			require.NoError(t, err)
+			require.False(t, exists)
+
+			_, err = store.Has("")
+			require.Error(t, err)
+		})
+
+		t.Run("Delete prefix "+provider.Name, func(t *testing.T) {
+			t.Parallel()
+
+			store, err := provider.OpenStore(randomKey())
+			require.NoError(t, err)
+
+			keys := []string{"abc_123", "abc_124", "abc_125", "abc_126", "jkl_123", "mno_123", "dab_123", "abc123"}
+
+			for _, key := range keys {
+				err = store.Put(key, []byte("value"))
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, store.SoftDelete("abc_126"))
+
+			// the soft deleted record isn't counted
+			deleted, err := store.DeletePrefix("abc_")
+			require.NoError(t, err)
+			require.Equal(t, 3, deleted)
+
+			itr := store.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+			verifyItr(t, itr, 0, "abc_")
+
+			// other keys remain
+			for _, key := range []string{"jkl_123", "mno_123", "dab_123", "abc123"} {
+				exists, errHas := store.Has(key)
+				require.NoError(t, errHas)
+				require.True(t, exists, key)
+			}
+
+			deleted, err = store.DeletePrefix("abc_")
+			require.NoError(t, err)
+			require.Zero(t, deleted)
+
+			_, err = store.DeletePrefix("")
+			require.Error(t, err)
+		})
+
+		t.Run("Reset "+provider.Name, func(t *testing.T) {
+			t.Parallel()
+
+			store, err := provider.OpenStore(randomKey())
+			require.NoError(t, err)
+
+			keys := []string{"abc_123", "abc_124", "jkl_123", "mno_123"}
+
+			for _, key := range keys {
+				err = store.Put(key, []byte("value"))
+				require.NoError(t, err)
+			}
+
+			err = store.Reset()
+			require.NoError(t, err)
+
+			itr := store.Iterator("", storage.EndKeySuffix)
+			verifyItr(t, itr, 0, "")
+
+			// the store remains open
+			err = store.Put("abc_123", []byte("value"))
+			require.NoError(t, err)
+
+			exists, err := store.Has("abc_123")
+			require.NoError(t, err)
+			require.True(t, exists)
+
+			err = store.Reset()
+			require.NoError(t, err)
+
+			exists, err = store.Has("abc_123")
+			require.NoError(t, err)
+			require.False(t, exists)
+		})
+
+		t.Run("Soft delete "+provider.Name, func(t *testing.T) {
+			t.Parallel()
+
+			store, err := provider.OpenStore(randomKey())
+			require.NoError(t, err)
+
+			values := map[string][]byte{
+				"abc_123": []byte("value1"),
+				"abc_124": []byte(`{"key1":"value1"}`),
+				"abc_125": []byte("value3"),
+			}
+
+			for k, v := range values {
+				err = store.Put(k, v)
+				require.NoError(t, err)
+			}
+
+			err = store.SoftDelete("abc_123")
+			require.NoError(t, err)
+
+			err = store.SoftDelete("abc_124")
+			require.NoError(t, err)
+
+			err = store.SoftDelete("abc_000")
+			require.NoError(t, err)
+
+			doc, err := store.Get("abc_123")
+			require.EqualError(t, err, storage.ErrDataNotFound.Error())
+			require.Empty(t, doc)
+
+			itr := store.Iterator("abc_", "abc_"+storage.EndKeySuffix)
+			verifyItr(t, itr, 1, "abc_")
+
+			for _, k := range []string{"abc_123", "abc_124"} {
+				doc, deleted, err := store.GetIncludingDeleted(k)
+				require.NoError(t, err)
+				require.True(t, deleted)
+				require.Equal(t, values[k], doc)
+			}
+
+			doc, deleted, err := store.GetIncludingDeleted("abc_125")
+			require.NoError(t, err)
+			require.False(t, deleted)
+			require.Equal(t, values["abc_125"], doc)
+
+			_, _, err = store.GetIncludingDeleted("abc_000")
+			require.EqualError(t, err, storage.ErrDataNotFound.Error())
+		})
+
+		t.Run("Put if version "+provider.Name, func(t *testing.T) {
+			t.Parallel()
+
+			store, err := provider.OpenStore(randomKey())
+			require.NoError(t, err)
+
+			const key = "did:example:123"
+
+			// create if absent
+			v1, err := store.PutIfVersion(key, []byte("value1"), "")
+			require.NoError(t, err)
+			require.NotEmpty(t, v1)
+
+			_, err = store.PutIfVersion(key, []byte("value2"), "")
+			require.True(t, errors.Is(err, storage.ErrConflict), err)
+
+			// successful CAS
+			v2, err := store.PutIfVersion(key, []byte(`{"key1":"value2"}`), v1)
+			require.NoError(t, err)
+			require.NotEqual(t, v1, v2)
+
+			// conflicting CAS, with a stale version
+			_, err = store.PutIfVersion(key, []byte("value3"), v1)
+			require.True(t, errors.Is(err, storage.ErrConflict), err)
+
+			doc, err := store.Get(key)
+			require.NoError(t, err)
+			require.Equal(t, []byte(`{"key1":"value2"}`), doc)
+
+			// conflicting CAS, with the stale version of a record put back to its previous value
+			_, err = store.PutIfVersion(key, []byte("value1"), v2)
+			require.NoError(t, err)
+
+			_, err = store.PutIfVersion(key, []byte("value3"), v1)
+			require.True(t, errors.Is(err, storage.ErrConflict), err)
+
+			// concurrent creations, only one succeeds
+			const creators = 5
+
+			results := make(chan error, creators)
+
+			for i := 0; i < creators; i++ {
+				go func(i int) {
+					_, errPut := store.PutIfVersion("did:example:456", []byte(fmt.Sprintf("value%d", i)), "")
+					results <- errPut
+				}(i)
+			}
+
+			var created int
+
+			for i := 0; i < creators; i++ {
+				if errPut := <-results; errPut == nil {
+					created++
+				} else {
+					require.True(t, errors.Is(errPut, storage.ErrConflict), errPut)
+				}
+			}
+
+			require.Equal(t, 1, created)
+		})
 	}
 }
 
@@ -212,6 +464,9 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 
 	var values []string
 
+	require.NoError(t, itr.Error())
+	require.Equal(t, count, itr.TotalItems())
+
 	for itr.Next() {
 		if prefix != "" {
 			require.True(t, strings.HasPrefix(string(itr.Key()), prefix))
@@ -220,12 +475,14 @@ func verifyItr(t *testing.T, itr storage.StoreIterator, count int, prefix string
 		values = append(values, string(itr.Value()))
 	}
 	require.Len(t, values, count)
+	require.Equal(t, count, itr.TotalItems())
 
 	itr.Release()
 	require.False(t, itr.Next())
 	require.Empty(t, itr.Key())
 	require.Empty(t, itr.Value())
 	require.Error(t, itr.Error())
+	require.Zero(t, itr.TotalItems())
 }
 
 func randomKey() string {
@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import "bytes"
+
+// tombstonePrefix marks the value of a soft deleted record, the original value follows the marker.
+var tombstonePrefix = []byte("\x00aries-tombstone\x00") //nolint:gochecknoglobals
+
+// NewTombstone returns the tombstone stored in place of a soft deleted record holding value v.
+// This is the default soft delete implementation for stores lacking native support of deleted records.
+func NewTombstone(v []byte) []byte {
+	tombstone := make([]byte, 0, len(tombstonePrefix)+len(v))
+	tombstone = append(tombstone, tombstonePrefix...)
+
+	return append(tombstone, v...)
+}
+
+// IsTombstone tells whether v is the tombstone of a soft deleted record.
+func IsTombstone(v []byte) bool {
+	return bytes.HasPrefix(v, tombstonePrefix)
+}
+
+// ParseTombstone returns the original value of a soft deleted record and true if v is a tombstone,
+// otherwise v is returned as is along with false.
+func ParseTombstone(v []byte) ([]byte, bool) {
+	if !IsTombstone(v) {
+		return v, false
+	}
+
+	return v[len(tombstonePrefix):], true
+}
+
+// SkipTombstones wraps itr to skip the tombstones of soft deleted records.
+func SkipTombstones(itr BaseIterator) StoreIterator {
+	if itr == nil {
+		return nil
+	}
+
+	return WithTotalItems(&tombstoneSkippingIterator{BaseIterator: itr})
+}
+
+type tombstoneSkippingIterator struct {
+	BaseIterator
+}
+
+// Next moves the iterator to the next key/value pair which isn't a tombstone.
+// It returns false if the iterator is exhausted.
+func (i *tombstoneSkippingIterator) Next() bool {
+	for i.BaseIterator.Next() {
+		if !IsTombstone(i.BaseIterator.Value()) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,31 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTombstone(t *testing.T) {
+	value := []byte("value")
+
+	tombstone := NewTombstone(value)
+	require.True(t, IsTombstone(tombstone))
+	require.False(t, IsTombstone(value))
+
+	v, deleted := ParseTombstone(tombstone)
+	require.True(t, deleted)
+	require.Equal(t, value, v)
+
+	v, deleted = ParseTombstone(value)
+	require.False(t, deleted)
+	require.Equal(t, value, v)
+
+	require.Nil(t, SkipTombstones(nil))
+}
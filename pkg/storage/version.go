@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// versionPrefix marks the value of a record stored along with its version, the version (a counter of the writes of
+// the record) and the stored value follow the marker.
+var versionPrefix = []byte("\x00aries-version\x00") //nolint:gochecknoglobals
+
+const versionLen = 8
+
+// NewVersionedValue returns the value stored in place of v for a record at version.
+// This is the default record versioning implementation for stores lacking native support of record versions
+// (eg: leveldb), the version is kept next to the value and compared by PutIfVersion.
+func NewVersionedValue(v []byte, version uint64) []byte {
+	value := make([]byte, len(versionPrefix)+versionLen, len(versionPrefix)+versionLen+len(v))
+	copy(value, versionPrefix)
+	binary.BigEndian.PutUint64(value[len(versionPrefix):], version)
+
+	return append(value, v...)
+}
+
+// ParseVersionedValue returns the stored value and the version of a record stored along with its version,
+// otherwise v is returned as is along with version 0.
+func ParseVersionedValue(v []byte) ([]byte, uint64) {
+	if len(v) < len(versionPrefix)+versionLen || !bytes.HasPrefix(v, versionPrefix) {
+		return v, 0
+	}
+
+	return v[len(versionPrefix)+versionLen:], binary.BigEndian.Uint64(v[len(versionPrefix):])
+}
+
+// NextVersion returns the version of a record written over a stored record at version current, the counter of the
+// record is bumped. A record written over an absent record (current 0) or over a record stored without version
+// starts from a random counter, so that a record deleted then put again doesn't get back a previous version.
+func NextVersion(current uint64) (uint64, error) {
+	if current != 0 && current+1 != 0 {
+		return current + 1, nil
+	}
+
+	counter := make([]byte, versionLen)
+
+	for {
+		if _, err := rand.Read(counter); err != nil {
+			return 0, fmt.Errorf("failed to generate record version: %w", err)
+		}
+
+		if version := binary.BigEndian.Uint64(counter); version != 0 {
+			return version, nil
+		}
+	}
+}
+
+// FormatVersion returns the opaque version of a record at version, as returned by PutIfVersion.
+func FormatVersion(version uint64) string {
+	return strconv.FormatUint(version, 10)
+}
+
+// SkipVersions wraps itr so that the values of the records are returned without their version.
+func SkipVersions(itr BaseIterator) StoreIterator {
+	if itr == nil {
+		return nil
+	}
+
+	return WithTotalItems(&versionSkippingIterator{BaseIterator: itr})
+}
+
+type versionSkippingIterator struct {
+	BaseIterator
+}
+
+// Value returns the value of the current key/value pair without its version, or nil if done.
+func (i *versionSkippingIterator) Value() []byte {
+	v := i.BaseIterator.Value()
+	if v == nil {
+		return nil
+	}
+
+	v, _ = ParseVersionedValue(v)
+
+	return v
+}
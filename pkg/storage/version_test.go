@@ -0,0 +1,49 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionedValue(t *testing.T) {
+	value := []byte("value")
+
+	v, version := ParseVersionedValue(NewVersionedValue(value, 42))
+	require.Equal(t, value, v)
+	require.Equal(t, uint64(42), version)
+
+	v, version = ParseVersionedValue(value)
+	require.Equal(t, value, v)
+	require.Zero(t, version)
+
+	require.Equal(t, "42", FormatVersion(42))
+
+	require.Nil(t, SkipVersions(nil))
+}
+
+func TestNextVersion(t *testing.T) {
+	next, err := NextVersion(42)
+	require.NoError(t, err)
+	require.Equal(t, uint64(43), next)
+
+	// new records start from a random counter
+	first, err := NextVersion(0)
+	require.NoError(t, err)
+	require.NotZero(t, first)
+
+	second, err := NextVersion(0)
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+
+	next, err = NextVersion(math.MaxUint64)
+	require.NoError(t, err)
+	require.NotZero(t, next)
+}
@@ -27,6 +27,7 @@ const (
 	invKeyPrefix        = "inv"
 	eventDataKeyPrefix  = "connevent"
 	didConnMapKeyPrefix = "didconn"
+	threadKeyPrefix     = "connthread"
 	// limitPattern with `~` at the end for lte of given prefix (less than or equal).
 	limitPattern    = "%s" + storage.EndKeySuffix
 	keySeparator    = "_"
@@ -59,6 +60,16 @@ type Record struct {
 	Namespace       string
 }
 
+// ThreadRecord correlates a message thread to the connection it was received on.
+type ThreadRecord struct {
+	ThreadID     string
+	ConnectionID string
+	// Protocol is the protocol of the thread, the message type without its message name.
+	Protocol string
+	// MessageType is the type of the last message received on the thread.
+	MessageType string
+}
+
 // NewLookup returns new connection lookup instance.
 // Lookup is read only connection store. It provides connection record related query features.
 func NewLookup(p provider) (*Lookup, error) {
@@ -190,6 +201,33 @@ func (c *Lookup) GetConnectionIDByDIDs(myDID, theirDID string) (string, error) {
 	return string(connectionIDBytes), nil
 }
 
+// GetThreadRecord returns the thread record of the given thread ID, storage.ErrDataNotFound is returned if no
+// message of the thread was received on a connection.
+func (c *Lookup) GetThreadRecord(threadID string) (*ThreadRecord, error) {
+	if threadID == "" {
+		return nil, fmt.Errorf(errMsgInvalidKey)
+	}
+
+	var rec ThreadRecord
+
+	err := getAndUnmarshal(getThreadKeyPrefix()(threadID), &rec, c.store)
+	if err != nil {
+		return nil, fmt.Errorf("get thread record : %w", err)
+	}
+
+	return &rec, nil
+}
+
+// GetConnectionByThread returns the record of the connection the messages of the given thread were received on.
+func (c *Lookup) GetConnectionByThread(threadID string) (*Record, error) {
+	thread, err := c.GetThreadRecord(threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetConnectionRecord(thread.ConnectionID)
+}
+
 // GetInvitation finds and parses stored invitation to target type.
 // TODO should avoid using target of type `interface{}` [Issue #1030].
 func (c *Lookup) GetInvitation(id string, target interface{}) error {
@@ -275,3 +313,10 @@ func CreateNamespaceKey(prefix, thID string) (string, error) {
 
 	return getNamespaceKeyPrefix(prefix)(key), nil
 }
+
+// getThreadKeyPrefix key prefix for saving thread records.
+func getThreadKeyPrefix() KeyPrefix {
+	return func(key ...string) string {
+		return fmt.Sprintf(keyPattern, threadKeyPrefix, strings.Join(key, keySeparator))
+	}
+}
@@ -11,6 +11,7 @@ package connection
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -380,6 +381,59 @@ func TestGetConnectionIDByDIDs(t *testing.T) {
 	})
 }
 
+func TestGetConnectionByThread(t *testing.T) {
+	const (
+		threadID = "thread-42"
+		msgType  = "https://didcomm.org/issue-credential/2.0/offer-credential"
+	)
+
+	recorder, err := NewRecorder(&protocol.MockProvider{})
+	require.NoError(t, err)
+
+	for _, connID := range []string{"conn-1", sampleConnID} {
+		require.NoError(t, recorder.SaveConnectionRecord(&Record{
+			ThreadID:     threadIDValue,
+			ConnectionID: connID,
+			State:        StateNameCompleted,
+			Namespace:    MyNSPrefix,
+		}))
+	}
+
+	require.NoError(t, recorder.SaveThreadRecord(&ThreadRecord{
+		ThreadID:     threadID,
+		ConnectionID: sampleConnID,
+		Protocol:     "https://didcomm.org/issue-credential/2.0",
+		MessageType:  msgType,
+	}))
+
+	t.Run("get connection by thread - success", func(t *testing.T) {
+		record, err := recorder.GetConnectionByThread(threadID)
+		require.NoError(t, err)
+		require.Equal(t, sampleConnID, record.ConnectionID)
+
+		thread, err := recorder.GetThreadRecord(threadID)
+		require.NoError(t, err)
+		require.Equal(t, "https://didcomm.org/issue-credential/2.0", thread.Protocol)
+		require.Equal(t, msgType, thread.MessageType)
+	})
+
+	t.Run("get connection by thread - unknown thread", func(t *testing.T) {
+		record, err := recorder.GetConnectionByThread("thread-unknown")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		require.Nil(t, record)
+
+		_, err = recorder.GetThreadRecord("")
+		require.EqualError(t, err, errMsgInvalidKey)
+	})
+
+	t.Run("save thread record - validation", func(t *testing.T) {
+		err := recorder.SaveThreadRecord(&ThreadRecord{ThreadID: threadID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cannot be empty")
+	})
+}
+
 // mockProvider for connection recorder
 type mockProvider struct {
 	protocolStateStoreError error
@@ -115,6 +115,16 @@ func (c *Recorder) SaveEvent(connectionID string, data []byte) error {
 	return c.protocolStateStore.Put(getEventDataKeyPrefix()(connectionID), data)
 }
 
+// SaveThreadRecord saves the given thread record in the permanent store, replacing the record of the same thread.
+func (c *Recorder) SaveThreadRecord(record *ThreadRecord) error {
+	if record.ThreadID == "" || record.ConnectionID == "" {
+		return fmt.Errorf("input parameters thid : %s and connectionId : %s cannot be empty",
+			record.ThreadID, record.ConnectionID)
+	}
+
+	return marshalAndSave(getThreadKeyPrefix()(record.ThreadID), record, c.store)
+}
+
 // SaveNamespaceThreadID saves given namespace, threadID and connection ID mapping in protocol state store.
 func (c *Recorder) SaveNamespaceThreadID(threadID, namespace, connectionID string) error {
 	if namespace != MyNSPrefix && namespace != TheirNSPrefix {
@@ -134,7 +144,8 @@ func (c *Recorder) SaveNamespaceThreadID(threadID, namespace, connectionID strin
 	return c.protocolStateStore.Put(getNamespaceKeyPrefix(prefix)(key), []byte(connectionID))
 }
 
-// RemoveConnection removes connection record from the store for given id.
+// RemoveConnection removes connection record from the store for given id, along with its states, event data and
+// thread mappings from the protocol state store.
 func (c *Recorder) RemoveConnection(connectionID string) error {
 	record, err := c.GetConnectionRecord(connectionID)
 	if err != nil {
@@ -152,6 +163,12 @@ func (c *Recorder) RemoveConnection(connectionID string) error {
 		return fmt.Errorf("remove records for different connections states error: %w", err)
 	}
 
+	// remove the event data so that pending accept requests can't resume the protocol
+	if err = c.protocolStateStore.Delete(getEventDataKeyPrefix()(connectionID)); err != nil {
+		return fmt.Errorf("unable to delete connection event data from the protocol state store: connectionid=%s "+
+			"err=%w", connectionID, err)
+	}
+
 	err = c.store.Delete(getConnectionKeyPrefix()(connectionID))
 	if err != nil {
 		return fmt.Errorf("unable to delete connection record from the store: connectionid=%s err=%w", connectionID, err)
@@ -204,24 +221,14 @@ func computeHash(bytes []byte) (string, error) {
 }
 
 func removeConnectionsForStates(c *Recorder, connectionID string) error {
-	itr := c.protocolStateStore.Iterator(getConnectionStateKeyPrefix()(
-		connectionID),
-		getConnectionStateKeyPrefix()(connectionID)+storage.EndKeySuffix,
-	)
-	defer itr.Release()
-
-	for itr.Next() {
-		key := string(itr.Key())
-		err := c.protocolStateStore.Delete(key)
-
-		if err != nil {
-			return fmt.Errorf(
-				"unable to delete connection state record from the protocol state store: key=%s connectionid=%s err=%w",
-				key, connectionID, err)
-		}
+	// the trailing separator keeps the states of connection IDs starting with connectionID
+	_, err := c.protocolStateStore.DeletePrefix(getConnectionStateKeyPrefix()(connectionID, ""))
+	if err != nil {
+		return fmt.Errorf("unable to delete connection state records from the protocol state store: "+
+			"connectionid=%s err=%w", connectionID, err)
 	}
 
-	return itr.Error()
+	return nil
 }
 
 func removeMappings(c *Recorder, record *Record) error {
@@ -230,5 +237,12 @@ func removeMappings(c *Recorder, record *Record) error {
 		return fmt.Errorf("compute hash: %w", err)
 	}
 
-	return c.store.Delete(getNamespaceKeyPrefix(record.Namespace)(key))
+	// the mappings are saved in the protocol state store while the connection is in progress
+	for _, store := range []storage.Store{c.protocolStateStore, c.store} {
+		if err := store.Delete(getNamespaceKeyPrefix(record.Namespace)(key)); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
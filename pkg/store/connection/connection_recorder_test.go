@@ -10,6 +10,7 @@
 package connection
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -146,12 +147,15 @@ func Test_RemoveConnectionRecordsForStates(t *testing.T) {
 		err = removeConnectionsForStates(recorder, record.ConnectionID)
 		require.NoError(t, err)
 	})
-	t.Run("test failed to iterate connection state records", func(t *testing.T) {
-		const errMsg = "get error"
-
+	t.Run("test states of other connections are kept", func(t *testing.T) {
 		store := &mockstorage.MockStore{
-			Store:  make(map[string][]byte),
-			ErrItr: fmt.Errorf(errMsg),
+			Store: make(map[string][]byte),
+		}
+
+		for _, connectionID := range []string{"conn-1", "conn-10"} {
+			err := marshalAndSave(getConnectionStateKeyPrefix()(connectionID, StateNameCompleted),
+				&Record{ConnectionID: connectionID, State: StateNameCompleted}, store)
+			require.NoError(t, err)
 		}
 
 		recorder, err := NewRecorder(&protocol.MockProvider{
@@ -160,9 +164,14 @@ func Test_RemoveConnectionRecordsForStates(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, recorder)
 
-		err = removeConnectionsForStates(recorder, "anyID")
-		require.Error(t, err)
-		require.Contains(t, err.Error(), errMsg)
+		err = removeConnectionsForStates(recorder, "conn-1")
+		require.NoError(t, err)
+
+		_, err = recorder.GetConnectionRecordAtState("conn-1", StateNameCompleted)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = recorder.GetConnectionRecordAtState("conn-10", StateNameCompleted)
+		require.NoError(t, err)
 	})
 	t.Run("test failed to delete connection state record from the store", func(t *testing.T) {
 		const errMsg = "get error"
@@ -581,32 +590,49 @@ func TestConnectionRecorder_RemoveConnection(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), errMsg)
 	})
-	t.Run("save and remove connection record - failed to iterate connection states records", func(t *testing.T) {
-		const errMsg = "get error"
-		recorder, err := NewRecorder(&protocol.MockProvider{
-			ProtocolStateStoreProvider: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
-				Store:  make(map[string][]byte),
-				ErrItr: fmt.Errorf(errMsg),
-			}),
-		})
-		require.NoError(t, err)
-		require.NotNil(t, recorder)
+	t.Run("save and remove in progress connection record - state, event and thread mapping removed",
+		func(t *testing.T) {
+			recorder, err := NewRecorder(&protocol.MockProvider{})
+			require.NoError(t, err)
+			require.NotNil(t, recorder)
+
+			record := &Record{
+				ThreadID:     threadIDValue,
+				ConnectionID: uuid.New().String(),
+				State:        stateNameInvited,
+				Namespace:    TheirNSPrefix,
+				MyDID:        "did:mydid:123",
+				TheirDID:     "did:theirdid:123",
+			}
+			require.NoError(t, recorder.SaveConnectionRecordWithMappings(record))
+			require.NoError(t, recorder.SaveEvent(record.ConnectionID, []byte(`{"event":"data"}`)))
+
+			nsThreadID, err := CreateNamespaceKey(TheirNSPrefix, threadIDValue)
+			require.NoError(t, err)
 
-		record := &Record{
-			ThreadID:     threadIDValue,
-			ConnectionID: uuid.New().String(),
-			State:        StateNameCompleted,
-			Namespace:    TheirNSPrefix,
-			MyDID:        "did:mydid:123",
-			TheirDID:     "did:theirdid:123",
-		}
-		err = recorder.SaveConnectionRecord(record)
-		require.NoError(t, err)
+			_, err = recorder.GetConnectionRecordByNSThreadID(nsThreadID)
+			require.NoError(t, err)
 
-		err = recorder.RemoveConnection(record.ConnectionID)
-		require.Error(t, err)
-		require.Contains(t, err.Error(), errMsg)
-	})
+			err = recorder.RemoveConnection(record.ConnectionID)
+			require.NoError(t, err)
+
+			_, err = recorder.GetConnectionRecord(record.ConnectionID)
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+			_, err = recorder.GetConnectionRecordAtState(record.ConnectionID, stateNameInvited)
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+			_, err = recorder.GetEvent(record.ConnectionID)
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+			_, err = recorder.GetConnectionRecordByNSThreadID(nsThreadID)
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+			// the connection can't be removed twice
+			err = recorder.RemoveConnection(record.ConnectionID)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		})
 	t.Run("save and remove connection record - failed to delete connection mapping record", func(t *testing.T) {
 		recorder, err := NewRecorder(&protocol.MockProvider{})
 		require.NoError(t, err)
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 
 package verifiable
 
+import "time"
+
 // Record model containing name, ID and other fields of interest.
 type Record struct {
 	Name      string   `json:"name,omitempty"`
@@ -13,6 +15,9 @@ type Record struct {
 	Context   []string `json:"context,omitempty"`
 	Type      []string `json:"type,omitempty"`
 	SubjectID string   `json:"subjectId,omitempty"`
+	Issuer    string   `json:"issuer,omitempty"`
+	// Expires is the expirationDate of the credential, nil if it does not expire.
+	Expires *time.Time `json:"expirationDate,omitempty"`
 	// MyDID and TheirDID contains information about participants who were involved in the process
 	// of issuing a credential or presentation.
 	MyDID    string `json:"my_did,omitempty"`
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -28,6 +29,13 @@ const (
 
 	// limitPattern for the iterator
 	limitPattern = "%s" + storage.EndKeySuffix
+
+	// IssuerTag tags the credential records by issuer ID.
+	IssuerTag = "issuer"
+	// TypeTag tags the credential records by credential type.
+	TypeTag = "type"
+	// SubjectTag tags the credential records by subject ID.
+	SubjectTag = "subject"
 )
 
 // Opt represents option function
@@ -61,6 +69,7 @@ type Store interface {
 	GetCredentialIDByName(name string) (string, error)
 	GetPresentationIDByName(name string) (string, error)
 	GetCredentials() ([]*Record, error)
+	GetCredentialsByTag(tag, value string) ([]*Record, error)
 	GetPresentations() ([]*Record, error)
 	RemoveCredentialByName(name string) error
 	RemovePresentationByName(name string) error
@@ -85,7 +94,7 @@ func New(ctx provider) (*StoreImplementation, error) {
 	return &StoreImplementation{store: store}, nil
 }
 
-// SaveCredential saves a verifiable credential.
+// SaveCredential saves a verifiable credential, credential names and IDs are unique.
 func (s *StoreImplementation) SaveCredential(name string, vc *verifiable.Credential, opts ...Opt) error {
 	if name == "" {
 		return errors.New("credential name is mandatory")
@@ -100,6 +109,17 @@ func (s *StoreImplementation) SaveCredential(name string, vc *verifiable.Credent
 		return errors.New("credential name already exists")
 	}
 
+	if vc.ID != "" {
+		exists, e := s.store.Has(vc.ID)
+		if e != nil {
+			return fmt.Errorf("check credential id : %w", e)
+		}
+
+		if exists {
+			return errors.New("credential id already exists")
+		}
+	}
+
 	vcBytes, err := vc.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("failed to marshal vc: %w", err)
@@ -129,6 +149,8 @@ func (s *StoreImplementation) SaveCredential(name string, vc *verifiable.Credent
 		MyDID:     o.MyDID,
 		TheirDID:  o.TheirDID,
 		SubjectID: getVCSubjectID(vc),
+		Issuer:    vc.Issuer.ID,
+		Expires:   getVCExpirationDate(vc),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to marshal record: %w", err)
@@ -258,6 +280,30 @@ func (s *StoreImplementation) GetCredentials() ([]*Record, error) {
 	return s.getAllRecords(credentialNameDataKey(""))
 }
 
+// GetCredentialsByTag retrieves the verifiable credential records tagged with value, tag is one of IssuerTag,
+// TypeTag and SubjectTag.
+func (s *StoreImplementation) GetCredentialsByTag(tag, value string) ([]*Record, error) {
+	hasTag, ok := recordTags[tag]
+	if !ok {
+		return nil, fmt.Errorf("unsupported credential tag: %s", tag)
+	}
+
+	records, err := s.GetCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	var tagged []*Record
+
+	for _, r := range records {
+		if hasTag(r, value) {
+			tagged = append(tagged, r)
+		}
+	}
+
+	return tagged, nil
+}
+
 // GetPresentations retrieves the verifiable presenations records containing name and fields of interest.
 func (s *StoreImplementation) GetPresentations() ([]*Record, error) {
 	return s.getAllRecords(presentationNameDataKey(""))
@@ -335,6 +381,25 @@ func (s *StoreImplementation) getAllRecords(searchKey string) ([]*Record, error)
 	return records, nil
 }
 
+// nolint:gochecknoglobals
+var recordTags = map[string]func(r *Record, value string) bool{
+	IssuerTag: func(r *Record, value string) bool {
+		return r.Issuer == value
+	},
+	TypeTag: func(r *Record, value string) bool {
+		for _, t := range r.Type {
+			if t == value {
+				return true
+			}
+		}
+
+		return false
+	},
+	SubjectTag: func(r *Record, value string) bool {
+		return r.SubjectID == value
+	},
+}
+
 func getVCSubjectID(vc *verifiable.Credential) string {
 	if subjectID, err := verifiable.SubjectID(vc.Subject); err == nil {
 		return subjectID
@@ -343,6 +408,16 @@ func getVCSubjectID(vc *verifiable.Credential) string {
 	return ""
 }
 
+func getVCExpirationDate(vc *verifiable.Credential) *time.Time {
+	if vc.Expired == nil {
+		return nil
+	}
+
+	expires := vc.Expired.Time
+
+	return &expires
+}
+
 func credentialNameDataKey(name string) string {
 	return fmt.Sprintf(credentialNameDataKeyPattern, name)
 }
@@ -11,9 +11,11 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
@@ -292,6 +294,22 @@ func TestSaveVC(t *testing.T) {
 		require.Contains(t, err.Error(), "credential name already exists")
 	})
 
+	t.Run("test save vc - id already exists", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: "vc1"}))
+
+		err = s.SaveCredential(sampleCredentialName+"2", &verifiable.Credential{ID: "vc1"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential id already exists")
+
+		records, err := s.GetCredentials()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+	})
+
 	t.Run("test save vc - with options", func(t *testing.T) {
 		const (
 			MyDID    = "MyDID"
@@ -469,6 +487,87 @@ func TestGetCredentials(t *testing.T) {
 	})
 }
 
+func TestGetCredentialsByTag(t *testing.T) {
+	s, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{
+		ID:      "http://example.edu/credentials/1872",
+		Types:   []string{"VerifiableCredential", "UniversityDegreeCredential"},
+		Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+	}))
+
+	expires := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, s.SaveCredential(sampleCredentialName+"2", &verifiable.Credential{
+		ID:      sampleCredentialID,
+		Types:   []string{"VerifiableCredential"},
+		Issuer:  verifiable.Issuer{ID: "did:example:other"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Expired: util.NewTime(expires),
+	}))
+
+	t.Run("test records hold the expiration date", func(t *testing.T) {
+		records, err := s.GetCredentialsByTag(IssuerTag, "did:example:other")
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, expires, records[0].Expires.UTC())
+
+		records, err = s.GetCredentialsByTag(IssuerTag, "did:example:76e12ec712ebc6f1c221ebfeb1f")
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Nil(t, records[0].Expires)
+	})
+
+	t.Run("test get credentials by tag", func(t *testing.T) {
+		for _, tc := range []struct {
+			tag, value string
+			names      []string
+		}{
+			{IssuerTag, "did:example:76e12ec712ebc6f1c221ebfeb1f", []string{sampleCredentialName}},
+			{IssuerTag, "did:example:other", []string{sampleCredentialName + "2"}},
+			{TypeTag, "UniversityDegreeCredential", []string{sampleCredentialName}},
+			{TypeTag, "VerifiableCredential", []string{sampleCredentialName, sampleCredentialName + "2"}},
+			{SubjectTag, "did:example:ebfeb1f712ebc6f1c276e12ec21", []string{sampleCredentialName, sampleCredentialName + "2"}},
+			{SubjectTag, "did:example:unknown", nil},
+		} {
+			records, err := s.GetCredentialsByTag(tc.tag, tc.value)
+			require.NoError(t, err)
+
+			var names []string
+			for _, r := range records {
+				names = append(names, r.Name)
+			}
+
+			require.ElementsMatch(t, tc.names, names, tc.tag+"="+tc.value)
+		}
+	})
+
+	t.Run("test get credentials by unsupported tag", func(t *testing.T) {
+		records, err := s.GetCredentialsByTag("name", sampleCredentialName)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported credential tag: name")
+		require.Nil(t, records)
+	})
+
+	t.Run("test get credentials by tag - store error", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(&mockstore.MockStore{
+				Store: map[string][]byte{credentialNameDataKey(sampleCredentialName): []byte("{")},
+			}),
+		})
+		require.NoError(t, err)
+
+		records, err := s.GetCredentialsByTag(IssuerTag, "did:example:other")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal record")
+		require.Nil(t, records)
+	})
+}
+
 func TestSaveVP(t *testing.T) {
 	t.Run("test save vp - success", func(t *testing.T) {
 		s, err := New(&mockprovider.Provider{
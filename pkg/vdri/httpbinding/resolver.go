@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
@@ -67,7 +68,18 @@ func (v *VDRI) resolveDID(uri string) ([]byte, error) {
 }
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
-func (v *VDRI) Read(didID string, _ ...vdriapi.ResolveOpts) (*did.Doc, error) {
+func (v *VDRI) Read(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+	result, err := v.ReadWithMetadata(didID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Doc, nil
+}
+
+// ReadWithMetadata reads the DID Document along with the resolver and method metadata returned
+// by the http binding resolver.
+func (v *VDRI) ReadWithMetadata(didID string, _ ...vdriapi.ResolveOpts) (*vdriapi.ResolveResult, error) {
 	reqURL, err := url.ParseRequestURI(v.endpointURL)
 	if err != nil {
 		return nil, fmt.Errorf("url parse request uri failed: %w", err)
@@ -100,5 +112,28 @@ func (v *VDRI) Read(didID string, _ ...vdriapi.ResolveOpts) (*did.Doc, error) {
 		}
 	}
 
-	return did.ParseDocument(didDocBytes)
+	doc, err := did.ParseDocument(didDocBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vdriapi.ResolveResult{Doc: doc, Metadata: resolutionMetadata(&r)}, nil
+}
+
+// resolutionMetadata maps did resolution resolver and method metadata to resolution metadata,
+// a DID is deactivated if any of those metadata flags it as such.
+func resolutionMetadata(r *didResolution) vdriapi.ResolutionMetadata {
+	metadata := vdriapi.ResolutionMetadata{
+		ContentType:    didLDJson,
+		Retrieved:      time.Now(),
+		MethodMetadata: r.MethodMetadata,
+	}
+
+	for _, m := range []map[string]interface{}{r.ResolverMetadata, r.MethodMetadata} {
+		if deactivated, ok := m["deactivated"].(bool); ok && deactivated {
+			metadata.Deactivated = true
+		}
+	}
+
+	return metadata
 }
@@ -187,6 +187,59 @@ func TestRead_DIDDoc(t *testing.T) {
 	})
 }
 
+func TestReadWithMetadata(t *testing.T) {
+	t.Run("test success return did resolution metadata", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Add("Content-type", "application/did+ld+json")
+			res.WriteHeader(http.StatusOK)
+			_, err := res.Write([]byte(didResolutionData))
+			require.NoError(t, err)
+		}))
+
+		defer func() { testServer.Close() }()
+
+		resolver, err := New(testServer.URL)
+		require.NoError(t, err)
+		result, err := resolver.ReadWithMetadata("did:example:334455")
+		require.NoError(t, err)
+		require.Equal(t, "did:peer:21tDAKCERh95uGgKbJNHYp", result.Doc.ID)
+		require.Equal(t, didLDJson, result.Metadata.ContentType)
+		require.False(t, result.Metadata.Deactivated)
+		require.Contains(t, result.Metadata.MethodMetadata, "nymResponse")
+	})
+
+	t.Run("test deactivated did", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Add("Content-type", "application/did+ld+json")
+			res.WriteHeader(http.StatusOK)
+			_, err := res.Write([]byte(`{"didDocument": ` + doc + `, "methodMetadata": {"deactivated": true}}`))
+			require.NoError(t, err)
+		}))
+
+		defer func() { testServer.Close() }()
+
+		resolver, err := New(testServer.URL)
+		require.NoError(t, err)
+		result, err := resolver.ReadWithMetadata("did:example:334455")
+		require.NoError(t, err)
+		require.True(t, result.Metadata.Deactivated)
+	})
+
+	t.Run("test did not found", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.WriteHeader(http.StatusNotFound)
+		}))
+
+		defer func() { testServer.Close() }()
+
+		resolver, err := New(testServer.URL)
+		require.NoError(t, err)
+		result, err := resolver.ReadWithMetadata("did:example:334455")
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
 func TestRead_DIDDocWithBasePath(t *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 		require.Equal(t, "/document/did:example:334455", req.URL.String())
@@ -70,6 +70,30 @@ func TestBuild(t *testing.T) {
 
 		assertDoc(t, doc)
 	})
+
+	t.Run("build with X25519 encryption key", func(t *testing.T) {
+		v := New()
+
+		pubKey := &vdriapi.PubKey{
+			Type:  ed25519VerificationKey2018,
+			Value: base58.Decode(pubKeyBase58),
+		}
+
+		doc, err := v.Build(pubKey, vdriapi.WithEncryptionKey(&vdriapi.PubKey{
+			ID:    "key-agreement",
+			Type:  vdriapi.X25519KeyAgreementKey2019,
+			Value: base58.Decode(keyAgreementBase58),
+		}))
+		require.NoError(t, err)
+		require.Len(t, doc.KeyAgreement, 1)
+
+		assertPubKey(t, &did.PublicKey{
+			ID:         didKey + "#key-agreement",
+			Type:       x25519KeyAgreementKey2019,
+			Controller: didKey,
+			Value:      base58.Decode(keyAgreementBase58),
+		}, &doc.KeyAgreement[0].PublicKey)
+	})
 }
 
 func assertDoc(t *testing.T, doc *did.Doc) {
@@ -27,6 +27,12 @@ func (v *VDRI) Accept(method string) bool {
 	return method == didMethod
 }
 
+// Methods describes the did:key method, DIDs are resolved and created, with a keyAgreement verification method if
+// requested.
+func (v *VDRI) Methods() []vdri.MethodCapabilities {
+	return []vdri.MethodCapabilities{{Method: didMethod, Resolve: true, Create: true, KeyAgreement: true}}
+}
+
 // Store saves a DID Document along with user key/signature.
 func (v *VDRI) Store(doc *did.Doc, by *[]vdri.ModifiedBy) error {
 	return nil
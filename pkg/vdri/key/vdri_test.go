@@ -34,6 +34,11 @@ func TestAccept(t *testing.T) {
 	})
 }
 
+func TestMethods(t *testing.T) {
+	require.Equal(t, []vdri.MethodCapabilities{{Method: "key", Resolve: true, Create: true, KeyAgreement: true}},
+		New().Methods())
+}
+
 func TestStore(t *testing.T) {
 	t.Run("test success", func(t *testing.T) {
 		v := New()
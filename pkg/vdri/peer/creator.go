@@ -26,7 +26,12 @@ func (v *VDRI) Build(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc,
 		opt(docOpts)
 	}
 
-	didDoc, err := build(pubKey, docOpts)
+	buildDoc := build
+	if docOpts.NumAlgo == numAlgo2 {
+		buildDoc = buildNumAlgo2
+	}
+
+	didDoc, err := buildDoc(pubKey, docOpts)
 	if err != nil {
 		return nil, fmt.Errorf("create peer DID : %w", err)
 	}
@@ -66,6 +71,14 @@ func build(pubKey *vdriapi.PubKey, docOpts *vdriapi.CreateDIDOpts) (*did.Doc, er
 		service = append(service, s)
 	}
 
+	for _, s := range docOpts.Services {
+		if s.Type == vdriapi.DIDCommServiceType && len(s.RecipientKeys) == 0 {
+			s.RecipientKeys = []string{base58.Encode(pubKey.Value)}
+		}
+
+		service = append(service, s)
+	}
+
 	// Created/Updated time
 	t := time.Now()
 
@@ -14,6 +14,7 @@ import (
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	api "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 )
@@ -58,6 +59,34 @@ func TestDIDCreator(t *testing.T) {
 		require.Equal(t, routingKeys, didDoc.Service[0].RoutingKeys)
 	})
 
+	t.Run("test services", func(t *testing.T) {
+		c, err := New(&storage.MockStoreProvider{})
+		require.NoError(t, err)
+		require.NotNil(t, c)
+
+		signingKey := getSigningKey()
+		didDoc, err := c.Build(
+			signingKey,
+			api.WithServiceEndpoint("request-endpoint"),
+			api.WithServiceType("request-type"),
+			api.WithServices([]did.Service{
+				{ID: "#didcomm", Type: api.DIDCommServiceType, ServiceEndpoint: "https://example.com/didcomm"},
+				{ID: "#hub", Type: "hub", ServiceEndpoint: "https://example.com/hub"},
+			}),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, didDoc)
+
+		// services are added after the service of the service type, DIDComm services get the signing key as
+		// recipient key
+		require.Len(t, didDoc.Service, 3)
+		require.Equal(t, "request-type", didDoc.Service[0].Type)
+		require.Equal(t, "https://example.com/didcomm", didDoc.Service[1].ServiceEndpoint)
+		require.Equal(t, []string{base58.Encode(signingKey.Value)}, didDoc.Service[1].RecipientKeys)
+		require.Equal(t, "https://example.com/hub", didDoc.Service[2].ServiceEndpoint)
+		require.Empty(t, didDoc.Service[2].RecipientKeys)
+	})
+
 	t.Run("test accept", func(t *testing.T) {
 		c, err := New(&storage.MockStoreProvider{})
 		require.NoError(t, err)
@@ -68,6 +97,8 @@ func TestDIDCreator(t *testing.T) {
 
 		accepted = c.Accept("peer")
 		require.True(t, accepted)
+
+		require.Equal(t, []api.MethodCapabilities{{Method: "peer", Resolve: true, Create: true}}, c.Methods())
 	})
 }
 
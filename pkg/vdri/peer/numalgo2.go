@@ -0,0 +1,293 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/fingerprint"
+)
+
+// did:peer numalgo 2 DIDs encode their keys and services, the DID doc is reconstructed from the DID alone.
+// Reference: https://identity.foundation/peer-did-method-spec/#generation-method
+
+const (
+	numAlgo2       = 2
+	numAlgo2Prefix = peerPrefix + "2"
+
+	x25519KeyAgreementKey2019 = "X25519KeyAgreementKey2019"
+
+	// source: https://github.com/multiformats/multicodec/blob/master/table.csv.
+	ed25519PubCode = 0xed
+	x25519PubCode  = 0xec
+
+	purposeService = 'S'
+
+	didCommMessagingType = "DIDCommMessaging"
+	acceptProperty       = "accept"
+)
+
+// nolint:gochecknoglobals
+var (
+	purposeCodes = map[did.VerificationRelationship]byte{
+		did.AssertionMethod:      'A',
+		did.KeyAgreement:         'E',
+		did.Authentication:       'V',
+		did.CapabilityInvocation: 'I',
+		did.CapabilityDelegation: 'D',
+	}
+
+	keyCodes = map[string]uint64{
+		ed25519VerificationKey2018: ed25519PubCode,
+		x25519KeyAgreementKey2019:  x25519PubCode,
+	}
+
+	serviceTypeAbbreviations = map[string]string{
+		didCommMessagingType: "dm",
+	}
+)
+
+// numAlgo2Key is a key encoded in a numalgo 2 DID along with its purpose.
+type numAlgo2Key struct {
+	purpose did.VerificationRelationship
+	key     *vdriapi.PubKey
+}
+
+// encodedService is the abbreviated service encoded in numalgo 2 DIDs, fields are in the order of the spec.
+type encodedService struct {
+	Type            string   `json:"t"`
+	ServiceEndpoint string   `json:"s"`
+	RoutingKeys     []string `json:"r,omitempty"`
+	Accept          []string `json:"a,omitempty"`
+}
+
+// buildNumAlgo2 builds the numalgo 2 DID doc of pubKey, used for authentication, along with the encryption key and
+// the services of docOpts. The same keys and options always build the same doc.
+func buildNumAlgo2(pubKey *vdriapi.PubKey, docOpts *vdriapi.CreateDIDOpts) (*did.Doc, error) {
+	keys := []numAlgo2Key{{purpose: did.Authentication, key: pubKey}}
+
+	if docOpts.EncryptionKey != nil {
+		keys = append(keys, numAlgo2Key{purpose: did.KeyAgreement, key: docOpts.EncryptionKey})
+	}
+
+	var services []did.Service
+
+	if docOpts.ServiceType != "" {
+		services = append(services, did.Service{
+			Type:            docOpts.ServiceType,
+			ServiceEndpoint: docOpts.ServiceEndpoint,
+			RoutingKeys:     docOpts.RoutingKeys,
+		})
+	}
+
+	services = append(services, docOpts.Services...)
+
+	didID, err := createDIDNumAlgo2(keys, services)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveDIDNumAlgo2(didID)
+}
+
+// createDIDNumAlgo2 creates the numalgo 2 DID encoding keys and services, in that order.
+// For example: did:peer:2.Ez6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc.Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22...
+func createDIDNumAlgo2(keys []numAlgo2Key, services []did.Service) (string, error) {
+	elements := []string{numAlgo2Prefix}
+
+	for _, k := range keys {
+		purpose, ok := purposeCodes[k.purpose]
+		if !ok {
+			return "", fmt.Errorf("not supported key purpose: %d", k.purpose)
+		}
+
+		code, ok := keyCodes[k.key.Type]
+		if !ok {
+			return "", fmt.Errorf("not supported public key type: %s", k.key.Type)
+		}
+
+		elements = append(elements, string(purpose)+fingerprint.KeyFingerprint(code, k.key.Value))
+	}
+
+	for i := range services {
+		encoded, err := encodeService(&services[i])
+		if err != nil {
+			return "", err
+		}
+
+		elements = append(elements, string(purposeService)+encoded)
+	}
+
+	return strings.Join(elements, "."), nil
+}
+
+// resolveDIDNumAlgo2 reconstructs the DID doc of the numalgo 2 DID didID. Keys are given the #key-N IDs and services
+// the #service, #service-N IDs in their order of appearance in the DID.
+func resolveDIDNumAlgo2(didID string) (*did.Doc, error) {
+	if !strings.HasPrefix(didID, numAlgo2Prefix+".") {
+		return nil, fmt.Errorf("not a did:peer numalgo 2 DID: %s", didID)
+	}
+
+	doc := did.BuildDoc()
+	doc.ID = didID
+
+	for _, element := range strings.Split(strings.TrimPrefix(didID, numAlgo2Prefix+"."), ".") {
+		if len(element) < 2 { // nolint:gomnd
+			return nil, fmt.Errorf("invalid did:peer numalgo 2 element: '%s'", element)
+		}
+
+		if element[0] == purposeService {
+			s, err := decodeService(element[1:])
+			if err != nil {
+				return nil, err
+			}
+
+			s.ID = "#service"
+			if len(doc.Service) > 0 {
+				s.ID = fmt.Sprintf("#service-%d", len(doc.Service))
+			}
+
+			doc.Service = append(doc.Service, *s)
+
+			continue
+		}
+
+		if err := addNumAlgo2Key(doc, element[0], element[1:]); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(doc.PublicKey) == 0 {
+		return nil, errors.New("the did:peer numalgo 2 DID must include public keys")
+	}
+
+	// DIDComm services carry the key used for authentication as recipient key
+	for i := range doc.Service {
+		if doc.Service[i].Type == vdriapi.DIDCommServiceType && len(doc.Authentication) > 0 {
+			doc.Service[i].RecipientKeys = []string{base58.Encode(doc.Authentication[0].PublicKey.Value)}
+		}
+	}
+
+	return doc, nil
+}
+
+func addNumAlgo2Key(doc *did.Doc, purpose byte, multibaseKey string) error {
+	keyType, value, err := decodeKey(multibaseKey)
+	if err != nil {
+		return err
+	}
+
+	pk := did.NewPublicKeyFromBytes(fmt.Sprintf("#key-%d", len(doc.PublicKey)+1), keyType, doc.ID, value)
+	doc.PublicKey = append(doc.PublicKey, *pk)
+
+	switch purpose {
+	case purposeCodes[did.AssertionMethod]:
+		doc.AssertionMethod = append(doc.AssertionMethod,
+			*did.NewReferencedVerificationMethod(pk, did.AssertionMethod, true))
+	case purposeCodes[did.KeyAgreement]:
+		doc.KeyAgreement = append(doc.KeyAgreement, *did.NewReferencedVerificationMethod(pk, did.KeyAgreement, true))
+	case purposeCodes[did.Authentication]:
+		doc.Authentication = append(doc.Authentication,
+			*did.NewReferencedVerificationMethod(pk, did.Authentication, true))
+	case purposeCodes[did.CapabilityInvocation]:
+		doc.CapabilityInvocation = append(doc.CapabilityInvocation,
+			*did.NewReferencedVerificationMethod(pk, did.CapabilityInvocation, true))
+	case purposeCodes[did.CapabilityDelegation]:
+		doc.CapabilityDelegation = append(doc.CapabilityDelegation,
+			*did.NewReferencedVerificationMethod(pk, did.CapabilityDelegation, true))
+	default:
+		return fmt.Errorf("not supported did:peer numalgo 2 purpose: '%c'", purpose)
+	}
+
+	return nil
+}
+
+// decodeKey decodes the base58 multibase encoded multicodec key into its type and raw value.
+func decodeKey(multibaseKey string) (string, []byte, error) {
+	if multibaseKey[0] != byte(transform) {
+		return "", nil, fmt.Errorf("not supported key multibase encoding: '%c'", multibaseKey[0])
+	}
+
+	mc := base58.Decode(multibaseKey[1:])
+
+	code, n := binary.Uvarint(mc)
+	if n <= 0 {
+		return "", nil, fmt.Errorf("invalid multicodec key: %s", multibaseKey)
+	}
+
+	for keyType, keyCode := range keyCodes {
+		if keyCode == code {
+			return keyType, mc[n:], nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("not supported public key (multicodec code: %#x)", code)
+}
+
+func encodeService(s *did.Service) (string, error) {
+	encoded := &encodedService{
+		Type:            s.Type,
+		ServiceEndpoint: s.ServiceEndpoint,
+		RoutingKeys:     s.RoutingKeys,
+	}
+
+	if abbreviation, ok := serviceTypeAbbreviations[s.Type]; ok {
+		encoded.Type = abbreviation
+	}
+
+	if accept, ok := s.Properties[acceptProperty].([]string); ok {
+		encoded.Accept = accept
+	}
+
+	bytes, err := json.Marshal(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode service: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func decodeService(encodedValue string) (*did.Service, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encodedValue, "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode service: %w", err)
+	}
+
+	encoded := &encodedService{}
+
+	err = json.Unmarshal(bytes, encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode service: %w", err)
+	}
+
+	s := &did.Service{
+		Type:            encoded.Type,
+		ServiceEndpoint: encoded.ServiceEndpoint,
+		RoutingKeys:     encoded.RoutingKeys,
+	}
+
+	for serviceType, abbreviation := range serviceTypeAbbreviations {
+		if encoded.Type == abbreviation {
+			s.Type = serviceType
+		}
+	}
+
+	if len(encoded.Accept) > 0 {
+		s.Properties = map[string]interface{}{acceptProperty: encoded.Accept}
+	}
+
+	return s, nil
+}
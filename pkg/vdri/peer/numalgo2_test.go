@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	api "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/fingerprint"
+)
+
+// spec test vector: https://identity.foundation/peer-did-method-spec/#generation-method
+const (
+	specNumAlgo2DID = "did:peer:2" +
+		".Ez6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc" +
+		".Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V" +
+		".Vz6MkgoLTnTypo3tDRwCkZXSccTPHRLhF4ZnjhueYAFpEX6vg" +
+		".SeyJ0IjoiZG0iLCJzIjoiaHR0cHM6Ly9leGFtcGxlLmNvbS9lbmRwb2ludCIsInIiOlsiZGlkOmV4YW1wbGU6c29tZW1lZGlhdG9yI3N" +
+		"vbWVrZXkiXSwiYSI6WyJkaWRjb21tL3YyIiwiZGlkY29tbS9haXAyO2Vudj1yZmM1ODciXX0"
+	specKeyAgreementKey = "z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc"
+	specAuthKey1        = "z6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V"
+	specAuthKey2        = "z6MkgoLTnTypo3tDRwCkZXSccTPHRLhF4ZnjhueYAFpEX6vg"
+)
+
+func TestResolveDIDNumAlgo2(t *testing.T) {
+	t.Run("test spec vector", func(t *testing.T) {
+		doc, err := resolveDIDNumAlgo2(specNumAlgo2DID)
+		require.NoError(t, err)
+
+		require.Equal(t, specNumAlgo2DID, doc.ID)
+		require.Equal(t, []string{did.Context}, doc.Context)
+		require.Len(t, doc.PublicKey, 3)
+
+		for i, pk := range doc.PublicKey {
+			require.Equal(t, []string{"#key-1", "#key-2", "#key-3"}[i], pk.ID)
+			require.Equal(t, specNumAlgo2DID, pk.Controller)
+		}
+
+		require.Equal(t, x25519KeyAgreementKey2019, doc.PublicKey[0].Type)
+		require.Equal(t, ed25519VerificationKey2018, doc.PublicKey[1].Type)
+		require.Equal(t, ed25519VerificationKey2018, doc.PublicKey[2].Type)
+		require.Equal(t, base58.Decode(specAuthKey1[1:])[2:], doc.PublicKey[1].Value)
+
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, "#key-1", doc.KeyAgreement[0].PublicKey.ID)
+		require.Len(t, doc.Authentication, 2)
+		require.Equal(t, "#key-2", doc.Authentication[0].PublicKey.ID)
+		require.Equal(t, "#key-3", doc.Authentication[1].PublicKey.ID)
+
+		require.Equal(t, []did.Service{{
+			ID:              "#service",
+			Type:            didCommMessagingType,
+			ServiceEndpoint: "https://example.com/endpoint",
+			RoutingKeys:     []string{"did:example:somemediator#somekey"},
+			Properties:      map[string]interface{}{acceptProperty: []string{"didcomm/v2", "didcomm/aip2;env=rfc587"}},
+		}}, doc.Service)
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		_, err = did.ParseDocument(docBytes)
+		require.NoError(t, err)
+	})
+
+	t.Run("test spec vector encoding", func(t *testing.T) {
+		doc, err := resolveDIDNumAlgo2(specNumAlgo2DID)
+		require.NoError(t, err)
+
+		var keys []numAlgo2Key
+
+		for _, vm := range append(doc.KeyAgreement, doc.Authentication...) {
+			keys = append(keys, numAlgo2Key{
+				purpose: vm.Relationship,
+				key:     &api.PubKey{Type: vm.PublicKey.Type, Value: vm.PublicKey.Value},
+			})
+		}
+
+		didID, err := createDIDNumAlgo2(keys, doc.Service)
+		require.NoError(t, err)
+		require.Equal(t, specNumAlgo2DID, didID)
+	})
+
+	t.Run("test all purposes and service IDs", func(t *testing.T) {
+		didID := "did:peer:2.A" + specAuthKey1 + ".I" + specAuthKey1 + ".D" + specAuthKey2 +
+			".SeyJ0IjoiZG0iLCJzIjoiaHR0cHM6Ly9leGFtcGxlLmNvbS9lbmRwb2ludCJ9" +
+			".SeyJ0IjoiZG0iLCJzIjoiaHR0cHM6Ly9leGFtcGxlLmNvbS9lbmRwb2ludCJ9"
+
+		doc, err := resolveDIDNumAlgo2(didID)
+		require.NoError(t, err)
+		require.Len(t, doc.PublicKey, 3)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.CapabilityInvocation, 1)
+		require.Len(t, doc.CapabilityDelegation, 1)
+		require.Empty(t, doc.Authentication)
+		require.Len(t, doc.Service, 2)
+		require.Equal(t, "#service", doc.Service[0].ID)
+		require.Equal(t, "#service-1", doc.Service[1].ID)
+	})
+
+	t.Run("test invalid DIDs", func(t *testing.T) {
+		for _, didID := range []string{
+			"did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa",
+			"did:peer:2",
+			"did:peer:2.V",
+			"did:peer:2.X" + specAuthKey1,
+			"did:peer:2.Vm" + specAuthKey1[1:],
+			"did:peer:2.Vz" + base58.Encode([]byte{0x12, 0x20, 0x01}),
+			"did:peer:2.V" + specAuthKey1 + ".S!",
+			"did:peer:2.SeyJ0IjoiZG0iLCJzIjoiaHR0cHM6Ly9leGFtcGxlLmNvbS9lbmRwb2ludCJ9",
+		} {
+			_, err := resolveDIDNumAlgo2(didID)
+			require.Error(t, err, didID)
+		}
+	})
+}
+
+func TestBuildNumAlgo2(t *testing.T) {
+	pubKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	signingKey := &api.PubKey{ID: "#key", Type: keyType, Value: pubKey}
+	encryptionKey := &api.PubKey{Type: x25519KeyAgreementKey2019, Value: base58.Decode(specKeyAgreementKey[1:])[2:]}
+
+	v, err := New(storage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	t.Run("test same key material builds same DID", func(t *testing.T) {
+		opts := []api.DocOpts{
+			api.WithNumAlgo(numAlgo2),
+			api.WithServiceType(api.DIDCommServiceType),
+			api.WithServiceEndpoint("https://example.com/endpoint"),
+			api.WithRoutingKeys([]string{"did:example:somemediator#somekey"}),
+			api.WithEncryptionKey(encryptionKey),
+		}
+
+		doc1, err := v.Build(signingKey, opts...)
+		require.NoError(t, err)
+
+		doc2, err := v.Build(signingKey, opts...)
+		require.NoError(t, err)
+		require.Equal(t, doc1, doc2)
+		require.Regexp(t, `^did:peer:2\.Vz6Mk[^.]+\.Ez6LS[^.]+\.S[^.]+$`, doc1.ID)
+
+		require.Len(t, doc1.Authentication, 1)
+		require.Equal(t, []byte(pubKey), doc1.Authentication[0].PublicKey.Value)
+		require.Len(t, doc1.KeyAgreement, 1)
+		require.Len(t, doc1.Service, 1)
+		require.Equal(t, []string{base58.Encode(pubKey)}, doc1.Service[0].RecipientKeys)
+
+		// resolved from the DID without storage
+		doc, err := v.Read(doc1.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc1, doc)
+	})
+
+	t.Run("test without service", func(t *testing.T) {
+		doc, err := v.Build(signingKey, api.WithNumAlgo(numAlgo2))
+		require.NoError(t, err)
+		require.Empty(t, doc.Service)
+		require.Equal(t, "did:peer:2.V"+fingerprint.KeyFingerprint(ed25519PubCode, pubKey), doc.ID)
+	})
+
+	t.Run("test not supported key type", func(t *testing.T) {
+		_, err := v.Build(&api.PubKey{Type: "unknown", Value: pubKey}, api.WithNumAlgo(numAlgo2))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not supported public key type")
+	})
+
+	t.Run("test read invalid numalgo 2 DID", func(t *testing.T) {
+		_, err := v.Read("did:peer:2.V")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve did:peer numalgo 2 DID failed")
+	})
+}
@@ -8,6 +8,7 @@ package peer
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
@@ -15,6 +16,16 @@ import (
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
 func (v *VDRI) Read(didID string, _ ...vdriapi.ResolveOpts) (*did.Doc, error) {
+	// numalgo 2 docs are reconstructed from the DID
+	if strings.HasPrefix(didID, numAlgo2Prefix+".") {
+		doc, err := resolveDIDNumAlgo2(didID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve did:peer numalgo 2 DID failed: %w", err)
+		}
+
+		return doc, nil
+	}
+
 	// get the document from the store
 	doc, err := v.Get(didID)
 	if err != nil {
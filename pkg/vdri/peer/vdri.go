@@ -9,6 +9,7 @@ package peer
 import (
 	"fmt"
 
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	"github.com/hyperledger/aries-framework-go/pkg/storage"
 )
 
@@ -36,3 +37,8 @@ func New(s storage.Provider) (*VDRI, error) {
 func (v *VDRI) Accept(method string) bool {
 	return method == DIDMethod
 }
+
+// Methods describes the did:peer method, DIDs are resolved and created.
+func (v *VDRI) Methods() []vdriapi.MethodCapabilities {
+	return []vdriapi.MethodCapabilities{{Method: DIDMethod, Resolve: true, Create: true}}
+}
@@ -9,7 +9,10 @@ package vdri
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
@@ -31,6 +34,9 @@ type provider interface {
 // Registry vdri registry.
 type Registry struct {
 	vdri               []vdriapi.VDRI
+	vdriLock           sync.RWMutex
+	registered         map[string]struct{}
+	fallback           vdriapi.VDRI
 	kms                kms.KeyManager
 	defServiceEndpoint string
 	defServiceType     string
@@ -38,7 +44,7 @@ type Registry struct {
 
 // New return new instance of vdri.
 func New(ctx provider, opts ...Option) *Registry {
-	baseVDRI := &Registry{kms: ctx.KMS()}
+	baseVDRI := &Registry{kms: ctx.KMS(), registered: map[string]struct{}{}}
 
 	// Apply options
 	for _, opt := range opts {
@@ -50,6 +56,17 @@ func New(ctx provider, opts ...Option) *Registry {
 
 // Resolve did document.
 func (r *Registry) Resolve(did string, opts ...vdriapi.ResolveOpts) (*diddoc.Doc, error) {
+	result, err := r.ResolveWithMetadata(did, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Doc, nil
+}
+
+// ResolveWithMetadata resolves did document along with its resolution metadata, method specific metadata
+// is populated when the did method vdri supports it.
+func (r *Registry) ResolveWithMetadata(did string, opts ...vdriapi.ResolveOpts) (*vdriapi.ResolveResult, error) {
 	resolveOpts := &vdriapi.ResolveDIDOpts{}
 	// Apply options
 	for _, opt := range opts {
@@ -64,11 +81,15 @@ func (r *Registry) Resolve(did string, opts ...vdriapi.ResolveOpts) (*diddoc.Doc
 	// resolve did method
 	method, err := r.resolveVDRI(didMethod)
 	if err != nil {
-		return nil, err
+		if r.fallback == nil {
+			return nil, err
+		}
+
+		method = r.fallback
 	}
 
 	// Obtain the DID Document
-	didDoc, err := method.Read(did, opts...)
+	result, err := read(method, did, opts...)
 	if err != nil {
 		if errors.Is(err, vdriapi.ErrNotFound) {
 			return nil, err
@@ -82,7 +103,29 @@ func (r *Registry) Resolve(did string, opts ...vdriapi.ResolveOpts) (*diddoc.Doc
 		return nil, errors.New("result type 'resolution-result' not supported")
 	}
 
-	return didDoc, nil
+	if result.Metadata.ContentType == "" {
+		result.Metadata.ContentType = vdriapi.DIDLDJSONContentType
+	}
+
+	if result.Metadata.Retrieved.IsZero() {
+		result.Metadata.Retrieved = time.Now()
+	}
+
+	return result, nil
+}
+
+// read reads did document along with its metadata if the vdri supports it, the document only otherwise.
+func read(method vdriapi.VDRI, did string, opts ...vdriapi.ResolveOpts) (*vdriapi.ResolveResult, error) {
+	if reader, ok := method.(vdriapi.MetadataReader); ok {
+		return reader.ReadWithMetadata(did, opts...)
+	}
+
+	didDoc, err := method.Read(did, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vdriapi.ResolveResult{Doc: didDoc}, nil
 }
 
 // Create a new DID Document and store it in this registry.
@@ -148,18 +191,90 @@ func (r *Registry) Store(doc *diddoc.Doc) error {
 	return method.Store(doc, nil)
 }
 
+// RegisterVDRI registers given vdri for given did method at runtime,
+// returns error if the did method is already supported by a registered vdri.
+func (r *Registry) RegisterVDRI(method string, v vdriapi.VDRI) error {
+	if method == "" {
+		return errors.New("did method is mandatory")
+	}
+
+	r.vdriLock.Lock()
+	defer r.vdriLock.Unlock()
+
+	for _, registered := range r.vdri {
+		if registered.Accept(method) {
+			return fmt.Errorf("did method %s already registered", method)
+		}
+	}
+
+	r.vdri = append(r.vdri, v)
+	r.registered[method] = struct{}{}
+
+	return nil
+}
+
+// Methods returns the DID methods supported by the vdris along with their capabilities, sorted by method. The vdris
+// describing their methods report their own capabilities, the undescribed methods registered at runtime are
+// reported as resolve only. The methods of the fallback vdri aren't known hence not reported.
+func (r *Registry) Methods() []vdriapi.MethodCapabilities {
+	r.vdriLock.RLock()
+	defer r.vdriLock.RUnlock()
+
+	methods := map[string]vdriapi.MethodCapabilities{}
+
+	for _, v := range r.vdri {
+		describer, ok := v.(vdriapi.MethodDescriber)
+		if !ok {
+			continue
+		}
+
+		for _, capabilities := range describer.Methods() {
+			if _, exists := methods[capabilities.Method]; !exists {
+				methods[capabilities.Method] = capabilities
+			}
+		}
+	}
+
+	for method := range r.registered {
+		if _, exists := methods[method]; !exists {
+			methods[method] = vdriapi.MethodCapabilities{Method: method, Resolve: true}
+		}
+	}
+
+	result := make([]vdriapi.MethodCapabilities, 0, len(methods))
+	for _, capabilities := range methods {
+		result = append(result, capabilities)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Method < result[j].Method })
+
+	return result
+}
+
 // Close frees resources being maintained by vdri.
 func (r *Registry) Close() error {
+	r.vdriLock.RLock()
+	defer r.vdriLock.RUnlock()
+
 	for _, v := range r.vdri {
 		if err := v.Close(); err != nil {
 			return fmt.Errorf("close vdri: %w", err)
 		}
 	}
 
+	if r.fallback != nil {
+		if err := r.fallback.Close(); err != nil {
+			return fmt.Errorf("close fallback vdri: %w", err)
+		}
+	}
+
 	return nil
 }
 
 func (r *Registry) resolveVDRI(method string) (vdriapi.VDRI, error) {
+	r.vdriLock.RLock()
+	defer r.vdriLock.RUnlock()
+
 	for _, v := range r.vdri {
 		if v.Accept(method) {
 			return v, nil
@@ -176,6 +291,14 @@ func WithVDRI(method vdriapi.VDRI) Option {
 	}
 }
 
+// WithFallbackVDRI sets the vdri resolving the DIDs of the methods not supported by any other vdri
+// (eg: a universal resolver vdri), the fallback vdri is only used for resolution.
+func WithFallbackVDRI(method vdriapi.VDRI) Option {
+	return func(opts *Registry) {
+		opts.fallback = method
+	}
+}
+
 // WithDefaultServiceType is default service type for this creator.
 func WithDefaultServiceType(serviceType string) Option {
 	return func(opts *Registry) {
@@ -7,8 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package vdri
 
 import (
+	"crypto/ed25519"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -16,7 +19,9 @@ import (
 	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdri "github.com/hyperledger/aries-framework-go/pkg/mock/vdri"
+	"github.com/hyperledger/aries-framework-go/pkg/vdri/peer"
 )
 
 func TestRegistry_New(t *testing.T) {
@@ -35,6 +40,107 @@ func TestRegistry_New(t *testing.T) {
 	})
 }
 
+func TestRegistry_FallbackVDRI(t *testing.T) {
+	fallback := &mockvdri.MockVDRI{
+		ReadFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+			return &did.Doc{ID: didID}, nil
+		},
+	}
+
+	t.Run("test fallback resolves methods not handled locally", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithFallbackVDRI(fallback),
+			WithVDRI(&mockvdri.MockVDRI{AcceptValue: false}))
+
+		doc, err := registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", doc.ID)
+	})
+
+	t.Run("test local vdri takes precedence over fallback", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithFallbackVDRI(fallback),
+			WithVDRI(&mockvdri.MockVDRI{
+				AcceptValue: true, ReadFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+					return &did.Doc{ID: "local"}, nil
+				}}))
+
+		doc, err := registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "local", doc.ID)
+	})
+
+	t.Run("test fallback isn't used for creation", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}}, WithFallbackVDRI(fallback))
+
+		_, err := registry.Create("example")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did method example not supported for vdri")
+	})
+
+	t.Run("test fallback close error", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{},
+			WithFallbackVDRI(&mockvdri.MockVDRI{CloseErr: fmt.Errorf("close error")}))
+
+		err := registry.Close()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "close fallback vdri")
+	})
+}
+
+func TestRegistry_RegisterVDRI(t *testing.T) {
+	t.Run("test register and resolve", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{})
+
+		err := registry.RegisterVDRI("example", &mockvdri.MockVDRI{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+				return &did.Doc{ID: didID}, nil
+			}})
+		require.NoError(t, err)
+
+		doc, err := registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", doc.ID)
+	})
+
+	t.Run("test duplicate method", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{AcceptValue: true}))
+
+		err := registry.RegisterVDRI("example", &mockvdri.MockVDRI{AcceptValue: true})
+		require.EqualError(t, err, "did method example already registered")
+	})
+
+	t.Run("test empty method", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{})
+
+		err := registry.RegisterVDRI("", &mockvdri.MockVDRI{AcceptValue: true})
+		require.EqualError(t, err, "did method is mandatory")
+	})
+}
+
+func TestRegistry_Methods(t *testing.T) {
+	registry := New(&mockprovider.Provider{},
+		WithVDRI(&mockvdri.MockVDRI{MethodsValue: []vdriapi.MethodCapabilities{
+			{Method: "peer", Resolve: true, Create: true},
+			{Method: "example", Resolve: true, Create: true, Update: true, Deactivate: true},
+		}}),
+		WithVDRI(&mockvdri.MockVDRI{MethodsValue: []vdriapi.MethodCapabilities{{Method: "peer", Resolve: true}}}),
+		WithVDRI(&mockvdri.MockVDRI{}),
+		WithFallbackVDRI(&mockvdri.MockVDRI{MethodsValue: []vdriapi.MethodCapabilities{{Method: "web"}}}))
+
+	require.NoError(t, registry.RegisterVDRI("sov", &mockvdri.MockVDRI{}))
+	require.NoError(t, registry.RegisterVDRI("other", &mockvdri.MockVDRI{MethodsValue: []vdriapi.MethodCapabilities{
+		{Method: "other", Resolve: true, Update: true},
+	}}))
+
+	require.Equal(t, []vdriapi.MethodCapabilities{
+		{Method: "example", Resolve: true, Create: true, Update: true, Deactivate: true},
+		{Method: "other", Resolve: true, Update: true},
+		{Method: "peer", Resolve: true, Create: true},
+		{Method: "sov", Resolve: true},
+	}, registry.Methods())
+
+	require.Empty(t, New(&mockprovider.Provider{}).Methods())
+}
+
 func TestRegistry_Close(t *testing.T) {
 	t.Run("test success", func(t *testing.T) {
 		registry := New(&mockprovider.Provider{})
@@ -118,6 +224,61 @@ func TestRegistry_Resolve(t *testing.T) {
 	})
 }
 
+func TestRegistry_ResolveWithMetadata(t *testing.T) {
+	t.Run("test deactivated flag propagated", func(t *testing.T) {
+		retrieved := time.Now().Add(-time.Minute)
+
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{
+			AcceptValue: true,
+			ReadWithMetadataFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*vdriapi.ResolveResult, error) {
+				return &vdriapi.ResolveResult{
+					Doc: &did.Doc{ID: didID},
+					Metadata: vdriapi.ResolutionMetadata{
+						Retrieved:      retrieved,
+						Deactivated:    true,
+						MethodMetadata: map[string]interface{}{"published": true},
+					},
+				}, nil
+			}}))
+		result, err := registry.ResolveWithMetadata("did:id:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:id:123", result.Doc.ID)
+		require.True(t, result.Metadata.Deactivated)
+		require.Equal(t, vdriapi.DIDLDJSONContentType, result.Metadata.ContentType)
+		require.Equal(t, retrieved, result.Metadata.Retrieved)
+		require.Equal(t, true, result.Metadata.MethodMetadata["published"])
+
+		doc, err := registry.Resolve("did:id:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:id:123", doc.ID)
+	})
+
+	t.Run("test default metadata for vdri without metadata", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+				return &did.Doc{ID: didID}, nil
+			}}))
+		result, err := registry.ResolveWithMetadata("did:id:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:id:123", result.Doc.ID)
+		require.False(t, result.Metadata.Deactivated)
+		require.Equal(t, vdriapi.DIDLDJSONContentType, result.Metadata.ContentType)
+		require.False(t, result.Metadata.Retrieved.IsZero())
+	})
+
+	t.Run("test error from read with metadata", func(t *testing.T) {
+		registry := New(&mockprovider.Provider{}, WithVDRI(&mockvdri.MockVDRI{
+			AcceptValue: true,
+			ReadWithMetadataFunc: func(didID string, opts ...vdriapi.ResolveOpts) (*vdriapi.ResolveResult, error) {
+				return nil, fmt.Errorf("read error")
+			}}))
+		result, err := registry.ResolveWithMetadata("did:id:123")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read error")
+		require.Nil(t, result)
+	})
+}
+
 func TestRegistry_Store(t *testing.T) {
 	t.Run("test invalid did input", func(t *testing.T) {
 		registry := New(&mockprovider.Provider{})
@@ -225,3 +386,33 @@ func TestRegistry_Create(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestRegistry_PeerNumAlgo2(t *testing.T) {
+	pubKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	peerVDRI, err := peer.New(mockstorage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	registry := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{
+		CrAndExportPubKeyID:    "123",
+		CrAndExportPubKeyValue: pubKey,
+	}}, WithVDRI(peerVDRI), WithDefaultServiceType(vdriapi.DIDCommServiceType),
+		WithDefaultServiceEndpoint("https://example.com/endpoint"))
+
+	doc, err := registry.Create(peer.DIDMethod, vdriapi.WithNumAlgo(2))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(doc.ID, "did:peer:2."))
+
+	same, err := registry.Create(peer.DIDMethod, vdriapi.WithNumAlgo(2))
+	require.NoError(t, err)
+	require.Equal(t, doc, same)
+
+	// resolved from the DID alone
+	emptyPeerVDRI, err := peer.New(mockstorage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	resolved, err := New(&mockprovider.Provider{}, WithVDRI(emptyPeerVDRI)).Resolve(doc.ID)
+	require.NoError(t, err)
+	require.Equal(t, doc, resolved)
+}
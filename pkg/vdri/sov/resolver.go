@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sov
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+const (
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	abbreviatedVerkeyPrefix    = "~"
+)
+
+// Read resolves the did:sov DID against the ledger of its namespace.
+func (v *VDRI) Read(didID string, _ ...vdriapi.ResolveOpts) (*did.Doc, error) {
+	parsed, err := did.Parse(didID)
+	if err != nil {
+		return nil, fmt.Errorf("sov vdri Read: failed to parse DID: %w", err)
+	}
+
+	namespace, id := splitNamespace(parsed.MethodSpecificID)
+
+	ledger, ok := v.ledgers[namespace]
+	if !ok {
+		return nil, fmt.Errorf("sov vdri Read: unknown ledger namespace '%s'", namespace)
+	}
+
+	nym, err := ledger.GetNym(id)
+	if err != nil {
+		return nil, fmt.Errorf("sov vdri Read: get nym of %s : %w", id, err)
+	}
+
+	verkey, err := fullVerkey(id, nym.Verkey)
+	if err != nil {
+		return nil, fmt.Errorf("sov vdri Read: %w", err)
+	}
+
+	endpoint, err := ledger.GetEndpoint(id)
+	if err != nil {
+		return nil, fmt.Errorf("sov vdri Read: get endpoint of %s : %w", id, err)
+	}
+
+	return createDoc(didID, verkey, endpoint), nil
+}
+
+// splitNamespace splits the method specific ID into the ledger namespace and the unqualified DID.
+func splitNamespace(methodSpecificID string) (string, string) {
+	i := strings.LastIndex(methodSpecificID, ":")
+	if i < 0 {
+		return "", methodSpecificID
+	}
+
+	return methodSpecificID[:i], methodSpecificID[i+1:]
+}
+
+// fullVerkey expands an abbreviated verkey, the abbreviated verkeys omit the DID bytes prefix of the full verkey.
+func fullVerkey(id, verkey string) ([]byte, error) {
+	if !strings.HasPrefix(verkey, abbreviatedVerkeyPrefix) {
+		key := base58.Decode(verkey)
+		if len(key) == 0 {
+			return nil, fmt.Errorf("invalid verkey of %s", id)
+		}
+
+		return key, nil
+	}
+
+	didBytes := base58.Decode(id)
+	suffix := base58.Decode(strings.TrimPrefix(verkey, abbreviatedVerkeyPrefix))
+
+	if len(didBytes) == 0 || len(suffix) == 0 {
+		return nil, fmt.Errorf("invalid abbreviated verkey of %s", id)
+	}
+
+	return append(didBytes, suffix...), nil
+}
+
+func createDoc(didID string, verkey []byte, endpoint string) *did.Doc {
+	publicKey := did.NewPublicKeyFromBytes(didID+"#key-1", ed25519VerificationKey2018, didID, verkey)
+
+	doc := &did.Doc{
+		Context:   []string{did.Context},
+		ID:        didID,
+		PublicKey: []did.PublicKey{*publicKey},
+		Authentication: []did.VerificationMethod{*did.NewReferencedVerificationMethod(publicKey,
+			did.Authentication, false)},
+	}
+
+	if endpoint != "" {
+		doc.Service = []did.Service{{
+			ID:              didID + "#did-communication",
+			Type:            vdriapi.DIDCommServiceType,
+			RecipientKeys:   []string{base58.Encode(verkey)},
+			ServiceEndpoint: endpoint,
+		}}
+	}
+
+	return doc
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sov
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+const (
+	sampleID      = "WRfXPg8dantKVubE3HX8pw"
+	sampleVerkey  = "GJ1SzoWzavQYfNL9XkaJdrQejfztN4XqdsiV4ct3LXKL"
+	stagingVerkey = "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+)
+
+func TestVDRI_Read(t *testing.T) {
+	prod := &mockLedger{
+		nyms:      map[string]string{sampleID: sampleVerkey},
+		endpoints: map[string]string{sampleID: "https://prod.agent.example.com"},
+	}
+	staging := &mockLedger{nyms: map[string]string{sampleID: stagingVerkey}}
+
+	v, err := New(WithDefaultLedger(prod), WithLedger("staging", staging))
+	require.NoError(t, err)
+
+	t.Run("test read - default ledger", func(t *testing.T) {
+		doc, err := v.Read("did:sov:" + sampleID)
+		require.NoError(t, err)
+		require.Equal(t, "did:sov:"+sampleID, doc.ID)
+		require.Len(t, doc.PublicKey, 1)
+		require.Equal(t, sampleVerkey, base58.Encode(doc.PublicKey[0].Value))
+		require.Len(t, doc.Authentication, 1)
+
+		require.Len(t, doc.Service, 1)
+		require.Equal(t, vdriapi.DIDCommServiceType, doc.Service[0].Type)
+		require.Equal(t, "https://prod.agent.example.com", doc.Service[0].ServiceEndpoint)
+		require.Equal(t, []string{sampleVerkey}, doc.Service[0].RecipientKeys)
+	})
+
+	t.Run("test read - namespaced ledger", func(t *testing.T) {
+		doc, err := v.Read("did:sov:staging:" + sampleID)
+		require.NoError(t, err)
+		require.Equal(t, "did:sov:staging:"+sampleID, doc.ID)
+		require.Equal(t, stagingVerkey, base58.Encode(doc.PublicKey[0].Value))
+		require.Empty(t, doc.Service)
+	})
+
+	t.Run("test read - unknown namespace", func(t *testing.T) {
+		_, err := v.Read("did:sov:builder:" + sampleID)
+		require.EqualError(t, err, "sov vdri Read: unknown ledger namespace 'builder'")
+	})
+
+	t.Run("test read - abbreviated verkey", func(t *testing.T) {
+		suffix := []byte("0123456789abcdef")
+		abbreviated, err := New(WithDefaultLedger(&mockLedger{
+			nyms: map[string]string{sampleID: "~" + base58.Encode(suffix)},
+		}))
+		require.NoError(t, err)
+
+		doc, err := abbreviated.Read("did:sov:" + sampleID)
+		require.NoError(t, err)
+		require.Equal(t, append(base58.Decode(sampleID), suffix...), doc.PublicKey[0].Value)
+	})
+
+	t.Run("test read - errors", func(t *testing.T) {
+		_, err := v.Read("did:sov")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse DID")
+
+		_, err = v.Read("did:sov:staging:unknown")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+
+		invalid, err := New(WithDefaultLedger(&mockLedger{nyms: map[string]string{sampleID: "~", "other": ""}}))
+		require.NoError(t, err)
+
+		_, err = invalid.Read("did:sov:" + sampleID)
+		require.EqualError(t, err, "sov vdri Read: invalid abbreviated verkey of "+sampleID)
+
+		_, err = invalid.Read("did:sov:other")
+		require.EqualError(t, err, "sov vdri Read: invalid verkey of other")
+
+		failing, err := New(WithDefaultLedger(&mockLedger{
+			nyms:        map[string]string{sampleID: sampleVerkey},
+			endpointErr: errors.New("attrib error"),
+		}))
+		require.NoError(t, err)
+
+		_, err = failing.Read("did:sov:" + sampleID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "attrib error")
+	})
+}
@@ -0,0 +1,136 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sov
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+var logger = log.New("aries-framework/vdri/sov")
+
+const didMethod = "sov"
+
+// LedgerClient reads the DID records of an Indy ledger pool.
+type LedgerClient interface {
+	// GetNym returns the NYM record of the DID (unqualified, eg. WRfXPg8dantKVubE3HX8pw), vdriapi.ErrNotFound is
+	// returned if the DID is not registered on the ledger.
+	GetNym(did string) (*Nym, error)
+	// GetEndpoint returns the endpoint attribute of the DID, empty if not set.
+	GetEndpoint(did string) (string, error)
+}
+
+// Nym is the NYM record of a DID on an Indy ledger.
+type Nym struct {
+	DID string
+	// Verkey is the base58 verification key of the DID, abbreviated (~ prefixed) or full.
+	Verkey string
+}
+
+// LedgerConnector connects to the pool of an Indy ledger from its genesis transactions file.
+type LedgerConnector func(genesisFile string) (LedgerClient, error)
+
+// VDRI resolves did:sov DIDs against several Indy ledgers. The ledger of a DID is selected by the namespace of the
+// DID (eg. did:sov:staging:WRfXPg8dantKVubE3HX8pw), the DIDs without namespace are resolved against the default
+// ledger.
+type VDRI struct {
+	ledgers map[string]LedgerClient
+	genesis map[string]string
+	connect LedgerConnector
+}
+
+// Option configures the did:sov vdri.
+type Option func(opts *VDRI)
+
+// WithLedger option is for resolving the DIDs of the namespace against the ledger client.
+func WithLedger(namespace string, client LedgerClient) Option {
+	return func(opts *VDRI) {
+		opts.ledgers[namespace] = client
+	}
+}
+
+// WithDefaultLedger option is for resolving the DIDs without namespace against the ledger client.
+func WithDefaultLedger(client LedgerClient) Option {
+	return WithLedger("", client)
+}
+
+// WithGenesisLedgers option is for connecting to the ledgers from their genesis transactions files, the genesis
+// map holds the genesis file of the ledger of each namespace (the empty namespace for the default ledger).
+func WithGenesisLedgers(connect LedgerConnector, genesis map[string]string) Option {
+	return func(opts *VDRI) {
+		opts.connect = connect
+
+		for namespace, genesisFile := range genesis {
+			opts.genesis[namespace] = genesisFile
+		}
+	}
+}
+
+// New returns new did:sov vdri resolving DIDs against the configured ledgers.
+func New(opts ...Option) (*VDRI, error) {
+	v := &VDRI{
+		ledgers: map[string]LedgerClient{},
+		genesis: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if len(v.genesis) > 0 && v.connect == nil {
+		return nil, errors.New("ledger connector is required to connect from genesis files")
+	}
+
+	for namespace, genesisFile := range v.genesis {
+		if _, ok := v.ledgers[namespace]; ok {
+			return nil, fmt.Errorf("ledger of namespace '%s' configured twice", namespace)
+		}
+
+		client, err := v.connect(genesisFile)
+		if err != nil {
+			return nil, fmt.Errorf("connect to ledger of namespace '%s' : %w", namespace, err)
+		}
+
+		v.ledgers[namespace] = client
+	}
+
+	if len(v.ledgers) == 0 {
+		return nil, errors.New("at least one ledger is required")
+	}
+
+	return v, nil
+}
+
+// Accept accepts did:sov method.
+func (v *VDRI) Accept(method string) bool {
+	return method == didMethod
+}
+
+// Methods describes the did:sov method, DIDs are resolved only.
+func (v *VDRI) Methods() []vdriapi.MethodCapabilities {
+	return []vdriapi.MethodCapabilities{{Method: didMethod, Resolve: true}}
+}
+
+// Store did doc.
+func (v *VDRI) Store(doc *did.Doc, by *[]vdriapi.ModifiedBy) error {
+	logger.Warnf("store not supported in did:sov vdri")
+	return nil
+}
+
+// Build did doc.
+func (v *VDRI) Build(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+	return nil, errors.New("build not supported in did:sov vdri")
+}
+
+// Close frees resources being maintained by vdri.
+func (v *VDRI) Close() error {
+	return nil
+}
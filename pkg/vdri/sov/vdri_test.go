@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sov
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+// mockLedger is a ledger client holding the NYM records and endpoints of a pool.
+type mockLedger struct {
+	nyms        map[string]string
+	endpoints   map[string]string
+	endpointErr error
+}
+
+func (l *mockLedger) GetNym(did string) (*Nym, error) {
+	verkey, ok := l.nyms[did]
+	if !ok {
+		return nil, vdriapi.ErrNotFound
+	}
+
+	return &Nym{DID: did, Verkey: verkey}, nil
+}
+
+func (l *mockLedger) GetEndpoint(did string) (string, error) {
+	return l.endpoints[did], l.endpointErr
+}
+
+func TestNew(t *testing.T) {
+	t.Run("test new - ledgers", func(t *testing.T) {
+		v, err := New(WithDefaultLedger(&mockLedger{}), WithLedger("staging", &mockLedger{}))
+		require.NoError(t, err)
+		require.Len(t, v.ledgers, 2)
+		require.True(t, v.Accept("sov"))
+		require.False(t, v.Accept("key"))
+		require.Equal(t, []vdriapi.MethodCapabilities{{Method: "sov", Resolve: true}}, v.Methods())
+
+		require.NoError(t, v.Store(nil, nil))
+		require.NoError(t, v.Close())
+
+		_, err = v.Build(nil)
+		require.EqualError(t, err, "build not supported in did:sov vdri")
+	})
+
+	t.Run("test new - genesis ledgers", func(t *testing.T) {
+		connected := map[string]LedgerClient{}
+
+		v, err := New(WithGenesisLedgers(func(genesisFile string) (LedgerClient, error) {
+			connected[genesisFile] = &mockLedger{}
+
+			return connected[genesisFile], nil
+		}, map[string]string{"": "prod.txn", "staging": "staging.txn"}))
+		require.NoError(t, err)
+		require.Equal(t, connected["prod.txn"], v.ledgers[""])
+		require.Equal(t, connected["staging.txn"], v.ledgers["staging"])
+	})
+
+	t.Run("test new - errors", func(t *testing.T) {
+		_, err := New()
+		require.EqualError(t, err, "at least one ledger is required")
+
+		_, err = New(WithGenesisLedgers(nil, map[string]string{"": "prod.txn"}))
+		require.EqualError(t, err, "ledger connector is required to connect from genesis files")
+
+		connect := func(genesisFile string) (LedgerClient, error) {
+			return nil, errors.New("pool unreachable")
+		}
+
+		_, err = New(WithGenesisLedgers(connect, map[string]string{"staging": "staging.txn"}))
+		require.EqualError(t, err, "connect to ledger of namespace 'staging' : pool unreachable")
+
+		_, err = New(WithLedger("staging", &mockLedger{}),
+			WithGenesisLedgers(connect, map[string]string{"staging": "staging.txn"}))
+		require.EqualError(t, err, "ledger of namespace 'staging' configured twice")
+	})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package universal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+// resolutionResult is the DID resolution result returned by the Universal Resolver,
+// older releases report metadata as methodMetadata and newer ones as didDocumentMetadata.
+type resolutionResult struct {
+	DIDDocument         json.RawMessage        `json:"didDocument"`
+	DIDDocumentMetadata map[string]interface{} `json:"didDocumentMetadata"`
+	MethodMetadata      map[string]interface{} `json:"methodMetadata"`
+}
+
+// Read resolves the DID through the Universal Resolver.
+func (v *VDRI) Read(didID string, opts ...vdriapi.ResolveOpts) (*did.Doc, error) {
+	result, err := v.ReadWithMetadata(didID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Doc, nil
+}
+
+// ReadWithMetadata resolves the DID through the Universal Resolver, along with the DID document metadata.
+func (v *VDRI) ReadWithMetadata(didID string, _ ...vdriapi.ResolveOpts) (*vdriapi.ResolveResult, error) {
+	data, contentType, err := v.resolve(didID)
+	if err != nil {
+		return nil, err
+	}
+
+	var r resolutionResult
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("unmarshal universal resolver response: %w", err)
+	}
+
+	if len(r.DIDDocument) == 0 || string(r.DIDDocument) == "null" {
+		return nil, vdriapi.ErrNotFound
+	}
+
+	doc, err := did.ParseDocument(r.DIDDocument)
+	if err != nil {
+		return nil, fmt.Errorf("parse did document from universal resolver: %w", err)
+	}
+
+	methodMetadata := r.DIDDocumentMetadata
+	if methodMetadata == nil {
+		methodMetadata = r.MethodMetadata
+	}
+
+	deactivated, _ := methodMetadata["deactivated"].(bool)
+
+	return &vdriapi.ResolveResult{
+		Doc: doc,
+		Metadata: vdriapi.ResolutionMetadata{
+			ContentType:    contentType,
+			Retrieved:      time.Now(),
+			Deactivated:    deactivated,
+			MethodMetadata: methodMetadata,
+		},
+	}, nil
+}
+
+// resolve issues GET {baseURL}/1.0/identifiers/{did} and returns the response body along with its content type.
+// The DID is escaped as a single path segment.
+func (v *VDRI) resolve(didID string) ([]byte, string, error) {
+	resp, err := v.client.Get(v.baseURL + identifiersPath + url.PathEscape(didID))
+	if err != nil {
+		return nil, "", fmt.Errorf("universal resolver HTTP Get request failed: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading response body failed: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, resp.Header.Get("Content-type"), nil
+	case http.StatusNotFound:
+		return nil, "", vdriapi.ErrNotFound
+	default:
+		return nil, "", fmt.Errorf("unsupported response from universal resolver [%v] body [%s]", resp.StatusCode, body)
+	}
+}
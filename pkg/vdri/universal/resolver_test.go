@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package universal
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+//nolint:lll
+const doc = `{
+  "@context": ["https://w3id.org/did/v0.11"],
+  "id": "did:example:21tDAKCERh95uGgKbJNHYp",
+  "publicKey": [
+    {
+      "id": "did:example:21tDAKCERh95uGgKbJNHYp#keys-1",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:example:21tDAKCERh95uGgKbJNHYp",
+      "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+    }
+  ]
+}`
+
+const resolutionResultData = `{
+  "@context": "https://w3id.org/did-resolution/v1",
+  "didDocument": ` + doc + `,
+  "didResolutionMetadata": {
+    "contentType": "application/did+ld+json",
+    "duration": 92
+  },
+  "didDocumentMetadata": {
+    "deactivated": true
+  }
+}`
+
+func TestVDRI_Read(t *testing.T) {
+	t.Run("test resolve did", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			require.Equal(t, http.MethodGet, req.Method)
+			require.Equal(t, "/1.0/identifiers/did:example:21tDAKCERh95uGgKbJNHYp", req.URL.Path)
+			res.Header().Add("Content-type", `application/ld+json;profile="https://w3id.org/did-resolution"`)
+			_, err := res.Write([]byte(resolutionResultData))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL)
+		require.NoError(t, err)
+
+		didDoc, err := v.Read("did:example:21tDAKCERh95uGgKbJNHYp")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:21tDAKCERh95uGgKbJNHYp", didDoc.ID)
+		require.Len(t, didDoc.PublicKey, 1)
+
+		result, err := v.ReadWithMetadata("did:example:21tDAKCERh95uGgKbJNHYp")
+		require.NoError(t, err)
+		require.True(t, result.Metadata.Deactivated)
+		require.Contains(t, result.Metadata.ContentType, "did-resolution")
+	})
+
+	t.Run("test resolve did with path, query and fragment characters", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			require.Equal(t, "/1.0/identifiers/did:example:123%2Fabc%3Fq=1%23frag", req.RequestURI)
+			require.Equal(t, "/1.0/identifiers/did:example:123/abc?q=1#frag", req.URL.Path)
+			require.Empty(t, req.URL.RawQuery)
+			res.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL)
+		require.NoError(t, err)
+
+		_, err = v.Read("did:example:123/abc?q=1#frag")
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+	})
+
+	t.Run("test did not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL)
+		require.NoError(t, err)
+
+		didDoc, err := v.Read("did:example:21tDAKCERh95uGgKbJNHYp")
+		require.True(t, errors.Is(err, vdriapi.ErrNotFound))
+		require.Nil(t, didDoc)
+	})
+
+	t.Run("test resolver error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.WriteHeader(http.StatusInternalServerError)
+			_, err := res.Write([]byte("driver failure"))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		v, err := New(server.URL)
+		require.NoError(t, err)
+
+		didDoc, err := v.Read("did:example:21tDAKCERh95uGgKbJNHYp")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported response from universal resolver [500] body [driver failure]")
+		require.Nil(t, didDoc)
+	})
+
+	t.Run("test invalid response", func(t *testing.T) {
+		for _, body := range []string{"{", `{"didDocument":null}`, `{"didDocument":{"id":1}}`} {
+			server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				_, err := res.Write([]byte(body))
+				require.NoError(t, err)
+			}))
+
+			v, err := New(server.URL)
+			require.NoError(t, err)
+
+			didDoc, err := v.Read("did:example:21tDAKCERh95uGgKbJNHYp")
+			require.Error(t, err)
+			require.Nil(t, didDoc)
+
+			server.Close()
+		}
+	})
+
+	t.Run("test HTTP get failed", func(t *testing.T) {
+		v, err := New("http://localhost:1")
+		require.NoError(t, err)
+
+		didDoc, err := v.Read("did:example:21tDAKCERh95uGgKbJNHYp")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "universal resolver HTTP Get request failed")
+		require.Nil(t, didDoc)
+	})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package universal
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdriapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdri"
+)
+
+var logger = log.New("aries-framework/vdri/universal")
+
+const identifiersPath = "/1.0/identifiers/"
+
+// VDRI resolves DIDs of any method through a Universal Resolver instance
+// (https://github.com/decentralized-identity/universal-resolver), it is typically registered
+// as the fallback VDRI of the registry for the methods not handled locally.
+type VDRI struct {
+	baseURL string
+	client  *http.Client
+	timeout time.Duration
+}
+
+// Option configures the universal resolver vdri.
+type Option func(opts *VDRI)
+
+// WithHTTPClient option is for setting the HTTP client used to query the Universal Resolver.
+func WithHTTPClient(client *http.Client) Option {
+	return func(opts *VDRI) {
+		opts.client = client
+	}
+}
+
+// WithTimeout option is for setting the timeout of the Universal Resolver HTTP requests.
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *VDRI) {
+		opts.timeout = timeout
+	}
+}
+
+// New returns new universal resolver vdri querying the Universal Resolver at baseURL (eg: https://dev.uniresolver.io).
+func New(baseURL string, opts ...Option) (*VDRI, error) {
+	v := &VDRI{client: &http.Client{}}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.timeout > 0 {
+		client := *v.client
+		client.Timeout = v.timeout
+		v.client = &client
+	}
+
+	_, err := url.ParseRequestURI(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("base URL invalid: %w", err)
+	}
+
+	v.baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return v, nil
+}
+
+// Accept did method - the Universal Resolver attempts to resolve any method.
+func (v *VDRI) Accept(method string) bool {
+	return true
+}
+
+// Store did doc.
+func (v *VDRI) Store(doc *did.Doc, by *[]vdriapi.ModifiedBy) error {
+	logger.Warnf("store not supported in universal resolver vdri")
+	return nil
+}
+
+// Build did doc.
+func (v *VDRI) Build(pubKey *vdriapi.PubKey, opts ...vdriapi.DocOpts) (*did.Doc, error) {
+	return nil, fmt.Errorf("build not supported in universal resolver vdri")
+}
+
+// Close frees resources being maintained by vdri.
+func (v *VDRI) Close() error {
+	return nil
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
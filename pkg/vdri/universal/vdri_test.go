@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package universal
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new success", func(t *testing.T) {
+		v, err := New("https://uniresolver.io/")
+		require.NoError(t, err)
+		require.Equal(t, "https://uniresolver.io", v.baseURL)
+		require.True(t, v.Accept("any"))
+	})
+
+	t.Run("test new with options", func(t *testing.T) {
+		client := &http.Client{}
+
+		v, err := New("https://uniresolver.io", WithTimeout(time.Second), WithHTTPClient(client))
+		require.NoError(t, err)
+		require.Equal(t, time.Second, v.client.Timeout)
+		// given client isn't modified
+		require.Zero(t, client.Timeout)
+	})
+
+	t.Run("test new with invalid url", func(t *testing.T) {
+		v, err := New("uniresolver")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "base URL invalid")
+		require.Nil(t, v)
+	})
+}
+
+func TestVDRI_Unsupported(t *testing.T) {
+	v, err := New("https://uniresolver.io")
+	require.NoError(t, err)
+
+	require.NoError(t, v.Store(nil, nil))
+
+	doc, err := v.Build(nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "build not supported")
+	require.Nil(t, doc)
+
+	require.NoError(t, v.Close())
+}